@@ -89,7 +89,11 @@ func main() {
 	}
 	defer dbConn.Close()
 
-	skipgo, err := skipgo.NewSkipGoClient("https://api.skip.build")
+	skipGoURL := cfg.SkipGo.URL
+	if skipGoURL == "" {
+		skipGoURL = config.DefaultSkipGoURL
+	}
+	skipgo, err := skipgo.NewSkipGoClient(skipGoURL)
 	if err != nil {
 		lmt.Logger(ctx).Fatal("Unable to create Skip Go client", zap.Error(err))
 	}
@@ -100,13 +104,21 @@ func main() {
 	cachedCoinGeckoClient := coingecko.NewCachedPriceClient(coingeckoClient, 15*time.Minute)
 	txPriceOracle := oracle.NewOracle(cachedCoinGeckoClient)
 
-	hype, err := hyperlane.NewMultiClientFromConfig(ctx, evmManager, keyStore, txPriceOracle, evmTxExecutor)
+	hype, err := hyperlane.NewMultiClientFromConfig(ctx, evmManager, keyStore, txPriceOracle, evmTxExecutor, cosmosTxExecutor)
 	if err != nil {
 		lmt.Logger(ctx).Fatal("creating hyperlane multi client from config", zap.Error(err))
 	}
 
 	relayer := hyperlane.NewRelayer(hype, make(map[string]string))
 	relayerRunner := hyperlane.NewRelayerRunner(db.New(dbConn), hype, relayer)
+	validatorMonitor := hyperlane.NewValidatorMonitor(hype, make(map[string]string))
+
+	// the fund rebalancer is shared with the gas monitor, which uses it to top
+	// up gas balances
+	fundRebalancer, err := fundrebalancer.NewFundRebalancer(ctx, keyStore, skipgo, evmManager, db.New(dbConn), txPriceOracle, evmTxExecutor, cosmosTxExecutor)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Unable to create fund rebalancer", zap.Error(err))
+	}
 
 	eg, ctx := errgroup.WithContext(ctx)
 
@@ -136,7 +148,7 @@ func main() {
 	})
 
 	eg.Go(func() error {
-		r, err := ordersettler.NewOrderSettler(ctx, db.New(dbConn), clientManager, relayerRunner)
+		r, err := ordersettler.NewOrderSettler(ctx, db.New(dbConn), clientManager, relayerRunner, validatorMonitor)
 		if err != nil {
 			return fmt.Errorf("creating order settler: %w", err)
 		}
@@ -145,11 +157,7 @@ func main() {
 	})
 
 	eg.Go(func() error {
-		r, err := fundrebalancer.NewFundRebalancer(ctx, keyStore, skipgo, evmManager, db.New(dbConn), txPriceOracle, evmTxExecutor)
-		if err != nil {
-			return fmt.Errorf("creating fund rebalancer: %w", err)
-		}
-		r.Run(ctx)
+		fundRebalancer.Run(ctx)
 		return nil
 	})
 
@@ -172,7 +180,7 @@ func main() {
 	})
 
 	eg.Go(func() error {
-		gasMonitor := gasmonitor.NewGasMonitor(clientManager)
+		gasMonitor := gasmonitor.NewGasMonitor(clientManager, fundRebalancer)
 		err := gasMonitor.Start(ctx)
 		if err != nil {
 			return fmt.Errorf("creating gas monitor: %w", err)
@@ -187,6 +195,16 @@ func main() {
 		return nil
 	})
 
+	eg.Go(func() error {
+		hyperlane.NewDispatchIndexer(db.New(dbConn), hype, relayerRunner).Run(ctx)
+		return nil
+	})
+
+	eg.Go(func() error {
+		validatorMonitor.Run(ctx)
+		return nil
+	})
+
 	if err := eg.Wait(); err != nil {
 		lmt.Logger(ctx).Fatal("error running solver", zap.Error(err))
 	}
@@ -211,6 +229,12 @@ func redactConfig(cfg *config.Config) config.Config {
 			evmCopy.RPCBasicAuthVar = "[redacted]"
 			chainCopy.EVM = &evmCopy
 		}
+		if chainCopy.SVM != nil {
+			svmCopy := *chainCopy.SVM
+			svmCopy.RPC = "[redacted]"
+			svmCopy.RPCBasicAuthVar = "[redacted]"
+			chainCopy.SVM = &svmCopy
+		}
 		redactedConfig.Chains[chainID] = chainCopy
 	}
 
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var allowancesCmd = &cobra.Command{
+	Use:   "allowances",
+	Short: "Show erc20 allowances granted to rebalancing spenders",
+	Long: `Show every spender contract the fund rebalancer has approved to move the solvers usdc, along with
+its current on chain allowance, when it was last used, and whether it has been revoked or is idle.`,
+	Example: `solver allowances`,
+	Run:     allowancesList,
+}
+
+var allowancesRevokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke erc20 allowances granted to rebalancing spenders",
+	Long: `Set the allowance of a spender for the solvers tokens to 0. The token defaults to the usdc
+contract of the chain. Pass --idle instead of a spender to revoke every idle allowance.`,
+	Example: `solver allowances revoke --chain-id 42161 --spender 0x23b8...
+solver allowances revoke --idle`,
+	Run: allowancesRevoke,
+}
+
+func init() {
+	rootCmd.AddCommand(allowancesCmd)
+	allowancesCmd.AddCommand(allowancesRevokeCmd)
+
+	allowancesRevokeCmd.Flags().String("chain-id", "", "Chain ID the allowance was granted on")
+	allowancesRevokeCmd.Flags().String("spender", "", "Address of the spender to revoke")
+	allowancesRevokeCmd.Flags().String("token", "", "Address of the token to revoke the allowance for. Defaults to the chains usdc contract")
+	allowancesRevokeCmd.Flags().Bool("idle", false, "Revoke every idle allowance")
+	allowancesRevokeCmd.Flags().Bool("yes", false, "Revoke without asking for confirmation")
+	allowancesRevokeCmd.MarkFlagsRequiredTogether("chain-id", "spender")
+	allowancesRevokeCmd.MarkFlagsOneRequired("spender", "idle")
+	allowancesRevokeCmd.MarkFlagsMutuallyExclusive("spender", "idle")
+}
+
+func allowancesList(cmd *cobra.Command, args []string) {
+	ctx := setupContext(cmd)
+
+	allowances, err := setupFundRebalancer(ctx, cmd).Allowances().Allowances(ctx)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get erc20 allowances", zap.Error(err))
+	}
+
+	fmt.Println("\nERC20 Allowances:")
+	fmt.Println("--------------------------")
+	for _, allowance := range allowances {
+		fmt.Printf("\n%s on %s:\n", allowance.Spender, allowance.ChainID)
+		fmt.Printf("  Token: %s\n", allowance.TokenContract)
+		fmt.Printf("  Allowance: %s USDC\n", normalizeBalance(allowance.AllowanceUUSDC, CCTP_TOKEN_DECIMALS))
+		fmt.Printf("  Last Used: %s\n", allowance.LastUsedAt.Format(time.RFC3339))
+		if !allowance.RevokedAt.IsZero() {
+			fmt.Printf("  Revoked: %s\n", allowance.RevokedAt.Format(time.RFC3339))
+		}
+		if allowance.Idle {
+			fmt.Println("  Idle: true")
+		}
+	}
+	fmt.Printf("\nTotal: %d spenders\n", len(allowances))
+}
+
+func allowancesRevoke(cmd *cobra.Command, args []string) {
+	ctx := setupContext(cmd)
+
+	chainID, err := cmd.Flags().GetString("chain-id")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get chain-id", zap.Error(err))
+	}
+	spender, err := cmd.Flags().GetString("spender")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get spender", zap.Error(err))
+	}
+	token, err := cmd.Flags().GetString("token")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get token", zap.Error(err))
+	}
+	idle, err := cmd.Flags().GetBool("idle")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get idle", zap.Error(err))
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get yes", zap.Error(err))
+	}
+
+	allowances := setupFundRebalancer(ctx, cmd).Allowances()
+
+	if idle {
+		if !yes && !confirm("Revoke every idle erc20 allowance?") {
+			fmt.Println("Aborted")
+			return
+		}
+		if err := allowances.RevokeIdleAllowances(ctx); err != nil {
+			lmt.Logger(ctx).Fatal("Failed to revoke idle erc20 allowances", zap.Error(err))
+		}
+		fmt.Println("Revoked idle erc20 allowances")
+		return
+	}
+
+	if token == "" {
+		chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to get chain config", zap.Error(err), zap.String("chainID", chainID))
+		}
+		token = chainConfig.USDCDenom
+	}
+
+	if !yes && !confirm(fmt.Sprintf("Revoke allowance of %s for %s on chain %s?", spender, token, chainID)) {
+		fmt.Println("Aborted")
+		return
+	}
+	hash, err := allowances.RevokeAllowance(ctx, chainID, token, spender)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to revoke erc20 allowance", zap.Error(err))
+	}
+	fmt.Printf("Revoked allowance of %s on chain %s: %s\n", spender, chainID, hash)
+}
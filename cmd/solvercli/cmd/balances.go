@@ -193,7 +193,7 @@ func buildBalancesRequest(ctx context.Context, cmd *cobra.Command) (*skipgo.Bala
 		var gasTokenDenom string
 		if chainConfig.Type == config.ChainType_COSMOS {
 			gasTokenDenom = chainConfig.Cosmos.GasDenom
-		} else if chainConfig.Type == config.ChainType_EVM {
+		} else if chainConfig.Type == config.ChainType_EVM || chainConfig.Type == config.ChainType_SVM {
 			gasTokenDenom = chainConfig.ChainName + "-native"
 		}
 
@@ -214,7 +214,7 @@ func buildBalancesRequest(ctx context.Context, cmd *cobra.Command) (*skipgo.Bala
 }
 
 func fetchBalances(ctx context.Context, request *skipgo.BalancesRequest) (*skipgo.BalancesResponse, error) {
-	skipClient, err := skipgo.NewSkipGoClient("https://api.skip.build")
+	skipClient, err := newSkipGoClient(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("creating skip client: %w", err)
 	}
@@ -259,7 +259,7 @@ func processGasBalance(
 	var gasTokenDenom string
 	if chainConfig.Type == config.ChainType_COSMOS {
 		gasTokenDenom = chainConfig.Cosmos.GasDenom
-	} else if chainConfig.Type == config.ChainType_EVM {
+	} else if chainConfig.Type == config.ChainType_EVM || chainConfig.Type == config.ChainType_SVM {
 		gasTokenDenom = chainConfig.ChainName + "-native"
 	}
 
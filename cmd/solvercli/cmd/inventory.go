@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
 	"github.com/skip-mev/go-fast-solver/ordersettler"
 	"github.com/skip-mev/go-fast-solver/shared/lmt"
 	"github.com/spf13/cobra"
@@ -78,6 +79,10 @@ var inventoryCmd = &cobra.Command{
 		fmt.Println("\nPending Rebalance Transfers:")
 		fmt.Println("--------------------------")
 		for _, transfer := range pendingRebalances {
+			if transfer.Kind == dbtypes.RebalanceTransferKindGasTopUp {
+				// gas top ups swap usdc out of the solvers inventory
+				continue
+			}
 			amount, _ := new(big.Int).SetString(transfer.Amount, 10)
 			fmt.Printf("\nFrom %s to %s:\n", transfer.SourceChainID, transfer.DestinationChainID)
 			fmt.Printf("  Amount: %s USDC\n", normalizeBalance(amount, CCTP_TOKEN_DECIMALS))
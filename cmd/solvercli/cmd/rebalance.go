@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/skip-mev/go-fast-solver/fundrebalancer"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var rebalanceCmd = &cobra.Command{
+	Use:   "rebalance",
+	Short: "Preview and run fund rebalancing moves",
+	Long:  "Preview the moves the fund rebalancer would make, or run one-off moves of usdc between chains",
+}
+
+var rebalancePlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the moves the fund rebalancer would make",
+	Long: `Show the current balances, pending inbound transfers, targets, deficits and surpluses of every chain
+configured for rebalancing, along with the routes and fees of the moves the fund rebalancer would make.
+
+Pass --execute to submit the planned moves after confirming them. Submitted moves are recorded as rebalance
+transfers and tracked by the solver like any other rebalance. Moves are capped by the configured rebalance
+limits when they are submitted, and chains within their rebalance cooldown are not planned.`,
+	Example: `solver rebalance plan
+solver rebalance plan --execute`,
+	Run: rebalancePlan,
+}
+
+var rebalanceExecuteCmd = &cobra.Command{
+	Use:   "execute",
+	Short: "Move usdc between two chains",
+	Long: `Quote and submit a one-off move of usdc between two chains using the cheapest bridge that supports them.
+The move does not require the source chain to have usdc to spare, and the source chains max rebalancing
+gas cost is not enforced. The move is recorded as a rebalance transfer and tracked by the solver like
+any other rebalance. The configured rebalance limits are enforced when the move is submitted.`,
+	Example: `solver rebalance execute --from 42161 --to osmosis-1 --amount 1000000000`,
+	Run:     rebalanceExecute,
+}
+
+func init() {
+	rootCmd.AddCommand(rebalanceCmd)
+	rebalanceCmd.AddCommand(rebalancePlanCmd)
+	rebalanceCmd.AddCommand(rebalanceExecuteCmd)
+
+	rebalanceCmd.PersistentFlags().Bool("yes", false, "Submit moves without asking for confirmation")
+	rebalanceCmd.PersistentFlags().Bool("override-limits", false, "Ignore the configured rebalance limits and cooldowns. For emergencies only")
+
+	rebalancePlanCmd.Flags().Bool("execute", false, "Submit the planned moves")
+
+	rebalanceExecuteCmd.Flags().String("from", "", "Chain ID to move usdc from")
+	rebalanceExecuteCmd.Flags().String("to", "", "Chain ID to move usdc to")
+	rebalanceExecuteCmd.Flags().String("amount", "", "Amount of uusdc to move")
+	for _, flag := range []string{"from", "to", "amount"} {
+		if err := rebalanceExecuteCmd.MarkFlagRequired(flag); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func rebalancePlan(cmd *cobra.Command, args []string) {
+	ctx := setupContext(cmd)
+
+	execute, err := cmd.Flags().GetBool("execute")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get execute", zap.Error(err))
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get yes", zap.Error(err))
+	}
+	overrideLimits, err := cmd.Flags().GetBool("override-limits")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get override-limits", zap.Error(err))
+	}
+
+	rebalancer := setupFundRebalancer(ctx, cmd)
+	plan, err := rebalancer.Plan(ctx, overrideLimits)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to plan rebalance", zap.Error(err))
+	}
+
+	fmt.Println("\nChains:")
+	fmt.Println("--------------------------")
+	for _, chain := range plan.Chains {
+		fmt.Printf("\n%s:\n", chain.ChainID)
+		fmt.Printf("  Balance: %s USDC\n", normalizeBalance(chain.BalanceUUSDC, CCTP_TOKEN_DECIMALS))
+		fmt.Printf("  Pending Inbound: %s USDC\n", normalizeBalance(chain.PendingInboundUUSDC, CCTP_TOKEN_DECIMALS))
+		fmt.Printf("  Min Allowed: %s USDC\n", normalizeBalance(chain.MinAllowedAmountUUSDC, CCTP_TOKEN_DECIMALS))
+		fmt.Printf("  Target: %s USDC\n", normalizeBalance(chain.TargetAmountUUSDC, CCTP_TOKEN_DECIMALS))
+		fmt.Printf("  Deficit: %s USDC\n", normalizeBalance(chain.DeficitUUSDC, CCTP_TOKEN_DECIMALS))
+		fmt.Printf("  Surplus: %s USDC\n", normalizeBalance(chain.SurplusUUSDC, CCTP_TOKEN_DECIMALS))
+		if reason, ok := plan.Unfunded[chain.ChainID]; ok {
+			fmt.Printf("  No Moves Planned: %s\n", reason)
+		}
+	}
+
+	fmt.Println("\nPlanned Moves:")
+	fmt.Println("--------------------------")
+	for _, move := range plan.Moves {
+		printPlannedMove(move)
+	}
+	fmt.Printf("\nTotal: %d moves\n", len(plan.Moves))
+
+	if !execute || len(plan.Moves) == 0 {
+		return
+	}
+	if overrideLimits {
+		fmt.Println("\nWARNING: rebalance limits will not be enforced")
+	}
+	if !yes && !confirm(fmt.Sprintf("Submit %d moves?", len(plan.Moves))) {
+		fmt.Println("Aborted")
+		return
+	}
+	for _, move := range plan.Moves {
+		hash, moved, err := rebalancer.ExecuteMove(ctx, move, overrideLimits)
+		if err != nil {
+			lmt.Logger(ctx).Error(
+				"Failed to submit move",
+				zap.String("sourceChainID", move.SourceChainID),
+				zap.String("destinationChainID", move.DestinationChainID),
+				zap.Error(err),
+			)
+			continue
+		}
+		printSubmittedMove(move, moved, hash)
+	}
+}
+
+func rebalanceExecute(cmd *cobra.Command, args []string) {
+	ctx := setupContext(cmd)
+
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get from", zap.Error(err))
+	}
+	to, err := cmd.Flags().GetString("to")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get to", zap.Error(err))
+	}
+	amountStr, err := cmd.Flags().GetString("amount")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get amount", zap.Error(err))
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get yes", zap.Error(err))
+	}
+	overrideLimits, err := cmd.Flags().GetBool("override-limits")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get override-limits", zap.Error(err))
+	}
+	amount, ok := new(big.Int).SetString(amountStr, 10)
+	if !ok {
+		lmt.Logger(ctx).Fatal("Amount must be an integer amount of uusdc", zap.String("amount", amountStr))
+	}
+
+	rebalancer := setupFundRebalancer(ctx, cmd)
+	move, err := rebalancer.QuoteMove(ctx, from, to, amount, overrideLimits)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to quote move", zap.Error(err))
+	}
+
+	fmt.Println("\nMove:")
+	fmt.Println("--------------------------")
+	printPlannedMove(*move)
+
+	if overrideLimits {
+		fmt.Println("\nWARNING: rebalance limits will not be enforced")
+	}
+	if !yes && !confirm("Submit move?") {
+		fmt.Println("Aborted")
+		return
+	}
+	hash, moved, err := rebalancer.ExecuteMove(ctx, *move, overrideLimits)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to submit move", zap.Error(err))
+	}
+	printSubmittedMove(*move, moved, hash)
+}
+
+func printSubmittedMove(move fundrebalancer.PlannedMove, moved *big.Int, hash skipgo.TxHash) {
+	fmt.Printf("Submitted move from %s to %s with tx hash %s\n", move.SourceChainID, move.DestinationChainID, hash)
+	if moved.Cmp(move.AmountUUSDC) != 0 {
+		fmt.Printf("  Amount capped by rebalance limits to %s USDC\n", normalizeBalance(moved, CCTP_TOKEN_DECIMALS))
+	}
+}
+
+func printPlannedMove(move fundrebalancer.PlannedMove) {
+	fmt.Printf("\nFrom %s to %s via %s:\n", move.SourceChainID, move.DestinationChainID, move.Bridge)
+	fmt.Printf("  Route: %s\n", strings.Join(move.Route, " -> "))
+	fmt.Printf("  Amount: %s USDC\n", normalizeBalance(move.AmountUUSDC, CCTP_TOKEN_DECIMALS))
+	fmt.Printf("  Route Fee: %s USDC\n", normalizeBalance(move.RouteFeeUUSDC, CCTP_TOKEN_DECIMALS))
+	fmt.Printf("  Bridge Fee: %s USDC\n", normalizeBalance(move.BridgeFeeUUSDC, CCTP_TOKEN_DECIMALS))
+	fmt.Printf("  Gas Cost: %s USDC\n", normalizeBalance(move.GasCostUUSDC, CCTP_TOKEN_DECIMALS))
+	fmt.Printf("  Total Cost: %s USDC\n", normalizeBalance(move.TotalCostUUSDC, CCTP_TOKEN_DECIMALS))
+	fmt.Printf("  Estimated Duration: %s\n", move.EstimatedDuration)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
 	"github.com/skip-mev/go-fast-solver/shared/lmt"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -36,6 +37,12 @@ var rebalancesCmd = &cobra.Command{
 			if !ok {
 				lmt.Logger(ctx).Fatal("Failed to get transfer amount big.Int", zap.Error(err))
 			}
+			if transfer.Kind == dbtypes.RebalanceTransferKindGasTopUp {
+				fmt.Printf("\nGas top up of %s from %s:\n", transfer.DestinationChainID, transfer.SourceChainID)
+				fmt.Printf("  Amount: %s USDC\n", normalizeBalance(transferAmount, CCTP_TOKEN_DECIMALS))
+				fmt.Printf("  Tx Hash: %s\n", transfer.TxHash)
+				continue
+			}
 			fmt.Printf("\nFrom %s to %s:\n", transfer.SourceChainID, transfer.DestinationChainID)
 			fmt.Printf("  Amount: %s USDC\n", normalizeBalance(transferAmount, CCTP_TOKEN_DECIMALS))
 			fmt.Printf("  Tx Hash: %s\n", transfer.TxHash)
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var rebalancesStrandedCmd = &cobra.Command{
+	Use:     "stranded",
+	Short:   "Show partially failed multi tx rebalance transfers",
+	Long:    "Show multi tx rebalance transfers that failed after their first tx completed, along with the chain their funds may be stranded on",
+	Example: `solver rebalances stranded`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := setupContext(cmd)
+
+		database, err := setupDatabase(ctx, cmd)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to setup database", zap.Error(err))
+		}
+
+		transfers, err := database.GetRebalanceTransfersWithStatus(ctx, dbtypes.RebalanceTransferStatusPartiallyFailed)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to get partially failed rebalances", zap.Error(err))
+		}
+
+		fmt.Println("\nPartially Failed Rebalance Transfers:")
+		fmt.Println("--------------------------")
+
+		for _, transfer := range transfers {
+			transferAmount, ok := new(big.Int).SetString(transfer.Amount, 10)
+			if !ok {
+				lmt.Logger(ctx).Fatal("Failed to get transfer amount big.Int", zap.String("amount", transfer.Amount))
+			}
+			fmt.Printf("\nTransfer %d from %s to %s:\n", transfer.ID, transfer.SourceChainID, transfer.DestinationChainID)
+			fmt.Printf("  Amount: %s USDC\n", normalizeBalance(transferAmount, CCTP_TOKEN_DECIMALS))
+
+			steps, err := database.GetRebalanceTransferSteps(ctx, transfer.ID)
+			if err != nil {
+				lmt.Logger(ctx).Fatal("Failed to get rebalance transfer steps", zap.Int64("id", transfer.ID), zap.Error(err))
+			}
+			for _, step := range steps {
+				fmt.Printf("  Step %d on %s: %s\n", step.StepIndex, step.ChainID, step.Status)
+				if step.TxHash.Valid {
+					fmt.Printf("    Tx Hash: %s\n", step.TxHash.String)
+				}
+				if step.Status == dbtypes.RebalanceTransferStepStatusFailed {
+					fmt.Printf("    Funds May Be Stranded On: %s\n", step.ChainID)
+					fmt.Printf("    Error: %s\n", step.StatusMessage.String)
+				}
+			}
+		}
+
+		fmt.Printf("\nTotal: %d transfers\n", len(transfers))
+	},
+}
+
+var rebalancesRetryCmd = &cobra.Command{
+	Use:     "retry",
+	Short:   "Retry the failed step of a partially failed rebalance transfer",
+	Long:    "Resets the failed step of a partially failed multi tx rebalance transfer so that the solver resubmits it and continues executing the rest of the route",
+	Example: `solver rebalances retry --transfer-id 12`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := setupContext(cmd)
+
+		transferID, err := cmd.Flags().GetInt64("transfer-id")
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Error reading transfer-id command line argument", zap.Error(err))
+		}
+
+		database, err := setupDatabase(ctx, cmd)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to setup database", zap.Error(err))
+		}
+
+		transfers, err := database.GetRebalanceTransfersWithStatus(ctx, dbtypes.RebalanceTransferStatusPartiallyFailed)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to get partially failed rebalances", zap.Error(err))
+		}
+		found := false
+		for _, transfer := range transfers {
+			if transfer.ID == transferID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			lmt.Logger(ctx).Fatal("No partially failed rebalance transfer found", zap.Int64("id", transferID))
+		}
+
+		steps, err := database.GetRebalanceTransferSteps(ctx, transferID)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to get rebalance transfer steps", zap.Int64("id", transferID), zap.Error(err))
+		}
+		for _, step := range steps {
+			if step.Status != dbtypes.RebalanceTransferStepStatusFailed {
+				continue
+			}
+
+			if _, err := database.ResetRebalanceTransferStep(ctx, step.ID); err != nil {
+				lmt.Logger(ctx).Fatal("Failed to reset rebalance transfer step", zap.Int64("id", transferID), zap.Int64("step", step.StepIndex), zap.Error(err))
+			}
+			if err := database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{
+				Status: dbtypes.RebalanceTransferStatusPending,
+				ID:     transferID,
+			}); err != nil {
+				lmt.Logger(ctx).Fatal("Failed to set rebalance transfer status to pending", zap.Int64("id", transferID), zap.Error(err))
+			}
+
+			fmt.Printf("Step %d of rebalance transfer %d on %s will be resubmitted by the solver\n", step.StepIndex, transferID, step.ChainID)
+			return
+		}
+
+		lmt.Logger(ctx).Fatal("No failed step found for rebalance transfer", zap.Int64("id", transferID))
+	},
+}
+
+func init() {
+	rebalancesCmd.AddCommand(rebalancesStrandedCmd)
+	rebalancesCmd.AddCommand(rebalancesRetryCmd)
+
+	rebalancesRetryCmd.Flags().Int64("transfer-id", 0, "id of the partially failed rebalance transfer to retry")
+	if err := rebalancesRetryCmd.MarkFlagRequired("transfer-id"); err != nil {
+		panic(err)
+	}
+}
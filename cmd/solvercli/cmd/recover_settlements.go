@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/ordersettler"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var recoverSettlementsCmd = &cobra.Command{
+	Use:   "recover-settlements",
+	Short: "Classify and recover failed or stuck settlements",
+	Long: `Classify failed or stuck settlements by the cause of their failure and recover them.
+
+By default the failed or stuck settlements are listed along with their failure cause and recommended
+recovery action, without making any changes. Pass --order-id to manually recover a single settlement,
+optionally overriding the recommended action with --action (REINITIATE, RERELAY, WRITE_OFF, MARK_COMPLETE).
+Pass --auto to apply the recommended recovery action to every failed or stuck settlement.`,
+	Example: `solver recover-settlements
+solver recover-settlements --order-id <order id> --action WRITE_OFF
+solver recover-settlements --auto --stuck-after 2h`,
+	Run: recoverSettlements,
+}
+
+func init() {
+	rootCmd.AddCommand(recoverSettlementsCmd)
+	recoverSettlementsCmd.Flags().String("order-id", "", "Recover only the settlement for this order ID")
+	recoverSettlementsCmd.Flags().String("action", "", "Recovery action to apply to --order-id instead of the recommended action")
+	recoverSettlementsCmd.Flags().Bool("auto", false, "Apply the recommended recovery action to all failed or stuck settlements")
+	recoverSettlementsCmd.Flags().Duration("stuck-after", time.Hour, "How long a settlement can go without progress before it is considered stuck")
+}
+
+func recoverSettlements(cmd *cobra.Command, args []string) {
+	ctx := setupContext(cmd)
+
+	orderID, err := cmd.Flags().GetString("order-id")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get order-id", zap.Error(err))
+	}
+	actionOverride, err := cmd.Flags().GetString("action")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get action", zap.Error(err))
+	}
+	auto, err := cmd.Flags().GetBool("auto")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get auto", zap.Error(err))
+	}
+	stuckAfter, err := cmd.Flags().GetDuration("stuck-after")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to get stuck-after", zap.Error(err))
+	}
+
+	if auto && orderID != "" {
+		lmt.Logger(ctx).Fatal("--auto and --order-id can not be used together")
+	}
+	if actionOverride != "" && orderID == "" {
+		lmt.Logger(ctx).Fatal("--action can only be used with --order-id")
+	}
+
+	database, err := setupDatabase(ctx, cmd)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to setup database", zap.Error(err))
+	}
+	_, clientManager := setupClients(ctx, cmd)
+
+	recoverer := ordersettler.NewSettlementRecoverer(database, clientManager, stuckAfter)
+
+	if auto {
+		recovered, err := recoverer.RecoverAll(ctx)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to recover settlements", zap.Error(err))
+		}
+		fmt.Printf("\nRecovered %d settlements:\n", len(recovered))
+		fmt.Println("------------------------")
+		printSettlementRecoveries(recovered)
+		return
+	}
+
+	recoveries, err := recoverer.Classify(ctx)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to classify settlements", zap.Error(err))
+	}
+
+	if orderID == "" {
+		fmt.Println("\nFailed or Stuck Settlements:")
+		fmt.Println("---------------------------")
+		printSettlementRecoveries(recoveries)
+		return
+	}
+
+	var recovery *ordersettler.SettlementRecovery
+	for i := range recoveries {
+		if recoveries[i].Settlement.OrderID == orderID {
+			recovery = &recoveries[i]
+			break
+		}
+	}
+	if recovery == nil {
+		lmt.Logger(ctx).Fatal("Settlement is not failed or stuck", zap.String("orderID", orderID))
+	}
+
+	action := recovery.Action
+	if actionOverride != "" {
+		action, err = ordersettler.ParseRecoveryAction(actionOverride)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Invalid recovery action", zap.Error(err))
+		}
+	}
+
+	if err := recoverer.Recover(ctx, *recovery, action); err != nil {
+		lmt.Logger(ctx).Fatal("Failed to recover settlement", zap.String("orderID", orderID), zap.Error(err))
+	}
+	fmt.Printf("\nApplied %s to settlement for order %s (cause: %s)\n", action, orderID, recovery.Cause)
+}
+
+func printSettlementRecoveries(recoveries []ordersettler.SettlementRecovery) {
+	for _, recovery := range recoveries {
+		amount, _ := new(big.Int).SetString(recovery.Settlement.Amount, 10)
+		fmt.Printf("\nOrder %s (%s -> %s):\n", recovery.Settlement.OrderID, recovery.Settlement.SourceChainID, recovery.Settlement.DestinationChainID)
+		fmt.Printf("  Amount: %s USDC\n", normalizeBalance(amount, CCTP_TOKEN_DECIMALS))
+		fmt.Printf("  Status: %s\n", recovery.Settlement.SettlementStatus)
+		fmt.Printf("  Cause: %s\n", recovery.Cause)
+		fmt.Printf("  Action: %s\n", recovery.Action)
+		if recovery.Reason != "" {
+			fmt.Printf("  Reason: %s\n", recovery.Reason)
+		}
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"time"
 
 	"github.com/skip-mev/go-fast-solver/shared/oracle"
-	"github.com/skip-mev/go-fast-solver/shared/txexecutor/evm"
+	"github.com/skip-mev/go-fast-solver/shared/txexecutor/cosmos"
 
 	"os/signal"
 	"syscall"
@@ -17,7 +17,6 @@ import (
 	"github.com/skip-mev/go-fast-solver/shared/clients/coingecko"
 	"github.com/skip-mev/go-fast-solver/shared/clients/utils"
 	"github.com/skip-mev/go-fast-solver/shared/config"
-	"github.com/skip-mev/go-fast-solver/shared/evmrpc"
 	"github.com/skip-mev/go-fast-solver/shared/keys"
 	"github.com/skip-mev/go-fast-solver/shared/lmt"
 	"github.com/skip-mev/go-fast-solver/shared/metrics"
@@ -99,8 +98,7 @@ var relayCmd = &cobra.Command{
 		coingeckoClient := coingecko.NewCoingeckoClient(rateLimitedClient, "https://api.coingecko.com/api/v3/", "")
 		cachedCoinGeckoClient := coingecko.NewCachedPriceClient(coingeckoClient, 15*time.Minute)
 		txPriceOracle := oracle.NewOracle(cachedCoinGeckoClient)
-		evmTxExecutor := evm.DefaultEVMTxExecutor()
-		hype, err := hyperlane.NewMultiClientFromConfig(ctx, evmrpc.NewEVMRPCClientManager(), keyStore, txPriceOracle, evmTxExecutor)
+		hype, err := hyperlane.NewMultiClientFromConfig(ctx, evmClientManager, keyStore, txPriceOracle, evmTxExecutor, cosmos.DefaultSerializedCosmosTxExecutor())
 		if err != nil {
 			lmt.Logger(ctx).Error("Error creating hyperlane multi client from config", zap.Error(err))
 		}
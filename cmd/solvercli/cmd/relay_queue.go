@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var relayQueueCmd = &cobra.Command{
+	Use:     "queue",
+	Short:   "Show hyperlane transfers queued to be relayed",
+	Long:    "Show hyperlane transfers queued to be relayed, along with their relay attempts and when they will next be attempted",
+	Example: `solver relay queue --status ABANDONED`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := setupContext(cmd)
+
+		status, err := cmd.Flags().GetString("status")
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Error reading status command line argument", zap.Error(err))
+		}
+
+		database, err := setupDatabase(ctx, cmd)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to setup database", zap.Error(err))
+		}
+
+		transfers, err := database.GetAllHyperlaneTransfersWithTransferStatus(ctx, status)
+		if err != nil {
+			lmt.Logger(ctx).Fatal("Failed to get hyperlane transfers", zap.Error(err))
+		}
+		sort.Slice(transfers, func(i, j int) bool {
+			return transfers[i].CreatedAt.Before(transfers[j].CreatedAt)
+		})
+
+		fmt.Printf("\nHyperlane Transfers (%s):\n", status)
+		fmt.Println("--------------------------")
+
+		now := time.Now()
+		for _, transfer := range transfers {
+			fmt.Printf("\nTransfer %d from %s to %s:\n", transfer.ID, transfer.SourceChainID, transfer.DestinationChainID)
+			fmt.Printf("  Message Sent Tx: %s\n", transfer.MessageSentTx)
+			fmt.Printf("  Message ID: %s\n", transfer.MessageID)
+			fmt.Printf("  Age: %s\n", now.Sub(transfer.CreatedAt).Truncate(time.Second))
+			fmt.Printf("  Attempts: %d\n", transfer.AttemptCount)
+			if transfer.LastErrorClass.Valid {
+				fmt.Printf("  Last Error: %s\n", transfer.LastErrorClass.String)
+			}
+			if status == dbtypes.TransferStatusPending {
+				nextAttempt := "now"
+				if transfer.NextAttemptAt.Valid && transfer.NextAttemptAt.Time.After(now) {
+					nextAttempt = fmt.Sprintf("in %s", transfer.NextAttemptAt.Time.Sub(now).Truncate(time.Second))
+				}
+				fmt.Printf("  Next Attempt: %s\n", nextAttempt)
+			}
+			if transfer.TransferStatusMessage.Valid {
+				fmt.Printf("  Status Message: %s\n", transfer.TransferStatusMessage.String)
+			}
+		}
+
+		fmt.Printf("\nTotal: %d transfers\n", len(transfers))
+	},
+}
+
+func init() {
+	relayCmd.AddCommand(relayQueueCmd)
+
+	relayQueueCmd.Flags().String("status", dbtypes.TransferStatusPending, "only show transfers with this status (PENDING, SUCCESS, ABANDONED, CANCELLED)")
+}
@@ -5,6 +5,7 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/skip-mev/go-fast-solver/db/gen/db"
 	"github.com/skip-mev/go-fast-solver/ordersettler/types"
@@ -138,11 +139,25 @@ func settleOrders(cmd *cobra.Command, args []string) {
 				continue
 			}
 
+			// the order amount is needed to resolve amount based settlement
+			// repayment address rules
+			exists, amount, err := sourceBridgeClient.OrderExists(ctx, sourceGatewayAddress, fill.OrderID, nil)
+			if err != nil {
+				lmt.Logger(ctx).Error("getting order amount",
+					zap.String("orderID", fill.OrderID),
+					zap.Error(err))
+				continue
+			}
+			if !exists {
+				continue
+			}
+
 			pendingSettlements = append(pendingSettlements, db.OrderSettlement{
 				SourceChainID:                     sourceChainID,
 				DestinationChainID:                chain.ChainID,
 				SourceChainGatewayContractAddress: sourceGatewayAddress,
 				OrderID:                           fill.OrderID,
+				Amount:                            amount.String(),
 			})
 		}
 	}
@@ -162,6 +177,13 @@ func settleOrders(cmd *cobra.Command, args []string) {
 			continue
 		}
 
+		repaymentAddress, err := batch.ResolveRepaymentAddress(ctx, time.Now())
+		if err != nil {
+			lmt.Logger(ctx).Error("resolving repayment address", zap.Error(err))
+			continue
+		}
+		batch = batch.WithRepaymentAddress(repaymentAddress)
+
 		txHash, _, err := destinationBridgeClient.InitiateBatchSettlement(ctx, batch)
 		if err != nil {
 			lmt.Logger(ctx).Error("initiating batch settlement", zap.Error(err))
@@ -172,6 +194,7 @@ func settleOrders(cmd *cobra.Command, args []string) {
 		fmt.Printf("Source Chain: %s\n", batch.SourceChainID())
 		fmt.Printf("Destination Chain: %s\n", batch.DestinationChainID())
 		fmt.Printf("Number of Orders: %d\n", len(batch.OrderIDs()))
+		fmt.Printf("Repayment Address: %s\n", repaymentAddress)
 		fmt.Printf("Transaction Hash: %s\n", txHash)
 	}
 }
@@ -1,16 +1,23 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"github.com/skip-mev/go-fast-solver/db/connect"
 	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/fundrebalancer"
 	"github.com/skip-mev/go-fast-solver/shared/clientmanager"
+	"github.com/skip-mev/go-fast-solver/shared/clients/coingecko"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/clients/utils"
 	"github.com/skip-mev/go-fast-solver/shared/config"
 	"github.com/skip-mev/go-fast-solver/shared/evmrpc"
 	"github.com/skip-mev/go-fast-solver/shared/keys"
 	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/oracle"
 	"github.com/skip-mev/go-fast-solver/shared/txexecutor/cosmos"
+	"github.com/skip-mev/go-fast-solver/shared/txexecutor/evm"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"math/big"
@@ -18,12 +25,21 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 )
 
 const (
 	CCTP_TOKEN_DECIMALS = 6
 )
 
+var (
+	// evmClientManager and evmTxExecutor are shared by everything a command
+	// sets up, so that every evm tx a command sends is allocated its nonce by
+	// the same executor
+	evmClientManager = evmrpc.NewEVMRPCClientManager()
+	evmTxExecutor    = evm.NewSerializedEVMTxExecutor(evmClientManager, evm.DefaultTxSubmissionDelay)
+)
+
 func setupContext(cmd *cobra.Command) context.Context {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -55,8 +71,6 @@ func setupContext(cmd *cobra.Command) context.Context {
 }
 
 func setupClients(ctx context.Context, cmd *cobra.Command) (evmrpc.EVMRPCClientManager, *clientmanager.ClientManager) {
-	evmClientManager := evmrpc.NewEVMRPCClientManager()
-
 	keysPath, err := cmd.Flags().GetString("keys")
 	if err != nil {
 		lmt.Logger(ctx).Fatal("Error reading keys path", zap.Error(err))
@@ -102,6 +116,75 @@ func normalizeBalance(balance *big.Int, decimals uint8) string {
 	return str
 }
 
+// setupFundRebalancer creates a fund rebalancer for running one-off
+// rebalances outside of the solver loop
+func setupFundRebalancer(ctx context.Context, cmd *cobra.Command) *fundrebalancer.FundRebalancer {
+	keysPath, err := cmd.Flags().GetString("keys")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Error reading keys path", zap.Error(err))
+	}
+
+	keyStoreType, err := cmd.Flags().GetString("key-store-type")
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Error reading key-store-type", zap.Error(err))
+	}
+
+	keyStore, err := keys.GetKeyStore(keyStoreType, keys.GetKeyStoreOpts{KeyFilePath: keysPath})
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Unable to load keystore", zap.Error(err))
+	}
+
+	database, err := setupDatabase(ctx, cmd)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to setup database", zap.Error(err))
+	}
+
+	skipgoClient, err := newSkipGoClient(ctx)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Unable to create Skip Go client", zap.Error(err))
+	}
+
+	coingeckoClient := coingecko.NewCoingeckoClient(utils.DefaultRateLimitedHTTPClient(3), "https://api.coingecko.com/api/v3/", "")
+	txPriceOracle := oracle.NewOracle(coingecko.NewCachedPriceClient(coingeckoClient, 15*time.Minute))
+
+	rebalancer, err := fundrebalancer.NewFundRebalancer(
+		ctx,
+		keyStore,
+		skipgoClient,
+		evmClientManager,
+		database,
+		txPriceOracle,
+		evmTxExecutor,
+		cosmos.DefaultSerializedCosmosTxExecutor(),
+	)
+	if err != nil {
+		lmt.Logger(ctx).Fatal("Failed to create fund rebalancer", zap.Error(err))
+	}
+	return rebalancer
+}
+
+// newSkipGoClient creates a client for the Skip Go api configured in the
+// config in ctx
+func newSkipGoClient(ctx context.Context) (skipgo.SkipGoClient, error) {
+	url := config.GetConfigReader(ctx).Config().SkipGo.URL
+	if url == "" {
+		url = config.DefaultSkipGoURL
+	}
+	return skipgo.NewSkipGoClient(url)
+}
+
+// confirm asks the user to confirm an action on stdin, returning true only if
+// they answer yes
+func confirm(prompt string) bool {
+	fmt.Printf("%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 func setupDatabase(ctx context.Context, cmd *cobra.Command) (*db.Queries, error) {
 	sqliteDBPath, err := cmd.Flags().GetString("sqlite-db-path")
 	if err != nil {
@@ -0,0 +1,204 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: erc20_allowances.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const getERC20Allowances = `-- name: GetERC20Allowances :many
+SELECT id, created_at, updated_at, chain_id, token_contract, spender, approved_amount, approve_tx_hash, last_used_at, revoke_tx_hash, revoked_at FROM erc20_allowances
+ORDER BY chain_id, spender
+`
+
+func (q *Queries) GetERC20Allowances(ctx context.Context) ([]Erc20Allowance, error) {
+	rows, err := q.db.QueryContext(ctx, getERC20Allowances)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Erc20Allowance
+	for rows.Next() {
+		var i Erc20Allowance
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ChainID,
+			&i.TokenContract,
+			&i.Spender,
+			&i.ApprovedAmount,
+			&i.ApproveTxHash,
+			&i.LastUsedAt,
+			&i.RevokeTxHash,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getIdleERC20Allowances = `-- name: GetIdleERC20Allowances :many
+SELECT id, created_at, updated_at, chain_id, token_contract, spender, approved_amount, approve_tx_hash, last_used_at, revoke_tx_hash, revoked_at FROM erc20_allowances
+WHERE revoked_at IS NULL AND last_used_at < ?
+ORDER BY chain_id, spender
+`
+
+func (q *Queries) GetIdleERC20Allowances(ctx context.Context, lastUsedAt time.Time) ([]Erc20Allowance, error) {
+	rows, err := q.db.QueryContext(ctx, getIdleERC20Allowances, lastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Erc20Allowance
+	for rows.Next() {
+		var i Erc20Allowance
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ChainID,
+			&i.TokenContract,
+			&i.Spender,
+			&i.ApprovedAmount,
+			&i.ApproveTxHash,
+			&i.LastUsedAt,
+			&i.RevokeTxHash,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setERC20AllowanceApproved = `-- name: SetERC20AllowanceApproved :one
+INSERT INTO erc20_allowances (
+    chain_id,
+    token_contract,
+    spender,
+    approved_amount,
+    approve_tx_hash
+) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (chain_id, token_contract, spender) DO UPDATE SET
+    updated_at=CURRENT_TIMESTAMP,
+    approved_amount=excluded.approved_amount,
+    approve_tx_hash=excluded.approve_tx_hash,
+    last_used_at=CURRENT_TIMESTAMP,
+    revoke_tx_hash=NULL,
+    revoked_at=NULL
+RETURNING id, created_at, updated_at, chain_id, token_contract, spender, approved_amount, approve_tx_hash, last_used_at, revoke_tx_hash, revoked_at
+`
+
+type SetERC20AllowanceApprovedParams struct {
+	ChainID        string
+	TokenContract  string
+	Spender        string
+	ApprovedAmount sql.NullString
+	ApproveTxHash  sql.NullString
+}
+
+func (q *Queries) SetERC20AllowanceApproved(ctx context.Context, arg SetERC20AllowanceApprovedParams) (Erc20Allowance, error) {
+	row := q.db.QueryRowContext(ctx, setERC20AllowanceApproved,
+		arg.ChainID,
+		arg.TokenContract,
+		arg.Spender,
+		arg.ApprovedAmount,
+		arg.ApproveTxHash,
+	)
+	var i Erc20Allowance
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ChainID,
+		&i.TokenContract,
+		&i.Spender,
+		&i.ApprovedAmount,
+		&i.ApproveTxHash,
+		&i.LastUsedAt,
+		&i.RevokeTxHash,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const setERC20AllowanceRevoked = `-- name: SetERC20AllowanceRevoked :one
+UPDATE erc20_allowances
+SET updated_at=CURRENT_TIMESTAMP, approved_amount='0', revoke_tx_hash = ?, revoked_at=CURRENT_TIMESTAMP
+WHERE chain_id = ? AND token_contract = ? AND spender = ?
+RETURNING id, created_at, updated_at, chain_id, token_contract, spender, approved_amount, approve_tx_hash, last_used_at, revoke_tx_hash, revoked_at
+`
+
+type SetERC20AllowanceRevokedParams struct {
+	RevokeTxHash  sql.NullString
+	ChainID       string
+	TokenContract string
+	Spender       string
+}
+
+func (q *Queries) SetERC20AllowanceRevoked(ctx context.Context, arg SetERC20AllowanceRevokedParams) (Erc20Allowance, error) {
+	row := q.db.QueryRowContext(ctx, setERC20AllowanceRevoked,
+		arg.RevokeTxHash,
+		arg.ChainID,
+		arg.TokenContract,
+		arg.Spender,
+	)
+	var i Erc20Allowance
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ChainID,
+		&i.TokenContract,
+		&i.Spender,
+		&i.ApprovedAmount,
+		&i.ApproveTxHash,
+		&i.LastUsedAt,
+		&i.RevokeTxHash,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const setERC20AllowanceUsed = `-- name: SetERC20AllowanceUsed :exec
+INSERT INTO erc20_allowances (
+    chain_id,
+    token_contract,
+    spender
+) VALUES (?, ?, ?)
+ON CONFLICT (chain_id, token_contract, spender) DO UPDATE SET
+    updated_at=CURRENT_TIMESTAMP,
+    last_used_at=CURRENT_TIMESTAMP
+`
+
+type SetERC20AllowanceUsedParams struct {
+	ChainID       string
+	TokenContract string
+	Spender       string
+}
+
+func (q *Queries) SetERC20AllowanceUsed(ctx context.Context, arg SetERC20AllowanceUsedParams) error {
+	_, err := q.db.ExecContext(ctx, setERC20AllowanceUsed, arg.ChainID, arg.TokenContract, arg.Spender)
+	return err
+}
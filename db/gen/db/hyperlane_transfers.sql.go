@@ -11,7 +11,7 @@ import (
 )
 
 const getAllHyperlaneTransfersWithTransferStatus = `-- name: GetAllHyperlaneTransfersWithTransferStatus :many
-SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc FROM hyperlane_transfers WHERE transfer_status = ?
+SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc, next_attempt_at, attempt_count, last_error_class FROM hyperlane_transfers WHERE transfer_status = ?
 `
 
 func (q *Queries) GetAllHyperlaneTransfersWithTransferStatus(ctx context.Context, transferStatus string) ([]HyperlaneTransfer, error) {
@@ -34,6 +34,9 @@ func (q *Queries) GetAllHyperlaneTransfersWithTransferStatus(ctx context.Context
 			&i.TransferStatus,
 			&i.TransferStatusMessage,
 			&i.MaxTxFeeUusdc,
+			&i.NextAttemptAt,
+			&i.AttemptCount,
+			&i.LastErrorClass,
 		); err != nil {
 			return nil, err
 		}
@@ -48,8 +51,81 @@ func (q *Queries) GetAllHyperlaneTransfersWithTransferStatus(ctx context.Context
 	return items, nil
 }
 
+const getHyperlaneDispatchIndexerMetadata = `-- name: GetHyperlaneDispatchIndexerMetadata :one
+SELECT id, created_at, updated_at, chain_id, height_last_seen FROM hyperlane_dispatch_indexer_metadata WHERE chain_id = ?
+`
+
+func (q *Queries) GetHyperlaneDispatchIndexerMetadata(ctx context.Context, chainID string) (HyperlaneDispatchIndexerMetadatum, error) {
+	row := q.db.QueryRowContext(ctx, getHyperlaneDispatchIndexerMetadata, chainID)
+	var i HyperlaneDispatchIndexerMetadatum
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ChainID,
+		&i.HeightLastSeen,
+	)
+	return i, err
+}
+
+const getHyperlaneTransfer = `-- name: GetHyperlaneTransfer :one
+SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc, next_attempt_at, attempt_count, last_error_class FROM hyperlane_transfers WHERE id = ?
+`
+
+func (q *Queries) GetHyperlaneTransfer(ctx context.Context, id int64) (HyperlaneTransfer, error) {
+	row := q.db.QueryRowContext(ctx, getHyperlaneTransfer, id)
+	var i HyperlaneTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.MessageID,
+		&i.MessageSentTx,
+		&i.TransferStatus,
+		&i.TransferStatusMessage,
+		&i.MaxTxFeeUusdc,
+		&i.NextAttemptAt,
+		&i.AttemptCount,
+		&i.LastErrorClass,
+	)
+	return i, err
+}
+
+const getHyperlaneTransferByMessageID = `-- name: GetHyperlaneTransferByMessageID :one
+SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc, next_attempt_at, attempt_count, last_error_class FROM hyperlane_transfers WHERE source_chain_id = ? AND destination_chain_id = ? AND message_id = ?
+`
+
+type GetHyperlaneTransferByMessageIDParams struct {
+	SourceChainID      string
+	DestinationChainID string
+	MessageID          string
+}
+
+func (q *Queries) GetHyperlaneTransferByMessageID(ctx context.Context, arg GetHyperlaneTransferByMessageIDParams) (HyperlaneTransfer, error) {
+	row := q.db.QueryRowContext(ctx, getHyperlaneTransferByMessageID, arg.SourceChainID, arg.DestinationChainID, arg.MessageID)
+	var i HyperlaneTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.MessageID,
+		&i.MessageSentTx,
+		&i.TransferStatus,
+		&i.TransferStatusMessage,
+		&i.MaxTxFeeUusdc,
+		&i.NextAttemptAt,
+		&i.AttemptCount,
+		&i.LastErrorClass,
+	)
+	return i, err
+}
+
 const getHyperlaneTransferByMessageSentTx = `-- name: GetHyperlaneTransferByMessageSentTx :one
-SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc FROM hyperlane_transfers WHERE message_sent_tx = ? AND source_chain_id = ?
+SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc, next_attempt_at, attempt_count, last_error_class FROM hyperlane_transfers WHERE message_sent_tx = ? AND source_chain_id = ?
 `
 
 type GetHyperlaneTransferByMessageSentTxParams struct {
@@ -71,6 +147,31 @@ func (q *Queries) GetHyperlaneTransferByMessageSentTx(ctx context.Context, arg G
 		&i.TransferStatus,
 		&i.TransferStatusMessage,
 		&i.MaxTxFeeUusdc,
+		&i.NextAttemptAt,
+		&i.AttemptCount,
+		&i.LastErrorClass,
+	)
+	return i, err
+}
+
+const insertHyperlaneDispatchIndexerMetadata = `-- name: InsertHyperlaneDispatchIndexerMetadata :one
+INSERT INTO hyperlane_dispatch_indexer_metadata (chain_id, height_last_seen) VALUES (?, ?) ON CONFLICT (chain_id) DO UPDATE SET height_last_seen = excluded.height_last_seen, updated_at=CURRENT_TIMESTAMP RETURNING id, created_at, updated_at, chain_id, height_last_seen
+`
+
+type InsertHyperlaneDispatchIndexerMetadataParams struct {
+	ChainID        string
+	HeightLastSeen int64
+}
+
+func (q *Queries) InsertHyperlaneDispatchIndexerMetadata(ctx context.Context, arg InsertHyperlaneDispatchIndexerMetadataParams) (HyperlaneDispatchIndexerMetadatum, error) {
+	row := q.db.QueryRowContext(ctx, insertHyperlaneDispatchIndexerMetadata, arg.ChainID, arg.HeightLastSeen)
+	var i HyperlaneDispatchIndexerMetadatum
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ChainID,
+		&i.HeightLastSeen,
 	)
 	return i, err
 }
@@ -83,7 +184,7 @@ INSERT INTO hyperlane_transfers (
     message_sent_tx,
     transfer_status,
     max_tx_fee_uusdc
-) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc
+) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc, next_attempt_at, attempt_count, last_error_class
 `
 
 type InsertHyperlaneTransferParams struct {
@@ -116,6 +217,77 @@ func (q *Queries) InsertHyperlaneTransfer(ctx context.Context, arg InsertHyperla
 		&i.TransferStatus,
 		&i.TransferStatusMessage,
 		&i.MaxTxFeeUusdc,
+		&i.NextAttemptAt,
+		&i.AttemptCount,
+		&i.LastErrorClass,
+	)
+	return i, err
+}
+
+const resetHyperlaneTransferRelayAttempts = `-- name: ResetHyperlaneTransferRelayAttempts :one
+UPDATE hyperlane_transfers
+SET updated_at=CURRENT_TIMESTAMP, attempt_count = 0, next_attempt_at = NULL, last_error_class = NULL
+WHERE id = ?
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc, next_attempt_at, attempt_count, last_error_class
+`
+
+func (q *Queries) ResetHyperlaneTransferRelayAttempts(ctx context.Context, id int64) (HyperlaneTransfer, error) {
+	row := q.db.QueryRowContext(ctx, resetHyperlaneTransferRelayAttempts, id)
+	var i HyperlaneTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.MessageID,
+		&i.MessageSentTx,
+		&i.TransferStatus,
+		&i.TransferStatusMessage,
+		&i.MaxTxFeeUusdc,
+		&i.NextAttemptAt,
+		&i.AttemptCount,
+		&i.LastErrorClass,
+	)
+	return i, err
+}
+
+const setHyperlaneTransferRelayAttempt = `-- name: SetHyperlaneTransferRelayAttempt :one
+UPDATE hyperlane_transfers
+SET updated_at=CURRENT_TIMESTAMP, attempt_count = ?, next_attempt_at = ?, last_error_class = ?
+WHERE id = ?
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc, next_attempt_at, attempt_count, last_error_class
+`
+
+type SetHyperlaneTransferRelayAttemptParams struct {
+	AttemptCount   int64
+	NextAttemptAt  sql.NullTime
+	LastErrorClass sql.NullString
+	ID             int64
+}
+
+func (q *Queries) SetHyperlaneTransferRelayAttempt(ctx context.Context, arg SetHyperlaneTransferRelayAttemptParams) (HyperlaneTransfer, error) {
+	row := q.db.QueryRowContext(ctx, setHyperlaneTransferRelayAttempt,
+		arg.AttemptCount,
+		arg.NextAttemptAt,
+		arg.LastErrorClass,
+		arg.ID,
+	)
+	var i HyperlaneTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.MessageID,
+		&i.MessageSentTx,
+		&i.TransferStatus,
+		&i.TransferStatusMessage,
+		&i.MaxTxFeeUusdc,
+		&i.NextAttemptAt,
+		&i.AttemptCount,
+		&i.LastErrorClass,
 	)
 	return i, err
 }
@@ -124,7 +296,7 @@ const setMessageStatus = `-- name: SetMessageStatus :one
 UPDATE hyperlane_transfers
 SET updated_at=CURRENT_TIMESTAMP, transfer_status = ?, transfer_status_message = ?
 WHERE source_chain_id = ? AND destination_chain_id = ? AND message_id = ?
-    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, message_id, message_sent_tx, transfer_status, transfer_status_message, max_tx_fee_uusdc, next_attempt_at, attempt_count, last_error_class
 `
 
 type SetMessageStatusParams struct {
@@ -155,6 +327,9 @@ func (q *Queries) SetMessageStatus(ctx context.Context, arg SetMessageStatusPara
 		&i.TransferStatus,
 		&i.TransferStatusMessage,
 		&i.MaxTxFeeUusdc,
+		&i.NextAttemptAt,
+		&i.AttemptCount,
+		&i.LastErrorClass,
 	)
 	return i, err
 }
@@ -9,6 +9,47 @@ import (
 	"time"
 )
 
+type CctpTransfer struct {
+	ID                  int64
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	RebalanceTransferID int64
+	SourceChainID       string
+	DestinationChainID  string
+	SourceDomain        int64
+	DestinationDomain   int64
+	BurnTxHash          string
+	Message             sql.NullString
+	MessageHash         sql.NullString
+	Attestation         sql.NullString
+	ReceiveTxHash       sql.NullString
+	ReceiveAttempts     int64
+	Status              string
+	StatusMessage       sql.NullString
+}
+
+type Erc20Allowance struct {
+	ID             int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ChainID        string
+	TokenContract  string
+	Spender        string
+	ApprovedAmount sql.NullString
+	ApproveTxHash  sql.NullString
+	LastUsedAt     time.Time
+	RevokeTxHash   sql.NullString
+	RevokedAt      sql.NullTime
+}
+
+type HyperlaneDispatchIndexerMetadatum struct {
+	ID             int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ChainID        string
+	HeightLastSeen int64
+}
+
 type HyperlaneTransfer struct {
 	ID                    int64
 	CreatedAt             time.Time
@@ -20,6 +61,25 @@ type HyperlaneTransfer struct {
 	TransferStatus        string
 	TransferStatusMessage sql.NullString
 	MaxTxFeeUusdc         sql.NullString
+	NextAttemptAt         sql.NullTime
+	AttemptCount          int64
+	LastErrorClass        sql.NullString
+}
+
+type IbcTransfer struct {
+	ID                  int64
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	RebalanceTransferID int64
+	SourceChainID       string
+	DestinationChainID  string
+	SourceChannel       string
+	TxHash              string
+	TimeoutTimestamp    int64
+	PacketSequence      sql.NullInt64
+	DestinationChannel  sql.NullString
+	Status              string
+	StatusMessage       sql.NullString
 }
 
 type Order struct {
@@ -62,6 +122,7 @@ type OrderSettlement struct {
 	SettlementStatusMessage           sql.NullString
 	HyperlaneTransferID               sql.NullInt64
 	InitiateSettlementTxTime          sql.NullTime
+	RepaymentAddress                  sql.NullString
 }
 
 type RebalanceTransfer struct {
@@ -73,23 +134,43 @@ type RebalanceTransfer struct {
 	DestinationChainID string
 	Amount             string
 	Status             string
+	QuotedCostUusdc    sql.NullString
+	Kind               string
 }
 
-type SubmittedTx struct {
+type RebalanceTransferStep struct {
 	ID                  int64
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
-	OrderID             sql.NullInt64
-	OrderSettlementID   sql.NullInt64
-	HyperlaneTransferID sql.NullInt64
+	RebalanceTransferID int64
+	StepIndex           int64
 	ChainID             string
-	TxHash              string
-	RawTx               string
-	TxType              string
-	TxStatus            string
-	TxStatusMessage     sql.NullString
-	TxCostUusdc         sql.NullString
-	RebalanceTransferID sql.NullInt64
+	Tx                  string
+	TxHash              sql.NullString
+	SubmittedAt         sql.NullTime
+	Status              string
+	StatusMessage       sql.NullString
+	SourceDenom         sql.NullString
+	DestinationDenom    sql.NullString
+	QuotedAmountIn      sql.NullString
+}
+
+type SubmittedTx struct {
+	ID                    int64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	OrderID               sql.NullInt64
+	OrderSettlementID     sql.NullInt64
+	HyperlaneTransferID   sql.NullInt64
+	ChainID               string
+	TxHash                string
+	RawTx                 string
+	TxType                string
+	TxStatus              string
+	TxStatusMessage       sql.NullString
+	TxCostUusdc           sql.NullString
+	RebalanceTransferID   sql.NullInt64
+	ReplacesSubmittedTxID sql.NullInt64
 }
 
 type TransferMonitorMetadatum struct {
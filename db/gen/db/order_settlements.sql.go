@@ -8,13 +8,14 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 const clearInitiateSettlement = `-- name: ClearInitiateSettlement :many
 UPDATE order_settlements
-SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx = null, hyperlane_transfer_id = null, initiate_settlement_tx_time = null, settlement_status = ?
+SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx = null, hyperlane_transfer_id = null, initiate_settlement_tx_time = null, repayment_address = null, settlement_status = ?
 WHERE destination_chain_id=? AND initiate_settlement_tx=?
-    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address
 `
 
 type ClearInitiateSettlementParams struct {
@@ -48,6 +49,7 @@ func (q *Queries) ClearInitiateSettlement(ctx context.Context, arg ClearInitiate
 			&i.SettlementStatusMessage,
 			&i.HyperlaneTransferID,
 			&i.InitiateSettlementTxTime,
+			&i.RepaymentAddress,
 		); err != nil {
 			return nil, err
 		}
@@ -63,7 +65,7 @@ func (q *Queries) ClearInitiateSettlement(ctx context.Context, arg ClearInitiate
 }
 
 const getAllOrderSettlementsWithSettlementStatus = `-- name: GetAllOrderSettlementsWithSettlementStatus :many
-SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time FROM order_settlements WHERE settlement_status = ?
+SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address FROM order_settlements WHERE settlement_status = ?
 `
 
 func (q *Queries) GetAllOrderSettlementsWithSettlementStatus(ctx context.Context, settlementStatus string) ([]OrderSettlement, error) {
@@ -91,6 +93,7 @@ func (q *Queries) GetAllOrderSettlementsWithSettlementStatus(ctx context.Context
 			&i.SettlementStatusMessage,
 			&i.HyperlaneTransferID,
 			&i.InitiateSettlementTxTime,
+			&i.RepaymentAddress,
 		); err != nil {
 			return nil, err
 		}
@@ -106,7 +109,7 @@ func (q *Queries) GetAllOrderSettlementsWithSettlementStatus(ctx context.Context
 }
 
 const getOrderSettlement = `-- name: GetOrderSettlement :one
-SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time FROM order_settlements WHERE source_chain_id = ? AND source_chain_gateway_contract_address = ? AND order_id = ?
+SELECT id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address FROM order_settlements WHERE source_chain_id = ? AND source_chain_gateway_contract_address = ? AND order_id = ?
 `
 
 type GetOrderSettlementParams struct {
@@ -134,10 +137,49 @@ func (q *Queries) GetOrderSettlement(ctx context.Context, arg GetOrderSettlement
 		&i.SettlementStatusMessage,
 		&i.HyperlaneTransferID,
 		&i.InitiateSettlementTxTime,
+		&i.RepaymentAddress,
 	)
 	return i, err
 }
 
+const getSettlementInflowsToChainSince = `-- name: GetSettlementInflowsToChainSince :many
+SELECT updated_at, amount FROM order_settlements WHERE source_chain_id = ? AND settlement_status = ? AND updated_at >= ?
+`
+
+type GetSettlementInflowsToChainSinceParams struct {
+	SourceChainID    string
+	SettlementStatus string
+	UpdatedAt        time.Time
+}
+
+type GetSettlementInflowsToChainSinceRow struct {
+	UpdatedAt time.Time
+	Amount    string
+}
+
+func (q *Queries) GetSettlementInflowsToChainSince(ctx context.Context, arg GetSettlementInflowsToChainSinceParams) ([]GetSettlementInflowsToChainSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSettlementInflowsToChainSince, arg.SourceChainID, arg.SettlementStatus, arg.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSettlementInflowsToChainSinceRow
+	for rows.Next() {
+		var i GetSettlementInflowsToChainSinceRow
+		if err := rows.Scan(&i.UpdatedAt, &i.Amount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertOrderSettlement = `-- name: InsertOrderSettlement :one
 INSERT INTO order_settlements (
     source_chain_id,
@@ -147,7 +189,7 @@ INSERT INTO order_settlements (
     profit,
     order_id,
     settlement_status
-) VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time
+) VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address
 `
 
 type InsertOrderSettlementParams struct {
@@ -187,6 +229,52 @@ func (q *Queries) InsertOrderSettlement(ctx context.Context, arg InsertOrderSett
 		&i.SettlementStatusMessage,
 		&i.HyperlaneTransferID,
 		&i.InitiateSettlementTxTime,
+		&i.RepaymentAddress,
+	)
+	return i, err
+}
+
+const resetOrderSettlement = `-- name: ResetOrderSettlement :one
+UPDATE order_settlements
+SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx = null, hyperlane_transfer_id = null, initiate_settlement_tx_time = null, repayment_address = null, settlement_status = ?, settlement_status_message = ?
+WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_address = ?
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address
+`
+
+type ResetOrderSettlementParams struct {
+	SettlementStatus                  string
+	SettlementStatusMessage           sql.NullString
+	SourceChainID                     string
+	OrderID                           string
+	SourceChainGatewayContractAddress string
+}
+
+func (q *Queries) ResetOrderSettlement(ctx context.Context, arg ResetOrderSettlementParams) (OrderSettlement, error) {
+	row := q.db.QueryRowContext(ctx, resetOrderSettlement,
+		arg.SettlementStatus,
+		arg.SettlementStatusMessage,
+		arg.SourceChainID,
+		arg.OrderID,
+		arg.SourceChainGatewayContractAddress,
+	)
+	var i OrderSettlement
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceChainGatewayContractAddress,
+		&i.Amount,
+		&i.Profit,
+		&i.OrderID,
+		&i.InitiateSettlementTx,
+		&i.CompleteSettlementTx,
+		&i.SettlementStatus,
+		&i.SettlementStatusMessage,
+		&i.HyperlaneTransferID,
+		&i.InitiateSettlementTxTime,
+		&i.RepaymentAddress,
 	)
 	return i, err
 }
@@ -195,7 +283,7 @@ const setCompleteSettlementTx = `-- name: SetCompleteSettlementTx :one
 UPDATE order_settlements
 SET updated_at=CURRENT_TIMESTAMP, complete_settlement_tx = ?
 WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_address = ?
-    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address
 `
 
 type SetCompleteSettlementTxParams struct {
@@ -229,6 +317,7 @@ func (q *Queries) SetCompleteSettlementTx(ctx context.Context, arg SetCompleteSe
 		&i.SettlementStatusMessage,
 		&i.HyperlaneTransferID,
 		&i.InitiateSettlementTxTime,
+		&i.RepaymentAddress,
 	)
 	return i, err
 }
@@ -237,7 +326,7 @@ const setHyperlaneTransferID = `-- name: SetHyperlaneTransferID :one
 UPDATE order_settlements
 SET updated_at=CURRENT_TIMESTAMP, hyperlane_transfer_id = ?
 WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_address = ?
-    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address
 `
 
 type SetHyperlaneTransferIDParams struct {
@@ -271,19 +360,21 @@ func (q *Queries) SetHyperlaneTransferID(ctx context.Context, arg SetHyperlaneTr
 		&i.SettlementStatusMessage,
 		&i.HyperlaneTransferID,
 		&i.InitiateSettlementTxTime,
+		&i.RepaymentAddress,
 	)
 	return i, err
 }
 
 const setInitiateSettlementTx = `-- name: SetInitiateSettlementTx :one
 UPDATE order_settlements
-SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx_time=CURRENT_TIMESTAMP, initiate_settlement_tx = ?
+SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx_time=CURRENT_TIMESTAMP, initiate_settlement_tx = ?, repayment_address = ?
 WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_address = ?
-    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address
 `
 
 type SetInitiateSettlementTxParams struct {
 	InitiateSettlementTx              sql.NullString
+	RepaymentAddress                  sql.NullString
 	SourceChainID                     string
 	OrderID                           string
 	SourceChainGatewayContractAddress string
@@ -292,6 +383,7 @@ type SetInitiateSettlementTxParams struct {
 func (q *Queries) SetInitiateSettlementTx(ctx context.Context, arg SetInitiateSettlementTxParams) (OrderSettlement, error) {
 	row := q.db.QueryRowContext(ctx, setInitiateSettlementTx,
 		arg.InitiateSettlementTx,
+		arg.RepaymentAddress,
 		arg.SourceChainID,
 		arg.OrderID,
 		arg.SourceChainGatewayContractAddress,
@@ -313,6 +405,7 @@ func (q *Queries) SetInitiateSettlementTx(ctx context.Context, arg SetInitiateSe
 		&i.SettlementStatusMessage,
 		&i.HyperlaneTransferID,
 		&i.InitiateSettlementTxTime,
+		&i.RepaymentAddress,
 	)
 	return i, err
 }
@@ -321,7 +414,7 @@ const setSettlementStatus = `-- name: SetSettlementStatus :one
 UPDATE order_settlements
 SET updated_at=CURRENT_TIMESTAMP, settlement_status = ?, settlement_status_message = ?
 WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_address = ?
-    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time
+    RETURNING id, created_at, updated_at, source_chain_id, destination_chain_id, source_chain_gateway_contract_address, amount, profit, order_id, initiate_settlement_tx, complete_settlement_tx, settlement_status, settlement_status_message, hyperlane_transfer_id, initiate_settlement_tx_time, repayment_address
 `
 
 type SetSettlementStatusParams struct {
@@ -357,6 +450,7 @@ func (q *Queries) SetSettlementStatus(ctx context.Context, arg SetSettlementStat
 		&i.SettlementStatusMessage,
 		&i.HyperlaneTransferID,
 		&i.InitiateSettlementTxTime,
+		&i.RepaymentAddress,
 	)
 	return i, err
 }
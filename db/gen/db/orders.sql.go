@@ -93,6 +93,43 @@ func (q *Queries) GetOrderByOrderID(ctx context.Context, orderID string) (Order,
 	return i, err
 }
 
+const getOrderOutflowsToChainSince = `-- name: GetOrderOutflowsToChainSince :many
+SELECT created_at, amount_out FROM orders WHERE destination_chain_id = ? AND created_at >= ?
+`
+
+type GetOrderOutflowsToChainSinceParams struct {
+	DestinationChainID string
+	CreatedAt          time.Time
+}
+
+type GetOrderOutflowsToChainSinceRow struct {
+	CreatedAt time.Time
+	AmountOut string
+}
+
+func (q *Queries) GetOrderOutflowsToChainSince(ctx context.Context, arg GetOrderOutflowsToChainSinceParams) ([]GetOrderOutflowsToChainSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOrderOutflowsToChainSince, arg.DestinationChainID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOrderOutflowsToChainSinceRow
+	for rows.Next() {
+		var i GetOrderOutflowsToChainSinceRow
+		if err := rows.Scan(&i.CreatedAt, &i.AmountOut); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertOrder = `-- name: InsertOrder :one
 INSERT INTO orders (
     source_chain_id,
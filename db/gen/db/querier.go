@@ -7,6 +7,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
@@ -16,27 +17,64 @@ type Querier interface {
 	GetAllOrdersWithOrderStatus(ctx context.Context, orderStatus string) ([]Order, error)
 	GetAllPendingRebalanceTransfers(ctx context.Context) ([]GetAllPendingRebalanceTransfersRow, error)
 	GetAllSubmittedTxs(ctx context.Context) ([]SubmittedTx, error)
+	GetERC20Allowances(ctx context.Context) ([]Erc20Allowance, error)
+	GetHyperlaneDispatchIndexerMetadata(ctx context.Context, chainID string) (HyperlaneDispatchIndexerMetadatum, error)
+	GetHyperlaneTransfer(ctx context.Context, id int64) (HyperlaneTransfer, error)
+	GetHyperlaneTransferByMessageID(ctx context.Context, arg GetHyperlaneTransferByMessageIDParams) (HyperlaneTransfer, error)
 	GetHyperlaneTransferByMessageSentTx(ctx context.Context, arg GetHyperlaneTransferByMessageSentTxParams) (HyperlaneTransfer, error)
+	GetIdleERC20Allowances(ctx context.Context, lastUsedAt time.Time) ([]Erc20Allowance, error)
 	GetOrderByOrderID(ctx context.Context, orderID string) (Order, error)
+	GetOrderOutflowsToChainSince(ctx context.Context, arg GetOrderOutflowsToChainSinceParams) ([]GetOrderOutflowsToChainSinceRow, error)
 	GetOrderSettlement(ctx context.Context, arg GetOrderSettlementParams) (OrderSettlement, error)
+	GetPendingCCTPTransfers(ctx context.Context) ([]CctpTransfer, error)
+	GetPendingIBCTransfers(ctx context.Context) ([]IbcTransfer, error)
 	GetPendingRebalanceTransfersToChain(ctx context.Context, destinationChainID string) ([]GetPendingRebalanceTransfersToChainRow, error)
+	GetPendingRebalanceTransfersWithSteps(ctx context.Context) ([]RebalanceTransfer, error)
+	GetRebalanceTransferSteps(ctx context.Context, rebalanceTransferID int64) ([]RebalanceTransferStep, error)
+	GetRebalanceTransfersSince(ctx context.Context, createdAt time.Time) ([]RebalanceTransfer, error)
+	GetRebalanceTransfersWithStatus(ctx context.Context, status string) ([]RebalanceTransfer, error)
+	GetSettlementInflowsToChainSince(ctx context.Context, arg GetSettlementInflowsToChainSinceParams) ([]GetSettlementInflowsToChainSinceRow, error)
+	GetSubmittedTx(ctx context.Context, id int64) (SubmittedTx, error)
 	GetSubmittedTxsByHyperlaneTransferId(ctx context.Context, hyperlaneTransferID sql.NullInt64) ([]SubmittedTx, error)
 	GetSubmittedTxsByOrderIdAndType(ctx context.Context, arg GetSubmittedTxsByOrderIdAndTypeParams) ([]SubmittedTx, error)
 	GetSubmittedTxsByOrderStatusAndType(ctx context.Context, arg GetSubmittedTxsByOrderStatusAndTypeParams) ([]SubmittedTx, error)
+	GetSubmittedTxsReplacing(ctx context.Context, replacesSubmittedTxID sql.NullInt64) ([]SubmittedTx, error)
 	GetSubmittedTxsWithStatus(ctx context.Context, txStatus string) ([]SubmittedTx, error)
 	GetTransferMonitorMetadata(ctx context.Context, chainID string) (TransferMonitorMetadatum, error)
+	InsertCCTPTransfer(ctx context.Context, arg InsertCCTPTransferParams) (CctpTransfer, error)
+	InsertGasTopUpTransfer(ctx context.Context, arg InsertGasTopUpTransferParams) (int64, error)
+	InsertHyperlaneDispatchIndexerMetadata(ctx context.Context, arg InsertHyperlaneDispatchIndexerMetadataParams) (HyperlaneDispatchIndexerMetadatum, error)
 	InsertHyperlaneTransfer(ctx context.Context, arg InsertHyperlaneTransferParams) (HyperlaneTransfer, error)
+	InsertIBCTransfer(ctx context.Context, arg InsertIBCTransferParams) (IbcTransfer, error)
 	InsertOrder(ctx context.Context, arg InsertOrderParams) (Order, error)
 	InsertOrderSettlement(ctx context.Context, arg InsertOrderSettlementParams) (OrderSettlement, error)
 	InsertRebalanceTransfer(ctx context.Context, arg InsertRebalanceTransferParams) (int64, error)
+	InsertRebalanceTransferStep(ctx context.Context, arg InsertRebalanceTransferStepParams) (RebalanceTransferStep, error)
 	InsertSubmittedTx(ctx context.Context, arg InsertSubmittedTxParams) (SubmittedTx, error)
 	InsertTransferMonitorMetadata(ctx context.Context, arg InsertTransferMonitorMetadataParams) (TransferMonitorMetadatum, error)
+	ResetHyperlaneTransferRelayAttempts(ctx context.Context, id int64) (HyperlaneTransfer, error)
+	ResetOrderSettlement(ctx context.Context, arg ResetOrderSettlementParams) (OrderSettlement, error)
+	ResetRebalanceTransferStep(ctx context.Context, id int64) (RebalanceTransferStep, error)
+	ResetRebalanceTransferStepSubmitting(ctx context.Context, arg ResetRebalanceTransferStepSubmittingParams) (RebalanceTransferStep, error)
+	SetCCTPTransferAttestation(ctx context.Context, arg SetCCTPTransferAttestationParams) (CctpTransfer, error)
+	SetCCTPTransferMessage(ctx context.Context, arg SetCCTPTransferMessageParams) (CctpTransfer, error)
+	SetCCTPTransferReceiveSubmitted(ctx context.Context, arg SetCCTPTransferReceiveSubmittedParams) (CctpTransfer, error)
+	SetCCTPTransferStatus(ctx context.Context, arg SetCCTPTransferStatusParams) (CctpTransfer, error)
 	SetCompleteSettlementTx(ctx context.Context, arg SetCompleteSettlementTxParams) (OrderSettlement, error)
+	SetERC20AllowanceApproved(ctx context.Context, arg SetERC20AllowanceApprovedParams) (Erc20Allowance, error)
+	SetERC20AllowanceRevoked(ctx context.Context, arg SetERC20AllowanceRevokedParams) (Erc20Allowance, error)
+	SetERC20AllowanceUsed(ctx context.Context, arg SetERC20AllowanceUsedParams) error
 	SetFillTx(ctx context.Context, arg SetFillTxParams) (Order, error)
 	SetHyperlaneTransferID(ctx context.Context, arg SetHyperlaneTransferIDParams) (OrderSettlement, error)
+	SetHyperlaneTransferRelayAttempt(ctx context.Context, arg SetHyperlaneTransferRelayAttemptParams) (HyperlaneTransfer, error)
+	SetIBCTransferPacketSent(ctx context.Context, arg SetIBCTransferPacketSentParams) (IbcTransfer, error)
+	SetIBCTransferStatus(ctx context.Context, arg SetIBCTransferStatusParams) (IbcTransfer, error)
 	SetInitiateSettlementTx(ctx context.Context, arg SetInitiateSettlementTxParams) (OrderSettlement, error)
 	SetMessageStatus(ctx context.Context, arg SetMessageStatusParams) (HyperlaneTransfer, error)
 	SetOrderStatus(ctx context.Context, arg SetOrderStatusParams) (Order, error)
+	SetRebalanceTransferStepStatus(ctx context.Context, arg SetRebalanceTransferStepStatusParams) (RebalanceTransferStep, error)
+	SetRebalanceTransferStepSubmitted(ctx context.Context, arg SetRebalanceTransferStepSubmittedParams) (RebalanceTransferStep, error)
+	SetRebalanceTransferStepSubmitting(ctx context.Context, arg SetRebalanceTransferStepSubmittingParams) (RebalanceTransferStep, error)
 	SetRefundTx(ctx context.Context, arg SetRefundTxParams) (Order, error)
 	SetSettlementStatus(ctx context.Context, arg SetSettlementStatusParams) (OrderSettlement, error)
 	SetSubmittedTxStatus(ctx context.Context, arg SetSubmittedTxStatusParams) (SubmittedTx, error)
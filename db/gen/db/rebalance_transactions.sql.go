@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
@@ -17,7 +18,8 @@ SELECT
     source_chain_id,
     destination_chain_id,
     amount,
-    created_at
+    created_at,
+    kind
 FROM rebalance_transfers 
 WHERE status = 'PENDING'
 `
@@ -29,6 +31,7 @@ type GetAllPendingRebalanceTransfersRow struct {
 	DestinationChainID string
 	Amount             string
 	CreatedAt          time.Time
+	Kind               string
 }
 
 func (q *Queries) GetAllPendingRebalanceTransfers(ctx context.Context) ([]GetAllPendingRebalanceTransfersRow, error) {
@@ -47,6 +50,94 @@ func (q *Queries) GetAllPendingRebalanceTransfers(ctx context.Context) ([]GetAll
 			&i.DestinationChainID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Kind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingCCTPTransfers = `-- name: GetPendingCCTPTransfers :many
+SELECT id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_domain, destination_domain, burn_tx_hash, message, message_hash, attestation, receive_tx_hash, receive_attempts, status, status_message FROM cctp_transfers
+WHERE status NOT IN ('RECEIVED', 'FAILED')
+`
+
+func (q *Queries) GetPendingCCTPTransfers(ctx context.Context) ([]CctpTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingCCTPTransfers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CctpTransfer
+	for rows.Next() {
+		var i CctpTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RebalanceTransferID,
+			&i.SourceChainID,
+			&i.DestinationChainID,
+			&i.SourceDomain,
+			&i.DestinationDomain,
+			&i.BurnTxHash,
+			&i.Message,
+			&i.MessageHash,
+			&i.Attestation,
+			&i.ReceiveTxHash,
+			&i.ReceiveAttempts,
+			&i.Status,
+			&i.StatusMessage,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingIBCTransfers = `-- name: GetPendingIBCTransfers :many
+SELECT id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_channel, tx_hash, timeout_timestamp, packet_sequence, destination_channel, status, status_message FROM ibc_transfers
+WHERE status NOT IN ('ACKNOWLEDGED', 'REFUNDED', 'FAILED')
+`
+
+func (q *Queries) GetPendingIBCTransfers(ctx context.Context) ([]IbcTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingIBCTransfers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IbcTransfer
+	for rows.Next() {
+		var i IbcTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RebalanceTransferID,
+			&i.SourceChainID,
+			&i.DestinationChainID,
+			&i.SourceChannel,
+			&i.TxHash,
+			&i.TimeoutTimestamp,
+			&i.PacketSequence,
+			&i.DestinationChannel,
+			&i.Status,
+			&i.StatusMessage,
 		); err != nil {
 			return nil, err
 		}
@@ -69,7 +160,7 @@ SELECT
     destination_chain_id,
     amount
 FROM rebalance_transfers
-WHERE destination_chain_id = ? AND status = 'PENDING'
+WHERE destination_chain_id = ? AND status = 'PENDING' AND kind = 'REBALANCE'
 `
 
 type GetPendingRebalanceTransfersToChainRow struct {
@@ -109,13 +200,304 @@ func (q *Queries) GetPendingRebalanceTransfersToChain(ctx context.Context, desti
 	return items, nil
 }
 
+const getPendingRebalanceTransfersWithSteps = `-- name: GetPendingRebalanceTransfersWithSteps :many
+SELECT id, created_at, updated_at, tx_hash, source_chain_id, destination_chain_id, amount, status, quoted_cost_uusdc, kind FROM rebalance_transfers
+WHERE status = 'PENDING' AND id IN (SELECT rebalance_transfer_id FROM rebalance_transfer_steps)
+`
+
+func (q *Queries) GetPendingRebalanceTransfersWithSteps(ctx context.Context) ([]RebalanceTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingRebalanceTransfersWithSteps)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RebalanceTransfer
+	for rows.Next() {
+		var i RebalanceTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TxHash,
+			&i.SourceChainID,
+			&i.DestinationChainID,
+			&i.Amount,
+			&i.Status,
+			&i.QuotedCostUusdc,
+			&i.Kind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRebalanceTransferSteps = `-- name: GetRebalanceTransferSteps :many
+SELECT id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message, source_denom, destination_denom, quoted_amount_in FROM rebalance_transfer_steps
+WHERE rebalance_transfer_id = ?
+ORDER BY step_index
+`
+
+func (q *Queries) GetRebalanceTransferSteps(ctx context.Context, rebalanceTransferID int64) ([]RebalanceTransferStep, error) {
+	rows, err := q.db.QueryContext(ctx, getRebalanceTransferSteps, rebalanceTransferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RebalanceTransferStep
+	for rows.Next() {
+		var i RebalanceTransferStep
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RebalanceTransferID,
+			&i.StepIndex,
+			&i.ChainID,
+			&i.Tx,
+			&i.TxHash,
+			&i.SubmittedAt,
+			&i.Status,
+			&i.StatusMessage,
+			&i.SourceDenom,
+			&i.DestinationDenom,
+			&i.QuotedAmountIn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRebalanceTransfersSince = `-- name: GetRebalanceTransfersSince :many
+SELECT id, created_at, updated_at, tx_hash, source_chain_id, destination_chain_id, amount, status, quoted_cost_uusdc, kind FROM rebalance_transfers WHERE created_at >= ? ORDER BY created_at
+`
+
+func (q *Queries) GetRebalanceTransfersSince(ctx context.Context, createdAt time.Time) ([]RebalanceTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, getRebalanceTransfersSince, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RebalanceTransfer
+	for rows.Next() {
+		var i RebalanceTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TxHash,
+			&i.SourceChainID,
+			&i.DestinationChainID,
+			&i.Amount,
+			&i.Status,
+			&i.QuotedCostUusdc,
+			&i.Kind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRebalanceTransfersWithStatus = `-- name: GetRebalanceTransfersWithStatus :many
+SELECT id, created_at, updated_at, tx_hash, source_chain_id, destination_chain_id, amount, status, quoted_cost_uusdc, kind FROM rebalance_transfers WHERE status = ?
+`
+
+func (q *Queries) GetRebalanceTransfersWithStatus(ctx context.Context, status string) ([]RebalanceTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, getRebalanceTransfersWithStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RebalanceTransfer
+	for rows.Next() {
+		var i RebalanceTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TxHash,
+			&i.SourceChainID,
+			&i.DestinationChainID,
+			&i.Amount,
+			&i.Status,
+			&i.QuotedCostUusdc,
+			&i.Kind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertCCTPTransfer = `-- name: InsertCCTPTransfer :one
+INSERT INTO cctp_transfers (
+    rebalance_transfer_id,
+    source_chain_id,
+    destination_chain_id,
+    source_domain,
+    destination_domain,
+    burn_tx_hash
+) VALUES (?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_domain, destination_domain, burn_tx_hash, message, message_hash, attestation, receive_tx_hash, receive_attempts, status, status_message
+`
+
+type InsertCCTPTransferParams struct {
+	RebalanceTransferID int64
+	SourceChainID       string
+	DestinationChainID  string
+	SourceDomain        int64
+	DestinationDomain   int64
+	BurnTxHash          string
+}
+
+func (q *Queries) InsertCCTPTransfer(ctx context.Context, arg InsertCCTPTransferParams) (CctpTransfer, error) {
+	row := q.db.QueryRowContext(ctx, insertCCTPTransfer,
+		arg.RebalanceTransferID,
+		arg.SourceChainID,
+		arg.DestinationChainID,
+		arg.SourceDomain,
+		arg.DestinationDomain,
+		arg.BurnTxHash,
+	)
+	var i CctpTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceDomain,
+		&i.DestinationDomain,
+		&i.BurnTxHash,
+		&i.Message,
+		&i.MessageHash,
+		&i.Attestation,
+		&i.ReceiveTxHash,
+		&i.ReceiveAttempts,
+		&i.Status,
+		&i.StatusMessage,
+	)
+	return i, err
+}
+
+const insertGasTopUpTransfer = `-- name: InsertGasTopUpTransfer :one
+INSERT INTO rebalance_transfers (
+    tx_hash,
+    source_chain_id,
+    destination_chain_id,
+    amount,
+    quoted_cost_uusdc,
+    kind
+) VALUES (?, ?, ?, ?, ?, 'GAS_TOP_UP') RETURNING id
+`
+
+type InsertGasTopUpTransferParams struct {
+	TxHash             string
+	SourceChainID      string
+	DestinationChainID string
+	Amount             string
+	QuotedCostUusdc    sql.NullString
+}
+
+func (q *Queries) InsertGasTopUpTransfer(ctx context.Context, arg InsertGasTopUpTransferParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertGasTopUpTransfer,
+		arg.TxHash,
+		arg.SourceChainID,
+		arg.DestinationChainID,
+		arg.Amount,
+		arg.QuotedCostUusdc,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertIBCTransfer = `-- name: InsertIBCTransfer :one
+INSERT INTO ibc_transfers (
+    rebalance_transfer_id,
+    source_chain_id,
+    destination_chain_id,
+    source_channel,
+    tx_hash,
+    timeout_timestamp
+) VALUES (?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_channel, tx_hash, timeout_timestamp, packet_sequence, destination_channel, status, status_message
+`
+
+type InsertIBCTransferParams struct {
+	RebalanceTransferID int64
+	SourceChainID       string
+	DestinationChainID  string
+	SourceChannel       string
+	TxHash              string
+	TimeoutTimestamp    int64
+}
+
+func (q *Queries) InsertIBCTransfer(ctx context.Context, arg InsertIBCTransferParams) (IbcTransfer, error) {
+	row := q.db.QueryRowContext(ctx, insertIBCTransfer,
+		arg.RebalanceTransferID,
+		arg.SourceChainID,
+		arg.DestinationChainID,
+		arg.SourceChannel,
+		arg.TxHash,
+		arg.TimeoutTimestamp,
+	)
+	var i IbcTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceChannel,
+		&i.TxHash,
+		&i.TimeoutTimestamp,
+		&i.PacketSequence,
+		&i.DestinationChannel,
+		&i.Status,
+		&i.StatusMessage,
+	)
+	return i, err
+}
+
 const insertRebalanceTransfer = `-- name: InsertRebalanceTransfer :one
 INSERT INTO rebalance_transfers (
     tx_hash,
     source_chain_id,
     destination_chain_id,
-    amount
-) VALUES (?, ?, ?, ?) RETURNING id
+    amount,
+    quoted_cost_uusdc
+) VALUES (?, ?, ?, ?, ?) RETURNING id
 `
 
 type InsertRebalanceTransferParams struct {
@@ -123,6 +505,7 @@ type InsertRebalanceTransferParams struct {
 	SourceChainID      string
 	DestinationChainID string
 	Amount             string
+	QuotedCostUusdc    sql.NullString
 }
 
 func (q *Queries) InsertRebalanceTransfer(ctx context.Context, arg InsertRebalanceTransferParams) (int64, error) {
@@ -131,12 +514,454 @@ func (q *Queries) InsertRebalanceTransfer(ctx context.Context, arg InsertRebalan
 		arg.SourceChainID,
 		arg.DestinationChainID,
 		arg.Amount,
+		arg.QuotedCostUusdc,
 	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
 }
 
+const insertRebalanceTransferStep = `-- name: InsertRebalanceTransferStep :one
+INSERT INTO rebalance_transfer_steps (
+    rebalance_transfer_id,
+    step_index,
+    chain_id,
+    tx,
+    tx_hash,
+    submitted_at,
+    status,
+    source_denom,
+    destination_denom,
+    quoted_amount_in
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message, source_denom, destination_denom, quoted_amount_in
+`
+
+type InsertRebalanceTransferStepParams struct {
+	RebalanceTransferID int64
+	StepIndex           int64
+	ChainID             string
+	Tx                  string
+	TxHash              sql.NullString
+	SubmittedAt         sql.NullTime
+	Status              string
+	SourceDenom         sql.NullString
+	DestinationDenom    sql.NullString
+	QuotedAmountIn      sql.NullString
+}
+
+func (q *Queries) InsertRebalanceTransferStep(ctx context.Context, arg InsertRebalanceTransferStepParams) (RebalanceTransferStep, error) {
+	row := q.db.QueryRowContext(ctx, insertRebalanceTransferStep,
+		arg.RebalanceTransferID,
+		arg.StepIndex,
+		arg.ChainID,
+		arg.Tx,
+		arg.TxHash,
+		arg.SubmittedAt,
+		arg.Status,
+		arg.SourceDenom,
+		arg.DestinationDenom,
+		arg.QuotedAmountIn,
+	)
+	var i RebalanceTransferStep
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.StepIndex,
+		&i.ChainID,
+		&i.Tx,
+		&i.TxHash,
+		&i.SubmittedAt,
+		&i.Status,
+		&i.StatusMessage,
+		&i.SourceDenom,
+		&i.DestinationDenom,
+		&i.QuotedAmountIn,
+	)
+	return i, err
+}
+
+const resetRebalanceTransferStep = `-- name: ResetRebalanceTransferStep :one
+UPDATE rebalance_transfer_steps
+SET updated_at=CURRENT_TIMESTAMP, status='PENDING', tx_hash=NULL, submitted_at=NULL, status_message=NULL
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message, source_denom, destination_denom, quoted_amount_in
+`
+
+func (q *Queries) ResetRebalanceTransferStep(ctx context.Context, id int64) (RebalanceTransferStep, error) {
+	row := q.db.QueryRowContext(ctx, resetRebalanceTransferStep, id)
+	var i RebalanceTransferStep
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.StepIndex,
+		&i.ChainID,
+		&i.Tx,
+		&i.TxHash,
+		&i.SubmittedAt,
+		&i.Status,
+		&i.StatusMessage,
+		&i.SourceDenom,
+		&i.DestinationDenom,
+		&i.QuotedAmountIn,
+	)
+	return i, err
+}
+
+const resetRebalanceTransferStepSubmitting = `-- name: ResetRebalanceTransferStepSubmitting :one
+UPDATE rebalance_transfer_steps
+SET status='PENDING', status_message = ?
+WHERE id = ? AND status = 'SUBMITTING'
+RETURNING id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message, source_denom, destination_denom, quoted_amount_in
+`
+
+type ResetRebalanceTransferStepSubmittingParams struct {
+	StatusMessage sql.NullString
+	ID            int64
+}
+
+func (q *Queries) ResetRebalanceTransferStepSubmitting(ctx context.Context, arg ResetRebalanceTransferStepSubmittingParams) (RebalanceTransferStep, error) {
+	row := q.db.QueryRowContext(ctx, resetRebalanceTransferStepSubmitting, arg.StatusMessage, arg.ID)
+	var i RebalanceTransferStep
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.StepIndex,
+		&i.ChainID,
+		&i.Tx,
+		&i.TxHash,
+		&i.SubmittedAt,
+		&i.Status,
+		&i.StatusMessage,
+		&i.SourceDenom,
+		&i.DestinationDenom,
+		&i.QuotedAmountIn,
+	)
+	return i, err
+}
+
+const setCCTPTransferAttestation = `-- name: SetCCTPTransferAttestation :one
+UPDATE cctp_transfers
+SET updated_at=CURRENT_TIMESTAMP, attestation = ?, status='ATTESTED', status_message=NULL
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_domain, destination_domain, burn_tx_hash, message, message_hash, attestation, receive_tx_hash, receive_attempts, status, status_message
+`
+
+type SetCCTPTransferAttestationParams struct {
+	Attestation sql.NullString
+	ID          int64
+}
+
+func (q *Queries) SetCCTPTransferAttestation(ctx context.Context, arg SetCCTPTransferAttestationParams) (CctpTransfer, error) {
+	row := q.db.QueryRowContext(ctx, setCCTPTransferAttestation, arg.Attestation, arg.ID)
+	var i CctpTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceDomain,
+		&i.DestinationDomain,
+		&i.BurnTxHash,
+		&i.Message,
+		&i.MessageHash,
+		&i.Attestation,
+		&i.ReceiveTxHash,
+		&i.ReceiveAttempts,
+		&i.Status,
+		&i.StatusMessage,
+	)
+	return i, err
+}
+
+const setCCTPTransferMessage = `-- name: SetCCTPTransferMessage :one
+UPDATE cctp_transfers
+SET updated_at=CURRENT_TIMESTAMP, message = ?, message_hash = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_domain, destination_domain, burn_tx_hash, message, message_hash, attestation, receive_tx_hash, receive_attempts, status, status_message
+`
+
+type SetCCTPTransferMessageParams struct {
+	Message     sql.NullString
+	MessageHash sql.NullString
+	ID          int64
+}
+
+func (q *Queries) SetCCTPTransferMessage(ctx context.Context, arg SetCCTPTransferMessageParams) (CctpTransfer, error) {
+	row := q.db.QueryRowContext(ctx, setCCTPTransferMessage, arg.Message, arg.MessageHash, arg.ID)
+	var i CctpTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceDomain,
+		&i.DestinationDomain,
+		&i.BurnTxHash,
+		&i.Message,
+		&i.MessageHash,
+		&i.Attestation,
+		&i.ReceiveTxHash,
+		&i.ReceiveAttempts,
+		&i.Status,
+		&i.StatusMessage,
+	)
+	return i, err
+}
+
+const setCCTPTransferReceiveSubmitted = `-- name: SetCCTPTransferReceiveSubmitted :one
+UPDATE cctp_transfers
+SET updated_at=CURRENT_TIMESTAMP, receive_tx_hash = ?, receive_attempts = receive_attempts + 1, status='RECEIVE_SUBMITTED'
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_domain, destination_domain, burn_tx_hash, message, message_hash, attestation, receive_tx_hash, receive_attempts, status, status_message
+`
+
+type SetCCTPTransferReceiveSubmittedParams struct {
+	ReceiveTxHash sql.NullString
+	ID            int64
+}
+
+func (q *Queries) SetCCTPTransferReceiveSubmitted(ctx context.Context, arg SetCCTPTransferReceiveSubmittedParams) (CctpTransfer, error) {
+	row := q.db.QueryRowContext(ctx, setCCTPTransferReceiveSubmitted, arg.ReceiveTxHash, arg.ID)
+	var i CctpTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceDomain,
+		&i.DestinationDomain,
+		&i.BurnTxHash,
+		&i.Message,
+		&i.MessageHash,
+		&i.Attestation,
+		&i.ReceiveTxHash,
+		&i.ReceiveAttempts,
+		&i.Status,
+		&i.StatusMessage,
+	)
+	return i, err
+}
+
+const setCCTPTransferStatus = `-- name: SetCCTPTransferStatus :one
+UPDATE cctp_transfers
+SET updated_at=CURRENT_TIMESTAMP, status = ?, status_message = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_domain, destination_domain, burn_tx_hash, message, message_hash, attestation, receive_tx_hash, receive_attempts, status, status_message
+`
+
+type SetCCTPTransferStatusParams struct {
+	Status        string
+	StatusMessage sql.NullString
+	ID            int64
+}
+
+func (q *Queries) SetCCTPTransferStatus(ctx context.Context, arg SetCCTPTransferStatusParams) (CctpTransfer, error) {
+	row := q.db.QueryRowContext(ctx, setCCTPTransferStatus, arg.Status, arg.StatusMessage, arg.ID)
+	var i CctpTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceDomain,
+		&i.DestinationDomain,
+		&i.BurnTxHash,
+		&i.Message,
+		&i.MessageHash,
+		&i.Attestation,
+		&i.ReceiveTxHash,
+		&i.ReceiveAttempts,
+		&i.Status,
+		&i.StatusMessage,
+	)
+	return i, err
+}
+
+const setIBCTransferPacketSent = `-- name: SetIBCTransferPacketSent :one
+UPDATE ibc_transfers
+SET updated_at=CURRENT_TIMESTAMP, packet_sequence = ?, destination_channel = ?, status='PACKET_SENT'
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_channel, tx_hash, timeout_timestamp, packet_sequence, destination_channel, status, status_message
+`
+
+type SetIBCTransferPacketSentParams struct {
+	PacketSequence     sql.NullInt64
+	DestinationChannel sql.NullString
+	ID                 int64
+}
+
+func (q *Queries) SetIBCTransferPacketSent(ctx context.Context, arg SetIBCTransferPacketSentParams) (IbcTransfer, error) {
+	row := q.db.QueryRowContext(ctx, setIBCTransferPacketSent, arg.PacketSequence, arg.DestinationChannel, arg.ID)
+	var i IbcTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceChannel,
+		&i.TxHash,
+		&i.TimeoutTimestamp,
+		&i.PacketSequence,
+		&i.DestinationChannel,
+		&i.Status,
+		&i.StatusMessage,
+	)
+	return i, err
+}
+
+const setIBCTransferStatus = `-- name: SetIBCTransferStatus :one
+UPDATE ibc_transfers
+SET updated_at=CURRENT_TIMESTAMP, status = ?, status_message = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, source_chain_id, destination_chain_id, source_channel, tx_hash, timeout_timestamp, packet_sequence, destination_channel, status, status_message
+`
+
+type SetIBCTransferStatusParams struct {
+	Status        string
+	StatusMessage sql.NullString
+	ID            int64
+}
+
+func (q *Queries) SetIBCTransferStatus(ctx context.Context, arg SetIBCTransferStatusParams) (IbcTransfer, error) {
+	row := q.db.QueryRowContext(ctx, setIBCTransferStatus, arg.Status, arg.StatusMessage, arg.ID)
+	var i IbcTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.SourceChainID,
+		&i.DestinationChainID,
+		&i.SourceChannel,
+		&i.TxHash,
+		&i.TimeoutTimestamp,
+		&i.PacketSequence,
+		&i.DestinationChannel,
+		&i.Status,
+		&i.StatusMessage,
+	)
+	return i, err
+}
+
+const setRebalanceTransferStepStatus = `-- name: SetRebalanceTransferStepStatus :one
+UPDATE rebalance_transfer_steps
+SET updated_at=CURRENT_TIMESTAMP, status = ?, status_message = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message, source_denom, destination_denom, quoted_amount_in
+`
+
+type SetRebalanceTransferStepStatusParams struct {
+	Status        string
+	StatusMessage sql.NullString
+	ID            int64
+}
+
+func (q *Queries) SetRebalanceTransferStepStatus(ctx context.Context, arg SetRebalanceTransferStepStatusParams) (RebalanceTransferStep, error) {
+	row := q.db.QueryRowContext(ctx, setRebalanceTransferStepStatus, arg.Status, arg.StatusMessage, arg.ID)
+	var i RebalanceTransferStep
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.StepIndex,
+		&i.ChainID,
+		&i.Tx,
+		&i.TxHash,
+		&i.SubmittedAt,
+		&i.Status,
+		&i.StatusMessage,
+		&i.SourceDenom,
+		&i.DestinationDenom,
+		&i.QuotedAmountIn,
+	)
+	return i, err
+}
+
+const setRebalanceTransferStepSubmitted = `-- name: SetRebalanceTransferStepSubmitted :one
+UPDATE rebalance_transfer_steps
+SET updated_at=CURRENT_TIMESTAMP, submitted_at=CURRENT_TIMESTAMP, status='SUBMITTED', tx_hash = ?
+WHERE id = ?
+RETURNING id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message, source_denom, destination_denom, quoted_amount_in
+`
+
+type SetRebalanceTransferStepSubmittedParams struct {
+	TxHash sql.NullString
+	ID     int64
+}
+
+func (q *Queries) SetRebalanceTransferStepSubmitted(ctx context.Context, arg SetRebalanceTransferStepSubmittedParams) (RebalanceTransferStep, error) {
+	row := q.db.QueryRowContext(ctx, setRebalanceTransferStepSubmitted, arg.TxHash, arg.ID)
+	var i RebalanceTransferStep
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.StepIndex,
+		&i.ChainID,
+		&i.Tx,
+		&i.TxHash,
+		&i.SubmittedAt,
+		&i.Status,
+		&i.StatusMessage,
+		&i.SourceDenom,
+		&i.DestinationDenom,
+		&i.QuotedAmountIn,
+	)
+	return i, err
+}
+
+const setRebalanceTransferStepSubmitting = `-- name: SetRebalanceTransferStepSubmitting :one
+UPDATE rebalance_transfer_steps
+SET status='SUBMITTING', tx = ?
+WHERE id = ? AND status = 'PENDING'
+RETURNING id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message, source_denom, destination_denom, quoted_amount_in
+`
+
+type SetRebalanceTransferStepSubmittingParams struct {
+	Tx string
+	ID int64
+}
+
+func (q *Queries) SetRebalanceTransferStepSubmitting(ctx context.Context, arg SetRebalanceTransferStepSubmittingParams) (RebalanceTransferStep, error) {
+	row := q.db.QueryRowContext(ctx, setRebalanceTransferStepSubmitting, arg.Tx, arg.ID)
+	var i RebalanceTransferStep
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RebalanceTransferID,
+		&i.StepIndex,
+		&i.ChainID,
+		&i.Tx,
+		&i.TxHash,
+		&i.SubmittedAt,
+		&i.Status,
+		&i.StatusMessage,
+		&i.SourceDenom,
+		&i.DestinationDenom,
+		&i.QuotedAmountIn,
+	)
+	return i, err
+}
+
 const updateTransferStatus = `-- name: UpdateTransferStatus :exec
 UPDATE rebalance_transfers
 SET updated_at=CURRENT_TIMESTAMP, status = ?
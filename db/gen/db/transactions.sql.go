@@ -11,7 +11,7 @@ import (
 )
 
 const getAllSubmittedTxs = `-- name: GetAllSubmittedTxs :many
-SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id FROM submitted_txs
+SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id, replaces_submitted_tx_id FROM submitted_txs
 `
 
 func (q *Queries) GetAllSubmittedTxs(ctx context.Context) ([]SubmittedTx, error) {
@@ -38,6 +38,7 @@ func (q *Queries) GetAllSubmittedTxs(ctx context.Context) ([]SubmittedTx, error)
 			&i.TxStatusMessage,
 			&i.TxCostUusdc,
 			&i.RebalanceTransferID,
+			&i.ReplacesSubmittedTxID,
 		); err != nil {
 			return nil, err
 		}
@@ -52,8 +53,35 @@ func (q *Queries) GetAllSubmittedTxs(ctx context.Context) ([]SubmittedTx, error)
 	return items, nil
 }
 
+const getSubmittedTx = `-- name: GetSubmittedTx :one
+SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id, replaces_submitted_tx_id FROM submitted_txs WHERE id = ?
+`
+
+func (q *Queries) GetSubmittedTx(ctx context.Context, id int64) (SubmittedTx, error) {
+	row := q.db.QueryRowContext(ctx, getSubmittedTx, id)
+	var i SubmittedTx
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrderID,
+		&i.OrderSettlementID,
+		&i.HyperlaneTransferID,
+		&i.ChainID,
+		&i.TxHash,
+		&i.RawTx,
+		&i.TxType,
+		&i.TxStatus,
+		&i.TxStatusMessage,
+		&i.TxCostUusdc,
+		&i.RebalanceTransferID,
+		&i.ReplacesSubmittedTxID,
+	)
+	return i, err
+}
+
 const getSubmittedTxsByHyperlaneTransferId = `-- name: GetSubmittedTxsByHyperlaneTransferId :many
-SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id FROM submitted_txs WHERE hyperlane_transfer_id = ?
+SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id, replaces_submitted_tx_id FROM submitted_txs WHERE hyperlane_transfer_id = ?
 `
 
 func (q *Queries) GetSubmittedTxsByHyperlaneTransferId(ctx context.Context, hyperlaneTransferID sql.NullInt64) ([]SubmittedTx, error) {
@@ -80,6 +108,7 @@ func (q *Queries) GetSubmittedTxsByHyperlaneTransferId(ctx context.Context, hype
 			&i.TxStatusMessage,
 			&i.TxCostUusdc,
 			&i.RebalanceTransferID,
+			&i.ReplacesSubmittedTxID,
 		); err != nil {
 			return nil, err
 		}
@@ -95,7 +124,7 @@ func (q *Queries) GetSubmittedTxsByHyperlaneTransferId(ctx context.Context, hype
 }
 
 const getSubmittedTxsByOrderIdAndType = `-- name: GetSubmittedTxsByOrderIdAndType :many
-SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id FROM submitted_txs WHERE order_id = ? AND tx_type = ?
+SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id, replaces_submitted_tx_id FROM submitted_txs WHERE order_id = ? AND tx_type = ?
 `
 
 type GetSubmittedTxsByOrderIdAndTypeParams struct {
@@ -127,6 +156,7 @@ func (q *Queries) GetSubmittedTxsByOrderIdAndType(ctx context.Context, arg GetSu
 			&i.TxStatusMessage,
 			&i.TxCostUusdc,
 			&i.RebalanceTransferID,
+			&i.ReplacesSubmittedTxID,
 		); err != nil {
 			return nil, err
 		}
@@ -142,7 +172,7 @@ func (q *Queries) GetSubmittedTxsByOrderIdAndType(ctx context.Context, arg GetSu
 }
 
 const getSubmittedTxsByOrderStatusAndType = `-- name: GetSubmittedTxsByOrderStatusAndType :many
-SELECT submitted_txs.id, submitted_txs.created_at, submitted_txs.updated_at, submitted_txs.order_id, submitted_txs.order_settlement_id, submitted_txs.hyperlane_transfer_id, submitted_txs.chain_id, submitted_txs.tx_hash, submitted_txs.raw_tx, submitted_txs.tx_type, submitted_txs.tx_status, submitted_txs.tx_status_message, submitted_txs.tx_cost_uusdc, submitted_txs.rebalance_transfer_id FROM submitted_txs INNER JOIN orders on submitted_txs.order_id = orders.id WHERE orders.order_status = ? AND submitted_txs.tx_type = ?
+SELECT submitted_txs.id, submitted_txs.created_at, submitted_txs.updated_at, submitted_txs.order_id, submitted_txs.order_settlement_id, submitted_txs.hyperlane_transfer_id, submitted_txs.chain_id, submitted_txs.tx_hash, submitted_txs.raw_tx, submitted_txs.tx_type, submitted_txs.tx_status, submitted_txs.tx_status_message, submitted_txs.tx_cost_uusdc, submitted_txs.rebalance_transfer_id, submitted_txs.replaces_submitted_tx_id FROM submitted_txs INNER JOIN orders on submitted_txs.order_id = orders.id WHERE orders.order_status = ? AND submitted_txs.tx_type = ?
 `
 
 type GetSubmittedTxsByOrderStatusAndTypeParams struct {
@@ -174,6 +204,50 @@ func (q *Queries) GetSubmittedTxsByOrderStatusAndType(ctx context.Context, arg G
 			&i.TxStatusMessage,
 			&i.TxCostUusdc,
 			&i.RebalanceTransferID,
+			&i.ReplacesSubmittedTxID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSubmittedTxsReplacing = `-- name: GetSubmittedTxsReplacing :many
+SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id, replaces_submitted_tx_id FROM submitted_txs WHERE replaces_submitted_tx_id = ?
+`
+
+func (q *Queries) GetSubmittedTxsReplacing(ctx context.Context, replacesSubmittedTxID sql.NullInt64) ([]SubmittedTx, error) {
+	rows, err := q.db.QueryContext(ctx, getSubmittedTxsReplacing, replacesSubmittedTxID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SubmittedTx
+	for rows.Next() {
+		var i SubmittedTx
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrderID,
+			&i.OrderSettlementID,
+			&i.HyperlaneTransferID,
+			&i.ChainID,
+			&i.TxHash,
+			&i.RawTx,
+			&i.TxType,
+			&i.TxStatus,
+			&i.TxStatusMessage,
+			&i.TxCostUusdc,
+			&i.RebalanceTransferID,
+			&i.ReplacesSubmittedTxID,
 		); err != nil {
 			return nil, err
 		}
@@ -189,7 +263,7 @@ func (q *Queries) GetSubmittedTxsByOrderStatusAndType(ctx context.Context, arg G
 }
 
 const getSubmittedTxsWithStatus = `-- name: GetSubmittedTxsWithStatus :many
-SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id FROM submitted_txs WHERE tx_status = ?
+SELECT id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id, replaces_submitted_tx_id FROM submitted_txs WHERE tx_status = ?
 `
 
 func (q *Queries) GetSubmittedTxsWithStatus(ctx context.Context, txStatus string) ([]SubmittedTx, error) {
@@ -216,6 +290,7 @@ func (q *Queries) GetSubmittedTxsWithStatus(ctx context.Context, txStatus string
 			&i.TxStatusMessage,
 			&i.TxCostUusdc,
 			&i.RebalanceTransferID,
+			&i.ReplacesSubmittedTxID,
 		); err != nil {
 			return nil, err
 		}
@@ -231,19 +306,20 @@ func (q *Queries) GetSubmittedTxsWithStatus(ctx context.Context, txStatus string
 }
 
 const insertSubmittedTx = `-- name: InsertSubmittedTx :one
-INSERT INTO submitted_txs (order_id, order_settlement_id, hyperlane_transfer_id, rebalance_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id
+INSERT INTO submitted_txs (order_id, order_settlement_id, hyperlane_transfer_id, rebalance_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, replaces_submitted_tx_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id, replaces_submitted_tx_id
 `
 
 type InsertSubmittedTxParams struct {
-	OrderID             sql.NullInt64
-	OrderSettlementID   sql.NullInt64
-	HyperlaneTransferID sql.NullInt64
-	RebalanceTransferID sql.NullInt64
-	ChainID             string
-	TxHash              string
-	RawTx               string
-	TxType              string
-	TxStatus            string
+	OrderID               sql.NullInt64
+	OrderSettlementID     sql.NullInt64
+	HyperlaneTransferID   sql.NullInt64
+	RebalanceTransferID   sql.NullInt64
+	ChainID               string
+	TxHash                string
+	RawTx                 string
+	TxType                string
+	TxStatus              string
+	ReplacesSubmittedTxID sql.NullInt64
 }
 
 func (q *Queries) InsertSubmittedTx(ctx context.Context, arg InsertSubmittedTxParams) (SubmittedTx, error) {
@@ -257,6 +333,7 @@ func (q *Queries) InsertSubmittedTx(ctx context.Context, arg InsertSubmittedTxPa
 		arg.RawTx,
 		arg.TxType,
 		arg.TxStatus,
+		arg.ReplacesSubmittedTxID,
 	)
 	var i SubmittedTx
 	err := row.Scan(
@@ -274,6 +351,7 @@ func (q *Queries) InsertSubmittedTx(ctx context.Context, arg InsertSubmittedTxPa
 		&i.TxStatusMessage,
 		&i.TxCostUusdc,
 		&i.RebalanceTransferID,
+		&i.ReplacesSubmittedTxID,
 	)
 	return i, err
 }
@@ -281,7 +359,7 @@ func (q *Queries) InsertSubmittedTx(ctx context.Context, arg InsertSubmittedTxPa
 const setSubmittedTxStatus = `-- name: SetSubmittedTxStatus :one
 UPDATE submitted_txs SET 
     tx_status = ?, tx_status_message = ?, tx_cost_uusdc = ?, updated_at = CURRENT_TIMESTAMP 
-WHERE tx_hash = ? AND chain_id = ? RETURNING id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id
+WHERE tx_hash = ? AND chain_id = ? RETURNING id, created_at, updated_at, order_id, order_settlement_id, hyperlane_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, tx_status_message, tx_cost_uusdc, rebalance_transfer_id, replaces_submitted_tx_id
 `
 
 type SetSubmittedTxStatusParams struct {
@@ -316,6 +394,7 @@ func (q *Queries) SetSubmittedTxStatus(ctx context.Context, arg SetSubmittedTxSt
 		&i.TxStatusMessage,
 		&i.TxCostUusdc,
 		&i.RebalanceTransferID,
+		&i.ReplacesSubmittedTxID,
 	)
 	return i, err
 }
@@ -0,0 +1 @@
+ALTER TABLE order_settlements ADD COLUMN repayment_address TEXT;
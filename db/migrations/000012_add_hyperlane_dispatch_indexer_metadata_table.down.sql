@@ -0,0 +1 @@
+DROP TABLE IF EXISTS hyperlane_dispatch_indexer_metadata;
@@ -0,0 +1,3 @@
+ALTER TABLE hyperlane_transfers DROP COLUMN last_error_class;
+ALTER TABLE hyperlane_transfers DROP COLUMN attempt_count;
+ALTER TABLE hyperlane_transfers DROP COLUMN next_attempt_at;
@@ -0,0 +1,3 @@
+ALTER TABLE hyperlane_transfers ADD next_attempt_at TIMESTAMP;
+ALTER TABLE hyperlane_transfers ADD attempt_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE hyperlane_transfers ADD last_error_class TEXT;
@@ -0,0 +1 @@
+ALTER TABLE submitted_txs DROP COLUMN replaces_submitted_tx_id;
@@ -0,0 +1 @@
+ALTER TABLE submitted_txs ADD COLUMN replaces_submitted_tx_id INT REFERENCES submitted_txs(id);
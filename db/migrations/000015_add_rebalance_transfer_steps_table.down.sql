@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS rebalance_transfer_steps;
+
+CREATE TABLE rebalance_transfers_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tx_hash TEXT NOT NULL,
+    source_chain_id      TEXT NOT NULL,
+    destination_chain_id TEXT NOT NULL,
+    amount TEXT NOT NULL,
+    status         TEXT NOT NULL DEFAULT 'PENDING',
+    CHECK (status IN ('PENDING', 'SUCCESS', 'FAILED', 'ABANDONED'))
+);
+
+INSERT INTO rebalance_transfers_old
+SELECT id, created_at, updated_at, tx_hash, source_chain_id, destination_chain_id, amount,
+    CASE WHEN status = 'PARTIALLY_FAILED' THEN 'FAILED' ELSE status END
+FROM rebalance_transfers;
+
+DROP TABLE rebalance_transfers;
+
+ALTER TABLE rebalance_transfers_old RENAME TO rebalance_transfers;
@@ -0,0 +1,34 @@
+CREATE TABLE rebalance_transfers_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tx_hash TEXT NOT NULL,
+    source_chain_id      TEXT NOT NULL,
+    destination_chain_id TEXT NOT NULL,
+    amount TEXT NOT NULL,
+    status         TEXT NOT NULL DEFAULT 'PENDING',
+    CHECK (status IN ('PENDING', 'SUCCESS', 'FAILED', 'ABANDONED', 'PARTIALLY_FAILED'))
+);
+
+INSERT INTO rebalance_transfers_new
+SELECT * FROM rebalance_transfers;
+
+DROP TABLE rebalance_transfers;
+
+ALTER TABLE rebalance_transfers_new RENAME TO rebalance_transfers;
+
+CREATE TABLE IF NOT EXISTS rebalance_transfer_steps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rebalance_transfer_id INT NOT NULL REFERENCES rebalance_transfers(id),
+    step_index INT NOT NULL,
+    chain_id TEXT NOT NULL,
+    tx TEXT NOT NULL,
+    tx_hash TEXT,
+    submitted_at TIMESTAMP,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    status_message TEXT,
+    CHECK (status IN ('PENDING', 'SUBMITTED', 'SUCCESS', 'FAILED')),
+    UNIQUE (rebalance_transfer_id, step_index)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cctp_transfers;
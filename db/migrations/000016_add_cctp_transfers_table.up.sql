@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS cctp_transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rebalance_transfer_id INT NOT NULL UNIQUE REFERENCES rebalance_transfers(id),
+    source_chain_id TEXT NOT NULL,
+    destination_chain_id TEXT NOT NULL,
+    source_domain INT NOT NULL,
+    destination_domain INT NOT NULL,
+    burn_tx_hash TEXT NOT NULL,
+    message TEXT,
+    message_hash TEXT,
+    attestation TEXT,
+    receive_tx_hash TEXT,
+    receive_attempts INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'BURNED',
+    status_message TEXT,
+    CHECK (status IN ('BURNED', 'ATTESTED', 'RECEIVE_SUBMITTED', 'RECEIVED', 'FAILED'))
+);
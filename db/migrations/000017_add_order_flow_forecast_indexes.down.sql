@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS orders_destination_chain_created_at_key;
+DROP INDEX IF EXISTS order_settlements_source_chain_status_updated_at_key;
@@ -0,0 +1,5 @@
+CREATE INDEX orders_destination_chain_created_at_key
+ON orders(destination_chain_id, created_at);
+
+CREATE INDEX order_settlements_source_chain_status_updated_at_key
+ON order_settlements(source_chain_id, settlement_status, updated_at);
@@ -0,0 +1 @@
+ALTER TABLE rebalance_transfers DROP COLUMN quoted_cost_uusdc;
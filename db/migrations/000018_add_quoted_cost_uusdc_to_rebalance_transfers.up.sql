@@ -0,0 +1 @@
+ALTER TABLE rebalance_transfers ADD quoted_cost_uusdc TEXT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS erc20_allowances;
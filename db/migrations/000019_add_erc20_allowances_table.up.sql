@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS erc20_allowances (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    chain_id TEXT NOT NULL,
+    token_contract TEXT NOT NULL,
+    spender TEXT NOT NULL,
+    approved_amount TEXT,
+    approve_tx_hash TEXT,
+    last_used_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoke_tx_hash TEXT,
+    revoked_at TIMESTAMP,
+    UNIQUE (chain_id, token_contract, spender)
+);
@@ -0,0 +1 @@
+ALTER TABLE rebalance_transfers DROP COLUMN kind;
@@ -0,0 +1 @@
+ALTER TABLE rebalance_transfers ADD kind TEXT NOT NULL DEFAULT 'REBALANCE' CHECK (kind IN ('REBALANCE', 'GAS_TOP_UP'));
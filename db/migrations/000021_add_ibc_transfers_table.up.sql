@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS ibc_transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rebalance_transfer_id INT NOT NULL UNIQUE REFERENCES rebalance_transfers(id),
+    source_chain_id TEXT NOT NULL,
+    destination_chain_id TEXT NOT NULL,
+    source_channel TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    timeout_timestamp INT NOT NULL,
+    packet_sequence INT,
+    destination_channel TEXT,
+    status TEXT NOT NULL DEFAULT 'SUBMITTED',
+    status_message TEXT,
+    CHECK (status IN ('SUBMITTED', 'PACKET_SENT', 'ACKNOWLEDGED', 'TIMED_OUT', 'REFUNDED', 'FAILED'))
+);
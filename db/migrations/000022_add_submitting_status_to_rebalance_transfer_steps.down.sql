@@ -0,0 +1,25 @@
+CREATE TABLE rebalance_transfer_steps_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rebalance_transfer_id INT NOT NULL REFERENCES rebalance_transfers(id),
+    step_index INT NOT NULL,
+    chain_id TEXT NOT NULL,
+    tx TEXT NOT NULL,
+    tx_hash TEXT,
+    submitted_at TIMESTAMP,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    status_message TEXT,
+    CHECK (status IN ('PENDING', 'SUBMITTED', 'SUCCESS', 'FAILED')),
+    UNIQUE (rebalance_transfer_id, step_index)
+);
+
+INSERT INTO rebalance_transfer_steps_old
+SELECT id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at,
+    CASE WHEN status = 'SUBMITTING' THEN 'FAILED' ELSE status END,
+    status_message
+FROM rebalance_transfer_steps;
+
+DROP TABLE rebalance_transfer_steps;
+
+ALTER TABLE rebalance_transfer_steps_old RENAME TO rebalance_transfer_steps;
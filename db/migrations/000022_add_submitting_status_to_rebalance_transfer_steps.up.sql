@@ -0,0 +1,26 @@
+CREATE TABLE rebalance_transfer_steps_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rebalance_transfer_id INT NOT NULL REFERENCES rebalance_transfers(id),
+    step_index INT NOT NULL,
+    chain_id TEXT NOT NULL,
+    tx TEXT NOT NULL,
+    tx_hash TEXT,
+    submitted_at TIMESTAMP,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    status_message TEXT,
+    source_denom TEXT,
+    destination_denom TEXT,
+    quoted_amount_in TEXT,
+    CHECK (status IN ('PENDING', 'SUBMITTING', 'SUBMITTED', 'SUCCESS', 'FAILED')),
+    UNIQUE (rebalance_transfer_id, step_index)
+);
+
+INSERT INTO rebalance_transfer_steps_new (id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message)
+SELECT id, created_at, updated_at, rebalance_transfer_id, step_index, chain_id, tx, tx_hash, submitted_at, status, status_message
+FROM rebalance_transfer_steps;
+
+DROP TABLE rebalance_transfer_steps;
+
+ALTER TABLE rebalance_transfer_steps_new RENAME TO rebalance_transfer_steps;
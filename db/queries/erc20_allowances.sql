@@ -0,0 +1,41 @@
+-- name: SetERC20AllowanceApproved :one
+INSERT INTO erc20_allowances (
+    chain_id,
+    token_contract,
+    spender,
+    approved_amount,
+    approve_tx_hash
+) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (chain_id, token_contract, spender) DO UPDATE SET
+    updated_at=CURRENT_TIMESTAMP,
+    approved_amount=excluded.approved_amount,
+    approve_tx_hash=excluded.approve_tx_hash,
+    last_used_at=CURRENT_TIMESTAMP,
+    revoke_tx_hash=NULL,
+    revoked_at=NULL
+RETURNING *;
+
+-- name: SetERC20AllowanceUsed :exec
+INSERT INTO erc20_allowances (
+    chain_id,
+    token_contract,
+    spender
+) VALUES (?, ?, ?)
+ON CONFLICT (chain_id, token_contract, spender) DO UPDATE SET
+    updated_at=CURRENT_TIMESTAMP,
+    last_used_at=CURRENT_TIMESTAMP;
+
+-- name: GetERC20Allowances :many
+SELECT * FROM erc20_allowances
+ORDER BY chain_id, spender;
+
+-- name: GetIdleERC20Allowances :many
+SELECT * FROM erc20_allowances
+WHERE revoked_at IS NULL AND last_used_at < ?
+ORDER BY chain_id, spender;
+
+-- name: SetERC20AllowanceRevoked :one
+UPDATE erc20_allowances
+SET updated_at=CURRENT_TIMESTAMP, approved_amount='0', revoke_tx_hash = ?, revoked_at=CURRENT_TIMESTAMP
+WHERE chain_id = ? AND token_contract = ? AND spender = ?
+RETURNING *;
@@ -17,5 +17,29 @@ SET updated_at=CURRENT_TIMESTAMP, transfer_status = ?, transfer_status_message =
 WHERE source_chain_id = ? AND destination_chain_id = ? AND message_id = ?
     RETURNING *;
 
+-- name: SetHyperlaneTransferRelayAttempt :one
+UPDATE hyperlane_transfers
+SET updated_at=CURRENT_TIMESTAMP, attempt_count = ?, next_attempt_at = ?, last_error_class = ?
+WHERE id = ?
+    RETURNING *;
+
+-- name: ResetHyperlaneTransferRelayAttempts :one
+UPDATE hyperlane_transfers
+SET updated_at=CURRENT_TIMESTAMP, attempt_count = 0, next_attempt_at = NULL, last_error_class = NULL
+WHERE id = ?
+    RETURNING *;
+
 -- name: GetHyperlaneTransferByMessageSentTx :one
 SELECT * FROM hyperlane_transfers WHERE message_sent_tx = ? AND source_chain_id = ?;
+
+-- name: GetHyperlaneTransfer :one
+SELECT * FROM hyperlane_transfers WHERE id = ?;
+
+-- name: GetHyperlaneTransferByMessageID :one
+SELECT * FROM hyperlane_transfers WHERE source_chain_id = ? AND destination_chain_id = ? AND message_id = ?;
+
+-- name: InsertHyperlaneDispatchIndexerMetadata :one
+INSERT INTO hyperlane_dispatch_indexer_metadata (chain_id, height_last_seen) VALUES (?, ?) ON CONFLICT (chain_id) DO UPDATE SET height_last_seen = excluded.height_last_seen, updated_at=CURRENT_TIMESTAMP RETURNING *;
+
+-- name: GetHyperlaneDispatchIndexerMetadata :one
+SELECT * FROM hyperlane_dispatch_indexer_metadata WHERE chain_id = ?;
@@ -17,7 +17,7 @@ SELECT * FROM order_settlements WHERE source_chain_id = ? AND source_chain_gatew
 
 -- name: SetInitiateSettlementTx :one
 UPDATE order_settlements
-SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx_time=CURRENT_TIMESTAMP, initiate_settlement_tx = ?
+SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx_time=CURRENT_TIMESTAMP, initiate_settlement_tx = ?, repayment_address = ?
 WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_address = ?
     RETURNING *;
 
@@ -41,6 +41,15 @@ WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_add
 
 -- name: ClearInitiateSettlement :many
 UPDATE order_settlements
-SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx = null, hyperlane_transfer_id = null, initiate_settlement_tx_time = null, settlement_status = ?
+SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx = null, hyperlane_transfer_id = null, initiate_settlement_tx_time = null, repayment_address = null, settlement_status = ?
 WHERE destination_chain_id=? AND initiate_settlement_tx=?
-    RETURNING *;
\ No newline at end of file
+    RETURNING *;
+
+-- name: ResetOrderSettlement :one
+UPDATE order_settlements
+SET updated_at=CURRENT_TIMESTAMP, initiate_settlement_tx = null, hyperlane_transfer_id = null, initiate_settlement_tx_time = null, repayment_address = null, settlement_status = ?, settlement_status_message = ?
+WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_address = ?
+    RETURNING *;
+
+-- name: GetSettlementInflowsToChainSince :many
+SELECT updated_at, amount FROM order_settlements WHERE source_chain_id = ? AND settlement_status = ? AND updated_at >= ?;
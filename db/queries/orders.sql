@@ -39,3 +39,6 @@ WHERE source_chain_id = ? AND order_id = ? AND source_chain_gateway_contract_add
 
 -- name: GetOrderByOrderID :one
 SELECT * FROM orders WHERE order_id = ?;
+
+-- name: GetOrderOutflowsToChainSince :many
+SELECT created_at, amount_out FROM orders WHERE destination_chain_id = ? AND created_at >= ?;
@@ -3,8 +3,19 @@ INSERT INTO rebalance_transfers (
     tx_hash,
     source_chain_id,
     destination_chain_id,
-    amount
-) VALUES (?, ?, ?, ?) RETURNING id;
+    amount,
+    quoted_cost_uusdc
+) VALUES (?, ?, ?, ?, ?) RETURNING id;
+
+-- name: InsertGasTopUpTransfer :one
+INSERT INTO rebalance_transfers (
+    tx_hash,
+    source_chain_id,
+    destination_chain_id,
+    amount,
+    quoted_cost_uusdc,
+    kind
+) VALUES (?, ?, ?, ?, ?, 'GAS_TOP_UP') RETURNING id;
 
 -- name: GetPendingRebalanceTransfersToChain :many
 SELECT 
@@ -14,7 +25,7 @@ SELECT
     destination_chain_id,
     amount
 FROM rebalance_transfers
-WHERE destination_chain_id = ? AND status = 'PENDING';
+WHERE destination_chain_id = ? AND status = 'PENDING' AND kind = 'REBALANCE';
 
 -- name: GetAllPendingRebalanceTransfers :many
 SELECT 
@@ -23,7 +34,8 @@ SELECT
     source_chain_id,
     destination_chain_id,
     amount,
-    created_at
+    created_at,
+    kind
 FROM rebalance_transfers 
 WHERE status = 'PENDING';
 
@@ -32,3 +44,126 @@ WHERE status = 'PENDING';
 UPDATE rebalance_transfers
 SET updated_at=CURRENT_TIMESTAMP, status = ?
 WHERE id = ?;
+
+-- name: GetRebalanceTransfersWithStatus :many
+SELECT * FROM rebalance_transfers WHERE status = ?;
+
+-- name: GetPendingRebalanceTransfersWithSteps :many
+SELECT * FROM rebalance_transfers
+WHERE status = 'PENDING' AND id IN (SELECT rebalance_transfer_id FROM rebalance_transfer_steps);
+
+-- name: InsertRebalanceTransferStep :one
+INSERT INTO rebalance_transfer_steps (
+    rebalance_transfer_id,
+    step_index,
+    chain_id,
+    tx,
+    tx_hash,
+    submitted_at,
+    status,
+    source_denom,
+    destination_denom,
+    quoted_amount_in
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetRebalanceTransferSteps :many
+SELECT * FROM rebalance_transfer_steps
+WHERE rebalance_transfer_id = ?
+ORDER BY step_index;
+
+-- name: SetRebalanceTransferStepSubmitting :one
+UPDATE rebalance_transfer_steps
+SET status='SUBMITTING', tx = ?
+WHERE id = ? AND status = 'PENDING'
+RETURNING *;
+
+-- name: ResetRebalanceTransferStepSubmitting :one
+UPDATE rebalance_transfer_steps
+SET status='PENDING', status_message = ?
+WHERE id = ? AND status = 'SUBMITTING'
+RETURNING *;
+
+-- name: SetRebalanceTransferStepSubmitted :one
+UPDATE rebalance_transfer_steps
+SET updated_at=CURRENT_TIMESTAMP, submitted_at=CURRENT_TIMESTAMP, status='SUBMITTED', tx_hash = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: SetRebalanceTransferStepStatus :one
+UPDATE rebalance_transfer_steps
+SET updated_at=CURRENT_TIMESTAMP, status = ?, status_message = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: ResetRebalanceTransferStep :one
+UPDATE rebalance_transfer_steps
+SET updated_at=CURRENT_TIMESTAMP, status='PENDING', tx_hash=NULL, submitted_at=NULL, status_message=NULL
+WHERE id = ?
+RETURNING *;
+
+-- name: InsertCCTPTransfer :one
+INSERT INTO cctp_transfers (
+    rebalance_transfer_id,
+    source_chain_id,
+    destination_chain_id,
+    source_domain,
+    destination_domain,
+    burn_tx_hash
+) VALUES (?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetPendingCCTPTransfers :many
+SELECT * FROM cctp_transfers
+WHERE status NOT IN ('RECEIVED', 'FAILED');
+
+-- name: SetCCTPTransferMessage :one
+UPDATE cctp_transfers
+SET updated_at=CURRENT_TIMESTAMP, message = ?, message_hash = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: SetCCTPTransferAttestation :one
+UPDATE cctp_transfers
+SET updated_at=CURRENT_TIMESTAMP, attestation = ?, status='ATTESTED', status_message=NULL
+WHERE id = ?
+RETURNING *;
+
+-- name: SetCCTPTransferReceiveSubmitted :one
+UPDATE cctp_transfers
+SET updated_at=CURRENT_TIMESTAMP, receive_tx_hash = ?, receive_attempts = receive_attempts + 1, status='RECEIVE_SUBMITTED'
+WHERE id = ?
+RETURNING *;
+
+-- name: SetCCTPTransferStatus :one
+UPDATE cctp_transfers
+SET updated_at=CURRENT_TIMESTAMP, status = ?, status_message = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: InsertIBCTransfer :one
+INSERT INTO ibc_transfers (
+    rebalance_transfer_id,
+    source_chain_id,
+    destination_chain_id,
+    source_channel,
+    tx_hash,
+    timeout_timestamp
+) VALUES (?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetPendingIBCTransfers :many
+SELECT * FROM ibc_transfers
+WHERE status NOT IN ('ACKNOWLEDGED', 'REFUNDED', 'FAILED');
+
+-- name: SetIBCTransferPacketSent :one
+UPDATE ibc_transfers
+SET updated_at=CURRENT_TIMESTAMP, packet_sequence = ?, destination_channel = ?, status='PACKET_SENT'
+WHERE id = ?
+RETURNING *;
+
+-- name: SetIBCTransferStatus :one
+UPDATE ibc_transfers
+SET updated_at=CURRENT_TIMESTAMP, status = ?, status_message = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: GetRebalanceTransfersSince :many
+SELECT * FROM rebalance_transfers WHERE created_at >= ? ORDER BY created_at;
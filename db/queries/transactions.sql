@@ -1,5 +1,11 @@
 -- name: InsertSubmittedTx :one
-INSERT INTO submitted_txs (order_id, order_settlement_id, hyperlane_transfer_id, rebalance_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+INSERT INTO submitted_txs (order_id, order_settlement_id, hyperlane_transfer_id, rebalance_transfer_id, chain_id, tx_hash, raw_tx, tx_type, tx_status, replaces_submitted_tx_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetSubmittedTx :one
+SELECT * FROM submitted_txs WHERE id = ?;
+
+-- name: GetSubmittedTxsReplacing :many
+SELECT * FROM submitted_txs WHERE replaces_submitted_tx_id = ?;
 
 -- name: GetSubmittedTxsByOrderIdAndType :many
 SELECT * FROM submitted_txs WHERE order_id = ? AND tx_type = ?;
@@ -11,11 +11,13 @@ const (
 	SettlementStatusSettlementInitiated string = "SETTLEMENT_INITIATED"
 	SettlementStatusComplete            string = "COMPLETE"
 	SettlementStatusFailed              string = "FAILED"
+	SettlementStatusWrittenOff          string = "WRITTEN_OFF"
 
 	TxStatusPending   string = "PENDING"
 	TxStatusSuccess   string = "SUCCESS"
 	TxStatusFailed    string = "FAILED"
 	TxStatusAbandoned string = "ABANDONED"
+	TxStatusReplaced  string = "REPLACED"
 
 	TxTypeOrderFill                string = "ORDER_FILL"
 	TxTypeSettlement               string = "SETTLEMENT"
@@ -28,12 +30,48 @@ const (
 	RebalanceTransferStatusPending   string = "PENDING"
 	RebalanceTransferStatusSuccess   string = "SUCCESS"
 	RebalanceTransferStatusFailed    string = "FAILED"
+	// RebalanceTransferStatusPartiallyFailed is a multi tx rebalance whose
+	// first txs succeeded but a later tx failed, leaving funds on an
+	// intermediate chain of the route
+	RebalanceTransferStatusPartiallyFailed string = "PARTIALLY_FAILED"
+
+	RebalanceTransferKindRebalance string = "REBALANCE"
+	// RebalanceTransferKindGasTopUp is a swap of usdc into a chains gas token
+	// to top up the solvers gas balance on that chain
+	RebalanceTransferKindGasTopUp string = "GAS_TOP_UP"
+
+	RebalanceTransferStepStatusPending string = "PENDING"
+	// RebalanceTransferStepStatusSubmitting is a step whose tx is being
+	// broadcast. The tx may or may not have been broadcast if a step is
+	// left in this status.
+	RebalanceTransferStepStatusSubmitting string = "SUBMITTING"
+	RebalanceTransferStepStatusSubmitted  string = "SUBMITTED"
+	RebalanceTransferStepStatusSuccess    string = "SUCCESS"
+	RebalanceTransferStepStatusFailed     string = "FAILED"
+
+	CCTPTransferStatusBurned           string = "BURNED"
+	CCTPTransferStatusAttested         string = "ATTESTED"
+	CCTPTransferStatusReceiveSubmitted string = "RECEIVE_SUBMITTED"
+	CCTPTransferStatusReceived         string = "RECEIVED"
+	CCTPTransferStatusFailed           string = "FAILED"
+
+	IBCTransferStatusSubmitted    string = "SUBMITTED"
+	IBCTransferStatusPacketSent   string = "PACKET_SENT"
+	IBCTransferStatusAcknowledged string = "ACKNOWLEDGED"
+	IBCTransferStatusTimedOut     string = "TIMED_OUT"
+	IBCTransferStatusRefunded     string = "REFUNDED"
+	IBCTransferStatusFailed       string = "FAILED"
 
 	TransferStatusPending   string = "PENDING"
 	TransferStatusSuccess   string = "SUCCESS"
 	TransferStatusAbandoned string = "ABANDONED"
 	TransferStatusCancelled string = "CANCELLED"
 
+	RelayErrorClassTooExpensive        string = "TOO_EXPENSIVE"
+	RelayErrorClassNotEnoughSignatures string = "NOT_ENOUGH_SIGNATURES"
+	RelayErrorClassRPCError            string = "RPC_ERROR"
+	RelayErrorClassReverted            string = "REVERTED"
+
 	GET    string = "GET"
 	INSERT string = "INSERT"
 	UPDATE string = "UPDATE"
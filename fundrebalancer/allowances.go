@@ -0,0 +1,331 @@
+package fundrebalancer
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/contracts/usdc"
+	"github.com/skip-mev/go-fast-solver/shared/evmrpc"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/signing"
+	evmtxsubmission "github.com/skip-mev/go-fast-solver/shared/txexecutor/evm"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const allowanceRevokerLoopDelay = 10 * time.Minute
+
+// SpenderAllowance is a spender contract that the fund rebalancer has used to
+// move usdc, along with its current on chain allowance
+type SpenderAllowance struct {
+	ChainID       string
+	TokenContract string
+	Spender       string
+	// AllowanceUUSDC is the spenders current on chain allowance
+	AllowanceUUSDC *big.Int
+	LastUsedAt     time.Time
+	// RevokedAt is the time the allowance was last revoked, or the zero time
+	// if it has not been revoked since it was last approved
+	RevokedAt time.Time
+	// Idle is true if the spender has not been used within the configured
+	// idle period and its allowance has not been revoked
+	Idle bool
+}
+
+// AllowanceManager grants the erc20 approvals required by rebalance txs and
+// keeps a registry of the spenders approved on each chain. Approvals are
+// bounded to the exact amount being moved plus a configured buffer, and
+// allowances of spenders that have not been used for a configured period are
+// revoked.
+type AllowanceManager struct {
+	chainIDToPrivateKey map[string]string
+	evmClientManager    evmrpc.EVMRPCClientManager
+	evmTxExecutor       evmtxsubmission.EVMTxExecutor
+	database            Database
+	buffer              *big.Int
+	revokeIdleAfter     time.Duration
+}
+
+func NewAllowanceManager(
+	chainIDToPrivateKey map[string]string,
+	evmClientManager evmrpc.EVMRPCClientManager,
+	evmTxExecutor evmtxsubmission.EVMTxExecutor,
+	database Database,
+	approvalsConfig config.ERC20ApprovalsConfig,
+) (*AllowanceManager, error) {
+	buffer := big.NewInt(0)
+	if approvalsConfig.BufferUUSDC != "" {
+		var ok bool
+		buffer, ok = new(big.Int).SetString(approvalsConfig.BufferUUSDC, 10)
+		if !ok {
+			return nil, fmt.Errorf("could not convert erc20 approval buffer %s to *big.Int", approvalsConfig.BufferUUSDC)
+		}
+	}
+	return &AllowanceManager{
+		chainIDToPrivateKey: chainIDToPrivateKey,
+		evmClientManager:    evmClientManager,
+		evmTxExecutor:       evmTxExecutor,
+		database:            database,
+		buffer:              buffer,
+		revokeIdleAfter:     approvalsConfig.RevokeIdleAfter,
+	}, nil
+}
+
+// Run periodically revokes the allowances of idle spenders. It returns
+// immediately if idle allowances are not configured to be revoked.
+func (m *AllowanceManager) Run(ctx context.Context) {
+	if m.revokeIdleAfter <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(allowanceRevokerLoopDelay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.RevokeIdleAllowances(ctx); err != nil {
+				lmt.Logger(ctx).Error("error revoking idle erc20 allowances", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Allowance gets the current on chain allowance of spender for the solvers
+// tokens at tokenContract on chainID
+func (m *AllowanceManager) Allowance(ctx context.Context, chainID, tokenContract, spender string) (*big.Int, error) {
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting config for chain %s: %w", chainID, err)
+	}
+	client, err := m.evmClientManager.GetClient(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting evm rpc client for chain %s: %w", chainID, err)
+	}
+
+	caller, err := usdc.NewUsdcCaller(common.HexToAddress(tokenContract), client)
+	if err != nil {
+		return nil, fmt.Errorf("creating new usdc contract caller at %s on chain %s: %w", tokenContract, chainID, err)
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	allowance, err := caller.Allowance(opts, common.HexToAddress(chainConfig.SolverAddress), common.HexToAddress(spender))
+	if err != nil {
+		return nil, fmt.Errorf("querying for erc20 allowance for solver %s at contract %s for spender %s: %w", chainConfig.SolverAddress, tokenContract, spender, err)
+	}
+	return allowance, nil
+}
+
+// approve submits an erc20 approval for the amount required by approval plus
+// the configured buffer. Approvals for more than maxAmount, the amount of usdc
+// being moved by the tx requiring the approval, are refused.
+func (m *AllowanceManager) approve(
+	ctx context.Context,
+	chainID string,
+	approval skipgo.ERC20Approval,
+	maxAmount *big.Int,
+) (txHash string, rawTx string, err error) {
+	required, ok := new(big.Int).SetString(approval.Amount, 10)
+	if !ok {
+		return "", "", fmt.Errorf("error converting erc20 approval amount %s on chain %s to *big.Int", approval.Amount, chainID)
+	}
+	if required.Cmp(maxAmount) > 0 {
+		return "", "", fmt.Errorf("refusing to approve %s for spender %s on chain %s, only %s is being moved", required.String(), approval.Spender, chainID, maxAmount.String())
+	}
+	amount := new(big.Int).Add(required, m.buffer)
+
+	hash, rawTx, err := m.submitApprove(ctx, chainID, approval.TokenContract, approval.Spender, amount)
+	if err != nil {
+		return "", "", err
+	}
+
+	if _, err := m.database.SetERC20AllowanceApproved(ctx, db.SetERC20AllowanceApprovedParams{
+		ChainID:        chainID,
+		TokenContract:  strings.ToLower(approval.TokenContract),
+		Spender:        strings.ToLower(approval.Spender),
+		ApprovedAmount: sql.NullString{String: amount.String(), Valid: true},
+		ApproveTxHash:  sql.NullString{String: hash, Valid: true},
+	}); err != nil {
+		return "", "", fmt.Errorf("recording erc20 approval of %s for spender %s on chain %s: %w", amount.String(), approval.Spender, chainID, err)
+	}
+	return hash, rawTx, nil
+}
+
+// recordUse marks the spender of approval as used by a rebalance, adding it to
+// the registry if this is the first time it has been seen
+func (m *AllowanceManager) recordUse(ctx context.Context, chainID string, approval skipgo.ERC20Approval) error {
+	if err := m.database.SetERC20AllowanceUsed(ctx, db.SetERC20AllowanceUsedParams{
+		ChainID:       chainID,
+		TokenContract: strings.ToLower(approval.TokenContract),
+		Spender:       strings.ToLower(approval.Spender),
+	}); err != nil {
+		return fmt.Errorf("recording use of erc20 allowance for spender %s on chain %s: %w", approval.Spender, chainID, err)
+	}
+	return nil
+}
+
+// Allowances lists every spender in the registry along with its current on
+// chain allowance
+func (m *AllowanceManager) Allowances(ctx context.Context) ([]SpenderAllowance, error) {
+	registered, err := m.database.GetERC20Allowances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting erc20 allowances from db: %w", err)
+	}
+
+	allowances := make([]SpenderAllowance, 0, len(registered))
+	for _, allowance := range registered {
+		onChainAllowance, err := m.Allowance(ctx, allowance.ChainID, allowance.TokenContract, allowance.Spender)
+		if err != nil {
+			return nil, err
+		}
+		spenderAllowance := SpenderAllowance{
+			ChainID:        allowance.ChainID,
+			TokenContract:  allowance.TokenContract,
+			Spender:        allowance.Spender,
+			AllowanceUUSDC: onChainAllowance,
+			LastUsedAt:     allowance.LastUsedAt,
+			Idle:           !allowance.RevokedAt.Valid && m.revokeIdleAfter > 0 && time.Since(allowance.LastUsedAt) > m.revokeIdleAfter,
+		}
+		if allowance.RevokedAt.Valid {
+			spenderAllowance.RevokedAt = allowance.RevokedAt.Time
+		}
+		allowances = append(allowances, spenderAllowance)
+	}
+	return allowances, nil
+}
+
+// RevokeAllowance sets the allowance of spender for the solvers tokens at
+// tokenContract on chainID to 0, returning the hash of the approval tx
+func (m *AllowanceManager) RevokeAllowance(ctx context.Context, chainID, tokenContract, spender string) (string, error) {
+	hash, rawTx, err := m.submitApprove(ctx, chainID, tokenContract, spender, big.NewInt(0))
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := m.database.InsertSubmittedTx(ctx, db.InsertSubmittedTxParams{
+		ChainID:  chainID,
+		TxHash:   hash,
+		RawTx:    rawTx,
+		TxType:   dbtypes.TxTypeERC20Approval,
+		TxStatus: dbtypes.TxStatusPending,
+	}); err != nil {
+		return "", fmt.Errorf("inserting submitted tx for erc20 allowance revocation with hash %s on chain %s into db: %w", hash, chainID, err)
+	}
+
+	if _, err := m.database.SetERC20AllowanceRevoked(ctx, db.SetERC20AllowanceRevokedParams{
+		RevokeTxHash:  sql.NullString{String: hash, Valid: true},
+		ChainID:       chainID,
+		TokenContract: strings.ToLower(tokenContract),
+		Spender:       strings.ToLower(spender),
+	}); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("recording revocation of erc20 allowance for spender %s on chain %s: %w", spender, chainID, err)
+	}
+	return hash, nil
+}
+
+// RevokeIdleAllowances revokes the allowances of spenders that have not been
+// used within the configured idle period. Nothing is revoked while any
+// rebalance transfer is pending, since the remaining steps of a multi tx route
+// may still need to use a spender.
+func (m *AllowanceManager) RevokeIdleAllowances(ctx context.Context) error {
+	if m.revokeIdleAfter <= 0 {
+		return nil
+	}
+
+	pendingTransfers, err := m.database.GetAllPendingRebalanceTransfers(ctx)
+	if err != nil {
+		return fmt.Errorf("getting pending rebalance transfers: %w", err)
+	}
+	if len(pendingTransfers) > 0 {
+		lmt.Logger(ctx).Debug("skipping revocation of idle erc20 allowances while rebalance transfers are pending", zap.Int("pendingTransfers", len(pendingTransfers)))
+		return nil
+	}
+
+	idle, err := m.database.GetIdleERC20Allowances(ctx, time.Now().Add(-m.revokeIdleAfter).UTC())
+	if err != nil {
+		return fmt.Errorf("getting idle erc20 allowances from db: %w", err)
+	}
+	for _, allowance := range idle {
+		onChainAllowance, err := m.Allowance(ctx, allowance.ChainID, allowance.TokenContract, allowance.Spender)
+		if err != nil {
+			return err
+		}
+		if onChainAllowance.Sign() == 0 {
+			// nothing to revoke, only record that the allowance is revoked
+			if _, err := m.database.SetERC20AllowanceRevoked(ctx, db.SetERC20AllowanceRevokedParams{
+				ChainID:       allowance.ChainID,
+				TokenContract: allowance.TokenContract,
+				Spender:       allowance.Spender,
+			}); err != nil {
+				return fmt.Errorf("recording revocation of erc20 allowance for spender %s on chain %s: %w", allowance.Spender, allowance.ChainID, err)
+			}
+			continue
+		}
+
+		hash, err := m.RevokeAllowance(ctx, allowance.ChainID, allowance.TokenContract, allowance.Spender)
+		if err != nil {
+			return err
+		}
+		lmt.Logger(ctx).Info(
+			"revoked idle erc20 allowance",
+			zap.String("chainID", allowance.ChainID),
+			zap.String("spender", allowance.Spender),
+			zap.String("allowance", onChainAllowance.String()),
+			zap.Time("lastUsedAt", allowance.LastUsedAt),
+			zap.String("txHash", hash),
+		)
+	}
+	return nil
+}
+
+// submitApprove submits an erc20 approve tx setting the allowance of spender
+// for the solvers tokens at tokenContract on chainID to amount
+func (m *AllowanceManager) submitApprove(ctx context.Context, chainID, tokenContract, spender string, amount *big.Int) (txHash string, rawTx string, err error) {
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return "", "", fmt.Errorf("getting config for chain %s: %w", chainID, err)
+	}
+	if chainConfig.Type != config.ChainType_EVM {
+		return "", "", fmt.Errorf("erc20 approvals are only supported on evm chains, chain %s is %s", chainID, chainConfig.Type)
+	}
+
+	signer, err := signing.NewSigner(ctx, chainID, m.chainIDToPrivateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("creating signer for chain %s: %w", chainID, err)
+	}
+
+	abi, err := usdc.UsdcMetaData.GetAbi()
+	if err != nil {
+		return "", "", fmt.Errorf("getting usdc contract abi: %w", err)
+	}
+
+	input, err := abi.Pack("approve", common.HexToAddress(spender), amount)
+	if err != nil {
+		return "", "", fmt.Errorf("packing input to erc20 approval tx: %w", err)
+	}
+
+	hash, rawTxB64, err := m.evmTxExecutor.ExecuteTx(
+		ctx,
+		chainID,
+		chainConfig.SolverAddress,
+		input,
+		"0",
+		tokenContract,
+		signer,
+	)
+	if err != nil {
+		return "", "", fmt.Errorf("executing erc20 approve for %s at contract %s for spender %s on %s: %w", amount.String(), tokenContract, spender, chainID, err)
+	}
+	return hash, rawTxB64, nil
+}
@@ -0,0 +1,135 @@
+package fundrebalancer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	mock_database "github.com/skip-mev/go-fast-solver/mocks/fundrebalancer"
+	mock_config "github.com/skip-mev/go-fast-solver/mocks/shared/config"
+	mock_evmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/evmrpc"
+	mock_evm "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/evm"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/contracts/usdc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowanceManager(t *testing.T) {
+	ctx := context.Background()
+	mockConfigReader := mock_config.NewMockConfigReader(t)
+	mockConfigReader.On("GetChainConfig", arbitrumChainID).Return(config.ChainConfig{
+		Type:          config.ChainType_EVM,
+		SolverAddress: arbitrumAddress,
+	}, nil).Maybe()
+	ctx = config.ConfigReaderContext(ctx, mockConfigReader)
+
+	usdcABI, err := usdc.UsdcMetaData.GetAbi()
+	require.NoError(t, err)
+	approveInput := func(spender string, amount int64) []byte {
+		input, err := usdcABI.Pack("approve", common.HexToAddress(spender), big.NewInt(amount))
+		require.NoError(t, err)
+		return input
+	}
+	expectAllowance := func(client *mock_evmrpc.MockEVMChainRPC, spender string, allowance int64) {
+		data, err := usdcABI.Pack("allowance", common.HexToAddress(arbitrumAddress), common.HexToAddress(spender))
+		require.NoError(t, err)
+		to := common.HexToAddress(arbitrumUSDCDenom)
+		var nilBigInt *big.Int
+		client.EXPECT().CallContract(mock.Anything, ethereum.CallMsg{To: &to, Data: data}, nilBigInt).
+			Return(common.LeftPadBytes(big.NewInt(allowance).Bytes(), 32), nil)
+	}
+	newManager := func(t *testing.T, approvalsConfig config.ERC20ApprovalsConfig) (*AllowanceManager, *mock_database.FakeDatabase, *mock_evmrpc.MockEVMChainRPC, *mock_evm.MockEVMTxExecutor) {
+		mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+		mockEVMClient := mock_evmrpc.NewMockEVMChainRPC(t)
+		mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockEVMClient, nil).Maybe()
+		mockEVMTxExecutor := mock_evm.NewMockEVMTxExecutor(t)
+		database := mock_database.NewFakeDatabase()
+		manager, err := NewAllowanceManager(
+			map[string]string{arbitrumChainID: arbitrumPrivateKey},
+			mockEVMClientManager,
+			mockEVMTxExecutor,
+			database,
+			approvalsConfig,
+		)
+		require.NoError(t, err)
+		return manager, database, mockEVMClient, mockEVMTxExecutor
+	}
+	approval := skipgo.ERC20Approval{TokenContract: arbitrumUSDCDenom, Spender: "0x5be0", Amount: "100"}
+
+	t.Run("approvals are bounded to the required amount plus buffer", func(t *testing.T) {
+		manager, database, _, mockEVMTxExecutor := newManager(t, config.ERC20ApprovalsConfig{BufferUUSDC: "50"})
+		mockEVMTxExecutor.On("ExecuteTx", mockContext, arbitrumChainID, arbitrumAddress, approveInput("0x5be0", 150), "0", arbitrumUSDCDenom, mock.Anything).
+			Return("approve hash", "", nil).Once()
+
+		hash, _, err := manager.approve(ctx, arbitrumChainID, approval, big.NewInt(100))
+		require.NoError(t, err)
+		assert.Equal(t, "approve hash", hash)
+
+		allowances, err := database.GetERC20Allowances(ctx)
+		require.NoError(t, err)
+		require.Len(t, allowances, 1)
+		assert.Equal(t, "0x5be0", allowances[0].Spender)
+		assert.Equal(t, "150", allowances[0].ApprovedAmount.String)
+		assert.Equal(t, "approve hash", allowances[0].ApproveTxHash.String)
+	})
+
+	t.Run("approvals for more than the amount moved are refused", func(t *testing.T) {
+		manager, database, _, _ := newManager(t, config.ERC20ApprovalsConfig{})
+
+		_, _, err := manager.approve(ctx, arbitrumChainID, approval, big.NewInt(99))
+		assert.Error(t, err)
+
+		allowances, err := database.GetERC20Allowances(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, allowances)
+	})
+
+	t.Run("idle allowances are revoked", func(t *testing.T) {
+		manager, database, mockEVMClient, mockEVMTxExecutor := newManager(t, config.ERC20ApprovalsConfig{RevokeIdleAfter: time.Hour})
+		require.NoError(t, manager.recordUse(ctx, arbitrumChainID, skipgo.ERC20Approval{TokenContract: arbitrumUSDCDenom, Spender: "0x1d1e"}))
+		require.NoError(t, manager.recordUse(ctx, arbitrumChainID, skipgo.ERC20Approval{TokenContract: arbitrumUSDCDenom, Spender: "0x1d1e0000"}))
+		require.NoError(t, manager.recordUse(ctx, arbitrumChainID, skipgo.ERC20Approval{TokenContract: arbitrumUSDCDenom, Spender: "0xac71"}))
+		database.SetAllowanceLastUsedAt(arbitrumChainID, arbitrumUSDCDenom, "0x1d1e", time.Now().Add(-2*time.Hour))
+		database.SetAllowanceLastUsedAt(arbitrumChainID, arbitrumUSDCDenom, "0x1d1e0000", time.Now().Add(-2*time.Hour))
+
+		// nothing is revoked while a rebalance is in flight
+		id, err := database.InsertRebalanceTransfer(ctx, db.InsertRebalanceTransferParams{
+			TxHash:             "hash",
+			SourceChainID:      arbitrumChainID,
+			DestinationChainID: ethChainID,
+			Amount:             "100",
+		})
+		require.NoError(t, err)
+		require.NoError(t, manager.RevokeIdleAllowances(ctx))
+
+		require.NoError(t, database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{ID: id, Status: "SUCCESS"}))
+		expectAllowance(mockEVMClient, "0x1d1e", 500)
+		expectAllowance(mockEVMClient, "0x1d1e0000", 0)
+		mockEVMTxExecutor.On("ExecuteTx", mockContext, arbitrumChainID, arbitrumAddress, approveInput("0x1d1e", 0), "0", arbitrumUSDCDenom, mock.Anything).
+			Return("revoke hash", "", nil).Once()
+		require.NoError(t, manager.RevokeIdleAllowances(ctx))
+
+		allowances, err := database.GetERC20Allowances(ctx)
+		require.NoError(t, err)
+		require.Len(t, allowances, 3)
+		revoked := make(map[string]db.Erc20Allowance)
+		for _, allowance := range allowances {
+			revoked[allowance.Spender] = allowance
+		}
+		assert.True(t, revoked["0x1d1e"].RevokedAt.Valid)
+		assert.Equal(t, "revoke hash", revoked["0x1d1e"].RevokeTxHash.String)
+		assert.True(t, revoked["0x1d1e0000"].RevokedAt.Valid)
+		assert.False(t, revoked["0x1d1e0000"].RevokeTxHash.Valid)
+		assert.False(t, revoked["0xac71"].RevokedAt.Valid)
+
+		// revoked allowances are not revoked again
+		require.NoError(t, manager.RevokeIdleAllowances(ctx))
+	})
+}
@@ -0,0 +1,62 @@
+package fundrebalancer
+
+import (
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// rebalanceBridge is a backend that the fund rebalancer can move usdc between
+// chains with. Every bridge that supports a source and destination chain is
+// quoted, and funds are moved with whichever bridge is cheapest.
+type rebalanceBridge interface {
+	// Name identifies the bridge in logs
+	Name() string
+	// Supports returns true if the bridge can move usdc from sourceChainID
+	// to destinationChainID
+	Supports(ctx context.Context, sourceChainID, destinationChainID string) bool
+	// Quote fills in the txs, fees, gas cost and estimated duration of moving
+	// quote.amount usdc from quote.sourceChainID to destinationChainID
+	Quote(ctx context.Context, destinationChainID string, quote *rebalanceQuote) error
+	// Execute submits the txs moving the allocated amount of usdc from the
+	// allocations source chain to destinationChainID, and persists the
+	// rebalance transfer so that it can be tracked until it completes
+	Execute(ctx context.Context, destinationChainID string, allocation rebalanceAllocation) (skipgo.TxHash, error)
+}
+
+// skipGoBridge moves usdc along routes returned by the Skip Go api
+type skipGoBridge struct {
+	r *FundRebalancer
+}
+
+func (b *skipGoBridge) Name() string {
+	return "skipgo"
+}
+
+// Supports returns true unless either chain is an svm chain. Skip Go
+// returns svm txs already signed by other parties with a blockhash that may
+// expire before the tx lands, which the solver can not resign, so usdc is only
+// moved to and from svm chains with cctp.
+func (b *skipGoBridge) Supports(ctx context.Context, sourceChainID, destinationChainID string) bool {
+	for _, chainID := range []string{sourceChainID, destinationChainID} {
+		chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+		if err != nil {
+			lmt.Logger(ctx).Warn("error getting chain config", zap.String("chainID", chainID), zap.Error(err))
+			return false
+		}
+		if chainConfig.Type == config.ChainType_SVM {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *skipGoBridge) Quote(ctx context.Context, destinationChainID string, quote *rebalanceQuote) error {
+	return b.r.quoteRebalanceSource(ctx, destinationChainID, quote)
+}
+
+func (b *skipGoBridge) Execute(ctx context.Context, destinationChainID string, allocation rebalanceAllocation) (skipgo.TxHash, error) {
+	return b.r.executeRebalance(ctx, destinationChainID, allocation)
+}
@@ -0,0 +1,858 @@
+package fundrebalancer
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gagliardetto/solana-go"
+	associatedtokenaccount "github.com/gagliardetto/solana-go/programs/associated-token-account"
+	"github.com/gagliardetto/solana-go/rpc"
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/cctp"
+	"github.com/skip-mev/go-fast-solver/shared/clients/circle"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/metrics"
+	"github.com/skip-mev/go-fast-solver/shared/tmrpc"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	cctpTrackerLoopDelay = 10 * time.Second
+	// cctpMaxReceiveAttempts is the number of times submitting a receive tx
+	// for an attested message can fail before the transfer is marked as
+	// failed. The message and attestation stay in the db so that the funds
+	// can be minted manually.
+	cctpMaxReceiveAttempts = 3
+	// cctpBurnInclusionTimeout is how long a burn tx can go without being
+	// included on chain before its transfer is failed, since a burn that was
+	// dropped never sends a message
+	cctpBurnInclusionTimeout = 30 * time.Minute
+	// cctpReceiveInclusionTimeout is how long a receive tx can go without
+	// being included on chain before it is treated as failed and resubmitted
+	cctpReceiveInclusionTimeout = 10 * time.Minute
+	// evmDepositForBurnGasEstimate is used to quote burns from evm chains
+	// whose TokenMessenger has not been approved to spend the solvers usdc
+	// yet, in which case the burn can not be simulated
+	evmDepositForBurnGasEstimate = 200_000
+	// burns from evm chains are attested once the burn is finalized on
+	// Ethereum, while burns from Noble are attested almost immediately and
+	// burns from Solana once they are finalized, which takes under a minute
+	evmCCTPTransferDuration   = 20 * time.Minute
+	nobleCCTPTransferDuration = time.Minute
+	svmCCTPTransferDuration   = time.Minute
+)
+
+// cctpBridge moves usdc between chains by burning it with Circle's CCTP
+// contracts on the source chain and minting it on the destination chain once
+// the burn is attested, without depending on the Skip Go api
+type cctpBridge struct {
+	r                *FundRebalancer
+	cctpChains       map[string]bool
+	attestations     circle.AttestationClient
+	rpcClientManager tmrpc.TendermintRPCClientManager
+}
+
+func newCCTPBridge(
+	r *FundRebalancer,
+	chains map[string]config.ChainConfig,
+	attestations circle.AttestationClient,
+	rpcClientManager tmrpc.TendermintRPCClientManager,
+) *cctpBridge {
+	cctpChains := make(map[string]bool)
+	for _, chain := range chains {
+		if chain.CCTP != nil && (chain.Type == config.ChainType_EVM || chain.Type == config.ChainType_COSMOS || chain.Type == config.ChainType_SVM) {
+			cctpChains[chain.ChainID] = true
+		}
+	}
+	return &cctpBridge{r: r, cctpChains: cctpChains, attestations: attestations, rpcClientManager: rpcClientManager}
+}
+
+func (b *cctpBridge) Name() string {
+	return "cctp"
+}
+
+// Supports returns true if CCTP is configured on both chains
+func (b *cctpBridge) Supports(ctx context.Context, sourceChainID, destinationChainID string) bool {
+	return b.cctpChains[sourceChainID] && b.cctpChains[destinationChainID]
+}
+
+// Quote quotes burning quote.amount usdc on the source chain. CCTP does not
+// charge a fee, so the only cost of moving funds is the gas cost of the burn.
+// Note that the gas cost of receiving the message on the destination chain is
+// not included, since it can not be estimated until the burn is attested.
+func (b *cctpBridge) Quote(ctx context.Context, destinationChainID string, quote *rebalanceQuote) error {
+	burnTx, err := b.burnTx(ctx, quote.sourceChainID, destinationChainID, quote.amount)
+	if err != nil {
+		return fmt.Errorf("building cctp burn tx from chain %s to chain %s: %w", quote.sourceChainID, destinationChainID, err)
+	}
+
+	needsApproval, err := b.r.NeedsERC20Approval(ctx, burnTx)
+	if err != nil {
+		return fmt.Errorf("checking if cctp burn on chain %s needs erc20 approval: %w", quote.sourceChainID, err)
+	}
+	txnWithMetadata := SkipGoTxnWithMetadata{
+		tx:                 burnTx,
+		sourceChainID:      quote.sourceChainID,
+		destinationChainID: destinationChainID,
+		amount:             quote.amount,
+		gasEstimate:        evmDepositForBurnGasEstimate,
+	}
+	if !needsApproval {
+		txnWithMetadata, err = b.r.TxnWithMetadata(ctx, quote.sourceChainID, destinationChainID, quote.amount, burnTx)
+		if err != nil {
+			return fmt.Errorf("getting transaction metadata to burn funds on chain %s: %w", quote.sourceChainID, err)
+		}
+	}
+	if burnTx.SVMTx != nil {
+		// the gas estimate of svm txs is their fee in lamports, and burns on
+		// svm chains also pay rent for the account the message is stored in
+		txnWithMetadata.gasEstimate += svmMessageSentEventRentLamports
+	}
+
+	gasCostUUSDC, err := b.r.gasCostUUSDC(ctx, txnWithMetadata, quote.sourceChainID)
+	if err != nil {
+		return fmt.Errorf("calculating cctp burn gas cost in UUSDC: %w", err)
+	}
+
+	quote.txns = []skipgo.Tx{burnTx}
+	quote.txnWithMetadata = txnWithMetadata
+	quote.routeFeeUUSDC = big.NewInt(0)
+	quote.bridgeFeeUUSDC = big.NewInt(0)
+	quote.gasCostUUSDC = gasCostUUSDC
+	switch {
+	case burnTx.CosmosTx != nil:
+		quote.estimatedDuration = nobleCCTPTransferDuration
+	case burnTx.SVMTx != nil:
+		quote.estimatedDuration = svmCCTPTransferDuration
+	default:
+		quote.estimatedDuration = evmCCTPTransferDuration
+	}
+	return nil
+}
+
+// Execute burns the allocated amount of usdc on the source chain and records
+// the burn so that it is received on the destination chain once attested
+func (b *cctpBridge) Execute(ctx context.Context, destinationChainID string, allocation rebalanceAllocation) (skipgo.TxHash, error) {
+	sourceChainID := allocation.quote.sourceChainID
+	ctx = lmt.With(
+		ctx,
+		zap.String("destinationChainID", destinationChainID),
+		zap.String("sourceChainID", sourceChainID),
+		zap.String("rebalanceAmountUUSDC", allocation.amount.String()),
+	)
+
+	sourceChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(sourceChainID)
+	if err != nil {
+		return "", fmt.Errorf("getting config for chain %s: %w", sourceChainID, err)
+	}
+	destinationChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(destinationChainID)
+	if err != nil {
+		return "", fmt.Errorf("getting config for chain %s: %w", destinationChainID, err)
+	}
+	burnTx, err := b.burnTx(ctx, sourceChainID, destinationChainID, allocation.amount)
+	if err != nil {
+		return "", fmt.Errorf("building cctp burn tx from chain %s to chain %s: %w", sourceChainID, destinationChainID, err)
+	}
+	if err := b.r.submitApproval(ctx, sourceChainID, burnTx, allocation.amount); err != nil {
+		return "", fmt.Errorf("approving cctp burn of %s uusdc on chain %s: %w", allocation.amount.String(), sourceChainID, err)
+	}
+
+	txnWithMetadata := SkipGoTxnWithMetadata{
+		tx:                 burnTx,
+		sourceChainID:      sourceChainID,
+		destinationChainID: destinationChainID,
+		amount:             allocation.amount,
+	}
+	burnHash, rawTx, err := b.r.SignAndSubmitTxn(ctx, txnWithMetadata)
+	if err != nil {
+		return "", fmt.Errorf("signing and submitting cctp burn transaction: %w", err)
+	}
+
+	if err := b.r.recordBridgeTransfer(ctx, txnWithMetadata, burnHash, rawTx, allocation.quote.costUUSDC(allocation.amount), func(ctx context.Context, q db.Querier, rebalanceID int64) error {
+		if _, err := q.InsertCCTPTransfer(ctx, db.InsertCCTPTransferParams{
+			RebalanceTransferID: rebalanceID,
+			SourceChainID:       sourceChainID,
+			DestinationChainID:  destinationChainID,
+			SourceDomain:        int64(sourceChainConfig.CCTP.Domain),
+			DestinationDomain:   int64(destinationChainConfig.CCTP.Domain),
+			BurnTxHash:          string(burnHash),
+		}); err != nil {
+			return fmt.Errorf("inserting cctp transfer for burn with hash %s into db: %w", burnHash, err)
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	lmt.Logger(ctx).Info("submitted cctp burn to rebalance funds", zap.String("txHash", string(burnHash)))
+	return burnHash, nil
+}
+
+// burnTx builds the tx that burns amount usdc on sourceChainID to be minted to
+// the solver on destinationChainID
+func (b *cctpBridge) burnTx(ctx context.Context, sourceChainID, destinationChainID string, amount *big.Int) (skipgo.Tx, error) {
+	sourceChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(sourceChainID)
+	if err != nil {
+		return skipgo.Tx{}, fmt.Errorf("getting config for chain %s: %w", sourceChainID, err)
+	}
+	destinationChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(destinationChainID)
+	if err != nil {
+		return skipgo.Tx{}, fmt.Errorf("getting config for chain %s: %w", destinationChainID, err)
+	}
+	if sourceChainConfig.CCTP == nil || destinationChainConfig.CCTP == nil {
+		return skipgo.Tx{}, fmt.Errorf("cctp is not configured on both chain %s and chain %s", sourceChainID, destinationChainID)
+	}
+
+	recipientAddress := destinationChainConfig.SolverAddress
+	if destinationChainConfig.Type == config.ChainType_SVM {
+		// usdc is minted to the solvers usdc token account on svm chains
+		_, _, tokenAccount, err := svmUSDCTokenAccount(destinationChainConfig)
+		if err != nil {
+			return skipgo.Tx{}, err
+		}
+		recipientAddress = tokenAccount.String()
+	}
+	mintRecipient, err := cctp.MintRecipient(destinationChainConfig.Type, recipientAddress)
+	if err != nil {
+		return skipgo.Tx{}, fmt.Errorf("encoding mint recipient on chain %s: %w", destinationChainID, err)
+	}
+
+	switch sourceChainConfig.Type {
+	case config.ChainType_EVM:
+		data, err := cctp.PackDepositForBurn(amount, destinationChainConfig.CCTP.Domain, mintRecipient, common.HexToAddress(sourceChainConfig.USDCDenom))
+		if err != nil {
+			return skipgo.Tx{}, fmt.Errorf("packing depositForBurn: %w", err)
+		}
+		return skipgo.Tx{EVMTx: &skipgo.EVMTx{
+			ChainID:       sourceChainID,
+			To:            sourceChainConfig.CCTP.TokenMessengerAddress,
+			Value:         "0",
+			Data:          hex.EncodeToString(data),
+			SignerAddress: sourceChainConfig.SolverAddress,
+			RequiredERC20Approvals: []skipgo.ERC20Approval{{
+				TokenContract: sourceChainConfig.USDCDenom,
+				Spender:       sourceChainConfig.CCTP.TokenMessengerAddress,
+				Amount:        amount.String(),
+			}},
+		}}, nil
+	case config.ChainType_COSMOS:
+		return b.r.cosmosTx(sourceChainConfig, &cctp.MsgDepositForBurn{
+			From:              sourceChainConfig.SolverAddress,
+			Amount:            amount.String(),
+			DestinationDomain: destinationChainConfig.CCTP.Domain,
+			MintRecipient:     mintRecipient[:],
+			BurnToken:         sourceChainConfig.USDCDenom,
+		})
+	case config.ChainType_SVM:
+		if !amount.IsUint64() {
+			return skipgo.Tx{}, fmt.Errorf("burn amount %s does not fit in a uint64", amount.String())
+		}
+		tokenMessengerMinter, messageTransmitter, err := svmCCTPPrograms(sourceChainConfig)
+		if err != nil {
+			return skipgo.Tx{}, err
+		}
+		owner, mint, _, err := svmUSDCTokenAccount(sourceChainConfig)
+		if err != nil {
+			return skipgo.Tx{}, err
+		}
+		// the burn message is stored in a new account, which signs the burn
+		// along with the solver
+		messageSentEventAccount, err := solana.NewRandomPrivateKey()
+		if err != nil {
+			return skipgo.Tx{}, fmt.Errorf("generating message sent event account: %w", err)
+		}
+		instruction, err := cctp.SVMDepositForBurnInstruction(
+			tokenMessengerMinter,
+			messageTransmitter,
+			owner,
+			mint,
+			messageSentEventAccount.PublicKey(),
+			amount.Uint64(),
+			destinationChainConfig.CCTP.Domain,
+			mintRecipient,
+		)
+		if err != nil {
+			return skipgo.Tx{}, err
+		}
+		return svmTx(sourceChainConfig, []solana.Instruction{instruction}, messageSentEventAccount)
+	default:
+		return skipgo.Tx{}, fmt.Errorf("cctp is not supported on chain %s of type %s", sourceChainID, sourceChainConfig.Type)
+	}
+}
+
+// receiveTx builds the tx that mints the usdc burned by an attested message
+// on chainID
+func (b *cctpBridge) receiveTx(ctx context.Context, chainID string, message, attestation []byte) (skipgo.Tx, error) {
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return skipgo.Tx{}, fmt.Errorf("getting config for chain %s: %w", chainID, err)
+	}
+	if chainConfig.CCTP == nil {
+		return skipgo.Tx{}, fmt.Errorf("cctp is not configured on chain %s", chainID)
+	}
+
+	switch chainConfig.Type {
+	case config.ChainType_EVM:
+		data, err := cctp.PackReceiveMessage(message, attestation)
+		if err != nil {
+			return skipgo.Tx{}, fmt.Errorf("packing receiveMessage: %w", err)
+		}
+		return skipgo.Tx{EVMTx: &skipgo.EVMTx{
+			ChainID:       chainID,
+			To:            chainConfig.CCTP.MessageTransmitterAddress,
+			Value:         "0",
+			Data:          hex.EncodeToString(data),
+			SignerAddress: chainConfig.SolverAddress,
+		}}, nil
+	case config.ChainType_COSMOS:
+		return b.r.cosmosTx(chainConfig, &cctp.MsgReceiveMessage{
+			From:        chainConfig.SolverAddress,
+			Message:     message,
+			Attestation: attestation,
+		})
+	case config.ChainType_SVM:
+		tokenMessengerMinter, messageTransmitter, err := svmCCTPPrograms(chainConfig)
+		if err != nil {
+			return skipgo.Tx{}, err
+		}
+		owner, mint, tokenAccount, err := svmUSDCTokenAccount(chainConfig)
+		if err != nil {
+			return skipgo.Tx{}, err
+		}
+		client, err := b.r.svmClientManager.GetClient(ctx, chainID)
+		if err != nil {
+			return skipgo.Tx{}, fmt.Errorf("getting svm rpc client for chain %s: %w", chainID, err)
+		}
+		tokenAccountData, err := client.GetAccountData(ctx, tokenAccount)
+		if err != nil {
+			return skipgo.Tx{}, fmt.Errorf("getting usdc token account %s: %w", tokenAccount, err)
+		}
+
+		var instructions []solana.Instruction
+		if tokenAccountData == nil {
+			// the solvers usdc token account is created the first time usdc
+			// is minted to it
+			instructions = append(instructions, associatedtokenaccount.NewCreateInstruction(owner, owner, mint).Build())
+		}
+		instruction, err := cctp.SVMReceiveMessageInstruction(tokenMessengerMinter, messageTransmitter, owner, mint, tokenAccount, message, attestation)
+		if err != nil {
+			return skipgo.Tx{}, err
+		}
+		return svmTx(chainConfig, append(instructions, instruction))
+	default:
+		return skipgo.Tx{}, fmt.Errorf("cctp is not supported on chain %s of type %s", chainID, chainConfig.Type)
+	}
+}
+
+// TrackTransfers advances pending cctp transfers until they are received on
+// their destination chain
+func (b *cctpBridge) TrackTransfers(ctx context.Context) {
+	ticker := time.NewTicker(cctpTrackerLoopDelay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.TrackPendingTransfers(ctx); err != nil {
+				lmt.Logger(ctx).Error("error tracking pending cctp transfers", zap.Error(err))
+			}
+		}
+	}
+}
+
+// TrackPendingTransfers makes a single pass over all pending cctp transfers,
+// advancing each as far as it can
+func (b *cctpBridge) TrackPendingTransfers(ctx context.Context) error {
+	transfers, err := b.r.database.GetPendingCCTPTransfers(ctx)
+	if err != nil {
+		return fmt.Errorf("getting pending cctp transfers: %w", err)
+	}
+
+	for _, transfer := range transfers {
+		if err := b.trackTransfer(ctx, transfer); err != nil {
+			lmt.Logger(ctx).Error(
+				"error tracking cctp transfer",
+				zap.Error(err),
+				zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+				zap.String("burnTxHash", transfer.BurnTxHash),
+				zap.String("sourceChainID", transfer.SourceChainID),
+				zap.String("destinationChainID", transfer.DestinationChainID),
+			)
+		}
+	}
+	return nil
+}
+
+// trackTransfer moves a cctp transfer through its states. The message sent by
+// the burn is read once the burn is included on chain, the attestation for the
+// message is then polled until it is complete, and finally the message is
+// received on the destination chain.
+func (b *cctpBridge) trackTransfer(ctx context.Context, transfer db.CctpTransfer) error {
+	var err error
+	if transfer.Status == dbtypes.CCTPTransferStatusBurned && !transfer.Message.Valid {
+		message, failure, err := b.burnMessage(ctx, transfer)
+		if err != nil {
+			return fmt.Errorf("getting message sent by burn: %w", err)
+		}
+		if failure != "" {
+			return b.failTransfer(ctx, transfer, fmt.Sprintf("burn tx failed: %s", failure))
+		}
+		if message == nil {
+			if time.Since(transfer.CreatedAt) > cctpBurnInclusionTimeout {
+				return b.failTransfer(ctx, transfer, fmt.Sprintf("burn tx was not included within %s", cctpBurnInclusionTimeout))
+			}
+			// burn tx has not been included yet
+			return nil
+		}
+		transfer, err = b.r.database.SetCCTPTransferMessage(ctx, db.SetCCTPTransferMessageParams{
+			Message:     sql.NullString{String: hex.EncodeToString(message), Valid: true},
+			MessageHash: sql.NullString{String: cctp.MessageHash(message).Hex(), Valid: true},
+			ID:          transfer.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("setting cctp transfer message: %w", err)
+		}
+	}
+
+	if transfer.Status == dbtypes.CCTPTransferStatusBurned {
+		attestation, err := b.attestations.GetAttestation(ctx, common.HexToHash(transfer.MessageHash.String))
+		if errors.Is(err, circle.ErrAttestationNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("getting attestation for message %s: %w", transfer.MessageHash.String, err)
+		}
+		if attestation.Status != circle.AttestationStatusComplete {
+			return nil
+		}
+		transfer, err = b.r.database.SetCCTPTransferAttestation(ctx, db.SetCCTPTransferAttestationParams{
+			Attestation: sql.NullString{String: hex.EncodeToString(attestation.Attestation), Valid: true},
+			ID:          transfer.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("setting cctp transfer attestation: %w", err)
+		}
+	}
+
+	if transfer.Status == dbtypes.CCTPTransferStatusAttested {
+		transfer, err = b.submitReceive(ctx, transfer)
+		if err != nil {
+			return fmt.Errorf("submitting receive tx: %w", err)
+		}
+	}
+
+	if transfer.Status == dbtypes.CCTPTransferStatusReceiveSubmitted {
+		included, failure, err := b.txResult(ctx, transfer.DestinationChainID, transfer.ReceiveTxHash.String)
+		if err != nil {
+			return fmt.Errorf("getting receive tx result: %w", err)
+		}
+		if !included {
+			if time.Since(transfer.UpdatedAt) <= cctpReceiveInclusionTimeout {
+				return nil
+			}
+			failure = fmt.Sprintf("not included within %s", cctpReceiveInclusionTimeout)
+		}
+		if failure != "" {
+			// the receive may have failed because the message was already
+			// received by an earlier receive tx that was thought to be dropped
+			received, err := b.messageReceived(ctx, transfer)
+			if err != nil {
+				return fmt.Errorf("checking if message was received: %w", err)
+			}
+			if received {
+				return b.completeTransfer(ctx, transfer)
+			}
+			return b.retryReceive(ctx, transfer, failure)
+		}
+		return b.completeTransfer(ctx, transfer)
+	}
+	return nil
+}
+
+// burnMessage gets the message sent by a transfers burn tx. If the burn tx has
+// not been included on chain yet, no message and no failure are returned.
+func (b *cctpBridge) burnMessage(ctx context.Context, transfer db.CctpTransfer) (message []byte, failure string, err error) {
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(transfer.SourceChainID)
+	if err != nil {
+		return nil, "", fmt.Errorf("getting config for chain %s: %w", transfer.SourceChainID, err)
+	}
+	if chainConfig.CCTP == nil {
+		return nil, "", fmt.Errorf("cctp is not configured on chain %s", transfer.SourceChainID)
+	}
+
+	switch chainConfig.Type {
+	case config.ChainType_EVM:
+		receipt, err := b.evmReceipt(ctx, transfer.SourceChainID, transfer.BurnTxHash)
+		if err != nil || receipt == nil {
+			return nil, "", err
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return nil, "tx reverted", nil
+		}
+		message, err := cctp.MessageSentFromReceipt(receipt, common.HexToAddress(chainConfig.CCTP.MessageTransmitterAddress))
+		return message, "", err
+	case config.ChainType_COSMOS:
+		client, err := b.rpcClientManager.GetClient(ctx, transfer.SourceChainID)
+		if err != nil {
+			return nil, "", fmt.Errorf("getting tendermint rpc client for chain %s: %w", transfer.SourceChainID, err)
+		}
+		txHashBytes, err := hex.DecodeString(transfer.BurnTxHash)
+		if err != nil {
+			return nil, "", fmt.Errorf("decoding tx hash %s: %w", transfer.BurnTxHash, err)
+		}
+		result, err := client.Tx(ctx, txHashBytes, false)
+		if err != nil {
+			if strings.HasSuffix(err.Error(), "not found") {
+				return nil, "", nil
+			}
+			return nil, "", fmt.Errorf("fetching tx result for hash %s: %w", transfer.BurnTxHash, err)
+		}
+		if result.TxResult.Code != 0 {
+			return nil, fmt.Sprintf("code %d: %s", result.TxResult.Code, result.TxResult.Log), nil
+		}
+		message, err := cctp.MessageSentFromEvents(result.TxResult.Events)
+		return message, "", err
+	case config.ChainType_SVM:
+		tx, meta, err := b.svmTxResult(ctx, transfer.SourceChainID, transfer.BurnTxHash)
+		if err != nil || tx == nil {
+			return nil, "", err
+		}
+		if meta.Err != nil {
+			return nil, fmt.Sprintf("%v", meta.Err), nil
+		}
+		tokenMessengerMinter, _, err := svmCCTPPrograms(chainConfig)
+		if err != nil {
+			return nil, "", err
+		}
+		messageSentEventAccount, err := cctp.SVMMessageSentEventAccount(tx, tokenMessengerMinter)
+		if err != nil {
+			return nil, "", fmt.Errorf("getting message sent event account of burn tx %s: %w", transfer.BurnTxHash, err)
+		}
+		client, err := b.r.svmClientManager.GetClient(ctx, transfer.SourceChainID)
+		if err != nil {
+			return nil, "", fmt.Errorf("getting svm rpc client for chain %s: %w", transfer.SourceChainID, err)
+		}
+		data, err := client.GetAccountData(ctx, messageSentEventAccount)
+		if err != nil {
+			return nil, "", fmt.Errorf("getting message sent event account %s: %w", messageSentEventAccount, err)
+		}
+		if data == nil {
+			return nil, "", fmt.Errorf("message sent event account %s of burn tx %s does not exist", messageSentEventAccount, transfer.BurnTxHash)
+		}
+		message, err := cctp.MessageSentFromSVMAccount(data)
+		return message, "", err
+	default:
+		return nil, "", fmt.Errorf("cctp is not supported on chain %s of type %s", transfer.SourceChainID, chainConfig.Type)
+	}
+}
+
+// txResult gets whether a tx on chainID has been included on chain, and the
+// reason it failed if it did not succeed
+func (b *cctpBridge) txResult(ctx context.Context, chainID, txHash string) (included bool, failure string, err error) {
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return false, "", fmt.Errorf("getting config for chain %s: %w", chainID, err)
+	}
+
+	switch chainConfig.Type {
+	case config.ChainType_EVM:
+		receipt, err := b.evmReceipt(ctx, chainID, txHash)
+		if err != nil || receipt == nil {
+			return false, "", err
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return true, "tx reverted", nil
+		}
+		return true, "", nil
+	case config.ChainType_COSMOS:
+		client, err := b.rpcClientManager.GetClient(ctx, chainID)
+		if err != nil {
+			return false, "", fmt.Errorf("getting tendermint rpc client for chain %s: %w", chainID, err)
+		}
+		txHashBytes, err := hex.DecodeString(txHash)
+		if err != nil {
+			return false, "", fmt.Errorf("decoding tx hash %s: %w", txHash, err)
+		}
+		result, err := client.Tx(ctx, txHashBytes, false)
+		if err != nil {
+			if strings.HasSuffix(err.Error(), "not found") {
+				return false, "", nil
+			}
+			return false, "", fmt.Errorf("fetching tx result for hash %s: %w", txHash, err)
+		}
+		if result.TxResult.Code != 0 {
+			return true, fmt.Sprintf("code %d: %s", result.TxResult.Code, result.TxResult.Log), nil
+		}
+		return true, "", nil
+	case config.ChainType_SVM:
+		tx, meta, err := b.svmTxResult(ctx, chainID, txHash)
+		if err != nil || tx == nil {
+			return false, "", err
+		}
+		if meta.Err != nil {
+			return true, fmt.Sprintf("%v", meta.Err), nil
+		}
+		return true, "", nil
+	default:
+		return false, "", fmt.Errorf("cctp is not supported on chain %s of type %s", chainID, chainConfig.Type)
+	}
+}
+
+// evmReceipt gets the receipt of an evm tx, returning a nil receipt if the tx
+// has not been included yet
+func (b *cctpBridge) evmReceipt(ctx context.Context, chainID, txHash string) (*types.Receipt, error) {
+	client, err := b.r.evmClientManager.GetClient(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting evm rpc client for chain %s: %w", chainID, err)
+	}
+	receipt, err := client.GetTxReceipt(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting receipt for tx %s on chain %s: %w", txHash, chainID, err)
+	}
+	return receipt, nil
+}
+
+// svmTxResult gets a landed svm tx and its execution result, returning a nil
+// tx if the tx has not landed yet
+func (b *cctpBridge) svmTxResult(ctx context.Context, chainID, txHash string) (*solana.Transaction, *rpc.TransactionMeta, error) {
+	client, err := b.r.svmClientManager.GetClient(ctx, chainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting svm rpc client for chain %s: %w", chainID, err)
+	}
+	signature, err := solana.SignatureFromBase58(txHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding tx signature %s: %w", txHash, err)
+	}
+	tx, meta, err := client.GetTx(ctx, signature)
+	if errors.Is(err, rpc.ErrNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting tx %s on chain %s: %w", txHash, chainID, err)
+	}
+	if meta == nil {
+		return nil, nil, fmt.Errorf("tx %s on chain %s has no execution result", txHash, chainID)
+	}
+	return tx, meta, nil
+}
+
+// messageReceived gets whether a transfers message has been received on its
+// destination chain, which the MessageTransmitter records by marking the
+// message's nonce from its source domain as used
+func (b *cctpBridge) messageReceived(ctx context.Context, transfer db.CctpTransfer) (bool, error) {
+	message, err := hex.DecodeString(transfer.Message.String)
+	if err != nil {
+		return false, fmt.Errorf("decoding message: %w", err)
+	}
+	sourceDomain, nonce, err := cctp.MessageNonce(message)
+	if err != nil {
+		return false, err
+	}
+	chainID := transfer.DestinationChainID
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return false, fmt.Errorf("getting config for chain %s: %w", chainID, err)
+	}
+	if chainConfig.CCTP == nil {
+		return false, fmt.Errorf("cctp is not configured on chain %s", chainID)
+	}
+
+	switch chainConfig.Type {
+	case config.ChainType_EVM:
+		data, err := cctp.PackUsedNonces(sourceDomain, nonce)
+		if err != nil {
+			return false, fmt.Errorf("packing usedNonces: %w", err)
+		}
+		client, err := b.r.evmClientManager.GetClient(ctx, chainID)
+		if err != nil {
+			return false, fmt.Errorf("getting evm rpc client for chain %s: %w", chainID, err)
+		}
+		messageTransmitter := common.HexToAddress(chainConfig.CCTP.MessageTransmitterAddress)
+		result, err := client.CallContract(ctx, ethereum.CallMsg{To: &messageTransmitter, Data: data}, nil)
+		if err != nil {
+			return false, fmt.Errorf("calling usedNonces on chain %s: %w", chainID, err)
+		}
+		return cctp.UnpackUsedNonces(result)
+	case config.ChainType_COSMOS:
+		request, err := proto.Marshal(&cctp.QueryGetUsedNonceRequest{SourceDomain: sourceDomain, Nonce: nonce})
+		if err != nil {
+			return false, fmt.Errorf("marshalling used nonce query: %w", err)
+		}
+		client, err := b.rpcClientManager.GetClient(ctx, chainID)
+		if err != nil {
+			return false, fmt.Errorf("getting tendermint rpc client for chain %s: %w", chainID, err)
+		}
+		response, err := client.ABCIQuery(ctx, cctp.NobleUsedNonceQueryPath, request)
+		if err != nil {
+			return false, fmt.Errorf("querying used nonce on chain %s: %w", chainID, err)
+		}
+		return cctp.NobleNonceUsed(response.Response)
+	case config.ChainType_SVM:
+		_, messageTransmitter, err := svmCCTPPrograms(chainConfig)
+		if err != nil {
+			return false, err
+		}
+		usedNoncesAccount, err := cctp.SVMUsedNoncesAccount(messageTransmitter, sourceDomain, nonce)
+		if err != nil {
+			return false, err
+		}
+		client, err := b.r.svmClientManager.GetClient(ctx, chainID)
+		if err != nil {
+			return false, fmt.Errorf("getting svm rpc client for chain %s: %w", chainID, err)
+		}
+		data, err := client.GetAccountData(ctx, usedNoncesAccount)
+		if err != nil {
+			return false, fmt.Errorf("getting used nonces account %s: %w", usedNoncesAccount, err)
+		}
+		if data == nil {
+			// the used nonces account is created when the first of its
+			// nonces is used
+			return false, nil
+		}
+		return cctp.SVMNonceUsed(data, nonce)
+	default:
+		return false, fmt.Errorf("cctp is not supported on chain %s of type %s", chainID, chainConfig.Type)
+	}
+}
+
+// submitReceive submits the tx receiving an attested message on the
+// transfers destination chain
+func (b *cctpBridge) submitReceive(ctx context.Context, transfer db.CctpTransfer) (db.CctpTransfer, error) {
+	message, err := hex.DecodeString(transfer.Message.String)
+	if err != nil {
+		return transfer, fmt.Errorf("decoding message: %w", err)
+	}
+	attestation, err := hex.DecodeString(transfer.Attestation.String)
+	if err != nil {
+		return transfer, fmt.Errorf("decoding attestation: %w", err)
+	}
+
+	receiveTx, err := b.receiveTx(ctx, transfer.DestinationChainID, message, attestation)
+	if err != nil {
+		return transfer, fmt.Errorf("building receive tx on chain %s: %w", transfer.DestinationChainID, err)
+	}
+	// the receive tx is submitted on the destination chain, so it is the
+	// source of the tx being submitted
+	receiveHash, rawTx, err := b.r.SignAndSubmitTxn(ctx, SkipGoTxnWithMetadata{
+		tx:                 receiveTx,
+		sourceChainID:      transfer.DestinationChainID,
+		destinationChainID: transfer.DestinationChainID,
+	})
+	if err != nil {
+		return transfer, fmt.Errorf("signing and submitting receive tx on chain %s: %w", transfer.DestinationChainID, err)
+	}
+
+	if _, err := b.r.database.InsertSubmittedTx(ctx, db.InsertSubmittedTxParams{
+		RebalanceTransferID: sql.NullInt64{Int64: transfer.RebalanceTransferID, Valid: true},
+		ChainID:             transfer.DestinationChainID,
+		TxHash:              string(receiveHash),
+		RawTx:               rawTx,
+		TxType:              dbtypes.TxTypeFundRebalnance,
+		TxStatus:            dbtypes.TxStatusPending,
+	}); err != nil {
+		return transfer, fmt.Errorf("inserting submitted tx for cctp receive with hash %s into db: %w", receiveHash, err)
+	}
+
+	transfer, err = b.r.database.SetCCTPTransferReceiveSubmitted(ctx, db.SetCCTPTransferReceiveSubmittedParams{
+		ReceiveTxHash: sql.NullString{String: string(receiveHash), Valid: true},
+		ID:            transfer.ID,
+	})
+	if err != nil {
+		return transfer, fmt.Errorf("setting cctp transfer receive tx hash %s: %w", receiveHash, err)
+	}
+	return transfer, nil
+}
+
+// retryReceive puts a transfer whose receive tx failed back into the attested
+// state so that the receive is resubmitted, or fails the transfer once it has
+// run out of receive attempts
+func (b *cctpBridge) retryReceive(ctx context.Context, transfer db.CctpTransfer, failure string) error {
+	statusMessage := fmt.Sprintf("receive tx %s failed: %s", transfer.ReceiveTxHash.String, failure)
+	if transfer.ReceiveAttempts >= cctpMaxReceiveAttempts {
+		return b.failTransfer(ctx, transfer, statusMessage)
+	}
+
+	lmt.Logger(ctx).Warn(
+		"cctp receive tx failed, resubmitting",
+		zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+		zap.String("destinationChainID", transfer.DestinationChainID),
+		zap.String("failure", statusMessage),
+	)
+	if _, err := b.r.database.SetCCTPTransferStatus(ctx, db.SetCCTPTransferStatusParams{
+		Status:        dbtypes.CCTPTransferStatusAttested,
+		StatusMessage: sql.NullString{String: statusMessage, Valid: true},
+		ID:            transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("setting cctp transfer status to %s: %w", dbtypes.CCTPTransferStatusAttested, err)
+	}
+	return nil
+}
+
+func (b *cctpBridge) completeTransfer(ctx context.Context, transfer db.CctpTransfer) error {
+	if _, err := b.r.database.SetCCTPTransferStatus(ctx, db.SetCCTPTransferStatusParams{
+		Status: dbtypes.CCTPTransferStatusReceived,
+		ID:     transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("setting cctp transfer status to %s: %w", dbtypes.CCTPTransferStatusReceived, err)
+	}
+	if err := b.r.database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{
+		Status: dbtypes.RebalanceTransferStatusSuccess,
+		ID:     transfer.RebalanceTransferID,
+	}); err != nil {
+		return fmt.Errorf("updating rebalance transfer status to %s: %w", dbtypes.RebalanceTransferStatusSuccess, err)
+	}
+	metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(transfer.SourceChainID, transfer.DestinationChainID, dbtypes.RebalanceTransferStatusSuccess)
+
+	lmt.Logger(ctx).Info(
+		"cctp rebalance transfer received",
+		zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+		zap.String("sourceChainID", transfer.SourceChainID),
+		zap.String("destinationChainID", transfer.DestinationChainID),
+		zap.String("receiveTxHash", transfer.ReceiveTxHash.String),
+	)
+	return nil
+}
+
+func (b *cctpBridge) failTransfer(ctx context.Context, transfer db.CctpTransfer, reason string) error {
+	if _, err := b.r.database.SetCCTPTransferStatus(ctx, db.SetCCTPTransferStatusParams{
+		Status:        dbtypes.CCTPTransferStatusFailed,
+		StatusMessage: sql.NullString{String: reason, Valid: true},
+		ID:            transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("setting cctp transfer status to %s: %w", dbtypes.CCTPTransferStatusFailed, err)
+	}
+	if err := b.r.database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{
+		Status: dbtypes.RebalanceTransferStatusFailed,
+		ID:     transfer.RebalanceTransferID,
+	}); err != nil {
+		return fmt.Errorf("updating rebalance transfer status to %s: %w", dbtypes.RebalanceTransferStatusFailed, err)
+	}
+	metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(transfer.SourceChainID, transfer.DestinationChainID, dbtypes.RebalanceTransferStatusFailed)
+
+	lmt.Logger(ctx).Error(
+		"cctp rebalance transfer failed",
+		zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+		zap.String("sourceChainID", transfer.SourceChainID),
+		zap.String("destinationChainID", transfer.DestinationChainID),
+		zap.String("reason", reason),
+	)
+	return nil
+}
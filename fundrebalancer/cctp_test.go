@@ -0,0 +1,487 @@
+package fundrebalancer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	mock_database "github.com/skip-mev/go-fast-solver/mocks/fundrebalancer"
+	mock_circle "github.com/skip-mev/go-fast-solver/mocks/shared/clients/circle"
+	mock_skipgo "github.com/skip-mev/go-fast-solver/mocks/shared/clients/skipgo"
+	mock_config "github.com/skip-mev/go-fast-solver/mocks/shared/config"
+	mock_evmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/evmrpc"
+	mock_oracle "github.com/skip-mev/go-fast-solver/mocks/shared/oracle"
+	mock_svmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/svmrpc"
+	mock_cosmos "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/cosmos"
+	evm2 "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/evm"
+	"github.com/skip-mev/go-fast-solver/shared/cctp"
+	"github.com/skip-mev/go-fast-solver/shared/clients/circle"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/keys"
+	"github.com/skip-mev/go-fast-solver/shared/svm/contracts/cctp/message_transmitter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ethMessageTransmitter      = "0x0a992d191DEeC32aFe36203Ad87D7d289a738F81"
+	arbitrumMessageTransmitter = "0xC30362313FBBA5cf9163F0bb16a0e01f01A896ca"
+
+	solanaChainID              = "solana"
+	solanaUSDCMint             = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	solanaTokenMessengerMinter = "CCTPiPYPc6AsJuwueEnWgSgucamXDZwBd53dQ11YiKX3"
+	solanaMessageTransmitter   = "CCTPmbSD7gX1bxKPAmg77w8oFzNFpaQiQUWD43TKaecd"
+)
+
+// cctpMessage builds a cctp message with the header fields that are read from
+// messages, sourceDomain and nonce, set
+func cctpMessage(sourceDomain uint32, nonce uint64) []byte {
+	message := make([]byte, 248)
+	binary.BigEndian.PutUint32(message[4:], sourceDomain)
+	binary.BigEndian.PutUint64(message[12:], nonce)
+	return message
+}
+
+func TestCCTPBridge_TrackPendingTransfers(t *testing.T) {
+	burnHash := "0xburn"
+	receiveHash := "0xreceive"
+	message := cctpMessage(0, 42)
+	attestation := []byte("attestation")
+
+	setup := func(t *testing.T) (context.Context, *FundRebalancer, *mock_database.FakeDatabase, *mock_evmrpc.MockEVMChainRPC, *mock_evmrpc.MockEVMChainRPC, *evm2.MockEVMTxExecutor, *mock_circle.FakeAttestationService) {
+		ctx := context.Background()
+		ethConfig := config.ChainConfig{
+			ChainID:       ethChainID,
+			Type:          config.ChainType_EVM,
+			USDCDenom:     ethUSDCDenom,
+			SolverAddress: ethAddress,
+			CCTP: &config.CCTPConfig{
+				Domain:                    0,
+				TokenMessengerAddress:     "0xBd3fa81B58Ba92a82136038B25aDec7066af3155",
+				MessageTransmitterAddress: ethMessageTransmitter,
+			},
+		}
+		arbitrumConfig := config.ChainConfig{
+			ChainID:       arbitrumChainID,
+			Type:          config.ChainType_EVM,
+			USDCDenom:     arbitrumUSDCDenom,
+			SolverAddress: arbitrumAddress,
+			CCTP: &config.CCTPConfig{
+				Domain:                    3,
+				TokenMessengerAddress:     "0x19330d10D9Cc8751218eaf51E8885D058642E08A",
+				MessageTransmitterAddress: arbitrumMessageTransmitter,
+			},
+		}
+		mockConfigReader := mock_config.NewMockConfigReader(t)
+		mockConfigReader.On("Config").Return(config.Config{
+			Chains: map[string]config.ChainConfig{"ethereum": ethConfig, "arbitrum": arbitrumConfig},
+		})
+		mockConfigReader.On("GetChainConfig", ethChainID).Return(ethConfig, nil).Maybe()
+		mockConfigReader.On("GetChainConfig", arbitrumChainID).Return(arbitrumConfig, nil).Maybe()
+		ctx = config.ConfigReaderContext(ctx, mockConfigReader)
+
+		f, err := loadKeysFile(defaultKeys)
+		require.NoError(t, err)
+		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
+		require.NoError(t, err)
+
+		mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+		mockEthClient := mock_evmrpc.NewMockEVMChainRPC(t)
+		mockArbitrumClient := mock_evmrpc.NewMockEVMChainRPC(t)
+		mockEVMClientManager.EXPECT().GetClient(mockContext, ethChainID).Return(mockEthClient, nil).Maybe()
+		mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockArbitrumClient, nil).Maybe()
+		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		database := mock_database.NewFakeDatabase()
+
+		rebalancer, err := NewFundRebalancer(
+			ctx,
+			keystore,
+			mock_skipgo.NewMockSkipGoClient(t),
+			mockEVMClientManager,
+			database,
+			mock_oracle.NewMockTxPriceOracle(t),
+			mockEVMTxExecutor,
+			mock_cosmos.NewMockCosmosTxExecutor(t),
+		)
+		require.NoError(t, err)
+		assert.True(t, rebalancer.cctpBridge.Supports(ctx, ethChainID, arbitrumChainID))
+		assert.False(t, rebalancer.cctpBridge.Supports(ctx, ethChainID, osmosisChainID))
+
+		attestationService := mock_circle.NewFakeAttestationService()
+		t.Cleanup(attestationService.Close)
+		rebalancer.cctpBridge.attestations = circle.NewAttestationClient(http.DefaultClient, attestationService.URL())
+
+		rebalanceID, err := database.InsertRebalanceTransfer(ctx, db.InsertRebalanceTransferParams{
+			TxHash:             burnHash,
+			SourceChainID:      ethChainID,
+			DestinationChainID: arbitrumChainID,
+			Amount:             "100",
+		})
+		require.NoError(t, err)
+		_, err = database.InsertCCTPTransfer(ctx, db.InsertCCTPTransferParams{
+			RebalanceTransferID: rebalanceID,
+			SourceChainID:       ethChainID,
+			DestinationChainID:  arbitrumChainID,
+			SourceDomain:        0,
+			DestinationDomain:   3,
+			BurnTxHash:          burnHash,
+		})
+		require.NoError(t, err)
+
+		return ctx, rebalancer, database, mockEthClient, mockArbitrumClient, mockEVMTxExecutor, attestationService
+	}
+
+	burnReceipt := func(t *testing.T) *types.Receipt {
+		log, err := cctp.MessageSentLog(common.HexToAddress(ethMessageTransmitter), message)
+		require.NoError(t, err)
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{log}}
+	}
+
+	usedNoncesCall := func(t *testing.T) interface{} {
+		data, err := cctp.PackUsedNonces(0, 42)
+		require.NoError(t, err)
+		return mock.MatchedBy(func(call ethereum.CallMsg) bool {
+			return call.To != nil && *call.To == common.HexToAddress(arbitrumMessageTransmitter) && bytes.Equal(call.Data, data)
+		})
+	}
+	usedNoncesResult := func(used int64) []byte {
+		return common.LeftPadBytes(big.NewInt(used).Bytes(), 32)
+	}
+
+	t.Run("burn is received on destination chain once attested", func(t *testing.T) {
+		ctx, rebalancer, database, mockEthClient, mockArbitrumClient, mockEVMTxExecutor, attestationService := setup(t)
+
+		// burn not yet included
+		mockEthClient.EXPECT().GetTxReceipt(mockContext, burnHash).Return(nil, ethereum.NotFound).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		assert.False(t, database.GetCCTPContents()[0].Message.Valid)
+
+		// burn included, attestation pending
+		mockEthClient.EXPECT().GetTxReceipt(mockContext, burnHash).Return(burnReceipt(t), nil).Once()
+		attestationService.SetPending(cctp.MessageHash(message))
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		transfer := database.GetCCTPContents()[0]
+		assert.Equal(t, dbtypes.CCTPTransferStatusBurned, transfer.Status)
+		assert.Equal(t, cctp.MessageHash(message).Hex(), transfer.MessageHash.String)
+
+		// attested, receive submitted on arbitrum
+		attestationService.Attest(cctp.MessageHash(message), attestation)
+		mockEVMTxExecutor.EXPECT().ExecuteTx(mockContext, arbitrumChainID, arbitrumAddress, mockContext, "0", arbitrumMessageTransmitter, mockContext).Return(receiveHash, "rawTx", nil).Once()
+		mockArbitrumClient.EXPECT().GetTxReceipt(mockContext, receiveHash).Return(nil, ethereum.NotFound).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		transfer = database.GetCCTPContents()[0]
+		assert.Equal(t, dbtypes.CCTPTransferStatusReceiveSubmitted, transfer.Status)
+		assert.Equal(t, receiveHash, transfer.ReceiveTxHash.String)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusPending, database.GetDBContents()[0].Status)
+
+		// receive included
+		mockArbitrumClient.EXPECT().GetTxReceipt(mockContext, receiveHash).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.CCTPTransferStatusReceived, database.GetCCTPContents()[0].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusSuccess, database.GetDBContents()[0].Status)
+	})
+
+	t.Run("reverted receive is retried until out of attempts", func(t *testing.T) {
+		ctx, rebalancer, database, mockEthClient, mockArbitrumClient, mockEVMTxExecutor, attestationService := setup(t)
+
+		mockEthClient.EXPECT().GetTxReceipt(mockContext, burnHash).Return(burnReceipt(t), nil).Once()
+		attestationService.Attest(cctp.MessageHash(message), attestation)
+		mockEVMTxExecutor.EXPECT().ExecuteTx(mockContext, arbitrumChainID, arbitrumAddress, mockContext, "0", arbitrumMessageTransmitter, mockContext).Return(receiveHash, "rawTx", nil).Times(cctpMaxReceiveAttempts)
+		mockArbitrumClient.EXPECT().GetTxReceipt(mockContext, receiveHash).Return(&types.Receipt{Status: types.ReceiptStatusFailed}, nil).Times(cctpMaxReceiveAttempts)
+		mockArbitrumClient.EXPECT().CallContract(mockContext, usedNoncesCall(t), (*big.Int)(nil)).Return(usedNoncesResult(0), nil).Times(cctpMaxReceiveAttempts)
+
+		for i := 0; i < cctpMaxReceiveAttempts-1; i++ {
+			require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+			assert.Equal(t, dbtypes.CCTPTransferStatusAttested, database.GetCCTPContents()[0].Status)
+		}
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+
+		transfer := database.GetCCTPContents()[0]
+		assert.Equal(t, dbtypes.CCTPTransferStatusFailed, transfer.Status)
+		assert.Equal(t, int64(cctpMaxReceiveAttempts), transfer.ReceiveAttempts)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusFailed, database.GetDBContents()[0].Status)
+	})
+
+	t.Run("reverted receive of an already received message completes the transfer", func(t *testing.T) {
+		ctx, rebalancer, database, mockEthClient, mockArbitrumClient, mockEVMTxExecutor, attestationService := setup(t)
+
+		mockEthClient.EXPECT().GetTxReceipt(mockContext, burnHash).Return(burnReceipt(t), nil).Once()
+		attestationService.Attest(cctp.MessageHash(message), attestation)
+		mockEVMTxExecutor.EXPECT().ExecuteTx(mockContext, arbitrumChainID, arbitrumAddress, mockContext, "0", arbitrumMessageTransmitter, mockContext).Return(receiveHash, "rawTx", nil).Once()
+		mockArbitrumClient.EXPECT().GetTxReceipt(mockContext, receiveHash).Return(&types.Receipt{Status: types.ReceiptStatusFailed}, nil).Once()
+		mockArbitrumClient.EXPECT().CallContract(mockContext, usedNoncesCall(t), (*big.Int)(nil)).Return(usedNoncesResult(1), nil).Once()
+
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.CCTPTransferStatusReceived, database.GetCCTPContents()[0].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusSuccess, database.GetDBContents()[0].Status)
+	})
+
+	t.Run("receive that is not included in time is resubmitted", func(t *testing.T) {
+		ctx, rebalancer, database, mockEthClient, mockArbitrumClient, mockEVMTxExecutor, attestationService := setup(t)
+
+		mockEthClient.EXPECT().GetTxReceipt(mockContext, burnHash).Return(burnReceipt(t), nil).Once()
+		attestationService.Attest(cctp.MessageHash(message), attestation)
+		mockEVMTxExecutor.EXPECT().ExecuteTx(mockContext, arbitrumChainID, arbitrumAddress, mockContext, "0", arbitrumMessageTransmitter, mockContext).Return(receiveHash, "rawTx", nil).Once()
+		mockArbitrumClient.EXPECT().GetTxReceipt(mockContext, receiveHash).Return(nil, ethereum.NotFound).Twice()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.CCTPTransferStatusReceiveSubmitted, database.GetCCTPContents()[0].Status)
+
+		// receive dropped without the message being received
+		transfer := database.GetCCTPContents()[0]
+		database.SetCCTPTransferTimes(transfer.ID, transfer.CreatedAt, time.Now().Add(-cctpReceiveInclusionTimeout-time.Minute))
+		mockArbitrumClient.EXPECT().CallContract(mockContext, usedNoncesCall(t), (*big.Int)(nil)).Return(usedNoncesResult(0), nil).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		transfer = database.GetCCTPContents()[0]
+		assert.Equal(t, dbtypes.CCTPTransferStatusAttested, transfer.Status)
+		assert.Contains(t, transfer.StatusMessage.String, "not included")
+
+		mockEVMTxExecutor.EXPECT().ExecuteTx(mockContext, arbitrumChainID, arbitrumAddress, mockContext, "0", arbitrumMessageTransmitter, mockContext).Return("0xreceive2", "rawTx", nil).Once()
+		mockArbitrumClient.EXPECT().GetTxReceipt(mockContext, "0xreceive2").Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		transfer = database.GetCCTPContents()[0]
+		assert.Equal(t, dbtypes.CCTPTransferStatusReceived, transfer.Status)
+		assert.Equal(t, int64(2), transfer.ReceiveAttempts)
+	})
+
+	t.Run("burn that is not included in time fails the transfer", func(t *testing.T) {
+		ctx, rebalancer, database, mockEthClient, _, _, _ := setup(t)
+
+		transfer := database.GetCCTPContents()[0]
+		database.SetCCTPTransferTimes(transfer.ID, time.Now().Add(-cctpBurnInclusionTimeout-time.Minute), transfer.UpdatedAt)
+		mockEthClient.EXPECT().GetTxReceipt(mockContext, burnHash).Return(nil, ethereum.NotFound).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+
+		transfer = database.GetCCTPContents()[0]
+		assert.Equal(t, dbtypes.CCTPTransferStatusFailed, transfer.Status)
+		assert.Contains(t, transfer.StatusMessage.String, "not included")
+		assert.Equal(t, dbtypes.RebalanceTransferStatusFailed, database.GetDBContents()[0].Status)
+	})
+}
+
+func TestCCTPBridge_TrackPendingSVMTransfers(t *testing.T) {
+	message := []byte("cctp message")
+	attestation := []byte("attestation")
+	receiveHash := "0xreceive"
+	// usdc burned on solana is minted to the solvers ethereum address, so it
+	// must be a full hex address
+	ethSolverAddress := common.HexToAddress(ethAddress).Hex()
+	solanaKey, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+
+	setup := func(t *testing.T) (context.Context, *FundRebalancer, *mock_database.FakeDatabase, *mock_svmrpc.MockSVMChainRPC, *mock_evmrpc.MockEVMChainRPC, *evm2.MockEVMTxExecutor, *mock_circle.FakeAttestationService) {
+		ctx := context.Background()
+		ethConfig := config.ChainConfig{
+			ChainID:       ethChainID,
+			Type:          config.ChainType_EVM,
+			USDCDenom:     ethUSDCDenom,
+			SolverAddress: ethSolverAddress,
+			CCTP: &config.CCTPConfig{
+				Domain:                    0,
+				TokenMessengerAddress:     "0xBd3fa81B58Ba92a82136038B25aDec7066af3155",
+				MessageTransmitterAddress: ethMessageTransmitter,
+			},
+		}
+		solanaConfig := config.ChainConfig{
+			ChainID:       solanaChainID,
+			Type:          config.ChainType_SVM,
+			USDCDenom:     solanaUSDCMint,
+			SolverAddress: solanaKey.PublicKey().String(),
+			SVM:           &config.SVMConfig{},
+			CCTP: &config.CCTPConfig{
+				Domain:                    5,
+				TokenMessengerAddress:     solanaTokenMessengerMinter,
+				MessageTransmitterAddress: solanaMessageTransmitter,
+			},
+		}
+		mockConfigReader := mock_config.NewMockConfigReader(t)
+		mockConfigReader.On("Config").Return(config.Config{
+			Chains: map[string]config.ChainConfig{"ethereum": ethConfig, "solana": solanaConfig},
+		})
+		mockConfigReader.On("GetChainConfig", ethChainID).Return(ethConfig, nil).Maybe()
+		mockConfigReader.On("GetChainConfig", solanaChainID).Return(solanaConfig, nil).Maybe()
+		ctx = config.ConfigReaderContext(ctx, mockConfigReader)
+
+		f, err := loadKeysFile(defaultKeys)
+		require.NoError(t, err)
+		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
+		require.NoError(t, err)
+
+		mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+		mockEthClient := mock_evmrpc.NewMockEVMChainRPC(t)
+		mockEVMClientManager.EXPECT().GetClient(mockContext, ethChainID).Return(mockEthClient, nil).Maybe()
+		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		database := mock_database.NewFakeDatabase()
+
+		rebalancer, err := NewFundRebalancer(
+			ctx,
+			keystore,
+			mock_skipgo.NewMockSkipGoClient(t),
+			mockEVMClientManager,
+			database,
+			mock_oracle.NewMockTxPriceOracle(t),
+			mockEVMTxExecutor,
+			mock_cosmos.NewMockCosmosTxExecutor(t),
+		)
+		require.NoError(t, err)
+		assert.True(t, rebalancer.cctpBridge.Supports(ctx, solanaChainID, ethChainID))
+		assert.False(t, (&skipGoBridge{r: rebalancer}).Supports(ctx, solanaChainID, ethChainID))
+
+		mockSVMClientManager := mock_svmrpc.NewMockSVMRPCClientManager(t)
+		mockSolanaClient := mock_svmrpc.NewMockSVMChainRPC(t)
+		mockSVMClientManager.EXPECT().GetClient(mockContext, solanaChainID).Return(mockSolanaClient, nil).Maybe()
+		rebalancer.svmClientManager = mockSVMClientManager
+
+		attestationService := mock_circle.NewFakeAttestationService()
+		t.Cleanup(attestationService.Close)
+		rebalancer.cctpBridge.attestations = circle.NewAttestationClient(http.DefaultClient, attestationService.URL())
+
+		return ctx, rebalancer, database, mockSolanaClient, mockEthClient, mockEVMTxExecutor, attestationService
+	}
+
+	// insertBurn builds a burn of usdc on solana and records it as pending
+	insertBurn := func(t *testing.T, ctx context.Context, rebalancer *FundRebalancer, database *mock_database.FakeDatabase) (*solana.Transaction, solana.Signature) {
+		burnTx, err := rebalancer.cctpBridge.burnTx(ctx, solanaChainID, ethChainID, big.NewInt(100))
+		require.NoError(t, err)
+		require.NotNil(t, burnTx.SVMTx)
+		// the message sent event account must sign the burn
+		require.Len(t, burnTx.SVMTx.AdditionalSigners, 1)
+
+		tx, err := decodeSVMTx(burnTx.SVMTx)
+		require.NoError(t, err)
+		_, err = tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
+			if key.Equals(solanaKey.PublicKey()) {
+				return &solanaKey
+			}
+			return &burnTx.SVMTx.AdditionalSigners[0]
+		})
+		require.NoError(t, err)
+		burnHash := tx.Signatures[0]
+
+		rebalanceID, err := database.InsertRebalanceTransfer(ctx, db.InsertRebalanceTransferParams{
+			TxHash:             burnHash.String(),
+			SourceChainID:      solanaChainID,
+			DestinationChainID: ethChainID,
+			Amount:             "100",
+		})
+		require.NoError(t, err)
+		_, err = database.InsertCCTPTransfer(ctx, db.InsertCCTPTransferParams{
+			RebalanceTransferID: rebalanceID,
+			SourceChainID:       solanaChainID,
+			DestinationChainID:  ethChainID,
+			SourceDomain:        5,
+			DestinationDomain:   0,
+			BurnTxHash:          burnHash.String(),
+		})
+		require.NoError(t, err)
+		return tx, burnHash
+	}
+
+	t.Run("burn message is read from the message sent event account", func(t *testing.T) {
+		ctx, rebalancer, database, mockSolanaClient, mockEthClient, mockEVMTxExecutor, attestationService := setup(t)
+		tx, burnHash := insertBurn(t, ctx, rebalancer, database)
+
+		var messageSent bytes.Buffer
+		require.NoError(t, bin.NewBorshEncoder(&messageSent).Encode(message_transmitter.MessageSent{
+			RentPayer: solanaKey.PublicKey(),
+			Message:   message,
+		}))
+		messageSentEventAccount, err := cctp.SVMMessageSentEventAccount(tx, solana.MustPublicKeyFromBase58(solanaTokenMessengerMinter))
+		require.NoError(t, err)
+
+		// burn not yet landed
+		mockSolanaClient.EXPECT().GetTx(mockContext, burnHash).Return(nil, nil, rpc.ErrNotFound).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		assert.False(t, database.GetCCTPContents()[0].Message.Valid)
+
+		// burn landed, attestation pending
+		mockSolanaClient.EXPECT().GetTx(mockContext, burnHash).Return(tx, &rpc.TransactionMeta{}, nil).Once()
+		mockSolanaClient.EXPECT().GetAccountData(mockContext, messageSentEventAccount).Return(messageSent.Bytes(), nil).Once()
+		attestationService.SetPending(cctp.MessageHash(message))
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		transfer := database.GetCCTPContents()[0]
+		assert.Equal(t, dbtypes.CCTPTransferStatusBurned, transfer.Status)
+		assert.Equal(t, cctp.MessageHash(message).Hex(), transfer.MessageHash.String)
+
+		// attested, receive submitted on ethereum
+		attestationService.Attest(cctp.MessageHash(message), attestation)
+		mockEVMTxExecutor.EXPECT().ExecuteTx(mockContext, ethChainID, ethSolverAddress, mockContext, "0", ethMessageTransmitter, mockContext).Return(receiveHash, "rawTx", nil).Once()
+		mockEthClient.EXPECT().GetTxReceipt(mockContext, receiveHash).Return(nil, ethereum.NotFound).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		transfer = database.GetCCTPContents()[0]
+		assert.Equal(t, dbtypes.CCTPTransferStatusReceiveSubmitted, transfer.Status)
+		assert.Equal(t, receiveHash, transfer.ReceiveTxHash.String)
+	})
+
+	t.Run("failed burn fails the transfer", func(t *testing.T) {
+		ctx, rebalancer, database, mockSolanaClient, _, _, _ := setup(t)
+		tx, burnHash := insertBurn(t, ctx, rebalancer, database)
+
+		mockSolanaClient.EXPECT().GetTx(mockContext, burnHash).Return(tx, &rpc.TransactionMeta{
+			Err: map[string]any{"InstructionError": []any{2, "Custom"}},
+		}, nil).Once()
+		require.NoError(t, rebalancer.cctpBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.CCTPTransferStatusFailed, database.GetCCTPContents()[0].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusFailed, database.GetDBContents()[0].Status)
+	})
+}
+
+func TestCCTPBridge_RecordBurn(t *testing.T) {
+	txnWithMetadata := SkipGoTxnWithMetadata{
+		sourceChainID:      arbitrumChainID,
+		destinationChainID: osmosisChainID,
+		amount:             big.NewInt(100),
+	}
+	insertCCTPTransfer := func(ctx context.Context, q db.Querier, rebalanceID int64) error {
+		_, err := q.InsertCCTPTransfer(ctx, db.InsertCCTPTransferParams{
+			RebalanceTransferID: rebalanceID,
+			SourceChainID:       arbitrumChainID,
+			DestinationChainID:  osmosisChainID,
+			BurnTxHash:          "burnhash",
+		})
+		return err
+	}
+
+	t.Run("burn is recorded with its rebalance transfer", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		r := &FundRebalancer{database: database}
+
+		err := r.recordBridgeTransfer(context.Background(), txnWithMetadata, "burnhash", "", big.NewInt(1), insertCCTPTransfer)
+		require.NoError(t, err)
+
+		require.Len(t, database.GetDBContents(), 1)
+		require.Len(t, database.GetCCTPContents(), 1)
+		assert.Equal(t, database.GetDBContents()[0].ID, database.GetCCTPContents()[0].RebalanceTransferID)
+	})
+
+	t.Run("rebalance transfer is not recorded when recording the burn fails", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		r := &FundRebalancer{database: database}
+
+		err := r.recordBridgeTransfer(context.Background(), txnWithMetadata, "burnhash", "", big.NewInt(1), func(ctx context.Context, q db.Querier, rebalanceID int64) error {
+			if err := insertCCTPTransfer(ctx, q, rebalanceID); err != nil {
+				return err
+			}
+			return errors.New("db error")
+		})
+		require.Error(t, err)
+
+		// the transfer tracker must not pick up a rebalance transfer without
+		// its burn
+		assert.Empty(t, database.GetDBContents())
+		assert.Empty(t, database.GetCCTPContents())
+	})
+}
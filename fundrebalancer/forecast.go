@@ -0,0 +1,219 @@
+package fundrebalancer
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const day = 24 * time.Hour
+
+// orderFlowForecast is the amount of uusdc expected to flow out of and into a
+// chain over the next rebalance lead time
+type orderFlowForecast struct {
+	// outflow is the uusdc expected to be spent filling orders to the chain
+	outflow *big.Int
+	// inflow is the uusdc expected to be paid out to the chain by settlements
+	inflow *big.Int
+}
+
+// netOutflow is the amount of uusdc the chain is expected to lose over the
+// forecast lead time, or 0 if it is expected to gain funds
+func (f orderFlowForecast) netOutflow() *big.Int {
+	net := new(big.Int).Sub(f.outflow, f.inflow)
+	if net.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return net
+}
+
+// forecastOrderFlow forecasts the order flow on chainID over the next lead
+// time by averaging the order flow during the same time of day window over
+// each of the lookback days. Every order to the chain counts towards the
+// outflow, not only filled orders, so that orders lost to insufficient
+// balance are part of the forecast.
+func (r *FundRebalancer) forecastOrderFlow(
+	ctx context.Context,
+	chainID string,
+	forecastConfig config.FundRebalancerForecastConfig,
+	now time.Time,
+) (orderFlowForecast, error) {
+	lookbackDays := forecastConfig.LookbackDays
+	if lookbackDays <= 0 {
+		lookbackDays = config.DefaultForecastLookbackDays
+	}
+	now = now.UTC()
+	since := now.Add(-time.Duration(lookbackDays) * day)
+
+	orders, err := r.database.GetOrderOutflowsToChainSince(ctx, db.GetOrderOutflowsToChainSinceParams{
+		DestinationChainID: chainID,
+		CreatedAt:          since,
+	})
+	if err != nil {
+		return orderFlowForecast{}, fmt.Errorf("getting orders to chain %s since %s: %w", chainID, since, err)
+	}
+	settlements, err := r.database.GetSettlementInflowsToChainSince(ctx, db.GetSettlementInflowsToChainSinceParams{
+		SourceChainID:    chainID,
+		SettlementStatus: dbtypes.SettlementStatusComplete,
+		UpdatedAt:        since,
+	})
+	if err != nil {
+		return orderFlowForecast{}, fmt.Errorf("getting settlements paid out on chain %s since %s: %w", chainID, since, err)
+	}
+
+	outflow := big.NewInt(0)
+	for _, order := range orders {
+		if !inForecastWindow(order.CreatedAt, now, forecastConfig.LeadTime) {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(order.AmountOut, 10)
+		if !ok {
+			return orderFlowForecast{}, fmt.Errorf("could not convert order amount out %s to *big.Int", order.AmountOut)
+		}
+		outflow.Add(outflow, amount)
+	}
+
+	inflow := big.NewInt(0)
+	for _, settlement := range settlements {
+		if !inForecastWindow(settlement.UpdatedAt, now, forecastConfig.LeadTime) {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(settlement.Amount, 10)
+		if !ok {
+			return orderFlowForecast{}, fmt.Errorf("could not convert settlement amount %s to *big.Int", settlement.Amount)
+		}
+		inflow.Add(inflow, amount)
+	}
+
+	days := big.NewInt(int64(lookbackDays))
+	return orderFlowForecast{
+		outflow: outflow.Div(outflow, days),
+		inflow:  inflow.Div(inflow, days),
+	}, nil
+}
+
+// forecastCacheContextKey is the context key of the forecasts cached during a
+// single rebalance loop
+type forecastCacheContextKey struct{}
+
+// forecastCache caches the order flow forecast of each chain. Forecasts query
+// the order and settlement history of a chain over all lookback days, so they
+// are computed once per rebalance loop rather than every time a chains
+// rebalance thresholds are needed.
+type forecastCache struct {
+	lock      sync.Mutex
+	forecasts map[string]orderFlowForecast
+}
+
+// withForecastCache returns a context in which each chains order flow is only
+// forecasted once, for use over a single rebalance loop
+func withForecastCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forecastCacheContextKey{}, &forecastCache{forecasts: make(map[string]orderFlowForecast)})
+}
+
+// cachedForecastOrderFlow forecasts the order flow on chainID, reusing the
+// forecast cached in ctx if the chain has already been forecasted during this
+// rebalance loop
+func (r *FundRebalancer) cachedForecastOrderFlow(
+	ctx context.Context,
+	chainID string,
+	forecastConfig config.FundRebalancerForecastConfig,
+) (orderFlowForecast, error) {
+	cache, ok := ctx.Value(forecastCacheContextKey{}).(*forecastCache)
+	if !ok {
+		return r.forecastOrderFlow(ctx, chainID, forecastConfig, time.Now())
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if forecast, ok := cache.forecasts[chainID]; ok {
+		return forecast, nil
+	}
+	forecast, err := r.forecastOrderFlow(ctx, chainID, forecastConfig, time.Now())
+	if err != nil {
+		return orderFlowForecast{}, err
+	}
+	cache.forecasts[chainID] = forecast
+	return forecast, nil
+}
+
+// inForecastWindow returns true if t falls within leadTime after the time of
+// day of now on a previous day
+func inForecastWindow(t time.Time, now time.Time, leadTime time.Duration) bool {
+	elapsed := now.Sub(t)
+	if elapsed <= 0 {
+		return false
+	}
+	timeOfDayOffset := (day - elapsed%day) % day
+	return timeOfDayOffset < leadTime
+}
+
+// rebalanceThresholds gets the balance below which chainID is rebalanced and
+// the balance it is rebalanced up to. These are the configured min allowed and
+// target amounts, raised to cover the forecasted net outflow if forecasting is
+// enabled for the chain. The forecast is reused for the rest of the rebalance
+// loop if ctx was created by withForecastCache.
+func (r *FundRebalancer) rebalanceThresholds(ctx context.Context, chainID string) (minAllowedAmount, targetAmount *big.Int, err error) {
+	chainConfig := r.config[chainID]
+	minAllowedAmount, ok := new(big.Int).SetString(chainConfig.MinAllowedAmount, 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("could not convert min allowed amount %s to *big.Int for chain %s", chainConfig.MinAllowedAmount, chainID)
+	}
+	targetAmount, ok = new(big.Int).SetString(chainConfig.TargetAmount, 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("could not convert target amount %s to *big.Int for chain %s", chainConfig.TargetAmount, chainID)
+	}
+	if chainConfig.Forecast == nil {
+		return minAllowedAmount, targetAmount, nil
+	}
+
+	maxTargetAmount, ok := new(big.Int).SetString(chainConfig.Forecast.MaxTargetAmount, 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("could not convert max target amount %s to *big.Int for chain %s", chainConfig.Forecast.MaxTargetAmount, chainID)
+	}
+
+	forecast, err := r.cachedForecastOrderFlow(ctx, chainID, *chainConfig.Forecast)
+	if err != nil {
+		return nil, nil, fmt.Errorf("forecasting order flow on chain %s: %w", chainID, err)
+	}
+	netOutflow := forecast.netOutflow()
+
+	// the forecasted min allowed amount covers the net outflow expected until
+	// a rebalance triggered now would arrive, and the forecasted target keeps
+	// the configured gap between the min allowed and target amounts above it
+	gap := new(big.Int).Sub(targetAmount, minAllowedAmount)
+	forecastMinAllowedAmount := bigMax(minAllowedAmount, bigMin(netOutflow, maxTargetAmount))
+	forecastTargetAmount := bigMax(targetAmount, bigMin(new(big.Int).Add(netOutflow, gap), maxTargetAmount))
+
+	lmt.Logger(ctx).Debug(
+		"forecasted rebalance thresholds",
+		zap.String("chainID", chainID),
+		zap.String("forecastedOutflowUUSDC", forecast.outflow.String()),
+		zap.String("forecastedInflowUUSDC", forecast.inflow.String()),
+		zap.String("minAllowedAmount", forecastMinAllowedAmount.String()),
+		zap.String("targetAmount", forecastTargetAmount.String()),
+	)
+	return forecastMinAllowedAmount, forecastTargetAmount, nil
+}
+
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
+
+func bigMin(a, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
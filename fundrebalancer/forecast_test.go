@@ -0,0 +1,140 @@
+package fundrebalancer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	mock_database "github.com/skip-mev/go-fast-solver/mocks/fundrebalancer"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInForecastWindow(t *testing.T) {
+	now := time.Date(2024, 6, 10, 14, 0, 0, 0, time.UTC)
+	leadTime := time.Hour
+
+	assert.True(t, inForecastWindow(now.Add(-day), now, leadTime))
+	assert.True(t, inForecastWindow(now.Add(-day+30*time.Minute), now, leadTime))
+	assert.True(t, inForecastWindow(now.Add(-3*day+59*time.Minute), now, leadTime))
+	assert.False(t, inForecastWindow(now.Add(-day+time.Hour), now, leadTime))
+	assert.False(t, inForecastWindow(now.Add(-day-time.Minute), now, leadTime))
+	assert.False(t, inForecastWindow(now.Add(-10*time.Minute), now, leadTime))
+	assert.False(t, inForecastWindow(now.Add(time.Minute), now, leadTime))
+}
+
+func TestFundRebalancer_RebalanceThresholds(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	newRebalancer := func(forecast *config.FundRebalancerForecastConfig) (*FundRebalancer, *mock_database.FakeDatabase) {
+		database := mock_database.NewFakeDatabase()
+		return &FundRebalancer{
+			database: database,
+			config: map[string]config.FundRebalancerConfig{
+				arbitrumChainID: {
+					MinAllowedAmount: "100",
+					TargetAmount:     "150",
+					Forecast:         forecast,
+				},
+			},
+		}, database
+	}
+	forecast := &config.FundRebalancerForecastConfig{
+		LeadTime:        time.Hour,
+		LookbackDays:    2,
+		MaxTargetAmount: "1000",
+	}
+	addOrder := func(database *mock_database.FakeDatabase, createdAt time.Time, amount string) {
+		database.AddOrder(db.Order{DestinationChainID: arbitrumChainID, CreatedAt: createdAt, AmountOut: amount})
+	}
+
+	t.Run("configured thresholds are used without forecasting", func(t *testing.T) {
+		r, database := newRebalancer(nil)
+		addOrder(database, now.Add(-day+time.Minute), "10000")
+
+		minAllowed, target, err := r.rebalanceThresholds(ctx, arbitrumChainID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(100), minAllowed)
+		assert.Equal(t, big.NewInt(150), target)
+	})
+
+	t.Run("thresholds are raised by forecasted net outflow", func(t *testing.T) {
+		r, database := newRebalancer(forecast)
+		// 800 uusdc of orders averaged over 2 days in the next hours window
+		addOrder(database, now.Add(-day+time.Minute), "500")
+		addOrder(database, now.Add(-2*day+10*time.Minute), "300")
+		// outside of the time of day window
+		addOrder(database, now.Add(-day+2*time.Hour), "10000")
+		// outside of the lookback window
+		addOrder(database, now.Add(-3*day+time.Minute), "10000")
+		// 200 uusdc of settlements averaged over 2 days
+		database.AddOrderSettlement(db.OrderSettlement{
+			SourceChainID:    arbitrumChainID,
+			SettlementStatus: dbtypes.SettlementStatusComplete,
+			UpdatedAt:        now.Add(-day + 5*time.Minute),
+			Amount:           "200",
+		})
+		database.AddOrderSettlement(db.OrderSettlement{
+			SourceChainID:    arbitrumChainID,
+			SettlementStatus: dbtypes.SettlementStatusPending,
+			UpdatedAt:        now.Add(-day + 5*time.Minute),
+			Amount:           "10000",
+		})
+
+		minAllowed, target, err := r.rebalanceThresholds(ctx, arbitrumChainID)
+		require.NoError(t, err)
+		// net outflow of (800 - 200) / 2
+		assert.Equal(t, big.NewInt(300), minAllowed)
+		assert.Equal(t, big.NewInt(350), target)
+	})
+
+	t.Run("forecasted thresholds are capped at max target amount", func(t *testing.T) {
+		r, database := newRebalancer(forecast)
+		addOrder(database, now.Add(-day+time.Minute), "1000000")
+
+		minAllowed, target, err := r.rebalanceThresholds(ctx, arbitrumChainID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(1000), minAllowed)
+		assert.Equal(t, big.NewInt(1000), target)
+	})
+
+	t.Run("net inflow does not lower configured thresholds", func(t *testing.T) {
+		r, database := newRebalancer(forecast)
+		addOrder(database, now.Add(-day+time.Minute), "100")
+		database.AddOrderSettlement(db.OrderSettlement{
+			SourceChainID:    arbitrumChainID,
+			SettlementStatus: dbtypes.SettlementStatusComplete,
+			UpdatedAt:        now.Add(-day + time.Minute),
+			Amount:           "10000",
+		})
+
+		minAllowed, target, err := r.rebalanceThresholds(ctx, arbitrumChainID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(100), minAllowed)
+		assert.Equal(t, big.NewInt(150), target)
+	})
+	t.Run("forecast is only computed once per rebalance loop", func(t *testing.T) {
+		r, database := newRebalancer(forecast)
+		addOrder(database, now.Add(-day+time.Minute), "600")
+
+		loopCtx := withForecastCache(ctx)
+		minAllowed, _, err := r.rebalanceThresholds(loopCtx, arbitrumChainID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(300), minAllowed)
+
+		// orders added during the loop are not seen until the next loop
+		addOrder(database, now.Add(-day+time.Minute), "600")
+		minAllowed, _, err = r.rebalanceThresholds(loopCtx, arbitrumChainID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(300), minAllowed)
+
+		minAllowed, _, err = r.rebalanceThresholds(withForecastCache(ctx), arbitrumChainID)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(600), minAllowed)
+	})
+}
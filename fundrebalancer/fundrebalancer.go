@@ -2,30 +2,48 @@ package fundrebalancer
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/skip-mev/go-fast-solver/shared/keys"
 	"github.com/skip-mev/go-fast-solver/shared/oracle"
+	cosmostxsubmission "github.com/skip-mev/go-fast-solver/shared/txexecutor/cosmos"
 	evmtxsubmission "github.com/skip-mev/go-fast-solver/shared/txexecutor/evm"
+	svmtxsubmission "github.com/skip-mev/go-fast-solver/shared/txexecutor/svm"
+
+	"cosmossdk.io/math"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/gogoproto/proto"
+	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	dbtypes "github.com/skip-mev/go-fast-solver/db"
 	"github.com/skip-mev/go-fast-solver/shared/metrics"
 
 	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/cctp"
+	"github.com/skip-mev/go-fast-solver/shared/clients/circle"
 	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
 	"github.com/skip-mev/go-fast-solver/shared/config"
-	"github.com/skip-mev/go-fast-solver/shared/contracts/usdc"
 	"github.com/skip-mev/go-fast-solver/shared/evmrpc"
 	"github.com/skip-mev/go-fast-solver/shared/lmt"
 	"github.com/skip-mev/go-fast-solver/shared/signing"
 	"github.com/skip-mev/go-fast-solver/shared/signing/evm"
+	"github.com/skip-mev/go-fast-solver/shared/svmrpc"
+	"github.com/skip-mev/go-fast-solver/shared/tmrpc"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
 )
@@ -39,9 +57,36 @@ const (
 type Database interface {
 	GetPendingRebalanceTransfersToChain(ctx context.Context, destinationChainID string) ([]db.GetPendingRebalanceTransfersToChainRow, error)
 	InsertRebalanceTransfer(ctx context.Context, arg db.InsertRebalanceTransferParams) (int64, error)
+	InsertGasTopUpTransfer(ctx context.Context, arg db.InsertGasTopUpTransferParams) (int64, error)
 	GetAllPendingRebalanceTransfers(ctx context.Context) ([]db.GetAllPendingRebalanceTransfersRow, error)
 	UpdateTransferStatus(ctx context.Context, arg db.UpdateTransferStatusParams) error
 	InsertSubmittedTx(ctx context.Context, arg db.InsertSubmittedTxParams) (db.SubmittedTx, error)
+	GetPendingRebalanceTransfersWithSteps(ctx context.Context) ([]db.RebalanceTransfer, error)
+	InsertRebalanceTransferStep(ctx context.Context, arg db.InsertRebalanceTransferStepParams) (db.RebalanceTransferStep, error)
+	GetRebalanceTransferSteps(ctx context.Context, rebalanceTransferID int64) ([]db.RebalanceTransferStep, error)
+	SetRebalanceTransferStepSubmitting(ctx context.Context, arg db.SetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error)
+	ResetRebalanceTransferStepSubmitting(ctx context.Context, arg db.ResetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error)
+	SetRebalanceTransferStepSubmitted(ctx context.Context, arg db.SetRebalanceTransferStepSubmittedParams) (db.RebalanceTransferStep, error)
+	SetRebalanceTransferStepStatus(ctx context.Context, arg db.SetRebalanceTransferStepStatusParams) (db.RebalanceTransferStep, error)
+	InsertCCTPTransfer(ctx context.Context, arg db.InsertCCTPTransferParams) (db.CctpTransfer, error)
+	GetPendingCCTPTransfers(ctx context.Context) ([]db.CctpTransfer, error)
+	SetCCTPTransferMessage(ctx context.Context, arg db.SetCCTPTransferMessageParams) (db.CctpTransfer, error)
+	SetCCTPTransferAttestation(ctx context.Context, arg db.SetCCTPTransferAttestationParams) (db.CctpTransfer, error)
+	SetCCTPTransferReceiveSubmitted(ctx context.Context, arg db.SetCCTPTransferReceiveSubmittedParams) (db.CctpTransfer, error)
+	SetCCTPTransferStatus(ctx context.Context, arg db.SetCCTPTransferStatusParams) (db.CctpTransfer, error)
+	InsertIBCTransfer(ctx context.Context, arg db.InsertIBCTransferParams) (db.IbcTransfer, error)
+	GetPendingIBCTransfers(ctx context.Context) ([]db.IbcTransfer, error)
+	SetIBCTransferPacketSent(ctx context.Context, arg db.SetIBCTransferPacketSentParams) (db.IbcTransfer, error)
+	SetIBCTransferStatus(ctx context.Context, arg db.SetIBCTransferStatusParams) (db.IbcTransfer, error)
+	GetOrderOutflowsToChainSince(ctx context.Context, arg db.GetOrderOutflowsToChainSinceParams) ([]db.GetOrderOutflowsToChainSinceRow, error)
+	GetSettlementInflowsToChainSince(ctx context.Context, arg db.GetSettlementInflowsToChainSinceParams) ([]db.GetSettlementInflowsToChainSinceRow, error)
+	GetRebalanceTransfersSince(ctx context.Context, createdAt time.Time) ([]db.RebalanceTransfer, error)
+	SetERC20AllowanceApproved(ctx context.Context, arg db.SetERC20AllowanceApprovedParams) (db.Erc20Allowance, error)
+	SetERC20AllowanceUsed(ctx context.Context, arg db.SetERC20AllowanceUsedParams) error
+	GetERC20Allowances(ctx context.Context) ([]db.Erc20Allowance, error)
+	GetIdleERC20Allowances(ctx context.Context, lastUsedAt time.Time) ([]db.Erc20Allowance, error)
+	SetERC20AllowanceRevoked(ctx context.Context, arg db.SetERC20AllowanceRevokedParams) (db.Erc20Allowance, error)
+	InTx(ctx context.Context, fn func(ctx context.Context, q db.Querier) error, opts *sql.TxOptions) error
 }
 
 type profitabilityFailure struct {
@@ -56,9 +101,21 @@ type FundRebalancer struct {
 	config                map[string]config.FundRebalancerConfig
 	database              Database
 	trasferTracker        *TransferTracker
+	routeExecutor         *RouteExecutor
+	bridges               []rebalanceBridge
+	cctpBridge            *cctpBridge
+	ibcBridge             *ibcBridge
 	evmTxExecutor         evmtxsubmission.EVMTxExecutor
+	cosmosTxExecutor      cosmostxsubmission.CosmosTxExecutor
+	svmClientManager      svmrpc.SVMRPCClientManager
+	svmTxExecutor         svmtxsubmission.SVMTxExecutor
+	cdc                   *codec.ProtoCodec
+	txConfig              sdkclient.TxConfig
 	txPriceOracle         oracle.TxPriceOracle
 	profitabilityFailures map[string]*profitabilityFailure
+	// globalLimits are the rebalance limits on all chains combined
+	globalLimits *config.RebalanceLimitsConfig
+	allowances   *AllowanceManager
 }
 
 func NewFundRebalancer(
@@ -69,8 +126,20 @@ func NewFundRebalancer(
 	database Database,
 	txPriceOracle oracle.TxPriceOracle,
 	evmTxExecutor evmtxsubmission.EVMTxExecutor,
+	cosmosTxExecutor cosmostxsubmission.CosmosTxExecutor,
 ) (*FundRebalancer, error) {
-	return &FundRebalancer{
+	// register the msg types Skip Go may return in cosmos txs so that they
+	// can be decoded and signed
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	wasmtypes.RegisterInterfaces(registry)
+	ibctransfertypes.RegisterInterfaces(registry)
+	cctp.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+	svmClientManager := svmrpc.NewSVMRPCClientManager()
+
+	r := &FundRebalancer{
 		chainIDToPrivateKey:   keystore,
 		skipgo:                skipgo,
 		evmClientManager:      evmClientManager,
@@ -79,8 +148,41 @@ func NewFundRebalancer(
 		trasferTracker:        NewTransferTracker(skipgo, database),
 		txPriceOracle:         txPriceOracle,
 		evmTxExecutor:         evmTxExecutor,
+		cosmosTxExecutor:      cosmosTxExecutor,
+		svmClientManager:      svmClientManager,
+		svmTxExecutor:         svmtxsubmission.DefaultSVMTxExecutor(svmClientManager),
+		cdc:                   cdc,
+		txConfig:              authtx.NewTxConfig(cdc, authtx.DefaultSignModes),
 		profitabilityFailures: make(map[string]*profitabilityFailure),
-	}, nil
+	}
+	r.routeExecutor = NewRouteExecutor(skipgo, database, r)
+	solverConfig := config.GetConfigReader(ctx).Config()
+	r.globalLimits = solverConfig.FundRebalancerLimits
+	allowances, err := NewAllowanceManager(keystore, evmClientManager, evmTxExecutor, database, solverConfig.ERC20Approvals)
+	if err != nil {
+		return nil, fmt.Errorf("creating allowance manager: %w", err)
+	}
+	r.allowances = allowances
+	attestationURL := solverConfig.CCTPAttestation.URL
+	if attestationURL == "" {
+		attestationURL = config.DefaultCCTPAttestationURL
+	}
+	rpcClientManager := tmrpc.NewTendermintRPCClientManager()
+	r.cctpBridge = newCCTPBridge(
+		r,
+		solverConfig.Chains,
+		circle.DefaultAttestationClient(attestationURL),
+		rpcClientManager,
+	)
+	r.ibcBridge = newIBCBridge(r, solverConfig.Chains, rpcClientManager)
+	r.bridges = []rebalanceBridge{&skipGoBridge{r: r}, r.cctpBridge, r.ibcBridge}
+	return r, nil
+}
+
+// Allowances returns the manager of the erc20 approvals granted by the fund
+// rebalancer
+func (r *FundRebalancer) Allowances() *AllowanceManager {
+	return r.allowances
 }
 
 // Run is the main loop of the fund rebalancer.
@@ -91,6 +193,10 @@ func (r *FundRebalancer) Run(ctx context.Context) {
 	}
 
 	go r.trasferTracker.TrackPendingTransfers(ctx)
+	go r.routeExecutor.ExecuteRoutes(ctx)
+	go r.cctpBridge.TrackTransfers(ctx)
+	go r.ibcBridge.TrackTransfers(ctx)
+	go r.allowances.Run(ctx)
 
 	ticker := time.NewTicker(initialRebalancerLoopDelay)
 	lmt.Logger(ctx).Info("fund rebalancer starting to monitor chains for fund imbalances")
@@ -114,16 +220,8 @@ func (r *FundRebalancer) Run(ctx context.Context) {
 // If multiple chains are in need of a rebalance, this function will attempt to
 // rebalance all of them.
 func (r *FundRebalancer) Rebalance(ctx context.Context) {
+	ctx = withForecastCache(ctx)
 	for chainID := range r.config {
-		chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
-		if err != nil {
-			lmt.Logger(ctx).Error("error getting chain config", zap.Error(err), zap.String("chainID", chainID))
-			continue
-		}
-		if chainConfig.Type != config.ChainType_COSMOS {
-			continue
-		}
-
 		usdcNeeded, err := r.USDCNeeded(ctx, chainID)
 		if err != nil {
 			lmt.Logger(ctx).Error("error getting usdc needed on chain", zap.Error(err), zap.String("chainID", chainID))
@@ -164,7 +262,8 @@ func (r *FundRebalancer) Rebalance(ctx context.Context) {
 }
 
 // USDCNeeded gets the amount of usdc a chain needs in order to reach its
-// configured target amount balance
+// target amount balance. If forecasting is enabled for the chain, its min
+// allowed and target amounts are raised to cover the forecasted order flow.
 func (r *FundRebalancer) USDCNeeded(
 	ctx context.Context,
 	chainID string,
@@ -192,9 +291,9 @@ func (r *FundRebalancer) USDCNeeded(
 	}
 	currentBalance.Add(currentBalance, pendingBalance)
 
-	minAllowedAmount, ok := new(big.Int).SetString(r.config[chainID].MinAllowedAmount, 10)
-	if !ok {
-		return nil, fmt.Errorf("could not convert min allowed amount %s to *big.Int for chain %s", r.config[chainID].MinAllowedAmount, chainID)
+	minAllowedAmount, targetAmount, err := r.rebalanceThresholds(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting rebalance thresholds for chain %s: %w", chainID, err)
 	}
 
 	if currentBalance.Cmp(minAllowedAmount) >= 0 {
@@ -202,183 +301,292 @@ func (r *FundRebalancer) USDCNeeded(
 		return big.NewInt(0), nil
 	}
 
-	targetAmount, ok := new(big.Int).SetString(r.config[chainID].TargetAmount, 10)
-	if !ok {
-		return nil, fmt.Errorf("could not convert target amount %s to *big.Int for chain %s", r.config[chainID].TargetAmount, chainID)
-	}
-
 	return new(big.Int).Sub(targetAmount, currentBalance), nil
 }
 
-// MoveFundsToChain moves usdc from each chain in the fund rebalancers config
-// that has usdc to spare (i.e. the chains usdc balance is > configured target
-// balance), until usdcToReachTarget usdc has been transfered to
-// rebalanceToChain.
+// MoveFundsToChain moves usdcToReachTarget usdc to rebalanceToChainID from
+// chains that have usdc to spare (i.e. the chains usdc balance is > configured
+// target balance). Every chain with usdc to spare is quoted, and the funds are
+// moved from the combination of chains that is cheapest to rebalance from.
 func (r *FundRebalancer) MoveFundsToChain(
 	ctx context.Context,
 	rebalanceToChainID string,
 	usdcToReachTarget *big.Int,
 ) ([]skipgo.TxHash, *big.Int, error) {
+	if err := r.checkRebalanceCooldown(ctx, rebalanceToChainID); err != nil {
+		if errors.Is(err, ErrRebalanceLimitReached) {
+			lmt.Logger(ctx).Info("skipping rebalance to chain", zap.String("chainID", rebalanceToChainID), zap.Error(err))
+			return nil, big.NewInt(0), nil
+		}
+		return nil, nil, fmt.Errorf("checking rebalance cooldown for chain %s: %w", rebalanceToChainID, err)
+	}
+
+	quotes, err := r.quoteRebalanceSources(ctx, rebalanceToChainID, usdcToReachTarget, r.USDCToSpare)
+	if err != nil {
+		return nil, nil, fmt.Errorf("quoting chains to rebalance %s uusdc to chain %s from: %w", usdcToReachTarget.String(), rebalanceToChainID, err)
+	}
+
+	allocations := selectRebalanceSources(quotes, usdcToReachTarget)
+	logRebalanceDecision(ctx, rebalanceToChainID, usdcToReachTarget, quotes, allocations)
+
 	var hashes []skipgo.TxHash
 	totalUSDCcMoved := big.NewInt(0)
-	remainingUSDCNeeded := usdcToReachTarget
-	for rebalanceFromChainID := range r.config {
-		if rebalanceFromChainID == rebalanceToChainID {
-			// do not try and rebalance funds from the same chain
-			continue
+	for _, allocation := range allocations {
+		allocation, rebalanceHash, err := r.executeAllocation(ctx, rebalanceToChainID, allocation, false)
+		if errors.Is(err, ErrRebalanceLimitReached) {
+			// later allocations count towards the same limits, so stop moving
+			// funds to this chain until the limits allow it again
+			lmt.Logger(ctx).Info("stopping rebalance to chain", zap.String("chainID", rebalanceToChainID), zap.Error(err))
+			break
 		}
-
-		usdcToSpare, err := r.USDCToSpare(ctx, rebalanceFromChainID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("could not get amount of usdc to spare from chain %s: %w", rebalanceFromChainID, err)
+			return nil, nil, err
 		}
 
-		if usdcToSpare.Cmp(big.NewInt(0)) == 0 {
-			continue
-		}
-
-		ctx = lmt.With(
-			ctx,
-			zap.String("destinationChainID", rebalanceToChainID),
-			zap.String("sourceChainID", rebalanceFromChainID),
-		)
-		lmt.Logger(ctx).Debug(
-			fmt.Sprintf("chain %s has %s uusdc to space for rebalancing to chain %s", rebalanceFromChainID, usdcToSpare.String(), rebalanceToChainID),
-			zap.String("uusdcToSpare", usdcToSpare.String()),
-		)
-
-		neededVsSpareDiff := new(big.Int).Sub(remainingUSDCNeeded, usdcToSpare)
-
-		var usdcToRebalance *big.Int
-		if neededVsSpareDiff.Cmp(big.NewInt(0)) <= 0 {
-			// usdc to spare on this chain is greater than or equal to the
-			// remaining amount needed, so only move the remaining amount so we
-			// dont wipe out a chains funds uncessarily
-			usdcToRebalance = remainingUSDCNeeded
-		} else {
-			// usdc to spare on this chain is less than the remaining amount
-			// needed, so move all that it has to spare and then continue
-			// moving from other over allocated chains
-			usdcToRebalance = usdcToSpare
-		}
+		totalUSDCcMoved = new(big.Int).Add(totalUSDCcMoved, allocation.amount)
+		hashes = append(hashes, rebalanceHash)
+	}
 
-		ctx = lmt.With(ctx, zap.String("rebalanceAmountUUSDC", usdcToRebalance.String()))
-		lmt.Logger(ctx).Debug(fmt.Sprintf("attempting to move %s uusdc from chain %s to chain %s", usdcToRebalance.String(), rebalanceFromChainID, rebalanceToChainID))
+	return hashes, totalUSDCcMoved, nil
+}
 
-		txns, err := r.GetRebalanceTxns(ctx, usdcToRebalance, rebalanceFromChainID, rebalanceToChainID)
+// executeRebalance submits the txs moving the allocated amount of usdc from
+// the allocations source chain to rebalanceToChainID
+func (r *FundRebalancer) executeRebalance(
+	ctx context.Context,
+	rebalanceToChainID string,
+	allocation rebalanceAllocation,
+) (skipgo.TxHash, error) {
+	rebalanceFromChainID := allocation.quote.sourceChainID
+	usdcToRebalance := allocation.amount
+	ctx = lmt.With(
+		ctx,
+		zap.String("destinationChainID", rebalanceToChainID),
+		zap.String("sourceChainID", rebalanceFromChainID),
+		zap.String("rebalanceAmountUUSDC", usdcToRebalance.String()),
+	)
+	lmt.Logger(ctx).Debug(fmt.Sprintf("attempting to move %s uusdc from chain %s to chain %s", usdcToRebalance.String(), rebalanceFromChainID, rebalanceToChainID))
+
+	route, txns, txnWithMetadata := allocation.quote.route, allocation.quote.txns, allocation.quote.txnWithMetadata
+	requote := usdcToRebalance.Cmp(allocation.quote.amount) != 0
+	if requote {
+		// only part of the quoted amount is being moved from this chain, so
+		// the txns have to be requested again for the allocated amount
+		var err error
+		route, txns, err = r.rebalanceRoute(ctx, usdcToRebalance, rebalanceFromChainID, rebalanceToChainID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("getting txns required for fund rebalancing %s uusdc from chain %s to chain %s: %w", usdcToRebalance.String(), rebalanceFromChainID, rebalanceToChainID, err)
+			return "", fmt.Errorf("getting txns required for fund rebalancing %s uusdc from chain %s to chain %s: %w", usdcToRebalance.String(), rebalanceFromChainID, rebalanceToChainID, err)
 		}
-		if len(txns) != 1 {
-			return nil, nil, fmt.Errorf("only single transaction transfers are supported to rebalance %s uusdc from chain %s to chain %s, instead got a %d tx transfer", usdcToRebalance.String(), rebalanceFromChainID, rebalanceToChainID, len(txns))
+		if len(txns) == 0 {
+			return "", fmt.Errorf("no txns returned to rebalance %s uusdc from chain %s to chain %s", usdcToRebalance.String(), rebalanceFromChainID, rebalanceToChainID)
 		}
-		txn := txns[0]
+	}
+	// only the first tx of a multi tx route is submitted here, the rest
+	// are submitted by the route executor once the previous tx's transfer
+	// has completed
+	if err := r.submitApproval(ctx, rebalanceFromChainID, txns[0], usdcToRebalance); err != nil {
+		return "", fmt.Errorf("approving txn for rebalance of %s uusdc from chain %s to chain %s: %w", usdcToRebalance.String(), rebalanceFromChainID, rebalanceToChainID, err)
+	}
 
-		approvalHash, rawTx, err := r.ApproveTxn(ctx, rebalanceFromChainID, txn)
+	if requote {
+		var err error
+		txnWithMetadata, err = r.TxnWithMetadata(ctx, rebalanceFromChainID, rebalanceToChainID, usdcToRebalance, txns[0])
 		if err != nil {
-			return nil, nil, fmt.Errorf("approving txn for rebalance of %s uusdc from chain %s to chain %s: %w", usdcToRebalance.String(), rebalanceFromChainID, rebalanceToChainID, err)
+			return "", fmt.Errorf("getting transaction metadata to rebalance funds from chain %s: %w", rebalanceFromChainID, err)
 		}
-		if approvalHash != "" {
-			lmt.Logger(ctx).Debug("submitted approval tx", zap.String("txHash", approvalHash))
+	}
 
-			// not we are not linking this submitted tx to the rebalance since the rebalance
-			// has not yet been created
-			approveTx := db.InsertSubmittedTxParams{
-				ChainID:  rebalanceFromChainID,
-				TxHash:   approvalHash,
-				RawTx:    rawTx,
-				TxType:   dbtypes.TxTypeERC20Approval,
-				TxStatus: dbtypes.TxStatusPending,
-			}
-			if _, err = r.database.InsertSubmittedTx(ctx, approveTx); err != nil {
-				return nil, nil, fmt.Errorf("inserting submitted tx for erc20 approval with hash %s on chain %s into db: %w", approvalHash, rebalanceFromChainID, err)
-			}
-		}
+	rebalanceHash, rawTx, err := r.SignAndSubmitTxn(ctx, txnWithMetadata)
+	if err != nil {
+		return "", fmt.Errorf("signing and submitting transaction: %w", err)
+	}
 
-		txnWithMetadata, err := r.TxnWithMetadata(ctx, rebalanceFromChainID, rebalanceToChainID, usdcToRebalance, txn)
-		if err != nil {
-			return nil, nil, fmt.Errorf("getting transaction metadata to rebalance funds from chain %s: %w", rebalanceFromChainID, err)
-		}
+	if _, err := r.recordRebalanceTransfer(ctx, dbtypes.RebalanceTransferKindRebalance, txnWithMetadata, rebalanceHash, rawTx, allocation.quote.costUUSDC(usdcToRebalance), route, txns); err != nil {
+		return "", err
+	}
 
-		chainFundRebalancingConfig, err := config.GetConfigReader(ctx).GetFundRebalancingConfig(rebalanceFromChainID)
+	return rebalanceHash, nil
+}
+
+// recordRebalanceTransfer adds a submitted rebalance transfer of kind and the
+// tx that initiated it to the db, returning the id of the rebalance transfer.
+// The quoted cost is recorded so that it counts towards daily fee budgets. If
+// the transfer's route has more than one tx, the steps of the route are
+// inserted in the same db transaction, since the route executor would never
+// submit the rest of the route if only the rebalance transfer was recorded.
+func (r *FundRebalancer) recordRebalanceTransfer(
+	ctx context.Context,
+	kind string,
+	txnWithMetadata SkipGoTxnWithMetadata,
+	rebalanceHash skipgo.TxHash,
+	rawTx string,
+	quotedCostUUSDC *big.Int,
+	route *skipgo.RouteResponse,
+	txns []skipgo.Tx,
+) (int64, error) {
+	metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(txnWithMetadata.sourceChainID, txnWithMetadata.destinationChainID, dbtypes.RebalanceTransferStatusPending)
+	var rebalanceID int64
+	err := r.database.InTx(ctx, func(ctx context.Context, q db.Querier) error {
+		var err error
+		rebalanceID, err = insertRebalanceTransfer(ctx, q, kind, txnWithMetadata, rebalanceHash, rawTx, quotedCostUUSDC)
 		if err != nil {
-			return nil, nil, fmt.Errorf("getting fund rebalancer config for gas threshold check: %w", err)
+			return err
 		}
-
-		if chainFundRebalancingConfig.MaxRebalancingGasCostUUSDC != "" {
-			gasAcceptable, gasCostUUSDC, err := r.isGasAcceptable(ctx, txnWithMetadata, rebalanceFromChainID)
-			if err != nil {
-				return nil, nil, fmt.Errorf("checking if total rebalancing gas cost is acceptable on chain %s: %w", rebalanceFromChainID, err)
-			}
-			if !gasAcceptable {
-				maxCost, ok := new(big.Int).SetString(chainFundRebalancingConfig.MaxRebalancingGasCostUUSDC, 10)
-				if !ok {
-					return nil, nil, fmt.Errorf("parsing max rebalancing gas cost uusdc %s for chain %s to *big.Int", chainFundRebalancingConfig.MaxRebalancingGasCostUUSDC, rebalanceFromChainID)
-				}
-				lmt.Logger(ctx).Info(
-					"skipping rebalance from chain "+rebalanceFromChainID+" due to high rebalancing gas cost",
-					zap.String("estimatedGasCostUUSDC", gasCostUUSDC),
-					zap.String("maxRebalancingGasCostUUSDC", maxCost.String()),
-				)
-				continue
+		if len(txns) > 1 {
+			if err := insertRouteSteps(ctx, q, rebalanceID, rebalanceHash, route, txns); err != nil {
+				return fmt.Errorf("inserting steps of %d tx rebalance transfer with hash %s into db: %w", len(txns), rebalanceHash, err)
 			}
 		}
+		return nil
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return rebalanceID, nil
+}
 
-		rebalanceHash, rawTx, err := r.SignAndSubmitTxn(ctx, txnWithMetadata)
+// recordBridgeTransfer adds a rebalance transfer that is executed directly
+// through a bridge to the db along with the bridge's own record of the
+// transfer, which insertBridgeTransfer inserts. Both are written in a single
+// db transaction, since the transfer tracker would otherwise track the
+// rebalance transfer through Skip Go until the bridge transfer is recorded,
+// and the funds would never be received if only the rebalance transfer was
+// recorded.
+func (r *FundRebalancer) recordBridgeTransfer(
+	ctx context.Context,
+	txnWithMetadata SkipGoTxnWithMetadata,
+	rebalanceHash skipgo.TxHash,
+	rawTx string,
+	quotedCostUUSDC *big.Int,
+	insertBridgeTransfer func(ctx context.Context, q db.Querier, rebalanceID int64) error,
+) error {
+	metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(txnWithMetadata.sourceChainID, txnWithMetadata.destinationChainID, dbtypes.RebalanceTransferStatusPending)
+	err := r.database.InTx(ctx, func(ctx context.Context, q db.Querier) error {
+		rebalanceID, err := insertRebalanceTransfer(ctx, q, dbtypes.RebalanceTransferKindRebalance, txnWithMetadata, rebalanceHash, rawTx, quotedCostUUSDC)
 		if err != nil {
-			return nil, nil, fmt.Errorf("signing and submitting transaction: %w", err)
+			return err
 		}
-		metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(rebalanceFromChainID, rebalanceToChainID, dbtypes.RebalanceTransferStatusPending)
+		return insertBridgeTransfer(ctx, q, rebalanceID)
+	}, nil)
+	if err != nil {
+		lmt.Logger(ctx).Error(
+			"failed to record submitted bridge transfer, the transfer must be completed manually",
+			zap.String("txHash", string(rebalanceHash)),
+			zap.String("sourceChainID", txnWithMetadata.sourceChainID),
+			zap.String("destinationChainID", txnWithMetadata.destinationChainID),
+			zap.String("amount", txnWithMetadata.amount.String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("recording bridge transfer with hash %s: %w", rebalanceHash, err)
+	}
+	return nil
+}
 
-		// add rebalance transfer to the db
-		rebalanceTransfer := db.InsertRebalanceTransferParams{
-			TxHash:             string(rebalanceHash),
-			Amount:             txnWithMetadata.amount.String(),
-			SourceChainID:      rebalanceFromChainID,
-			DestinationChainID: rebalanceToChainID,
-		}
-		rebalanceID, err := r.database.InsertRebalanceTransfer(ctx, rebalanceTransfer)
-		if err != nil {
-			return nil, nil, fmt.Errorf("updating rebalance transfer with hash %s: %w", string(rebalanceHash), err)
-		}
+// rebalanceTransferWriter inserts rebalance transfers, either directly into
+// the db or as part of a db transaction
+type rebalanceTransferWriter interface {
+	InsertRebalanceTransfer(ctx context.Context, arg db.InsertRebalanceTransferParams) (int64, error)
+	InsertGasTopUpTransfer(ctx context.Context, arg db.InsertGasTopUpTransferParams) (int64, error)
+	InsertSubmittedTx(ctx context.Context, arg db.InsertSubmittedTxParams) (db.SubmittedTx, error)
+}
 
-		// add rebalance tx to submitted txs table
-		rebalanceTx := db.InsertSubmittedTxParams{
-			RebalanceTransferID: sql.NullInt64{Int64: rebalanceID, Valid: true},
-			ChainID:             txnWithMetadata.sourceChainID,
-			TxHash:              string(rebalanceHash),
-			RawTx:               rawTx,
-			TxType:              dbtypes.TxTypeFundRebalnance,
-			TxStatus:            dbtypes.TxStatusPending,
-		}
-		if _, err = r.database.InsertSubmittedTx(ctx, rebalanceTx); err != nil {
-			return nil, nil, fmt.Errorf("inserting submitted tx for rebalance transfer with hash %s into db: %w", rebalanceHash, err)
-		}
+func insertRebalanceTransfer(
+	ctx context.Context,
+	q rebalanceTransferWriter,
+	kind string,
+	txnWithMetadata SkipGoTxnWithMetadata,
+	rebalanceHash skipgo.TxHash,
+	rawTx string,
+	quotedCostUUSDC *big.Int,
+) (int64, error) {
+	// add rebalance transfer to the db
+	rebalanceTransfer := db.InsertRebalanceTransferParams{
+		TxHash:             string(rebalanceHash),
+		Amount:             txnWithMetadata.amount.String(),
+		SourceChainID:      txnWithMetadata.sourceChainID,
+		DestinationChainID: txnWithMetadata.destinationChainID,
+		QuotedCostUusdc:    sql.NullString{String: quotedCostUUSDC.String(), Valid: true},
+	}
+	var rebalanceID int64
+	var err error
+	switch kind {
+	case dbtypes.RebalanceTransferKindRebalance:
+		rebalanceID, err = q.InsertRebalanceTransfer(ctx, rebalanceTransfer)
+	case dbtypes.RebalanceTransferKindGasTopUp:
+		rebalanceID, err = q.InsertGasTopUpTransfer(ctx, db.InsertGasTopUpTransferParams(rebalanceTransfer))
+	default:
+		return 0, fmt.Errorf("unknown rebalance transfer kind %s", kind)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("updating rebalance transfer with hash %s: %w", string(rebalanceHash), err)
+	}
 
-		totalUSDCcMoved = new(big.Int).Add(totalUSDCcMoved, usdcToRebalance)
-		hashes = append(hashes, rebalanceHash)
+	// add rebalance tx to submitted txs table
+	rebalanceTx := db.InsertSubmittedTxParams{
+		RebalanceTransferID: sql.NullInt64{Int64: rebalanceID, Valid: true},
+		ChainID:             txnWithMetadata.sourceChainID,
+		TxHash:              string(rebalanceHash),
+		RawTx:               rawTx,
+		TxType:              dbtypes.TxTypeFundRebalnance,
+		TxStatus:            dbtypes.TxStatusPending,
+	}
+	if _, err = q.InsertSubmittedTx(ctx, rebalanceTx); err != nil {
+		return 0, fmt.Errorf("inserting submitted tx for rebalance transfer with hash %s into db: %w", rebalanceHash, err)
+	}
+	return rebalanceID, nil
+}
 
-		// if there is no more usdc needed, we are done rebalancing
-		remainingUSDCNeeded = new(big.Int).Sub(remainingUSDCNeeded, usdcToRebalance)
-		if remainingUSDCNeeded.Cmp(big.NewInt(0)) <= 0 {
-			return hashes, totalUSDCcMoved, nil
-		}
+// submitApproval submits the erc20 approval required by txn on chainID, if
+// any, and records it as a submitted tx. amount is the amount of usdc moved by
+// txn, which the approval is bounded by.
+func (r *FundRebalancer) submitApproval(ctx context.Context, chainID string, txn skipgo.Tx, amount *big.Int) error {
+	approvalHash, rawTx, err := r.ApproveTxn(ctx, chainID, txn, amount)
+	if err != nil {
+		return err
 	}
+	if approvalHash == "" {
+		return nil
+	}
+	lmt.Logger(ctx).Debug("submitted approval tx", zap.String("txHash", approvalHash))
 
-	// we have moved all available funds from all available chains
-	return hashes, totalUSDCcMoved, nil
+	// not we are not linking this submitted tx to the rebalance since the rebalance
+	// has not yet been created
+	approveTx := db.InsertSubmittedTxParams{
+		ChainID:  chainID,
+		TxHash:   approvalHash,
+		RawTx:    rawTx,
+		TxType:   dbtypes.TxTypeERC20Approval,
+		TxStatus: dbtypes.TxStatusPending,
+	}
+	if _, err = r.database.InsertSubmittedTx(ctx, approveTx); err != nil {
+		return fmt.Errorf("inserting submitted tx for erc20 approval with hash %s on chain %s into db: %w", approvalHash, chainID, err)
+	}
+	return nil
 }
 
+// ApproveTxn submits the erc20 approval required by txn, if any, for at most
+// amount plus the configured approval buffer. The spender of the approval is
+// recorded in the allowance registry whether or not an approval is needed.
 func (r *FundRebalancer) ApproveTxn(
 	ctx context.Context,
 	chainID string,
 	txn skipgo.Tx,
+	amount *big.Int,
 ) (txHash string, rawTx string, err error) {
 	needsApproal, err := r.NeedsERC20Approval(ctx, txn)
 	if err != nil {
 		return "", "", fmt.Errorf("checking if ERC20 approval is necessary for rebalance txn from %s: %w", chainID, err)
 	}
 	if !needsApproal {
+		approval, err := requiredUSDCApproval(ctx, txn)
+		if err != nil {
+			return "", "", err
+		}
+		if approval != nil {
+			if err := r.allowances.recordUse(ctx, chainID, *approval); err != nil {
+				return "", "", err
+			}
+		}
 		lmt.Logger(ctx).Debug(
 			"fund rebalance does not need erc20 approval",
 			zap.Any("rebalanceTxn", txn),
@@ -391,7 +599,7 @@ func (r *FundRebalancer) ApproveTxn(
 		zap.Any("rebalanceTxn", txn),
 	)
 
-	hash, rawTx, err := r.ERC20Approval(ctx, txn)
+	hash, rawTx, err := r.ERC20Approval(ctx, txn, amount)
 	if err != nil {
 		return "", "", fmt.Errorf("handling ERC20 approval for rebalance txn from %s: %w", chainID, err)
 	}
@@ -400,8 +608,10 @@ func (r *FundRebalancer) ApproveTxn(
 }
 
 // USDCToSpare returns a chains current balance - a chains target amount of
-// usdc, or 0 if this value is negative. This does not take into account any
-// pending rebalance transactions in the db that are bound for this chain.
+// usdc (raised by its forecasted order flow if forecasting is enabled) and
+// hysteresis band, or 0 if this value is negative. This does not take into
+// account any pending rebalance transactions in the db that are bound for this
+// chain.
 func (r *FundRebalancer) USDCToSpare(
 	ctx context.Context,
 	chainID string,
@@ -411,16 +621,37 @@ func (r *FundRebalancer) USDCToSpare(
 		return nil, fmt.Errorf("getting usdc balance on chain %s: %w", chainID, err)
 	}
 
-	targetAmountBig, ok := new(big.Int).SetString(r.config[chainID].TargetAmount, 10)
-	if !ok {
-		return nil, fmt.Errorf("converting target amount to *big.Int")
+	spareThreshold, err := r.spareThreshold(ctx, chainID)
+	if err != nil {
+		return nil, err
 	}
 
-	if currentBalance.Cmp(targetAmountBig) <= 0 {
+	if currentBalance.Cmp(spareThreshold) <= 0 {
 		return big.NewInt(0), nil
 	}
 
-	return new(big.Int).Sub(currentBalance, targetAmountBig), nil
+	return new(big.Int).Sub(currentBalance, spareThreshold), nil
+}
+
+// spareThreshold gets the balance above which a chain has usdc to spare. This
+// is the chains target amount plus its hysteresis band.
+func (r *FundRebalancer) spareThreshold(ctx context.Context, chainID string) (*big.Int, error) {
+	// chains expecting to be drained by order flow keep their forecasted
+	// target amount rather than giving it to other chains
+	_, targetAmount, err := r.rebalanceThresholds(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting rebalance thresholds for chain %s: %w", chainID, err)
+	}
+
+	hysteresisBand := r.config[chainID].HysteresisBandUUSDC
+	if hysteresisBand == "" {
+		return targetAmount, nil
+	}
+	hysteresisBandBig, ok := new(big.Int).SetString(hysteresisBand, 10)
+	if !ok {
+		return nil, fmt.Errorf("could not convert hysteresis band %s for chain %s to *big.Int", hysteresisBand, chainID)
+	}
+	return new(big.Int).Add(targetAmount, hysteresisBandBig), nil
 }
 
 // usdcBalance gets the balance on chainID in uusdc.
@@ -429,7 +660,12 @@ func (r *FundRebalancer) usdcBalance(ctx context.Context, chainID string) (*big.
 	if err != nil {
 		return nil, fmt.Errorf("getting usdc denom for chain %s: %w", chainID, err)
 	}
+	return r.tokenBalance(ctx, chainID, usdcDenom)
+}
 
+// tokenBalance gets the solver's balance of denom on chainID. Only usdc
+// balances are supported on svm chains.
+func (r *FundRebalancer) tokenBalance(ctx context.Context, chainID, denom string) (*big.Int, error) {
 	var currentBalance *big.Int
 	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
 	if err != nil {
@@ -442,16 +678,16 @@ func (r *FundRebalancer) usdcBalance(ctx context.Context, chainID string) (*big.
 			return nil, fmt.Errorf("getting evm client for chain %s: %w", chainID, err)
 		}
 
-		currentBalance, err = client.GetUSDCBalance(ctx, usdcDenom, chainConfig.SolverAddress)
+		currentBalance, err = client.GetUSDCBalance(ctx, denom, chainConfig.SolverAddress)
 		if err != nil {
-			return nil, fmt.Errorf("fetching balance for address %s on chain %s for denom %s: %w", chainConfig.SolverAddress, chainID, usdcDenom, err)
+			return nil, fmt.Errorf("fetching balance for address %s on chain %s for denom %s: %w", chainConfig.SolverAddress, chainID, denom, err)
 		}
 	case config.ChainType_COSMOS:
 		request := &skipgo.BalancesRequest{
 			Chains: map[string]skipgo.ChainRequest{
 				chainID: {
 					Address: chainConfig.SolverAddress,
-					Denoms:  []string{usdcDenom},
+					Denoms:  []string{denom},
 				},
 			},
 		}
@@ -459,7 +695,7 @@ func (r *FundRebalancer) usdcBalance(ctx context.Context, chainID string) (*big.
 		resp, err := r.skipgo.Balance(ctx, request)
 		if err != nil {
 			return nil, fmt.Errorf("fetching balance for address %s on chain %s for denom %s: %w",
-				chainConfig.SolverAddress, chainID, usdcDenom, err)
+				chainConfig.SolverAddress, chainID, denom, err)
 		}
 
 		chainResp, ok := resp.Chains[chainID]
@@ -467,15 +703,34 @@ func (r *FundRebalancer) usdcBalance(ctx context.Context, chainID string) (*big.
 			return nil, fmt.Errorf("no balance found for chain %s", chainID)
 		}
 
-		denomDetail, ok := chainResp.Denoms[usdcDenom]
+		denomDetail, ok := chainResp.Denoms[denom]
 		if !ok {
-			return nil, fmt.Errorf("no balance found for denom %s on chain %s", usdcDenom, chainID)
+			return nil, fmt.Errorf("no balance found for denom %s on chain %s", denom, chainID)
 		}
 
 		currentBalance, ok = new(big.Int).SetString(denomDetail.Amount, 10)
 		if !ok {
 			return nil, fmt.Errorf("could not convert balance %s to *big.Int", denomDetail.Amount)
 		}
+	case config.ChainType_SVM:
+		if denom != chainConfig.USDCDenom {
+			return nil, fmt.Errorf("only usdc balances are supported on svm chain %s", chainID)
+		}
+		client, err := r.svmClientManager.GetClient(ctx, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("getting svm client for chain %s: %w", chainID, err)
+		}
+		_, _, tokenAccount, err := svmUSDCTokenAccount(chainConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		currentBalance, err = client.GetTokenBalance(ctx, tokenAccount)
+		if err != nil {
+			return nil, fmt.Errorf("fetching balance for address %s on chain %s for denom %s: %w", chainConfig.SolverAddress, chainID, denom, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported chain type %s for chain %s", chainConfig.Type, chainID)
 	}
 
 	return currentBalance, nil
@@ -509,32 +764,45 @@ type SkipGoTxnWithMetadata struct {
 	gasEstimate        uint64
 }
 
-// GetRebalanceTxns gets transaction msgs/data from Skip Go that can be signed
-// and submitted on chain in order to rebalance the solvers funds.
-func (r *FundRebalancer) GetRebalanceTxns(
+// rebalanceRoute gets the route and transaction msgs/data from Skip Go that
+// can be signed and submitted on chain in order to rebalance the solvers funds.
+func (r *FundRebalancer) rebalanceRoute(
 	ctx context.Context,
 	amount *big.Int,
 	sourceChainID string,
 	destChainID string,
-) ([]skipgo.Tx, error) {
+) (*skipgo.RouteResponse, []skipgo.Tx, error) {
 	rebalanceFromDenom, err := config.GetConfigReader(ctx).GetUSDCDenom(sourceChainID)
 	if err != nil {
-		return nil, fmt.Errorf("getting usdc denom for chain %s: %w", sourceChainID, err)
+		return nil, nil, fmt.Errorf("getting usdc denom for chain %s: %w", sourceChainID, err)
 	}
 	rebalanceToDenom, err := config.GetConfigReader(ctx).GetUSDCDenom(destChainID)
 	if err != nil {
-		return nil, fmt.Errorf("getting usdc denom for chain %s: %w", destChainID, err)
+		return nil, nil, fmt.Errorf("getting usdc denom for chain %s: %w", destChainID, err)
 	}
+	return r.skipGoRoute(ctx, amount, sourceChainID, rebalanceFromDenom, destChainID, rebalanceToDenom)
+}
 
+// skipGoRoute gets the route and transaction msgs/data from Skip Go that can
+// be signed and submitted on chain in order to move amount of the solvers
+// sourceDenom on sourceChainID to destDenom on destChainID.
+func (r *FundRebalancer) skipGoRoute(
+	ctx context.Context,
+	amount *big.Int,
+	sourceChainID string,
+	rebalanceFromDenom string,
+	destChainID string,
+	rebalanceToDenom string,
+) (*skipgo.RouteResponse, []skipgo.Tx, error) {
 	sourceChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(sourceChainID)
 	if err != nil {
-		return nil, fmt.Errorf("getting source chain config for chain %s: %w", sourceChainID, err)
+		return nil, nil, fmt.Errorf("getting source chain config for chain %s: %w", sourceChainID, err)
 	}
 	rebalanceFromAddress := sourceChainConfig.SolverAddress
 
 	destinationChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(destChainID)
 	if err != nil {
-		return nil, fmt.Errorf("getting destination chain config for chain %s: %w", destChainID, err)
+		return nil, nil, fmt.Errorf("getting destination chain config for chain %s: %w", destChainID, err)
 	}
 	rebalanceToAddress := destinationChainConfig.SolverAddress
 
@@ -548,7 +816,7 @@ func (r *FundRebalancer) GetRebalanceTxns(
 		amount,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("getting rebalancing route from Skip Go: %w", err)
+		return nil, nil, fmt.Errorf("getting rebalancing route from Skip Go: %w", err)
 	}
 
 	// create addres list from required chain addreses response field
@@ -556,7 +824,7 @@ func (r *FundRebalancer) GetRebalanceTxns(
 	for _, requiredChainAddress := range route.RequiredChainAddresses {
 		chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(requiredChainAddress)
 		if err != nil {
-			return nil, fmt.Errorf("getting chain config for chain %s: %w", requiredChainAddress, err)
+			return nil, nil, fmt.Errorf("getting chain config for chain %s: %w", requiredChainAddress, err)
 		}
 
 		addresses = append(addresses, chainConfig.SolverAddress)
@@ -564,7 +832,7 @@ func (r *FundRebalancer) GetRebalanceTxns(
 
 	amountOut, ok := new(big.Int).SetString(route.AmountOut, 10)
 	if !ok {
-		return nil, fmt.Errorf("converting amount out %s to *bit.Int", route.AmountOut)
+		return nil, nil, fmt.Errorf("converting amount out %s to *bit.Int", route.AmountOut)
 	}
 
 	// get txn data from for the route to be executed
@@ -582,10 +850,10 @@ func (r *FundRebalancer) GetRebalanceTxns(
 		route.Operations,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("getting rebalancing txn operations from Skip Go: %w", err)
+		return nil, nil, fmt.Errorf("getting rebalancing txn operations from Skip Go: %w", err)
 	}
 
-	return txns, nil
+	return route, txns, nil
 }
 
 func (r *FundRebalancer) TxnWithMetadata(
@@ -595,29 +863,64 @@ func (r *FundRebalancer) TxnWithMetadata(
 	amount *big.Int,
 	txn skipgo.Tx,
 ) (SkipGoTxnWithMetadata, error) {
-	sourceChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(sourceChainID)
-	if err != nil {
-		return SkipGoTxnWithMetadata{}, fmt.Errorf("getting source chain config for chain %s: %w", sourceChainID, err)
-	}
-	decodedData, err := hex.DecodeString(txn.EVMTx.Data)
-	if err != nil {
-		return SkipGoTxnWithMetadata{}, fmt.Errorf("hex decoding evm call data: %w", err)
-	}
+	var estimate uint64
+	switch {
+	case txn.EVMTx != nil:
+		sourceChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(sourceChainID)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("getting source chain config for chain %s: %w", sourceChainID, err)
+		}
+		decodedData, err := hex.DecodeString(txn.EVMTx.Data)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("hex decoding evm call data: %w", err)
+		}
 
-	client, err := r.evmClientManager.GetClient(ctx, sourceChainID)
-	if err != nil {
-		return SkipGoTxnWithMetadata{}, fmt.Errorf("getting evm rpc client for chain %s: %w", sourceChainID, err)
-	}
-	txBuilder := evm.NewTxBuilder(client)
-	estimate, err := txBuilder.EstimateGasForTx(
-		ctx,
-		sourceChainConfig.SolverAddress,
-		txn.EVMTx.To,
-		txn.EVMTx.Value,
-		decodedData,
-	)
-	if err != nil {
-		return SkipGoTxnWithMetadata{}, fmt.Errorf("estimating gas: %w", err)
+		client, err := r.evmClientManager.GetClient(ctx, sourceChainID)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("getting evm rpc client for chain %s: %w", sourceChainID, err)
+		}
+		txBuilder := evm.NewTxBuilder(client)
+		estimate, err = txBuilder.EstimateGasForTx(
+			ctx,
+			sourceChainConfig.SolverAddress,
+			txn.EVMTx.To,
+			txn.EVMTx.Value,
+			decodedData,
+		)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("estimating gas: %w", err)
+		}
+	case txn.CosmosTx != nil:
+		msgs, err := r.cosmosMsgs(txn.CosmosTx)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("decoding cosmos msgs from Skip Go: %w", err)
+		}
+		signer, err := signing.NewSigner(ctx, sourceChainID, r.chainIDToPrivateKey)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("creating signer for chain %s: %w", sourceChainID, err)
+		}
+		estimate, err = r.cosmosTxExecutor.EstimateGas(ctx, sourceChainID, txn.CosmosTx.SignerAddress, msgs, r.txConfig, signer)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("estimating gas: %w", err)
+		}
+	case txn.SVMTx != nil:
+		// svm txs are charged a fee per signature plus the priority fee for
+		// their compute unit limit rather than for the gas they use, so the
+		// estimate is the fee in lamports
+		sourceChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(sourceChainID)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("getting source chain config for chain %s: %w", sourceChainID, err)
+		}
+		if sourceChainConfig.SVM == nil {
+			return SkipGoTxnWithMetadata{}, fmt.Errorf("svm chain config is null for chain id %s", sourceChainID)
+		}
+		tx, err := decodeSVMTx(txn.SVMTx)
+		if err != nil {
+			return SkipGoTxnWithMetadata{}, err
+		}
+		estimate = svmTxFeeLamports(sourceChainConfig.SVM, tx)
+	default:
+		return SkipGoTxnWithMetadata{}, fmt.Errorf("no valid tx types returned from Skip Go")
 	}
 
 	return SkipGoTxnWithMetadata{
@@ -629,6 +932,44 @@ func (r *FundRebalancer) TxnWithMetadata(
 	}, nil
 }
 
+// cosmosTx wraps msg in a cosmos tx in the same json form that Skip Go
+// returns cosmos txs in, so that it can be signed and submitted the same way
+func (r *FundRebalancer) cosmosTx(chainConfig config.ChainConfig, msg proto.Message) (skipgo.Tx, error) {
+	msgJSON, err := r.cdc.MarshalJSON(msg)
+	if err != nil {
+		return skipgo.Tx{}, fmt.Errorf("json encoding %s: %w", proto.MessageName(msg), err)
+	}
+	return skipgo.Tx{CosmosTx: &skipgo.CosmosTx{
+		ChainID:       chainConfig.ChainID,
+		Path:          []string{chainConfig.ChainID},
+		SignerAddress: chainConfig.SolverAddress,
+		Msgs: []skipgo.CosmosMessage{{
+			Msg:        string(msgJSON),
+			MsgTypeURL: "/" + proto.MessageName(msg),
+		}},
+	}}, nil
+}
+
+// cosmosMsgs decodes the json encoded msgs of a Skip Go cosmos tx into sdk
+// msgs that can be signed and submitted
+func (r *FundRebalancer) cosmosMsgs(cosmosTx *skipgo.CosmosTx) ([]sdk.Msg, error) {
+	var msgs []sdk.Msg
+	for _, cosmosMsg := range cosmosTx.Msgs {
+		msg, err := r.cdc.InterfaceRegistry().Resolve(cosmosMsg.MsgTypeURL)
+		if err != nil {
+			return nil, fmt.Errorf("resolving cosmos msg type %s: %w", cosmosMsg.MsgTypeURL, err)
+		}
+		if err := r.cdc.UnmarshalJSON([]byte(cosmosMsg.Msg), msg); err != nil {
+			return nil, fmt.Errorf("unmarshaling cosmos msg of type %s: %w", cosmosMsg.MsgTypeURL, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no msgs in cosmos tx for chain %s", cosmosTx.ChainID)
+	}
+	return msgs, nil
+}
+
 // SignAndSubmitTxn signs and submits txs to chain
 func (r *FundRebalancer) SignAndSubmitTxn(
 	ctx context.Context,
@@ -670,7 +1011,75 @@ func (r *FundRebalancer) SignAndSubmitTxn(
 
 		return skipgo.TxHash(txHash), rawTxB64, nil
 	case txn.tx.CosmosTx != nil:
-		return "", "", fmt.Errorf("cosmos txns not supported yet")
+		signer, err := signing.NewSigner(ctx, txn.sourceChainID, r.chainIDToPrivateKey)
+		if err != nil {
+			return "", "", fmt.Errorf("creating signer for chain %s: %w", txn.sourceChainID, err)
+		}
+
+		msgs, err := r.cosmosMsgs(txn.tx.CosmosTx)
+		if err != nil {
+			return "", "", fmt.Errorf("decoding cosmos msgs from Skip Go: %w", err)
+		}
+
+		chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(txn.sourceChainID)
+		if err != nil {
+			return "", "", fmt.Errorf("getting config for chain %s: %w", txn.sourceChainID, err)
+		}
+
+		result, tx, err := r.cosmosTxExecutor.ExecuteTx(
+			ctx,
+			txn.sourceChainID,
+			txn.tx.CosmosTx.SignerAddress,
+			msgs,
+			r.txConfig,
+			signer,
+			chainConfig.Cosmos.GasPrice,
+			chainConfig.Cosmos.GasDenom,
+		)
+		if err != nil {
+			return "", "", fmt.Errorf("submitting cosmos txn to chain %s: %w", txn.sourceChainID, err)
+		}
+		if result.Code != 0 {
+			return "", "", fmt.Errorf("submitting cosmos txn to chain %s failed with code %d and log: %s", txn.sourceChainID, result.Code, result.Log)
+		}
+
+		txBytes, err := r.txConfig.TxJSONEncoder()(tx)
+		if err != nil {
+			return "", "", fmt.Errorf("json encoding cosmos txn: %w", err)
+		}
+
+		lmt.Logger(ctx).Info(
+			"submitted txHash to Skip Go to rebalance funds",
+			zap.String("sourceChainID", txn.sourceChainID),
+			zap.String("destChainID", txn.destinationChainID),
+			zap.String("txnHash", result.Hash.String()),
+		)
+
+		return skipgo.TxHash(result.Hash.String()), base64.StdEncoding.EncodeToString(txBytes), nil
+	case txn.tx.SVMTx != nil:
+		signer, err := signing.NewSigner(ctx, txn.sourceChainID, r.chainIDToPrivateKey)
+		if err != nil {
+			return "", "", fmt.Errorf("creating signer for chain %s: %w", txn.sourceChainID, err)
+		}
+
+		tx, err := decodeSVMTx(txn.tx.SVMTx)
+		if err != nil {
+			return "", "", err
+		}
+
+		txHash, rawTxB64, err := r.svmTxExecutor.ExecuteTx(ctx, txn.sourceChainID, tx, signer, txn.tx.SVMTx.AdditionalSigners...)
+		if err != nil {
+			return "", "", fmt.Errorf("submitting svm txn to chain %s: %w", txn.sourceChainID, err)
+		}
+
+		lmt.Logger(ctx).Info(
+			"submitted txHash to Skip Go to rebalance funds",
+			zap.String("sourceChainID", txn.sourceChainID),
+			zap.String("destChainID", txn.destinationChainID),
+			zap.String("txnHash", txHash),
+		)
+
+		return skipgo.TxHash(txHash), rawTxB64, nil
 	default:
 		return "", "", fmt.Errorf("no valid txHash types returned from Skip Go")
 	}
@@ -680,49 +1089,15 @@ func (r *FundRebalancer) NeedsERC20Approval(
 	ctx context.Context,
 	txn skipgo.Tx,
 ) (bool, error) {
-	if txn.EVMTx == nil {
-		// if this isnt an evm tx, no erc20 approvals are required
-		return false, nil
+	approval, err := requiredUSDCApproval(ctx, txn)
+	if err != nil || approval == nil {
+		return false, err
 	}
 	evmTx := txn.EVMTx
-	if len(evmTx.RequiredERC20Approvals) == 0 {
-		// if no approvals are required, return with no error
-		return false, nil
-	}
-	if len(evmTx.RequiredERC20Approvals) > 1 {
-		// only support single approval
-		return false, fmt.Errorf("expected 1 required erc20 approval but got %d", len(evmTx.RequiredERC20Approvals))
-	}
-	approval := evmTx.RequiredERC20Approvals[0]
-
-	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(evmTx.ChainID)
-	if err != nil {
-		return false, fmt.Errorf("getting config for chain %s: %w", evmTx.ChainID, err)
-	}
-	usdcDenom, err := config.GetConfigReader(ctx).GetUSDCDenom(evmTx.ChainID)
-	if err != nil {
-		return false, fmt.Errorf("fetching usdc denom on chain %s: %w", evmTx.ChainID, err)
-	}
-	client, err := r.evmClientManager.GetClient(ctx, evmTx.ChainID)
-	if err != nil {
-		return false, fmt.Errorf("getting evm rpc client for chain %s: %w", evmTx.ChainID, err)
-	}
-
-	// sanity check on the address being returned to be what the solver expects
-	if !strings.EqualFold(approval.TokenContract, usdcDenom) {
-		return false, fmt.Errorf("expected required approval for usdc token contract %s, but got %s", usdcDenom, approval.TokenContract)
-	}
-	spender := common.HexToAddress(approval.Spender)
 
-	caller, err := usdc.NewUsdcCaller(common.HexToAddress(approval.TokenContract), client)
+	allowance, err := r.allowances.Allowance(ctx, evmTx.ChainID, approval.TokenContract, approval.Spender)
 	if err != nil {
-		return false, fmt.Errorf("creating new usdc contract caller at %s on chain %s: %w", approval.TokenContract, evmTx.ChainID, err)
-	}
-
-	opts := &bind.CallOpts{Context: ctx}
-	allowance, err := caller.Allowance(opts, common.HexToAddress(chainConfig.SolverAddress), spender)
-	if err != nil {
-		return false, fmt.Errorf("querying for erc20 allowance for solver %s at contract %s for spender %s: %w", chainConfig.SolverAddress, approval.TokenContract, spender.String(), err)
+		return false, err
 	}
 
 	necessaryApprovalAmount, ok := new(big.Int).SetString(approval.Amount, 10)
@@ -737,121 +1112,87 @@ func (r *FundRebalancer) NeedsERC20Approval(
 	return allowance.Cmp(necessaryApprovalAmount) < 0, nil
 }
 
-func (r *FundRebalancer) ERC20Approval(ctx context.Context, txn skipgo.Tx) (txHash string, rawTx string, err error) {
+// ERC20Approval submits the erc20 approval required by txn. Only the amount
+// required by txn plus the configured approval buffer is approved, and
+// approvals for more than maxAmount, the amount of usdc moved by txn, are
+// refused.
+func (r *FundRebalancer) ERC20Approval(ctx context.Context, txn skipgo.Tx, maxAmount *big.Int) (txHash string, rawTx string, err error) {
+	approval, err := requiredUSDCApproval(ctx, txn)
+	if err != nil || approval == nil {
+		return "", "", err
+	}
+	return r.allowances.approve(ctx, txn.EVMTx.ChainID, *approval, maxAmount)
+}
+
+// requiredUSDCApproval gets the usdc approval required by txn, or nil if txn
+// does not require an approval
+func requiredUSDCApproval(ctx context.Context, txn skipgo.Tx) (*skipgo.ERC20Approval, error) {
 	if txn.EVMTx == nil {
 		// if this isnt an evm tx, no erc20 approvals are required
-		return "", "", nil
+		return nil, nil
 	}
-
 	evmTx := txn.EVMTx
 	if len(evmTx.RequiredERC20Approvals) == 0 {
 		// if no approvals are required, return with no error
-		return "", "", nil
+		return nil, nil
 	}
 	if len(evmTx.RequiredERC20Approvals) > 1 {
 		// only support single approval
-		return "", "", fmt.Errorf("expected 1 required erc20 approval but got %d", len(evmTx.RequiredERC20Approvals))
+		return nil, fmt.Errorf("expected 1 required erc20 approval but got %d", len(evmTx.RequiredERC20Approvals))
 	}
 	approval := evmTx.RequiredERC20Approvals[0]
 
-	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(evmTx.ChainID)
-	if err != nil {
-		return "", "", fmt.Errorf("getting config for chain %s: %w", evmTx.ChainID, err)
-	}
 	usdcDenom, err := config.GetConfigReader(ctx).GetUSDCDenom(evmTx.ChainID)
 	if err != nil {
-		return "", "", fmt.Errorf("fetching usdc denom on chain %s: %w", evmTx.ChainID, err)
+		return nil, fmt.Errorf("fetching usdc denom on chain %s: %w", evmTx.ChainID, err)
 	}
 
 	// sanity check on the address being returned to be what the solver expects
 	if !strings.EqualFold(approval.TokenContract, usdcDenom) {
-		return "", "", fmt.Errorf("expected required approval for usdc token contract %s, but got %s", usdcDenom, approval.TokenContract)
-	}
-
-	signer, err := signing.NewSigner(ctx, evmTx.ChainID, r.chainIDToPrivateKey)
-	if err != nil {
-		return "", "", fmt.Errorf("creating signer for chain %s: %w", evmTx.ChainID, err)
+		return nil, fmt.Errorf("expected required approval for usdc token contract %s, but got %s", usdcDenom, approval.TokenContract)
 	}
-
-	spender := common.HexToAddress(approval.Spender)
-
-	amount, ok := new(big.Int).SetString(approval.Amount, 10)
-	if !ok {
-		return "", "", fmt.Errorf("error converting erc20 approval amount %s on chain %s to *big.Int", approval.Amount, evmTx.ChainID)
-	}
-
-	abi, err := usdc.UsdcMetaData.GetAbi()
-	if err != nil {
-		return "", "", fmt.Errorf("getting usdc contract abi: %w", err)
-	}
-
-	input, err := abi.Pack("approve", spender, amount)
-	if err != nil {
-		return "", "", fmt.Errorf("packing input to erc20 approval tx: %w", err)
-	}
-
-	hash, rawTxB64, err := r.evmTxExecutor.ExecuteTx(
-		ctx,
-		evmTx.ChainID,
-		chainConfig.SolverAddress,
-		input,
-		"0",
-		approval.TokenContract,
-		signer,
-	)
-	if err != nil {
-		return "", "", fmt.Errorf("executing erc20 approve for %s at contract %s for spender %s on %s: %w", amount.String(), approval.TokenContract, approval.Spender, evmTx.ChainID, err)
-	}
-
-	return hash, rawTxB64, nil
+	return &approval, nil
 }
 
 // isGasAcceptable checks if the gas cost for rebalancing transactions is
 // acceptable based on configured thresholds and timeouts
 func (r *FundRebalancer) isGasAcceptable(ctx context.Context, txn SkipGoTxnWithMetadata, chainID string) (bool, string, error) {
-	client, err := r.evmClientManager.GetClient(ctx, chainID)
+	gasCostUUSDC, err := r.gasCostUUSDC(ctx, txn, chainID)
 	if err != nil {
-		return false, "", fmt.Errorf("getting evm client: %w", err)
+		return false, "", fmt.Errorf("calculating total fund rebalancing gas cost in UUSDC: %w", err)
 	}
 
-	gasPrice, err := client.SuggestGasPrice(ctx)
+	acceptable, err := r.isGasCostAcceptable(ctx, gasCostUUSDC, chainID)
 	if err != nil {
-		return false, "", fmt.Errorf("getting gas price: %w", err)
+		return false, "", err
 	}
+	return acceptable, gasCostUUSDC.String(), nil
+}
 
+// isGasCostAcceptable checks if rebalancing from chainID for gasCostUUSDC is
+// within the chains max rebalancing gas cost, or within its transfer cost cap
+// once the chain has been too expensive to rebalance from for longer than its
+// profitability timeout
+func (r *FundRebalancer) isGasCostAcceptable(ctx context.Context, gasCostUUSDC *big.Int, chainID string) (bool, error) {
 	chainFundRebalancingConfig, err := config.GetConfigReader(ctx).GetFundRebalancingConfig(chainID)
 	if err != nil {
-		return false, "", fmt.Errorf("getting chain fund rebalancing config: %w", err)
-	}
-
-	chainIDBigInt, ok := new(big.Int).SetString(chainID, 10)
-	if !ok {
-		return false, "", fmt.Errorf("could not convert chainID %s to *big.Int", chainID)
-	}
-
-	gasCostUUSDC, err := r.txPriceOracle.TxFeeUUSDC(ctx, types.NewTx(&types.DynamicFeeTx{
-		Gas:       txn.gasEstimate,
-		GasFeeCap: gasPrice,
-		ChainID:   chainIDBigInt,
-	}))
-	if err != nil {
-		return false, "", fmt.Errorf("calculating total fund rebalancing gas cost in UUSDC: %w", err)
+		return false, fmt.Errorf("getting chain fund rebalancing config: %w", err)
 	}
 
 	maxCost, ok := new(big.Int).SetString(chainFundRebalancingConfig.MaxRebalancingGasCostUUSDC, 10)
 	if !ok {
-		return false, "", fmt.Errorf("parsing max gas cost threshold")
+		return false, fmt.Errorf("parsing max gas cost threshold")
 	}
 
 	if gasCostUUSDC.Cmp(maxCost) <= 0 {
 		// Gas cost is acceptable, clear any failure tracking for this chain
 		delete(r.profitabilityFailures, chainID)
-		return true, gasCostUUSDC.String(), nil
+		return true, nil
 	}
 
 	// No fund rebalancing timeout set
 	if chainFundRebalancingConfig.ProfitabilityTimeout == -1 {
-		return false, gasCostUUSDC.String(), nil
+		return false, nil
 	}
 
 	failure, exists := r.profitabilityFailures[chainID]
@@ -860,14 +1201,14 @@ func (r *FundRebalancer) isGasAcceptable(ctx context.Context, txn SkipGoTxnWithM
 			firstFailureTime: time.Now(),
 			chainID:          chainID,
 		}
-		return false, gasCostUUSDC.String(), nil
+		return false, nil
 	}
 
 	// If timeout is exceeded, use higher cost cap for timed out rebalancing
 	if time.Since(failure.firstFailureTime) > chainFundRebalancingConfig.ProfitabilityTimeout {
 		costCap, ok := new(big.Int).SetString(chainFundRebalancingConfig.TransferCostCapUUSDC, 10)
 		if !ok {
-			return false, "", fmt.Errorf("parsing rebalancing cost cap")
+			return false, fmt.Errorf("parsing rebalancing cost cap")
 		}
 
 		lmt.Logger(ctx).Info(
@@ -879,9 +1220,55 @@ func (r *FundRebalancer) isGasAcceptable(ctx context.Context, txn SkipGoTxnWithM
 			zap.Time("firstFailureTime", failure.firstFailureTime),
 		)
 
-		return gasCostUUSDC.Cmp(costCap) <= 0, gasCostUUSDC.String(), nil
+		return gasCostUUSDC.Cmp(costCap) <= 0, nil
 	}
 
 	// If timeout hasn't passed, don't accept the current gas price
-	return false, gasCostUUSDC.String(), nil
+	return false, nil
+}
+
+// gasCostUUSDC estimates the cost in uusdc of executing a rebalance txn on
+// chainID at current gas prices
+func (r *FundRebalancer) gasCostUUSDC(ctx context.Context, txn SkipGoTxnWithMetadata, chainID string) (*big.Int, error) {
+	switch {
+	case txn.tx.EVMTx != nil:
+		client, err := r.evmClientManager.GetClient(ctx, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("getting evm client: %w", err)
+		}
+
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getting gas price: %w", err)
+		}
+
+		chainIDBigInt, ok := new(big.Int).SetString(chainID, 10)
+		if !ok {
+			return nil, fmt.Errorf("could not convert chainID %s to *big.Int", chainID)
+		}
+
+		return r.txPriceOracle.TxFeeUUSDC(ctx, types.NewTx(&types.DynamicFeeTx{
+			Gas:       txn.gasEstimate,
+			GasFeeCap: gasPrice,
+			ChainID:   chainIDBigInt,
+		}))
+	case txn.tx.CosmosTx != nil:
+		chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+		if err != nil {
+			return nil, fmt.Errorf("getting config for chain %s: %w", chainID, err)
+		}
+
+		gasPriceDec, err := math.LegacyNewDecFromStr(strconv.FormatFloat(chainConfig.Cosmos.GasPrice, 'f', -1, 64))
+		if err != nil {
+			return nil, fmt.Errorf("converting gas price %f to decimal: %w", chainConfig.Cosmos.GasPrice, err)
+		}
+		txFee := gasPriceDec.MulInt64(int64(txn.gasEstimate)).Ceil().TruncateInt().BigInt()
+
+		return r.txPriceOracle.GasCostUUSDC(ctx, txFee, chainID)
+	case txn.tx.SVMTx != nil:
+		// the gas estimate of svm txs is their fee in lamports
+		return r.txPriceOracle.GasCostUUSDC(ctx, new(big.Int).SetUint64(txn.gasEstimate), chainID)
+	default:
+		return nil, fmt.Errorf("no valid tx types returned from Skip Go")
+	}
 }
@@ -11,8 +11,14 @@ import (
 	"testing"
 	"time"
 
+	"cosmossdk.io/math"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	mock_cosmos "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/cosmos"
 	evm2 "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/evm"
 
 	dbtypes "github.com/skip-mev/go-fast-solver/db"
@@ -27,6 +33,7 @@ import (
 	"github.com/skip-mev/go-fast-solver/shared/config"
 	"github.com/skip-mev/go-fast-solver/shared/contracts/usdc"
 	"github.com/skip-mev/go-fast-solver/shared/keys"
+	"github.com/skip-mev/go-fast-solver/shared/signing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -98,6 +105,24 @@ func loadKeysFile(keys any) (*os.File, error) {
 	return f, nil
 }
 
+// mockTxQuerier passes the writes made in a db transaction through to a
+// MockDatabase so that they are checked against its expectations. Queries the
+// MockDatabase does not mock panic.
+type mockTxQuerier struct {
+	*mock_database.MockDatabase
+	querier
+}
+
+type querier struct{ db.Querier }
+
+// expectInTx expects a db transaction to be run against mockDatabase
+func expectInTx(mockDatabase *mock_database.MockDatabase) {
+	mockDatabase.EXPECT().InTx(mockContext, mock.Anything, (*sql.TxOptions)(nil)).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context, db.Querier) error, opts *sql.TxOptions) error {
+			return fn(ctx, mockTxQuerier{MockDatabase: mockDatabase})
+		})
+}
+
 func TestFundRebalancer_Rebalance(t *testing.T) {
 	t.Run("no rebalancing necessary", func(t *testing.T) {
 		ctx := context.Background()
@@ -122,6 +147,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		})
 
 		mockConfigReader.EXPECT().GetUSDCDenom(osmosisChainID).Return(osmosisUSDCDenom, nil)
+		mockConfigReader.EXPECT().GetUSDCDenom(arbitrumChainID).Return(arbitrumUSDCDenom, nil)
 		mockConfigReader.On("GetChainConfig", osmosisChainID).Return(
 			config.ChainConfig{
 				Type:          config.ChainType_COSMOS,
@@ -145,13 +171,16 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 
 		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
 		mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+		mockEVMClient := mock_evmrpc.NewMockEVMChainRPC(t)
+		mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockEVMClient, nil)
 		mockDatabse := mock_database.NewMockDatabase(t)
 		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 		assert.NoError(t, err)
 		mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
 
-		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor)
+		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 		assert.NoError(t, err)
 
 		// setup initial state of mocks
@@ -159,8 +188,10 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		// no pending txns
 		mockDatabse.EXPECT().GetAllPendingRebalanceTransfers(mockContext).Return(nil, nil).Maybe()
 		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, osmosisChainID).Return(nil, nil)
+		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, arbitrumChainID).Return(nil, nil)
 
 		// balances higher than min amount
+		mockEVMClient.EXPECT().GetUSDCBalance(mockContext, arbitrumUSDCDenom, arbitrumAddress).Return(big.NewInt(arbitrumTargetAmount), nil)
 		mockSkipGo.EXPECT().Balance(mockContext, &skipgo.BalancesRequest{
 			Chains: map[string]skipgo.ChainRequest{
 				osmosisChainID: {
@@ -254,6 +285,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		mockDatabse := mock_database.NewMockDatabase(t)
 
 		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 		mockEVMTxExecutor.On("ExecuteTx", mockContext, arbitrumChainID, arbitrumAddress, []byte{}, "999", osmosisAddress, mock.Anything).Return("arbitrum hash", "", nil)
 
 		mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
@@ -262,7 +294,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 		assert.NoError(t, err)
 
-		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor)
+		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 		assert.NoError(t, err)
 
 		// setup initial state of mocks
@@ -270,6 +302,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		// no pending txns
 		mockDatabse.EXPECT().GetAllPendingRebalanceTransfers(mockContext).Return(nil, nil).Maybe()
 		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, osmosisChainID).Return(nil, nil)
+		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, arbitrumChainID).Return(nil, nil)
 
 		// osmosis balance lower than min amount, arbitrum & eth balances higher than target
 		mockSkipGo.EXPECT().Balance(mockContext, &skipgo.BalancesRequest{
@@ -311,11 +344,13 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 
 		mockEVMClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(100), nil).Once()
 
+		expectInTx(mockDatabse)
 		mockDatabse.EXPECT().InsertRebalanceTransfer(mockContext, db.InsertRebalanceTransferParams{
 			TxHash:             "arbitrum hash",
 			SourceChainID:      arbitrumChainID,
 			DestinationChainID: osmosisChainID,
 			Amount:             "100",
+			QuotedCostUusdc:    sql.NullString{String: "75", Valid: true},
 		}).Return(0, nil)
 
 		// insert tx into submitted txs table
@@ -402,9 +437,12 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockEVMClient, nil)
 		mockEVMClientManager.EXPECT().GetClient(mockContext, ethChainID).Return(mockEVMClient, nil)
 		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 		mockEVMTxExecutor.On("ExecuteTx", mockContext, "42161", arbitrumAddress, []byte{}, "0", osmosisAddress, mock.Anything).Return("arbhash", "", nil)
 		mockEVMTxExecutor.On("ExecuteTx", mockContext, "1", ethAddress, []byte{}, "0", osmosisAddress, mock.Anything).Return("ethhash", "", nil)
+		mockEVMClient.EXPECT().SuggestGasPrice(mockContext).Return(big.NewInt(100), nil)
 		mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
+		mockTxPriceOracle.On("TxFeeUUSDC", mockContext, mock.Anything).Return(big.NewInt(1), nil)
 
 		// using an in memory database for this test
 		mockDatabse := mock_database.NewFakeDatabase()
@@ -412,7 +450,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 		assert.NoError(t, err)
 
-		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor)
+		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 		assert.NoError(t, err)
 
 		// setup initial state of mocks
@@ -512,6 +550,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		})
 
 		mockConfigReader.EXPECT().GetUSDCDenom(osmosisChainID).Return(osmosisUSDCDenom, nil)
+		mockConfigReader.EXPECT().GetUSDCDenom(arbitrumChainID).Return(arbitrumUSDCDenom, nil)
 		mockConfigReader.On("GetChainConfig", osmosisChainID).Return(
 			config.ChainConfig{
 				Type:          config.ChainType_COSMOS,
@@ -535,13 +574,16 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 
 		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
 		mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+		mockEVMClient := mock_evmrpc.NewMockEVMChainRPC(t)
+		mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockEVMClient, nil)
 		mockDatabse := mock_database.NewMockDatabase(t)
 		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 		mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
 		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 		assert.NoError(t, err)
 
-		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor)
+		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 		assert.NoError(t, err)
 
 		// setup initial state of mocks
@@ -554,7 +596,10 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 			{ID: 1, TxHash: "hash", SourceChainID: arbitrumChainID, DestinationChainID: osmosisChainID, Amount: strconv.Itoa(osmosisTargetAmount)},
 		}, nil)
 
-		// osmosis balance lower than min amount, arbitrum & eth balances higher than target
+		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, arbitrumChainID).Return(nil, nil)
+
+		// osmosis balance lower than min amount, arbitrum balance at target
+		mockEVMClient.EXPECT().GetUSDCBalance(mockContext, arbitrumUSDCDenom, arbitrumAddress).Return(big.NewInt(arbitrumTargetAmount), nil)
 		mockSkipGo.EXPECT().Balance(mockContext, &skipgo.BalancesRequest{
 			Chains: map[string]skipgo.ChainRequest{
 				osmosisChainID: {
@@ -647,6 +692,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		mockDatabse := mock_database.NewMockDatabase(t)
 
 		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 
 		mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
 		mockTxPriceOracle.On("TxFeeUUSDC", mockContext, mock.Anything, mock.Anything).Return(big.NewInt(51), nil)
@@ -654,10 +700,11 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 		assert.NoError(t, err)
 
-		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor)
+		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 		assert.NoError(t, err)
 		// No pending txns
 		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, osmosisChainID).Return(nil, nil)
+		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, arbitrumChainID).Return(nil, nil)
 		// Osmosis needs funds, Arbitrum has excess
 		mockSkipGo.EXPECT().Balance(mockContext, &skipgo.BalancesRequest{
 			Chains: map[string]skipgo.ChainRequest{
@@ -773,11 +820,12 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		to := common.HexToAddress(arbitrumUSDCDenom)
 		msg := ethereum.CallMsg{From: common.Address{}, To: &to, Data: data}
 		var nilBigInt *big.Int
-		mockEVMClient.EXPECT().CallContract(mock.Anything, msg, nilBigInt).Return(common.LeftPadBytes(big.NewInt(100).Bytes(), 32), nil)
+		mockEVMClient.EXPECT().CallContract(mock.Anything, msg, nilBigInt).Return(common.LeftPadBytes(big.NewInt(10).Bytes(), 32), nil)
 
 		mockDatabse := mock_database.NewMockDatabase(t)
 
 		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 		mockEVMTxExecutor.On("ExecuteTx", mockContext, arbitrumChainID, arbitrumAddress, []byte{}, "999", osmosisAddress, mock.Anything).Return("arbitrum hash", "", nil)
 
 		// mock executing the approval tx
@@ -790,12 +838,23 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 			TxStatus: dbtypes.TxStatusPending,
 		}).Return(db.SubmittedTx{}, nil).Once()
 
+		// record the approved spender in the allowance registry
+		mockDatabse.EXPECT().SetERC20AllowanceApproved(mockContext, db.SetERC20AllowanceApprovedParams{
+			ChainID:        arbitrumChainID,
+			TokenContract:  arbitrumUSDCDenom,
+			Spender:        "0xskipgo",
+			ApprovedAmount: sql.NullString{String: strconv.Itoa(osmosisTargetAmount), Valid: true},
+			ApproveTxHash:  sql.NullString{String: "arbitrum approval hash", Valid: true},
+		}).Return(db.Erc20Allowance{}, nil).Once()
+
 		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 		assert.NoError(t, err)
 
+		mockEVMClient.EXPECT().SuggestGasPrice(mockContext).Return(big.NewInt(100), nil)
 		mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
+		mockTxPriceOracle.On("TxFeeUUSDC", mockContext, mock.Anything).Return(big.NewInt(1), nil)
 
-		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor)
+		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 		assert.NoError(t, err)
 
 		// setup initial state of mocks
@@ -803,6 +862,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		// no pending txns
 		mockDatabse.EXPECT().GetAllPendingRebalanceTransfers(mockContext).Return(nil, nil).Maybe()
 		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, osmosisChainID).Return(nil, nil)
+		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, arbitrumChainID).Return(nil, nil)
 
 		// osmosis balance lower than min amount, arbitrum & eth balances higher than target
 		mockSkipGo.EXPECT().Balance(mockContext, &skipgo.BalancesRequest{
@@ -846,7 +906,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 				RequiredERC20Approvals: []skipgo.ERC20Approval{{
 					TokenContract: arbitrumUSDCDenom,
 					Spender:       "0xskipgo",
-					Amount:        "999",
+					Amount:        strconv.Itoa(osmosisTargetAmount),
 				}},
 				SignerAddress: arbitrumAddress,
 			}}}
@@ -855,11 +915,13 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 
 		mockEVMClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(100), nil)
 
+		expectInTx(mockDatabse)
 		mockDatabse.EXPECT().InsertRebalanceTransfer(mockContext, db.InsertRebalanceTransferParams{
 			TxHash:             "arbitrum hash",
 			SourceChainID:      arbitrumChainID,
 			DestinationChainID: osmosisChainID,
 			Amount:             strconv.Itoa(osmosisTargetAmount),
+			QuotedCostUusdc:    sql.NullString{String: "1", Valid: true},
 		}).Return(0, nil)
 
 		// insert tx into submitted txs table
@@ -937,15 +999,25 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 
 		mockDatabse := mock_database.NewMockDatabase(t)
 
+		// the existing allowance is still recorded as used in the allowance registry
+		mockDatabse.EXPECT().SetERC20AllowanceUsed(mockContext, db.SetERC20AllowanceUsedParams{
+			ChainID:       arbitrumChainID,
+			TokenContract: arbitrumUSDCDenom,
+			Spender:       "0xskipgo",
+		}).Return(nil).Once()
+
 		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 		mockEVMTxExecutor.On("ExecuteTx", mockContext, arbitrumChainID, arbitrumAddress, []byte{}, "999", osmosisAddress, mock.Anything).Return("arbitrum hash", "", nil)
 
 		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 		assert.NoError(t, err)
 
+		mockEVMClient.EXPECT().SuggestGasPrice(mockContext).Return(big.NewInt(100), nil)
 		mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
+		mockTxPriceOracle.On("TxFeeUUSDC", mockContext, mock.Anything).Return(big.NewInt(1), nil)
 
-		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor)
+		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 		assert.NoError(t, err)
 
 		// setup initial state of mocks
@@ -953,6 +1025,7 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 		// no pending txns
 		mockDatabse.EXPECT().GetAllPendingRebalanceTransfers(mockContext).Return(nil, nil).Maybe()
 		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, osmosisChainID).Return(nil, nil)
+		mockDatabse.EXPECT().GetPendingRebalanceTransfersToChain(mockContext, arbitrumChainID).Return(nil, nil)
 
 		// osmosis balance lower than min amount, arbitrum & eth balances higher than target
 		mockSkipGo.EXPECT().Balance(mockContext, &skipgo.BalancesRequest{
@@ -1005,11 +1078,13 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 
 		mockEVMClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(100), nil)
 
+		expectInTx(mockDatabse)
 		mockDatabse.EXPECT().InsertRebalanceTransfer(mockContext, db.InsertRebalanceTransferParams{
 			TxHash:             "arbitrum hash",
 			SourceChainID:      arbitrumChainID,
 			DestinationChainID: osmosisChainID,
 			Amount:             strconv.Itoa(osmosisTargetAmount),
+			QuotedCostUusdc:    sql.NullString{String: "1", Valid: true},
 		}).Return(0, nil)
 
 		// insert tx into submitted txs table
@@ -1023,6 +1098,136 @@ func TestFundRebalancer_Rebalance(t *testing.T) {
 
 		rebalancer.Rebalance(ctx)
 	})
+
+	t.Run("osmosis to arbitrum rebalance with cosmos tx", func(t *testing.T) {
+		ctx := context.Background()
+		mockConfigReader := mock_config.NewMockConfigReader(t)
+		osmosisRebalancingConfig := config.FundRebalancerConfig{
+			TargetAmount:               strconv.Itoa(arbitrumTargetAmount),
+			MinAllowedAmount:           strconv.Itoa(arbitrumMinAmount),
+			MaxRebalancingGasCostUUSDC: "50000000",
+			ProfitabilityTimeout:       disabledTimeout,
+			TransferCostCapUUSDC:       "10000000",
+		}
+		mockConfigReader.On("Config").Return(config.Config{
+			FundRebalancer: map[string]config.FundRebalancerConfig{
+				osmosisChainID: osmosisRebalancingConfig,
+				arbitrumChainID: {
+					TargetAmount:     strconv.Itoa(osmosisTargetAmount),
+					MinAllowedAmount: strconv.Itoa(osmosisMinAmount),
+				},
+			},
+		})
+		mockConfigReader.On("GetFundRebalancingConfig", osmosisChainID).Return(osmosisRebalancingConfig, nil)
+		mockConfigReader.EXPECT().GetUSDCDenom(osmosisChainID).Return(osmosisUSDCDenom, nil)
+		mockConfigReader.EXPECT().GetUSDCDenom(arbitrumChainID).Return(arbitrumUSDCDenom, nil)
+		mockConfigReader.On("GetChainConfig", osmosisChainID).Return(
+			config.ChainConfig{
+				Type:          config.ChainType_COSMOS,
+				USDCDenom:     osmosisUSDCDenom,
+				SolverAddress: osmosisAddress,
+				Cosmos: &config.CosmosConfig{
+					AddressPrefix: "osmo",
+					GasPrice:      0.025,
+					GasDenom:      "uosmo",
+				},
+			},
+			nil,
+		)
+		mockConfigReader.On("GetChainConfig", arbitrumChainID).Return(
+			config.ChainConfig{
+				Type:          config.ChainType_EVM,
+				USDCDenom:     arbitrumUSDCDenom,
+				SolverAddress: arbitrumAddress,
+			},
+			nil,
+		)
+		ctx = config.ConfigReaderContext(ctx, mockConfigReader)
+
+		f, err := loadKeysFile(defaultKeys)
+		assert.NoError(t, err)
+
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+		mockEVMClient := mock_evmrpc.NewMockEVMChainRPC(t)
+		mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockEVMClient, nil)
+		mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+		mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
+		mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
+		mockDatabse := mock_database.NewFakeDatabase()
+
+		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
+		assert.NoError(t, err)
+
+		rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabse, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
+		assert.NoError(t, err)
+
+		// arbitrum balance lower than min amount, osmosis balance higher than target
+		mockEVMClient.EXPECT().GetUSDCBalance(mockContext, arbitrumUSDCDenom, arbitrumAddress).Return(big.NewInt(0), nil)
+		mockSkipGo.EXPECT().Balance(mockContext, &skipgo.BalancesRequest{
+			Chains: map[string]skipgo.ChainRequest{
+				osmosisChainID: {
+					Address: osmosisAddress,
+					Denoms:  []string{osmosisUSDCDenom},
+				},
+			},
+		}).Return(&skipgo.BalancesResponse{
+			Chains: map[string]skipgo.ChainResponse{
+				osmosisChainID: {
+					Address: osmosisAddress,
+					Denoms: map[string]skipgo.DenomDetail{
+						osmosisUSDCDenom: {Amount: "1000", Decimals: 6},
+					},
+				},
+			},
+		}, nil)
+
+		route := &skipgo.RouteResponse{
+			AmountOut:              strconv.Itoa(osmosisTargetAmount),
+			Operations:             []any{"opts"},
+			RequiredChainAddresses: []string{osmosisChainID, arbitrumChainID},
+		}
+		mockSkipGo.EXPECT().Route(mockContext, osmosisUSDCDenom, osmosisChainID, arbitrumUSDCDenom, arbitrumChainID, big.NewInt(osmosisTargetAmount)).
+			Return(route, nil).Once()
+
+		transferMsg := `{"source_port":"transfer","source_channel":"channel-750","token":{"denom":"ibc/123","amount":"100"},"sender":"osmo1abc","receiver":"noble1abc","timeout_height":{},"timeout_timestamp":1700000000000000000,"memo":""}`
+		txs := []skipgo.Tx{{CosmosTx: &skipgo.CosmosTx{
+			ChainID:       osmosisChainID,
+			SignerAddress: osmosisAddress,
+			Msgs:          []skipgo.CosmosMessage{{Msg: transferMsg, MsgTypeURL: "/ibc.applications.transfer.v1.MsgTransfer"}},
+		}}}
+		mockSkipGo.EXPECT().Msgs(mockContext, osmosisUSDCDenom, osmosisChainID, osmosisAddress, arbitrumUSDCDenom, arbitrumChainID, arbitrumAddress, big.NewInt(osmosisTargetAmount), big.NewInt(osmosisTargetAmount), []string{osmosisAddress, arbitrumAddress}, route.Operations).
+			Return(txs, nil).Once()
+
+		expectedMsg := &ibctransfertypes.MsgTransfer{
+			SourcePort:       "transfer",
+			SourceChannel:    "channel-750",
+			Token:            sdk.NewCoin(osmosisUSDCDenom, math.NewInt(100)),
+			Sender:           osmosisAddress,
+			Receiver:         nobleAddress,
+			TimeoutTimestamp: 1700000000000000000,
+		}
+		mockCosmosTxExecutor.EXPECT().EstimateGas(mockContext, osmosisChainID, osmosisAddress, []sdk.Msg{expectedMsg}, mock.Anything, mock.Anything).Return(100000, nil)
+		// 0.025 uosmo gas price * 100000 gas
+		mockTxPriceOracle.EXPECT().GasCostUUSDC(mockContext, big.NewInt(2500), osmosisChainID).Return(big.NewInt(10), nil)
+		mockCosmosTxExecutor.EXPECT().ExecuteTx(mockContext, osmosisChainID, osmosisAddress, []sdk.Msg{expectedMsg}, mock.Anything, mock.Anything, 0.025, "uosmo").
+			RunAndReturn(func(ctx context.Context, chainID string, signerAddress string, msgs []sdk.Msg, txConfig sdkclient.TxConfig, signer signing.Signer, gasPrice float64, gasDenom string) (*coretypes.ResultBroadcastTx, sdk.Tx, error) {
+				txBuilder := txConfig.NewTxBuilder()
+				if err := txBuilder.SetMsgs(msgs...); err != nil {
+					return nil, nil, err
+				}
+				return &coretypes.ResultBroadcastTx{Hash: []byte{0xab, 0xcd}}, txBuilder.GetTx(), nil
+			})
+
+		rebalancer.Rebalance(ctx)
+
+		transfers := mockDatabse.GetDBContents()
+		assert.Len(t, transfers, 1)
+		assert.Equal(t, "ABCD", transfers[0].TxHash)
+		assert.Equal(t, osmosisChainID, transfers[0].SourceChainID)
+		assert.Equal(t, arbitrumChainID, transfers[0].DestinationChainID)
+		assert.Equal(t, strconv.Itoa(osmosisTargetAmount), transfers[0].Amount)
+	})
 }
 
 func TestFundRebalancer_GasAcceptability(t *testing.T) {
@@ -1195,18 +1400,62 @@ func TestFundRebalancer_GasAcceptability(t *testing.T) {
 	})
 }
 
+func TestFundRebalancer_RecordRebalanceTransfer(t *testing.T) {
+	txnWithMetadata := SkipGoTxnWithMetadata{
+		sourceChainID:      arbitrumChainID,
+		destinationChainID: osmosisChainID,
+		amount:             big.NewInt(100),
+	}
+	txns := []skipgo.Tx{
+		{EVMTx: &skipgo.EVMTx{ChainID: arbitrumChainID, To: "0x1", Data: "aa", Value: "0"}},
+		{CosmosTx: &skipgo.CosmosTx{ChainID: nobleChainID, Path: []string{nobleChainID, osmosisChainID}}},
+		{CosmosTx: &skipgo.CosmosTx{ChainID: osmosisChainID, Path: []string{osmosisChainID}}},
+	}
+	route := &skipgo.RouteResponse{DestAssetDenom: osmosisUSDCDenom}
+
+	t.Run("multi tx transfer is recorded with its steps", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		r := &FundRebalancer{database: database}
+
+		id, err := r.recordRebalanceTransfer(context.Background(), dbtypes.RebalanceTransferKindRebalance, txnWithMetadata, "hash", "", big.NewInt(1), route, txns)
+		assert.NoError(t, err)
+
+		assert.Len(t, database.GetDBContents(), 1)
+		steps := database.GetStepContents()
+		assert.Len(t, steps, len(txns))
+		for _, step := range steps {
+			assert.Equal(t, id, step.RebalanceTransferID)
+		}
+	})
+
+	t.Run("transfer is not recorded when inserting its steps fails", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		database.FailStepInsert(2, fmt.Errorf("db error"))
+		r := &FundRebalancer{database: database}
+
+		_, err := r.recordRebalanceTransfer(context.Background(), dbtypes.RebalanceTransferKindRebalance, txnWithMetadata, "hash", "", big.NewInt(1), route, txns)
+		assert.Error(t, err)
+
+		// a multi tx transfer without its steps would be tracked without the
+		// rest of its route ever being submitted
+		assert.Empty(t, database.GetDBContents())
+		assert.Empty(t, database.GetStepContents())
+	})
+}
+
 func setupRebalancer(t *testing.T, ctx context.Context, mockEVMClient *mock_evmrpc.MockEVMChainRPC, mockTxPriceOracle *mock_oracle.MockTxPriceOracle, mockDatabase *mock_database.MockDatabase) *FundRebalancer {
 	mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
 	mockEVMClientManager.EXPECT().GetClient(mock.Anything, arbitrumChainID).Return(mockEVMClient, nil)
 	mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
 	mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+	mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 
 	f, err := loadKeysFile(defaultKeys)
 	assert.NoError(t, err)
 	keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 	assert.NoError(t, err)
 
-	rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabase, mockTxPriceOracle, mockEVMTxExecutor)
+	rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, mockDatabase, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 	assert.NoError(t, err)
 	return rebalancer
 }
@@ -0,0 +1,211 @@
+package fundrebalancer
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/metrics"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	// gasTopUpLimitWindow is the rolling window daily gas top up caps are
+	// enforced over
+	gasTopUpLimitWindow = 24 * time.Hour
+
+	limitGasTopUpPending  = "gas_top_up_pending"
+	limitGasTopUpDaily    = "gas_top_up_max_daily"
+	limitGasTopUpCooldown = "gas_top_up_cooldown"
+)
+
+// gasTopUp is a parsed gas top up config
+type gasTopUp struct {
+	amount        *big.Int
+	gasTokenDenom string
+	sourceChainID string
+	maxDaily      *big.Int
+	cooldown      time.Duration
+}
+
+// gasTopUpFor gets the gas top up configured for chainID, or nil if the
+// chains gas balance should not be topped up
+func gasTopUpFor(ctx context.Context, chainID string) (*gasTopUp, error) {
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting config for chain %s: %w", chainID, err)
+	}
+
+	var topUpConfig *config.GasTopUpConfig
+	gasTokenDenom := ""
+	switch chainConfig.Type {
+	case config.ChainType_COSMOS:
+		topUpConfig = chainConfig.Cosmos.SignerGasBalance.TopUp
+		gasTokenDenom = chainConfig.Cosmos.GasDenom
+	case config.ChainType_EVM:
+		topUpConfig = chainConfig.EVM.SignerGasBalance.TopUp
+	default:
+		return nil, nil
+	}
+	if topUpConfig == nil {
+		return nil, nil
+	}
+
+	topUp := &gasTopUp{
+		gasTokenDenom: gasTokenDenom,
+		sourceChainID: chainID,
+		cooldown:      topUpConfig.Cooldown,
+	}
+	if topUpConfig.GasTokenDenom != "" {
+		topUp.gasTokenDenom = topUpConfig.GasTokenDenom
+	}
+	if topUpConfig.SourceChainID != "" {
+		topUp.sourceChainID = topUpConfig.SourceChainID
+	}
+	amount, ok := new(big.Int).SetString(topUpConfig.AmountUUSDC, 10)
+	if !ok {
+		return nil, fmt.Errorf("could not convert gas top up amount %s for chain %s to *big.Int", topUpConfig.AmountUUSDC, chainID)
+	}
+	topUp.amount = amount
+	if topUpConfig.MaxDailyUUSDC != "" {
+		maxDaily, ok := new(big.Int).SetString(topUpConfig.MaxDailyUUSDC, 10)
+		if !ok {
+			return nil, fmt.Errorf("could not convert gas top up max daily amount %s for chain %s to *big.Int", topUpConfig.MaxDailyUUSDC, chainID)
+		}
+		topUp.maxDaily = maxDaily
+	}
+	return topUp, nil
+}
+
+// TopUpGas swaps the configured amount of usdc into the gas token of chainID
+// through Skip Go, bridging the usdc from the configured source chain if it
+// is not chainID. The top up is recorded as a gas top up rebalance transfer
+// and tracked until it completes like any other rebalance. An empty hash is
+// returned if no top up is configured for chainID. An error wrapping
+// ErrRebalanceLimitReached is returned if the top up is blocked by a pending
+// top up, the configured cooldown or the daily cap.
+func (r *FundRebalancer) TopUpGas(ctx context.Context, chainID string) (skipgo.TxHash, error) {
+	topUp, err := gasTopUpFor(ctx, chainID)
+	if err != nil {
+		return "", err
+	}
+	if topUp == nil {
+		return "", nil
+	}
+	if err := r.checkGasTopUpLimits(ctx, chainID, topUp); err != nil {
+		return "", err
+	}
+
+	ctx = lmt.With(
+		ctx,
+		zap.String("destinationChainID", chainID),
+		zap.String("sourceChainID", topUp.sourceChainID),
+		zap.String("topUpAmountUUSDC", topUp.amount.String()),
+		zap.String("gasTokenDenom", topUp.gasTokenDenom),
+	)
+
+	balance, err := r.usdcBalance(ctx, topUp.sourceChainID)
+	if err != nil {
+		return "", fmt.Errorf("getting usdc balance on chain %s: %w", topUp.sourceChainID, err)
+	}
+	if balance.Cmp(topUp.amount) < 0 {
+		return "", fmt.Errorf("usdc balance %s on chain %s is less than the gas top up amount of %s uusdc", balance.String(), topUp.sourceChainID, topUp.amount.String())
+	}
+
+	usdcDenom, err := config.GetConfigReader(ctx).GetUSDCDenom(topUp.sourceChainID)
+	if err != nil {
+		return "", fmt.Errorf("getting usdc denom for chain %s: %w", topUp.sourceChainID, err)
+	}
+	route, txns, err := r.skipGoRoute(ctx, topUp.amount, topUp.sourceChainID, usdcDenom, chainID, topUp.gasTokenDenom)
+	if err != nil {
+		return "", fmt.Errorf("getting txns required to swap %s uusdc on chain %s to %s on chain %s: %w", topUp.amount.String(), topUp.sourceChainID, topUp.gasTokenDenom, chainID, err)
+	}
+	if len(txns) == 0 {
+		return "", fmt.Errorf("no txns returned to swap %s uusdc on chain %s to %s on chain %s", topUp.amount.String(), topUp.sourceChainID, topUp.gasTokenDenom, chainID)
+	}
+	feeUUSDC, err := routeFeeUUSDC(route, usdcDenom)
+	if err != nil {
+		return "", fmt.Errorf("getting fees of gas top up route: %w", err)
+	}
+
+	// only the first tx of a multi tx route is submitted here, the rest are
+	// submitted by the route executor once the previous tx's transfer has
+	// completed
+	if err := r.submitApproval(ctx, topUp.sourceChainID, txns[0], topUp.amount); err != nil {
+		return "", fmt.Errorf("approving txn for gas top up of chain %s: %w", chainID, err)
+	}
+	txnWithMetadata, err := r.TxnWithMetadata(ctx, topUp.sourceChainID, chainID, topUp.amount, txns[0])
+	if err != nil {
+		return "", fmt.Errorf("getting transaction metadata to top up gas on chain %s: %w", chainID, err)
+	}
+	hash, rawTx, err := r.SignAndSubmitTxn(ctx, txnWithMetadata)
+	if err != nil {
+		return "", fmt.Errorf("signing and submitting transaction: %w", err)
+	}
+
+	if _, err := r.recordRebalanceTransfer(ctx, dbtypes.RebalanceTransferKindGasTopUp, txnWithMetadata, hash, rawTx, feeUUSDC, route, txns); err != nil {
+		return "", err
+	}
+
+	lmt.Logger(ctx).Info("submitted gas top up", zap.String("txHash", string(hash)))
+	return hash, nil
+}
+
+// checkGasTopUpLimits returns an error wrapping ErrRebalanceLimitReached if
+// a gas top up of chainID is still pending, if chainID was topped up within
+// the configured cooldown, or if topping it up again would exceed the daily
+// cap
+func (r *FundRebalancer) checkGasTopUpLimits(ctx context.Context, chainID string, topUp *gasTopUp) error {
+	pendingTransfers, err := r.database.GetAllPendingRebalanceTransfers(ctx)
+	if err != nil {
+		return fmt.Errorf("getting pending rebalance transfers: %w", err)
+	}
+	for _, transfer := range pendingTransfers {
+		if transfer.Kind == dbtypes.RebalanceTransferKindGasTopUp && transfer.DestinationChainID == chainID {
+			metrics.FromContext(ctx).IncFundsRebalanceLimitHit(chainID, limitGasTopUpPending)
+			return fmt.Errorf("%w: gas top up with hash %s into %s is still pending", ErrRebalanceLimitReached, transfer.TxHash, chainID)
+		}
+	}
+	if topUp.maxDaily == nil && topUp.cooldown <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	transfers, err := r.database.GetRebalanceTransfersSince(ctx, now.Add(-gasTopUpLimitWindow).UTC())
+	if err != nil {
+		return fmt.Errorf("getting rebalance transfers in the last %s: %w", gasTopUpLimitWindow, err)
+	}
+	spent := big.NewInt(0)
+	var lastTopUp time.Time
+	for _, transfer := range transfers {
+		if transfer.Kind != dbtypes.RebalanceTransferKindGasTopUp || transfer.DestinationChainID != chainID {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(transfer.Amount, 10)
+		if !ok {
+			return fmt.Errorf("could not convert gas top up amount %s to *big.Int", transfer.Amount)
+		}
+		spent.Add(spent, amount)
+		if transfer.CreatedAt.After(lastTopUp) {
+			lastTopUp = transfer.CreatedAt
+		}
+	}
+
+	if topUp.cooldown > 0 && !lastTopUp.IsZero() && now.Sub(lastTopUp) < topUp.cooldown {
+		metrics.FromContext(ctx).IncFundsRebalanceLimitHit(chainID, limitGasTopUpCooldown)
+		return fmt.Errorf("%w: last gas top up into %s was %s ago, cooldown is %s", ErrRebalanceLimitReached, chainID, now.Sub(lastTopUp).Round(time.Second), topUp.cooldown)
+	}
+	if topUp.maxDaily != nil {
+		metrics.FromContext(ctx).SetFundsRebalanceBudgetUsage(chainID, limitGasTopUpDaily, spent, topUp.maxDaily)
+		if new(big.Int).Add(spent, topUp.amount).Cmp(topUp.maxDaily) > 0 {
+			metrics.FromContext(ctx).IncFundsRebalanceLimitHit(chainID, limitGasTopUpDaily)
+			return fmt.Errorf("%w: gas top up of %s uusdc would exceed the max daily gas top ups of %s uusdc for %s, %s uusdc already spent in the last %s", ErrRebalanceLimitReached, topUp.amount.String(), topUp.maxDaily.String(), chainID, spent.String(), gasTopUpLimitWindow)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package fundrebalancer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	mock_database "github.com/skip-mev/go-fast-solver/mocks/fundrebalancer"
+	mock_skipgo "github.com/skip-mev/go-fast-solver/mocks/shared/clients/skipgo"
+	mock_config "github.com/skip-mev/go-fast-solver/mocks/shared/config"
+	mock_evmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/evmrpc"
+	mock_evm "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/evm"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundRebalancer_TopUpGas(t *testing.T) {
+	const gasTokenDenom = "arbitrum-native"
+
+	newRebalancer := func(t *testing.T, topUp *config.GasTopUpConfig) (context.Context, *FundRebalancer, *mock_database.FakeDatabase, *mock_skipgo.MockSkipGoClient, *mock_evmrpc.MockEVMChainRPC, *mock_evm.MockEVMTxExecutor) {
+		mockConfigReader := mock_config.NewMockConfigReader(t)
+		mockConfigReader.On("GetChainConfig", arbitrumChainID).Return(config.ChainConfig{
+			Type:          config.ChainType_EVM,
+			USDCDenom:     arbitrumUSDCDenom,
+			SolverAddress: arbitrumAddress,
+			EVM: &config.EVMConfig{
+				SignerGasBalance: config.SignerGasBalanceConfig{TopUp: topUp},
+			},
+		}, nil).Maybe()
+		mockConfigReader.On("GetUSDCDenom", arbitrumChainID).Return(arbitrumUSDCDenom, nil).Maybe()
+		ctx := config.ConfigReaderContext(context.Background(), mockConfigReader)
+
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+		mockEVMClient := mock_evmrpc.NewMockEVMChainRPC(t)
+		mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockEVMClient, nil).Maybe()
+		mockEVMTxExecutor := mock_evm.NewMockEVMTxExecutor(t)
+		database := mock_database.NewFakeDatabase()
+
+		r := &FundRebalancer{
+			chainIDToPrivateKey: map[string]string{arbitrumChainID: arbitrumPrivateKey},
+			skipgo:              mockSkipGo,
+			evmClientManager:    mockEVMClientManager,
+			database:            database,
+			evmTxExecutor:       mockEVMTxExecutor,
+		}
+		return ctx, r, database, mockSkipGo, mockEVMClient, mockEVMTxExecutor
+	}
+	// expectSwap expects a swap route whose first tx is submitted with hash,
+	// followed by laterTxs which are left to the route executor
+	expectSwap := func(mockSkipGo *mock_skipgo.MockSkipGoClient, mockEVMClient *mock_evmrpc.MockEVMChainRPC, mockEVMTxExecutor *mock_evm.MockEVMTxExecutor, hash string, laterTxs ...skipgo.Tx) {
+		mockEVMClient.EXPECT().GetUSDCBalance(mockContext, arbitrumUSDCDenom, arbitrumAddress).Return(big.NewInt(1000), nil).Once()
+		route := &skipgo.RouteResponse{
+			AmountOut:              "5000000000000",
+			Operations:             []any{"swap"},
+			RequiredChainAddresses: []string{arbitrumChainID},
+		}
+		mockSkipGo.EXPECT().Route(mockContext, arbitrumUSDCDenom, arbitrumChainID, gasTokenDenom, arbitrumChainID, big.NewInt(100)).
+			Return(route, nil).Once()
+		mockSkipGo.EXPECT().Msgs(mockContext, arbitrumUSDCDenom, arbitrumChainID, arbitrumAddress, gasTokenDenom, arbitrumChainID, arbitrumAddress, big.NewInt(100), big.NewInt(5000000000000), []string{arbitrumAddress}, route.Operations).
+			Return(append([]skipgo.Tx{{EVMTx: &skipgo.EVMTx{ChainID: arbitrumChainID, To: "0x5be0", Value: "0", SignerAddress: arbitrumAddress}}}, laterTxs...), nil).Once()
+		mockEVMClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(100), nil).Once()
+		mockEVMTxExecutor.On("ExecuteTx", mockContext, arbitrumChainID, arbitrumAddress, []byte{}, "0", "0x5be0", mock.Anything).
+			Return(hash, "", nil).Once()
+	}
+
+	t.Run("chains without a top up configured are not topped up", func(t *testing.T) {
+		ctx, r, database, _, _, _ := newRebalancer(t, nil)
+
+		hash, err := r.TopUpGas(ctx, arbitrumChainID)
+		require.NoError(t, err)
+		assert.Empty(t, hash)
+		assert.Empty(t, database.GetDBContents())
+	})
+
+	t.Run("usdc is swapped into the gas token and recorded as a gas top up", func(t *testing.T) {
+		ctx, r, database, mockSkipGo, mockEVMClient, mockEVMTxExecutor := newRebalancer(t, &config.GasTopUpConfig{
+			AmountUUSDC:   "100",
+			GasTokenDenom: gasTokenDenom,
+		})
+		expectSwap(mockSkipGo, mockEVMClient, mockEVMTxExecutor, "top up hash")
+
+		hash, err := r.TopUpGas(ctx, arbitrumChainID)
+		require.NoError(t, err)
+		assert.Equal(t, skipgo.TxHash("top up hash"), hash)
+
+		transfers := database.GetDBContents()
+		require.Len(t, transfers, 1)
+		assert.Equal(t, dbtypes.RebalanceTransferKindGasTopUp, transfers[0].Kind)
+		assert.Equal(t, "100", transfers[0].Amount)
+		assert.Equal(t, arbitrumChainID, transfers[0].DestinationChainID)
+
+		// gas top ups are not pending inbound usdc
+		pending, err := database.GetPendingRebalanceTransfersToChain(ctx, arbitrumChainID)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		// nothing is topped up while the previous top up is pending
+		_, err = r.TopUpGas(ctx, arbitrumChainID)
+		assert.True(t, errors.Is(err, ErrRebalanceLimitReached))
+	})
+
+	t.Run("multi tx top up is not recorded when inserting its steps fails", func(t *testing.T) {
+		ctx, r, database, mockSkipGo, mockEVMClient, mockEVMTxExecutor := newRebalancer(t, &config.GasTopUpConfig{
+			AmountUUSDC:   "100",
+			GasTokenDenom: gasTokenDenom,
+		})
+		expectSwap(mockSkipGo, mockEVMClient, mockEVMTxExecutor, "top up hash", skipgo.Tx{EVMTx: &skipgo.EVMTx{ChainID: arbitrumChainID, To: "0x5be1", Value: "0", SignerAddress: arbitrumAddress}})
+		database.FailStepInsert(1, errors.New("db error"))
+
+		_, err := r.TopUpGas(ctx, arbitrumChainID)
+		require.Error(t, err)
+		assert.Empty(t, database.GetDBContents())
+		assert.Empty(t, database.GetStepContents())
+	})
+
+	t.Run("top ups are subject to the cooldown and daily cap", func(t *testing.T) {
+		ctx, r, database, mockSkipGo, mockEVMClient, mockEVMTxExecutor := newRebalancer(t, &config.GasTopUpConfig{
+			AmountUUSDC:   "100",
+			GasTokenDenom: gasTokenDenom,
+			MaxDailyUUSDC: "250",
+			Cooldown:      time.Hour,
+		})
+		addTopUp := func(createdAt time.Time) {
+			id, err := database.InsertGasTopUpTransfer(ctx, db.InsertGasTopUpTransferParams{
+				TxHash:             "hash",
+				SourceChainID:      arbitrumChainID,
+				DestinationChainID: arbitrumChainID,
+				Amount:             "100",
+			})
+			require.NoError(t, err)
+			require.NoError(t, database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{ID: id, Status: dbtypes.RebalanceTransferStatusSuccess}))
+			require.NoError(t, database.UpdateTransferCreatedAt(ctx, id, createdAt))
+		}
+
+		addTopUp(time.Now().Add(-30 * time.Minute))
+		_, err := r.TopUpGas(ctx, arbitrumChainID)
+		assert.True(t, errors.Is(err, ErrRebalanceLimitReached))
+
+		// rebalances do not count towards gas top up limits
+		_, err = database.InsertRebalanceTransfer(ctx, db.InsertRebalanceTransferParams{
+			TxHash:             "rebalance hash",
+			SourceChainID:      ethChainID,
+			DestinationChainID: arbitrumChainID,
+			Amount:             "1000",
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, database.UpdateTransferCreatedAt(ctx, 0, time.Now().Add(-2*time.Hour)))
+		expectSwap(mockSkipGo, mockEVMClient, mockEVMTxExecutor, "top up hash")
+		_, err = r.TopUpGas(ctx, arbitrumChainID)
+		require.NoError(t, err)
+
+		// a third top up within the limit window would exceed the daily cap
+		transfers := database.GetDBContents()
+		require.NoError(t, database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{ID: transfers[2].ID, Status: dbtypes.RebalanceTransferStatusSuccess}))
+		require.NoError(t, database.UpdateTransferCreatedAt(ctx, transfers[2].ID, time.Now().Add(-2*time.Hour)))
+		_, err = r.TopUpGas(ctx, arbitrumChainID)
+		assert.True(t, errors.Is(err, ErrRebalanceLimitReached))
+	})
+}
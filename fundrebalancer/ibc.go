@@ -0,0 +1,513 @@
+package fundrebalancer
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/metrics"
+	"github.com/skip-mev/go-fast-solver/shared/tmrpc"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	ibcTrackerLoopDelay = 10 * time.Second
+	// ibcTransferDuration is roughly how long it takes relayers to deliver a
+	// packet between two cosmos chains
+	ibcTransferDuration = time.Minute
+	// ibcRefundTimeout is how long after a packet times out its timeout can
+	// go without being relayed back to the source chain before the transfer
+	// stops being tracked. The timeout then has to be relayed manually for
+	// the usdc to be refunded.
+	ibcRefundTimeout = time.Hour
+)
+
+// ibcBridge moves usdc between cosmos chains with plain ics-20 transfers
+// over the channels configured on the source chain, without depending on the
+// Skip Go api
+type ibcBridge struct {
+	r *FundRebalancer
+	// channels maps source chain ids to the destination chain ids that usdc
+	// can be sent to from them, and the transfer channel leading there
+	channels         map[string]map[string]string
+	rpcClientManager tmrpc.TendermintRPCClientManager
+}
+
+func newIBCBridge(
+	r *FundRebalancer,
+	chains map[string]config.ChainConfig,
+	rpcClientManager tmrpc.TendermintRPCClientManager,
+) *ibcBridge {
+	cosmosChains := make(map[string]bool)
+	for _, chain := range chains {
+		if chain.Type == config.ChainType_COSMOS {
+			cosmosChains[chain.ChainID] = true
+		}
+	}
+
+	channels := make(map[string]map[string]string)
+	for _, chain := range chains {
+		if chain.Type != config.ChainType_COSMOS || chain.IBC == nil {
+			continue
+		}
+		for destinationChainID, channel := range chain.IBC.Channels {
+			if !cosmosChains[destinationChainID] {
+				continue
+			}
+			if channels[chain.ChainID] == nil {
+				channels[chain.ChainID] = make(map[string]string)
+			}
+			channels[chain.ChainID][destinationChainID] = channel
+		}
+	}
+	return &ibcBridge{r: r, channels: channels, rpcClientManager: rpcClientManager}
+}
+
+func (b *ibcBridge) Name() string {
+	return "ibc"
+}
+
+// Supports returns true if the source chain has a transfer channel to the
+// destination chain configured
+func (b *ibcBridge) Supports(ctx context.Context, sourceChainID, destinationChainID string) bool {
+	_, ok := b.channels[sourceChainID][destinationChainID]
+	return ok
+}
+
+// Quote quotes sending quote.amount usdc over ibc. Transfers do not charge a
+// fee, so the only cost of moving funds is the gas cost of the transfer tx.
+func (b *ibcBridge) Quote(ctx context.Context, destinationChainID string, quote *rebalanceQuote) error {
+	transferTx, _, err := b.transferTx(ctx, quote.sourceChainID, destinationChainID, quote.amount)
+	if err != nil {
+		return fmt.Errorf("building ibc transfer tx from chain %s to chain %s: %w", quote.sourceChainID, destinationChainID, err)
+	}
+
+	txnWithMetadata, err := b.r.TxnWithMetadata(ctx, quote.sourceChainID, destinationChainID, quote.amount, transferTx)
+	if err != nil {
+		return fmt.Errorf("getting transaction metadata to transfer funds from chain %s: %w", quote.sourceChainID, err)
+	}
+	gasCostUUSDC, err := b.r.gasCostUUSDC(ctx, txnWithMetadata, quote.sourceChainID)
+	if err != nil {
+		return fmt.Errorf("calculating ibc transfer gas cost in UUSDC: %w", err)
+	}
+
+	quote.txns = []skipgo.Tx{transferTx}
+	quote.txnWithMetadata = txnWithMetadata
+	quote.routeFeeUUSDC = big.NewInt(0)
+	quote.bridgeFeeUUSDC = big.NewInt(0)
+	quote.gasCostUUSDC = gasCostUUSDC
+	quote.estimatedDuration = ibcTransferDuration
+	return nil
+}
+
+// Execute sends the allocated amount of usdc to the destination chain and
+// records the transfer so that its packet is tracked until it is
+// acknowledged or times out
+func (b *ibcBridge) Execute(ctx context.Context, destinationChainID string, allocation rebalanceAllocation) (skipgo.TxHash, error) {
+	sourceChainID := allocation.quote.sourceChainID
+	ctx = lmt.With(
+		ctx,
+		zap.String("destinationChainID", destinationChainID),
+		zap.String("sourceChainID", sourceChainID),
+		zap.String("rebalanceAmountUUSDC", allocation.amount.String()),
+	)
+
+	transferTx, msg, err := b.transferTx(ctx, sourceChainID, destinationChainID, allocation.amount)
+	if err != nil {
+		return "", fmt.Errorf("building ibc transfer tx from chain %s to chain %s: %w", sourceChainID, destinationChainID, err)
+	}
+
+	txnWithMetadata := SkipGoTxnWithMetadata{
+		tx:                 transferTx,
+		sourceChainID:      sourceChainID,
+		destinationChainID: destinationChainID,
+		amount:             allocation.amount,
+	}
+	txHash, rawTx, err := b.r.SignAndSubmitTxn(ctx, txnWithMetadata)
+	if err != nil {
+		return "", fmt.Errorf("signing and submitting ibc transfer transaction: %w", err)
+	}
+
+	if err := b.r.recordBridgeTransfer(ctx, txnWithMetadata, txHash, rawTx, allocation.quote.costUUSDC(allocation.amount), func(ctx context.Context, q db.Querier, rebalanceID int64) error {
+		if _, err := q.InsertIBCTransfer(ctx, db.InsertIBCTransferParams{
+			RebalanceTransferID: rebalanceID,
+			SourceChainID:       sourceChainID,
+			DestinationChainID:  destinationChainID,
+			SourceChannel:       msg.SourceChannel,
+			TxHash:              string(txHash),
+			TimeoutTimestamp:    int64(msg.TimeoutTimestamp),
+		}); err != nil {
+			return fmt.Errorf("inserting ibc transfer with hash %s into db: %w", txHash, err)
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	lmt.Logger(ctx).Info("submitted ibc transfer to rebalance funds", zap.String("txHash", string(txHash)))
+	return txHash, nil
+}
+
+// transferTx builds the tx that sends amount usdc from the solver on
+// sourceChainID to the solver on destinationChainID, along with the transfer
+// msg in it
+func (b *ibcBridge) transferTx(ctx context.Context, sourceChainID, destinationChainID string, amount *big.Int) (skipgo.Tx, *ibctransfertypes.MsgTransfer, error) {
+	channel, ok := b.channels[sourceChainID][destinationChainID]
+	if !ok {
+		return skipgo.Tx{}, nil, fmt.Errorf("no ibc channel from chain %s to chain %s is configured", sourceChainID, destinationChainID)
+	}
+	sourceChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(sourceChainID)
+	if err != nil {
+		return skipgo.Tx{}, nil, fmt.Errorf("getting config for chain %s: %w", sourceChainID, err)
+	}
+	destinationChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(destinationChainID)
+	if err != nil {
+		return skipgo.Tx{}, nil, fmt.Errorf("getting config for chain %s: %w", destinationChainID, err)
+	}
+
+	timeout := config.DefaultIBCTransferTimeout
+	if sourceChainConfig.IBC != nil && sourceChainConfig.IBC.TransferTimeout > 0 {
+		timeout = sourceChainConfig.IBC.TransferTimeout
+	}
+	msg := &ibctransfertypes.MsgTransfer{
+		SourcePort:       ibctransfertypes.PortID,
+		SourceChannel:    channel,
+		Token:            sdk.NewCoin(sourceChainConfig.USDCDenom, sdkmath.NewIntFromBigInt(amount)),
+		Sender:           sourceChainConfig.SolverAddress,
+		Receiver:         destinationChainConfig.SolverAddress,
+		TimeoutTimestamp: uint64(time.Now().Add(timeout).UnixNano()),
+	}
+	tx, err := b.r.cosmosTx(sourceChainConfig, msg)
+	if err != nil {
+		return skipgo.Tx{}, nil, err
+	}
+	return tx, msg, nil
+}
+
+// TrackTransfers advances pending ibc transfers until their packets are
+// acknowledged or refunded
+func (b *ibcBridge) TrackTransfers(ctx context.Context) {
+	ticker := time.NewTicker(ibcTrackerLoopDelay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.TrackPendingTransfers(ctx); err != nil {
+				lmt.Logger(ctx).Error("error tracking pending ibc transfers", zap.Error(err))
+			}
+		}
+	}
+}
+
+// TrackPendingTransfers makes a single pass over all pending ibc transfers,
+// advancing each as far as it can
+func (b *ibcBridge) TrackPendingTransfers(ctx context.Context) error {
+	transfers, err := b.r.database.GetPendingIBCTransfers(ctx)
+	if err != nil {
+		return fmt.Errorf("getting pending ibc transfers: %w", err)
+	}
+
+	for _, transfer := range transfers {
+		if err := b.trackTransfer(ctx, transfer); err != nil {
+			lmt.Logger(ctx).Error(
+				"error tracking ibc transfer",
+				zap.Error(err),
+				zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+				zap.String("txHash", transfer.TxHash),
+				zap.String("sourceChainID", transfer.SourceChainID),
+				zap.String("destinationChainID", transfer.DestinationChainID),
+			)
+		}
+	}
+	return nil
+}
+
+// trackTransfer moves an ibc transfer through its states. The packet sent by
+// the transfer tx is read once the tx is included on chain, and the packet is
+// then watched on the destination chain until it is acknowledged or times
+// out. Timed out packets are watched on the source chain until the usdc is
+// refunded, or until ibcRefundTimeout has passed without a refund.
+func (b *ibcBridge) trackTransfer(ctx context.Context, transfer db.IbcTransfer) error {
+	var err error
+	if transfer.Status == dbtypes.IBCTransferStatusSubmitted {
+		transfer, err = b.trackTransferTx(ctx, transfer)
+		if err != nil {
+			return fmt.Errorf("getting packet sent by transfer tx: %w", err)
+		}
+	}
+
+	if transfer.Status == dbtypes.IBCTransferStatusPacketSent {
+		if err := b.trackPacket(ctx, transfer); err != nil {
+			return fmt.Errorf("getting packet acknowledgement: %w", err)
+		}
+		return nil
+	}
+
+	if transfer.Status == dbtypes.IBCTransferStatusTimedOut {
+		if err := b.trackRefund(ctx, transfer); err != nil {
+			return fmt.Errorf("getting packet timeout: %w", err)
+		}
+	}
+	return nil
+}
+
+// trackTransferTx records the packet sent by a transfers tx once the tx is
+// included on the source chain
+func (b *ibcBridge) trackTransferTx(ctx context.Context, transfer db.IbcTransfer) (db.IbcTransfer, error) {
+	client, err := b.rpcClientManager.GetClient(ctx, transfer.SourceChainID)
+	if err != nil {
+		return transfer, fmt.Errorf("getting tendermint rpc client for chain %s: %w", transfer.SourceChainID, err)
+	}
+	txHashBytes, err := hex.DecodeString(transfer.TxHash)
+	if err != nil {
+		return transfer, fmt.Errorf("decoding tx hash %s: %w", transfer.TxHash, err)
+	}
+	result, err := client.Tx(ctx, txHashBytes, false)
+	if err != nil {
+		if !strings.HasSuffix(err.Error(), "not found") {
+			return transfer, fmt.Errorf("fetching tx result for hash %s: %w", transfer.TxHash, err)
+		}
+		// a packet sent after its timeout can only ever be timed out, so
+		// the transfer will never arrive even if the tx is included later
+		if time.Now().UnixNano() < transfer.TimeoutTimestamp {
+			return transfer, nil
+		}
+		return transfer, b.failTransfer(ctx, transfer, dbtypes.IBCTransferStatusFailed, "transfer tx was not included before the transfer timed out")
+	}
+	if result.TxResult.Code != 0 {
+		return transfer, b.failTransfer(ctx, transfer, dbtypes.IBCTransferStatusFailed, fmt.Sprintf("transfer tx failed with code %d: %s", result.TxResult.Code, result.TxResult.Log))
+	}
+
+	sendPacket, ok := findEvent(result.TxResult.Events, "send_packet", map[string]string{"packet_src_channel": transfer.SourceChannel})
+	if !ok {
+		return transfer, fmt.Errorf("no send_packet event on channel %s found in transfer tx %s", transfer.SourceChannel, transfer.TxHash)
+	}
+	sequence, err := strconv.ParseInt(sendPacket["packet_sequence"], 10, 64)
+	if err != nil {
+		return transfer, fmt.Errorf("parsing packet sequence %s: %w", sendPacket["packet_sequence"], err)
+	}
+	transfer, err = b.r.database.SetIBCTransferPacketSent(ctx, db.SetIBCTransferPacketSentParams{
+		PacketSequence:     sql.NullInt64{Int64: sequence, Valid: true},
+		DestinationChannel: sql.NullString{String: sendPacket["packet_dst_channel"], Valid: true},
+		ID:                 transfer.ID,
+	})
+	if err != nil {
+		return transfer, fmt.Errorf("setting ibc transfer packet: %w", err)
+	}
+	return transfer, nil
+}
+
+// trackPacket completes a transfer once its packet is acknowledged on the
+// destination chain, or marks it as timed out once the destination chain is
+// past the packets timeout without having received it
+func (b *ibcBridge) trackPacket(ctx context.Context, transfer db.IbcTransfer) error {
+	client, err := b.rpcClientManager.GetClient(ctx, transfer.DestinationChainID)
+	if err != nil {
+		return fmt.Errorf("getting tendermint rpc client for chain %s: %w", transfer.DestinationChainID, err)
+	}
+	// the destination chains time is checked before searching for the
+	// acknowledgement, so that a packet received just before its timeout
+	// is not mistaken for a timed out packet
+	status, err := client.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("getting status of chain %s: %w", transfer.DestinationChainID, err)
+	}
+
+	query := fmt.Sprintf(
+		"write_acknowledgement.packet_dst_channel='%s' AND write_acknowledgement.packet_sequence='%d'",
+		transfer.DestinationChannel.String,
+		transfer.PacketSequence.Int64,
+	)
+	results, err := client.TxSearch(ctx, query, false, nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("searching for acknowledgement of packet %d on chain %s: %w", transfer.PacketSequence.Int64, transfer.DestinationChainID, err)
+	}
+	for _, result := range results.Txs {
+		writeAcknowledgement, ok := findEvent(result.TxResult.Events, "write_acknowledgement", map[string]string{
+			"packet_dst_channel": transfer.DestinationChannel.String,
+			"packet_sequence":    strconv.FormatInt(transfer.PacketSequence.Int64, 10),
+		})
+		if !ok {
+			continue
+		}
+		var ack struct {
+			Result []byte `json:"result"`
+			Error  string `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(writeAcknowledgement["packet_ack"]), &ack); err != nil {
+			return fmt.Errorf("decoding acknowledgement %s: %w", writeAcknowledgement["packet_ack"], err)
+		}
+		if ack.Error != "" || ack.Result == nil {
+			// the usdc is refunded on the source chain once the error
+			// acknowledgement is relayed back to it
+			return b.failTransfer(ctx, transfer, dbtypes.IBCTransferStatusFailed, fmt.Sprintf("packet acknowledged with error: %s", ack.Error))
+		}
+		return b.completeTransfer(ctx, transfer)
+	}
+
+	if status.SyncInfo.LatestBlockTime.UnixNano() < transfer.TimeoutTimestamp {
+		return nil
+	}
+	return b.failTransfer(ctx, transfer, dbtypes.IBCTransferStatusTimedOut, "packet timed out before it was received")
+}
+
+// trackRefund marks a timed out transfer as refunded once the packet timeout
+// has been relayed back to the source chain, or gives up on the refund if no
+// relayer has relayed the timeout within ibcRefundTimeout
+func (b *ibcBridge) trackRefund(ctx context.Context, transfer db.IbcTransfer) error {
+	client, err := b.rpcClientManager.GetClient(ctx, transfer.SourceChainID)
+	if err != nil {
+		return fmt.Errorf("getting tendermint rpc client for chain %s: %w", transfer.SourceChainID, err)
+	}
+	query := fmt.Sprintf(
+		"timeout_packet.packet_src_channel='%s' AND timeout_packet.packet_sequence='%d'",
+		transfer.SourceChannel,
+		transfer.PacketSequence.Int64,
+	)
+	results, err := client.TxSearch(ctx, query, false, nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("searching for timeout of packet %d on chain %s: %w", transfer.PacketSequence.Int64, transfer.SourceChainID, err)
+	}
+	if len(results.Txs) == 0 {
+		if time.Since(time.Unix(0, transfer.TimeoutTimestamp)) <= ibcRefundTimeout {
+			return nil
+		}
+		return b.abandonRefund(ctx, transfer)
+	}
+
+	if _, err := b.r.database.SetIBCTransferStatus(ctx, db.SetIBCTransferStatusParams{
+		Status:        dbtypes.IBCTransferStatusRefunded,
+		StatusMessage: transfer.StatusMessage,
+		ID:            transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("setting ibc transfer status to %s: %w", dbtypes.IBCTransferStatusRefunded, err)
+	}
+	lmt.Logger(ctx).Info(
+		"timed out ibc rebalance transfer refunded",
+		zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+		zap.String("sourceChainID", transfer.SourceChainID),
+		zap.String("destinationChainID", transfer.DestinationChainID),
+	)
+	return nil
+}
+
+// abandonRefund stops tracking a timed out transfer whose timeout was never
+// relayed back to the source chain. Its rebalance transfer already failed
+// when the packet timed out.
+func (b *ibcBridge) abandonRefund(ctx context.Context, transfer db.IbcTransfer) error {
+	reason := fmt.Sprintf("packet timeout was not relayed back to chain %s within %s", transfer.SourceChainID, ibcRefundTimeout)
+	if _, err := b.r.database.SetIBCTransferStatus(ctx, db.SetIBCTransferStatusParams{
+		Status:        dbtypes.IBCTransferStatusFailed,
+		StatusMessage: sql.NullString{String: reason, Valid: true},
+		ID:            transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("setting ibc transfer status to %s: %w", dbtypes.IBCTransferStatusFailed, err)
+	}
+	lmt.Logger(ctx).Error(
+		"timed out ibc rebalance transfer was not refunded, the packet timeout must be relayed manually",
+		zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+		zap.String("sourceChainID", transfer.SourceChainID),
+		zap.String("sourceChannel", transfer.SourceChannel),
+		zap.Int64("packetSequence", transfer.PacketSequence.Int64),
+		zap.String("reason", reason),
+	)
+	return nil
+}
+
+func (b *ibcBridge) completeTransfer(ctx context.Context, transfer db.IbcTransfer) error {
+	if _, err := b.r.database.SetIBCTransferStatus(ctx, db.SetIBCTransferStatusParams{
+		Status: dbtypes.IBCTransferStatusAcknowledged,
+		ID:     transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("setting ibc transfer status to %s: %w", dbtypes.IBCTransferStatusAcknowledged, err)
+	}
+	if err := b.r.database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{
+		Status: dbtypes.RebalanceTransferStatusSuccess,
+		ID:     transfer.RebalanceTransferID,
+	}); err != nil {
+		return fmt.Errorf("updating rebalance transfer status to %s: %w", dbtypes.RebalanceTransferStatusSuccess, err)
+	}
+	metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(transfer.SourceChainID, transfer.DestinationChainID, dbtypes.RebalanceTransferStatusSuccess)
+
+	lmt.Logger(ctx).Info(
+		"ibc rebalance transfer acknowledged",
+		zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+		zap.String("sourceChainID", transfer.SourceChainID),
+		zap.String("destinationChainID", transfer.DestinationChainID),
+		zap.String("txHash", transfer.TxHash),
+	)
+	return nil
+}
+
+// failTransfer fails the rebalance transfer of an ibc transfer and moves the
+// transfer to status, which is either failed or timed out. Timed out
+// transfers stay pending until their refund is seen on the source chain.
+func (b *ibcBridge) failTransfer(ctx context.Context, transfer db.IbcTransfer, status string, reason string) error {
+	if _, err := b.r.database.SetIBCTransferStatus(ctx, db.SetIBCTransferStatusParams{
+		Status:        status,
+		StatusMessage: sql.NullString{String: reason, Valid: true},
+		ID:            transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("setting ibc transfer status to %s: %w", status, err)
+	}
+	if err := b.r.database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{
+		Status: dbtypes.RebalanceTransferStatusFailed,
+		ID:     transfer.RebalanceTransferID,
+	}); err != nil {
+		return fmt.Errorf("updating rebalance transfer status to %s: %w", dbtypes.RebalanceTransferStatusFailed, err)
+	}
+	metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(transfer.SourceChainID, transfer.DestinationChainID, dbtypes.RebalanceTransferStatusFailed)
+
+	lmt.Logger(ctx).Error(
+		"ibc rebalance transfer failed",
+		zap.Int64("rebalanceTransferID", transfer.RebalanceTransferID),
+		zap.String("sourceChainID", transfer.SourceChainID),
+		zap.String("destinationChainID", transfer.DestinationChainID),
+		zap.String("status", status),
+		zap.String("reason", reason),
+	)
+	return nil
+}
+
+// findEvent gets the attributes of the first event of type eventType whose
+// attributes include all of the given attributes
+func findEvent(events []abcitypes.Event, eventType string, attributes map[string]string) (map[string]string, bool) {
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		eventAttributes := make(map[string]string, len(event.Attributes))
+		for _, attribute := range event.Attributes {
+			eventAttributes[attribute.Key] = attribute.Value
+		}
+		matches := true
+		for key, value := range attributes {
+			if eventAttributes[key] != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return eventAttributes, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,240 @@
+package fundrebalancer
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	mock_database "github.com/skip-mev/go-fast-solver/mocks/fundrebalancer"
+	mock_cometclient "github.com/skip-mev/go-fast-solver/mocks/github.com/cometbft/cometbft/rpc/client"
+	mock_skipgo "github.com/skip-mev/go-fast-solver/mocks/shared/clients/skipgo"
+	mock_config "github.com/skip-mev/go-fast-solver/mocks/shared/config"
+	mock_evmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/evmrpc"
+	mock_oracle "github.com/skip-mev/go-fast-solver/mocks/shared/oracle"
+	mock_tmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/tmrpc"
+	mock_cosmos "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/cosmos"
+	evm2 "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/evm"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	osmosisToNobleChannel = "channel-750"
+	nobleToOsmosisChannel = "channel-1"
+)
+
+func TestIBCBridge_TrackPendingTransfers(t *testing.T) {
+	txHash := "ABCD"
+	txHashBytes, err := hex.DecodeString(txHash)
+	require.NoError(t, err)
+	timeout := time.Now().Add(10 * time.Minute)
+
+	setup := func(t *testing.T, timeout time.Time) (context.Context, *FundRebalancer, *mock_database.FakeDatabase, *mock_cometclient.MockClient, *mock_cometclient.MockClient) {
+		ctx := context.Background()
+		osmosisConfig := config.ChainConfig{
+			ChainID:       osmosisChainID,
+			Type:          config.ChainType_COSMOS,
+			USDCDenom:     osmosisUSDCDenom,
+			SolverAddress: osmosisAddress,
+			IBC: &config.IBCConfig{
+				Channels: map[string]string{nobleChainID: osmosisToNobleChannel},
+			},
+		}
+		nobleConfig := config.ChainConfig{
+			ChainID:       nobleChainID,
+			Type:          config.ChainType_COSMOS,
+			USDCDenom:     "uusdc",
+			SolverAddress: nobleAddress,
+			IBC: &config.IBCConfig{
+				Channels:        map[string]string{osmosisChainID: nobleToOsmosisChannel},
+				TransferTimeout: time.Hour,
+			},
+		}
+		mockConfigReader := mock_config.NewMockConfigReader(t)
+		mockConfigReader.On("Config").Return(config.Config{
+			Chains: map[string]config.ChainConfig{"osmosis": osmosisConfig, "noble": nobleConfig},
+		})
+		mockConfigReader.On("GetChainConfig", osmosisChainID).Return(osmosisConfig, nil).Maybe()
+		mockConfigReader.On("GetChainConfig", nobleChainID).Return(nobleConfig, nil).Maybe()
+		ctx = config.ConfigReaderContext(ctx, mockConfigReader)
+
+		f, err := loadKeysFile(defaultKeys)
+		require.NoError(t, err)
+		keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
+		require.NoError(t, err)
+		database := mock_database.NewFakeDatabase()
+
+		rebalancer, err := NewFundRebalancer(
+			ctx,
+			keystore,
+			mock_skipgo.NewMockSkipGoClient(t),
+			mock_evmrpc.NewMockEVMRPCClientManager(t),
+			database,
+			mock_oracle.NewMockTxPriceOracle(t),
+			evm2.NewMockEVMTxExecutor(t),
+			mock_cosmos.NewMockCosmosTxExecutor(t),
+		)
+		require.NoError(t, err)
+		assert.True(t, rebalancer.ibcBridge.Supports(ctx, osmosisChainID, nobleChainID))
+		assert.True(t, rebalancer.ibcBridge.Supports(ctx, nobleChainID, osmosisChainID))
+		assert.False(t, rebalancer.ibcBridge.Supports(ctx, osmosisChainID, arbitrumChainID))
+
+		mockRPCClientManager := mock_tmrpc.NewMockTendermintRPCClientManager(t)
+		mockOsmosisClient := mock_cometclient.NewMockClient(t)
+		mockNobleClient := mock_cometclient.NewMockClient(t)
+		mockRPCClientManager.EXPECT().GetClient(mockContext, osmosisChainID).Return(mockOsmosisClient, nil).Maybe()
+		mockRPCClientManager.EXPECT().GetClient(mockContext, nobleChainID).Return(mockNobleClient, nil).Maybe()
+		rebalancer.ibcBridge.rpcClientManager = mockRPCClientManager
+
+		rebalanceID, err := database.InsertRebalanceTransfer(ctx, db.InsertRebalanceTransferParams{
+			TxHash:             txHash,
+			SourceChainID:      osmosisChainID,
+			DestinationChainID: nobleChainID,
+			Amount:             "100",
+		})
+		require.NoError(t, err)
+		_, err = database.InsertIBCTransfer(ctx, db.InsertIBCTransferParams{
+			RebalanceTransferID: rebalanceID,
+			SourceChainID:       osmosisChainID,
+			DestinationChainID:  nobleChainID,
+			SourceChannel:       osmosisToNobleChannel,
+			TxHash:              txHash,
+			TimeoutTimestamp:    timeout.UnixNano(),
+		})
+		require.NoError(t, err)
+
+		return ctx, rebalancer, database, mockOsmosisClient, mockNobleClient
+	}
+
+	packetEvent := func(eventType string) abcitypes.Event {
+		return abcitypes.Event{Type: eventType, Attributes: []abcitypes.EventAttribute{
+			{Key: "packet_sequence", Value: "7"},
+			{Key: "packet_src_channel", Value: osmosisToNobleChannel},
+			{Key: "packet_dst_channel", Value: nobleToOsmosisChannel},
+		}}
+	}
+	transferTxResult := &coretypes.ResultTx{TxResult: abcitypes.ExecTxResult{
+		Events: []abcitypes.Event{packetEvent("send_packet")},
+	}}
+	ackQuery := fmt.Sprintf("write_acknowledgement.packet_dst_channel='%s' AND write_acknowledgement.packet_sequence='7'", nobleToOsmosisChannel)
+	ackResult := func(ack string) *coretypes.ResultTxSearch {
+		event := packetEvent("write_acknowledgement")
+		event.Attributes = append(event.Attributes, abcitypes.EventAttribute{Key: "packet_ack", Value: ack})
+		return &coretypes.ResultTxSearch{Txs: []*coretypes.ResultTx{{TxResult: abcitypes.ExecTxResult{Events: []abcitypes.Event{event}}}}}
+	}
+	blockTime := func(blockTime time.Time) *coretypes.ResultStatus {
+		return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockTime: blockTime}}
+	}
+
+	t.Run("transfer succeeds once its packet is acknowledged", func(t *testing.T) {
+		ctx, rebalancer, database, mockOsmosisClient, mockNobleClient := setup(t, timeout)
+
+		// transfer tx not yet included
+		mockOsmosisClient.EXPECT().Tx(mockContext, txHashBytes, false).Return(nil, errors.New("tx not found")).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.IBCTransferStatusSubmitted, database.GetIBCContents()[0].Status)
+
+		// transfer tx included, packet not yet received
+		mockOsmosisClient.EXPECT().Tx(mockContext, txHashBytes, false).Return(transferTxResult, nil).Once()
+		mockNobleClient.EXPECT().Status(mockContext).Return(blockTime(time.Now()), nil).Once()
+		mockNobleClient.EXPECT().TxSearch(mockContext, ackQuery, false, mockContext, mockContext, "").Return(&coretypes.ResultTxSearch{}, nil).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+		transfer := database.GetIBCContents()[0]
+		assert.Equal(t, dbtypes.IBCTransferStatusPacketSent, transfer.Status)
+		assert.Equal(t, int64(7), transfer.PacketSequence.Int64)
+		assert.Equal(t, nobleToOsmosisChannel, transfer.DestinationChannel.String)
+
+		// packet acknowledged
+		mockNobleClient.EXPECT().Status(mockContext).Return(blockTime(time.Now()), nil).Once()
+		mockNobleClient.EXPECT().TxSearch(mockContext, ackQuery, false, mockContext, mockContext, "").Return(ackResult(`{"result":"AQ=="}`), nil).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.IBCTransferStatusAcknowledged, database.GetIBCContents()[0].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusSuccess, database.GetDBContents()[0].Status)
+	})
+
+	t.Run("transfer fails if its packet is acknowledged with an error", func(t *testing.T) {
+		ctx, rebalancer, database, mockOsmosisClient, mockNobleClient := setup(t, timeout)
+
+		mockOsmosisClient.EXPECT().Tx(mockContext, txHashBytes, false).Return(transferTxResult, nil).Once()
+		mockNobleClient.EXPECT().Status(mockContext).Return(blockTime(time.Now()), nil).Once()
+		mockNobleClient.EXPECT().TxSearch(mockContext, ackQuery, false, mockContext, mockContext, "").Return(ackResult(`{"error":"ABCI code: 1: error handling packet"}`), nil).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+
+		transfer := database.GetIBCContents()[0]
+		assert.Equal(t, dbtypes.IBCTransferStatusFailed, transfer.Status)
+		assert.Contains(t, transfer.StatusMessage.String, "error handling packet")
+		assert.Equal(t, dbtypes.RebalanceTransferStatusFailed, database.GetDBContents()[0].Status)
+	})
+
+	t.Run("timed out transfer fails and is tracked until refunded", func(t *testing.T) {
+		ctx, rebalancer, database, mockOsmosisClient, mockNobleClient := setup(t, timeout)
+		timeoutQuery := fmt.Sprintf("timeout_packet.packet_src_channel='%s' AND timeout_packet.packet_sequence='7'", osmosisToNobleChannel)
+
+		// destination chain is past the packets timeout without receiving it
+		mockOsmosisClient.EXPECT().Tx(mockContext, txHashBytes, false).Return(transferTxResult, nil).Once()
+		mockNobleClient.EXPECT().Status(mockContext).Return(blockTime(timeout), nil).Once()
+		mockNobleClient.EXPECT().TxSearch(mockContext, ackQuery, false, mockContext, mockContext, "").Return(&coretypes.ResultTxSearch{}, nil).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.IBCTransferStatusTimedOut, database.GetIBCContents()[0].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusFailed, database.GetDBContents()[0].Status)
+
+		// timeout not yet relayed
+		mockOsmosisClient.EXPECT().TxSearch(mockContext, timeoutQuery, false, mockContext, mockContext, "").Return(&coretypes.ResultTxSearch{}, nil).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.IBCTransferStatusTimedOut, database.GetIBCContents()[0].Status)
+
+		// timeout relayed and usdc refunded
+		mockOsmosisClient.EXPECT().TxSearch(mockContext, timeoutQuery, false, mockContext, mockContext, "").Return(&coretypes.ResultTxSearch{
+			Txs: []*coretypes.ResultTx{{TxResult: abcitypes.ExecTxResult{Events: []abcitypes.Event{packetEvent("timeout_packet")}}}},
+		}, nil).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.IBCTransferStatusRefunded, database.GetIBCContents()[0].Status)
+		assert.Equal(t, "packet timed out before it was received", database.GetIBCContents()[0].StatusMessage.String)
+	})
+
+	t.Run("timed out transfer stops being tracked if its timeout is never relayed", func(t *testing.T) {
+		timeout := time.Now().Add(-ibcRefundTimeout - time.Minute)
+		ctx, rebalancer, database, mockOsmosisClient, mockNobleClient := setup(t, timeout)
+		timeoutQuery := fmt.Sprintf("timeout_packet.packet_src_channel='%s' AND timeout_packet.packet_sequence='7'", osmosisToNobleChannel)
+
+		mockOsmosisClient.EXPECT().Tx(mockContext, txHashBytes, false).Return(transferTxResult, nil).Once()
+		mockNobleClient.EXPECT().Status(mockContext).Return(blockTime(time.Now()), nil).Once()
+		mockNobleClient.EXPECT().TxSearch(mockContext, ackQuery, false, mockContext, mockContext, "").Return(&coretypes.ResultTxSearch{}, nil).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+		assert.Equal(t, dbtypes.IBCTransferStatusTimedOut, database.GetIBCContents()[0].Status)
+
+		// no relayer relayed the timeout within the refund timeout
+		mockOsmosisClient.EXPECT().TxSearch(mockContext, timeoutQuery, false, mockContext, mockContext, "").Return(&coretypes.ResultTxSearch{}, nil).Once()
+		require.NoError(t, rebalancer.ibcBridge.TrackPendingTransfers(ctx))
+		transfer := database.GetIBCContents()[0]
+		assert.Equal(t, dbtypes.IBCTransferStatusFailed, transfer.Status)
+		assert.Contains(t, transfer.StatusMessage.String, "not relayed")
+		assert.Equal(t, dbtypes.RebalanceTransferStatusFailed, database.GetDBContents()[0].Status)
+		pending, err := database.GetPendingIBCTransfers(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("transfer tx builds a transfer over the configured channel", func(t *testing.T) {
+		ctx, rebalancer, _, _, _ := setup(t, timeout)
+
+		_, msg, err := rebalancer.ibcBridge.transferTx(ctx, nobleChainID, osmosisChainID, big.NewInt(100))
+		require.NoError(t, err)
+		assert.Equal(t, "transfer", msg.SourcePort)
+		assert.Equal(t, nobleToOsmosisChannel, msg.SourceChannel)
+		assert.Equal(t, "100uusdc", msg.Token.String())
+		assert.Equal(t, nobleAddress, msg.Sender)
+		assert.Equal(t, osmosisAddress, msg.Receiver)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), time.Unix(0, int64(msg.TimeoutTimestamp)), time.Minute)
+	})
+}
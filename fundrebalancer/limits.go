@@ -0,0 +1,274 @@
+package fundrebalancer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/metrics"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	// rebalanceLimitWindow is the rolling window daily budgets are enforced over
+	rebalanceLimitWindow = 24 * time.Hour
+	// allChainsLimitsLabel is the chain id that limits on all chains combined
+	// are reported under in metrics
+	allChainsLimitsLabel = "all"
+
+	limitMaxTransferAmount = "max_transfer_amount"
+	limitMaxDailyVolume    = "max_daily_volume"
+	limitMaxDailyFees      = "max_daily_fees"
+	limitCooldown          = "cooldown"
+)
+
+// ErrRebalanceLimitReached is returned when a rebalance is blocked by a
+// configured rebalance limit
+var ErrRebalanceLimitReached = errors.New("rebalance limit reached")
+
+// rebalanceLimits are parsed rebalance limits. Nil amounts and a zero cooldown
+// are not limited.
+type rebalanceLimits struct {
+	scope             string
+	maxTransferAmount *big.Int
+	maxDailyVolume    *big.Int
+	maxDailyFees      *big.Int
+	cooldown          time.Duration
+}
+
+func parseRebalanceLimits(scope string, limitsConfig *config.RebalanceLimitsConfig) (rebalanceLimits, error) {
+	limits := rebalanceLimits{scope: scope}
+	if limitsConfig == nil {
+		return limits, nil
+	}
+	limits.cooldown = limitsConfig.Cooldown
+
+	amounts := []struct {
+		name   string
+		amount string
+		limit  **big.Int
+	}{
+		{limitMaxTransferAmount, limitsConfig.MaxTransferAmountUUSDC, &limits.maxTransferAmount},
+		{limitMaxDailyVolume, limitsConfig.MaxDailyVolumeUUSDC, &limits.maxDailyVolume},
+		{limitMaxDailyFees, limitsConfig.MaxDailyFeesUUSDC, &limits.maxDailyFees},
+	}
+	for _, amount := range amounts {
+		if amount.amount == "" {
+			continue
+		}
+		limit, ok := new(big.Int).SetString(amount.amount, 10)
+		if !ok {
+			return rebalanceLimits{}, fmt.Errorf("could not convert %s %s to *big.Int", amount.name, amount.amount)
+		}
+		*amount.limit = limit
+	}
+	return limits, nil
+}
+
+// usesHistory returns true if enforcing the limits requires the recent
+// rebalance history
+func (l rebalanceLimits) usesHistory() bool {
+	return l.maxDailyVolume != nil || l.maxDailyFees != nil || l.cooldown > 0
+}
+
+// rebalanceUsage is the usdc moved and the quoted cost spent by rebalances
+// within the limit window
+type rebalanceUsage struct {
+	volume        *big.Int
+	fees          *big.Int
+	lastRebalance time.Time
+}
+
+// rebalanceLimitsFor gets the limits on rebalances into chainID, followed by
+// the limits on all chains combined
+func (r *FundRebalancer) rebalanceLimitsFor(chainID string) ([]rebalanceLimits, error) {
+	chainLimits, err := parseRebalanceLimits(chainID, r.config[chainID].Limits)
+	if err != nil {
+		return nil, fmt.Errorf("parsing rebalance limits for chain %s: %w", chainID, err)
+	}
+	globalLimits, err := parseRebalanceLimits(allChainsLimitsLabel, r.globalLimits)
+	if err != nil {
+		return nil, fmt.Errorf("parsing fund rebalancer limits: %w", err)
+	}
+	return []rebalanceLimits{chainLimits, globalLimits}, nil
+}
+
+// rebalanceUsage gets the usage of rebalances into chainID and of rebalances
+// into all chains combined since the start of the limit window, keyed by the
+// scope of the limits they count towards
+func (r *FundRebalancer) rebalanceUsage(ctx context.Context, chainID string, now time.Time) (map[string]*rebalanceUsage, error) {
+	transfers, err := r.database.GetRebalanceTransfersSince(ctx, now.Add(-rebalanceLimitWindow).UTC())
+	if err != nil {
+		return nil, fmt.Errorf("getting rebalance transfers in the last %s: %w", rebalanceLimitWindow, err)
+	}
+
+	usage := map[string]*rebalanceUsage{
+		chainID:              {volume: big.NewInt(0), fees: big.NewInt(0)},
+		allChainsLimitsLabel: {volume: big.NewInt(0), fees: big.NewInt(0)},
+	}
+	for _, transfer := range transfers {
+		if transfer.Kind != dbtypes.RebalanceTransferKindRebalance {
+			// gas top ups have their own daily caps and cooldowns
+			continue
+		}
+		amount, ok := new(big.Int).SetString(transfer.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("could not convert rebalance transfer amount %s to *big.Int", transfer.Amount)
+		}
+		fees := big.NewInt(0)
+		if transfer.QuotedCostUusdc.Valid {
+			fees, ok = new(big.Int).SetString(transfer.QuotedCostUusdc.String, 10)
+			if !ok {
+				return nil, fmt.Errorf("could not convert rebalance transfer quoted cost %s to *big.Int", transfer.QuotedCostUusdc.String)
+			}
+		}
+
+		scopes := []string{allChainsLimitsLabel}
+		if transfer.DestinationChainID == chainID {
+			scopes = append(scopes, chainID)
+		}
+		for _, scope := range scopes {
+			usage[scope].volume.Add(usage[scope].volume, amount)
+			usage[scope].fees.Add(usage[scope].fees, fees)
+			if transfer.CreatedAt.After(usage[scope].lastRebalance) {
+				usage[scope].lastRebalance = transfer.CreatedAt
+			}
+		}
+	}
+	return usage, nil
+}
+
+// checkRebalanceCooldown returns an error wrapping ErrRebalanceLimitReached if
+// chainID, or any chain if a global cooldown is configured, was rebalanced
+// into within the configured cooldown
+func (r *FundRebalancer) checkRebalanceCooldown(ctx context.Context, chainID string) error {
+	allLimits, err := r.rebalanceLimitsFor(chainID)
+	if err != nil {
+		return err
+	}
+	if allLimits[0].cooldown <= 0 && allLimits[1].cooldown <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	usage, err := r.rebalanceUsage(ctx, chainID, now)
+	if err != nil {
+		return err
+	}
+	for _, limits := range allLimits {
+		if limits.cooldown <= 0 {
+			continue
+		}
+		lastRebalance := usage[limits.scope].lastRebalance
+		if !lastRebalance.IsZero() && now.Sub(lastRebalance) < limits.cooldown {
+			metrics.FromContext(ctx).IncFundsRebalanceLimitHit(limits.scope, limitCooldown)
+			return fmt.Errorf("%w: last rebalance into %s was %s ago, cooldown is %s", ErrRebalanceLimitReached, limits.scope, now.Sub(lastRebalance).Round(time.Second), limits.cooldown)
+		}
+	}
+	return nil
+}
+
+// applyRebalanceLimits caps the amount moved by allocation to the configured
+// max transfer amount and remaining daily volume of chainID and of all chains
+// combined. An error wrapping ErrRebalanceLimitReached is returned if there is
+// no daily volume left, or if the allocations quoted cost would exceed the
+// remaining daily fee budget.
+func (r *FundRebalancer) applyRebalanceLimits(ctx context.Context, chainID string, allocation rebalanceAllocation) (rebalanceAllocation, error) {
+	allLimits, err := r.rebalanceLimitsFor(chainID)
+	if err != nil {
+		return rebalanceAllocation{}, err
+	}
+
+	var usage map[string]*rebalanceUsage
+	if allLimits[0].usesHistory() || allLimits[1].usesHistory() {
+		usage, err = r.rebalanceUsage(ctx, chainID, time.Now())
+		if err != nil {
+			return rebalanceAllocation{}, err
+		}
+	}
+
+	amount := new(big.Int).Set(allocation.amount)
+	for _, limits := range allLimits {
+		if limits.maxTransferAmount != nil && amount.Cmp(limits.maxTransferAmount) > 0 {
+			metrics.FromContext(ctx).IncFundsRebalanceLimitHit(limits.scope, limitMaxTransferAmount)
+			lmt.Logger(ctx).Info(
+				"capping rebalance transfer to max transfer amount",
+				zap.String("limitScope", limits.scope),
+				zap.String("amount", amount.String()),
+				zap.String("maxTransferAmount", limits.maxTransferAmount.String()),
+			)
+			amount.Set(limits.maxTransferAmount)
+		}
+
+		if limits.maxDailyVolume != nil {
+			used := usage[limits.scope].volume
+			metrics.FromContext(ctx).SetFundsRebalanceBudgetUsage(limits.scope, limitMaxDailyVolume, used, limits.maxDailyVolume)
+			remaining := new(big.Int).Sub(limits.maxDailyVolume, used)
+			if remaining.Sign() <= 0 {
+				metrics.FromContext(ctx).IncFundsRebalanceLimitHit(limits.scope, limitMaxDailyVolume)
+				return rebalanceAllocation{}, fmt.Errorf("%w: %s uusdc moved into %s in the last %s, max daily volume is %s uusdc", ErrRebalanceLimitReached, used.String(), limits.scope, rebalanceLimitWindow, limits.maxDailyVolume.String())
+			}
+			if amount.Cmp(remaining) > 0 {
+				metrics.FromContext(ctx).IncFundsRebalanceLimitHit(limits.scope, limitMaxDailyVolume)
+				lmt.Logger(ctx).Info(
+					"capping rebalance transfer to remaining daily volume",
+					zap.String("limitScope", limits.scope),
+					zap.String("amount", amount.String()),
+					zap.String("remainingDailyVolume", remaining.String()),
+				)
+				amount.Set(remaining)
+			}
+		}
+	}
+
+	cost := allocation.quote.costUUSDC(amount)
+	for _, limits := range allLimits {
+		if limits.maxDailyFees == nil {
+			continue
+		}
+		used := usage[limits.scope].fees
+		metrics.FromContext(ctx).SetFundsRebalanceBudgetUsage(limits.scope, limitMaxDailyFees, used, limits.maxDailyFees)
+		if new(big.Int).Add(used, cost).Cmp(limits.maxDailyFees) > 0 {
+			metrics.FromContext(ctx).IncFundsRebalanceLimitHit(limits.scope, limitMaxDailyFees)
+			return rebalanceAllocation{}, fmt.Errorf("%w: rebalance costing %s uusdc would exceed the max daily fees of %s uusdc for %s, %s uusdc already spent in the last %s", ErrRebalanceLimitReached, cost.String(), limits.maxDailyFees.String(), limits.scope, used.String(), rebalanceLimitWindow)
+		}
+	}
+
+	return rebalanceAllocation{quote: allocation.quote, amount: amount}, nil
+}
+
+// executeAllocation submits the txs for allocation after applying the
+// configured rebalance limits, unless overrideLimits is set
+func (r *FundRebalancer) executeAllocation(
+	ctx context.Context,
+	rebalanceToChainID string,
+	allocation rebalanceAllocation,
+	overrideLimits bool,
+) (rebalanceAllocation, skipgo.TxHash, error) {
+	if overrideLimits {
+		lmt.Logger(ctx).Warn(
+			"overriding rebalance limits",
+			zap.String("sourceChainID", allocation.quote.sourceChainID),
+			zap.String("destinationChainID", rebalanceToChainID),
+			zap.String("amount", allocation.amount.String()),
+		)
+	} else {
+		var err error
+		allocation, err = r.applyRebalanceLimits(ctx, rebalanceToChainID, allocation)
+		if err != nil {
+			return rebalanceAllocation{}, "", err
+		}
+	}
+
+	hash, err := allocation.quote.bridge.Execute(ctx, rebalanceToChainID, allocation)
+	if err != nil {
+		return rebalanceAllocation{}, "", err
+	}
+	return allocation, hash, nil
+}
@@ -0,0 +1,151 @@
+package fundrebalancer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	mock_database "github.com/skip-mev/go-fast-solver/mocks/fundrebalancer"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundRebalancer_RebalanceLimits(t *testing.T) {
+	ctx := context.Background()
+
+	newRebalancer := func(chainLimits, globalLimits *config.RebalanceLimitsConfig) (*FundRebalancer, *mock_database.FakeDatabase, *fakeBridge) {
+		database := mock_database.NewFakeDatabase()
+		bridge := &fakeBridge{name: "fake", gasCost: 5}
+		return &FundRebalancer{
+			database: database,
+			bridges:  []rebalanceBridge{bridge},
+			config: map[string]config.FundRebalancerConfig{
+				ethChainID:      {MinAllowedAmount: "50", TargetAmount: "100", Limits: chainLimits},
+				arbitrumChainID: {MinAllowedAmount: "50", TargetAmount: "100"},
+			},
+			globalLimits: globalLimits,
+		}, database, bridge
+	}
+	allocation := func(t *testing.T, bridge *fakeBridge, amount int64) rebalanceAllocation {
+		quote := &rebalanceQuote{sourceChainID: arbitrumChainID, amount: big.NewInt(amount), bridge: bridge}
+		require.NoError(t, bridge.Quote(ctx, ethChainID, quote))
+		return rebalanceAllocation{quote: quote, amount: big.NewInt(amount)}
+	}
+	addTransfer := func(t *testing.T, database *mock_database.FakeDatabase, destinationChainID, amount, cost string, createdAt time.Time) {
+		id, err := database.InsertRebalanceTransfer(ctx, db.InsertRebalanceTransferParams{
+			TxHash:             "hash",
+			SourceChainID:      arbitrumChainID,
+			DestinationChainID: destinationChainID,
+			Amount:             amount,
+			QuotedCostUusdc:    sql.NullString{String: cost, Valid: true},
+		})
+		require.NoError(t, err)
+		require.NoError(t, database.UpdateTransferCreatedAt(ctx, id, createdAt))
+	}
+
+	t.Run("transfers are capped at max transfer amount", func(t *testing.T) {
+		r, _, bridge := newRebalancer(&config.RebalanceLimitsConfig{MaxTransferAmountUUSDC: "300"}, nil)
+
+		capped, _, err := r.executeAllocation(ctx, ethChainID, allocation(t, bridge, 500), false)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(300), capped.amount)
+		require.Len(t, bridge.executed, 1)
+		assert.Equal(t, big.NewInt(300), bridge.executed[0].amount)
+	})
+
+	t.Run("transfers are capped at remaining daily volume", func(t *testing.T) {
+		r, database, bridge := newRebalancer(&config.RebalanceLimitsConfig{MaxDailyVolumeUUSDC: "1000"}, nil)
+		addTransfer(t, database, ethChainID, "800", "10", time.Now().Add(-time.Hour))
+		// outside of the limit window
+		addTransfer(t, database, ethChainID, "800", "10", time.Now().Add(-25*time.Hour))
+		// into another chain
+		addTransfer(t, database, arbitrumChainID, "800", "10", time.Now().Add(-time.Hour))
+
+		capped, _, err := r.executeAllocation(ctx, ethChainID, allocation(t, bridge, 500), false)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(200), capped.amount)
+
+		addTransfer(t, database, ethChainID, "200", "10", time.Now())
+		_, _, err = r.executeAllocation(ctx, ethChainID, allocation(t, bridge, 500), false)
+		assert.True(t, errors.Is(err, ErrRebalanceLimitReached))
+		assert.Len(t, bridge.executed, 1)
+	})
+
+	t.Run("global daily volume counts transfers into every chain", func(t *testing.T) {
+		r, database, bridge := newRebalancer(nil, &config.RebalanceLimitsConfig{MaxDailyVolumeUUSDC: "1000"})
+		addTransfer(t, database, arbitrumChainID, "900", "10", time.Now().Add(-time.Hour))
+
+		capped, _, err := r.executeAllocation(ctx, ethChainID, allocation(t, bridge, 500), false)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(100), capped.amount)
+	})
+
+	t.Run("transfers exceeding the daily fee budget are rejected", func(t *testing.T) {
+		r, database, bridge := newRebalancer(&config.RebalanceLimitsConfig{MaxDailyFeesUUSDC: "50"}, nil)
+		addTransfer(t, database, ethChainID, "100", "30", time.Now().Add(-time.Hour))
+
+		// costs 10 uusdc of bridge fees and 5 uusdc of gas
+		_, _, err := r.executeAllocation(ctx, ethChainID, allocation(t, bridge, 100), false)
+		require.NoError(t, err)
+
+		// costs 20 uusdc of bridge fees and 5 uusdc of gas
+		_, _, err = r.executeAllocation(ctx, ethChainID, allocation(t, bridge, 200), false)
+		assert.True(t, errors.Is(err, ErrRebalanceLimitReached))
+		assert.Len(t, bridge.executed, 1)
+	})
+
+	t.Run("limits are not enforced when overridden", func(t *testing.T) {
+		r, database, bridge := newRebalancer(&config.RebalanceLimitsConfig{MaxTransferAmountUUSDC: "300", MaxDailyVolumeUUSDC: "100"}, nil)
+		addTransfer(t, database, ethChainID, "100", "10", time.Now().Add(-time.Hour))
+
+		moved, _, err := r.executeAllocation(ctx, ethChainID, allocation(t, bridge, 500), true)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(500), moved.amount)
+	})
+
+	t.Run("chains rebalanced into within their cooldown are skipped", func(t *testing.T) {
+		r, database, bridge := newRebalancer(&config.RebalanceLimitsConfig{Cooldown: time.Hour}, nil)
+		require.NoError(t, r.checkRebalanceCooldown(ctx, ethChainID))
+
+		addTransfer(t, database, ethChainID, "100", "10", time.Now().Add(-2*time.Hour))
+		require.NoError(t, r.checkRebalanceCooldown(ctx, ethChainID))
+
+		addTransfer(t, database, ethChainID, "100", "10", time.Now().Add(-30*time.Minute))
+		err := r.checkRebalanceCooldown(ctx, ethChainID)
+		assert.True(t, errors.Is(err, ErrRebalanceLimitReached))
+
+		hashes, moved, err := r.MoveFundsToChain(ctx, ethChainID, big.NewInt(100))
+		require.NoError(t, err)
+		assert.Empty(t, hashes)
+		assert.Equal(t, big.NewInt(0), moved)
+		assert.Empty(t, bridge.executed)
+
+		_, err = r.QuoteMove(ctx, arbitrumChainID, ethChainID, big.NewInt(100), false)
+		assert.True(t, errors.Is(err, ErrRebalanceLimitReached))
+		_, err = r.QuoteMove(ctx, arbitrumChainID, ethChainID, big.NewInt(100), true)
+		assert.NoError(t, err)
+	})
+}
+
+func TestFundRebalancer_SpareThreshold(t *testing.T) {
+	ctx := context.Background()
+	r := &FundRebalancer{
+		config: map[string]config.FundRebalancerConfig{
+			ethChainID:      {MinAllowedAmount: "50", TargetAmount: "100", HysteresisBandUUSDC: "25"},
+			arbitrumChainID: {MinAllowedAmount: "50", TargetAmount: "100"},
+		},
+	}
+
+	threshold, err := r.spareThreshold(ctx, ethChainID)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(125), threshold)
+
+	threshold, err = r.spareThreshold(ctx, arbitrumChainID)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), threshold)
+}
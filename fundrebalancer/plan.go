@@ -0,0 +1,291 @@
+package fundrebalancer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"golang.org/x/net/context"
+)
+
+// ChainPlan is the rebalancing state of a single chain
+type ChainPlan struct {
+	ChainID               string
+	BalanceUUSDC          *big.Int
+	PendingInboundUUSDC   *big.Int
+	MinAllowedAmountUUSDC *big.Int
+	TargetAmountUUSDC     *big.Int
+	// DeficitUUSDC is the amount of usdc that will be moved to the chain
+	// to reach its target amount, or 0 if the chain is above its min allowed
+	// amount
+	DeficitUUSDC *big.Int
+	// SurplusUUSDC is the amount of usdc above the chains target amount and
+	// hysteresis band that can be moved to other chains
+	SurplusUUSDC *big.Int
+}
+
+// PlannedMove is a quoted move of usdc between two chains
+type PlannedMove struct {
+	SourceChainID      string
+	DestinationChainID string
+	Bridge             string
+	// Route is the chains the usdc moves through, starting with the source
+	// chain and ending with the destination chain
+	Route             []string
+	AmountUUSDC       *big.Int
+	RouteFeeUUSDC     *big.Int
+	BridgeFeeUUSDC    *big.Int
+	GasCostUUSDC      *big.Int
+	TotalCostUUSDC    *big.Int
+	EstimatedDuration time.Duration
+
+	allocation rebalanceAllocation
+}
+
+// RebalancePlan is the state of every chain configured for rebalancing, and
+// the moves the fund rebalancer would make to bring chains in deficit back to
+// their target amounts
+type RebalancePlan struct {
+	Chains []ChainPlan
+	Moves  []PlannedMove
+	// Unfunded maps chains in deficit that no moves could be planned for to
+	// the reason why
+	Unfunded map[string]string
+}
+
+// Plan computes the moves the fund rebalancer would make if it rebalanced now,
+// without submitting any txs. Chains in deficit are planned in the same way
+// that Rebalance handles them, one after another, with the usdc planned to be
+// moved from a source chain no longer available to the chains after it.
+// Chains rebalanced into within their cooldown are not planned unless
+// overrideLimits is set.
+func (r *FundRebalancer) Plan(ctx context.Context, overrideLimits bool) (*RebalancePlan, error) {
+	ctx = withForecastCache(ctx)
+	chainIDs := make([]string, 0, len(r.config))
+	for chainID := range r.config {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Strings(chainIDs)
+
+	plan := &RebalancePlan{Unfunded: make(map[string]string)}
+	for _, chainID := range chainIDs {
+		chainPlan, err := r.planChain(ctx, chainID)
+		if err != nil {
+			return nil, err
+		}
+		plan.Chains = append(plan.Chains, chainPlan)
+	}
+
+	// Rebalance sees the balance of a source chain drop as it moves usdc
+	// from it, so the usdc left to spare on each chain is tracked as moves
+	// are planned
+	remainingSurplus := make(map[string]*big.Int)
+	for _, chainPlan := range plan.Chains {
+		remainingSurplus[chainPlan.ChainID] = new(big.Int).Set(chainPlan.SurplusUUSDC)
+	}
+	spareUSDC := func(ctx context.Context, chainID string) (*big.Int, error) {
+		surplus, ok := remainingSurplus[chainID]
+		if !ok {
+			return big.NewInt(0), nil
+		}
+		return new(big.Int).Set(surplus), nil
+	}
+
+	for _, chainPlan := range plan.Chains {
+		if chainPlan.DeficitUUSDC.Sign() <= 0 {
+			continue
+		}
+		if !overrideLimits {
+			if err := r.checkRebalanceCooldown(ctx, chainPlan.ChainID); errors.Is(err, ErrRebalanceLimitReached) {
+				plan.Unfunded[chainPlan.ChainID] = err.Error()
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("checking rebalance cooldown for chain %s: %w", chainPlan.ChainID, err)
+			}
+		}
+		quotes, err := r.quoteRebalanceSources(ctx, chainPlan.ChainID, chainPlan.DeficitUUSDC, spareUSDC)
+		if err != nil {
+			return nil, fmt.Errorf("quoting chains to rebalance %s uusdc to chain %s from: %w", chainPlan.DeficitUUSDC.String(), chainPlan.ChainID, err)
+		}
+		allocations := selectRebalanceSources(quotes, chainPlan.DeficitUUSDC)
+		if len(allocations) == 0 {
+			plan.Unfunded[chainPlan.ChainID] = unfundedReason(quotes)
+			continue
+		}
+		for _, allocation := range allocations {
+			plan.Moves = append(plan.Moves, plannedMove(chainPlan.ChainID, allocation))
+			surplus := remainingSurplus[allocation.quote.sourceChainID]
+			surplus.Sub(surplus, allocation.amount)
+			if surplus.Sign() < 0 {
+				surplus.SetInt64(0)
+			}
+		}
+	}
+	return plan, nil
+}
+
+func (r *FundRebalancer) planChain(ctx context.Context, chainID string) (ChainPlan, error) {
+	balance, err := r.usdcBalance(ctx, chainID)
+	if err != nil {
+		return ChainPlan{}, fmt.Errorf("getting usdc balance on chain %s: %w", chainID, err)
+	}
+	pendingInbound, err := r.pendingUSDCBalance(ctx, chainID)
+	if err != nil {
+		return ChainPlan{}, fmt.Errorf("getting pending balance on chain %s: %w", chainID, err)
+	}
+	minAllowedAmount, targetAmount, err := r.rebalanceThresholds(ctx, chainID)
+	if err != nil {
+		return ChainPlan{}, fmt.Errorf("getting rebalance thresholds for chain %s: %w", chainID, err)
+	}
+	spareThreshold, err := r.spareThreshold(ctx, chainID)
+	if err != nil {
+		return ChainPlan{}, err
+	}
+
+	// deficits include pending inbound transfers while surpluses do not, in
+	// the same way as USDCNeeded and USDCToSpare
+	deficit := big.NewInt(0)
+	expectedBalance := new(big.Int).Add(balance, pendingInbound)
+	if expectedBalance.Cmp(minAllowedAmount) < 0 {
+		deficit = new(big.Int).Sub(targetAmount, expectedBalance)
+	}
+	surplus := big.NewInt(0)
+	if balance.Cmp(spareThreshold) > 0 {
+		surplus = new(big.Int).Sub(balance, spareThreshold)
+	}
+
+	return ChainPlan{
+		ChainID:               chainID,
+		BalanceUUSDC:          balance,
+		PendingInboundUUSDC:   pendingInbound,
+		MinAllowedAmountUUSDC: minAllowedAmount,
+		TargetAmountUUSDC:     targetAmount,
+		DeficitUUSDC:          deficit,
+		SurplusUUSDC:          surplus,
+	}, nil
+}
+
+// QuoteMove quotes moving amount usdc from sourceChainID to
+// destinationChainID with the cheapest bridge that supports both chains.
+// Unlike the moves made by Rebalance, the source chain does not need to have
+// usdc to spare and the max rebalancing gas cost of the source chain is not
+// enforced, so that operators can force moves between chains. The destination
+// chains cooldown is still enforced unless overrideLimits is set.
+func (r *FundRebalancer) QuoteMove(ctx context.Context, sourceChainID, destinationChainID string, amount *big.Int, overrideLimits bool) (*PlannedMove, error) {
+	if sourceChainID == destinationChainID {
+		return nil, fmt.Errorf("source and destination chain must be different")
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("amount must be greater than 0")
+	}
+	if !overrideLimits {
+		if err := r.checkRebalanceCooldown(ctx, destinationChainID); err != nil {
+			return nil, err
+		}
+	}
+
+	var cheapest *rebalanceQuote
+	var quoteErrs []error
+	for _, bridge := range r.bridges {
+		if !bridge.Supports(ctx, sourceChainID, destinationChainID) {
+			continue
+		}
+		quote := &rebalanceQuote{sourceChainID: sourceChainID, amount: amount, bridge: bridge}
+		if err := bridge.Quote(ctx, destinationChainID, quote); err != nil {
+			quoteErrs = append(quoteErrs, fmt.Errorf("%s: %w", bridge.Name(), err))
+			continue
+		}
+		if cheapest == nil || quote.cheaperThan(cheapest) {
+			cheapest = quote
+		}
+	}
+	if cheapest == nil {
+		return nil, fmt.Errorf("no bridge could quote moving %s uusdc from chain %s to chain %s: %v", amount.String(), sourceChainID, destinationChainID, quoteErrs)
+	}
+
+	move := plannedMove(destinationChainID, rebalanceAllocation{quote: cheapest, amount: amount})
+	return &move, nil
+}
+
+// ExecuteMove submits the txs for a planned move. The move is recorded as a
+// rebalance transfer and tracked by the solver like any other rebalance.
+// Unless overrideLimits is set, the amount moved is capped by the configured
+// rebalance limits and the move fails if a daily budget is exhausted. The
+// amount actually moved is returned along with the tx hash.
+func (r *FundRebalancer) ExecuteMove(ctx context.Context, move PlannedMove, overrideLimits bool) (skipgo.TxHash, *big.Int, error) {
+	if move.allocation.quote == nil {
+		return "", nil, fmt.Errorf("move from chain %s to chain %s was not quoted", move.SourceChainID, move.DestinationChainID)
+	}
+	allocation, hash, err := r.executeAllocation(ctx, move.DestinationChainID, move.allocation, overrideLimits)
+	if err != nil {
+		return "", nil, err
+	}
+	return hash, allocation.amount, nil
+}
+
+func plannedMove(destinationChainID string, allocation rebalanceAllocation) PlannedMove {
+	quote := allocation.quote
+	return PlannedMove{
+		SourceChainID:      quote.sourceChainID,
+		DestinationChainID: destinationChainID,
+		Bridge:             quote.bridge.Name(),
+		Route:              routeChainIDs(quote.sourceChainID, destinationChainID, quote.txns),
+		AmountUUSDC:        allocation.amount,
+		RouteFeeUUSDC:      quote.routeFeeUUSDC,
+		BridgeFeeUUSDC:     quote.bridgeFeeUUSDC,
+		GasCostUUSDC:       quote.gasCostUUSDC,
+		TotalCostUUSDC:     quote.costUUSDC(allocation.amount),
+		EstimatedDuration:  quote.estimatedDuration,
+		allocation:         allocation,
+	}
+}
+
+// routeChainIDs lists the chains a route moves usdc through
+func routeChainIDs(sourceChainID, destinationChainID string, txns []skipgo.Tx) []string {
+	chainIDs := []string{sourceChainID}
+	appendChainID := func(chainID string) {
+		if chainID != "" && chainIDs[len(chainIDs)-1] != chainID {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	for _, txn := range txns {
+		switch {
+		case txn.EVMTx != nil:
+			appendChainID(txn.EVMTx.ChainID)
+		case txn.CosmosTx != nil:
+			appendChainID(txn.CosmosTx.ChainID)
+			for _, chainID := range txn.CosmosTx.Path {
+				appendChainID(chainID)
+			}
+		case txn.SVMTx != nil:
+			appendChainID(txn.SVMTx.ChainID)
+		}
+	}
+	appendChainID(destinationChainID)
+	return chainIDs
+}
+
+// unfundedReason summarizes why none of the quotes for a chain in deficit
+// were selected
+func unfundedReason(quotes []*rebalanceQuote) string {
+	if len(quotes) == 0 {
+		return "no chains have usdc to spare"
+	}
+	var reasons []string
+	for _, quote := range quotes {
+		switch {
+		case quote.err != nil:
+			reasons = append(reasons, fmt.Sprintf("%s via %s: %s", quote.sourceChainID, quote.bridge.Name(), quote.err.Error()))
+		case quote.skipReason != "":
+			reasons = append(reasons, fmt.Sprintf("%s via %s: %s", quote.sourceChainID, quote.bridge.Name(), quote.skipReason))
+		}
+	}
+	if len(reasons) == 0 {
+		return "no viable quotes"
+	}
+	return strings.Join(reasons, "; ")
+}
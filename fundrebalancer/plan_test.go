@@ -0,0 +1,199 @@
+package fundrebalancer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	mock_database "github.com/skip-mev/go-fast-solver/mocks/fundrebalancer"
+	mock_config "github.com/skip-mev/go-fast-solver/mocks/shared/config"
+	mock_evmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/evmrpc"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBridge quotes a fixed gas cost and fee per uusdc moved and records the
+// allocations it executes
+type fakeBridge struct {
+	name     string
+	gasCost  int64
+	executed []rebalanceAllocation
+}
+
+func (b *fakeBridge) Name() string {
+	return b.name
+}
+
+func (b *fakeBridge) Supports(ctx context.Context, sourceChainID, destinationChainID string) bool {
+	return true
+}
+
+func (b *fakeBridge) Quote(ctx context.Context, destinationChainID string, quote *rebalanceQuote) error {
+	quote.txns = []skipgo.Tx{{EVMTx: &skipgo.EVMTx{ChainID: quote.sourceChainID}}}
+	quote.routeFeeUUSDC = big.NewInt(0)
+	quote.bridgeFeeUUSDC = new(big.Int).Div(quote.amount, big.NewInt(10))
+	quote.gasCostUUSDC = big.NewInt(b.gasCost)
+	quote.estimatedDuration = time.Minute
+	return nil
+}
+
+func (b *fakeBridge) Execute(ctx context.Context, destinationChainID string, allocation rebalanceAllocation) (skipgo.TxHash, error) {
+	b.executed = append(b.executed, allocation)
+	return "hash", nil
+}
+
+func TestFundRebalancer_Plan(t *testing.T) {
+	ctx := context.Background()
+	mockConfigReader := mock_config.NewMockConfigReader(t)
+	mockConfigReader.EXPECT().GetUSDCDenom(ethChainID).Return(ethUSDCDenom, nil)
+	mockConfigReader.EXPECT().GetUSDCDenom(arbitrumChainID).Return(arbitrumUSDCDenom, nil)
+	mockConfigReader.EXPECT().GetChainConfig(ethChainID).Return(config.ChainConfig{Type: config.ChainType_EVM, SolverAddress: ethAddress}, nil)
+	mockConfigReader.EXPECT().GetChainConfig(arbitrumChainID).Return(config.ChainConfig{Type: config.ChainType_EVM, SolverAddress: arbitrumAddress}, nil)
+	mockConfigReader.EXPECT().GetFundRebalancingConfig(arbitrumChainID).Return(config.FundRebalancerConfig{}, nil).Maybe()
+	ctx = config.ConfigReaderContext(ctx, mockConfigReader)
+
+	mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+	mockEthClient := mock_evmrpc.NewMockEVMChainRPC(t)
+	mockArbitrumClient := mock_evmrpc.NewMockEVMChainRPC(t)
+	mockEVMClientManager.EXPECT().GetClient(mockContext, ethChainID).Return(mockEthClient, nil)
+	mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockArbitrumClient, nil)
+	mockEthClient.EXPECT().GetUSDCBalance(mockContext, ethUSDCDenom, ethAddress).Return(big.NewInt(20), nil)
+	mockArbitrumClient.EXPECT().GetUSDCBalance(mockContext, arbitrumUSDCDenom, arbitrumAddress).Return(big.NewInt(500), nil)
+
+	database := mock_database.NewFakeDatabase()
+	_, err := database.InsertRebalanceTransfer(ctx, db.InsertRebalanceTransferParams{
+		TxHash:             "pending",
+		SourceChainID:      arbitrumChainID,
+		DestinationChainID: ethChainID,
+		Amount:             "10",
+	})
+	require.NoError(t, err)
+
+	bridge := &fakeBridge{name: "fake", gasCost: 1}
+	r := &FundRebalancer{
+		evmClientManager: mockEVMClientManager,
+		database:         database,
+		bridges:          []rebalanceBridge{bridge},
+		config: map[string]config.FundRebalancerConfig{
+			ethChainID:      {MinAllowedAmount: "50", TargetAmount: "100"},
+			arbitrumChainID: {MinAllowedAmount: "50", TargetAmount: "100"},
+		},
+	}
+
+	t.Run("plan shows chain state and planned moves", func(t *testing.T) {
+		plan, err := r.Plan(ctx, false)
+		require.NoError(t, err)
+
+		require.Len(t, plan.Chains, 2)
+		eth, arbitrum := plan.Chains[0], plan.Chains[1]
+		assert.Equal(t, arbitrumChainID, arbitrum.ChainID)
+		assert.Equal(t, big.NewInt(500), arbitrum.BalanceUUSDC)
+		assert.Equal(t, big.NewInt(0), arbitrum.DeficitUUSDC)
+		assert.Equal(t, big.NewInt(400), arbitrum.SurplusUUSDC)
+		assert.Equal(t, ethChainID, eth.ChainID)
+		assert.Equal(t, big.NewInt(10), eth.PendingInboundUUSDC)
+		assert.Equal(t, big.NewInt(70), eth.DeficitUUSDC)
+		assert.Equal(t, big.NewInt(0), eth.SurplusUUSDC)
+
+		require.Len(t, plan.Moves, 1)
+		move := plan.Moves[0]
+		assert.Equal(t, arbitrumChainID, move.SourceChainID)
+		assert.Equal(t, ethChainID, move.DestinationChainID)
+		assert.Equal(t, "fake", move.Bridge)
+		assert.Equal(t, []string{arbitrumChainID, ethChainID}, move.Route)
+		assert.Equal(t, big.NewInt(70), move.AmountUUSDC)
+		assert.Equal(t, big.NewInt(7), move.BridgeFeeUUSDC)
+		assert.Equal(t, big.NewInt(8), move.TotalCostUUSDC)
+		assert.Empty(t, plan.Unfunded)
+		assert.Empty(t, bridge.executed)
+
+		_, moved, err := r.ExecuteMove(ctx, move, false)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(70), moved)
+		require.Len(t, bridge.executed, 1)
+		assert.Equal(t, big.NewInt(70), bridge.executed[0].amount)
+	})
+
+	t.Run("forced moves do not require usdc to spare", func(t *testing.T) {
+		bridge.executed = nil
+		move, err := r.QuoteMove(ctx, ethChainID, arbitrumChainID, big.NewInt(15), false)
+		require.NoError(t, err)
+		assert.Equal(t, ethChainID, move.SourceChainID)
+		assert.Equal(t, big.NewInt(15), move.AmountUUSDC)
+
+		_, _, err = r.ExecuteMove(ctx, *move, false)
+		require.NoError(t, err)
+		require.Len(t, bridge.executed, 1)
+		assert.Equal(t, ethChainID, bridge.executed[0].quote.sourceChainID)
+
+		_, err = r.QuoteMove(ctx, ethChainID, ethChainID, big.NewInt(15), false)
+		assert.Error(t, err)
+	})
+}
+
+func TestFundRebalancer_PlanSharesSourceSurplus(t *testing.T) {
+	const (
+		optimismChainID   = "10"
+		optimismAddress   = "0x789"
+		optimismUSDCDenom = "0xopusdc"
+	)
+	ctx := context.Background()
+	mockConfigReader := mock_config.NewMockConfigReader(t)
+	mockConfigReader.EXPECT().GetUSDCDenom(ethChainID).Return(ethUSDCDenom, nil)
+	mockConfigReader.EXPECT().GetUSDCDenom(optimismChainID).Return(optimismUSDCDenom, nil)
+	mockConfigReader.EXPECT().GetUSDCDenom(arbitrumChainID).Return(arbitrumUSDCDenom, nil)
+	mockConfigReader.EXPECT().GetChainConfig(ethChainID).Return(config.ChainConfig{Type: config.ChainType_EVM, SolverAddress: ethAddress}, nil)
+	mockConfigReader.EXPECT().GetChainConfig(optimismChainID).Return(config.ChainConfig{Type: config.ChainType_EVM, SolverAddress: optimismAddress}, nil)
+	mockConfigReader.EXPECT().GetChainConfig(arbitrumChainID).Return(config.ChainConfig{Type: config.ChainType_EVM, SolverAddress: arbitrumAddress}, nil)
+	mockConfigReader.EXPECT().GetFundRebalancingConfig(mockContext).Return(config.FundRebalancerConfig{}, nil).Maybe()
+	ctx = config.ConfigReaderContext(ctx, mockConfigReader)
+
+	mockEVMClientManager := mock_evmrpc.NewMockEVMRPCClientManager(t)
+	mockEthClient := mock_evmrpc.NewMockEVMChainRPC(t)
+	mockOptimismClient := mock_evmrpc.NewMockEVMChainRPC(t)
+	mockArbitrumClient := mock_evmrpc.NewMockEVMChainRPC(t)
+	mockEVMClientManager.EXPECT().GetClient(mockContext, ethChainID).Return(mockEthClient, nil)
+	mockEVMClientManager.EXPECT().GetClient(mockContext, optimismChainID).Return(mockOptimismClient, nil)
+	mockEVMClientManager.EXPECT().GetClient(mockContext, arbitrumChainID).Return(mockArbitrumClient, nil)
+	mockEthClient.EXPECT().GetUSDCBalance(mockContext, ethUSDCDenom, ethAddress).Return(big.NewInt(0), nil)
+	mockOptimismClient.EXPECT().GetUSDCBalance(mockContext, optimismUSDCDenom, optimismAddress).Return(big.NewInt(40), nil)
+	mockArbitrumClient.EXPECT().GetUSDCBalance(mockContext, arbitrumUSDCDenom, arbitrumAddress).Return(big.NewInt(220), nil)
+
+	r := &FundRebalancer{
+		evmClientManager: mockEVMClientManager,
+		database:         mock_database.NewFakeDatabase(),
+		bridges:          []rebalanceBridge{&fakeBridge{name: "fake", gasCost: 1}},
+		config: map[string]config.FundRebalancerConfig{
+			ethChainID:      {MinAllowedAmount: "50", TargetAmount: "100"},
+			optimismChainID: {MinAllowedAmount: "50", TargetAmount: "100"},
+			arbitrumChainID: {MinAllowedAmount: "50", TargetAmount: "100"},
+		},
+	}
+
+	plan, err := r.Plan(ctx, false)
+	require.NoError(t, err)
+
+	// arbitrum can spare 120 uusdc, so eth is planned to receive its full
+	// deficit of 100 and optimism only the remaining 20 of its deficit of 60
+	require.Len(t, plan.Moves, 2)
+	assert.Equal(t, ethChainID, plan.Moves[0].DestinationChainID)
+	assert.Equal(t, big.NewInt(100), plan.Moves[0].AmountUUSDC)
+	assert.Equal(t, optimismChainID, plan.Moves[1].DestinationChainID)
+	assert.Equal(t, big.NewInt(20), plan.Moves[1].AmountUUSDC)
+
+	// the surplus of each chain is reported before any moves are made
+	assert.Equal(t, arbitrumChainID, plan.Chains[2].ChainID)
+	assert.Equal(t, big.NewInt(120), plan.Chains[2].SurplusUUSDC)
+}
+
+func TestRouteChainIDs(t *testing.T) {
+	txns := []skipgo.Tx{
+		{EVMTx: &skipgo.EVMTx{ChainID: arbitrumChainID}},
+		{CosmosTx: &skipgo.CosmosTx{ChainID: nobleChainID, Path: []string{nobleChainID, osmosisChainID}}},
+	}
+	assert.Equal(t, []string{arbitrumChainID, nobleChainID, osmosisChainID}, routeChainIDs(arbitrumChainID, osmosisChainID, txns))
+}
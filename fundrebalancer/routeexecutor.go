@@ -0,0 +1,474 @@
+package fundrebalancer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/metrics"
+	"go.uber.org/zap"
+)
+
+// routeStepSubmitter requests, signs and submits the txs of a rebalance route
+type routeStepSubmitter interface {
+	submitApproval(ctx context.Context, chainID string, txn skipgo.Tx, amount *big.Int) error
+	SignAndSubmitTxn(ctx context.Context, txn SkipGoTxnWithMetadata) (skipgo.TxHash, string, error)
+	skipGoRoute(ctx context.Context, amount *big.Int, sourceChainID, sourceDenom, destChainID, destDenom string) (*skipgo.RouteResponse, []skipgo.Tx, error)
+	tokenBalance(ctx context.Context, chainID, denom string) (*big.Int, error)
+}
+
+// RouteExecutor executes the remaining txs of multi tx rebalance routes. Each
+// tx is only submitted once the transfer started by the previous tx has
+// completed. The progress of every route is persisted per step, so routes
+// that are in flight when the solver stops are resumed when it restarts.
+type RouteExecutor struct {
+	skipgo    skipgo.SkipGoClient
+	database  Database
+	submitter routeStepSubmitter
+}
+
+func NewRouteExecutor(skipgo skipgo.SkipGoClient, database Database, submitter routeStepSubmitter) *RouteExecutor {
+	return &RouteExecutor{
+		skipgo:    skipgo,
+		database:  database,
+		submitter: submitter,
+	}
+}
+
+func (e *RouteExecutor) ExecuteRoutes(ctx context.Context) {
+	const pollInterval = 2 * time.Second
+	const initialPollInterval = 1 * time.Nanosecond
+	ticker := time.NewTicker(initialPollInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Stop()
+
+			if err := e.ExecuteInFlightRoutes(ctx); err != nil {
+				lmt.Logger(ctx).Error("got an error executing multi tx rebalance routes", zap.Error(err))
+			}
+
+			ticker.Reset(pollInterval)
+		}
+	}
+}
+
+// ExecuteInFlightRoutes advances every pending multi tx rebalance route by
+// checking on its current step, and submitting the next step once the
+// current one has completed
+func (e *RouteExecutor) ExecuteInFlightRoutes(ctx context.Context) error {
+	transfers, err := e.database.GetPendingRebalanceTransfersWithSteps(ctx)
+	if err != nil {
+		return fmt.Errorf("getting pending multi tx rebalance transfers: %w", err)
+	}
+
+	for _, transfer := range transfers {
+		if err := e.executeRoute(ctx, transfer); err != nil {
+			lmt.Logger(ctx).Error(
+				"error executing multi tx rebalance route",
+				zap.Error(err),
+				zap.Int64("id", transfer.ID),
+				zap.String("sourceChainID", transfer.SourceChainID),
+				zap.String("destinationChainID", transfer.DestinationChainID),
+			)
+		}
+	}
+
+	return nil
+}
+
+// rebalanceTransferStepWriter inserts the steps of multi tx rebalance
+// transfers, either directly into the db or as part of a db transaction
+type rebalanceTransferStepWriter interface {
+	InsertRebalanceTransferStep(ctx context.Context, arg db.InsertRebalanceTransferStepParams) (db.RebalanceTransferStep, error)
+}
+
+// insertRouteSteps persists the txs of a multi tx route whose first tx has
+// already been submitted with hash firstTxHash, so that the route executor
+// can execute the rest of the route. The asset each step spends is recorded
+// from the route's operations so that the step's tx can be requested again
+// once the previous step has completed.
+func insertRouteSteps(ctx context.Context, q rebalanceTransferStepWriter, rebalanceTransferID int64, firstTxHash skipgo.TxHash, route *skipgo.RouteResponse, txns []skipgo.Tx) error {
+	for i, txn := range txns {
+		txJSON, err := json.Marshal(txn)
+		if err != nil {
+			return fmt.Errorf("json encoding tx for step %d: %w", i, err)
+		}
+
+		step := db.InsertRebalanceTransferStepParams{
+			RebalanceTransferID: rebalanceTransferID,
+			StepIndex:           int64(i),
+			ChainID:             txChainID(txn),
+			Tx:                  string(txJSON),
+			Status:              dbtypes.RebalanceTransferStepStatusPending,
+		}
+		if denom, amountIn, ok := routeTxAsset(route, i); ok {
+			step.SourceDenom = sql.NullString{String: denom, Valid: true}
+			step.DestinationDenom = sql.NullString{String: route.DestAssetDenom, Valid: true}
+			step.QuotedAmountIn = sql.NullString{String: amountIn, Valid: amountIn != ""}
+		} else if i > 0 {
+			lmt.Logger(ctx).Warn(
+				"could not find the asset spent by a rebalance route step, the step's quoted tx will be submitted as is",
+				zap.Int64("id", rebalanceTransferID),
+				zap.Int("step", i),
+			)
+		}
+		if i == 0 {
+			step.TxHash = sql.NullString{String: string(firstTxHash), Valid: true}
+			step.SubmittedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+			step.Status = dbtypes.RebalanceTransferStepStatusSubmitted
+		}
+		if _, err := q.InsertRebalanceTransferStep(ctx, step); err != nil {
+			return fmt.Errorf("inserting step %d of rebalance transfer %d: %w", i, rebalanceTransferID, err)
+		}
+	}
+	return nil
+}
+
+func (e *RouteExecutor) executeRoute(ctx context.Context, transfer db.RebalanceTransfer) error {
+	steps, err := e.database.GetRebalanceTransferSteps(ctx, transfer.ID)
+	if err != nil {
+		return fmt.Errorf("getting steps of rebalance transfer %d: %w", transfer.ID, err)
+	}
+
+	for i, step := range steps {
+		switch step.Status {
+		case dbtypes.RebalanceTransferStepStatusSuccess:
+			continue
+		case dbtypes.RebalanceTransferStepStatusSubmitted:
+			completed, err := e.checkStep(ctx, transfer, step)
+			if err != nil || !completed {
+				return err
+			}
+			// the next step can be submitted right away
+			continue
+		case dbtypes.RebalanceTransferStepStatusPending:
+			// the previous step has completed, or this is the first step
+			readySince := step.UpdatedAt
+			if i > 0 && steps[i-1].UpdatedAt.After(readySince) {
+				readySince = steps[i-1].UpdatedAt
+			}
+			return e.submitStep(ctx, transfer, step, len(steps)-i, readySince)
+		case dbtypes.RebalanceTransferStepStatusSubmitting:
+			// steps are only left submitting if the solver stopped, or the
+			// db could not be updated, after the step's tx was handed off to
+			// be broadcast. The tx may have been broadcast, so it is never
+			// submitted again and the route is left for an operator to
+			// reconcile.
+			return e.failStep(ctx, transfer, step, "solver stopped while submitting the step's tx, check if the tx was broadcast before retrying the step")
+		case dbtypes.RebalanceTransferStepStatusFailed:
+			return e.failRoute(ctx, transfer, step, step.StatusMessage.String)
+		default:
+			return fmt.Errorf("unknown status %s for step %d of rebalance transfer %d", step.Status, step.StepIndex, transfer.ID)
+		}
+	}
+
+	// every step has completed successfully
+	lmt.Logger(ctx).Info(
+		"multi tx rebalance route completed successfully",
+		zap.Int64("id", transfer.ID),
+		zap.String("sourceChainID", transfer.SourceChainID),
+		zap.String("destinationChainID", transfer.DestinationChainID),
+		zap.Int("steps", len(steps)),
+	)
+	metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(transfer.SourceChainID, transfer.DestinationChainID, dbtypes.RebalanceTransferStatusSuccess)
+
+	if err := e.database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{
+		Status: dbtypes.RebalanceTransferStatusSuccess,
+		ID:     transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("updating transfer status to completed for rebalance transfer %d: %w", transfer.ID, err)
+	}
+	return nil
+}
+
+// checkStep checks if the transfer started by a submitted step has completed,
+// marking the step as succeeded or failed once it has
+func (e *RouteExecutor) checkStep(ctx context.Context, transfer db.RebalanceTransfer, step db.RebalanceTransferStep) (bool, error) {
+	if step.SubmittedAt.Valid && time.Since(step.SubmittedAt.Time) > transferTimeout {
+		return false, e.failStep(ctx, transfer, step, fmt.Sprintf("timed out after %s waiting for tx %s to complete", transferTimeout, step.TxHash.String))
+	}
+
+	done, latestState, transferError, err := transferResult(ctx, e.skipgo, step.TxHash.String, step.ChainID)
+	if err != nil {
+		return false, err
+	}
+	if !done {
+		lmt.Logger(ctx).Debug(
+			"waiting for rebalance route step to complete",
+			zap.Int64("id", transfer.ID),
+			zap.Int64("step", step.StepIndex),
+			zap.String("latestState", string(latestState)),
+			zap.String("txnHash", step.TxHash.String),
+			zap.String("chainID", step.ChainID),
+		)
+		return false, nil
+	}
+	if transferError != "" {
+		return false, e.failStep(ctx, transfer, step, transferError)
+	}
+
+	lmt.Logger(ctx).Info(
+		"rebalance route step completed successfully",
+		zap.Int64("id", transfer.ID),
+		zap.Int64("step", step.StepIndex),
+		zap.String("txnHash", step.TxHash.String),
+		zap.String("chainID", step.ChainID),
+	)
+	if _, err := e.database.SetRebalanceTransferStepStatus(ctx, db.SetRebalanceTransferStepStatusParams{
+		Status: dbtypes.RebalanceTransferStepStatusSuccess,
+		ID:     step.ID,
+	}); err != nil {
+		return false, fmt.Errorf("updating status of step %d of rebalance transfer %d to success: %w", step.StepIndex, transfer.ID, err)
+	}
+	return true, nil
+}
+
+// submitStep submits the tx of a pending step. Submission is retried on every
+// poll until it succeeds, or the step has been ready for longer than the
+// transfer timeout. remainingSteps is the number of steps of the route from
+// step on.
+func (e *RouteExecutor) submitStep(ctx context.Context, transfer db.RebalanceTransfer, step db.RebalanceTransferStep, remainingSteps int, readySince time.Time) error {
+	if time.Since(readySince) > transferTimeout {
+		return e.failStep(ctx, transfer, step, fmt.Sprintf("could not submit tx within %s", transferTimeout))
+	}
+
+	var txn skipgo.Tx
+	var amount *big.Int
+	if step.SourceDenom.Valid && step.DestinationDenom.Valid {
+		var err error
+		txn, amount, err = e.requoteStep(ctx, transfer, step, remainingSteps)
+		if err != nil {
+			return fmt.Errorf("requesting tx for step %d of rebalance transfer %d: %w", step.StepIndex, transfer.ID, err)
+		}
+	} else {
+		// the assets spent by the steps of the route were not recorded, so
+		// the tx quoted when the route started is submitted as is
+		if err := json.Unmarshal([]byte(step.Tx), &txn); err != nil {
+			return e.failStep(ctx, transfer, step, fmt.Sprintf("decoding step tx: %s", err))
+		}
+		var ok bool
+		amount, ok = new(big.Int).SetString(transfer.Amount, 10)
+		if !ok {
+			return fmt.Errorf("converting amount %s of rebalance transfer %d to *big.Int", transfer.Amount, transfer.ID)
+		}
+	}
+
+	if err := e.submitter.submitApproval(ctx, step.ChainID, txn, amount); err != nil {
+		return fmt.Errorf("approving step %d of rebalance transfer %d on chain %s: %w", step.StepIndex, transfer.ID, step.ChainID, err)
+	}
+
+	// the step is marked as submitting before its tx is broadcast, so that a
+	// tx that may have been broadcast is never submitted again if the solver
+	// stops before the tx hash is recorded. updated_at is left as is so that
+	// failed submissions do not extend the time the step has to be submitted.
+	txJSON, err := json.Marshal(txn)
+	if err != nil {
+		return fmt.Errorf("json encoding tx for step %d of rebalance transfer %d: %w", step.StepIndex, transfer.ID, err)
+	}
+	if _, err := e.database.SetRebalanceTransferStepSubmitting(ctx, db.SetRebalanceTransferStepSubmittingParams{
+		Tx: string(txJSON),
+		ID: step.ID,
+	}); err != nil {
+		return fmt.Errorf("updating status of step %d of rebalance transfer %d to submitting: %w", step.StepIndex, transfer.ID, err)
+	}
+
+	txHash, rawTx, err := e.submitter.SignAndSubmitTxn(ctx, SkipGoTxnWithMetadata{
+		tx:                 txn,
+		sourceChainID:      step.ChainID,
+		destinationChainID: transfer.DestinationChainID,
+		amount:             amount,
+	})
+	if err != nil {
+		// the tx was rejected, so the step can be submitted again
+		if _, resetErr := e.database.ResetRebalanceTransferStepSubmitting(ctx, db.ResetRebalanceTransferStepSubmittingParams{
+			StatusMessage: sql.NullString{String: err.Error(), Valid: true},
+			ID:            step.ID,
+		}); resetErr != nil {
+			return fmt.Errorf("resetting status of step %d of rebalance transfer %d to pending after submission failed with %s: %w", step.StepIndex, transfer.ID, err, resetErr)
+		}
+		return fmt.Errorf("submitting step %d of rebalance transfer %d on chain %s: %w", step.StepIndex, transfer.ID, step.ChainID, err)
+	}
+
+	if _, err := e.database.SetRebalanceTransferStepSubmitted(ctx, db.SetRebalanceTransferStepSubmittedParams{
+		TxHash: sql.NullString{String: string(txHash), Valid: true},
+		ID:     step.ID,
+	}); err != nil {
+		return fmt.Errorf("updating step %d of rebalance transfer %d with submitted tx hash %s: %w", step.StepIndex, transfer.ID, txHash, err)
+	}
+
+	if _, err := e.database.InsertSubmittedTx(ctx, db.InsertSubmittedTxParams{
+		RebalanceTransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+		ChainID:             step.ChainID,
+		TxHash:              string(txHash),
+		RawTx:               rawTx,
+		TxType:              dbtypes.TxTypeFundRebalnance,
+		TxStatus:            dbtypes.TxStatusPending,
+	}); err != nil {
+		return fmt.Errorf("inserting submitted tx for step %d of rebalance transfer %d with hash %s into db: %w", step.StepIndex, transfer.ID, txHash, err)
+	}
+
+	lmt.Logger(ctx).Info(
+		"submitted rebalance route step",
+		zap.Int64("id", transfer.ID),
+		zap.Int64("step", step.StepIndex),
+		zap.String("txnHash", string(txHash)),
+		zap.String("chainID", step.ChainID),
+	)
+	return nil
+}
+
+// requoteStep requests the tx of step from Skip Go for the amount that
+// actually arrived on the step's chain. The txs quoted when the route started
+// are not submitted, since their amounts assume the quoted fees and their
+// timeouts may have passed by the time the previous steps complete.
+func (e *RouteExecutor) requoteStep(ctx context.Context, transfer db.RebalanceTransfer, step db.RebalanceTransferStep, remainingSteps int) (skipgo.Tx, *big.Int, error) {
+	amount, err := e.submitter.tokenBalance(ctx, step.ChainID, step.SourceDenom.String)
+	if err != nil {
+		return skipgo.Tx{}, nil, fmt.Errorf("getting balance of %s on chain %s: %w", step.SourceDenom.String, step.ChainID, err)
+	}
+	if step.QuotedAmountIn.Valid {
+		// the solver's other funds on the step's chain are not moved with
+		// the route
+		quoted, ok := new(big.Int).SetString(step.QuotedAmountIn.String, 10)
+		if !ok {
+			return skipgo.Tx{}, nil, fmt.Errorf("converting quoted amount in %s to *big.Int", step.QuotedAmountIn.String)
+		}
+		if quoted.Cmp(amount) < 0 {
+			amount = quoted
+		}
+	}
+	if amount.Sign() <= 0 {
+		return skipgo.Tx{}, nil, fmt.Errorf("no %s has arrived on chain %s", step.SourceDenom.String, step.ChainID)
+	}
+
+	_, txns, err := e.submitter.skipGoRoute(ctx, amount, step.ChainID, step.SourceDenom.String, transfer.DestinationChainID, step.DestinationDenom.String)
+	if err != nil {
+		return skipgo.Tx{}, nil, err
+	}
+	// the rest of the route must keep the same shape, since later steps are
+	// requested again as they become ready
+	if len(txns) != remainingSteps {
+		return skipgo.Tx{}, nil, fmt.Errorf("expected %d txs to complete the route but Skip Go returned %d", remainingSteps, len(txns))
+	}
+	if chainID := txChainID(txns[0]); chainID != step.ChainID {
+		return skipgo.Tx{}, nil, fmt.Errorf("expected tx on chain %s but Skip Go returned a tx on chain %s", step.ChainID, chainID)
+	}
+	return txns[0], amount, nil
+}
+
+// failStep marks a step and the route it is part of as failed
+func (e *RouteExecutor) failStep(ctx context.Context, transfer db.RebalanceTransfer, step db.RebalanceTransferStep, reason string) error {
+	if _, err := e.database.SetRebalanceTransferStepStatus(ctx, db.SetRebalanceTransferStepStatusParams{
+		Status:        dbtypes.RebalanceTransferStepStatusFailed,
+		StatusMessage: sql.NullString{String: reason, Valid: true},
+		ID:            step.ID,
+	}); err != nil {
+		return fmt.Errorf("updating status of step %d of rebalance transfer %d to failed: %w", step.StepIndex, transfer.ID, err)
+	}
+	return e.failRoute(ctx, transfer, step, reason)
+}
+
+// failRoute marks a route whose step failed as failed. If the failed step is
+// not the first step of the route, the funds moved by the previous steps may
+// be stranded on the failed steps chain, so the route is marked as partially
+// failed in order to be recovered.
+func (e *RouteExecutor) failRoute(ctx context.Context, transfer db.RebalanceTransfer, step db.RebalanceTransferStep, reason string) error {
+	status := dbtypes.RebalanceTransferStatusFailed
+	if step.StepIndex > 0 {
+		status = dbtypes.RebalanceTransferStatusPartiallyFailed
+		lmt.Logger(ctx).Error(
+			"multi tx rebalance route partially failed, funds may be stranded on an intermediate chain",
+			zap.Int64("id", transfer.ID),
+			zap.Int64("failedStep", step.StepIndex),
+			zap.String("strandedChainID", step.ChainID),
+			zap.String("sourceChainID", transfer.SourceChainID),
+			zap.String("destinationChainID", transfer.DestinationChainID),
+			zap.String("amount", transfer.Amount),
+			zap.String("error", reason),
+		)
+	} else {
+		lmt.Logger(ctx).Info(
+			"rebalance transaction completed with an error",
+			zap.Int64("id", transfer.ID),
+			zap.String("txnHash", step.TxHash.String),
+			zap.String("sourceChainID", transfer.SourceChainID),
+			zap.String("destinationChainID", transfer.DestinationChainID),
+			zap.String("error", reason),
+		)
+	}
+	metrics.FromContext(ctx).IncFundsRebalanceTransferStatusChange(transfer.SourceChainID, transfer.DestinationChainID, status)
+
+	if err := e.database.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{
+		Status: status,
+		ID:     transfer.ID,
+	}); err != nil {
+		return fmt.Errorf("updating transfer status to %s for rebalance transfer %d: %w", status, transfer.ID, err)
+	}
+	return nil
+}
+
+// txChainID returns the chain a Skip Go tx is executed on
+func txChainID(txn skipgo.Tx) string {
+	switch {
+	case txn.EVMTx != nil:
+		return txn.EVMTx.ChainID
+	case txn.CosmosTx != nil:
+		return txn.CosmosTx.ChainID
+	case txn.SVMTx != nil:
+		return txn.SVMTx.ChainID
+	default:
+		return ""
+	}
+}
+
+// routeTxAsset returns the denom and amount spent by the tx at txIndex of
+// route, which are the denom and amount in of the first operation executed
+// by the tx
+func routeTxAsset(route *skipgo.RouteResponse, txIndex int) (denom string, amountIn string, ok bool) {
+	if route == nil {
+		return "", "", false
+	}
+	for _, operation := range route.Operations {
+		op, isMap := operation.(map[string]any)
+		if !isMap {
+			continue
+		}
+		// operations decoded from json have float64 indexes
+		var index int
+		switch opIndex := op["tx_index"].(type) {
+		case float64:
+			index = int(opIndex)
+		case int:
+			index = opIndex
+		default:
+			continue
+		}
+		if index != txIndex {
+			continue
+		}
+
+		amountIn, _ = op["amount_in"].(string)
+		for _, value := range op {
+			details, isMap := value.(map[string]any)
+			if !isMap {
+				continue
+			}
+			if denom, isString := details["denom_in"].(string); isString && denom != "" {
+				return denom, amountIn, true
+			}
+		}
+		return "", "", false
+	}
+	return "", "", false
+}
@@ -0,0 +1,268 @@
+package fundrebalancer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	mock_database "github.com/skip-mev/go-fast-solver/mocks/fundrebalancer"
+	mock_skipgo "github.com/skip-mev/go-fast-solver/mocks/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStepSubmitter records the txs submitted for rebalance route steps. The
+// txs of requoted steps are routes[sourceChainID].
+type fakeStepSubmitter struct {
+	approved  []string
+	submitted []SkipGoTxnWithMetadata
+	submitErr error
+
+	balances map[string]*big.Int
+	routes   map[string][]skipgo.Tx
+	quoted   []*big.Int
+}
+
+func (s *fakeStepSubmitter) submitApproval(ctx context.Context, chainID string, txn skipgo.Tx, amount *big.Int) error {
+	s.approved = append(s.approved, chainID)
+	return nil
+}
+
+func (s *fakeStepSubmitter) SignAndSubmitTxn(ctx context.Context, txn SkipGoTxnWithMetadata) (skipgo.TxHash, string, error) {
+	if s.submitErr != nil {
+		return "", "", s.submitErr
+	}
+	s.submitted = append(s.submitted, txn)
+	return skipgo.TxHash(fmt.Sprintf("hash%d", len(s.submitted)+1)), "rawTx", nil
+}
+
+func (s *fakeStepSubmitter) skipGoRoute(ctx context.Context, amount *big.Int, sourceChainID, sourceDenom, destChainID, destDenom string) (*skipgo.RouteResponse, []skipgo.Tx, error) {
+	s.quoted = append(s.quoted, amount)
+	return &skipgo.RouteResponse{}, s.routes[sourceChainID], nil
+}
+
+func (s *fakeStepSubmitter) tokenBalance(ctx context.Context, chainID, denom string) (*big.Int, error) {
+	balance, ok := s.balances[chainID+"/"+denom]
+	if !ok {
+		return big.NewInt(0), nil
+	}
+	return balance, nil
+}
+
+func newFakeStepSubmitter() *fakeStepSubmitter {
+	return &fakeStepSubmitter{
+		balances: map[string]*big.Int{nobleChainID + "/uusdc": big.NewInt(150)},
+		routes: map[string][]skipgo.Tx{
+			nobleChainID: {{CosmosTx: &skipgo.CosmosTx{ChainID: nobleChainID, Path: []string{nobleChainID, osmosisChainID}, SignerAddress: "requoted"}}},
+		},
+	}
+}
+
+func TestRouteExecutor_ExecuteInFlightRoutes(t *testing.T) {
+	ctx := context.Background()
+	route := []skipgo.Tx{
+		{EVMTx: &skipgo.EVMTx{ChainID: arbitrumChainID, To: "0x1", Data: "aa", Value: "0"}},
+		{CosmosTx: &skipgo.CosmosTx{ChainID: nobleChainID, Path: []string{nobleChainID, osmosisChainID}}},
+	}
+	skipGoRoute := &skipgo.RouteResponse{
+		DestAssetDenom: "uosmousdc",
+		Operations: []any{
+			map[string]any{"tx_index": float64(0), "amount_in": "100", "cctp_transfer": map[string]any{"denom_in": "arbusdc", "denom_out": "uusdc"}},
+			map[string]any{"tx_index": float64(1), "amount_in": "99", "transfer": map[string]any{"denom_in": "uusdc", "denom_out": "uosmousdc"}},
+		},
+	}
+	completed := &skipgo.StatusResponse{Transfers: []skipgo.Transfer{{State: skipgo.STATE_COMPLETED_SUCCESS}}}
+
+	insertRoute := func(t *testing.T, database *mock_database.FakeDatabase) int64 {
+		id, err := database.InsertRebalanceTransfer(ctx, db.InsertRebalanceTransferParams{
+			TxHash:             "hash1",
+			SourceChainID:      arbitrumChainID,
+			DestinationChainID: osmosisChainID,
+			Amount:             "100",
+		})
+		require.NoError(t, err)
+		require.NoError(t, insertRouteSteps(ctx, database, id, "hash1", skipGoRoute, route))
+		return id
+	}
+
+	t.Run("steps are submitted after the previous step completes", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		submitter := newFakeStepSubmitter()
+		executor := NewRouteExecutor(mockSkipGo, database, submitter)
+		insertRoute(t, database)
+
+		mockSkipGo.EXPECT().TrackTx(ctx, "hash1", arbitrumChainID).Return("hash1", nil)
+		mockSkipGo.EXPECT().Status(ctx, skipgo.TxHash("hash1"), arbitrumChainID).Return(completed, nil)
+
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		// the step is requested again for the amount that arrived on noble,
+		// capped at the quoted amount
+		require.Len(t, submitter.submitted, 1)
+		assert.Equal(t, nobleChainID, submitter.submitted[0].sourceChainID)
+		assert.Equal(t, osmosisChainID, submitter.submitted[0].destinationChainID)
+		assert.Equal(t, big.NewInt(99), submitter.submitted[0].amount)
+		assert.Equal(t, "requoted", submitter.submitted[0].tx.CosmosTx.SignerAddress)
+		assert.Equal(t, []*big.Int{big.NewInt(99)}, submitter.quoted)
+		assert.Equal(t, []string{nobleChainID}, submitter.approved)
+
+		steps := database.GetStepContents()
+		require.Len(t, steps, 2)
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusSuccess, steps[0].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusSubmitted, steps[1].Status)
+		assert.Equal(t, "hash2", steps[1].TxHash.String)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusPending, database.GetDBContents()[0].Status)
+
+		mockSkipGo.EXPECT().TrackTx(ctx, "hash2", nobleChainID).Return("hash2", nil)
+		mockSkipGo.EXPECT().Status(ctx, skipgo.TxHash("hash2"), nobleChainID).Return(completed, nil)
+
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusSuccess, steps[1].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusSuccess, database.GetDBContents()[0].Status)
+		assert.Len(t, submitter.submitted, 1)
+	})
+
+	t.Run("failed intermediate step marks route partially failed", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		submitter := newFakeStepSubmitter()
+		executor := NewRouteExecutor(mockSkipGo, database, submitter)
+		insertRoute(t, database)
+
+		mockSkipGo.EXPECT().TrackTx(ctx, "hash1", arbitrumChainID).Return("hash1", nil)
+		mockSkipGo.EXPECT().Status(ctx, skipgo.TxHash("hash1"), arbitrumChainID).Return(completed, nil)
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		transferError := "ibc transfer timed out"
+		mockSkipGo.EXPECT().TrackTx(ctx, "hash2", nobleChainID).Return("hash2", nil)
+		mockSkipGo.EXPECT().Status(ctx, skipgo.TxHash("hash2"), nobleChainID).Return(&skipgo.StatusResponse{
+			Transfers: []skipgo.Transfer{{State: skipgo.STATE_COMPLETED_ERROR, Error: &transferError}},
+		}, nil)
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		steps := database.GetStepContents()
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusFailed, steps[1].Status)
+		assert.Equal(t, transferError, steps[1].StatusMessage.String)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusPartiallyFailed, database.GetDBContents()[0].Status)
+	})
+
+	t.Run("failed first step marks route failed", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		submitter := newFakeStepSubmitter()
+		executor := NewRouteExecutor(mockSkipGo, database, submitter)
+		insertRoute(t, database)
+
+		transferError := "execution reverted"
+		mockSkipGo.EXPECT().TrackTx(ctx, "hash1", arbitrumChainID).Return("hash1", nil)
+		mockSkipGo.EXPECT().Status(ctx, skipgo.TxHash("hash1"), arbitrumChainID).Return(&skipgo.StatusResponse{
+			Transfers: []skipgo.Transfer{{State: skipgo.STATE_COMPLETED_ERROR, Error: &transferError}},
+		}, nil)
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		assert.Empty(t, submitter.submitted)
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusFailed, database.GetStepContents()[0].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusFailed, database.GetDBContents()[0].Status)
+	})
+
+	// completeFirstStep marks the first step of a route as completed
+	completeFirstStep := func(mockSkipGo *mock_skipgo.MockSkipGoClient) {
+		mockSkipGo.EXPECT().TrackTx(ctx, "hash1", arbitrumChainID).Return("hash1", nil).Once()
+		mockSkipGo.EXPECT().Status(ctx, skipgo.TxHash("hash1"), arbitrumChainID).Return(completed, nil).Once()
+	}
+
+	t.Run("step waits for funds to arrive on its chain", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		submitter := newFakeStepSubmitter()
+		submitter.balances = nil
+		executor := NewRouteExecutor(mockSkipGo, database, submitter)
+		insertRoute(t, database)
+		completeFirstStep(mockSkipGo)
+
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		assert.Empty(t, submitter.submitted)
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusPending, database.GetStepContents()[1].Status)
+	})
+
+	t.Run("requoted route with a different number of txs is not submitted", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		submitter := newFakeStepSubmitter()
+		submitter.routes[nobleChainID] = append(submitter.routes[nobleChainID], skipgo.Tx{CosmosTx: &skipgo.CosmosTx{ChainID: osmosisChainID}})
+		executor := NewRouteExecutor(mockSkipGo, database, submitter)
+		insertRoute(t, database)
+		completeFirstStep(mockSkipGo)
+
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		assert.Empty(t, submitter.submitted)
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusPending, database.GetStepContents()[1].Status)
+	})
+
+	t.Run("rejected step tx is submitted again", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		submitter := newFakeStepSubmitter()
+		submitter.submitErr = fmt.Errorf("insufficient fees")
+		executor := NewRouteExecutor(mockSkipGo, database, submitter)
+		insertRoute(t, database)
+		completeFirstStep(mockSkipGo)
+
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		step := database.GetStepContents()[1]
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusPending, step.Status)
+		assert.Equal(t, "insufficient fees", step.StatusMessage.String)
+
+		submitter.submitErr = nil
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		require.Len(t, submitter.submitted, 1)
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusSubmitted, step.Status)
+	})
+
+	t.Run("step left submitting is not submitted again", func(t *testing.T) {
+		database := mock_database.NewFakeDatabase()
+		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
+		submitter := newFakeStepSubmitter()
+		executor := NewRouteExecutor(mockSkipGo, database, submitter)
+		insertRoute(t, database)
+
+		// the solver stopped while the second step's tx was being submitted
+		steps := database.GetStepContents()
+		steps[0].Status = dbtypes.RebalanceTransferStepStatusSuccess
+		steps[1].Status = dbtypes.RebalanceTransferStepStatusSubmitting
+
+		require.NoError(t, executor.ExecuteInFlightRoutes(ctx))
+
+		assert.Empty(t, submitter.submitted)
+		assert.Equal(t, dbtypes.RebalanceTransferStepStatusFailed, steps[1].Status)
+		assert.Equal(t, dbtypes.RebalanceTransferStatusPartiallyFailed, database.GetDBContents()[0].Status)
+	})
+}
+
+func TestRouteTxAsset(t *testing.T) {
+	route := &skipgo.RouteResponse{Operations: []any{
+		map[string]any{"tx_index": float64(0), "amount_in": "100", "cctp_transfer": map[string]any{"denom_in": "arbusdc"}},
+		map[string]any{"tx_index": float64(1), "amount_in": "99", "transfer": map[string]any{"denom_in": "uusdc"}},
+		map[string]any{"tx_index": float64(1), "amount_in": "99", "swap": map[string]any{"denom_in": "ibc/usdc"}},
+	}}
+
+	denom, amountIn, ok := routeTxAsset(route, 1)
+	require.True(t, ok)
+	assert.Equal(t, "uusdc", denom)
+	assert.Equal(t, "99", amountIn)
+
+	_, _, ok = routeTxAsset(route, 2)
+	assert.False(t, ok)
+	_, _, ok = routeTxAsset(nil, 0)
+	assert.False(t, ok)
+}
@@ -0,0 +1,392 @@
+package fundrebalancer
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// rebalanceQuote is the estimated cost of moving amount usdc from a source
+// chain to the chain being rebalanced with a bridge
+type rebalanceQuote struct {
+	sourceChainID string
+	amount        *big.Int
+	bridge        rebalanceBridge
+
+	route           *skipgo.RouteResponse
+	txns            []skipgo.Tx
+	txnWithMetadata SkipGoTxnWithMetadata
+
+	// routeFeeUUSDC is the sum of the fees Skip Go estimates will be charged
+	// on top of the transferred usdc, e.g. smart relay fees paid in another
+	// asset
+	routeFeeUUSDC *big.Int
+	// bridgeFeeUUSDC is the difference between the amount of usdc sent and the
+	// amount received, i.e. the fees that are deducted from the transfer
+	bridgeFeeUUSDC    *big.Int
+	gasCostUUSDC      *big.Int
+	estimatedDuration time.Duration
+
+	// err is set if the source could not be quoted, and skipReason is set if
+	// the source was quoted but should not be rebalanced from
+	err        error
+	skipReason string
+}
+
+// totalCostUUSDC is the total estimated cost of moving the quoted amount
+func (q *rebalanceQuote) totalCostUUSDC() *big.Int {
+	total := new(big.Int).Add(q.routeFeeUUSDC, q.bridgeFeeUUSDC)
+	return total.Add(total, q.gasCostUUSDC)
+}
+
+// costUUSDC is the estimated cost of moving amount usdc using this quotes
+// route. Gas costs are assumed to be fixed, while fees are assumed to scale
+// with the amount moved.
+func (q *rebalanceQuote) costUUSDC(amount *big.Int) *big.Int {
+	fees := new(big.Int).Add(q.routeFeeUUSDC, q.bridgeFeeUUSDC)
+	fees.Mul(fees, amount)
+	fees.Div(fees, q.amount)
+	return fees.Add(fees, q.gasCostUUSDC)
+}
+
+func (q *rebalanceQuote) viable() bool {
+	return q.err == nil && q.skipReason == ""
+}
+
+// rebalanceAllocation is an amount of usdc to move from a quoted source chain
+type rebalanceAllocation struct {
+	quote  *rebalanceQuote
+	amount *big.Int
+}
+
+// quoteRebalanceSources quotes moving usdc to rebalanceToChainID from every
+// chain that has usdc to spare, with every bridge that supports the source
+// chain. Each source is quoted for the amount it can contribute towards
+// usdcNeeded, as reported by spareUSDC, and all quotes are requested in
+// parallel.
+func (r *FundRebalancer) quoteRebalanceSources(
+	ctx context.Context,
+	rebalanceToChainID string,
+	usdcNeeded *big.Int,
+	spareUSDC func(ctx context.Context, chainID string) (*big.Int, error),
+) ([]*rebalanceQuote, error) {
+	var quotes []*rebalanceQuote
+	for rebalanceFromChainID := range r.config {
+		if rebalanceFromChainID == rebalanceToChainID {
+			// do not try and rebalance funds from the same chain
+			continue
+		}
+
+		usdcToSpare, err := spareUSDC(ctx, rebalanceFromChainID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get amount of usdc to spare from chain %s: %w", rebalanceFromChainID, err)
+		}
+		if usdcToSpare.Cmp(big.NewInt(0)) == 0 {
+			continue
+		}
+
+		lmt.Logger(ctx).Debug(
+			fmt.Sprintf("chain %s has %s uusdc to space for rebalancing to chain %s", rebalanceFromChainID, usdcToSpare.String(), rebalanceToChainID),
+			zap.String("uusdcToSpare", usdcToSpare.String()),
+		)
+
+		amount := usdcToSpare
+		if amount.Cmp(usdcNeeded) > 0 {
+			// only quote the amount needed so we dont wipe out a chains funds
+			// uncessarily
+			amount = new(big.Int).Set(usdcNeeded)
+		}
+		for _, bridge := range r.bridges {
+			if !bridge.Supports(ctx, rebalanceFromChainID, rebalanceToChainID) {
+				continue
+			}
+			quotes = append(quotes, &rebalanceQuote{sourceChainID: rebalanceFromChainID, amount: amount, bridge: bridge})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, quote := range quotes {
+		wg.Add(1)
+		go func(quote *rebalanceQuote) {
+			defer wg.Done()
+			quote.err = quote.bridge.Quote(ctx, rebalanceToChainID, quote)
+		}(quote)
+	}
+	wg.Wait()
+
+	// gas costs are checked after quoting since the profitability failure
+	// tracking is not safe for concurrent use
+	quotesBySource := make(map[string][]*rebalanceQuote)
+	for _, quote := range quotes {
+		if quote.err == nil {
+			quotesBySource[quote.sourceChainID] = append(quotesBySource[quote.sourceChainID], quote)
+		}
+	}
+	for sourceChainID, sourceQuotes := range quotesBySource {
+		if err := r.checkRebalanceGasCosts(ctx, sourceChainID, sourceQuotes); err != nil {
+			return nil, err
+		}
+	}
+
+	return quotes, nil
+}
+
+// checkRebalanceGasCosts skips the quotes from sourceChainID whose gas cost is
+// not acceptable. Quotes are checked from cheapest to most expensive gas cost
+// so that the chains profitability failure tracking is only started once the
+// cheapest quote is too expensive.
+func (r *FundRebalancer) checkRebalanceGasCosts(ctx context.Context, sourceChainID string, quotes []*rebalanceQuote) error {
+	chainFundRebalancingConfig, err := config.GetConfigReader(ctx).GetFundRebalancingConfig(sourceChainID)
+	if err != nil {
+		return fmt.Errorf("getting fund rebalancer config for gas threshold check: %w", err)
+	}
+	if chainFundRebalancingConfig.MaxRebalancingGasCostUUSDC == "" {
+		return nil
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].gasCostUUSDC.Cmp(quotes[j].gasCostUUSDC) < 0
+	})
+	skipReason := fmt.Sprintf("gas cost above max rebalancing gas cost of %s uusdc", chainFundRebalancingConfig.MaxRebalancingGasCostUUSDC)
+	for i, quote := range quotes {
+		// note that for multi tx routes only the gas cost of the first tx is
+		// checked, since the later txs cannot be estimated until the
+		// transfers preceding them have completed
+		gasAcceptable, err := r.isGasCostAcceptable(ctx, quote.gasCostUUSDC, sourceChainID)
+		if err != nil {
+			return fmt.Errorf("checking if total rebalancing gas cost is acceptable on chain %s: %w", sourceChainID, err)
+		}
+		if !gasAcceptable {
+			// quotes with a higher gas cost are not acceptable either
+			for _, expensive := range quotes[i:] {
+				expensive.skipReason = skipReason
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// quoteRebalanceSource gets the route, fees, gas cost and expected arrival
+// time of moving quote.amount usdc from quote.sourceChainID
+func (r *FundRebalancer) quoteRebalanceSource(ctx context.Context, rebalanceToChainID string, quote *rebalanceQuote) error {
+	route, txns, err := r.rebalanceRoute(ctx, quote.amount, quote.sourceChainID, rebalanceToChainID)
+	if err != nil {
+		return fmt.Errorf("getting txns required for fund rebalancing %s uusdc from chain %s to chain %s: %w", quote.amount.String(), quote.sourceChainID, rebalanceToChainID, err)
+	}
+	if len(txns) == 0 {
+		return fmt.Errorf("no txns returned to rebalance %s uusdc from chain %s to chain %s", quote.amount.String(), quote.sourceChainID, rebalanceToChainID)
+	}
+
+	txnWithMetadata, err := r.TxnWithMetadata(ctx, quote.sourceChainID, rebalanceToChainID, quote.amount, txns[0])
+	if err != nil {
+		return fmt.Errorf("getting transaction metadata to rebalance funds from chain %s: %w", quote.sourceChainID, err)
+	}
+
+	gasCostUUSDC, err := r.gasCostUUSDC(ctx, txnWithMetadata, quote.sourceChainID)
+	if err != nil {
+		return fmt.Errorf("calculating total fund rebalancing gas cost in UUSDC: %w", err)
+	}
+
+	usdcDenom, err := config.GetConfigReader(ctx).GetUSDCDenom(quote.sourceChainID)
+	if err != nil {
+		return fmt.Errorf("getting usdc denom for chain %s: %w", quote.sourceChainID, err)
+	}
+	routeFeeUUSDC, err := routeFeeUUSDC(route, usdcDenom)
+	if err != nil {
+		return fmt.Errorf("calculating route fees from chain %s: %w", quote.sourceChainID, err)
+	}
+
+	amountOut, ok := new(big.Int).SetString(route.AmountOut, 10)
+	if !ok {
+		return fmt.Errorf("converting amount out %s to *big.Int", route.AmountOut)
+	}
+	bridgeFeeUUSDC := new(big.Int).Sub(quote.amount, amountOut)
+	if bridgeFeeUUSDC.Sign() < 0 {
+		bridgeFeeUUSDC = big.NewInt(0)
+	}
+
+	quote.route = route
+	quote.txns = txns
+	quote.txnWithMetadata = txnWithMetadata
+	quote.routeFeeUUSDC = routeFeeUUSDC
+	quote.bridgeFeeUUSDC = bridgeFeeUUSDC
+	quote.gasCostUUSDC = gasCostUUSDC
+	quote.estimatedDuration = time.Duration(route.EstimatedRouteDurationSeconds) * time.Second
+	return nil
+}
+
+// routeFeeUUSDC sums the usd value of the fees Skip Go estimates for a route
+// that are not paid in usdcDenom. Fees paid in usdcDenom are deducted from
+// the transferred amount and are therefore already reflected in the routes
+// amount out.
+func routeFeeUUSDC(route *skipgo.RouteResponse, usdcDenom string) (*big.Int, error) {
+	total := big.NewInt(0)
+	for _, fee := range route.EstimatedFees {
+		if fee.OriginAsset.Denom == usdcDenom || fee.USDAmount == "" {
+			continue
+		}
+		usdAmount, err := math.LegacyNewDecFromStr(fee.USDAmount)
+		if err != nil {
+			return nil, fmt.Errorf("parsing usd amount %s of %s fee: %w", fee.USDAmount, fee.FeeType, err)
+		}
+		total.Add(total, usdAmount.MulInt64(1_000_000).Ceil().TruncateInt().BigInt())
+	}
+	return total, nil
+}
+
+// cheaperThan returns true if moving usdc with q costs less per uusdc than
+// with other, preferring the faster quote between quotes of equal cost
+func (q *rebalanceQuote) cheaperThan(other *rebalanceQuote) bool {
+	// compare cost per uusdc without losing precision, i.e.
+	// cost_q / amount_q < cost_other / amount_other
+	cost := new(big.Int).Mul(q.totalCostUUSDC(), other.amount)
+	otherCost := new(big.Int).Mul(other.totalCostUUSDC(), q.amount)
+	if cmp := cost.Cmp(otherCost); cmp != 0 {
+		return cmp < 0
+	}
+	if q.estimatedDuration != other.estimatedDuration {
+		return q.estimatedDuration < other.estimatedDuration
+	}
+	return q.sourceChainID < other.sourceChainID
+}
+
+// selectRebalanceSources chooses how much usdc to move from each quoted source
+// to cover usdcNeeded as cheaply as possible. Only the cheapest bridge quoted
+// for each source is used. Sources are filled in order of their cost per uusdc
+// moved, with faster routes preferred between sources of equal cost, unless
+// moving everything from a single source is cheaper than splitting the amount
+// across sources. If the quoted sources cannot cover usdcNeeded, all of their
+// usdc is moved.
+func selectRebalanceSources(quotes []*rebalanceQuote, usdcNeeded *big.Int) []rebalanceAllocation {
+	cheapestBySource := make(map[string]*rebalanceQuote)
+	for _, quote := range quotes {
+		if !quote.viable() {
+			continue
+		}
+		if cheapest, ok := cheapestBySource[quote.sourceChainID]; !ok || quote.cheaperThan(cheapest) {
+			cheapestBySource[quote.sourceChainID] = quote
+		}
+	}
+	viable := make([]*rebalanceQuote, 0, len(cheapestBySource))
+	for _, quote := range cheapestBySource {
+		viable = append(viable, quote)
+	}
+	sort.Slice(viable, func(i, j int) bool {
+		return viable[i].cheaperThan(viable[j])
+	})
+
+	var split []rebalanceAllocation
+	splitCost := big.NewInt(0)
+	remaining := new(big.Int).Set(usdcNeeded)
+	for _, quote := range viable {
+		if remaining.Sign() <= 0 {
+			break
+		}
+		amount := quote.amount
+		if amount.Cmp(remaining) > 0 {
+			amount = new(big.Int).Set(remaining)
+		}
+		split = append(split, rebalanceAllocation{quote: quote, amount: amount})
+		splitCost.Add(splitCost, quote.costUUSDC(amount))
+		remaining.Sub(remaining, amount)
+	}
+	if len(split) <= 1 {
+		return split
+	}
+
+	// splitting pays the fixed gas cost of every source, so check if a single
+	// source that can cover the full amount is cheaper
+	var single *rebalanceQuote
+	for _, quote := range viable {
+		if quote.amount.Cmp(usdcNeeded) < 0 {
+			continue
+		}
+		if single == nil || quote.totalCostUUSDC().Cmp(single.totalCostUUSDC()) < 0 {
+			single = quote
+		}
+	}
+	if single != nil && single.totalCostUUSDC().Cmp(splitCost) < 0 {
+		return []rebalanceAllocation{{quote: single, amount: new(big.Int).Set(usdcNeeded)}}
+	}
+	return split
+}
+
+// rebalanceDecisionRow is a row of the decision matrix logged for a rebalance
+type rebalanceDecisionRow struct {
+	SourceChainID     string `json:"sourceChainID"`
+	Bridge            string `json:"bridge"`
+	QuotedAmountUUSDC string `json:"quotedAmountUUSDC"`
+	RouteFeeUUSDC     string `json:"routeFeeUUSDC,omitempty"`
+	BridgeFeeUUSDC    string `json:"bridgeFeeUUSDC,omitempty"`
+	GasCostUUSDC      string `json:"gasCostUUSDC,omitempty"`
+	TotalCostUUSDC    string `json:"totalCostUUSDC,omitempty"`
+	EstimatedDuration string `json:"estimatedDuration,omitempty"`
+	SelectedUUSDC     string `json:"selectedUUSDC"`
+	Skipped           string `json:"skipped,omitempty"`
+}
+
+// logRebalanceDecision logs every quoted source and bridge of a rebalance
+// along with the amount selected to be moved with it
+func logRebalanceDecision(
+	ctx context.Context,
+	rebalanceToChainID string,
+	usdcNeeded *big.Int,
+	quotes []*rebalanceQuote,
+	allocations []rebalanceAllocation,
+) {
+	selected := make(map[*rebalanceQuote]*big.Int)
+	for _, allocation := range allocations {
+		selected[allocation.quote] = allocation.amount
+	}
+
+	rows := make([]rebalanceDecisionRow, 0, len(quotes))
+	for _, quote := range quotes {
+		row := rebalanceDecisionRow{
+			SourceChainID:     quote.sourceChainID,
+			QuotedAmountUUSDC: quote.amount.String(),
+			SelectedUUSDC:     "0",
+		}
+		if quote.bridge != nil {
+			row.Bridge = quote.bridge.Name()
+		}
+		if amount, ok := selected[quote]; ok {
+			row.SelectedUUSDC = amount.String()
+		}
+		switch {
+		case quote.err != nil:
+			row.Skipped = quote.err.Error()
+		default:
+			row.RouteFeeUUSDC = quote.routeFeeUUSDC.String()
+			row.BridgeFeeUUSDC = quote.bridgeFeeUUSDC.String()
+			row.GasCostUUSDC = quote.gasCostUUSDC.String()
+			row.TotalCostUUSDC = quote.totalCostUUSDC().String()
+			row.EstimatedDuration = quote.estimatedDuration.String()
+			row.Skipped = quote.skipReason
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].SourceChainID != rows[j].SourceChainID {
+			return rows[i].SourceChainID < rows[j].SourceChainID
+		}
+		return rows[i].Bridge < rows[j].Bridge
+	})
+
+	lmt.Logger(ctx).Info(
+		"rebalance source selection",
+		zap.String("destinationChainID", rebalanceToChainID),
+		zap.String("usdcNeeded", usdcNeeded.String()),
+		zap.Int("sourcesSelected", len(allocations)),
+		zap.Any("decisionMatrix", rows),
+	)
+}
@@ -0,0 +1,118 @@
+package fundrebalancer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQuote(chainID string, amount, fees, gas int64, duration time.Duration) *rebalanceQuote {
+	return &rebalanceQuote{
+		sourceChainID:     chainID,
+		amount:            big.NewInt(amount),
+		routeFeeUUSDC:     big.NewInt(0),
+		bridgeFeeUUSDC:    big.NewInt(fees),
+		gasCostUUSDC:      big.NewInt(gas),
+		estimatedDuration: duration,
+	}
+}
+
+func allocatedAmounts(allocations []rebalanceAllocation) map[string]int64 {
+	amounts := make(map[string]int64)
+	for _, allocation := range allocations {
+		amounts[allocation.quote.sourceChainID] = allocation.amount.Int64()
+	}
+	return amounts
+}
+
+func TestSelectRebalanceSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		quotes   []*rebalanceQuote
+		needed   int64
+		expected map[string]int64
+	}{
+		{
+			name: "cheapest source covering the full amount is chosen",
+			quotes: []*rebalanceQuote{
+				newTestQuote(ethChainID, 100, 0, 500, time.Minute),
+				newTestQuote(arbitrumChainID, 100, 0, 10, time.Minute),
+			},
+			needed:   100,
+			expected: map[string]int64{arbitrumChainID: 100},
+		},
+		{
+			name: "amount is split across sources when none can cover it",
+			quotes: []*rebalanceQuote{
+				newTestQuote(ethChainID, 75, 0, 50, time.Minute),
+				newTestQuote(arbitrumChainID, 40, 0, 10, time.Minute),
+			},
+			needed:   100,
+			expected: map[string]int64{arbitrumChainID: 40, ethChainID: 60},
+		},
+		{
+			name: "single source is preferred over a split that costs more",
+			quotes: []*rebalanceQuote{
+				// cheapest per uusdc, but cannot cover the full amount
+				newTestQuote(arbitrumChainID, 50, 0, 10, time.Minute),
+				newTestQuote(ethChainID, 100, 0, 30, time.Minute),
+			},
+			needed:   100,
+			expected: map[string]int64{ethChainID: 100},
+		},
+		{
+			name: "faster source is preferred between sources of equal cost",
+			quotes: []*rebalanceQuote{
+				newTestQuote(ethChainID, 100, 5, 5, 20*time.Minute),
+				newTestQuote(arbitrumChainID, 100, 5, 5, time.Minute),
+			},
+			needed:   100,
+			expected: map[string]int64{arbitrumChainID: 100},
+		},
+		{
+			name: "sources that failed to quote or were skipped are not used",
+			quotes: []*rebalanceQuote{
+				{sourceChainID: ethChainID, amount: big.NewInt(100), err: errors.New("no route found")},
+				func() *rebalanceQuote {
+					quote := newTestQuote(osmosisChainID, 100, 0, 1, time.Minute)
+					quote.skipReason = "gas cost above max rebalancing gas cost"
+					return quote
+				}(),
+				newTestQuote(arbitrumChainID, 100, 0, 100, time.Minute),
+			},
+			needed:   100,
+			expected: map[string]int64{arbitrumChainID: 100},
+		},
+		{
+			name:     "no viable sources",
+			quotes:   []*rebalanceQuote{{sourceChainID: ethChainID, amount: big.NewInt(100), err: errors.New("no route found")}},
+			needed:   100,
+			expected: map[string]int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocations := selectRebalanceSources(tt.quotes, big.NewInt(tt.needed))
+			assert.Equal(t, tt.expected, allocatedAmounts(allocations))
+		})
+	}
+}
+
+func TestRouteFeeUUSDC(t *testing.T) {
+	route := &skipgo.RouteResponse{
+		EstimatedFees: []skipgo.EstimatedFee{
+			// deducted from the transferred usdc, already reflected in amount out
+			{FeeType: "SMART_RELAY", USDAmount: "0.25", OriginAsset: skipgo.FeeAsset{Denom: arbitrumUSDCDenom}},
+			{FeeType: "SMART_RELAY", USDAmount: "1.5000001", OriginAsset: skipgo.FeeAsset{Denom: "uosmo"}},
+		},
+	}
+
+	fee, err := routeFeeUUSDC(route, arbitrumUSDCDenom)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1500001), fee)
+}
@@ -0,0 +1,125 @@
+package fundrebalancer
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	computebudget "github.com/gagliardetto/solana-go/programs/compute-budget"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+)
+
+const (
+	svmLamportsPerSignature    = 5000
+	svmDefaultComputeUnitLimit = 200_000
+	// svmMessageSentEventRentLamports is the rent paid for the account that a
+	// cctp burn on an svm chain stores its message in. The account holds an 8
+	// byte discriminator, the 32 byte rent payer and the 4 byte length
+	// prefixed 248 byte burn message, and rent exemption costs 6960 lamports
+	// per byte of the account plus 128 bytes of account overhead. The rent
+	// is not reclaimed once the message is received, so it is part of the
+	// cost of burning.
+	svmMessageSentEventRentLamports = (128 + 8 + 32 + 4 + 248) * 6960
+)
+
+// svmUSDCTokenAccount gets the solvers usdc token account on an svm chain,
+// along with the solvers wallet and the usdc mint
+func svmUSDCTokenAccount(chainConfig config.ChainConfig) (owner solana.PublicKey, mint solana.PublicKey, tokenAccount solana.PublicKey, err error) {
+	owner, err = solana.PublicKeyFromBase58(chainConfig.SolverAddress)
+	if err != nil {
+		return owner, mint, tokenAccount, fmt.Errorf("decoding solver address %s: %w", chainConfig.SolverAddress, err)
+	}
+	mint, err = solana.PublicKeyFromBase58(chainConfig.USDCDenom)
+	if err != nil {
+		return owner, mint, tokenAccount, fmt.Errorf("decoding usdc mint %s: %w", chainConfig.USDCDenom, err)
+	}
+	tokenAccount, _, err = solana.FindAssociatedTokenAddress(owner, mint)
+	if err != nil {
+		return owner, mint, tokenAccount, fmt.Errorf("finding usdc token account of %s: %w", chainConfig.SolverAddress, err)
+	}
+	return owner, mint, tokenAccount, nil
+}
+
+// svmCCTPPrograms gets the cctp programs configured on an svm chain
+func svmCCTPPrograms(chainConfig config.ChainConfig) (tokenMessengerMinter solana.PublicKey, messageTransmitter solana.PublicKey, err error) {
+	if chainConfig.CCTP == nil {
+		return tokenMessengerMinter, messageTransmitter, fmt.Errorf("cctp is not configured on chain %s", chainConfig.ChainID)
+	}
+	tokenMessengerMinter, err = solana.PublicKeyFromBase58(chainConfig.CCTP.TokenMessengerAddress)
+	if err != nil {
+		return tokenMessengerMinter, messageTransmitter, fmt.Errorf("decoding token messenger minter program %s: %w", chainConfig.CCTP.TokenMessengerAddress, err)
+	}
+	messageTransmitter, err = solana.PublicKeyFromBase58(chainConfig.CCTP.MessageTransmitterAddress)
+	if err != nil {
+		return tokenMessengerMinter, messageTransmitter, fmt.Errorf("decoding message transmitter program %s: %w", chainConfig.CCTP.MessageTransmitterAddress, err)
+	}
+	return tokenMessengerMinter, messageTransmitter, nil
+}
+
+// svmTx wraps instructions in an unsigned tx paid for by the solver, in the
+// same form that Skip Go returns svm txs in. The chains configured priority
+// fee is added to the tx, and its blockhash is set when it is submitted.
+func svmTx(chainConfig config.ChainConfig, instructions []solana.Instruction, additionalSigners ...solana.PrivateKey) (skipgo.Tx, error) {
+	if chainConfig.SVM == nil {
+		return skipgo.Tx{}, fmt.Errorf("svm chain config is null for chain id %s", chainConfig.ChainID)
+	}
+	payer, err := solana.PublicKeyFromBase58(chainConfig.SolverAddress)
+	if err != nil {
+		return skipgo.Tx{}, fmt.Errorf("decoding solver address %s: %w", chainConfig.SolverAddress, err)
+	}
+	if price := chainConfig.SVM.ComputeUnitPriceMicroLamports; price > 0 {
+		instructions = append([]solana.Instruction{
+			computebudget.NewSetComputeUnitLimitInstruction(svmComputeUnitLimit(chainConfig.SVM)).Build(),
+			computebudget.NewSetComputeUnitPriceInstruction(price).Build(),
+		}, instructions...)
+	}
+
+	tx, err := solana.NewTransaction(instructions, solana.Hash{}, solana.TransactionPayer(payer))
+	if err != nil {
+		return skipgo.Tx{}, fmt.Errorf("building tx: %w", err)
+	}
+	txB64, err := tx.ToBase64()
+	if err != nil {
+		return skipgo.Tx{}, fmt.Errorf("encoding tx: %w", err)
+	}
+	return skipgo.Tx{SVMTx: &skipgo.SVMTx{
+		ChainID:           chainConfig.ChainID,
+		Tx:                txB64,
+		SignerAddress:     chainConfig.SolverAddress,
+		AdditionalSigners: additionalSigners,
+	}}, nil
+}
+
+// decodeSVMTx decodes the base64 encoded tx of a Skip Go svm tx
+func decodeSVMTx(svmTx *skipgo.SVMTx) (*solana.Transaction, error) {
+	txBytes, err := base64.StdEncoding.DecodeString(svmTx.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decoding svm tx: %w", err)
+	}
+	tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(txBytes))
+	if err != nil {
+		return nil, fmt.Errorf("decoding svm tx: %w", err)
+	}
+	return tx, nil
+}
+
+// svmTxFeeLamports estimates the fee in lamports of submitting tx, which is
+// the base fee per signature plus the priority fee for the txs compute unit
+// limit
+func svmTxFeeLamports(svmConfig *config.SVMConfig, tx *solana.Transaction) uint64 {
+	fee := uint64(tx.Message.Header.NumRequiredSignatures) * svmLamportsPerSignature
+	if svmConfig.ComputeUnitPriceMicroLamports > 0 {
+		priorityFeeMicroLamports := svmConfig.ComputeUnitPriceMicroLamports * uint64(svmComputeUnitLimit(svmConfig))
+		fee += (priorityFeeMicroLamports + 999_999) / 1_000_000
+	}
+	return fee
+}
+
+func svmComputeUnitLimit(svmConfig *config.SVMConfig) uint32 {
+	if svmConfig.ComputeUnitLimit == 0 {
+		return svmDefaultComputeUnitLimit
+	}
+	return svmConfig.ComputeUnitLimit
+}
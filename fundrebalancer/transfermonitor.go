@@ -57,7 +57,37 @@ func (t *TransferTracker) UpdateTransfers(ctx context.Context) error {
 		return fmt.Errorf("getting all pending rebalance transfers: %w", err)
 	}
 
+	// multi tx routes are tracked step by step by the route executor
+	multiTxTransfers, err := t.database.GetPendingRebalanceTransfersWithSteps(ctx)
+	if err != nil {
+		return fmt.Errorf("getting pending multi tx rebalance transfers: %w", err)
+	}
+	// cctp transfers are tracked by the cctp bridge, since Skip Go does not
+	// know about burns that were not submitted through its api
+	cctpTransfers, err := t.database.GetPendingCCTPTransfers(ctx)
+	if err != nil {
+		return fmt.Errorf("getting pending cctp transfers: %w", err)
+	}
+	// ibc transfers are tracked by the ibc bridge for the same reason
+	ibcTransfers, err := t.database.GetPendingIBCTransfers(ctx)
+	if err != nil {
+		return fmt.Errorf("getting pending ibc transfers: %w", err)
+	}
+	trackedElsewhere := make(map[int64]bool)
+	for _, transfer := range multiTxTransfers {
+		trackedElsewhere[transfer.ID] = true
+	}
+	for _, transfer := range cctpTransfers {
+		trackedElsewhere[transfer.RebalanceTransferID] = true
+	}
+	for _, transfer := range ibcTransfers {
+		trackedElsewhere[transfer.RebalanceTransferID] = true
+	}
+
 	for _, pendingTransfer := range pendingTransfers {
+		if trackedElsewhere[pendingTransfer.ID] {
+			continue
+		}
 		err := t.updateTransferStatus(ctx, pendingTransfer.ID, pendingTransfer.CreatedAt, pendingTransfer.TxHash, pendingTransfer.SourceChainID, pendingTransfer.DestinationChainID)
 		if err != nil {
 			lmt.Logger(ctx).Error(
@@ -87,25 +117,9 @@ func (t *TransferTracker) updateTransferStatus(ctx context.Context, transferID i
 		return nil
 	}
 
-	txHash, err := t.skipgo.TrackTx(ctx, hash, sourceChainID)
+	allTransfersDone, latestState, transferError, err := transferResult(ctx, t.skipgo, hash, sourceChainID)
 	if err != nil {
-		return fmt.Errorf("failed to track transaction %s on chain %s: %w", hash, sourceChainID, err)
-	}
-
-	currentStatus, err := t.skipgo.Status(ctx, txHash, sourceChainID)
-	if err != nil {
-		return fmt.Errorf("getting status for transaction %s on chain %s: %w", hash, sourceChainID, err)
-	}
-
-	// check if all transfers in the status are done
-	allTransfersDone := true
-	var latestState skipgo.TransactionState
-	for _, transfer := range currentStatus.Transfers {
-		if !transfer.State.IsCompleted() {
-			allTransfersDone = false
-			latestState = transfer.State
-			break
-		}
+		return err
 	}
 
 	if !allTransfersDone {
@@ -119,19 +133,6 @@ func (t *TransferTracker) updateTransferStatus(ctx context.Context, transferID i
 		return nil
 	}
 
-	// all transfers have finished, grab the first error if any
-	var transferError string
-	for _, transfer := range currentStatus.Transfers {
-		// report the first error that occurred, if any
-		if transfer.State.IsCompletedError() {
-			if transfer.Error != nil {
-				transferError = *transfer.Error
-			} else {
-				transferError = "error occurred during transfer but reason could not be found. state is " + string(transfer.State)
-			}
-		}
-	}
-
 	if transferError != "" {
 		lmt.Logger(ctx).Info(
 			"rebalance transaction completed with an error",
@@ -171,3 +172,44 @@ func (t *TransferTracker) updateTransferStatus(ctx context.Context, transferID i
 
 	return nil
 }
+
+// transferResult gets the result of the Skip Go transfer started by the tx
+// with hash on chainID. done is false while the transfer is in flight, in
+// which case latestState is its current state. transferError is set if the
+// transfer completed with an error.
+func transferResult(
+	ctx context.Context,
+	skipgoClient skipgo.SkipGoClient,
+	hash string,
+	chainID string,
+) (done bool, latestState skipgo.TransactionState, transferError string, err error) {
+	txHash, err := skipgoClient.TrackTx(ctx, hash, chainID)
+	if err != nil {
+		return false, "", "", fmt.Errorf("failed to track transaction %s on chain %s: %w", hash, chainID, err)
+	}
+
+	currentStatus, err := skipgoClient.Status(ctx, txHash, chainID)
+	if err != nil {
+		return false, "", "", fmt.Errorf("getting status for transaction %s on chain %s: %w", hash, chainID, err)
+	}
+
+	// check if all transfers in the status are done
+	for _, transfer := range currentStatus.Transfers {
+		if !transfer.State.IsCompleted() {
+			return false, transfer.State, "", nil
+		}
+	}
+
+	// all transfers have finished, grab the first error if any
+	for _, transfer := range currentStatus.Transfers {
+		// report the first error that occurred, if any
+		if transfer.State.IsCompletedError() {
+			if transfer.Error != nil {
+				return true, "", *transfer.Error, nil
+			}
+			return true, "", "error occurred during transfer but reason could not be found. state is " + string(transfer.State), nil
+		}
+	}
+
+	return true, "", "", nil
+}
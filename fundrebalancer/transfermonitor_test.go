@@ -13,6 +13,7 @@ import (
 	mock_config "github.com/skip-mev/go-fast-solver/mocks/shared/config"
 	mock_evmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/evmrpc"
 	mock_oracle "github.com/skip-mev/go-fast-solver/mocks/shared/oracle"
+	mock_cosmos "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/cosmos"
 	evm2 "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/evm"
 	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
 	"github.com/skip-mev/go-fast-solver/shared/config"
@@ -31,6 +32,10 @@ func TestTransferMonitor_UpdateTransfers(t *testing.T) {
 
 		ctx = config.ConfigReaderContext(ctx, mockConfigReader)
 
+		mockDatabase.EXPECT().GetPendingRebalanceTransfersWithSteps(ctx).Return(nil, nil)
+		mockDatabase.EXPECT().GetPendingCCTPTransfers(ctx).Return(nil, nil)
+		mockDatabase.EXPECT().GetPendingIBCTransfers(ctx).Return(nil, nil)
+
 		// two osmosis pending tx's, one will fail and another will complete successfully
 		mockDatabase.EXPECT().GetAllPendingRebalanceTransfers(ctx).Return([]db.GetAllPendingRebalanceTransfersRow{
 			{ID: 1, TxHash: "hash", SourceChainID: arbitrumChainID, DestinationChainID: osmosisChainID, Amount: strconv.Itoa(osmosisTargetAmount), CreatedAt: time.Now()},
@@ -76,6 +81,10 @@ func TestTransferMonitor_UpdateTransfers(t *testing.T) {
 		mockSkipGo := mock_skipgo.NewMockSkipGoClient(t)
 		mockDatabse := mock_database.NewMockDatabase(t)
 
+		mockDatabse.EXPECT().GetPendingRebalanceTransfersWithSteps(mockContext).Return(nil, nil)
+		mockDatabse.EXPECT().GetPendingCCTPTransfers(mockContext).Return(nil, nil)
+		mockDatabse.EXPECT().GetPendingIBCTransfers(mockContext).Return(nil, nil)
+
 		// two osmosis pending tx's, one will fail and another will complete successfully
 		mockDatabse.EXPECT().GetAllPendingRebalanceTransfers(mockContext).Return([]db.GetAllPendingRebalanceTransfersRow{
 			{ID: 1, TxHash: "hash", SourceChainID: arbitrumChainID, DestinationChainID: osmosisChainID, Amount: strconv.Itoa(osmosisTargetAmount), CreatedAt: time.Now()},
@@ -176,12 +185,13 @@ func TestFundRebalancer_RebalanceWithAbandonedTransfer(t *testing.T) {
 	mockEVMClientManager.EXPECT().GetClient(mock.Anything, arbitrumChainID).Return(mockEVMClient, nil)
 	fakeDatabase := mock_database.NewFakeDatabase()
 	mockEVMTxExecutor := evm2.NewMockEVMTxExecutor(t)
+	mockCosmosTxExecutor := mock_cosmos.NewMockCosmosTxExecutor(t)
 	mockTxPriceOracle := mock_oracle.NewMockTxPriceOracle(t)
 	mockTxPriceOracle.On("TxFeeUUSDC", mock.Anything, mock.Anything, mock.Anything).Return(big.NewInt(75), nil)
 	keystore, err := keys.LoadKeyStoreFromPlaintextFile(f.Name())
 	assert.NoError(t, err)
 
-	rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, fakeDatabase, mockTxPriceOracle, mockEVMTxExecutor)
+	rebalancer, err := NewFundRebalancer(ctx, keystore, mockSkipGo, mockEVMClientManager, fakeDatabase, mockTxPriceOracle, mockEVMTxExecutor, mockCosmosTxExecutor)
 	assert.NoError(t, err)
 
 	// Insert an old pending transfer that should be abandoned
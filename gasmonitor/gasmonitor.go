@@ -2,9 +2,12 @@ package gasmonitor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/skip-mev/go-fast-solver/fundrebalancer"
 	"github.com/skip-mev/go-fast-solver/shared/bridges/cctp"
 	"github.com/skip-mev/go-fast-solver/shared/clientmanager"
+	"github.com/skip-mev/go-fast-solver/shared/clients/skipgo"
 	"github.com/skip-mev/go-fast-solver/shared/metrics"
 	"go.uber.org/zap"
 	"time"
@@ -13,13 +16,23 @@ import (
 	"github.com/skip-mev/go-fast-solver/shared/lmt"
 )
 
+// GasTopUpper tops up the solvers gas balance on a chain
+type GasTopUpper interface {
+	TopUpGas(ctx context.Context, chainID string) (skipgo.TxHash, error)
+}
+
 type GasMonitor struct {
 	clientManager *clientmanager.ClientManager
+	gasTopUpper   GasTopUpper
 }
 
-func NewGasMonitor(clientManager *clientmanager.ClientManager) *GasMonitor {
+// NewGasMonitor creates a gas monitor that tops up the solvers gas balance on
+// chains whose balance drops below the warning threshold with gasTopUpper.
+// gasTopUpper may be nil, in which case gas balances are only monitored.
+func NewGasMonitor(clientManager *clientmanager.ClientManager, gasTopUpper GasTopUpper) *GasMonitor {
 	return &GasMonitor{
 		clientManager: clientManager,
+		gasTopUpper:   gasTopUpper,
 	}
 }
 
@@ -34,8 +47,13 @@ func (gm *GasMonitor) Start(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("error getting cosmos chains: %w", err)
 	}
+	svmChains, err := config.GetConfigReader(ctx).GetAllChainConfigsOfType(config.ChainType_SVM)
+	if err != nil {
+		return fmt.Errorf("error getting svm chains: %w", err)
+	}
 	chains = append(chains, evmChains...)
 	chains = append(chains, cosmosChains...)
+	chains = append(chains, svmChains...)
 
 	ticker := time.NewTicker(1 * time.Minute)
 	for {
@@ -48,37 +66,62 @@ func (gm *GasMonitor) Start(ctx context.Context) error {
 				if err != nil {
 					return err
 				}
-				err = monitorGasBalance(ctx, chain.ChainID, client)
+				belowWarning, err := monitorGasBalance(ctx, chain.ChainID, client)
 				if err != nil {
 					lmt.Logger(ctx).Error("failed to monitor gas balance", zap.String("chain_id", chain.ChainID), zap.Error(err))
+					continue
+				}
+				if belowWarning {
+					gm.topUpGas(ctx, chain.ChainID)
 				}
 			}
 		}
 	}
 }
 
-// monitorGasBalance exports a metric indicating the current gas balance of the relayer signer and whether it is below alerting thresholds
-func monitorGasBalance(ctx context.Context, chainID string, chainClient cctp.BridgeClient) error {
+// monitorGasBalance exports a metric indicating the current gas balance of the relayer signer and whether it is below alerting thresholds.
+// belowWarning is true if the balance is below the warning threshold.
+func monitorGasBalance(ctx context.Context, chainID string, chainClient cctp.BridgeClient) (belowWarning bool, err error) {
 	balance, err := chainClient.SignerGasTokenBalance(ctx)
 	if err != nil {
 		lmt.Logger(ctx).Error("failed to get gas token balance", zap.Error(err), zap.String("chain_id", chainID))
-		return err
+		return false, err
 	}
 
 	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
 	if err != nil {
-		return err
+		return false, err
 	}
 	warningThreshold, criticalThreshold, err := config.GetConfigReader(ctx).GetGasAlertThresholds(chainID)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if balance == nil || warningThreshold == nil || criticalThreshold == nil {
-		return fmt.Errorf("gas balance or alert thresholds are nil for chain %s", chainID)
+		return false, fmt.Errorf("gas balance or alert thresholds are nil for chain %s", chainID)
 	}
 	if balance.Cmp(criticalThreshold) < 0 {
 		lmt.Logger(ctx).Error("low balance", zap.String("balance", balance.String()), zap.String("chainID", chainID))
 	}
 	metrics.FromContext(ctx).SetGasBalance(chainID, chainConfig.ChainName, chainConfig.GasTokenSymbol, *balance, *warningThreshold, *criticalThreshold, chainConfig.GasTokenDecimals)
-	return nil
+	return balance.Cmp(warningThreshold) < 0, nil
+}
+
+// topUpGas tops up the solvers gas balance on chainID, if gas top ups are
+// enabled
+func (gm *GasMonitor) topUpGas(ctx context.Context, chainID string) {
+	if gm.gasTopUpper == nil {
+		return
+	}
+	hash, err := gm.gasTopUpper.TopUpGas(ctx, chainID)
+	if errors.Is(err, fundrebalancer.ErrRebalanceLimitReached) {
+		lmt.Logger(ctx).Info("skipping gas top up", zap.String("chain_id", chainID), zap.Error(err))
+		return
+	}
+	if err != nil {
+		lmt.Logger(ctx).Error("failed to top up gas balance", zap.String("chain_id", chainID), zap.Error(err))
+		return
+	}
+	if hash != "" {
+		lmt.Logger(ctx).Info("topped up gas balance", zap.String("chain_id", chainID), zap.String("tx_hash", string(hash)))
+	}
 }
@@ -0,0 +1,218 @@
+package hyperlane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/skip-mev/go-fast-solver/shared/metrics"
+)
+
+const (
+	// maxCachedCheckpoints is the maximum number of signed checkpoints kept in
+	// the checkpoint cache, the oldest checkpoints are evicted first
+	maxCachedCheckpoints = 10_000
+
+	// storageLocationCacheTTL is how long a validators announced storage
+	// location is cached for before it is looked up again, since validators
+	// may announce new storage locations
+	storageLocationCacheTTL = 10 * time.Minute
+)
+
+type checkpointCacheKey struct {
+	domain    string
+	validator string
+	index     uint64
+}
+
+type cachedStorageLocation struct {
+	location  types.ValidatorStorageLocation
+	expiresAt time.Time
+}
+
+// checkpointCache caches signed checkpoints and validator storage locations
+// across relays. Signed checkpoints never change once a validator has written
+// them, so they are cached until evicted to make room for newer checkpoints.
+type checkpointCache struct {
+	lock sync.Mutex
+
+	checkpoints     map[checkpointCacheKey]*types.SignedCheckpoint
+	checkpointOrder []checkpointCacheKey
+
+	storageLocations map[string]map[string]cachedStorageLocation
+}
+
+func newCheckpointCache() *checkpointCache {
+	return &checkpointCache{
+		checkpoints:      make(map[checkpointCacheKey]*types.SignedCheckpoint),
+		storageLocations: make(map[string]map[string]cachedStorageLocation),
+	}
+}
+
+func (c *checkpointCache) checkpoint(domain string, validator string, index uint64) (*types.SignedCheckpoint, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	checkpoint, ok := c.checkpoints[checkpointCacheKey{domain: domain, validator: validatorKey(validator), index: index}]
+	return checkpoint, ok
+}
+
+func (c *checkpointCache) putCheckpoint(domain string, validator string, index uint64, checkpoint *types.SignedCheckpoint) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := checkpointCacheKey{domain: domain, validator: validatorKey(validator), index: index}
+	if _, ok := c.checkpoints[key]; ok {
+		return
+	}
+	c.checkpoints[key] = checkpoint
+	c.checkpointOrder = append(c.checkpointOrder, key)
+
+	for len(c.checkpointOrder) > maxCachedCheckpoints {
+		delete(c.checkpoints, c.checkpointOrder[0])
+		c.checkpointOrder = c.checkpointOrder[1:]
+	}
+}
+
+// validatorStorageLocations returns the storage locations of validators on
+// domain, only looking up the locations of validators that are not cached
+func (c *checkpointCache) validatorStorageLocations(
+	ctx context.Context,
+	hyperlane Client,
+	domain string,
+	validators []common.Address,
+) ([]*types.ValidatorStorageLocation, error) {
+	c.lock.Lock()
+	now := time.Now()
+	cached := c.storageLocations[domain]
+	var storageLocations []*types.ValidatorStorageLocation
+	var uncached []common.Address
+	for _, validator := range validators {
+		location, ok := cached[validatorKey(validator.Hex())]
+		if !ok || now.After(location.expiresAt) {
+			uncached = append(uncached, validator)
+			continue
+		}
+		storageLocation := location.location
+		storageLocations = append(storageLocations, &storageLocation)
+	}
+	c.lock.Unlock()
+
+	if len(uncached) == 0 {
+		return storageLocations, nil
+	}
+
+	fetched, err := hyperlane.ValidatorStorageLocations(ctx, domain, uncached)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.storageLocations[domain]; !ok {
+		c.storageLocations[domain] = make(map[string]cachedStorageLocation)
+	}
+	for _, location := range fetched {
+		c.storageLocations[domain][validatorKey(location.Validator)] = cachedStorageLocation{
+			location:  *location,
+			expiresAt: now.Add(storageLocationCacheTTL),
+		}
+	}
+
+	return append(storageLocations, fetched...), nil
+}
+
+// validatorKey normalizes a hex validator address, which may or may not be 0x
+// prefixed or checksummed depending on where it came from
+func validatorKey(validator string) string {
+	return strings.ToLower(strings.TrimPrefix(validator, "0x"))
+}
+
+// statuses of a single validator checkpoint fetch
+const (
+	checkpointFetchStatusSigned    = "signed"
+	checkpointFetchStatusNotSigned = "not_signed"
+	checkpointFetchStatusTimeout   = "timeout"
+	checkpointFetchStatusError     = "error"
+)
+
+// latestIndexPollInterval is how often a validators latest signed checkpoint
+// index is polled while waiting for it to reach the index being relayed
+var latestIndexPollInterval = 2 * time.Second
+
+// cachedCheckpointFetcher wraps a validators checkpoint fetcher, serving
+// checkpoints from the checkpoint cache when possible and waiting for the
+// validator to sign checkpoints that it has not yet reached
+type cachedCheckpointFetcher struct {
+	CheckpointFetcher
+	domain string
+	cache  *checkpointCache
+}
+
+// AwaitCheckpoint fetches the checkpoint at index, waiting up to timeout for
+// the validators latest signed index to reach index first. Returns
+// ErrCheckpointDoesNotExist if the validator does not sign the checkpoint
+// within timeout. Fetched checkpoints are not cached until the caller has
+// verified them via cacheCheckpoint.
+func (f *cachedCheckpointFetcher) AwaitCheckpoint(ctx context.Context, index uint64, timeout time.Duration) (*types.SignedCheckpoint, error) {
+	if checkpoint, ok := f.cache.checkpoint(f.domain, f.Validator(), index); ok {
+		return checkpoint, nil
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	checkpoint, err := f.awaitCheckpoint(ctx, index)
+	status := checkpointFetchStatusSigned
+	switch {
+	case errors.Is(err, context.Canceled):
+		// the caller no longer needs this checkpoint, i.e. quorum was
+		// reached via other validators
+		return nil, err
+	case errors.Is(err, ErrCheckpointDoesNotExist):
+		status = checkpointFetchStatusNotSigned
+	case errors.Is(err, context.DeadlineExceeded):
+		status = checkpointFetchStatusTimeout
+		err = fmt.Errorf("%w: %w", ErrCheckpointDoesNotExist, err)
+	case err != nil:
+		status = checkpointFetchStatusError
+	}
+	metrics.FromContext(ctx).ObserveHyperlaneCheckpointFetchLatency(f.Validator(), status, time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// cacheCheckpoint caches the checkpoint at index for the validator. It must
+// only be called once the checkpoint has been verified to be signed by the
+// validator at index, otherwise a bad checkpoint served from the validators
+// storage location would be served from the cache until evicted.
+func (f *cachedCheckpointFetcher) cacheCheckpoint(index uint64, checkpoint *types.SignedCheckpoint) {
+	f.cache.putCheckpoint(f.domain, f.Validator(), index, checkpoint)
+}
+
+func (f *cachedCheckpointFetcher) awaitCheckpoint(ctx context.Context, index uint64) (*types.SignedCheckpoint, error) {
+	for {
+		latestIndex, err := f.LatestIndex(ctx)
+		if err != nil || latestIndex >= index {
+			// if the latest index can not be fetched, fall back to trying
+			// to fetch the checkpoint directly
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(latestIndexPollInterval):
+		}
+	}
+
+	return f.Checkpoint(ctx, index)
+}
@@ -0,0 +1,78 @@
+package hyperlane
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// laggingCheckpointFetcher is a validator whose latest signed index advances
+// by one every time it is polled, starting from latestIndex
+type laggingCheckpointFetcher struct {
+	latestIndex       atomic.Uint64
+	checkpointFetches atomic.Int32
+	checkpoint        *types.SignedCheckpoint
+}
+
+func (f *laggingCheckpointFetcher) LatestIndex(ctx context.Context) (uint64, error) {
+	return f.latestIndex.Add(1) - 1, nil
+}
+
+func (f *laggingCheckpointFetcher) Checkpoint(ctx context.Context, index uint64) (*types.SignedCheckpoint, error) {
+	f.checkpointFetches.Add(1)
+	if f.latestIndex.Load() <= index {
+		return nil, ErrCheckpointDoesNotExist
+	}
+	return f.checkpoint, nil
+}
+
+func (f *laggingCheckpointFetcher) Validator() string {
+	return testValidator
+}
+
+func TestCachedCheckpointFetcher(t *testing.T) {
+	originalPollInterval := latestIndexPollInterval
+	latestIndexPollInterval = time.Millisecond
+	t.Cleanup(func() { latestIndexPollInterval = originalPollInterval })
+
+	ctx := context.Background()
+	checkpoint := testSignedCheckpoint()
+
+	t.Run("waits for the validator to reach the index", func(t *testing.T) {
+		lagging := &laggingCheckpointFetcher{checkpoint: &checkpoint}
+		lagging.latestIndex.Store(2)
+		fetcher := &cachedCheckpointFetcher{CheckpointFetcher: lagging, domain: "1", cache: newCheckpointCache()}
+
+		fetched, err := fetcher.AwaitCheckpoint(ctx, 5, time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, &checkpoint, fetched)
+		assert.Equal(t, int32(1), lagging.checkpointFetches.Load())
+
+		// unverified checkpoints are not cached
+		_, err = fetcher.AwaitCheckpoint(ctx, 5, time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), lagging.checkpointFetches.Load())
+
+		// once verified the checkpoint is served from the cache
+		fetcher.cacheCheckpoint(5, fetched)
+		fetched, err = fetcher.AwaitCheckpoint(ctx, 5, time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, &checkpoint, fetched)
+		assert.Equal(t, int32(2), lagging.checkpointFetches.Load())
+	})
+
+	t.Run("validator that does not reach the index in time has not signed", func(t *testing.T) {
+		latestIndexPollInterval = 100 * time.Millisecond
+
+		lagging := &laggingCheckpointFetcher{checkpoint: &checkpoint}
+		fetcher := &cachedCheckpointFetcher{CheckpointFetcher: lagging, domain: "1", cache: newCheckpointCache()}
+
+		_, err := fetcher.AwaitCheckpoint(ctx, 1000, 50*time.Millisecond)
+		assert.ErrorIs(t, err, ErrCheckpointDoesNotExist)
+	})
+}
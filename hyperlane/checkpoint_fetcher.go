@@ -11,6 +11,8 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/skip-mev/go-fast-solver/hyperlane/types"
 )
@@ -24,6 +26,11 @@ type CheckpointFetcher interface {
 const (
 	latestIndexFilePathLocalStorage = "index.json"
 	latestIndexFilePathS3           = "checkpoint_latest_index.json"
+	latestIndexFilePathGCS          = "gcsLatestIndexKey"
+
+	// DefaultCheckpointFetchTimeout is the timeout applied to each request
+	// made by a remote checkpoint fetcher if no timeout is configured
+	DefaultCheckpointFetchTimeout = 10 * time.Second
 )
 
 var (
@@ -38,14 +45,62 @@ func checkpointFilePathS3(index uint64) string {
 	return fmt.Sprintf("checkpoint_%d_with_id.json", index)
 }
 
-func NewCheckpointFetcherFromStorageLocation(storageLocation string, validator string) (CheckpointFetcher, error) {
-	if strings.HasPrefix(storageLocation, "file://") {
-		return NewLocalFileFetcher(strings.TrimPrefix(storageLocation, "file://"), validator), nil
+// CheckpointFetcherOptions are options applied to every checkpoint fetcher
+// created from a storage location
+type CheckpointFetcherOptions struct {
+	// Timeout is the maximum amount of time a single request made by a remote
+	// checkpoint fetcher may take. Defaults to DefaultCheckpointFetchTimeout.
+	Timeout time.Duration
+}
+
+// CheckpointFetcherFactory creates a checkpoint fetcher for validator from a
+// storage location announced by the validator
+type CheckpointFetcherFactory func(storageLocation string, validator string, opts CheckpointFetcherOptions) (CheckpointFetcher, error)
+
+var (
+	checkpointFetchersLock sync.RWMutex
+	checkpointFetchers     = map[string]CheckpointFetcherFactory{
+		"file": func(storageLocation string, validator string, _ CheckpointFetcherOptions) (CheckpointFetcher, error) {
+			return NewLocalFileFetcher(strings.TrimPrefix(storageLocation, "file://"), validator), nil
+		},
+		"s3": func(storageLocation string, validator string, opts CheckpointFetcherOptions) (CheckpointFetcher, error) {
+			return NewS3Fetcher(storageLocation, validator, opts)
+		},
+		"gs": func(storageLocation string, validator string, opts CheckpointFetcherOptions) (CheckpointFetcher, error) {
+			return NewGCSFetcher(storageLocation, validator, opts)
+		},
+		"https": func(storageLocation string, validator string, opts CheckpointFetcherOptions) (CheckpointFetcher, error) {
+			return NewHTTPSFetcher(storageLocation, validator, opts)
+		},
 	}
-	if strings.HasPrefix(storageLocation, "s3://") {
-		return NewS3Fetcher(storageLocation, validator)
+)
+
+// RegisterCheckpointFetcher registers factory to create checkpoint fetchers
+// for storage locations with scheme (i.e. "s3" for s3:// locations),
+// replacing any factory already registered for scheme
+func RegisterCheckpointFetcher(scheme string, factory CheckpointFetcherFactory) {
+	checkpointFetchersLock.Lock()
+	defer checkpointFetchersLock.Unlock()
+	checkpointFetchers[scheme] = factory
+}
+
+func NewCheckpointFetcherFromStorageLocation(storageLocation string, validator string, opts CheckpointFetcherOptions) (CheckpointFetcher, error) {
+	scheme, _, ok := strings.Cut(storageLocation, "://")
+	if !ok {
+		return nil, fmt.Errorf("no fetcher type found for storage location %s", storageLocation)
 	}
-	return nil, fmt.Errorf("no fetcher type found for storage location %s", storageLocation)
+
+	checkpointFetchersLock.RLock()
+	factory, ok := checkpointFetchers[scheme]
+	checkpointFetchersLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no fetcher type found for storage location %s", storageLocation)
+	}
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultCheckpointFetchTimeout
+	}
+	return factory(storageLocation, validator, opts)
 }
 
 type LocalFileFetcher struct {
@@ -93,57 +148,70 @@ func (f *LocalFileFetcher) Validator() string {
 	return f.validator
 }
 
-type S3Fetcher struct {
-	url       string
-	validator string
-	client    *http.Client
+// httpFetcher fetches checkpoints from a validators storage location that is
+// served over http
+type httpFetcher struct {
+	url             string
+	latestIndexPath string
+	checkpointPath  func(index uint64) string
+	validator       string
+	client          *http.Client
 }
 
-func NewS3Fetcher(storageLocation string, validator string) (*S3Fetcher, error) {
-	locationString := strings.TrimPrefix(storageLocation, "s3://")
-	locationSplit := strings.Split(locationString, "/")
-	if len(locationSplit) < 2 {
-		return nil, fmt.Errorf("invalid s3 storage location %s", storageLocation)
+func newHTTPFetcher(url string, latestIndexPath string, checkpointPath func(index uint64) string, validator string, opts CheckpointFetcherOptions) httpFetcher {
+	return httpFetcher{
+		url:             url,
+		latestIndexPath: latestIndexPath,
+		checkpointPath:  checkpointPath,
+		validator:       validator,
+		client:          &http.Client{Timeout: opts.Timeout},
 	}
-	url := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", locationSplit[0], locationSplit[1])
-	if len(locationSplit) > 2 {
-		url += "/" + strings.Join(locationSplit[2:], "/")
-	}
-	return &S3Fetcher{url: url, validator: validator, client: http.DefaultClient}, nil
 }
 
-func (f *S3Fetcher) LatestIndex(ctx context.Context) (uint64, error) {
-	u, err := url.JoinPath(f.url, latestIndexFilePathS3)
+func (f *httpFetcher) LatestIndex(ctx context.Context) (uint64, error) {
+	body, err := f.get(ctx, f.latestIndexPath)
 	if err != nil {
-		return 0, fmt.Errorf("joining base url %s and path %s: %w", f.url, latestIndexFilePathS3, err)
+		return 0, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
-	if err != nil {
-		return 0, fmt.Errorf("creating request: %w", err)
+	var index uint64
+	if err = json.Unmarshal(body, &index); err != nil {
+		return 0, fmt.Errorf("unmarshaling latest index file %s contents: %w", f.latestIndexPath, err)
 	}
+	return index, nil
+}
 
-	resp, err := f.client.Do(req)
+func (f *httpFetcher) Checkpoint(ctx context.Context, index uint64) (*types.SignedCheckpoint, error) {
+	body, err := f.get(ctx, f.checkpointPath(index))
 	if err != nil {
-		return 0, fmt.Errorf("performing request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, fmt.Errorf("reading response body: %w", err)
+	var checkpoint types.SignedCheckpoint
+	if err = json.Unmarshal(body, &checkpoint); err != nil {
+		return nil, fmt.Errorf("unmarshaling checkpoint file %s contents: %w", f.checkpointPath(index), err)
 	}
-	var index uint64
-	if err = json.Unmarshal(body, &index); err != nil {
-		return 0, fmt.Errorf("unmarshaling latest index file %s contents: %w", u, err)
+	// we do this because for some reason the validator strips leading and trailing 0s from the R and S values when it serializes
+	// the checkpoint and puts it in remote storage
+	serializedSignature := strings.TrimPrefix(checkpoint.SerializedSignature, "0x")
+	if len(serializedSignature) < 128 {
+		return nil, fmt.Errorf("checkpoint file %s has invalid serialized signature %s", f.checkpointPath(index), checkpoint.SerializedSignature)
 	}
-	return index, nil
+	checkpoint.Signature.R = serializedSignature[:64]
+	checkpoint.Signature.S = serializedSignature[64:128]
+	return &checkpoint, nil
+}
+
+func (f *httpFetcher) Validator() string {
+	return f.validator
 }
 
-func (f *S3Fetcher) Checkpoint(ctx context.Context, index uint64) (*types.SignedCheckpoint, error) {
-	u, err := url.JoinPath(f.url, checkpointFilePathS3(index))
+// get fetches the file at path relative to the fetchers base url, returning
+// ErrCheckpointDoesNotExist if there is no file at path
+func (f *httpFetcher) get(ctx context.Context, path string) ([]byte, error) {
+	u, err := url.JoinPath(f.url, path)
 	if err != nil {
-		return nil, fmt.Errorf("joining base url %s and path %s: %w", f.url, checkpointFilePathS3(index), err)
+		return nil, fmt.Errorf("joining base url %s and path %s: %w", f.url, path, err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
@@ -160,24 +228,68 @@ func (f *S3Fetcher) Checkpoint(ctx context.Context, index uint64) (*types.Signed
 		if resp.StatusCode == http.StatusNotFound {
 			return nil, ErrCheckpointDoesNotExist
 		}
-		return nil, fmt.Errorf("unexpected status code %d fetching s3 checkpoint from %s", resp.StatusCode, u)
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", resp.StatusCode, u)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("reading response body: %w", err)
 	}
-	var checkpoint types.SignedCheckpoint
-	if err = json.Unmarshal(body, &checkpoint); err != nil {
-		return nil, fmt.Errorf("unmarshaling checkpoint file %s contents: %w", u, err)
+	return body, nil
+}
+
+type S3Fetcher struct {
+	httpFetcher
+}
+
+func NewS3Fetcher(storageLocation string, validator string, opts CheckpointFetcherOptions) (*S3Fetcher, error) {
+	locationString := strings.TrimPrefix(storageLocation, "s3://")
+	locationSplit := strings.Split(locationString, "/")
+	if len(locationSplit) < 2 {
+		return nil, fmt.Errorf("invalid s3 storage location %s", storageLocation)
 	}
-	// we do this because for some reason the validator strips leading and trailing 0s from the R and S values when it serializes
-	// the checkpoint and puts it in s3
-	checkpoint.Signature.R = strings.TrimPrefix(checkpoint.SerializedSignature, "0x")[:64]
-	checkpoint.Signature.S = strings.TrimPrefix(checkpoint.SerializedSignature, "0x")[64:128]
-	return &checkpoint, nil
+	url := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", locationSplit[0], locationSplit[1])
+	if len(locationSplit) > 2 {
+		url += "/" + strings.Join(locationSplit[2:], "/")
+	}
+	return &S3Fetcher{newHTTPFetcher(url, latestIndexFilePathS3, checkpointFilePathS3, validator, opts)}, nil
 }
 
-func (f *S3Fetcher) Validator() string {
-	return f.validator
+// gcsBaseURL is the url that public google cloud storage objects are served
+// from
+var gcsBaseURL = "https://storage.googleapis.com"
+
+// GCSFetcher fetches checkpoints from a public google cloud storage bucket,
+// announced by validators as gs://<bucket>/<optional folder>
+type GCSFetcher struct {
+	httpFetcher
+}
+
+func NewGCSFetcher(storageLocation string, validator string, opts CheckpointFetcherOptions) (*GCSFetcher, error) {
+	location := strings.Trim(strings.TrimPrefix(storageLocation, "gs://"), "/")
+	if location == "" {
+		return nil, fmt.Errorf("invalid gcs storage location %s", storageLocation)
+	}
+	baseURL, err := url.JoinPath(gcsBaseURL, strings.Split(location, "/")...)
+	if err != nil {
+		return nil, fmt.Errorf("creating gcs url for storage location %s: %w", storageLocation, err)
+	}
+	return &GCSFetcher{newHTTPFetcher(baseURL, latestIndexFilePathGCS, checkpointFilePathS3, validator, opts)}, nil
+}
+
+// HTTPSFetcher fetches checkpoints from a generic https storage location,
+// which is expected to serve checkpoints with the same layout as s3
+type HTTPSFetcher struct {
+	httpFetcher
+}
+
+func NewHTTPSFetcher(storageLocation string, validator string, opts CheckpointFetcherOptions) (*HTTPSFetcher, error) {
+	u, err := url.Parse(storageLocation)
+	if err != nil {
+		return nil, fmt.Errorf("parsing https storage location %s: %w", storageLocation, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid https storage location %s", storageLocation)
+	}
+	return &HTTPSFetcher{newHTTPFetcher(storageLocation, latestIndexFilePathS3, checkpointFilePathS3, validator, opts)}, nil
 }
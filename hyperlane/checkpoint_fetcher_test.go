@@ -0,0 +1,180 @@
+package hyperlane
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testValidator = "0x1111111111111111111111111111111111111111"
+
+// newCheckpointServer starts a local stand in for a validators checkpoint
+// storage that serves files from files, keyed by request path
+func newCheckpointServer(t *testing.T, tls bool, files map[string]any) *httptest.Server {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(file))
+	})
+
+	var server *httptest.Server
+	if tls {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testSignedCheckpoint() types.SignedCheckpoint {
+	return types.SignedCheckpoint{
+		Value: types.CheckpointWithMessageID{
+			Checkpoint: types.Checkpoint{
+				Index:         5,
+				MailboxDomain: 1,
+				Root:          "0x" + strings.Repeat("22", 32),
+			},
+			MessageID: "0x" + strings.Repeat("33", 32),
+		},
+		SerializedSignature: "0x" + strings.Repeat("44", 32) + strings.Repeat("55", 32) + "1b",
+	}
+}
+
+func TestNewCheckpointFetcherFromStorageLocation(t *testing.T) {
+	opts := CheckpointFetcherOptions{}
+
+	tests := []struct {
+		storageLocation string
+		expected        any
+	}{
+		{"file:///tmp/checkpoints", &LocalFileFetcher{}},
+		{"s3://bucket/us-east-1/folder", &S3Fetcher{}},
+		{"gs://bucket/folder", &GCSFetcher{}},
+		{"https://checkpoints.example.com/validator", &HTTPSFetcher{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.storageLocation, func(t *testing.T) {
+			fetcher, err := NewCheckpointFetcherFromStorageLocation(tt.storageLocation, testValidator, opts)
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, fetcher)
+			assert.Equal(t, testValidator, fetcher.Validator())
+		})
+	}
+
+	t.Run("unknown scheme", func(t *testing.T) {
+		_, err := NewCheckpointFetcherFromStorageLocation("ftp://checkpoints.example.com", testValidator, opts)
+		assert.ErrorContains(t, err, "no fetcher type found")
+	})
+
+	t.Run("registered scheme", func(t *testing.T) {
+		local := NewLocalFileFetcher("/tmp", testValidator)
+		RegisterCheckpointFetcher("test", func(string, string, CheckpointFetcherOptions) (CheckpointFetcher, error) {
+			return local, nil
+		})
+		t.Cleanup(func() {
+			checkpointFetchersLock.Lock()
+			delete(checkpointFetchers, "test")
+			checkpointFetchersLock.Unlock()
+		})
+
+		fetcher, err := NewCheckpointFetcherFromStorageLocation("test://anything", testValidator, opts)
+		require.NoError(t, err)
+		assert.Same(t, local, fetcher)
+	})
+
+	t.Run("default timeout", func(t *testing.T) {
+		fetcher, err := NewCheckpointFetcherFromStorageLocation("https://checkpoints.example.com", testValidator, opts)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultCheckpointFetchTimeout, fetcher.(*HTTPSFetcher).client.Timeout)
+	})
+}
+
+func TestGCSFetcher(t *testing.T) {
+	checkpoint := testSignedCheckpoint()
+	server := newCheckpointServer(t, false, map[string]any{
+		"/bucket/folder/gcsLatestIndexKey":         uint64(5),
+		"/bucket/folder/checkpoint_5_with_id.json": checkpoint,
+	})
+
+	originalBaseURL := gcsBaseURL
+	gcsBaseURL = server.URL
+	t.Cleanup(func() { gcsBaseURL = originalBaseURL })
+
+	fetcher, err := NewCheckpointFetcherFromStorageLocation("gs://bucket/folder", testValidator, CheckpointFetcherOptions{})
+	require.NoError(t, err)
+
+	index, err := fetcher.LatestIndex(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), index)
+
+	fetched, err := fetcher.Checkpoint(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint.Value, fetched.Value)
+	assert.Equal(t, strings.Repeat("44", 32), fetched.Signature.R)
+	assert.Equal(t, strings.Repeat("55", 32), fetched.Signature.S)
+
+	_, err = fetcher.Checkpoint(context.Background(), 6)
+	assert.ErrorIs(t, err, ErrCheckpointDoesNotExist)
+}
+
+func TestHTTPSFetcher(t *testing.T) {
+	checkpoint := testSignedCheckpoint()
+	server := newCheckpointServer(t, true, map[string]any{
+		"/validator/checkpoint_latest_index.json": uint64(5),
+		"/validator/checkpoint_5_with_id.json":    checkpoint,
+	})
+
+	fetcher, err := NewHTTPSFetcher(server.URL+"/validator", testValidator, CheckpointFetcherOptions{Timeout: time.Second})
+	require.NoError(t, err)
+	fetcher.client = server.Client()
+
+	index, err := fetcher.LatestIndex(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), index)
+
+	fetched, err := fetcher.Checkpoint(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint.Value, fetched.Value)
+
+	_, err = fetcher.Checkpoint(context.Background(), 6)
+	assert.ErrorIs(t, err, ErrCheckpointDoesNotExist)
+
+	t.Run("rejects non https locations", func(t *testing.T) {
+		_, err := NewHTTPSFetcher("http://checkpoints.example.com", testValidator, CheckpointFetcherOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPFetcherTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	originalBaseURL := gcsBaseURL
+	gcsBaseURL = server.URL
+	t.Cleanup(func() { gcsBaseURL = originalBaseURL })
+
+	fetcher, err := NewGCSFetcher("gs://bucket", testValidator, CheckpointFetcherOptions{Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = fetcher.LatestIndex(context.Background())
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/skip-mev/go-fast-solver/shared/oracle"
+	cosmostxexecutor "github.com/skip-mev/go-fast-solver/shared/txexecutor/cosmos"
 	"github.com/skip-mev/go-fast-solver/shared/txexecutor/evm"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -20,9 +22,14 @@ type Client interface {
 	HasBeenDelivered(ctx context.Context, destinationDomain string, messageID string) (bool, error)
 	ISMType(ctx context.Context, domain string, recipient string) (uint8, error)
 	ValidatorsAndThreshold(ctx context.Context, domain string, recipient string, message string) ([]common.Address, uint8, error)
+	InterchainSecurityModule(ctx context.Context, domain string, recipient string, message []byte) (*types.InterchainSecurityModule, error)
 	ValidatorStorageLocations(ctx context.Context, domain string, validators []common.Address) ([]*types.ValidatorStorageLocation, error)
 	MerkleTreeLeafCount(ctx context.Context, domain string) (uint64, error)
+	MerkleTreeInsertions(ctx context.Context, domain string, fromHeight uint64) ([]types.MailboxMerkleHookPostDispatchEvent, uint64, error)
+	MailboxDispatches(ctx context.Context, domain string, sender string, fromHeight uint64) ([]types.MailboxDispatchTx, uint64, error)
+	LatestHeight(ctx context.Context, domain string) (uint64, error)
 	Process(ctx context.Context, domain string, message []byte, metadata []byte) ([]byte, string, error)
+	ReplaceProcess(ctx context.Context, domain string, rawTx string, maxTxFeeUUSDC *big.Int) ([]byte, string, error)
 	IsContract(ctx context.Context, domain, address string) (bool, error)
 	GetHyperlaneDispatch(ctx context.Context, domain, originChainID, initiateTxHash string) (*types.MailboxDispatchEvent, *types.MailboxMerkleHookPostDispatchEvent, error)
 	QuoteProcessUUSDC(ctx context.Context, domain string, message []byte, metadata []byte) (*big.Int, error)
@@ -34,7 +41,14 @@ type MultiClient struct {
 
 // NewMultiClientFromConfig creates a MultiClient that is configured for every
 // chain specific in the config that has a HyperlaneDomain set
-func NewMultiClientFromConfig(ctx context.Context, manager evmrpc.EVMRPCClientManager, keystore keys.KeyStore, evmTxPriceOracle ethereum.TxPriceOracle, evmTxExecutor evm.EVMTxExecutor) (*MultiClient, error) {
+func NewMultiClientFromConfig(
+	ctx context.Context,
+	manager evmrpc.EVMRPCClientManager,
+	keystore keys.KeyStore,
+	txPriceOracle oracle.TxPriceOracle,
+	evmTxExecutor evm.EVMTxExecutor,
+	cosmosTxExecutor cosmostxexecutor.CosmosTxExecutor,
+) (*MultiClient, error) {
 	clients := make(map[string]Client)
 	for _, cfg := range config.GetConfigReader(ctx).Config().Chains {
 		if cfg.HyperlaneDomain == "" {
@@ -43,15 +57,15 @@ func NewMultiClientFromConfig(ctx context.Context, manager evmrpc.EVMRPCClientMa
 
 		switch cfg.Type {
 		case config.ChainType_COSMOS:
-			client, err := cosmos.NewHyperlaneClient(ctx, cfg.HyperlaneDomain)
+			client, err := cosmos.NewHyperlaneClient(ctx, cfg.HyperlaneDomain, keystore, txPriceOracle, cosmosTxExecutor)
 			if err != nil {
 				return nil, fmt.Errorf("creating cosmos hyperlane client for domain %s: %w", cfg.HyperlaneDomain, err)
 			}
 			clients[cfg.HyperlaneDomain] = client
 		case config.ChainType_EVM:
-			client, err := ethereum.NewHyperlaneClient(ctx, cfg.HyperlaneDomain, manager, keystore, evmTxPriceOracle, evmTxExecutor)
+			client, err := ethereum.NewHyperlaneClient(ctx, cfg.HyperlaneDomain, manager, keystore, txPriceOracle, evmTxExecutor)
 			if err != nil {
-				return nil, fmt.Errorf("creating cosmos hyperlane client for domain %s: %w", cfg.HyperlaneDomain, err)
+				return nil, fmt.Errorf("creating evm hyperlane client for domain %s: %w", cfg.HyperlaneDomain, err)
 			}
 			clients[cfg.HyperlaneDomain] = client
 		}
@@ -97,6 +111,14 @@ func (c *MultiClient) ValidatorsAndThreshold(ctx context.Context, domain string,
 	return client.ValidatorsAndThreshold(ctx, domain, recipient, message)
 }
 
+func (c *MultiClient) InterchainSecurityModule(ctx context.Context, domain string, recipient string, message []byte) (*types.InterchainSecurityModule, error) {
+	client, ok := c.clients[domain]
+	if !ok {
+		return nil, fmt.Errorf("no configured client for domain %s", domain)
+	}
+	return client.InterchainSecurityModule(ctx, domain, recipient, message)
+}
+
 func (c *MultiClient) ValidatorStorageLocations(
 	ctx context.Context,
 	domain string,
@@ -117,6 +139,30 @@ func (c *MultiClient) MerkleTreeLeafCount(ctx context.Context, domain string) (u
 	return client.MerkleTreeLeafCount(ctx, domain)
 }
 
+func (c *MultiClient) MailboxDispatches(ctx context.Context, domain string, sender string, fromHeight uint64) ([]types.MailboxDispatchTx, uint64, error) {
+	client, ok := c.clients[domain]
+	if !ok {
+		return nil, 0, fmt.Errorf("no configured client for domain %s", domain)
+	}
+	return client.MailboxDispatches(ctx, domain, sender, fromHeight)
+}
+
+func (c *MultiClient) LatestHeight(ctx context.Context, domain string) (uint64, error) {
+	client, ok := c.clients[domain]
+	if !ok {
+		return 0, fmt.Errorf("no configured client for domain %s", domain)
+	}
+	return client.LatestHeight(ctx, domain)
+}
+
+func (c *MultiClient) MerkleTreeInsertions(ctx context.Context, domain string, fromHeight uint64) ([]types.MailboxMerkleHookPostDispatchEvent, uint64, error) {
+	client, ok := c.clients[domain]
+	if !ok {
+		return nil, 0, fmt.Errorf("no configured client for domain %s", domain)
+	}
+	return client.MerkleTreeInsertions(ctx, domain, fromHeight)
+}
+
 func (c *MultiClient) Process(ctx context.Context, domain string, message []byte, metadata []byte) ([]byte, string, error) {
 	client, ok := c.clients[domain]
 	if !ok {
@@ -125,6 +171,14 @@ func (c *MultiClient) Process(ctx context.Context, domain string, message []byte
 	return client.Process(ctx, domain, message, metadata)
 }
 
+func (c *MultiClient) ReplaceProcess(ctx context.Context, domain string, rawTx string, maxTxFeeUUSDC *big.Int) ([]byte, string, error) {
+	client, ok := c.clients[domain]
+	if !ok {
+		return nil, "", fmt.Errorf("no configured client for domain %s", domain)
+	}
+	return client.ReplaceProcess(ctx, domain, rawTx, maxTxFeeUUSDC)
+}
+
 func (c *MultiClient) QuoteProcessUUSDC(ctx context.Context, domain string, message []byte, metadata []byte) (*big.Int, error) {
 	client, ok := c.clients[domain]
 	if !ok {
@@ -3,9 +3,26 @@ package cosmos
 import (
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/skip-mev/go-fast-solver/shared/keys"
+	"github.com/skip-mev/go-fast-solver/shared/signing"
+	cosmostxexecutor "github.com/skip-mev/go-fast-solver/shared/txexecutor/cosmos"
 
 	"google.golang.org/grpc/credentials"
 
@@ -14,22 +31,46 @@ import (
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/skip-mev/go-fast-solver/hyperlane/types"
 	"github.com/skip-mev/go-fast-solver/shared/config"
 	"github.com/skip-mev/go-fast-solver/shared/tmrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
+// TxPriceOracle converts a tx fee paid in a chains gas token to uusdc
+type TxPriceOracle interface {
+	GasCostUUSDC(ctx context.Context, txFee *big.Int, chainID string) (*big.Int, error)
+}
+
 type HyperlaneClient struct {
 	client                   wasmtypes.QueryClient
+	chainID                  string
 	hyperlaneDomain          string
+	addressPrefix            string
+	mailboxAddress           string
 	validatorAnnounceAddress string
 	merkleHookAddress        string
 	tmRPCManager             tmrpc.TendermintRPCClientManager
+
+	keystore      keys.KeyStore
+	txConfig      client.TxConfig
+	txPriceOracle TxPriceOracle
+	txExecutor    cosmostxexecutor.CosmosTxExecutor
+	gasPrice      float64
+	gasDenom      string
 }
 
-func NewHyperlaneClient(ctx context.Context, hyperlaneDomain string) (*HyperlaneClient, error) {
+func NewHyperlaneClient(
+	ctx context.Context,
+	hyperlaneDomain string,
+	keystore keys.KeyStore,
+	txPriceOracle TxPriceOracle,
+	txExecutor cosmostxexecutor.CosmosTxExecutor,
+) (*HyperlaneClient, error) {
 	chainID, err := config.GetConfigReader(ctx).GetChainIDByHyperlaneDomain(hyperlaneDomain)
 	if err != nil {
 		return nil, fmt.Errorf("getting chainID from hyperlane domain %s: %w", hyperlaneDomain, err)
@@ -52,12 +93,27 @@ func NewHyperlaneClient(ctx context.Context, hyperlaneDomain string) (*Hyperlane
 		return nil, fmt.Errorf("dialing grpc address %s: %w", chainConfig.Cosmos.GRPC, err)
 	}
 
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	wasmtypes.RegisterInterfaces(registry)
+	txConfig := authtx.NewTxConfig(codec.NewProtoCodec(registry), authtx.DefaultSignModes)
+
 	return &HyperlaneClient{
 		client:                   wasmtypes.NewQueryClient(conn),
+		chainID:                  chainID,
 		hyperlaneDomain:          hyperlaneDomain,
+		addressPrefix:            chainConfig.Cosmos.AddressPrefix,
+		mailboxAddress:           chainConfig.Relayer.MailboxAddress,
 		validatorAnnounceAddress: chainConfig.Relayer.ValidatorAnnounceContractAddress,
 		merkleHookAddress:        chainConfig.Relayer.MerkleHookContractAddress,
 		tmRPCManager:             tmrpc.NewTendermintRPCClientManager(),
+		keystore:                 keystore,
+		txConfig:                 txConfig,
+		txPriceOracle:            txPriceOracle,
+		txExecutor:               txExecutor,
+		gasPrice:                 chainConfig.Cosmos.GasPrice,
+		gasDenom:                 chainConfig.Cosmos.GasDenom,
 	}, nil
 }
 
@@ -75,53 +131,85 @@ func ParseTxResults(tx *coretypes.ResultTx) (*types.MailboxDispatchEvent, *types
 	return dispatch, merkleHookPostDispatch, nil
 }
 
+const merkleHookPostDispatchEventType = "wasm-hpl_hook_merkle::post_dispatch"
+
 func ParseMerkleHookPostDispatch(tx *coretypes.ResultTx) (*types.MailboxMerkleHookPostDispatchEvent, error) {
-	const merkleHookPostDispatchEventType = "wasm-hpl_hook_merkle::post_dispatch"
+	insertions, err := ParseMerkleHookPostDispatches(tx, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(insertions) == 0 {
+		return nil, fmt.Errorf("could not find merkle hook post dispatch event type %s", merkleHookPostDispatchEventType)
+	}
+	if len(insertions) > 1 {
+		return nil, fmt.Errorf("found multiple merkle hook post dispatch events in tx results")
+	}
+	return &insertions[0], nil
+}
 
-	var d types.MailboxMerkleHookPostDispatchEvent
-	found := false
+// ParseMerkleHookPostDispatches returns every merkle hook post dispatch event
+// in tx, in the order they were emitted. If hookAddress is not empty, only
+// events emitted by the merkle hook at hookAddress are returned.
+func ParseMerkleHookPostDispatches(tx *coretypes.ResultTx, hookAddress string) ([]types.MailboxMerkleHookPostDispatchEvent, error) {
+	var insertions []types.MailboxMerkleHookPostDispatchEvent
 	for _, event := range tx.TxResult.Events {
-		switch event.Type {
-		case merkleHookPostDispatchEventType:
-			if found {
-				return nil, fmt.Errorf("found multiple merkle hook post dispatch events in tx results")
-			}
-			found = true
-			for _, attribute := range event.Attributes {
-				switch attribute.Key {
-				case "message_id":
-					d.MessageID = attribute.Value
-				case "index":
-					idx, err := strconv.Atoi(attribute.Value)
-					if err != nil {
-						return nil, fmt.Errorf("converting index value %s to int: %w", attribute.Value, err)
-					}
-					d.Index = uint64(idx)
+		if event.Type != merkleHookPostDispatchEventType {
+			continue
+		}
+		var d types.MailboxMerkleHookPostDispatchEvent
+		var contractAddress string
+		for _, attribute := range event.Attributes {
+			switch attribute.Key {
+			case "_contract_address":
+				contractAddress = attribute.Value
+			case "message_id":
+				d.MessageID = attribute.Value
+			case "index":
+				idx, err := strconv.Atoi(attribute.Value)
+				if err != nil {
+					return nil, fmt.Errorf("converting index value %s to int: %w", attribute.Value, err)
 				}
+				d.Index = uint64(idx)
 			}
 		}
+		if hookAddress != "" && contractAddress != hookAddress {
+			continue
+		}
+		insertions = append(insertions, d)
 	}
-	if !found {
-		return nil, fmt.Errorf("could not find merkle hook post dispatch event type %s", merkleHookPostDispatchEventType)
-	}
-
-	return &d, nil
+	return insertions, nil
 }
 
+const (
+	dispatchEventType   = "wasm-mailbox_dispatch"
+	dispatchIDEventType = "wasm-mailbox_dispatch_id"
+)
+
 func ParseDispatch(tx *coretypes.ResultTx) (*types.MailboxDispatchEvent, error) {
-	const dispatchEventType = "wasm-mailbox_dispatch"
-	const dispatchIDEventType = "wasm-mailbox_dispatch_id"
+	dispatches, err := ParseDispatches(tx, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(dispatches) == 0 {
+		return nil, fmt.Errorf("could not find dispatch event type %s", dispatchEventType)
+	}
+	if len(dispatches) > 1 {
+		return nil, fmt.Errorf("found multiple dispatch events in tx results")
+	}
+	return &dispatches[0], nil
+}
 
-	var d types.MailboxDispatchEvent
-	dispatchFound := false
-	dispatchMessageIDFound := false
+// ParseDispatches returns every dispatch in tx, in the order they were
+// emitted. Each dispatch event is paired with the dispatch id event the
+// mailbox emits after it. If mailboxAddress is not empty, only dispatches
+// emitted by the mailbox at mailboxAddress are returned.
+func ParseDispatches(tx *coretypes.ResultTx, mailboxAddress string) ([]types.MailboxDispatchEvent, error) {
+	var dispatches []types.MailboxDispatchEvent
+	var messageIDs []string
 	for _, event := range tx.TxResult.Events {
 		switch event.Type {
 		case dispatchEventType:
-			if dispatchFound {
-				return nil, fmt.Errorf("found multiple dispatch events in tx results")
-			}
-			dispatchFound = true
+			var d types.MailboxDispatchEvent
 			for _, attribute := range event.Attributes {
 				switch attribute.Key {
 				case "recipient":
@@ -136,28 +224,45 @@ func ParseDispatch(tx *coretypes.ResultTx) (*types.MailboxDispatchEvent, error)
 					d.Message = attribute.Value
 				}
 			}
-		case dispatchIDEventType:
-			if dispatchMessageIDFound {
-				return nil, fmt.Errorf("found multiple dispatch message id events in tx results")
+			if mailboxAddress != "" && d.SenderMailbox != mailboxAddress {
+				continue
 			}
-			dispatchMessageIDFound = true
+			dispatches = append(dispatches, d)
+		case dispatchIDEventType:
+			var messageID, contractAddress string
 			for _, attribute := range event.Attributes {
 				switch attribute.Key {
+				case "_contract_address":
+					contractAddress = attribute.Value
 				case "message_id":
-					d.MessageID = attribute.Value
+					messageID = attribute.Value
 				}
 			}
+			if mailboxAddress != "" && contractAddress != mailboxAddress {
+				continue
+			}
+			messageIDs = append(messageIDs, messageID)
 		}
 	}
 
-	if !dispatchFound {
-		return nil, fmt.Errorf("could not find dispatch event type %s", dispatchEventType)
+	if len(messageIDs) != len(dispatches) {
+		return nil, fmt.Errorf("found %d dispatch events but %d dispatch message id events in tx results", len(dispatches), len(messageIDs))
 	}
-	if !dispatchMessageIDFound {
-		return nil, fmt.Errorf("could not find dipatch message id event type %s", dispatchIDEventType)
+	for i := range dispatches {
+		// the message id is the keccak256 hash of the message, check that the
+		// dispatch was paired with its own message id
+		message, err := hex.DecodeString(strings.TrimPrefix(dispatches[i].Message, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("hex decoding dispatched message %s: %w", dispatches[i].Message, err)
+		}
+		expectedMessageID := hex.EncodeToString(crypto.Keccak256(message))
+		if !strings.EqualFold(strings.TrimPrefix(messageIDs[i], "0x"), expectedMessageID) {
+			return nil, fmt.Errorf("dispatch message id %s does not match the id %s of the dispatched message", messageIDs[i], expectedMessageID)
+		}
+		dispatches[i].MessageID = messageIDs[i]
 	}
 
-	return &d, nil
+	return dispatches, nil
 }
 
 func (c *HyperlaneClient) GetHyperlaneDispatch(ctx context.Context, domain, originChainID, initiateTxHash string) (*types.MailboxDispatchEvent, *types.MailboxMerkleHookPostDispatchEvent, error) {
@@ -181,7 +286,12 @@ func (c *HyperlaneClient) HasBeenDelivered(ctx context.Context, domain string, m
 		return false, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
 	}
 
-	panic("not implemented")
+	delivered, err := NewMailboxQuerier(c.mailboxAddress, c.client).MessageDelivered(ctx, messageID)
+	if err != nil {
+		return false, fmt.Errorf("checking if message %s was delivered: %w", messageID, err)
+	}
+
+	return delivered, nil
 }
 
 func (c *HyperlaneClient) ISMType(ctx context.Context, domain string, recipient string) (uint8, error) {
@@ -189,7 +299,12 @@ func (c *HyperlaneClient) ISMType(ctx context.Context, domain string, recipient
 		return 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
 	}
 
-	panic("not implemented")
+	ismAddress, err := c.recipientISM(ctx, recipient)
+	if err != nil {
+		return 0, err
+	}
+
+	return NewISMQuerier(ismAddress, c.client).ModuleType(ctx)
 }
 
 func (c *HyperlaneClient) ValidatorsAndThreshold(
@@ -202,7 +317,136 @@ func (c *HyperlaneClient) ValidatorsAndThreshold(
 		return nil, 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
 	}
 
-	panic("not implemented")
+	ismAddress, err := c.recipientISM(ctx, recipient)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ismType, err := NewISMQuerier(ismAddress, c.client).ModuleType(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting ism type for recipient %s on domain %s: %w", recipient, domain, err)
+	}
+
+	switch ismType {
+	case types.ISMTypeMessageIDMultisig, types.ISMTypeMerkleRootMultisig:
+		return c.multisigValidatorsAndThreshold(ctx, ismAddress, message)
+	default:
+		return nil, 0, fmt.Errorf("ism type %d not supported", ismType)
+	}
+}
+
+func (c *HyperlaneClient) multisigValidatorsAndThreshold(ctx context.Context, ismAddress string, message string) ([]common.Address, uint8, error) {
+	validatorStrs, threshold, err := NewISMQuerier(ismAddress, c.client).ValidatorsAndThreshold(ctx, message)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetching validators and threshold from multisig ism at address %s: %w", ismAddress, err)
+	}
+
+	var validators []common.Address
+	for _, validator := range validatorStrs {
+		if !common.IsHexAddress(validator) {
+			return nil, 0, fmt.Errorf("multisig ism %s returned invalid validator address %s", ismAddress, validator)
+		}
+		validators = append(validators, common.HexToAddress(validator))
+	}
+
+	return validators, threshold, nil
+}
+
+// maxISMDepth is the maximum number of nested routing isms that will be
+// resolved, to protect against misconfigured isms that reference themselves
+const maxISMDepth = 4
+
+// InterchainSecurityModule resolves the ism that message will be verified by
+// when delivered to recipient. Routing isms are resolved into the module that
+// they route message to. Aggregation isms are returned with only their type
+// set, since their sub modules can not be resolved on cosmos.
+func (c *HyperlaneClient) InterchainSecurityModule(
+	ctx context.Context,
+	domain string,
+	recipient string,
+	message []byte,
+) (*types.InterchainSecurityModule, error) {
+	if domain != c.hyperlaneDomain {
+		return nil, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	ismAddress, err := c.recipientISM(ctx, recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.interchainSecurityModule(ctx, ismAddress, hex.EncodeToString(message), 0)
+}
+
+func (c *HyperlaneClient) interchainSecurityModule(ctx context.Context, ismAddress string, message string, depth int) (*types.InterchainSecurityModule, error) {
+	if depth > maxISMDepth {
+		return nil, fmt.Errorf("ism at address %s is nested more than %d levels deep", ismAddress, maxISMDepth)
+	}
+
+	querier := NewISMQuerier(ismAddress, c.client)
+	moduleType, err := querier.ModuleType(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ism := &types.InterchainSecurityModule{
+		Address:    ismAddress,
+		ModuleType: moduleType,
+	}
+
+	switch moduleType {
+	case types.ISMTypeMessageIDMultisig, types.ISMTypeMerkleRootMultisig:
+		validators, threshold, err := c.multisigValidatorsAndThreshold(ctx, ismAddress, message)
+		if err != nil {
+			return nil, err
+		}
+		ism.Validators = validators
+		ism.Threshold = threshold
+	case types.ISMTypeRouting:
+		moduleAddress, err := querier.Route(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+		module, err := c.interchainSecurityModule(ctx, moduleAddress, message, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("resolving module %s routed to by routing ism %s: %w", moduleAddress, ismAddress, err)
+		}
+		ism.Modules = []types.InterchainSecurityModule{*module}
+	}
+
+	return ism, nil
+}
+
+// recipientISM gets the address of the ism that recipient has set on the
+// mailbox
+func (c *HyperlaneClient) recipientISM(ctx context.Context, recipient string) (string, error) {
+	recipientAddress, err := c.bech32Address(recipient)
+	if err != nil {
+		return "", fmt.Errorf("converting recipient %s to bech32 address: %w", recipient, err)
+	}
+
+	ismAddress, err := NewMailboxQuerier(c.mailboxAddress, c.client).RecipientIsm(ctx, recipientAddress)
+	if err != nil {
+		return "", fmt.Errorf("getting ism address for recipient %s on domain %s: %w", recipient, c.hyperlaneDomain, err)
+	}
+
+	return ismAddress, nil
+}
+
+// bech32Address converts a hyperlane hex encoded 32 byte address into a bech32
+// address for this chain. Addresses that are already bech32 encoded are
+// returned as is.
+func (c *HyperlaneClient) bech32Address(address string) (string, error) {
+	if strings.HasPrefix(address, c.addressPrefix+"1") {
+		return address, nil
+	}
+
+	addressBytes, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("hex decoding address: %w", err)
+	}
+
+	return bech32.ConvertAndEncode(c.addressPrefix, addressBytes)
 }
 
 func (c *HyperlaneClient) ValidatorStorageLocations(
@@ -235,14 +479,333 @@ func (c *HyperlaneClient) MerkleTreeLeafCount(ctx context.Context, domain string
 	return NewMerkleTreeHookQuerier(c.merkleHookAddress, c.client).Count(ctx)
 }
 
+// maxMerkleTreeInsertionsBlockRange is the max number of blocks that will be
+// searched for merkle tree insertion events in a single call
+const maxMerkleTreeInsertionsBlockRange = 10_000
+
+// MerkleTreeInsertions returns the insertions into the merkle tree hook that
+// were emitted from fromHeight up to and including the returned height. The
+// returned height is capped at the chains latest height, so a returned height
+// that is less than fromHeight means there are no new blocks to index.
+func (c *HyperlaneClient) MerkleTreeInsertions(ctx context.Context, domain string, fromHeight uint64) ([]types.MailboxMerkleHookPostDispatchEvent, uint64, error) {
+	if domain != c.hyperlaneDomain {
+		return nil, 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	chainID, err := config.GetConfigReader(ctx).GetChainIDByHyperlaneDomain(domain)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting chainID for hyperlane domain %s: %w", domain, err)
+	}
+	tmRpcClient, err := c.tmRPCManager.GetClient(ctx, chainID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting tendermint rpc client for chain %s: %w", chainID, err)
+	}
+
+	status, err := tmRpcClient.Status(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting status of chain %s: %w", chainID, err)
+	}
+	latestHeight := uint64(status.SyncInfo.LatestBlockHeight)
+	if fromHeight > latestHeight {
+		return nil, fromHeight - 1, nil
+	}
+	toHeight := min(fromHeight+maxMerkleTreeInsertionsBlockRange-1, latestHeight)
+
+	query := fmt.Sprintf(
+		"%s._contract_address='%s' AND tx.height>=%d AND tx.height<=%d",
+		merkleHookPostDispatchEventType, c.merkleHookAddress, fromHeight, toHeight,
+	)
+
+	var insertions []types.MailboxMerkleHookPostDispatchEvent
+	perPage := 100
+	for page := 1; ; page++ {
+		result, err := tmRpcClient.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, 0, fmt.Errorf("searching for merkle hook post dispatch events from height %d to %d: %w", fromHeight, toHeight, err)
+		}
+		for _, tx := range result.Txs {
+			// a tx may dispatch multiple messages, each of which is inserted
+			// into the tree
+			txInsertions, err := ParseMerkleHookPostDispatches(tx, c.merkleHookAddress)
+			if err != nil {
+				return nil, 0, fmt.Errorf("parsing merkle hook post dispatch events from tx %s: %w", tx.Hash.String(), err)
+			}
+			insertions = append(insertions, txInsertions...)
+		}
+		if page*perPage >= result.TotalCount {
+			break
+		}
+	}
+
+	return insertions, toHeight, nil
+}
+
+// MailboxDispatches returns the dispatches from sender on the mailbox that
+// were emitted from fromHeight up to and including the returned height. The
+// returned height is capped at the chains latest height, so a returned height
+// that is less than fromHeight means there are no new blocks to index.
+func (c *HyperlaneClient) MailboxDispatches(ctx context.Context, domain string, sender string, fromHeight uint64) ([]types.MailboxDispatchTx, uint64, error) {
+	if domain != c.hyperlaneDomain {
+		return nil, 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	// the mailbox emits senders as hex encoded 32 byte addresses
+	_, senderBytes, err := bech32.DecodeAndConvert(sender)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decoding sender address %s: %w", sender, err)
+	}
+
+	tmRpcClient, err := c.tmRPCManager.GetClient(ctx, c.chainID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting tendermint rpc client for chain %s: %w", c.chainID, err)
+	}
+
+	latestHeight, err := c.LatestHeight(ctx, domain)
+	if err != nil {
+		return nil, 0, err
+	}
+	if fromHeight > latestHeight {
+		return nil, fromHeight - 1, nil
+	}
+	toHeight := min(fromHeight+maxMerkleTreeInsertionsBlockRange-1, latestHeight)
+
+	query := fmt.Sprintf(
+		"%s._contract_address='%s' AND %s.sender='%s' AND tx.height>=%d AND tx.height<=%d",
+		dispatchEventType, c.mailboxAddress, dispatchEventType, hex.EncodeToString(senderBytes), fromHeight, toHeight,
+	)
+
+	var dispatches []types.MailboxDispatchTx
+	perPage := 100
+	for page := 1; ; page++ {
+		result, err := tmRpcClient.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, 0, fmt.Errorf("searching for mailbox dispatch events from height %d to %d: %w", fromHeight, toHeight, err)
+		}
+		for _, tx := range result.Txs {
+			// a tx may dispatch multiple messages, possibly from other
+			// senders than the one searched for
+			txDispatches, err := ParseDispatches(tx, c.mailboxAddress)
+			if err != nil {
+				return nil, 0, fmt.Errorf("parsing mailbox dispatch events from tx %s: %w", tx.Hash.String(), err)
+			}
+			for _, dispatch := range txDispatches {
+				if !strings.EqualFold(strings.TrimPrefix(dispatch.Sender, "0x"), hex.EncodeToString(senderBytes)) {
+					continue
+				}
+				dispatches = append(dispatches, types.MailboxDispatchTx{
+					Dispatch: dispatch,
+					TxHash:   tx.Hash.String(),
+					TxSender: parseTxSender(tx),
+					Height:   uint64(tx.Height),
+				})
+			}
+		}
+		if page*perPage >= result.TotalCount {
+			break
+		}
+	}
+
+	return dispatches, toHeight, nil
+}
+
+// parseTxSender returns the sender of the first message in a tx, which is the
+// address that signed the tx
+func parseTxSender(tx *coretypes.ResultTx) string {
+	for _, event := range tx.TxResult.Events {
+		if event.Type != sdk.EventTypeMessage {
+			continue
+		}
+		for _, attribute := range event.Attributes {
+			if attribute.Key == sdk.AttributeKeySender {
+				return attribute.Value
+			}
+		}
+	}
+	return ""
+}
+
+func (c *HyperlaneClient) LatestHeight(ctx context.Context, domain string) (uint64, error) {
+	if domain != c.hyperlaneDomain {
+		return 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	tmRpcClient, err := c.tmRPCManager.GetClient(ctx, c.chainID)
+	if err != nil {
+		return 0, fmt.Errorf("getting tendermint rpc client for chain %s: %w", c.chainID, err)
+	}
+	status, err := tmRpcClient.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting status of chain %s: %w", c.chainID, err)
+	}
+	return uint64(status.SyncInfo.LatestBlockHeight), nil
+}
+
 func (c *HyperlaneClient) Process(ctx context.Context, domain string, message []byte, metadata []byte) ([]byte, string, error) {
-	panic("not implemented")
+	if domain != c.hyperlaneDomain {
+		return nil, "", fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	signer, address, err := c.signer(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("getting signer: %w", err)
+	}
+
+	msgs, err := c.processMsgs(address, message, metadata)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, tx, err := c.txExecutor.ExecuteTx(ctx, c.chainID, address, msgs, c.txConfig, signer, c.gasPrice, c.gasDenom)
+	if err != nil {
+		return nil, "", fmt.Errorf("processing message on destination mailbox: %w", err)
+	}
+	if result.Code != 0 {
+		return nil, "", fmt.Errorf("processing message on destination mailbox failed with code %d and log: %s", result.Code, result.Log)
+	}
+
+	txBytes, err := c.txConfig.TxJSONEncoder()(tx)
+	if err != nil {
+		return nil, "", fmt.Errorf("json encoding process tx: %w", err)
+	}
+
+	return result.Hash, base64.StdEncoding.EncodeToString(txBytes), nil
+}
+
+// ReplaceProcess is not supported on cosmos chains, since txs are ordered by
+// account sequence and are not replaced in the mempool by fee
+func (c *HyperlaneClient) ReplaceProcess(ctx context.Context, domain string, rawTx string, maxTxFeeUUSDC *big.Int) ([]byte, string, error) {
+	return nil, "", types.ErrTxReplacementNotSupported
 }
 
 func (c *HyperlaneClient) QuoteProcessUUSDC(ctx context.Context, domain string, message []byte, metadata []byte) (*big.Int, error) {
-	panic("not implemented")
+	if domain != c.hyperlaneDomain {
+		return nil, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	signer, address, err := c.signer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting signer: %w", err)
+	}
+
+	msgs, err := c.processMsgs(address, message, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	gasEstimate, err := c.txExecutor.EstimateGas(ctx, c.chainID, address, msgs, c.txConfig, signer)
+	if err != nil {
+		if isContractExecutionFailure(err) {
+			// keep the execution reverted wording consistent with evm so that
+			// callers can detect that the process call itself would fail
+			return nil, fmt.Errorf("simulating process tx: execution reverted: %w", err)
+		}
+		return nil, fmt.Errorf("simulating process tx: %w", err)
+	}
+
+	gasPriceDec, err := math.LegacyNewDecFromStr(strconv.FormatFloat(c.gasPrice, 'f', -1, 64))
+	if err != nil {
+		return nil, fmt.Errorf("converting gas price %f to decimal: %w", c.gasPrice, err)
+	}
+	txFee := gasPriceDec.MulInt64(int64(gasEstimate)).Ceil().TruncateInt().BigInt()
+
+	txFeeUUSDC, err := c.txPriceOracle.GasCostUUSDC(ctx, txFee, c.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting tx fee in uusdc from gas oracle: %w", err)
+	}
+
+	return txFeeUUSDC, nil
+}
+
+func (c *HyperlaneClient) processMsgs(sender string, message []byte, metadata []byte) ([]sdk.Msg, error) {
+	processMsg, err := json.Marshal(MailboxProcessEnvelope{
+		Process: MailboxProcess{
+			Metadata: hex.EncodeToString(metadata),
+			Message:  hex.EncodeToString(message),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling mailbox process msg: %w", err)
+	}
+
+	return []sdk.Msg{&wasmtypes.MsgExecuteContract{
+		Sender:   sender,
+		Contract: c.mailboxAddress,
+		Msg:      processMsg,
+	}}, nil
 }
 
 func (c *HyperlaneClient) IsContract(ctx context.Context, domain, address string) (bool, error) {
-	panic("not implemented")
+	if domain != c.hyperlaneDomain {
+		return false, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	bech32Address, err := c.bech32Address(address)
+	if err != nil {
+		return false, fmt.Errorf("converting address %s to bech32 address: %w", address, err)
+	}
+
+	_, err = c.client.ContractInfo(ctx, &wasmtypes.QueryContractInfoRequest{Address: bech32Address})
+	if err != nil {
+		if isNoSuchContract(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("querying contract info for address %s: %w", bech32Address, err)
+	}
+
+	return true, nil
+}
+
+// isContractExecutionFailure returns true if a tx simulation failed since a
+// contract call in the tx failed, rather than since the simulation could not
+// be run. Wasm execution failures are registered without a grpc code, so the
+// node returns them as unknown with the registered error description.
+func isContractExecutionFailure(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Unknown {
+		return false
+	}
+	return strings.Contains(st.Message(), wasmtypes.ErrExecuteFailed.Error())
+}
+
+// isNoSuchContract returns true if a contract info query failed since there
+// is no contract at the queried address
+func isNoSuchContract(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.NotFound:
+		return true
+	case codes.Unknown:
+		// wasmd registers its no such contract error without a grpc code, so
+		// it is returned as unknown with the registered error description
+		return strings.Contains(st.Message(), wasmtypes.ErrNoSuchContractFn("").Error())
+	default:
+		return false
+	}
+}
+
+// signer returns the solvers signer and bech32 address on this chain
+func (c *HyperlaneClient) signer(ctx context.Context) (signing.Signer, string, error) {
+	privateKeyStr, ok := c.keystore.GetPrivateKey(c.chainID)
+	if !ok {
+		return nil, "", fmt.Errorf("relayer private key not found for chainID %s", c.chainID)
+	}
+	privateKeyBytes, err := hex.DecodeString(strings.TrimPrefix(privateKeyStr, "0x"))
+	if err != nil {
+		return nil, "", fmt.Errorf("hex decoding private key: %w", err)
+	}
+
+	privateKey := &secp256k1.PrivKey{}
+	if err := privateKey.UnmarshalAmino(privateKeyBytes); err != nil {
+		return nil, "", fmt.Errorf("unmarshaling private key: %w", err)
+	}
+
+	address, err := bech32.ConvertAndEncode(c.addressPrefix, privateKey.PubKey().Address())
+	if err != nil {
+		return nil, "", fmt.Errorf("converting signer address to bech32: %w", err)
+	}
+
+	return signing.NewLocalCosmosSigner(privateKey, address), address, nil
 }
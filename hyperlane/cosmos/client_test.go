@@ -0,0 +1,297 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	mockwasm "github.com/skip-mev/go-fast-solver/mocks/github.com/CosmWasm/wasmd/x/wasm/types"
+	mockcosmostxexecutor "github.com/skip-mev/go-fast-solver/mocks/shared/txexecutor/cosmos"
+	"github.com/skip-mev/go-fast-solver/shared/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testDomain         = "875"
+	testChainID        = "osmosis-1"
+	testMailboxAddress = "osmo1mailbox"
+	testISMAddress     = "osmo1ism"
+)
+
+func newTestHyperlaneClient(queryClient wasmtypes.QueryClient) *HyperlaneClient {
+	return &HyperlaneClient{
+		client:          queryClient,
+		chainID:         testChainID,
+		hyperlaneDomain: testDomain,
+		addressPrefix:   "osmo",
+		mailboxAddress:  testMailboxAddress,
+	}
+}
+
+// expectSmartQuery mocks a smart contract query to address that returns resp
+// json encoded
+func expectSmartQuery(t *testing.T, queryClient *mockwasm.MockQueryClient, address string, resp any) {
+	data, err := json.Marshal(resp)
+	require.NoError(t, err)
+	queryClient.On("SmartContractState", mock.Anything, mock.MatchedBy(func(req *wasmtypes.QuerySmartContractStateRequest) bool {
+		return req.Address == address
+	})).Return(&wasmtypes.QuerySmartContractStateResponse{Data: data}, nil).Once()
+}
+
+func TestISMQuerierModuleType(t *testing.T) {
+	tests := []struct {
+		name          string
+		typ           any
+		expModuleType uint8
+		expErr        bool
+	}{
+		{name: "type name", typ: "message_id_multisig", expModuleType: types.ISMTypeMessageIDMultisig},
+		{name: "routing type name", typ: "routing", expModuleType: types.ISMTypeRouting},
+		{name: "type number", typ: types.ISMTypeMerkleRootMultisig, expModuleType: types.ISMTypeMerkleRootMultisig},
+		{name: "unknown type name", typ: "unknown", expErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryClient := mockwasm.NewMockQueryClient(t)
+			expectSmartQuery(t, queryClient, testISMAddress, map[string]any{"typ": tt.typ})
+
+			moduleType, err := NewISMQuerier(testISMAddress, queryClient).ModuleType(context.Background())
+			if tt.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expModuleType, moduleType)
+		})
+	}
+
+	t.Run("query error", func(t *testing.T) {
+		queryClient := mockwasm.NewMockQueryClient(t)
+		queryClient.On("SmartContractState", mock.Anything, mock.Anything).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+
+		_, err := NewISMQuerier(testISMAddress, queryClient).ModuleType(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestHyperlaneClientProcessMsgs(t *testing.T) {
+	client := newTestHyperlaneClient(nil)
+	message := []byte{0x01, 0x02}
+	metadata := []byte{0x03}
+
+	msgs, err := client.processMsgs("osmo1sender", message, metadata)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+
+	msg, ok := msgs[0].(*wasmtypes.MsgExecuteContract)
+	require.True(t, ok)
+	assert.Equal(t, "osmo1sender", msg.Sender)
+	assert.Equal(t, testMailboxAddress, msg.Contract)
+
+	var envelope MailboxProcessEnvelope
+	require.NoError(t, json.Unmarshal(msg.Msg, &envelope))
+	assert.Equal(t, hex.EncodeToString(message), envelope.Process.Message)
+	assert.Equal(t, hex.EncodeToString(metadata), envelope.Process.Metadata)
+}
+
+func TestHyperlaneClientIsContract(t *testing.T) {
+	address := "0x" + hex.EncodeToString(make([]byte, 32))
+
+	tests := []struct {
+		name          string
+		err           error
+		expIsContract bool
+		expErr        bool
+	}{
+		{name: "contract", expIsContract: true},
+		{name: "no such contract", err: status.Error(codes.Unknown, "address osmo1qqq: no such contract"), expIsContract: false},
+		{name: "not found", err: status.Error(codes.NotFound, "contract not found"), expIsContract: false},
+		{name: "unavailable node", err: status.Error(codes.Unavailable, "connection refused"), expErr: true},
+		{name: "other unknown error", err: status.Error(codes.Unknown, "something went wrong"), expErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryClient := mockwasm.NewMockQueryClient(t)
+			var resp *wasmtypes.QueryContractInfoResponse
+			if tt.err == nil {
+				resp = &wasmtypes.QueryContractInfoResponse{}
+			}
+			queryClient.On("ContractInfo", mock.Anything, mock.Anything).Return(resp, tt.err)
+
+			isContract, err := newTestHyperlaneClient(queryClient).IsContract(context.Background(), testDomain, address)
+			if tt.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expIsContract, isContract)
+		})
+	}
+}
+
+func TestHyperlaneClientQuoteProcessUUSDCSimulationErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		expReverted bool
+	}{
+		{name: "contract execution failed", err: status.Error(codes.Unknown, "failed to execute message; message index: 0: execute wasm contract failed"), expReverted: true},
+		{name: "unavailable node", err: status.Error(codes.Unavailable, "connection refused")},
+		{name: "unknown simulation error", err: status.Error(codes.Unknown, "account sequence mismatch")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txExecutor := mockcosmostxexecutor.NewMockCosmosTxExecutor(t)
+			txExecutor.On("EstimateGas", mock.Anything, testChainID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(uint64(0), tt.err)
+
+			client := newTestHyperlaneClient(nil)
+			client.txExecutor = txExecutor
+			client.keystore = keys.KeyStore{testChainID: hex.EncodeToString(secp256k1.GenPrivKey().Bytes())}
+
+			_, err := client.QuoteProcessUUSDC(context.Background(), testDomain, []byte{0x01}, []byte{0x02})
+			require.Error(t, err)
+			// the relayer classifies relay errors as reverts by this wording
+			assert.Equal(t, tt.expReverted, strings.Contains(err.Error(), "execution reverted"))
+		})
+	}
+}
+
+func TestHyperlaneClientInterchainSecurityModule(t *testing.T) {
+	recipient := "0x" + hex.EncodeToString(make([]byte, 32))
+	validator := "0x0000000000000000000000000000000000000001"
+	multisigISMAddress := "osmo1multisig"
+
+	t.Run("routing ism is resolved into the module it routes to", func(t *testing.T) {
+		queryClient := mockwasm.NewMockQueryClient(t)
+		expectSmartQuery(t, queryClient, testMailboxAddress, map[string]any{"ism": testISMAddress})
+		expectSmartQuery(t, queryClient, testISMAddress, map[string]any{"typ": "routing"})
+		expectSmartQuery(t, queryClient, testISMAddress, map[string]any{"ism": multisigISMAddress})
+		expectSmartQuery(t, queryClient, multisigISMAddress, map[string]any{"typ": "message_id_multisig"})
+		expectSmartQuery(t, queryClient, multisigISMAddress, map[string]any{"threshold": 1, "validators": []string{validator}})
+
+		ism, err := newTestHyperlaneClient(queryClient).InterchainSecurityModule(context.Background(), testDomain, recipient, []byte{0x01})
+		require.NoError(t, err)
+		assert.Equal(t, types.ISMTypeRouting, ism.ModuleType)
+		require.Len(t, ism.Modules, 1)
+		assert.Equal(t, types.ISMTypeMessageIDMultisig, ism.Modules[0].ModuleType)
+		assert.Equal(t, uint8(1), ism.Modules[0].Threshold)
+		require.Len(t, ism.Modules[0].Validators, 1)
+		assert.Equal(t, validator, ism.Modules[0].Validators[0].Hex())
+	})
+
+	t.Run("query errors are returned", func(t *testing.T) {
+		queryClient := mockwasm.NewMockQueryClient(t)
+		queryClient.On("SmartContractState", mock.Anything, mock.Anything).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+
+		_, err := newTestHyperlaneClient(queryClient).InterchainSecurityModule(context.Background(), testDomain, recipient, []byte{0x01})
+		require.Error(t, err)
+	})
+
+	t.Run("unexpected domain is an error", func(t *testing.T) {
+		_, err := newTestHyperlaneClient(nil).InterchainSecurityModule(context.Background(), "1", recipient, []byte{0x01})
+		require.Error(t, err)
+	})
+}
+
+func newTestEvent(eventType string, attributes ...string) abcitypes.Event {
+	event := abcitypes.Event{Type: eventType}
+	for i := 0; i+1 < len(attributes); i += 2 {
+		event.Attributes = append(event.Attributes, abcitypes.EventAttribute{Key: attributes[i], Value: attributes[i+1]})
+	}
+	return event
+}
+
+func TestParseMerkleHookPostDispatches(t *testing.T) {
+	const hookAddress = "osmo1merklehook"
+	tx := &coretypes.ResultTx{TxResult: abcitypes.ExecTxResult{Events: []abcitypes.Event{
+		newTestEvent(merkleHookPostDispatchEventType, "_contract_address", hookAddress, "message_id", "0x01", "index", "4"),
+		newTestEvent("wasm", "_contract_address", hookAddress),
+		newTestEvent(merkleHookPostDispatchEventType, "_contract_address", hookAddress, "message_id", "0x02", "index", "5"),
+		newTestEvent(merkleHookPostDispatchEventType, "_contract_address", "osmo1otherhook", "message_id", "0x03", "index", "0"),
+	}}}
+
+	insertions, err := ParseMerkleHookPostDispatches(tx, hookAddress)
+	require.NoError(t, err)
+	assert.Equal(t, []types.MailboxMerkleHookPostDispatchEvent{
+		{MessageID: "0x01", Index: 4},
+		{MessageID: "0x02", Index: 5},
+	}, insertions)
+
+	insertions, err = ParseMerkleHookPostDispatches(tx, "")
+	require.NoError(t, err)
+	assert.Len(t, insertions, 3)
+
+	_, err = ParseMerkleHookPostDispatch(tx)
+	require.Error(t, err)
+}
+
+func TestParseDispatches(t *testing.T) {
+	firstMessage := hex.EncodeToString([]byte("first message"))
+	firstMessageID := hex.EncodeToString(crypto.Keccak256([]byte("first message")))
+	secondMessage := hex.EncodeToString([]byte("second message"))
+	secondMessageID := hex.EncodeToString(crypto.Keccak256([]byte("second message")))
+
+	dispatch := func(mailbox, sender, message string) abcitypes.Event {
+		return newTestEvent(dispatchEventType, "_contract_address", mailbox, "sender", sender, "destination", "1", "recipient", "recipient", "message", message)
+	}
+	dispatchID := func(mailbox, messageID string) abcitypes.Event {
+		return newTestEvent(dispatchIDEventType, "_contract_address", mailbox, "message_id", messageID)
+	}
+
+	t.Run("every dispatch in tx is parsed", func(t *testing.T) {
+		tx := &coretypes.ResultTx{TxResult: abcitypes.ExecTxResult{Events: []abcitypes.Event{
+			dispatch(testMailboxAddress, "sender1", firstMessage),
+			dispatchID(testMailboxAddress, firstMessageID),
+			dispatch("osmo1othermailbox", "sender1", firstMessage),
+			dispatchID("osmo1othermailbox", firstMessageID),
+			dispatch(testMailboxAddress, "sender2", secondMessage),
+			dispatchID(testMailboxAddress, secondMessageID),
+		}}}
+
+		dispatches, err := ParseDispatches(tx, testMailboxAddress)
+		require.NoError(t, err)
+		require.Len(t, dispatches, 2)
+		assert.Equal(t, "sender1", dispatches[0].Sender)
+		assert.Equal(t, firstMessage, dispatches[0].Message)
+		assert.Equal(t, firstMessageID, dispatches[0].MessageID)
+		assert.Equal(t, "sender2", dispatches[1].Sender)
+		assert.Equal(t, secondMessage, dispatches[1].Message)
+		assert.Equal(t, secondMessageID, dispatches[1].MessageID)
+
+		_, err = ParseDispatch(tx)
+		require.Error(t, err)
+	})
+
+	t.Run("missing dispatch id is an error", func(t *testing.T) {
+		tx := &coretypes.ResultTx{TxResult: abcitypes.ExecTxResult{Events: []abcitypes.Event{
+			dispatch(testMailboxAddress, "sender1", firstMessage),
+			dispatchID(testMailboxAddress, firstMessageID),
+			dispatch(testMailboxAddress, "sender2", secondMessage),
+		}}}
+
+		_, err := ParseDispatches(tx, testMailboxAddress)
+		require.Error(t, err)
+	})
+
+	t.Run("dispatch id of another message is an error", func(t *testing.T) {
+		tx := &coretypes.ResultTx{TxResult: abcitypes.ExecTxResult{Events: []abcitypes.Event{
+			dispatch(testMailboxAddress, "sender1", firstMessage),
+			dispatchID(testMailboxAddress, secondMessageID),
+		}}}
+
+		_, err := ParseDispatches(tx, testMailboxAddress)
+		require.Error(t, err)
+	})
+}
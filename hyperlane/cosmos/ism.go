@@ -0,0 +1,117 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cosmwasm "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+)
+
+type ISMQuerier struct {
+	client  cosmwasm.QueryClient
+	address string
+}
+
+func NewISMQuerier(address string, client cosmwasm.QueryClient) *ISMQuerier {
+	return &ISMQuerier{client, address}
+}
+
+type ISMQueryRequest struct {
+	ISM ISMQuery `json:"ism"`
+}
+
+type ISMQuery struct {
+	ModuleType *struct{}   `json:"module_type,omitempty"`
+	VerifyInfo *VerifyInfo `json:"verify_info,omitempty"`
+}
+
+type VerifyInfo struct {
+	Message string `json:"message"`
+}
+
+type RoutingISMQueryRequest struct {
+	RoutingISM RoutingISMQuery `json:"routing_ism"`
+}
+
+type RoutingISMQuery struct {
+	Route Route `json:"route"`
+}
+
+type Route struct {
+	Message string `json:"message"`
+}
+
+// ismTypes maps the cosmwasm hyperlane ism type names to their module type
+var ismTypes = map[string]uint8{
+	"unused":               0,
+	"routing":              types.ISMTypeRouting,
+	"aggregation":          types.ISMTypeAggregation,
+	"legacy_multisig":      3,
+	"merkle_root_multisig": types.ISMTypeMerkleRootMultisig,
+	"message_id_multisig":  types.ISMTypeMessageIDMultisig,
+	"null":                 6,
+	"ccip_read":            7,
+}
+
+func (q *ISMQuerier) ModuleType(ctx context.Context) (uint8, error) {
+	req := ISMQueryRequest{ISMQuery{ModuleType: &struct{}{}}}
+
+	type ModuleTypeResponse struct {
+		Typ json.RawMessage `json:"typ"`
+	}
+	var resp ModuleTypeResponse
+	if err := querySmartContract(ctx, q.client, q.address, req, &resp); err != nil {
+		return 0, fmt.Errorf("querying ism %s for module type: %w", q.address, err)
+	}
+
+	// the module type may be returned either as its name or its number
+	var name string
+	if err := json.Unmarshal(resp.Typ, &name); err == nil {
+		moduleType, ok := ismTypes[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown ism type %s for ism %s", name, q.address)
+		}
+		return moduleType, nil
+	}
+	var moduleType uint8
+	if err := json.Unmarshal(resp.Typ, &moduleType); err != nil {
+		return 0, fmt.Errorf("unmarshaling ism type %s for ism %s: %w", string(resp.Typ), q.address, err)
+	}
+	return moduleType, nil
+}
+
+// ValidatorsAndThreshold returns the validators and threshold of a multisig ism
+// for message, which is hex encoded
+func (q *ISMQuerier) ValidatorsAndThreshold(ctx context.Context, message string) ([]string, uint8, error) {
+	req := ISMQueryRequest{ISMQuery{VerifyInfo: &VerifyInfo{Message: strings.TrimPrefix(message, "0x")}}}
+
+	type VerifyInfoResponse struct {
+		Threshold  uint8    `json:"threshold"`
+		Validators []string `json:"validators"`
+	}
+	var resp VerifyInfoResponse
+	if err := querySmartContract(ctx, q.client, q.address, req, &resp); err != nil {
+		return nil, 0, fmt.Errorf("querying ism %s for verify info: %w", q.address, err)
+	}
+
+	return resp.Validators, resp.Threshold, nil
+}
+
+// Route returns the address of the ism that a routing ism routes message to,
+// message is hex encoded
+func (q *ISMQuerier) Route(ctx context.Context, message string) (string, error) {
+	req := RoutingISMQueryRequest{RoutingISMQuery{Route: Route{Message: strings.TrimPrefix(message, "0x")}}}
+
+	type RouteResponse struct {
+		Ism string `json:"ism"`
+	}
+	var resp RouteResponse
+	if err := querySmartContract(ctx, q.client, q.address, req, &resp); err != nil {
+		return "", fmt.Errorf("querying routing ism %s for route: %w", q.address, err)
+	}
+
+	return resp.Ism, nil
+}
@@ -0,0 +1,103 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cosmwasm "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+type MailboxQuerier struct {
+	client  cosmwasm.QueryClient
+	address string
+}
+
+func NewMailboxQuerier(address string, client cosmwasm.QueryClient) *MailboxQuerier {
+	return &MailboxQuerier{client, address}
+}
+
+type MailboxQueryRequest struct {
+	Mailbox MailboxQuery `json:"mailbox"`
+}
+
+type MailboxQuery struct {
+	MessageDelivered *MessageDelivered `json:"message_delivered,omitempty"`
+	RecipientIsm     *RecipientIsm     `json:"recipient_ism,omitempty"`
+}
+
+type MessageDelivered struct {
+	ID string `json:"id"`
+}
+
+type RecipientIsm struct {
+	RecipientAddr string `json:"recipient_addr"`
+}
+
+func (m *MailboxQuerier) MessageDelivered(ctx context.Context, messageID string) (bool, error) {
+	req := MailboxQueryRequest{
+		MailboxQuery{MessageDelivered: &MessageDelivered{ID: strings.TrimPrefix(messageID, "0x")}},
+	}
+
+	type MessageDeliveredResponse struct {
+		Delivered bool `json:"delivered"`
+	}
+	var resp MessageDeliveredResponse
+	if err := querySmartContract(ctx, m.client, m.address, req, &resp); err != nil {
+		return false, fmt.Errorf("querying mailbox %s for message %s delivered: %w", m.address, messageID, err)
+	}
+
+	return resp.Delivered, nil
+}
+
+func (m *MailboxQuerier) RecipientIsm(ctx context.Context, recipient string) (string, error) {
+	req := MailboxQueryRequest{
+		MailboxQuery{RecipientIsm: &RecipientIsm{RecipientAddr: recipient}},
+	}
+
+	type RecipientIsmResponse struct {
+		Ism string `json:"ism"`
+	}
+	var resp RecipientIsmResponse
+	if err := querySmartContract(ctx, m.client, m.address, req, &resp); err != nil {
+		return "", fmt.Errorf("querying mailbox %s for recipient %s ism: %w", m.address, recipient, err)
+	}
+
+	return resp.Ism, nil
+}
+
+type MailboxProcessEnvelope struct {
+	Process MailboxProcess `json:"process"`
+}
+
+type MailboxProcess struct {
+	Metadata string `json:"metadata"`
+	Message  string `json:"message"`
+}
+
+// querySmartContract json encodes req and queries the contract at address with
+// it, json decoding the response data into resp
+func querySmartContract(ctx context.Context, client cosmwasm.QueryClient, address string, req any, resp any) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshaling query request: %w", err)
+	}
+
+	res, err := client.SmartContractState(ctx, &cosmwasm.QuerySmartContractStateRequest{
+		Address:   address,
+		QueryData: data,
+	})
+	if err != nil {
+		return fmt.Errorf("querying smart contract %s: %w", address, err)
+	}
+	if res.Data == nil {
+		return fmt.Errorf("got nil response when querying smart contract %s", address)
+	}
+
+	if err := json.Unmarshal(res.Data, resp); err != nil {
+		return fmt.Errorf("unmarshaling query bytes into formatted data: %w", err)
+	}
+
+	return nil
+}
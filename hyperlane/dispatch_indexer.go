@@ -0,0 +1,233 @@
+package hyperlane
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"go.uber.org/zap"
+)
+
+const (
+	dispatchIndexInterval = 30 * time.Second
+
+	// dispatchEnqueueGracePeriod is how long a dispatch is left for the code
+	// path that sent it (i.e. the order settler) to submit it to be relayed,
+	// before the dispatch indexer submits it itself
+	dispatchEnqueueGracePeriod = 5 * time.Minute
+)
+
+type DispatchIndexerDatabase interface {
+	GetHyperlaneDispatchIndexerMetadata(ctx context.Context, chainID string) (db.HyperlaneDispatchIndexerMetadatum, error)
+	InsertHyperlaneDispatchIndexerMetadata(ctx context.Context, arg db.InsertHyperlaneDispatchIndexerMetadataParams) (db.HyperlaneDispatchIndexerMetadatum, error)
+	GetHyperlaneTransferByMessageID(ctx context.Context, arg db.GetHyperlaneTransferByMessageIDParams) (db.HyperlaneTransfer, error)
+}
+
+type TxRelaySubmitter interface {
+	SubmitTxToRelay(ctx context.Context, txHash string, sourceChainID string, maxTxFeeUUSDC *big.Int) (int64, error)
+}
+
+// DispatchIndexer indexes the mailbox dispatches on every configured origin
+// chain that were sent by the fast transfer gateway in a tx signed by the
+// solver, and submits any that have not been submitted to be relayed. This
+// ensures that settlements and timeouts are relayed even if they were
+// initiated manually or the process that initiated them crashed before
+// submitting them.
+type DispatchIndexer struct {
+	db        DispatchIndexerDatabase
+	hyperlane Client
+	relayer   TxRelaySubmitter
+
+	chains map[string]*indexedDispatchChain
+}
+
+type indexedDispatchChain struct {
+	// nextHeight is the next block height to index dispatches from
+	nextHeight uint64
+	// pending are dispatches that are waiting for their grace period to pass
+	// before they are submitted to be relayed
+	pending []pendingDispatch
+}
+
+type pendingDispatch struct {
+	dispatch types.MailboxDispatchTx
+	seenAt   time.Time
+}
+
+func NewDispatchIndexer(db DispatchIndexerDatabase, hyperlaneClient Client, relayer TxRelaySubmitter) *DispatchIndexer {
+	return &DispatchIndexer{
+		db:        db,
+		hyperlane: hyperlaneClient,
+		relayer:   relayer,
+		chains:    make(map[string]*indexedDispatchChain),
+	}
+}
+
+func (i *DispatchIndexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatchIndexInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, chainConfig := range config.GetConfigReader(ctx).Config().Chains {
+				if !shouldIndexDispatches(chainConfig) {
+					continue
+				}
+				if err := i.indexChain(ctx, chainConfig, time.Now()); err != nil {
+					lmt.Logger(ctx).Error(
+						"error indexing hyperlane dispatches",
+						zap.String("chainID", chainConfig.ChainID),
+						zap.Error(err),
+					)
+				}
+			}
+		}
+	}
+}
+
+func shouldIndexDispatches(chainConfig config.ChainConfig) bool {
+	return chainConfig.HyperlaneDomain != "" &&
+		chainConfig.Relayer.MailboxAddress != "" &&
+		chainConfig.FastTransferContractAddress != "" &&
+		chainConfig.SolverAddress != ""
+}
+
+// indexChain indexes new dispatches on a chain and submits the dispatches
+// whose grace period has passed by now to be relayed
+func (i *DispatchIndexer) indexChain(ctx context.Context, chainConfig config.ChainConfig, now time.Time) error {
+	chain, err := i.chain(ctx, chainConfig)
+	if err != nil {
+		return err
+	}
+
+	for {
+		dispatches, toHeight, err := i.hyperlane.MailboxDispatches(ctx, chainConfig.HyperlaneDomain, chainConfig.FastTransferContractAddress, chain.nextHeight)
+		if err != nil {
+			return fmt.Errorf("getting mailbox dispatches from height %d: %w", chain.nextHeight, err)
+		}
+		if toHeight < chain.nextHeight {
+			// caught up to the latest height
+			break
+		}
+
+		for _, dispatch := range dispatches {
+			if !strings.EqualFold(dispatch.TxSender, chainConfig.SolverAddress) {
+				continue
+			}
+			chain.pending = append(chain.pending, pendingDispatch{dispatch: dispatch, seenAt: now})
+		}
+		chain.nextHeight = toHeight + 1
+	}
+
+	var stillPending []pendingDispatch
+	for _, pending := range chain.pending {
+		if now.Sub(pending.seenAt) < dispatchEnqueueGracePeriod {
+			stillPending = append(stillPending, pending)
+			continue
+		}
+		if err := i.submit(ctx, chainConfig.ChainID, pending.dispatch); err != nil {
+			lmt.Logger(ctx).Warn(
+				"error submitting indexed hyperlane dispatch to be relayed, retrying on next index",
+				zap.String("sourceChainID", chainConfig.ChainID),
+				zap.String("txHash", pending.dispatch.TxHash),
+				zap.Error(err),
+			)
+			stillPending = append(stillPending, pending)
+		}
+	}
+	chain.pending = stillPending
+
+	// only persist the height up to the first dispatch that is still pending
+	// so that pending dispatches are indexed again after a restart
+	heightLastSeen := chain.nextHeight - 1
+	for _, pending := range chain.pending {
+		heightLastSeen = min(heightLastSeen, pending.dispatch.Height-1)
+	}
+	if _, err := i.db.InsertHyperlaneDispatchIndexerMetadata(ctx, db.InsertHyperlaneDispatchIndexerMetadataParams{
+		ChainID:        chainConfig.ChainID,
+		HeightLastSeen: int64(heightLastSeen),
+	}); err != nil {
+		return fmt.Errorf("updating hyperlane dispatch indexer metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (i *DispatchIndexer) chain(ctx context.Context, chainConfig config.ChainConfig) (*indexedDispatchChain, error) {
+	if chain, ok := i.chains[chainConfig.ChainID]; ok {
+		return chain, nil
+	}
+
+	chain := &indexedDispatchChain{}
+	metadata, err := i.db.GetHyperlaneDispatchIndexerMetadata(ctx, chainConfig.ChainID)
+	switch {
+	case err == nil:
+		chain.nextHeight = uint64(metadata.HeightLastSeen) + 1
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("getting hyperlane dispatch indexer metadata: %w", err)
+	case chainConfig.Relayer.DispatchIndexerStartHeight != 0:
+		chain.nextHeight = chainConfig.Relayer.DispatchIndexerStartHeight
+	default:
+		// nothing has been indexed on this chain and no start height is
+		// configured, start indexing new dispatches from the latest height
+		latestHeight, err := i.hyperlane.LatestHeight(ctx, chainConfig.HyperlaneDomain)
+		if err != nil {
+			return nil, fmt.Errorf("getting latest height: %w", err)
+		}
+		chain.nextHeight = latestHeight
+	}
+
+	i.chains[chainConfig.ChainID] = chain
+	return chain, nil
+}
+
+// submit submits a dispatch to be relayed if it has not already been
+func (i *DispatchIndexer) submit(ctx context.Context, sourceChainID string, dispatch types.MailboxDispatchTx) error {
+	destinationChainID, err := config.GetConfigReader(ctx).GetChainIDByHyperlaneDomain(dispatch.Dispatch.DestinationDomain)
+	if err != nil {
+		lmt.Logger(ctx).Debug(
+			"skipping indexed hyperlane dispatch to unconfigured domain",
+			zap.String("destinationDomain", dispatch.Dispatch.DestinationDomain),
+			zap.String("txHash", dispatch.TxHash),
+		)
+		return nil
+	}
+
+	_, err = i.db.GetHyperlaneTransferByMessageID(ctx, db.GetHyperlaneTransferByMessageIDParams{
+		SourceChainID:      sourceChainID,
+		DestinationChainID: destinationChainID,
+		MessageID:          dispatch.Dispatch.MessageID,
+	})
+	if err == nil {
+		// already submitted to be relayed
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("getting hyperlane transfer for message %s: %w", dispatch.Dispatch.MessageID, err)
+	}
+
+	id, err := i.relayer.SubmitTxToRelay(ctx, dispatch.TxHash, sourceChainID, nil)
+	if err != nil {
+		return fmt.Errorf("submitting tx %s to relay: %w", dispatch.TxHash, err)
+	}
+
+	lmt.Logger(ctx).Info(
+		"submitted indexed hyperlane dispatch to be relayed",
+		zap.Int64("transferId", id),
+		zap.String("sourceChainID", sourceChainID),
+		zap.String("destinationChainID", destinationChainID),
+		zap.String("txHash", dispatch.TxHash),
+		zap.String("messageID", dispatch.Dispatch.MessageID),
+	)
+	return nil
+}
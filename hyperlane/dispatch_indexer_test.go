@@ -0,0 +1,141 @@
+package hyperlane
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGateway = "0x2222222222222222222222222222222222222222"
+	testSolver  = "0x3333333333333333333333333333333333333333"
+)
+
+// fakeDispatchClient serves dispatches from a fixed set of blocks
+type fakeDispatchClient struct {
+	Client
+	latestHeight uint64
+	dispatches   []types.MailboxDispatchTx
+}
+
+func (c *fakeDispatchClient) LatestHeight(ctx context.Context, domain string) (uint64, error) {
+	return c.latestHeight, nil
+}
+
+func (c *fakeDispatchClient) MailboxDispatches(ctx context.Context, domain string, sender string, fromHeight uint64) ([]types.MailboxDispatchTx, uint64, error) {
+	if fromHeight > c.latestHeight {
+		return nil, fromHeight - 1, nil
+	}
+	var dispatches []types.MailboxDispatchTx
+	for _, dispatch := range c.dispatches {
+		if dispatch.Height >= fromHeight && dispatch.Dispatch.Sender == sender {
+			dispatches = append(dispatches, dispatch)
+		}
+	}
+	return dispatches, c.latestHeight, nil
+}
+
+type fakeDispatchIndexerDB struct {
+	heightLastSeen map[string]int64
+	transfers      map[string]bool
+}
+
+func (d *fakeDispatchIndexerDB) GetHyperlaneDispatchIndexerMetadata(ctx context.Context, chainID string) (db.HyperlaneDispatchIndexerMetadatum, error) {
+	height, ok := d.heightLastSeen[chainID]
+	if !ok {
+		return db.HyperlaneDispatchIndexerMetadatum{}, sql.ErrNoRows
+	}
+	return db.HyperlaneDispatchIndexerMetadatum{ChainID: chainID, HeightLastSeen: height}, nil
+}
+
+func (d *fakeDispatchIndexerDB) InsertHyperlaneDispatchIndexerMetadata(ctx context.Context, arg db.InsertHyperlaneDispatchIndexerMetadataParams) (db.HyperlaneDispatchIndexerMetadatum, error) {
+	d.heightLastSeen[arg.ChainID] = arg.HeightLastSeen
+	return db.HyperlaneDispatchIndexerMetadatum{ChainID: arg.ChainID, HeightLastSeen: arg.HeightLastSeen}, nil
+}
+
+func (d *fakeDispatchIndexerDB) GetHyperlaneTransferByMessageID(ctx context.Context, arg db.GetHyperlaneTransferByMessageIDParams) (db.HyperlaneTransfer, error) {
+	if !d.transfers[arg.MessageID] {
+		return db.HyperlaneTransfer{}, sql.ErrNoRows
+	}
+	return db.HyperlaneTransfer{MessageID: arg.MessageID}, nil
+}
+
+type fakeTxRelaySubmitter struct {
+	submitted []string
+}
+
+func (s *fakeTxRelaySubmitter) SubmitTxToRelay(ctx context.Context, txHash string, sourceChainID string, maxTxFeeUUSDC *big.Int) (int64, error) {
+	s.submitted = append(s.submitted, txHash)
+	return int64(len(s.submitted)), nil
+}
+
+func TestDispatchIndexer(t *testing.T) {
+	chainConfig := config.ChainConfig{
+		ChainID:                     "1",
+		HyperlaneDomain:             "1",
+		FastTransferContractAddress: testGateway,
+		SolverAddress:               testSolver,
+		Relayer: config.RelayerConfig{
+			MailboxAddress:             "0x4444444444444444444444444444444444444444",
+			DispatchIndexerStartHeight: 100,
+		},
+	}
+	ctx := config.ConfigReaderContext(context.Background(), config.NewConfigReader(config.Config{
+		Chains: map[string]config.ChainConfig{
+			"1":     chainConfig,
+			"42161": {ChainID: "42161", HyperlaneDomain: "42161"},
+		},
+	}))
+
+	dispatch := func(height uint64, txHash string, txSender string, messageID string) types.MailboxDispatchTx {
+		return types.MailboxDispatchTx{
+			Dispatch: types.MailboxDispatchEvent{
+				DestinationDomain: "42161",
+				Sender:            testGateway,
+				MessageID:         messageID,
+			},
+			TxHash:   txHash,
+			TxSender: txSender,
+			Height:   height,
+		}
+	}
+
+	client := &fakeDispatchClient{
+		latestHeight: 120,
+		dispatches: []types.MailboxDispatchTx{
+			dispatch(105, "0xsolver", testSolver, "aa"),
+			dispatch(106, "0xuser", "0x5555555555555555555555555555555555555555", "bb"),
+			dispatch(107, "0xalreadysubmitted", testSolver, "cc"),
+		},
+	}
+	database := &fakeDispatchIndexerDB{heightLastSeen: make(map[string]int64), transfers: map[string]bool{"cc": true}}
+	submitter := &fakeTxRelaySubmitter{}
+	indexer := NewDispatchIndexer(database, client, submitter)
+
+	now := time.Now()
+	require.NoError(t, indexer.indexChain(ctx, chainConfig, now))
+
+	// nothing is submitted during the grace period, and the persisted height
+	// stays before the first pending dispatch
+	assert.Empty(t, submitter.submitted)
+	assert.Equal(t, int64(104), database.heightLastSeen["1"])
+
+	require.NoError(t, indexer.indexChain(ctx, chainConfig, now.Add(dispatchEnqueueGracePeriod)))
+
+	// only the solvers dispatch that was not already submitted is submitted
+	assert.Equal(t, []string{"0xsolver"}, submitter.submitted)
+	assert.Equal(t, int64(120), database.heightLastSeen["1"])
+
+	// a restarted indexer resumes from the persisted height
+	restarted := NewDispatchIndexer(database, client, submitter)
+	require.NoError(t, restarted.indexChain(ctx, chainConfig, now.Add(2*dispatchEnqueueGracePeriod)))
+	assert.Equal(t, []string{"0xsolver"}, submitter.submitted)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -12,9 +13,13 @@ import (
 	evmtxexecutor "github.com/skip-mev/go-fast-solver/shared/txexecutor/evm"
 
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	aggregation_ism "github.com/skip-mev/go-fast-solver/shared/contracts/hyperlane/AggregationIsm"
 	interchain_security_module "github.com/skip-mev/go-fast-solver/shared/contracts/hyperlane/InterchainSecurityModule"
 	mailbox "github.com/skip-mev/go-fast-solver/shared/contracts/hyperlane/Mailbox"
+	merkle_tree_hook "github.com/skip-mev/go-fast-solver/shared/contracts/hyperlane/MerkleTreeHook"
 	multisig_ism "github.com/skip-mev/go-fast-solver/shared/contracts/hyperlane/MultisigIsm"
+	routing_ism "github.com/skip-mev/go-fast-solver/shared/contracts/hyperlane/RoutingIsm"
+	validator_announce "github.com/skip-mev/go-fast-solver/shared/contracts/hyperlane/ValidatorAnnounce"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,14 +33,17 @@ import (
 
 type TxPriceOracle interface {
 	TxFeeUUSDC(ctx context.Context, tx *ethtypes.Transaction) (*big.Int, error)
+	GasCostUUSDC(ctx context.Context, txFee *big.Int, chainID string) (*big.Int, error)
 }
 
 type HyperlaneClient struct {
-	client          evmrpc.EVMChainRPC
-	chainID         string
-	hyperlaneDomain string
-	mailboxAddress  common.Address
-	keystore        keys.KeyStore
+	client                   evmrpc.EVMChainRPC
+	chainID                  string
+	hyperlaneDomain          string
+	mailboxAddress           common.Address
+	merkleHookAddress        common.Address
+	validatorAnnounceAddress common.Address
+	keystore                 keys.KeyStore
 
 	ismAddress     *common.Address
 	ismAddressLock sync.RWMutex
@@ -68,18 +76,115 @@ func NewHyperlaneClient(
 	}
 
 	return &HyperlaneClient{
-		client:          client,
-		chainID:         chainID,
-		hyperlaneDomain: hyperlaneDomain,
-		mailboxAddress:  common.HexToAddress(chainConfig.Relayer.MailboxAddress),
-		keystore:        keystore,
-		txPriceOracle:   priceOracle,
-		txExecutor:      txSubmitter,
+		client:                   client,
+		chainID:                  chainID,
+		hyperlaneDomain:          hyperlaneDomain,
+		mailboxAddress:           common.HexToAddress(chainConfig.Relayer.MailboxAddress),
+		merkleHookAddress:        common.HexToAddress(chainConfig.Relayer.MerkleHookContractAddress),
+		validatorAnnounceAddress: common.HexToAddress(chainConfig.Relayer.ValidatorAnnounceContractAddress),
+		keystore:                 keystore,
+		txPriceOracle:            priceOracle,
+		txExecutor:               txSubmitter,
 	}, nil
 }
 
 func (c *HyperlaneClient) GetHyperlaneDispatch(ctx context.Context, domain, originChainID, initiateTxHash string) (*types.MailboxDispatchEvent, *types.MailboxMerkleHookPostDispatchEvent, error) {
-	panic("not implemented")
+	if domain != c.hyperlaneDomain {
+		return nil, nil, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	receipt, err := c.client.GetTxReceipt(ctx, initiateTxHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting tx receipt for tx %s on chain %s: %w", initiateTxHash, originChainID, err)
+	}
+
+	originMailbox, err := mailbox.NewMailboxFilterer(c.mailboxAddress, c.client.Client())
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating mailbox filterer for address %s: %w", c.mailboxAddress.String(), err)
+	}
+	merkleHook, err := merkle_tree_hook.NewMerkleTreeHookFilterer(c.merkleHookAddress, c.client.Client())
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating merkle tree hook filterer for address %s: %w", c.merkleHookAddress.String(), err)
+	}
+
+	var dispatch *types.MailboxDispatchEvent
+	var messageID string
+	var merkleHookPostDispatch *types.MailboxMerkleHookPostDispatchEvent
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		switch {
+		case log.Address == c.mailboxAddress && log.Topics[0] == mailboxDispatchEventID:
+			if dispatch != nil {
+				return nil, nil, fmt.Errorf("found multiple dispatch events in tx %s", initiateTxHash)
+			}
+			event, err := originMailbox.ParseDispatch(*log)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing dispatch event from tx %s: %w", initiateTxHash, err)
+			}
+			dispatch = &types.MailboxDispatchEvent{
+				Recipient:         hex.EncodeToString(event.Recipient[:]),
+				Message:           hex.EncodeToString(event.Message),
+				DestinationDomain: strconv.FormatUint(uint64(event.Destination), 10),
+				SenderMailbox:     c.mailboxAddress.String(),
+				Sender:            event.Sender.String(),
+			}
+		case log.Address == c.mailboxAddress && log.Topics[0] == mailboxDispatchIDEventID:
+			if messageID != "" {
+				return nil, nil, fmt.Errorf("found multiple dispatch message id events in tx %s", initiateTxHash)
+			}
+			event, err := originMailbox.ParseDispatchId(*log)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing dispatch id event from tx %s: %w", initiateTxHash, err)
+			}
+			messageID = hex.EncodeToString(event.MessageId[:])
+		case log.Address == c.merkleHookAddress && log.Topics[0] == merkleHookInsertedIntoTreeEventID:
+			if merkleHookPostDispatch != nil {
+				return nil, nil, fmt.Errorf("found multiple merkle tree insertion events in tx %s", initiateTxHash)
+			}
+			event, err := merkleHook.ParseInsertedIntoTree(*log)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing inserted into tree event from tx %s: %w", initiateTxHash, err)
+			}
+			merkleHookPostDispatch = &types.MailboxMerkleHookPostDispatchEvent{
+				MessageID: hex.EncodeToString(event.MessageId[:]),
+				Index:     uint64(event.Index),
+			}
+		}
+	}
+
+	if dispatch == nil {
+		return nil, nil, fmt.Errorf("could not find dispatch event in tx %s", initiateTxHash)
+	}
+	if messageID == "" {
+		return nil, nil, fmt.Errorf("could not find dispatch message id event in tx %s", initiateTxHash)
+	}
+	if merkleHookPostDispatch == nil {
+		return nil, nil, fmt.Errorf("could not find merkle tree insertion event in tx %s", initiateTxHash)
+	}
+	dispatch.MessageID = messageID
+
+	return dispatch, merkleHookPostDispatch, nil
+}
+
+var (
+	mailboxDispatchEventID            = mustEventID(mailbox.MailboxMetaData, "Dispatch")
+	mailboxDispatchIDEventID          = mustEventID(mailbox.MailboxMetaData, "DispatchId")
+	merkleHookInsertedIntoTreeEventID = mustEventID(merkle_tree_hook.MerkleTreeHookMetaData, "InsertedIntoTree")
+)
+
+func mustEventID(metadata *bind.MetaData, event string) common.Hash {
+	abi, err := metadata.GetAbi()
+	if err != nil {
+		panic(fmt.Sprintf("getting abi: %v", err))
+	}
+	e, ok := abi.Events[event]
+	if !ok {
+		panic(fmt.Sprintf("event %s not found in abi", event))
+	}
+	return e.ID
 }
 
 func (c *HyperlaneClient) HasBeenDelivered(ctx context.Context, domain string, messageID string) (bool, error) {
@@ -122,6 +227,10 @@ func (c *HyperlaneClient) ISMType(ctx context.Context, domain string, recipient
 		return 0, fmt.Errorf("getting ism address for recipeint %s on domain %s: %w", recipient, domain, err)
 	}
 
+	return c.moduleType(ctx, ismAddress)
+}
+
+func (c *HyperlaneClient) moduleType(ctx context.Context, ismAddress common.Address) (uint8, error) {
 	ism, err := interchain_security_module.NewInterchainSecurityModuleCaller(ismAddress, c.client.Client())
 	if err != nil {
 		return 0, fmt.Errorf("creating ism contract caller for address %s: %w", ismAddress.String(), err)
@@ -139,10 +248,6 @@ func (c *HyperlaneClient) ISMType(ctx context.Context, domain string, recipient
 	return ismType, nil
 }
 
-const (
-	ismTypeMessageIDMultisig = 5
-)
-
 func (c *HyperlaneClient) ValidatorsAndThreshold(
 	ctx context.Context,
 	domain string,
@@ -164,22 +269,125 @@ func (c *HyperlaneClient) ValidatorsAndThreshold(
 	}
 
 	switch ismType {
-	case ismTypeMessageIDMultisig:
-		multisigISM, err := multisig_ism.NewMultisigIsmCaller(ismAddress, c.client.Client())
+	case types.ISMTypeMessageIDMultisig:
+		return c.multisigValidatorsAndThreshold(ctx, ismAddress, []byte(message))
+	default:
+		return nil, 0, fmt.Errorf("ism type %d not supported", ismType)
+	}
+}
+
+func (c *HyperlaneClient) multisigValidatorsAndThreshold(ctx context.Context, ismAddress common.Address, message []byte) ([]common.Address, uint8, error) {
+	multisigISM, err := multisig_ism.NewMultisigIsmCaller(ismAddress, c.client.Client())
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating multisign ism contract caller for address %s: %w", ismAddress.String(), err)
+	}
+	multisigISMSession := multisig_ism.MultisigIsmCallerSession{Contract: multisigISM, CallOpts: bind.CallOpts{Context: ctx}}
+
+	validatorsAndThreshold, err := multisigISMSession.ValidatorsAndThreshold(message)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetching validators and threshold from multisig ism at address %s: %w", ismAddress.String(), err)
+	}
+
+	return validatorsAndThreshold.Validators, validatorsAndThreshold.Threshold, nil
+}
+
+// maxISMDepth is the maximum number of nested aggregation or routing isms that
+// will be resolved, to protect against misconfigured isms that reference
+// themselves
+const maxISMDepth = 4
+
+// InterchainSecurityModule resolves the ism that message will be verified by
+// when delivered to recipient. Aggregation isms are resolved recursively into
+// their sub modules, and routing isms into the module that they route message
+// to.
+func (c *HyperlaneClient) InterchainSecurityModule(
+	ctx context.Context,
+	domain string,
+	recipient string,
+	message []byte,
+) (*types.InterchainSecurityModule, error) {
+	if domain != c.hyperlaneDomain {
+		return nil, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	ismAddress, err := c.getISMAddress(ctx, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("getting ism address for recipeint %s on domain %s: %w", recipient, domain, err)
+	}
+
+	return c.interchainSecurityModule(ctx, ismAddress, message, 0)
+}
+
+func (c *HyperlaneClient) interchainSecurityModule(
+	ctx context.Context,
+	ismAddress common.Address,
+	message []byte,
+	depth int,
+) (*types.InterchainSecurityModule, error) {
+	if depth > maxISMDepth {
+		return nil, fmt.Errorf("ism at address %s is nested more than %d levels deep", ismAddress.String(), maxISMDepth)
+	}
+
+	moduleType, err := c.moduleType(ctx, ismAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ism := &types.InterchainSecurityModule{
+		Address:    ismAddress.String(),
+		ModuleType: moduleType,
+	}
+
+	switch moduleType {
+	case types.ISMTypeMessageIDMultisig, types.ISMTypeMerkleRootMultisig:
+		validators, threshold, err := c.multisigValidatorsAndThreshold(ctx, ismAddress, message)
 		if err != nil {
-			return nil, 0, fmt.Errorf("creating multisign ism contract caller for address %s: %w", ismAddress.String(), err)
+			return nil, err
 		}
-		multisigISMSession := multisig_ism.MultisigIsmCallerSession{Contract: multisigISM, CallOpts: bind.CallOpts{Context: ctx}}
+		ism.Validators = validators
+		ism.Threshold = threshold
+	case types.ISMTypeAggregation:
+		aggregationISM, err := aggregation_ism.NewAggregationIsmCaller(ismAddress, c.client.Client())
+		if err != nil {
+			return nil, fmt.Errorf("creating aggregation ism contract caller for address %s: %w", ismAddress.String(), err)
+		}
+		aggregationISMSession := aggregation_ism.AggregationIsmCallerSession{Contract: aggregationISM, CallOpts: bind.CallOpts{Context: ctx}}
 
-		validatorsAndThreshold, err := multisigISMSession.ValidatorsAndThreshold([]byte(message))
+		modulesAndThreshold, err := aggregationISMSession.ModulesAndThreshold(message)
 		if err != nil {
-			return nil, 0, fmt.Errorf("fetching validators and threshold from multisig ism at address %s: %w", ismAddress.String(), err)
+			return nil, fmt.Errorf("fetching modules and threshold from aggregation ism at address %s: %w", ismAddress.String(), err)
 		}
 
-		return validatorsAndThreshold.Validators, validatorsAndThreshold.Threshold, nil
-	default:
-		return nil, 0, fmt.Errorf("ism type %d not supported", ismType)
+		ism.Threshold = modulesAndThreshold.Threshold
+		for _, moduleAddress := range modulesAndThreshold.Modules {
+			module, err := c.interchainSecurityModule(ctx, moduleAddress, message, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("resolving sub module %s of aggregation ism %s: %w", moduleAddress.String(), ismAddress.String(), err)
+			}
+			ism.Modules = append(ism.Modules, *module)
+		}
+	case types.ISMTypeRouting:
+		routingISM, err := routing_ism.NewRoutingIsmCaller(ismAddress, c.client.Client())
+		if err != nil {
+			return nil, fmt.Errorf("creating routing ism contract caller for address %s: %w", ismAddress.String(), err)
+		}
+		routingISMSession := routing_ism.RoutingIsmCallerSession{Contract: routingISM, CallOpts: bind.CallOpts{Context: ctx}}
+
+		moduleAddress, err := routingISMSession.Route(message)
+		if err != nil {
+			return nil, fmt.Errorf("routing message through routing ism at address %s: %w", ismAddress.String(), err)
+		}
+
+		module, err := c.interchainSecurityModule(ctx, moduleAddress, message, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("resolving module %s routed to by routing ism %s: %w", moduleAddress.String(), ismAddress.String(), err)
+		}
+		ism.Modules = []types.InterchainSecurityModule{*module}
 	}
+
+	// isms of other types are returned with only their type set, it is up to
+	// the caller to decide if they can build metadata for them
+	return ism, nil
 }
 
 func (c *HyperlaneClient) getISMAddress(ctx context.Context, recipient string) (common.Address, error) {
@@ -269,6 +477,59 @@ func (c *HyperlaneClient) Process(ctx context.Context, domain string, message []
 	return txHashBytes, rawTx, nil
 }
 
+// ReplaceProcess replaces a process tx that is stuck in the mempool with a tx
+// that has the same nonce and bumped fees. The replacement's max tx fee is
+// capped at maxTxFeeUUSDC, if it is not nil.
+func (c *HyperlaneClient) ReplaceProcess(ctx context.Context, domain string, rawTx string, maxTxFeeUUSDC *big.Int) ([]byte, string, error) {
+	if domain != c.hyperlaneDomain {
+		return nil, "", fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	signer, err := c.signer(ctx, domain)
+	if err != nil {
+		return nil, "", fmt.Errorf("getting signer: %w", err)
+	}
+
+	var maxTxFee *big.Int
+	if maxTxFeeUUSDC != nil {
+		maxTxFee, err = c.uusdcToGasToken(ctx, maxTxFeeUUSDC)
+		if err != nil {
+			return nil, "", fmt.Errorf("converting max tx fee of %s uusdc to gas token: %w", maxTxFeeUUSDC.String(), err)
+		}
+	}
+
+	txHash, replacementRawTx, err := c.txExecutor.ReplaceTx(ctx, c.chainID, rawTx, maxTxFee, signer)
+	if err != nil {
+		return nil, "", fmt.Errorf("replacing process tx on destination mailbox: %w", err)
+	}
+
+	txHashBytes, err := hex.DecodeString(strings.TrimPrefix(txHash, "0x"))
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding replacement process tx hash %s: %w", txHash, err)
+	}
+
+	return txHashBytes, replacementRawTx, nil
+}
+
+// uusdcToGasToken converts an amount of uusdc to the equivalent amount of the
+// chains gas token in its smallest denomination
+func (c *HyperlaneClient) uusdcToGasToken(ctx context.Context, uusdc *big.Int) (*big.Int, error) {
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(c.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting config for chain %s: %w", c.chainID, err)
+	}
+	oneGasToken := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(chainConfig.GasTokenDecimals)), nil)
+	gasTokenPriceUUSDC, err := c.txPriceOracle.GasCostUUSDC(ctx, oneGasToken, c.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting gas token price in uusdc: %w", err)
+	}
+	if gasTokenPriceUUSDC.Sign() <= 0 {
+		return nil, fmt.Errorf("gas token price of %s uusdc must be positive", gasTokenPriceUUSDC.String())
+	}
+	wei := new(big.Int).Mul(uusdc, oneGasToken)
+	return wei.Div(wei, gasTokenPriceUUSDC), nil
+}
+
 func (c *HyperlaneClient) QuoteProcessUUSDC(ctx context.Context, domain string, message []byte, metadata []byte) (*big.Int, error) {
 	abi, err := mailbox.MailboxMetaData.GetAbi()
 	if err != nil {
@@ -316,7 +577,157 @@ func (c *HyperlaneClient) QuoteProcessUUSDC(ctx context.Context, domain string,
 }
 
 func (c *HyperlaneClient) MerkleTreeLeafCount(ctx context.Context, domain string) (uint64, error) {
-	panic("not implemented")
+	if domain != c.hyperlaneDomain {
+		return 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	merkleHook, err := merkle_tree_hook.NewMerkleTreeHookCaller(c.merkleHookAddress, c.client.Client())
+	if err != nil {
+		return 0, fmt.Errorf("creating merkle tree hook caller for address %s: %w", c.merkleHookAddress.String(), err)
+	}
+	count, err := merkleHook.Count(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, fmt.Errorf("querying merkle tree hook at %s for count: %w", c.merkleHookAddress.String(), err)
+	}
+
+	return uint64(count), nil
+}
+
+// maxMerkleTreeInsertionsBlockRange is the max number of blocks that will be
+// queried for merkle tree insertion events in a single call, since most rpc
+// providers limit the block range of log queries
+const maxMerkleTreeInsertionsBlockRange = 10_000
+
+// MerkleTreeInsertions returns the insertions into the merkle tree hook that
+// were emitted from fromHeight up to and including the returned height. The
+// returned height is capped at the chains latest height, so a returned height
+// that is less than fromHeight means there are no new blocks to index.
+func (c *HyperlaneClient) MerkleTreeInsertions(ctx context.Context, domain string, fromHeight uint64) ([]types.MailboxMerkleHookPostDispatchEvent, uint64, error) {
+	if domain != c.hyperlaneDomain {
+		return nil, 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting latest block header: %w", err)
+	}
+	latestHeight := header.Number.Uint64()
+	if fromHeight > latestHeight {
+		return nil, fromHeight - 1, nil
+	}
+	toHeight := min(fromHeight+maxMerkleTreeInsertionsBlockRange-1, latestHeight)
+
+	merkleHook, err := merkle_tree_hook.NewMerkleTreeHookFilterer(c.merkleHookAddress, c.client.Client())
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating merkle tree hook filterer for address %s: %w", c.merkleHookAddress.String(), err)
+	}
+	iterator, err := merkleHook.FilterInsertedIntoTree(&bind.FilterOpts{Context: ctx, Start: fromHeight, End: &toHeight})
+	if err != nil {
+		return nil, 0, fmt.Errorf("filtering merkle tree hook inserted into tree events from height %d to %d: %w", fromHeight, toHeight, err)
+	}
+	defer iterator.Close()
+
+	var insertions []types.MailboxMerkleHookPostDispatchEvent
+	for iterator.Next() {
+		insertions = append(insertions, types.MailboxMerkleHookPostDispatchEvent{
+			MessageID: hex.EncodeToString(iterator.Event.MessageId[:]),
+			Index:     uint64(iterator.Event.Index),
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, 0, fmt.Errorf("iterating merkle tree hook inserted into tree events: %w", err)
+	}
+
+	return insertions, toHeight, nil
+}
+
+// MailboxDispatches returns the dispatches from sender on the mailbox that
+// were emitted from fromHeight up to and including the returned height. The
+// returned height is capped at the chains latest height, so a returned height
+// that is less than fromHeight means there are no new blocks to index.
+func (c *HyperlaneClient) MailboxDispatches(ctx context.Context, domain string, sender string, fromHeight uint64) ([]types.MailboxDispatchTx, uint64, error) {
+	if domain != c.hyperlaneDomain {
+		return nil, 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+	if !common.IsHexAddress(sender) {
+		return nil, 0, fmt.Errorf("invalid sender address %s", sender)
+	}
+
+	latestHeight, err := c.LatestHeight(ctx, domain)
+	if err != nil {
+		return nil, 0, err
+	}
+	if fromHeight > latestHeight {
+		return nil, fromHeight - 1, nil
+	}
+	toHeight := min(fromHeight+maxMerkleTreeInsertionsBlockRange-1, latestHeight)
+
+	originMailbox, err := mailbox.NewMailboxFilterer(c.mailboxAddress, c.client.Client())
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating mailbox filterer for address %s: %w", c.mailboxAddress.String(), err)
+	}
+	iterator, err := originMailbox.FilterDispatch(
+		&bind.FilterOpts{Context: ctx, Start: fromHeight, End: &toHeight},
+		[]common.Address{common.HexToAddress(sender)},
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("filtering mailbox dispatch events from height %d to %d: %w", fromHeight, toHeight, err)
+	}
+	defer iterator.Close()
+
+	var dispatches []types.MailboxDispatchTx
+	for iterator.Next() {
+		event := iterator.Event
+		txSender, err := c.txSender(ctx, event.Raw.TxHash)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		dispatches = append(dispatches, types.MailboxDispatchTx{
+			Dispatch: types.MailboxDispatchEvent{
+				Recipient:         hex.EncodeToString(event.Recipient[:]),
+				Message:           hex.EncodeToString(event.Message),
+				DestinationDomain: strconv.FormatUint(uint64(event.Destination), 10),
+				SenderMailbox:     c.mailboxAddress.String(),
+				Sender:            event.Sender.String(),
+				MessageID:         hex.EncodeToString(crypto.Keccak256(event.Message)),
+			},
+			TxHash:   event.Raw.TxHash.Hex(),
+			TxSender: txSender.String(),
+			Height:   event.Raw.BlockNumber,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, 0, fmt.Errorf("iterating mailbox dispatch events: %w", err)
+	}
+
+	return dispatches, toHeight, nil
+}
+
+func (c *HyperlaneClient) txSender(ctx context.Context, txHash common.Hash) (common.Address, error) {
+	tx, _, err := c.client.GetTxByHash(ctx, txHash.Hex())
+	if err != nil {
+		return common.Address{}, fmt.Errorf("getting tx %s: %w", txHash.Hex(), err)
+	}
+	sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("recovering sender of tx %s: %w", txHash.Hex(), err)
+	}
+	return sender, nil
+}
+
+func (c *HyperlaneClient) LatestHeight(ctx context.Context, domain string) (uint64, error) {
+	if domain != c.hyperlaneDomain {
+		return 0, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("getting latest block header: %w", err)
+	}
+	return header.Number.Uint64(), nil
 }
 
 func (c *HyperlaneClient) ValidatorStorageLocations(
@@ -324,7 +735,36 @@ func (c *HyperlaneClient) ValidatorStorageLocations(
 	domain string,
 	validators []common.Address,
 ) ([]*types.ValidatorStorageLocation, error) {
-	panic("not implemented")
+	if domain != c.hyperlaneDomain {
+		return nil, fmt.Errorf("expected domain %s but got %s", c.hyperlaneDomain, domain)
+	}
+
+	validatorAnnounce, err := validator_announce.NewValidatorAnnounceCaller(c.validatorAnnounceAddress, c.client.Client())
+	if err != nil {
+		return nil, fmt.Errorf("creating validator announce caller for address %s: %w", c.validatorAnnounceAddress.String(), err)
+	}
+	storageLocations, err := validatorAnnounce.GetAnnouncedStorageLocations(&bind.CallOpts{Context: ctx}, validators)
+	if err != nil {
+		return nil, fmt.Errorf("getting storage locations for validators %+v: %w", validators, err)
+	}
+	if len(storageLocations) != len(validators) {
+		return nil, fmt.Errorf("expected storage locations for %d validators but got %d", len(validators), len(storageLocations))
+	}
+
+	var validatorStorageLocations []*types.ValidatorStorageLocation
+	for i, locations := range storageLocations {
+		if len(locations) == 0 {
+			return nil, fmt.Errorf("expected at least one storage location for validator %s, got none", validators[i].String())
+		}
+		// take the last announced location as the one the validator is
+		// intending to use
+		validatorStorageLocations = append(validatorStorageLocations, &types.ValidatorStorageLocation{
+			Validator:       strings.TrimPrefix(strings.ToLower(validators[i].String()), "0x"),
+			StorageLocation: locations[len(locations)-1],
+		})
+	}
+
+	return validatorStorageLocations, nil
 }
 
 func (c *HyperlaneClient) IsContract(ctx context.Context, domain, address string) (bool, error) {
@@ -0,0 +1,167 @@
+package hyperlane
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"go.uber.org/zap"
+)
+
+// merkleTreeIndexer maintains a local copy of each origin domains merkle tree
+// hook tree, built from the hooks insertion events, so that merkle proofs can
+// be generated for messages being relayed to merkle root multisig isms
+type merkleTreeIndexer struct {
+	hyperlane Client
+
+	// lock guards trees, each tree is guarded by its own lock
+	lock  sync.Mutex
+	trees map[string]*indexedMerkleTree
+}
+
+type indexedMerkleTree struct {
+	// lock guards tree and nextHeight. It is not held while insertions are
+	// fetched from the origin domain, so that a long backfill does not block
+	// proofs on other domains.
+	lock sync.Mutex
+	tree *types.MerkleTree
+
+	// nextHeight is the next block height on the origin domain to index
+	// insertion events from
+	nextHeight uint64
+}
+
+func newMerkleTreeIndexer(hyperlane Client) *merkleTreeIndexer {
+	return &merkleTreeIndexer{
+		hyperlane: hyperlane,
+		trees:     make(map[string]*indexedMerkleTree),
+	}
+}
+
+// Proof returns a merkle proof for the message at index in the origin domains
+// merkle tree against the root of the tree when it contained count leaves,
+// along with that root.
+func (i *merkleTreeIndexer) Proof(ctx context.Context, domain string, index uint64, count uint64) (types.MerkleProof, [32]byte, error) {
+	indexed, err := i.tree(ctx, domain)
+	if err != nil {
+		return types.MerkleProof{}, [32]byte{}, err
+	}
+
+	if err := i.sync(ctx, domain, indexed, count); err != nil {
+		return types.MerkleProof{}, [32]byte{}, fmt.Errorf("indexing merkle tree insertions on domain %s: %w", domain, err)
+	}
+
+	indexed.lock.Lock()
+	defer indexed.lock.Unlock()
+	if indexed.tree.Count() < count {
+		return types.MerkleProof{}, [32]byte{}, fmt.Errorf("merkle tree on domain %s has %d indexed insertions but %d are required", domain, indexed.tree.Count(), count)
+	}
+
+	proof, err := indexed.tree.Proof(index, count)
+	if err != nil {
+		return types.MerkleProof{}, [32]byte{}, fmt.Errorf("generating merkle proof for index %d: %w", index, err)
+	}
+	root, err := indexed.tree.RootAt(count)
+	if err != nil {
+		return types.MerkleProof{}, [32]byte{}, fmt.Errorf("computing merkle root at count %d: %w", count, err)
+	}
+
+	return proof, root, nil
+}
+
+func (i *merkleTreeIndexer) tree(ctx context.Context, domain string) (*indexedMerkleTree, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if indexed, ok := i.trees[domain]; ok {
+		return indexed, nil
+	}
+
+	chainID, err := config.GetConfigReader(ctx).GetChainIDByHyperlaneDomain(domain)
+	if err != nil {
+		return nil, fmt.Errorf("getting chainID for hyperlane domain %s: %w", domain, err)
+	}
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting chain config for chainID %s: %w", chainID, err)
+	}
+
+	indexed := &indexedMerkleTree{
+		tree:       types.NewMerkleTree(),
+		nextHeight: chainConfig.Relayer.MerkleHookStartHeight,
+	}
+	i.trees[domain] = indexed
+	return indexed, nil
+}
+
+// sync indexes insertion events into the tree until the tree has at least
+// count leaves or there are no more blocks to index
+func (i *merkleTreeIndexer) sync(ctx context.Context, domain string, indexed *indexedMerkleTree, count uint64) error {
+	for {
+		indexed.lock.Lock()
+		fromHeight := indexed.nextHeight
+		synced := indexed.tree.Count() >= count
+		indexed.lock.Unlock()
+		if synced {
+			return nil
+		}
+
+		insertions, toHeight, err := i.hyperlane.MerkleTreeInsertions(ctx, domain, fromHeight)
+		if err != nil {
+			return fmt.Errorf("getting merkle tree insertions from height %d: %w", fromHeight, err)
+		}
+		if toHeight < fromHeight {
+			// caught up to the latest height on the origin domain
+			return nil
+		}
+
+		if err := i.insert(ctx, domain, indexed, fromHeight, toHeight, insertions); err != nil {
+			return err
+		}
+	}
+}
+
+// insert inserts the insertions fetched from fromHeight to toHeight into the
+// tree. If the tree was advanced past fromHeight by a concurrent sync while
+// they were being fetched, the insertions are discarded.
+func (i *merkleTreeIndexer) insert(ctx context.Context, domain string, indexed *indexedMerkleTree, fromHeight, toHeight uint64, insertions []types.MailboxMerkleHookPostDispatchEvent) error {
+	indexed.lock.Lock()
+	defer indexed.lock.Unlock()
+	if indexed.nextHeight != fromHeight {
+		return nil
+	}
+
+	for _, insertion := range insertions {
+		if insertion.Index < indexed.tree.Count() {
+			// already indexed, this can happen if the same block range
+			// is indexed twice
+			continue
+		}
+		if insertion.Index != indexed.tree.Count() {
+			return fmt.Errorf("merkle tree insertion at index %d is out of order, expected index %d. check that relayer.merkle_hook_start_height is at or before the merkle hook deployment height", insertion.Index, indexed.tree.Count())
+		}
+
+		messageID, err := hex.DecodeString(strings.TrimPrefix(insertion.MessageID, "0x"))
+		if err != nil {
+			return fmt.Errorf("hex decoding inserted message id %s: %w", insertion.MessageID, err)
+		}
+		var leaf [32]byte
+		copy(leaf[:], messageID)
+		if err := indexed.tree.Insert(leaf); err != nil {
+			return fmt.Errorf("inserting message id %s into merkle tree: %w", insertion.MessageID, err)
+		}
+	}
+	indexed.nextHeight = toHeight + 1
+
+	lmt.Logger(ctx).Debug(
+		"indexed merkle tree insertions",
+		zap.String("domain", domain),
+		zap.Uint64("indexedToHeight", toHeight),
+		zap.Uint64("treeCount", indexed.tree.Count()),
+	)
+	return nil
+}
@@ -0,0 +1,92 @@
+package hyperlane
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+)
+
+// relayRetryPolicy is how a transfer whose relay attempt failed with a
+// certain class of error is retried
+type relayRetryPolicy struct {
+	// initialBackoff is how long to wait before retrying after the first
+	// failed attempt, the backoff doubles on every following failed attempt
+	initialBackoff time.Duration
+	// maxBackoff caps how long to wait between attempts
+	maxBackoff time.Duration
+	// maxAttempts is the number of failed attempts after which the transfer
+	// is abandoned, 0 means the transfer is never abandoned
+	maxAttempts int64
+}
+
+// relayAbandonTimeout is how long after a transfer was created its relay may
+// keep failing with errors of classes that have max attempts before the
+// transfer is abandoned. Attempts are counted per error class, so without it
+// a transfer whose relay alternates between error classes is retried forever.
+const relayAbandonTimeout = 24 * time.Hour
+
+var relayRetryPolicies = map[string]relayRetryPolicy{
+	// gas prices and the relays cost cap change over time, so relays that
+	// are too expensive are retried until they become cheap enough
+	dbtypes.RelayErrorClassTooExpensive: {
+		initialBackoff: 30 * time.Second,
+		maxBackoff:     10 * time.Minute,
+	},
+	// validators may be slow to sign checkpoints or temporarily down
+	dbtypes.RelayErrorClassNotEnoughSignatures: {
+		initialBackoff: 30 * time.Second,
+		maxBackoff:     15 * time.Minute,
+		maxAttempts:    200,
+	},
+	dbtypes.RelayErrorClassRPCError: {
+		initialBackoff: 10 * time.Second,
+		maxBackoff:     5 * time.Minute,
+		maxAttempts:    100,
+	},
+	// a reverted relay is unlikely to succeed when retried, but it is retried
+	// a few times in case the revert was caused by transient chain state
+	dbtypes.RelayErrorClassReverted: {
+		initialBackoff: time.Minute,
+		maxBackoff:     10 * time.Minute,
+		maxAttempts:    3,
+	},
+}
+
+// relayErrorClass classifies an error returned when relaying a transfer
+func relayErrorClass(err error) string {
+	switch {
+	case errors.Is(err, ErrRelayTooExpensive):
+		return dbtypes.RelayErrorClassTooExpensive
+	case errors.Is(err, ErrNotEnoughSignaturesFound):
+		return dbtypes.RelayErrorClassNotEnoughSignatures
+	case strings.Contains(err.Error(), "execution reverted"):
+		return dbtypes.RelayErrorClassReverted
+	default:
+		return dbtypes.RelayErrorClassRPCError
+	}
+}
+
+// backoff returns how long to wait before the next relay attempt after
+// attempts failed attempts
+func (p relayRetryPolicy) backoff(attempts int64) time.Duration {
+	backoff := p.initialBackoff
+	for i := int64(1); i < attempts && backoff < p.maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, p.maxBackoff)
+}
+
+// shouldAbandon returns true if a transfer should be abandoned after attempts
+// failed attempts
+func (p relayRetryPolicy) shouldAbandon(attempts int64) bool {
+	return p.maxAttempts != 0 && attempts >= p.maxAttempts
+}
+
+// pastDeadline returns true if a transfer created at createdAt should be
+// abandoned because its relay has been failing for longer than
+// relayAbandonTimeout. Policies without max attempts never pass the deadline.
+func (p relayRetryPolicy) pastDeadline(createdAt time.Time) bool {
+	return p.maxAttempts != 0 && time.Since(createdAt) > relayAbandonTimeout
+}
@@ -0,0 +1,143 @@
+package hyperlane
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayErrorClass(t *testing.T) {
+	assert.Equal(t, dbtypes.RelayErrorClassTooExpensive, relayErrorClass(fmt.Errorf("relaying: %w", ErrRelayTooExpensive)))
+	assert.Equal(t, dbtypes.RelayErrorClassNotEnoughSignatures, relayErrorClass(fmt.Errorf("relaying: %w", ErrNotEnoughSignaturesFound)))
+	assert.Equal(t, dbtypes.RelayErrorClassReverted, relayErrorClass(errors.New("simulating process: execution reverted")))
+	assert.Equal(t, dbtypes.RelayErrorClassRPCError, relayErrorClass(errors.New("connection refused")))
+	assert.Equal(t, dbtypes.RelayErrorClassRPCError, relayErrorClass(ErrCouldNotDetermineRelayFee))
+}
+
+func TestRelayRetryPolicyBackoff(t *testing.T) {
+	policy := relayRetryPolicy{initialBackoff: 10 * time.Second, maxBackoff: time.Minute, maxAttempts: 5}
+
+	assert.Equal(t, 10*time.Second, policy.backoff(1))
+	assert.Equal(t, 20*time.Second, policy.backoff(2))
+	assert.Equal(t, 40*time.Second, policy.backoff(3))
+	assert.Equal(t, time.Minute, policy.backoff(4))
+	assert.Equal(t, time.Minute, policy.backoff(1000))
+
+	assert.False(t, policy.shouldAbandon(4))
+	assert.True(t, policy.shouldAbandon(5))
+	assert.False(t, relayRetryPolicy{}.shouldAbandon(1000), "policies without max attempts never abandon")
+
+	assert.False(t, policy.pastDeadline(time.Now().Add(-relayAbandonTimeout+time.Minute)))
+	assert.True(t, policy.pastDeadline(time.Now().Add(-relayAbandonTimeout-time.Minute)))
+	assert.False(t, relayRetryPolicy{}.pastDeadline(time.Now().Add(-relayAbandonTimeout-time.Minute)), "policies without max attempts never abandon")
+}
+
+// fakeRelayAttemptDB records relay attempts and status updates of a single
+// hyperlane transfer
+type fakeRelayAttemptDB struct {
+	Database
+	transfer db.HyperlaneTransfer
+}
+
+func (d *fakeRelayAttemptDB) SetHyperlaneTransferRelayAttempt(ctx context.Context, arg db.SetHyperlaneTransferRelayAttemptParams) (db.HyperlaneTransfer, error) {
+	d.transfer.AttemptCount = arg.AttemptCount
+	d.transfer.NextAttemptAt = arg.NextAttemptAt
+	d.transfer.LastErrorClass = arg.LastErrorClass
+	return d.transfer, nil
+}
+
+func (d *fakeRelayAttemptDB) GetHyperlaneTransferByMessageSentTx(ctx context.Context, arg db.GetHyperlaneTransferByMessageSentTxParams) (db.HyperlaneTransfer, error) {
+	return d.transfer, nil
+}
+
+func (d *fakeRelayAttemptDB) SetMessageStatus(ctx context.Context, arg db.SetMessageStatusParams) (db.HyperlaneTransfer, error) {
+	d.transfer.TransferStatus = arg.TransferStatus
+	d.transfer.TransferStatusMessage = arg.TransferStatusMessage
+	return d.transfer, nil
+}
+
+func TestHandleRelayError(t *testing.T) {
+	ctx := context.Background()
+	database := &fakeRelayAttemptDB{transfer: db.HyperlaneTransfer{
+		ID:             1,
+		CreatedAt:      time.Now(),
+		SourceChainID:  "1",
+		MessageSentTx:  "0xabc",
+		TransferStatus: dbtypes.TransferStatusPending,
+	}}
+	runner := NewRelayerRunner(database, nil, nil)
+	revertErr := errors.New("execution reverted")
+	policy := relayRetryPolicies[dbtypes.RelayErrorClassReverted]
+
+	for attempt := int64(1); attempt < policy.maxAttempts; attempt++ {
+		before := time.Now()
+		runner.handleRelayError(ctx, database.transfer, revertErr)
+
+		assert.Equal(t, attempt, database.transfer.AttemptCount)
+		assert.Equal(t, sql.NullString{String: dbtypes.RelayErrorClassReverted, Valid: true}, database.transfer.LastErrorClass)
+		require.True(t, database.transfer.NextAttemptAt.Valid)
+		assert.False(t, database.transfer.NextAttemptAt.Time.Before(before.Add(policy.backoff(attempt))))
+		assert.Equal(t, dbtypes.TransferStatusPending, database.transfer.TransferStatus)
+	}
+
+	runner.handleRelayError(ctx, database.transfer, revertErr)
+	assert.Equal(t, dbtypes.TransferStatusAbandoned, database.transfer.TransferStatus)
+	assert.Contains(t, database.transfer.TransferStatusMessage.String, dbtypes.RelayErrorClassReverted)
+}
+
+func TestHandleRelayErrorCountsAttemptsPerErrorClass(t *testing.T) {
+	ctx := context.Background()
+	database := &fakeRelayAttemptDB{transfer: db.HyperlaneTransfer{
+		ID:             1,
+		CreatedAt:      time.Now(),
+		SourceChainID:  "1",
+		MessageSentTx:  "0xabc",
+		TransferStatus: dbtypes.TransferStatusPending,
+	}}
+	runner := NewRelayerRunner(database, nil, nil)
+
+	tooExpensiveErr := fmt.Errorf("relaying: %w", ErrRelayTooExpensive)
+	for i := 0; i < 50; i++ {
+		runner.handleRelayError(ctx, database.transfer, tooExpensiveErr)
+	}
+	assert.Equal(t, int64(50), database.transfer.AttemptCount)
+
+	// a single revert after many too expensive attempts starts a new count
+	// for reverts instead of abandoning the transfer
+	runner.handleRelayError(ctx, database.transfer, errors.New("execution reverted"))
+	assert.Equal(t, int64(1), database.transfer.AttemptCount)
+	assert.Equal(t, sql.NullString{String: dbtypes.RelayErrorClassReverted, Valid: true}, database.transfer.LastErrorClass)
+	assert.Equal(t, dbtypes.TransferStatusPending, database.transfer.TransferStatus)
+}
+
+func TestHandleRelayErrorAbandonsAlternatingErrorClassesPastDeadline(t *testing.T) {
+	ctx := context.Background()
+	database := &fakeRelayAttemptDB{transfer: db.HyperlaneTransfer{
+		ID:             1,
+		CreatedAt:      time.Now().Add(-relayAbandonTimeout - time.Minute),
+		SourceChainID:  "1",
+		MessageSentTx:  "0xabc",
+		TransferStatus: dbtypes.TransferStatusPending,
+	}}
+	runner := NewRelayerRunner(database, nil, nil)
+
+	// too expensive relays are retried until they become cheap enough, even
+	// past the deadline
+	runner.handleRelayError(ctx, database.transfer, fmt.Errorf("relaying: %w", ErrRelayTooExpensive))
+	assert.Equal(t, dbtypes.TransferStatusPending, database.transfer.TransferStatus)
+
+	// the first attempt of a new error class would not reach its max
+	// attempts, but the transfer has been failing for too long
+	runner.handleRelayError(ctx, database.transfer, errors.New("connection refused"))
+	assert.Equal(t, int64(1), database.transfer.AttemptCount)
+	assert.Equal(t, dbtypes.TransferStatusAbandoned, database.transfer.TransferStatus)
+	assert.Contains(t, database.transfer.TransferStatusMessage.String, dbtypes.RelayErrorClassRPCError)
+}
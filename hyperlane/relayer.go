@@ -7,10 +7,11 @@ import (
 	dbtypes "github.com/skip-mev/go-fast-solver/db"
 	"github.com/skip-mev/go-fast-solver/shared/metrics"
 	"math/big"
-
+	"sort"
 	"strings"
+	"time"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/skip-mev/go-fast-solver/hyperlane/types"
 
 	"github.com/skip-mev/go-fast-solver/shared/config"
@@ -26,12 +27,16 @@ type Relayer interface {
 type relayer struct {
 	hyperlane                Client
 	storageLocationOverrides map[string]string
+	merkleTrees              *merkleTreeIndexer
+	checkpoints              *checkpointCache
 }
 
 func NewRelayer(hyperlaneClient Client, storageLocationOverrides map[string]string) Relayer {
 	return &relayer{
 		hyperlane:                hyperlaneClient,
 		storageLocationOverrides: storageLocationOverrides,
+		merkleTrees:              newMerkleTreeIndexer(hyperlaneClient),
+		checkpoints:              newCheckpointCache(),
 	}
 }
 
@@ -39,6 +44,7 @@ var (
 	ErrRelayTooExpensive        = fmt.Errorf("relay is too expensive")
 	ErrMessageAlreadyDelivered  = fmt.Errorf("message has already been delivered")
 	ErrNotEnoughSignaturesFound = errors.New("number of signatures found in multisig signed checkpoint is below expected threshold")
+	ErrISMNotSupported          = errors.New("ism type not supported")
 )
 
 func (r *relayer) Relay(ctx context.Context, originChainID string, initiateTxHash string, maxTxFeeUUSDC *big.Int) (destinationTxHash string, destinationChainID string, rawTx string, err error) {
@@ -75,27 +81,174 @@ func (r *relayer) Relay(ctx context.Context, originChainID string, initiateTxHas
 		return "", "", "", fmt.Errorf("recipient %s is not a contract", dispatch.Recipient)
 	}
 
-	// fetch all validators that should validate this message according to the
-	// destination chains ism, and get how many of them need to validate
-	validators, threshold, err := r.hyperlane.ValidatorsAndThreshold(ctx, dispatch.DestinationDomain, dispatch.Recipient, dispatch.Message)
+	message, err := hex.DecodeString(dispatch.Message)
+	if err != nil {
+		return "", "", "", fmt.Errorf("hex decoding dispatch message to bytes: %w", err)
+	}
+
+	// resolve the ism on the destination chain that will verify this message
+	ism, err := r.hyperlane.InterchainSecurityModule(ctx, dispatch.DestinationDomain, dispatch.Recipient, message)
+	if err != nil {
+		return "", "", "", fmt.Errorf("getting ism from domain %s for recipient %s: %w", dispatch.DestinationDomain, dispatch.Recipient, err)
+	}
+
+	lmt.Logger(ctx).Debug(
+		"got recipient ism",
+		zap.String("ismAddress", ism.Address),
+		zap.Uint8("ismType", ism.ModuleType),
+	)
+
+	// build the metadata to be passed to the destination ism for verification
+	metadata, err := r.metadata(ctx, originChainConfig.HyperlaneDomain, *ism, merkleHookPostDispatch.Index, dispatch.MessageID)
+	if err != nil {
+		return "", "", "", fmt.Errorf("building metadata for ism %s: %w", ism.Address, err)
+	}
+
+	// if the user specified a max tx fee, ensure that the tx fee to relay will
+	// be less than this amount
+	if maxTxFeeUUSDC != nil {
+		isFeeLessThanMax, err := r.isRelayFeeLessThanMax(ctx, dispatch.DestinationDomain, message, metadata, maxTxFeeUUSDC)
+		if err != nil {
+			return "", "", "", fmt.Errorf("checking if relay to domain %s is profitable: %w", dispatch.DestinationDomain, err)
+		}
+		if !isFeeLessThanMax {
+			metrics.FromContext(ctx).IncHyperlaneRelayTooExpensive(originChainID, destinationChainID)
+			return "", "", "", ErrRelayTooExpensive
+		}
+	}
+
+	// submit the message to the destination mailbox for processing (ism
+	// verification, emit events, calling recipient contract)
+	hash, rawTx, err := r.hyperlane.Process(ctx, dispatch.DestinationDomain, message, metadata)
+	metrics.FromContext(ctx).IncTransactionSubmitted(err == nil, destinationChainID, dbtypes.TxTypeHyperlaneMessageDelivery)
+	if err != nil {
+		return "", "", "", fmt.Errorf("processing message on domain %s: %w", dispatch.DestinationDomain, err)
+	}
+
+	lmt.Logger(ctx).Info(
+		fmt.Sprintf("relayed hyperlane message from %s to %s", originChainConfig.ChainName, destinationChainConfig.ChainName),
+		zap.String("originDispatchTxHash", initiateTxHash),
+		zap.String("destinationProcessTxHash", hex.EncodeToString(hash)),
+	)
+
+	return hex.EncodeToString(hash), destinationChainID, rawTx, nil
+}
+
+// metadata builds the metadata that ism requires to verify the message with
+// id messageID at merkle tree index on the origin domain
+func (r *relayer) metadata(
+	ctx context.Context,
+	originDomain string,
+	ism types.InterchainSecurityModule,
+	index uint64,
+	messageID string,
+) ([]byte, error) {
+	switch ism.ModuleType {
+	case types.ISMTypeMessageIDMultisig:
+		return r.messageIDMultisigMetadata(ctx, originDomain, ism, index, messageID)
+	case types.ISMTypeMerkleRootMultisig:
+		return r.merkleRootMultisigMetadata(ctx, originDomain, ism, index, messageID)
+	case types.ISMTypeAggregation:
+		return r.aggregationMetadata(ctx, originDomain, ism, index, messageID)
+	case types.ISMTypeRouting:
+		if len(ism.Modules) != 1 {
+			return nil, fmt.Errorf("expected routing ism %s to route to exactly one module but got %d", ism.Address, len(ism.Modules))
+		}
+		// routing isms pass the metadata through to the module they route to
+		return r.metadata(ctx, originDomain, ism.Modules[0], index, messageID)
+	default:
+		return nil, fmt.Errorf("%w: ism type %d", ErrISMNotSupported, ism.ModuleType)
+	}
+}
+
+func (r *relayer) messageIDMultisigMetadata(
+	ctx context.Context,
+	originDomain string,
+	ism types.InterchainSecurityModule,
+	index uint64,
+	messageID string,
+) ([]byte, error) {
+	quorumCheckpoint, err := r.multisigCheckpoint(ctx, originDomain, ism, index, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := quorumCheckpoint.ToMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("creating message metadata from multisig checkpoint: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// merkleRootMultisigMetadata builds metadata proving that the message at index
+// is included in the merkle root signed by a quorum of the isms validators
+func (r *relayer) merkleRootMultisigMetadata(
+	ctx context.Context,
+	originDomain string,
+	ism types.InterchainSecurityModule,
+	index uint64,
+	messageID string,
+) ([]byte, error) {
+	quorumCheckpoint, err := r.multisigCheckpoint(ctx, originDomain, ism, index, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the checkpoint is signed at the messages index, so the proof is against
+	// the root of the tree when the message was the last leaf inserted
+	signedIndex := uint64(quorumCheckpoint.Checkpoint.Checkpoint.Index)
+	proof, root, err := r.merkleTrees.Proof(ctx, originDomain, index, signedIndex+1)
 	if err != nil {
-		return "", "", "", fmt.Errorf("getting validators and quorum threshold from doamin %s for recipient %s: %w", dispatch.DestinationDomain, dispatch.Recipient, err)
+		return nil, fmt.Errorf("getting merkle proof for message at index %d: %w", index, err)
+	}
+	if !strings.EqualFold(hex.EncodeToString(root[:]), strings.TrimPrefix(quorumCheckpoint.Checkpoint.Checkpoint.Root, "0x")) {
+		return nil, fmt.Errorf("locally indexed merkle root 0x%s does not match signed checkpoint root %s at index %d", hex.EncodeToString(root[:]), quorumCheckpoint.Checkpoint.Checkpoint.Root, signedIndex)
 	}
-	if len(validators) == 0 {
-		return "", "", "", fmt.Errorf("no validator set received from multisig ism")
+
+	metadata, err := quorumCheckpoint.ToMerkleRootMetadata(uint32(index), proof)
+	if err != nil {
+		return nil, fmt.Errorf("creating merkle root message metadata from multisig checkpoint: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// multisigCheckpoint fetches the checkpoint at index signed by a quorum of the
+// multisig isms validators
+func (r *relayer) multisigCheckpoint(
+	ctx context.Context,
+	originDomain string,
+	ism types.InterchainSecurityModule,
+	index uint64,
+	messageID string,
+) (types.MultiSigSignedCheckpoint, error) {
+	if len(ism.Validators) == 0 {
+		return types.MultiSigSignedCheckpoint{}, fmt.Errorf("no validator set received from multisig ism %s", ism.Address)
 	}
 
 	lmt.Logger(ctx).Debug(
-		"got validators and threshold from recipient ism",
-		zap.Any("validators", validators),
-		zap.Uint8("threshold", threshold),
+		"got validators and threshold from multisig ism",
+		zap.String("ismAddress", ism.Address),
+		zap.Any("validators", ism.Validators),
+		zap.Uint8("threshold", ism.Threshold),
 	)
 
-	// get the checkpoint storage locations for these validators via the origin
-	// chains validator announce contract
-	validatorStorageLocations, err := r.hyperlane.ValidatorStorageLocations(ctx, originChainConfig.HyperlaneDomain, validators)
+	originChainID, err := config.GetConfigReader(ctx).GetChainIDByHyperlaneDomain(originDomain)
 	if err != nil {
-		return "", "", "", fmt.Errorf("getting validator storage locations on domain %s for validators %v: %w", originChainConfig.HyperlaneDomain, validators, err)
+		return types.MultiSigSignedCheckpoint{}, fmt.Errorf("getting chainID for hyperlane domain %s: %w", originDomain, err)
+	}
+	originChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(originChainID)
+	if err != nil {
+		return types.MultiSigSignedCheckpoint{}, fmt.Errorf("getting chain config for chainID %s: %w", originChainID, err)
+	}
+
+	// get the checkpoint storage locations for these validators via the
+	// configured storage locations or the origin chains validator announce
+	// contract
+	validatorStorageLocations, err := resolveValidatorStorageLocations(ctx, r.checkpoints, r.hyperlane, originChainConfig, ism.Validators, r.storageLocationOverrides)
+	if err != nil {
+		return types.MultiSigSignedCheckpoint{}, fmt.Errorf("getting validator storage locations on domain %s for validators %v: %w", originDomain, ism.Validators, err)
 	}
 
 	lmt.Logger(ctx).Debug(
@@ -103,93 +256,217 @@ func (r *relayer) Relay(ctx context.Context, originChainID string, initiateTxHas
 		zap.Any("validatorStorageLocations", validatorStorageLocations),
 	)
 
-	// create fetchers for the validators storage locations (either S3 or local
-	// files)
-	var checkpointFetchers []CheckpointFetcher
+	fetcherOpts := CheckpointFetcherOptions{Timeout: originChainConfig.Relayer.CheckpointFetchTimeout}
+	awaitTimeout := originChainConfig.Relayer.CheckpointAwaitTimeout
+	if awaitTimeout <= 0 {
+		awaitTimeout = defaultCheckpointAwaitTimeout
+	}
+
+	// create fetchers for the validators storage locations (s3, gcs, https or
+	// local files)
+	var checkpointFetchers []*cachedCheckpointFetcher
 	for _, validatorStorageLocation := range validatorStorageLocations {
 		validator := validatorStorageLocation.Validator
 		storageLocation := validatorStorageLocation.StorageLocation
-		if override, ok := r.storageLocationOverrides[validator]; ok {
-			storageLocation = override
-		}
-
-		fetcher, err := NewCheckpointFetcherFromStorageLocation(storageLocation, validator)
+		fetcher, err := NewCheckpointFetcherFromStorageLocation(storageLocation, validator, fetcherOpts)
 		if err != nil {
-			return "", "", "", fmt.Errorf("creating checkpoint fetcher from storage location %s for validator %s: %w", storageLocation, validator, err)
+			return types.MultiSigSignedCheckpoint{}, fmt.Errorf("creating checkpoint fetcher from storage location %s for validator %s: %w", storageLocation, validator, err)
 		}
-		checkpointFetchers = append(checkpointFetchers, fetcher)
+		checkpointFetchers = append(checkpointFetchers, &cachedCheckpointFetcher{
+			CheckpointFetcher: fetcher,
+			domain:            originDomain,
+			cache:             r.checkpoints,
+		})
 	}
 
 	// fetch the checkpoint at index if we have reached a quorum of validators
 	// there
-	quorumCheckpoint, err := r.checkpointAtIndex(ctx, merkleHookPostDispatch.Index, checkpointFetchers, threshold, dispatch.MessageID)
+	quorumCheckpoint, err := r.checkpointAtIndex(ctx, index, checkpointFetchers, ism.Validators, ism.Threshold, awaitTimeout, messageID)
 	if err != nil {
-		return "", "", "", fmt.Errorf("getting checkpoint at index %d: %w", merkleHookPostDispatch.Index, err)
+		return types.MultiSigSignedCheckpoint{}, fmt.Errorf("getting checkpoint at index %d: %w", index, err)
 	}
 
-	lmt.Logger(ctx).Debug("found checkpoint with quorum", zap.Uint64("index", merkleHookPostDispatch.Index))
+	lmt.Logger(ctx).Debug("found checkpoint with quorum", zap.Uint64("index", index))
 
-	// convert the checkpoint to metadata to be passed to the destination ism
-	// for verification
-	metadata, err := quorumCheckpoint.ToMetadata()
-	if err != nil {
-		return "", "", "", fmt.Errorf("creating message metadata from multisig checkpoint: %w", err)
+	return quorumCheckpoint, nil
+}
+
+// resolveValidatorStorageLocations returns where the signed checkpoints of
+// validators signing messages sent from origin are fetched from. Validators
+// with a configured storage location are not looked up on the origin chains
+// validator announce contract, so validators that never announced a storage
+// location can still be used. Overrides take precedence over the origin
+// chains configured overrides.
+func resolveValidatorStorageLocations(
+	ctx context.Context,
+	cache *checkpointCache,
+	hyperlane Client,
+	origin config.ChainConfig,
+	validators []common.Address,
+	overrides map[string]string,
+) ([]*types.ValidatorStorageLocation, error) {
+	configured := make(map[string]string)
+	for validator, storageLocation := range origin.Relayer.CheckpointStorageLocationOverrides {
+		configured[validatorKey(validator)] = storageLocation
+	}
+	for validator, storageLocation := range overrides {
+		configured[validatorKey(validator)] = storageLocation
 	}
 
-	// submit the message to the destination mailbox for processing (ism
-	// verification, emit events, calling recipient contract)
-	message, err := hex.DecodeString(dispatch.Message)
+	var storageLocations []*types.ValidatorStorageLocation
+	var unconfigured []common.Address
+	for _, validator := range validators {
+		key := validatorKey(validator.Hex())
+		storageLocation, ok := configured[key]
+		if !ok {
+			unconfigured = append(unconfigured, validator)
+			continue
+		}
+		storageLocations = append(storageLocations, &types.ValidatorStorageLocation{
+			Validator:       key,
+			StorageLocation: storageLocation,
+		})
+	}
+	if len(unconfigured) == 0 {
+		return storageLocations, nil
+	}
+
+	announced, err := cache.validatorStorageLocations(ctx, hyperlane, origin.HyperlaneDomain, unconfigured)
 	if err != nil {
-		return "", "", "", fmt.Errorf("hex decoding dispatch message to bytes: %w", err)
+		return nil, err
 	}
+	return append(storageLocations, announced...), nil
+}
 
-	// if the user specified a max tx fee, ensure that the tx fee to relay will
-	// be less than this amount
-	if maxTxFeeUUSDC != nil {
-		isFeeLessThanMax, err := r.isRelayFeeLessThanMax(ctx, dispatch.DestinationDomain, message, metadata, maxTxFeeUUSDC)
-		if err != nil {
-			return "", "", "", fmt.Errorf("checking if relay to domain %s is profitable: %w", dispatch.DestinationDomain, err)
+// aggregationMetadata builds metadata for the first threshold sub modules of
+// the aggregation ism that metadata can be built for. Sub modules of
+// unsupported types, or whose metadata is not yet available, are skipped.
+func (r *relayer) aggregationMetadata(
+	ctx context.Context,
+	originDomain string,
+	ism types.InterchainSecurityModule,
+	index uint64,
+	messageID string,
+) ([]byte, error) {
+	if ism.Threshold == 0 || int(ism.Threshold) > len(ism.Modules) {
+		return nil, fmt.Errorf("invalid threshold %d for aggregation ism %s with %d modules", ism.Threshold, ism.Address, len(ism.Modules))
+	}
+
+	subModuleMetadata := make([][]byte, len(ism.Modules))
+	var built int
+	var subModuleErrs []error
+	for i, module := range ism.Modules {
+		if built >= int(ism.Threshold) {
+			break
 		}
-		if !isFeeLessThanMax {
-			metrics.FromContext(ctx).IncHyperlaneRelayTooExpensive(originChainID, destinationChainID)
-			return "", "", "", ErrRelayTooExpensive
+
+		metadata, err := r.metadata(ctx, originDomain, module, index, messageID)
+		if err != nil {
+			lmt.Logger(ctx).Debug(
+				"could not build metadata for aggregation ism sub module",
+				zap.String("aggregationIsmAddress", ism.Address),
+				zap.String("subModuleAddress", module.Address),
+				zap.Uint8("subModuleType", module.ModuleType),
+				zap.Error(err),
+			)
+			subModuleErrs = append(subModuleErrs, fmt.Errorf("sub module %s: %w", module.Address, err))
+			continue
 		}
+
+		subModuleMetadata[i] = metadata
+		built++
+	}
+	if built < int(ism.Threshold) {
+		return nil, fmt.Errorf(
+			"built metadata for %d of the %d required sub modules of aggregation ism %s: %w",
+			built, ism.Threshold, ism.Address, errors.Join(subModuleErrs...),
+		)
 	}
 
-	hash, rawTx, err := r.hyperlane.Process(ctx, dispatch.DestinationDomain, message, metadata)
-	metrics.FromContext(ctx).IncTransactionSubmitted(err == nil, destinationChainID, dbtypes.TxTypeHyperlaneMessageDelivery)
+	metadata, err := types.ToAggregationMetadata(subModuleMetadata)
 	if err != nil {
-		return "", "", "", fmt.Errorf("processing message on domain %s: %w", dispatch.DestinationDomain, err)
+		return nil, fmt.Errorf("creating aggregation metadata for ism %s: %w", ism.Address, err)
 	}
 
-	lmt.Logger(ctx).Info(
-		fmt.Sprintf("relayed hyperlane message from %s to %s", originChainConfig.ChainName, destinationChainConfig.ChainName),
-		zap.String("originDispatchTxHash", initiateTxHash),
-		zap.String("destinationProcessTxHash", hex.EncodeToString(hash)),
-	)
-
-	return hex.EncodeToString(hash), destinationChainID, rawTx, nil
+	return metadata, nil
 }
 
+// reasons a checkpoint signature is rejected during verification
+const (
+	signatureMismatchInvalidSignature  = "invalid_signature"
+	signatureMismatchNotFetchValidator = "signer_not_storage_validator"
+	signatureMismatchNotInValidatorSet = "signer_not_in_validator_set"
+)
+
+// defaultCheckpointAwaitTimeout is how long to wait for each validator to
+// sign a checkpoint during a relay attempt if no timeout is configured
+const defaultCheckpointAwaitTimeout = 30 * time.Second
+
+// checkpointAtIndex concurrently fetches the checkpoint at index from each
+// fetcher, waiting up to awaitTimeout for each validator to sign it, and
+// returns it once threshold distinct validators from validators have signed
+// the same root. A signature is only counted if its signer is the validator
+// whose storage location it was read from and is in the ism's validator set.
+// Signatures are returned in the order of validators, as required by the
+// multisig isms.
 func (r *relayer) checkpointAtIndex(
 	ctx context.Context,
 	index uint64,
-	checkpointFetchers []CheckpointFetcher,
+	checkpointFetchers []*cachedCheckpointFetcher,
+	validators []common.Address,
 	threshold uint8,
+	awaitTimeout time.Duration,
 	messageID string,
 ) (types.MultiSigSignedCheckpoint, error) {
-	var multiSigCheckpoint types.MultiSigSignedCheckpoint
-	signedCheckpointsPerRoot := make(map[string][]types.SignedCheckpoint)
+	validatorPositions := make(map[common.Address]int, len(validators))
+	for i, validator := range validators {
+		validatorPositions[validator] = i
+	}
+
+	type validatorSignature struct {
+		signature types.Signature
+		position  int
+	}
+	type fetchResult struct {
+		fetcher    *cachedCheckpointFetcher
+		checkpoint *types.SignedCheckpoint
+		err        error
+	}
+
+	// cancel any outstanding fetches once a quorum has been reached
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan fetchResult, len(checkpointFetchers))
 	for _, fetcher := range checkpointFetchers {
-		signedCheckpoint, err := fetcher.Checkpoint(ctx, index)
+		go func(fetcher *cachedCheckpointFetcher) {
+			checkpoint, err := fetcher.AwaitCheckpoint(fetchCtx, index, awaitTimeout)
+			results <- fetchResult{fetcher: fetcher, checkpoint: checkpoint, err: err}
+		}(fetcher)
+	}
+
+	var multiSigCheckpoint types.MultiSigSignedCheckpoint
+	signers := make(map[common.Address]struct{})
+	signaturesPerRoot := make(map[string][]validatorSignature)
+	for range checkpointFetchers {
+		result := <-results
+		fetcher, signedCheckpoint, err := result.fetcher, result.checkpoint, result.err
 		if errors.Is(err, ErrCheckpointDoesNotExist) {
 			// if the validator for this fetcher has not signed the
 			// checkpoint, ignore it
 			continue
 		}
 		if err != nil {
+			// a single unavailable validator should not prevent reaching a
+			// quorum with the remaining validators
 			metrics.FromContext(ctx).IncHyperlaneCheckpointingErrors()
-			return types.MultiSigSignedCheckpoint{}, fmt.Errorf("fetching checkpoint at index %d: %w", index, err)
+			lmt.Logger(ctx).Warn(
+				"error fetching checkpoint from validator",
+				zap.String("validator", fetcher.Validator()),
+				zap.Uint64("checkpointIndex", index),
+				zap.Error(err),
+			)
+			continue
 		}
 
 		// ensure that the checkpoint is actually for this index
@@ -202,37 +479,58 @@ func (r *relayer) checkpointAtIndex(
 			continue
 		}
 
-		digest, err := signedCheckpoint.Digest()
+		signer, err := signedCheckpoint.Signer()
 		if err != nil {
-			return types.MultiSigSignedCheckpoint{}, fmt.Errorf("hex decoding checkpoint root: %w", err)
-		}
-		pubkey, err := signedCheckpoint.Signature.RecoverPubKey(digest)
-		if err != nil {
-			return types.MultiSigSignedCheckpoint{}, fmt.Errorf("recovering pubkey from signature: %w", err)
-		}
-		signature, err := signedCheckpoint.Signature.RSBytes()
-		if err != nil {
-			return types.MultiSigSignedCheckpoint{}, fmt.Errorf("converting checkpoint signature to bytes: %w", err)
+			lmt.Logger(ctx).Warn(
+				"could not recover signer of checkpoint",
+				zap.String("validator", fetcher.Validator()),
+				zap.Uint64("checkpointIndex", index),
+				zap.Error(err),
+			)
+			metrics.FromContext(ctx).IncHyperlaneCheckpointSignatureMismatches(fetcher.Validator(), signatureMismatchInvalidSignature)
+			continue
 		}
-		if !crypto.VerifySignature(pubkey, digest, signature) {
+		if !common.IsHexAddress(fetcher.Validator()) || signer != common.HexToAddress(fetcher.Validator()) {
 			lmt.Logger(ctx).Warn(
 				"checkpoint signature is not from validator",
 				zap.String("validator", fetcher.Validator()),
+				zap.String("signer", signer.Hex()),
 				zap.Uint64("checkpointIndex", index),
 			)
+			metrics.FromContext(ctx).IncHyperlaneCheckpointSignatureMismatches(fetcher.Validator(), signatureMismatchNotFetchValidator)
 			continue
 		}
+		// only cache checkpoints once they are known to be signed by the
+		// validator, so a bad checkpoint is refetched on the next attempt
+		fetcher.cacheCheckpoint(index, signedCheckpoint)
 
-		root := signedCheckpoint.Value.Checkpoint.Root
-		if _, ok := signedCheckpointsPerRoot[root]; !ok {
-			signedCheckpointsPerRoot[root] = make([]types.SignedCheckpoint, 0)
+		position, ok := validatorPositions[signer]
+		if !ok {
+			lmt.Logger(ctx).Warn(
+				"checkpoint signer is not in the ism validator set",
+				zap.String("signer", signer.Hex()),
+				zap.Uint64("checkpointIndex", index),
+			)
+			metrics.FromContext(ctx).IncHyperlaneCheckpointSignatureMismatches(fetcher.Validator(), signatureMismatchNotInValidatorSet)
+			continue
 		}
-		signedCheckpointsPerRoot[root] = append(signedCheckpointsPerRoot[root], *signedCheckpoint)
+		if _, ok := signers[signer]; ok {
+			// the same validator may be reachable via multiple storage
+			// locations, only count its signature once
+			continue
+		}
+		signers[signer] = struct{}{}
+
+		root := signedCheckpoint.Value.Checkpoint.Root
+		signaturesPerRoot[root] = append(signaturesPerRoot[root], validatorSignature{signature: signedCheckpoint.Signature, position: position})
+
+		if len(signaturesPerRoot[root]) >= int(threshold) {
+			signatures := signaturesPerRoot[root]
+			sort.Slice(signatures, func(i, j int) bool { return signatures[i].position < signatures[j].position })
 
-		if len(signedCheckpointsPerRoot[root]) >= int(threshold) {
 			multiSigCheckpoint.Checkpoint = signedCheckpoint.Value
-			for _, checkpoint := range signedCheckpointsPerRoot[root] {
-				multiSigCheckpoint.Signatures = append(multiSigCheckpoint.Signatures, checkpoint.Signature)
+			for _, signature := range signatures {
+				multiSigCheckpoint.Signatures = append(multiSigCheckpoint.Signatures, signature.signature)
 			}
 			break
 		}
@@ -3,14 +3,16 @@ package hyperlane
 import (
 	"context"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
 	"github.com/skip-mev/go-fast-solver/shared/metrics"
+	evmtxexecutor "github.com/skip-mev/go-fast-solver/shared/txexecutor/evm"
 
 	dbtypes "github.com/skip-mev/go-fast-solver/db"
 	"github.com/skip-mev/go-fast-solver/db/gen/db"
@@ -22,6 +24,17 @@ import (
 const (
 	relayInterval                  = 10 * time.Second
 	excessiveHyperlaneRelayLatency = 30 * time.Minute
+
+	// defaultStuckTxReplacementTimeout is how long a delivery tx can be
+	// pending before it is replaced, if no timeout is configured for the
+	// destination chain
+	defaultStuckTxReplacementTimeout = 3 * time.Minute
+
+	// stuckTxAbandonTimeout is how long the delivery txs sharing a nonce can
+	// be pending while they are too expensive to replace before they are
+	// abandoned, since they may have been dropped from the mempool and would
+	// otherwise be pending forever
+	stuckTxAbandonTimeout = 30 * time.Minute
 )
 
 type Database interface {
@@ -34,6 +47,10 @@ type Database interface {
 	GetSubmittedTxsByOrderStatusAndType(ctx context.Context, arg db.GetSubmittedTxsByOrderStatusAndTypeParams) ([]db.SubmittedTx, error)
 	GetAllOrdersWithOrderStatus(ctx context.Context, orderStatus string) ([]db.Order, error)
 	GetHyperlaneTransferByMessageSentTx(ctx context.Context, arg db.GetHyperlaneTransferByMessageSentTxParams) (db.HyperlaneTransfer, error)
+	GetHyperlaneTransferByMessageID(ctx context.Context, arg db.GetHyperlaneTransferByMessageIDParams) (db.HyperlaneTransfer, error)
+	SetSubmittedTxStatus(ctx context.Context, arg db.SetSubmittedTxStatusParams) (db.SubmittedTx, error)
+	SetHyperlaneTransferRelayAttempt(ctx context.Context, arg db.SetHyperlaneTransferRelayAttemptParams) (db.HyperlaneTransfer, error)
+	ResetHyperlaneTransferRelayAttempts(ctx context.Context, id int64) (db.HyperlaneTransfer, error)
 }
 
 type RelayerRunner struct {
@@ -103,6 +120,7 @@ func (r *RelayerRunner) Run(ctx context.Context) error {
 				return fmt.Errorf("getting pending hyperlane transfers: %w", err)
 			}
 
+			now := time.Now()
 			for _, transfer := range transfers {
 				shouldRelay, err := r.checkHyperlaneTransferStatus(ctx, transfer)
 				if err != nil {
@@ -118,60 +136,32 @@ func (r *RelayerRunner) Run(ctx context.Context) error {
 					continue
 				}
 
+				if transfer.NextAttemptAt.Valid && transfer.NextAttemptAt.Time.After(now) {
+					// a previous relay attempt failed, wait for its backoff
+					// to pass before retrying it
+					continue
+				}
+
+				if r.deferRelay(ctx, transfer, now) {
+					continue
+				}
+
 				destinationTxHash, destinationChainID, rawTx, err := r.relayTransfer(ctx, transfer)
 				if err != nil {
-					switch {
-					case errors.Is(err, ErrRelayTooExpensive):
-						lmt.Logger(ctx).Warn(
-							"relaying transfer is too expensive, waiting for better conditions",
-							zap.Int64("transferId", transfer.ID),
-							zap.String("sourceChainID", transfer.SourceChainID),
-							zap.String("destChainID", transfer.DestinationChainID),
-							zap.String("txHash", transfer.MessageSentTx),
-						)
-					case errors.Is(err, ErrCouldNotDetermineRelayFee):
-						lmt.Logger(ctx).Warn(
-							"could not determine relay fee, retrying",
-							zap.Int64("transferId", transfer.ID),
-							zap.String("sourceChainID", transfer.SourceChainID),
-							zap.String("destChainID", transfer.DestinationChainID),
-							zap.String("txHash", transfer.MessageSentTx),
-						)
-					case errors.Is(err, ErrNotEnoughSignaturesFound):
-						// warning already logged in relayer
-					case strings.Contains(err.Error(), "execution reverted"):
-						// Unrecoverable error
-						lmt.Logger(ctx).Warn(
-							"abandoning hyperlane transfer",
-							zap.Int64("transferId", transfer.ID),
-							zap.String("txHash", transfer.MessageSentTx),
-							zap.Error(err),
-						)
-						if _, err := r.updateTransferStatusIfPending(
-							ctx,
-							transfer.MessageSentTx,
-							transfer.SourceChainID,
-							dbtypes.TransferStatusAbandoned,
-							err.Error(),
-						); err != nil {
-							lmt.Logger(ctx).Error(
-								"error updating invalid transfer status",
-								zap.Int64("transferId", transfer.ID),
-								zap.String("txHash", transfer.MessageSentTx),
-								zap.Error(err),
-							)
-						}
-					default:
+					r.handleRelayError(ctx, transfer, err)
+					continue
+				}
+
+				if transfer.AttemptCount > 0 {
+					// the relay was submitted, so any later failures start a
+					// new series of attempts
+					if _, err := r.db.ResetHyperlaneTransferRelayAttempts(ctx, transfer.ID); err != nil {
 						lmt.Logger(ctx).Error(
-							"error relaying pending hyperlane transfer",
+							"error resetting hyperlane transfer relay attempts",
 							zap.Error(err),
 							zap.Int64("transferId", transfer.ID),
-							zap.String("sourceChainID", transfer.SourceChainID),
-							zap.String("destChainID", transfer.DestinationChainID),
-							zap.String("txHash", transfer.MessageSentTx),
 						)
 					}
-					continue
 				}
 
 				if _, err := r.db.InsertSubmittedTx(ctx, db.InsertSubmittedTxParams{
@@ -196,21 +186,118 @@ func (r *RelayerRunner) Run(ctx context.Context) error {
 	}
 }
 
+// deferRelay returns true if the solver should not relay an undelivered
+// transfer yet, either because relaying is disabled on the transfers route, or
+// because the public relayer is still within its grace period to deliver it
+func (r *RelayerRunner) deferRelay(ctx context.Context, transfer db.HyperlaneTransfer, now time.Time) bool {
+	originChainConfig, err := config.GetConfigReader(ctx).GetChainConfig(transfer.SourceChainID)
+	if err != nil {
+		lmt.Logger(ctx).Error(
+			"error getting origin chain config for hyperlane transfer",
+			zap.Error(err),
+			zap.Int64("transferId", transfer.ID),
+			zap.String("sourceChainID", transfer.SourceChainID),
+		)
+		return true
+	}
+
+	enabled, publicRelayerGracePeriod := originChainConfig.Relayer.RelayRoute(transfer.DestinationChainID)
+	if !enabled {
+		lmt.Logger(ctx).Debug(
+			"relaying is disabled on route, waiting for hyperlane transfer to be delivered by another relayer",
+			zap.Int64("transferId", transfer.ID),
+			zap.String("sourceChainID", transfer.SourceChainID),
+			zap.String("destChainID", transfer.DestinationChainID),
+		)
+		return true
+	}
+	if now.Sub(transfer.CreatedAt) < publicRelayerGracePeriod {
+		lmt.Logger(ctx).Debug(
+			"waiting for public relayer to deliver hyperlane transfer",
+			zap.Int64("transferId", transfer.ID),
+			zap.String("sourceChainID", transfer.SourceChainID),
+			zap.String("destChainID", transfer.DestinationChainID),
+			zap.Time("gracePeriodEndsAt", transfer.CreatedAt.Add(publicRelayerGracePeriod)),
+		)
+		return true
+	}
+	return false
+}
+
+// handleRelayError schedules the next relay attempt of a transfer whose relay
+// failed with err, according to the retry policy for the class of err. If the
+// transfer has reached the max attempts of the policy it is abandoned instead.
+// Attempts are counted per error class, so the count restarts when a relay
+// fails with a different class of error than the previous attempt. To bound
+// retries of a transfer alternating between error classes, it is also
+// abandoned once it is older than relayAbandonTimeout and failed with a class
+// of error that has max attempts.
+func (r *RelayerRunner) handleRelayError(ctx context.Context, transfer db.HyperlaneTransfer, err error) {
+	errorClass := relayErrorClass(err)
+	policy := relayRetryPolicies[errorClass]
+	attempts := int64(1)
+	if transfer.LastErrorClass.Valid && transfer.LastErrorClass.String == errorClass {
+		attempts = transfer.AttemptCount + 1
+	}
+	nextAttemptAt := time.Now().Add(policy.backoff(attempts)).UTC()
+
+	logger := lmt.Logger(ctx).With(
+		zap.Int64("transferId", transfer.ID),
+		zap.String("sourceChainID", transfer.SourceChainID),
+		zap.String("destChainID", transfer.DestinationChainID),
+		zap.String("txHash", transfer.MessageSentTx),
+		zap.String("errorClass", errorClass),
+		zap.Int64("attempts", attempts),
+	)
+
+	switch errorClass {
+	case dbtypes.RelayErrorClassTooExpensive:
+		logger.Warn("relaying transfer is too expensive, waiting for better conditions", zap.Time("nextAttemptAt", nextAttemptAt))
+	case dbtypes.RelayErrorClassNotEnoughSignatures:
+		// warning already logged in relayer
+	default:
+		logger.Error("error relaying pending hyperlane transfer", zap.Time("nextAttemptAt", nextAttemptAt), zap.Error(err))
+	}
+
+	if _, err := r.db.SetHyperlaneTransferRelayAttempt(ctx, db.SetHyperlaneTransferRelayAttemptParams{
+		ID:             transfer.ID,
+		AttemptCount:   attempts,
+		NextAttemptAt:  sql.NullTime{Time: nextAttemptAt, Valid: true},
+		LastErrorClass: sql.NullString{String: errorClass, Valid: true},
+	}); err != nil {
+		logger.Error("error recording hyperlane transfer relay attempt", zap.Error(err))
+	}
+
+	var reason string
+	switch {
+	case policy.shouldAbandon(attempts):
+		logger.Warn("abandoning hyperlane transfer after reaching max relay attempts", zap.Error(err))
+		reason = fmt.Sprintf("abandoned after %d relay attempts, last attempt failed with %s error: %s", attempts, errorClass, err.Error())
+	case policy.pastDeadline(transfer.CreatedAt):
+		logger.Warn("abandoning hyperlane transfer after relaying failed past the abandon timeout", zap.Error(err))
+		reason = fmt.Sprintf("abandoned after relaying failed for more than %s, last attempt failed with %s error: %s", relayAbandonTimeout, errorClass, err.Error())
+	default:
+		return
+	}
+
+	if _, err := r.updateTransferStatusIfPending(
+		ctx,
+		transfer.SourceChainID,
+		transfer.MessageSentTx,
+		dbtypes.TransferStatusAbandoned,
+		reason,
+	); err != nil {
+		logger.Error("error updating abandoned transfer status", zap.Error(err))
+	}
+}
+
 // relayTransfer constructs relay options and calls the relayer to relay
 // preform a hyperlane relay on a dispatch message. Returning the destination
 // chain tx hash and the destination chain id.
 func (r *RelayerRunner) relayTransfer(ctx context.Context, transfer db.HyperlaneTransfer) (string, string, string, error) {
-	var maxRelayTxFeeUUSDC *big.Int
-	if transfer.MaxTxFeeUusdc.Valid {
-		maxTxFeeUUSDC, ok := new(big.Int).SetString(transfer.MaxTxFeeUusdc.String, 10)
-		if !ok {
-			return "", "", "", fmt.Errorf("converting max tx fee uusdc %s to *big.Int", transfer.MaxTxFeeUusdc.String)
-		}
-		maxRelayTxFeeUUSDC = maxTxFeeUUSDC
-	}
-	costCap, err := r.getRelayCostCap(ctx, transfer.DestinationChainID, maxRelayTxFeeUUSDC, transfer.CreatedAt)
+	costCap, err := r.transferCostCap(ctx, transfer)
 	if err != nil {
-		return "", "", "", fmt.Errorf("getting relay cost cap for transfer from %s to %s: %w", transfer.SourceChainID, transfer.DestinationChainID, err)
+		return "", "", "", err
 	}
 
 	// we take the lock here to ensure that we block on the cancel relay function completing
@@ -235,6 +322,23 @@ func (r *RelayerRunner) relayTransfer(ctx context.Context, transfer db.Hyperlane
 	return destinationTxHash, destinationChainID, rawTx, err
 }
 
+// transferCostCap returns the max uusdc that should be paid to relay transfer
+func (r *RelayerRunner) transferCostCap(ctx context.Context, transfer db.HyperlaneTransfer) (*big.Int, error) {
+	var maxRelayTxFeeUUSDC *big.Int
+	if transfer.MaxTxFeeUusdc.Valid {
+		maxTxFeeUUSDC, ok := new(big.Int).SetString(transfer.MaxTxFeeUusdc.String, 10)
+		if !ok {
+			return nil, fmt.Errorf("converting max tx fee uusdc %s to *big.Int", transfer.MaxTxFeeUusdc.String)
+		}
+		maxRelayTxFeeUUSDC = maxTxFeeUUSDC
+	}
+	costCap, err := r.getRelayCostCap(ctx, transfer.DestinationChainID, maxRelayTxFeeUUSDC, transfer.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("getting relay cost cap for transfer from %s to %s: %w", transfer.SourceChainID, transfer.DestinationChainID, err)
+	}
+	return costCap, nil
+}
+
 // checkHyperlaneTransferStatus checks if a hyperlane transfer should be
 // relayed or not
 func (r *RelayerRunner) checkHyperlaneTransferStatus(ctx context.Context, transfer db.HyperlaneTransfer) (shouldRelay bool, err error) {
@@ -286,6 +390,17 @@ func (r *RelayerRunner) checkHyperlaneTransferStatus(ctx context.Context, transf
 			return true, nil
 		}
 
+		stuckTxReplacementTimeout := destinationChainConfig.Relayer.StuckTxReplacementTimeout
+		if stuckTxReplacementTimeout <= 0 {
+			stuckTxReplacementTimeout = defaultStuckTxReplacementTimeout
+		}
+		if lastAttempt.TxStatus == dbtypes.TxStatusPending && time.Since(lastAttempt.CreatedAt) > stuckTxReplacementTimeout {
+			if err := r.replaceStuckTx(ctx, transfer, destinationChainConfig.HyperlaneDomain, lastAttempt, txs); err != nil {
+				return false, fmt.Errorf("replacing stuck delivery tx %s: %w", lastAttempt.TxHash, err)
+			}
+			return false, nil
+		}
+
 		lmt.Logger(ctx).Info(
 			"delivery attempt already made for message, waiting for retry interval to pass",
 			zap.String("sourceChainID", transfer.SourceChainID),
@@ -300,6 +415,124 @@ func (r *RelayerRunner) checkHyperlaneTransferStatus(ctx context.Context, transf
 	return true, nil
 }
 
+// replaceStuckTx replaces a delivery tx that has been pending for too long
+// with a tx that has the same nonce and bumped fees, so that it does not
+// leave a nonce gap or pay for the delivery twice. The replacement is tracked
+// as a new submitted tx that replaces the stuck tx.
+func (r *RelayerRunner) replaceStuckTx(
+	ctx context.Context,
+	transfer db.HyperlaneTransfer,
+	destinationDomain string,
+	stuckTx db.SubmittedTx,
+	txs []db.SubmittedTx,
+) error {
+	costCap, err := r.transferCostCap(ctx, transfer)
+	if err != nil {
+		return err
+	}
+
+	hash, rawTx, err := r.hyperlane.ReplaceProcess(ctx, destinationDomain, stuckTx.RawTx, costCap)
+	switch {
+	case errors.Is(err, types.ErrTxReplacementNotSupported):
+		return nil
+	case errors.Is(err, evmtxexecutor.ErrReplacementTooExpensive):
+		pendingSince := oldestPendingTx(txs).CreatedAt
+		if time.Since(pendingSince) > stuckTxAbandonTimeout {
+			// the stuck tx may have been dropped from the mempool, in which
+			// case it will never be mined. abandon it so that the message is
+			// relayed again with a new nonce, the nonce of the dropped tx is
+			// filled by the tx executor once its gap is noticed. if the stuck
+			// tx is mined after all the new relay only fails since the
+			// message has already been delivered.
+			lmt.Logger(ctx).Warn(
+				"replacing stuck delivery tx has been too expensive for too long, abandoning delivery attempts",
+				zap.Int64("transferId", transfer.ID),
+				zap.String("destinationChainID", transfer.DestinationChainID),
+				zap.String("stuckTxHash", stuckTx.TxHash),
+				zap.Time("pendingSince", pendingSince),
+				zap.Error(err),
+			)
+			return r.abandonPendingDeliveryTxs(ctx, txs, "tx was stuck and too expensive to replace")
+		}
+		lmt.Logger(ctx).Warn(
+			"replacing stuck delivery tx is too expensive, waiting for it to be mined",
+			zap.Int64("transferId", transfer.ID),
+			zap.String("destinationChainID", transfer.DestinationChainID),
+			zap.String("stuckTxHash", stuckTx.TxHash),
+			zap.Error(err),
+		)
+		return nil
+	case err != nil && evmtxexecutor.IsNonceError(err):
+		// the stuck txs nonce has been used by another tx. the message was
+		// checked to not be delivered before replacing, but one of the
+		// delivery attempts may have been mined since, so delivery is checked
+		// again now that the nonce is known to be taken.
+		delivered, err := r.hyperlane.HasBeenDelivered(ctx, destinationDomain, transfer.MessageID)
+		if err != nil {
+			return fmt.Errorf("checking if message with id %s has been delivered: %w", transfer.MessageID, err)
+		}
+		if delivered {
+			// the transfer is marked as successful on the next relay pass and
+			// the delivery txs are resolved by the tx verifier
+			return nil
+		}
+
+		// none of the delivery attempts with the nonce were mined. abandon
+		// them so that the message is relayed again with a new nonce.
+		lmt.Logger(ctx).Warn(
+			"stuck delivery tx nonce has been used by another tx, abandoning delivery attempts",
+			zap.Int64("transferId", transfer.ID),
+			zap.String("destinationChainID", transfer.DestinationChainID),
+			zap.String("stuckTxHash", stuckTx.TxHash),
+		)
+		return r.abandonPendingDeliveryTxs(ctx, txs, "tx nonce was used by another tx")
+	case err != nil:
+		return err
+	}
+	metrics.FromContext(ctx).IncTransactionSubmitted(true, transfer.DestinationChainID, dbtypes.TxTypeHyperlaneMessageDelivery)
+
+	txHash := hex.EncodeToString(hash)
+	if _, err := r.db.InsertSubmittedTx(ctx, db.InsertSubmittedTxParams{
+		HyperlaneTransferID:   sql.NullInt64{Int64: transfer.ID, Valid: true},
+		ChainID:               transfer.DestinationChainID,
+		TxHash:                txHash,
+		RawTx:                 rawTx,
+		TxType:                dbtypes.TxTypeHyperlaneMessageDelivery,
+		TxStatus:              dbtypes.TxStatusPending,
+		ReplacesSubmittedTxID: sql.NullInt64{Int64: stuckTx.ID, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("inserting replacement delivery tx %s: %w", txHash, err)
+	}
+
+	lmt.Logger(ctx).Info(
+		"replaced stuck hyperlane message delivery tx",
+		zap.Int64("transferId", transfer.ID),
+		zap.String("destinationChainID", transfer.DestinationChainID),
+		zap.String("stuckTxHash", stuckTx.TxHash),
+		zap.String("replacementTxHash", txHash),
+	)
+	return nil
+}
+
+// abandonPendingDeliveryTxs abandons every pending delivery tx in txs so that
+// the message they deliver is relayed again
+func (r *RelayerRunner) abandonPendingDeliveryTxs(ctx context.Context, txs []db.SubmittedTx, reason string) error {
+	for _, tx := range txs {
+		if tx.TxStatus != dbtypes.TxStatusPending {
+			continue
+		}
+		if _, err := r.db.SetSubmittedTxStatus(ctx, db.SetSubmittedTxStatusParams{
+			TxStatus:        dbtypes.TxStatusAbandoned,
+			TxStatusMessage: sql.NullString{String: reason, Valid: true},
+			TxHash:          tx.TxHash,
+			ChainID:         tx.ChainID,
+		}); err != nil {
+			return fmt.Errorf("abandoning delivery tx %s: %w", tx.TxHash, err)
+		}
+	}
+	return nil
+}
+
 // SubmitTxToRelay submits a transaction hash on a source chain to be relayed.
 // This transaction must contain a dispatch message/event that can be relayed
 // by hyperlane. This tx will not be immediately relayed but will be placed in
@@ -350,7 +583,20 @@ func (r *RelayerRunner) SubmitTxToRelay(
 	}
 
 	hyperlaneTransfer, err = r.db.InsertHyperlaneTransfer(ctx, insert)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+	if errors.Is(err, sql.ErrNoRows) {
+		// the message has already been submitted to be relayed, i.e. by the
+		// dispatch indexer, return the existing transfer
+		hyperlaneTransfer, err = r.db.GetHyperlaneTransferByMessageID(ctx, db.GetHyperlaneTransferByMessageIDParams{
+			SourceChainID:      sourceChainID,
+			DestinationChainID: destinationChainID,
+			MessageID:          dispatch.MessageID,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("getting existing hyperlane transfer for message %s: %w", dispatch.MessageID, err)
+		}
+		return hyperlaneTransfer.ID, nil
+	}
+	if err != nil {
 		return 0, fmt.Errorf("inserting hyperlane transfer: %w", err)
 	}
 	metrics.FromContext(ctx).IncHyperlaneMessages(sourceChainID, destinationChainID, dbtypes.TransferStatusPending)
@@ -433,3 +679,18 @@ func mostRecentTx(txs []db.SubmittedTx) db.SubmittedTx {
 	}
 	return recentTx
 }
+
+// oldestPendingTx returns the pending tx in txs that was submitted first. txs
+// must contain at least one pending tx.
+func oldestPendingTx(txs []db.SubmittedTx) db.SubmittedTx {
+	var oldestTx db.SubmittedTx
+	for _, tx := range txs {
+		if tx.TxStatus != dbtypes.TxStatusPending {
+			continue
+		}
+		if oldestTx.CreatedAt.IsZero() || tx.CreatedAt.Before(oldestTx.CreatedAt) {
+			oldestTx = tx
+		}
+	}
+	return oldestTx
+}
@@ -0,0 +1,172 @@
+package hyperlane
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core"
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	evmtxexecutor "github.com/skip-mev/go-fast-solver/shared/txexecutor/evm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReplaceProcessClient struct {
+	Client
+	err           error
+	delivered     bool
+	replacedRawTx string
+	maxTxFeeUUSDC *big.Int
+}
+
+func (c *fakeReplaceProcessClient) HasBeenDelivered(ctx context.Context, destinationDomain string, messageID string) (bool, error) {
+	return c.delivered, nil
+}
+
+func (c *fakeReplaceProcessClient) ReplaceProcess(ctx context.Context, domain string, rawTx string, maxTxFeeUUSDC *big.Int) ([]byte, string, error) {
+	if c.err != nil {
+		return nil, "", c.err
+	}
+	c.replacedRawTx = rawTx
+	c.maxTxFeeUUSDC = maxTxFeeUUSDC
+	return []byte{0xbe, 0xef}, "replacementRawTx", nil
+}
+
+type fakeSubmittedTxDB struct {
+	Database
+	inserted []db.InsertSubmittedTxParams
+	statuses map[string]string
+}
+
+func (d *fakeSubmittedTxDB) InsertSubmittedTx(ctx context.Context, arg db.InsertSubmittedTxParams) (db.SubmittedTx, error) {
+	d.inserted = append(d.inserted, arg)
+	return db.SubmittedTx{TxHash: arg.TxHash}, nil
+}
+
+func (d *fakeSubmittedTxDB) SetSubmittedTxStatus(ctx context.Context, arg db.SetSubmittedTxStatusParams) (db.SubmittedTx, error) {
+	d.statuses[arg.TxHash] = arg.TxStatus
+	return db.SubmittedTx{TxHash: arg.TxHash, TxStatus: arg.TxStatus}, nil
+}
+
+func TestReplaceStuckTx(t *testing.T) {
+	ctx := config.ConfigReaderContext(context.Background(), config.NewConfigReader(config.Config{
+		Chains: map[string]config.ChainConfig{
+			"42161": {ChainID: "42161", HyperlaneDomain: "42161", Relayer: config.RelayerConfig{RelayCostCapUUSDC: "1000000"}},
+		},
+	}))
+	transfer := db.HyperlaneTransfer{
+		ID:                 1,
+		SourceChainID:      "1",
+		DestinationChainID: "42161",
+		CreatedAt:          time.Now(),
+		MaxTxFeeUusdc:      sql.NullString{String: "500000", Valid: true},
+	}
+	stuckTx := db.SubmittedTx{ID: 10, ChainID: "42161", TxHash: "aa", RawTx: "stuckRawTx", TxStatus: dbtypes.TxStatusPending, CreatedAt: time.Now().Add(-5 * time.Minute)}
+	olderTx := db.SubmittedTx{ID: 9, ChainID: "42161", TxHash: "bb", RawTx: "olderRawTx", TxStatus: dbtypes.TxStatusPending, CreatedAt: time.Now().Add(-10 * time.Minute)}
+
+	t.Run("replacement is tracked as replacing the stuck tx", func(t *testing.T) {
+		database := &fakeSubmittedTxDB{statuses: make(map[string]string)}
+		client := &fakeReplaceProcessClient{}
+		runner := NewRelayerRunner(database, client, nil)
+
+		require.NoError(t, runner.replaceStuckTx(ctx, transfer, "42161", stuckTx, []db.SubmittedTx{olderTx, stuckTx}))
+
+		assert.Equal(t, "stuckRawTx", client.replacedRawTx)
+		assert.Equal(t, big.NewInt(500000), client.maxTxFeeUUSDC)
+		require.Len(t, database.inserted, 1)
+		assert.Equal(t, "beef", database.inserted[0].TxHash)
+		assert.Equal(t, "replacementRawTx", database.inserted[0].RawTx)
+		assert.Equal(t, sql.NullInt64{Int64: stuckTx.ID, Valid: true}, database.inserted[0].ReplacesSubmittedTxID)
+		assert.Equal(t, sql.NullInt64{Int64: transfer.ID, Valid: true}, database.inserted[0].HyperlaneTransferID)
+	})
+
+	t.Run("too expensive replacement waits for the stuck tx", func(t *testing.T) {
+		database := &fakeSubmittedTxDB{statuses: make(map[string]string)}
+		client := &fakeReplaceProcessClient{err: evmtxexecutor.ErrReplacementTooExpensive}
+		runner := NewRelayerRunner(database, client, nil)
+
+		require.NoError(t, runner.replaceStuckTx(ctx, transfer, "42161", stuckTx, []db.SubmittedTx{olderTx, stuckTx}))
+		assert.Empty(t, database.inserted)
+		assert.Empty(t, database.statuses)
+	})
+
+	t.Run("too expensive replacement abandons stuck tx after timeout", func(t *testing.T) {
+		database := &fakeSubmittedTxDB{statuses: make(map[string]string)}
+		client := &fakeReplaceProcessClient{err: evmtxexecutor.ErrReplacementTooExpensive}
+		runner := NewRelayerRunner(database, client, nil)
+
+		droppedTx := olderTx
+		droppedTx.CreatedAt = time.Now().Add(-stuckTxAbandonTimeout - time.Minute)
+		require.NoError(t, runner.replaceStuckTx(ctx, transfer, "42161", stuckTx, []db.SubmittedTx{droppedTx, stuckTx}))
+		assert.Empty(t, database.inserted)
+		assert.Equal(t, map[string]string{"aa": dbtypes.TxStatusAbandoned, "bb": dbtypes.TxStatusAbandoned}, database.statuses)
+	})
+
+	t.Run("used nonce does not abandon delivery that landed", func(t *testing.T) {
+		database := &fakeSubmittedTxDB{statuses: make(map[string]string)}
+		client := &fakeReplaceProcessClient{err: fmt.Errorf("replacing process tx: %w", core.ErrNonceTooLow), delivered: true}
+		runner := NewRelayerRunner(database, client, nil)
+
+		require.NoError(t, runner.replaceStuckTx(ctx, transfer, "42161", stuckTx, []db.SubmittedTx{olderTx, stuckTx}))
+		assert.Empty(t, database.inserted)
+		assert.Empty(t, database.statuses)
+	})
+
+	t.Run("used nonce abandons every pending version", func(t *testing.T) {
+		database := &fakeSubmittedTxDB{statuses: make(map[string]string)}
+		client := &fakeReplaceProcessClient{err: errors.New("replacing process tx: nonce too low")}
+		runner := NewRelayerRunner(database, client, nil)
+
+		require.NoError(t, runner.replaceStuckTx(ctx, transfer, "42161", stuckTx, []db.SubmittedTx{olderTx, stuckTx}))
+		assert.Empty(t, database.inserted)
+		assert.Equal(t, map[string]string{"aa": dbtypes.TxStatusAbandoned, "bb": dbtypes.TxStatusAbandoned}, database.statuses)
+	})
+}
+
+func TestDeferRelay(t *testing.T) {
+	gracePeriod := 5 * time.Minute
+	noGracePeriod := time.Duration(0)
+	ctx := config.ConfigReaderContext(context.Background(), config.NewConfigReader(config.Config{
+		Chains: map[string]config.ChainConfig{
+			"1": {ChainID: "1", Relayer: config.RelayerConfig{
+				PublicRelayerGracePeriod: gracePeriod,
+				Routes: map[string]config.RelayRouteConfig{
+					"10":    {Disabled: true},
+					"42161": {PublicRelayerGracePeriod: &noGracePeriod},
+				},
+			}},
+		},
+	}))
+	runner := NewRelayerRunner(nil, nil, nil)
+	now := time.Now()
+
+	tests := []struct {
+		name               string
+		destinationChainID string
+		age                time.Duration
+		deferred           bool
+	}{
+		{name: "within public relayer grace period", destinationChainID: "8453", age: time.Minute, deferred: true},
+		{name: "after public relayer grace period", destinationChainID: "8453", age: gracePeriod + time.Second, deferred: false},
+		{name: "disabled route", destinationChainID: "10", age: time.Hour, deferred: true},
+		{name: "route without grace period", destinationChainID: "42161", age: 0, deferred: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer := db.HyperlaneTransfer{
+				ID:                 1,
+				SourceChainID:      "1",
+				DestinationChainID: tt.destinationChainID,
+				CreatedAt:          now.Add(-tt.age),
+			}
+			assert.Equal(t, tt.deferred, runner.deferRelay(ctx, transfer, now))
+		})
+	}
+}
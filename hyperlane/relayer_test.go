@@ -0,0 +1,200 @@
+package hyperlane
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMessageID = "0x" + strings.Repeat("33", 32)
+
+// staticCheckpointFetcher serves a single pre signed checkpoint for validator
+type staticCheckpointFetcher struct {
+	validator  string
+	checkpoint *types.SignedCheckpoint
+}
+
+func (f staticCheckpointFetcher) LatestIndex(ctx context.Context) (uint64, error) {
+	return uint64(f.checkpoint.Value.Checkpoint.Index), nil
+}
+
+func (f staticCheckpointFetcher) Checkpoint(ctx context.Context, index uint64) (*types.SignedCheckpoint, error) {
+	if uint64(f.checkpoint.Value.Checkpoint.Index) != index {
+		return nil, ErrCheckpointDoesNotExist
+	}
+	return f.checkpoint, nil
+}
+
+func (f staticCheckpointFetcher) Validator() string {
+	return f.validator
+}
+
+func signCheckpoint(t *testing.T, key *ecdsa.PrivateKey, index uint32) *types.SignedCheckpoint {
+	checkpoint := &types.SignedCheckpoint{
+		Value: types.CheckpointWithMessageID{
+			Checkpoint: types.Checkpoint{
+				MerkleTreeHookAddress: "0x" + strings.Repeat("11", 32),
+				MailboxDomain:         1,
+				Root:                  "0x" + strings.Repeat("22", 32),
+				Index:                 index,
+			},
+			MessageID: testMessageID,
+		},
+	}
+	digest, err := checkpoint.Digest()
+	require.NoError(t, err)
+	signature, err := crypto.Sign(accounts.TextHash(digest), key)
+	require.NoError(t, err)
+
+	checkpoint.Signature = types.Signature{
+		R: hex.EncodeToString(signature[:32]),
+		S: hex.EncodeToString(signature[32:64]),
+		V: signature[64] + 27,
+	}
+	return checkpoint
+}
+
+func newValidatorKeys(t *testing.T, n int) ([]*ecdsa.PrivateKey, []common.Address) {
+	var keys []*ecdsa.PrivateKey
+	var addresses []common.Address
+	for i := 0; i < n; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys = append(keys, key)
+		addresses = append(addresses, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return keys, addresses
+}
+
+func TestCheckpointAtIndex(t *testing.T) {
+	ctx := context.Background()
+	r := &relayer{checkpoints: newCheckpointCache()}
+	keys, validators := newValidatorKeys(t, 3)
+
+	// each subtest uses a fresh cache so that checkpoints are always fetched
+	// from its own fetchers
+	cached := func(fetchers ...CheckpointFetcher) []*cachedCheckpointFetcher {
+		var wrapped []*cachedCheckpointFetcher
+		for _, fetcher := range fetchers {
+			wrapped = append(wrapped, &cachedCheckpointFetcher{CheckpointFetcher: fetcher, domain: t.Name(), cache: newCheckpointCache()})
+		}
+		return wrapped
+	}
+
+	t.Run("signatures are returned in validator set order", func(t *testing.T) {
+		fetchers := cached(
+			staticCheckpointFetcher{validators[2].Hex(), signCheckpoint(t, keys[2], 5)},
+			staticCheckpointFetcher{validators[0].Hex(), signCheckpoint(t, keys[0], 5)},
+		)
+
+		checkpoint, err := r.checkpointAtIndex(ctx, 5, fetchers, validators, 2, time.Second, testMessageID)
+		require.NoError(t, err)
+		require.Len(t, checkpoint.Signatures, 2)
+
+		first := types.SignedCheckpoint{Value: checkpoint.Checkpoint, Signature: checkpoint.Signatures[0]}
+		signer, err := first.Signer()
+		require.NoError(t, err)
+		assert.Equal(t, validators[0], signer)
+	})
+
+	t.Run("signature from a different validator than the storage location is rejected", func(t *testing.T) {
+		fetchers := cached(
+			staticCheckpointFetcher{validators[0].Hex(), signCheckpoint(t, keys[0], 5)},
+			// validator 1's storage location serves a checkpoint signed by
+			// validator 2
+			staticCheckpointFetcher{validators[1].Hex(), signCheckpoint(t, keys[2], 5)},
+		)
+
+		_, err := r.checkpointAtIndex(ctx, 5, fetchers, validators, 2, time.Second, testMessageID)
+		assert.ErrorIs(t, err, ErrNotEnoughSignaturesFound)
+
+		// only the verified checkpoint is cached
+		_, ok := fetchers[0].cache.checkpoint(fetchers[0].domain, fetchers[0].Validator(), 5)
+		assert.True(t, ok)
+		_, ok = fetchers[1].cache.checkpoint(fetchers[1].domain, fetchers[1].Validator(), 5)
+		assert.False(t, ok)
+	})
+
+	t.Run("signer outside of the validator set is rejected", func(t *testing.T) {
+		outsiderKeys, outsiders := newValidatorKeys(t, 1)
+		fetchers := cached(
+			staticCheckpointFetcher{validators[0].Hex(), signCheckpoint(t, keys[0], 5)},
+			staticCheckpointFetcher{outsiders[0].Hex(), signCheckpoint(t, outsiderKeys[0], 5)},
+		)
+
+		_, err := r.checkpointAtIndex(ctx, 5, fetchers, validators, 2, time.Second, testMessageID)
+		assert.ErrorIs(t, err, ErrNotEnoughSignaturesFound)
+	})
+
+	t.Run("duplicate signers are only counted once", func(t *testing.T) {
+		checkpoint := signCheckpoint(t, keys[0], 5)
+		fetchers := cached(
+			staticCheckpointFetcher{validators[0].Hex(), checkpoint},
+			staticCheckpointFetcher{strings.ToLower(validators[0].Hex()), checkpoint},
+		)
+
+		_, err := r.checkpointAtIndex(ctx, 5, fetchers, validators, 2, time.Second, testMessageID)
+		assert.ErrorIs(t, err, ErrNotEnoughSignaturesFound)
+	})
+}
+
+// announcingClient serves announced storage locations for validators and
+// records which validators were looked up
+type announcingClient struct {
+	Client
+	lookedUp []common.Address
+}
+
+func (c *announcingClient) ValidatorStorageLocations(ctx context.Context, domain string, validators []common.Address) ([]*types.ValidatorStorageLocation, error) {
+	c.lookedUp = append(c.lookedUp, validators...)
+	var locations []*types.ValidatorStorageLocation
+	for _, validator := range validators {
+		locations = append(locations, &types.ValidatorStorageLocation{Validator: validatorKey(validator.Hex()), StorageLocation: "s3://announced/us-east-1"})
+	}
+	return locations, nil
+}
+
+func TestResolveValidatorStorageLocations(t *testing.T) {
+	_, validators := newValidatorKeys(t, 4)
+	_, unknownValidators := newValidatorKeys(t, 1)
+	origin := config.ChainConfig{
+		ChainID:         "1",
+		HyperlaneDomain: "1",
+		Relayer: config.RelayerConfig{
+			CheckpointStorageLocationOverrides: map[string]string{
+				validators[1].Hex():        "https://unannounced.xyz",
+				validators[2].Hex():        "gs://config-override",
+				validators[3].Hex():        "gs://config-override",
+				unknownValidators[0].Hex(): "https://unknown.xyz",
+			},
+		},
+	}
+	overrides := map[string]string{strings.TrimPrefix(strings.ToLower(validators[3].Hex()), "0x"): "gs://cli-override"}
+
+	client := &announcingClient{}
+	locations, err := resolveValidatorStorageLocations(context.Background(), newCheckpointCache(), client, origin, validators, overrides)
+	require.NoError(t, err)
+
+	resolved := make(map[string]string)
+	for _, location := range locations {
+		resolved[location.Validator] = location.StorageLocation
+	}
+	assert.Equal(t, map[string]string{
+		validatorKey(validators[0].Hex()): "s3://announced/us-east-1",
+		validatorKey(validators[1].Hex()): "https://unannounced.xyz",
+		validatorKey(validators[2].Hex()): "gs://config-override",
+		validatorKey(validators[3].Hex()): "gs://cli-override",
+	}, resolved, "validators outside of the ism validator set should not be used")
+	assert.Equal(t, []common.Address{validators[0]}, client.lookedUp, "only validators without a configured storage location should be looked up")
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	MERKLE_TREE_DEPTH = 32
+	MERKLE_PROOF_LEN  = MERKLE_TREE_DEPTH * 32
+)
+
+// zeroHashes[i] is the root of an empty merkle tree of depth i
+var zeroHashes = func() [MERKLE_TREE_DEPTH][32]byte {
+	var zeros [MERKLE_TREE_DEPTH][32]byte
+	for i := 1; i < MERKLE_TREE_DEPTH; i++ {
+		zeros[i] = crypto.Keccak256Hash(zeros[i-1][:], zeros[i-1][:])
+	}
+	return zeros
+}()
+
+// MerkleProof is the sibling of a leaf at every level of the merkle tree,
+// starting from the leaf level
+type MerkleProof [MERKLE_TREE_DEPTH][32]byte
+
+// MerkleTree is a local copy of a Hyperlane MerkleTreeHook's incremental
+// merkle tree. Unlike the on chain incremental tree, every leaf is kept so
+// that proofs can be generated for any leaf against the root of the tree at
+// any point in its history.
+type MerkleTree struct {
+	leaves [][32]byte
+}
+
+func NewMerkleTree() *MerkleTree {
+	return &MerkleTree{}
+}
+
+// Count is the number of leaves that have been inserted into the tree
+func (t *MerkleTree) Count() uint64 {
+	return uint64(len(t.leaves))
+}
+
+// Insert appends leaf to the tree at the next index
+func (t *MerkleTree) Insert(leaf [32]byte) error {
+	if t.Count() >= uint64(1)<<MERKLE_TREE_DEPTH {
+		return fmt.Errorf("merkle tree is full")
+	}
+	t.leaves = append(t.leaves, leaf)
+	return nil
+}
+
+// Root is the root of the tree containing all inserted leaves
+func (t *MerkleTree) Root() [32]byte {
+	root, _ := t.RootAt(t.Count())
+	return root
+}
+
+// RootAt is the root of the tree when it contained only its first count
+// leaves
+func (t *MerkleTree) RootAt(count uint64) ([32]byte, error) {
+	if count > t.Count() {
+		return [32]byte{}, fmt.Errorf("tree has %d leaves, can not compute root at count %d", t.Count(), count)
+	}
+	if count == 0 {
+		return crypto.Keccak256Hash(zeroHashes[MERKLE_TREE_DEPTH-1][:], zeroHashes[MERKLE_TREE_DEPTH-1][:]), nil
+	}
+	proof, err := t.Proof(0, count)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return BranchRoot(t.leaves[0], proof, 0), nil
+}
+
+// Proof generates a merkle proof for the leaf at index against the root of
+// the tree when it contained only its first count leaves
+func (t *MerkleTree) Proof(index uint64, count uint64) (MerkleProof, error) {
+	if count > t.Count() {
+		return MerkleProof{}, fmt.Errorf("tree has %d leaves, can not generate proof at count %d", t.Count(), count)
+	}
+	if index >= count {
+		return MerkleProof{}, fmt.Errorf("leaf index %d is not in tree with %d leaves", index, count)
+	}
+
+	var proof MerkleProof
+	level := make([][32]byte, count)
+	copy(level, t.leaves[:count])
+	position := index
+	for depth := 0; depth < MERKLE_TREE_DEPTH; depth++ {
+		sibling := position ^ 1
+		if sibling < uint64(len(level)) {
+			proof[depth] = level[sibling]
+		} else {
+			proof[depth] = zeroHashes[depth]
+		}
+
+		// hash the current level into its parent level, padding with the
+		// empty subtree root of this depth if the level has an odd length
+		parents := make([][32]byte, (len(level)+1)/2)
+		for i := range parents {
+			left := level[2*i]
+			right := zeroHashes[depth]
+			if 2*i+1 < len(level) {
+				right = level[2*i+1]
+			}
+			parents[i] = crypto.Keccak256Hash(left[:], right[:])
+		}
+		level = parents
+		position /= 2
+	}
+
+	return proof, nil
+}
+
+// BranchRoot computes the root of a merkle tree from a leaf at index and its
+// merkle proof, matching Hyperlane's MerkleLib.branchRoot
+func BranchRoot(leaf [32]byte, proof MerkleProof, index uint64) [32]byte {
+	current := leaf
+	for depth := 0; depth < MERKLE_TREE_DEPTH; depth++ {
+		if (index>>depth)&1 == 1 {
+			current = crypto.Keccak256Hash(proof[depth][:], current[:])
+		} else {
+			current = crypto.Keccak256Hash(current[:], proof[depth][:])
+		}
+	}
+	return current
+}
@@ -0,0 +1,64 @@
+package types_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleTree(t *testing.T) {
+	t.Run("empty tree root matches hyperlane merkle lib", func(t *testing.T) {
+		tree := types.NewMerkleTree()
+		root := tree.Root()
+		assert.Equal(t, "27ae5ba08d7291c96c8cbddcc148bf48a6d68c7974b94356f53754ef6171d757", hex.EncodeToString(root[:]))
+	})
+
+	t.Run("proofs verify against historical roots", func(t *testing.T) {
+		tree := types.NewMerkleTree()
+		var leaves [][32]byte
+		for i := 0; i < 11; i++ {
+			leaf := crypto.Keccak256Hash([]byte{byte(i)})
+			leaves = append(leaves, leaf)
+			require.NoError(t, tree.Insert(leaf))
+		}
+
+		for count := uint64(1); count <= tree.Count(); count++ {
+			root, err := tree.RootAt(count)
+			require.NoError(t, err)
+			for index := uint64(0); index < count; index++ {
+				proof, err := tree.Proof(index, count)
+				require.NoError(t, err)
+				assert.Equal(t, root, types.BranchRoot(leaves[index], proof, index))
+			}
+		}
+	})
+
+	t.Run("single leaf root", func(t *testing.T) {
+		tree := types.NewMerkleTree()
+		leaf := crypto.Keccak256Hash([]byte("message"))
+		require.NoError(t, tree.Insert(leaf))
+
+		// a single leaf is hashed with the empty subtree root at every level
+		expected := [32]byte(leaf)
+		zero := [32]byte{}
+		for i := 0; i < types.MERKLE_TREE_DEPTH; i++ {
+			expected = crypto.Keccak256Hash(expected[:], zero[:])
+			zero = crypto.Keccak256Hash(zero[:], zero[:])
+		}
+		assert.Equal(t, expected, tree.Root())
+	})
+
+	t.Run("proof for leaf outside of tree errors", func(t *testing.T) {
+		tree := types.NewMerkleTree()
+		require.NoError(t, tree.Insert([32]byte{1}))
+
+		_, err := tree.Proof(1, 1)
+		assert.Error(t, err)
+		_, err = tree.Proof(0, 2)
+		assert.Error(t, err)
+	})
+}
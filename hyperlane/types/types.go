@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 )
@@ -47,6 +50,25 @@ func (c SignedCheckpoint) Digest() ([]byte, error) {
 	return crypto.Keccak256Hash(domainHash, root, buf.Bytes(), messageID).Bytes(), nil
 }
 
+// Signer recovers the address of the validator that signed the checkpoint.
+// Validators sign the EIP-191 eth signed message hash of the checkpoint
+// digest.
+func (c SignedCheckpoint) Signer() (common.Address, error) {
+	digest, err := c.Digest()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("computing checkpoint digest: %w", err)
+	}
+	pubkey, err := c.Signature.RecoverPubKey(accounts.TextHash(digest))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("recovering pubkey from signature: %w", err)
+	}
+	publicKey, err := crypto.UnmarshalPubkey(pubkey)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("unmarshaling recovered pubkey: %w", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
 type Checkpoint struct {
 	MerkleTreeHookAddress string `json:"merkle_tree_hook_address"`
 	MailboxDomain         uint32 `json:"mailbox_domain"`
@@ -144,9 +166,10 @@ type MultiSigSignedCheckpoint struct {
 }
 
 const (
-	MERKLE_TREE_ADDRESS_LEN    = 32
-	SIGNED_CHECKPOINT_ROOT_LEN = 32
-	VALIDATOR_SIGNATURE_LENGTH = 65
+	MERKLE_TREE_ADDRESS_LEN          = 32
+	SIGNED_CHECKPOINT_ROOT_LEN       = 32
+	SIGNED_CHECKPOINT_MESSAGE_ID_LEN = 32
+	VALIDATOR_SIGNATURE_LENGTH       = 65
 )
 
 func (c MultiSigSignedCheckpoint) ToMetadata() ([]byte, error) {
@@ -208,6 +231,80 @@ func (c MultiSigSignedCheckpoint) ToMetadata() ([]byte, error) {
 	return metadata.Bytes(), nil
 }
 
+// ToMerkleRootMetadata converts the checkpoint to metadata for a merkle root
+// multisig ism. messageIndex is the index of the message being delivered in
+// the origin merkle tree, and proof is the merkle proof of that message
+// against the signed checkpoints root.
+func (c MultiSigSignedCheckpoint) ToMerkleRootMetadata(messageIndex uint32, proof MerkleProof) ([]byte, error) {
+	/**
+	 * Format of metadata we need to construct:
+	 * [   0:  32] Origin merkle tree address
+	 * [  32:  36] Index of message ID in merkle tree
+	 * [  36:  68] Signed checkpoint message ID
+	 * [  68:1092] Merkle proof
+	 * [1092:1096] Signed checkpoint index
+	 * [1096:????] Validator signatures (length := threshold * 65)
+	 */
+	var buf []byte
+	metadata := bytes.NewBuffer(buf)
+
+	hook, err := hex.DecodeString(strings.TrimPrefix(c.Checkpoint.Checkpoint.MerkleTreeHookAddress, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decoding hex merkle tree checkpoint address: %w", err)
+	}
+	n, err := metadata.Write(hook)
+	if err != nil {
+		return nil, fmt.Errorf("writing merkle tree contract addr %s to message metadata: %w", c.Checkpoint.Checkpoint.MerkleTreeHookAddress, err)
+	}
+	if n != MERKLE_TREE_ADDRESS_LEN {
+		return nil, fmt.Errorf("invalid length for merkle tree contract addr, expected %d, got %d", MERKLE_TREE_ADDRESS_LEN, n)
+	}
+
+	if err = binary.Write(metadata, binary.BigEndian, messageIndex); err != nil {
+		return nil, fmt.Errorf("writing message index %d to message metadata: %w", messageIndex, err)
+	}
+
+	messageID, err := hex.DecodeString(strings.TrimPrefix(c.Checkpoint.MessageID, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decoding hex checkpoint message id: %w", err)
+	}
+	n, err = metadata.Write(messageID)
+	if err != nil {
+		return nil, fmt.Errorf("writing signed checkpoint message id %s to message metadata: %w", c.Checkpoint.MessageID, err)
+	}
+	if n != SIGNED_CHECKPOINT_MESSAGE_ID_LEN {
+		return nil, fmt.Errorf("invalid length for signed checkpoint message id, expected %d, got %d", SIGNED_CHECKPOINT_MESSAGE_ID_LEN, n)
+	}
+
+	for _, sibling := range proof {
+		if _, err = metadata.Write(sibling[:]); err != nil {
+			return nil, fmt.Errorf("writing merkle proof to message metadata: %w", err)
+		}
+	}
+
+	index := c.Checkpoint.Checkpoint.Index
+	if err = binary.Write(metadata, binary.BigEndian, index); err != nil {
+		return nil, fmt.Errorf("writing signed checkpoint index %d to message metadata: %w", index, err)
+	}
+
+	for _, signature := range c.Signatures {
+		sigBytes, err := signature.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("converting signature to bytes: %w", err)
+		}
+
+		n, err = metadata.Write(sigBytes)
+		if err != nil {
+			return nil, fmt.Errorf("writing signature bytes %s to message metadata: %w", string(sigBytes), err)
+		}
+		if n != VALIDATOR_SIGNATURE_LENGTH {
+			return nil, fmt.Errorf("invalid length for signature, expected %d, got %d", VALIDATOR_SIGNATURE_LENGTH, n)
+		}
+	}
+
+	return metadata.Bytes(), nil
+}
+
 type MailboxDispatchEvent struct {
 	Recipient         string
 	Message           string
@@ -217,7 +314,96 @@ type MailboxDispatchEvent struct {
 	MessageID         string
 }
 
+// MailboxDispatchTx is a dispatch emitted by a mailbox along with the tx that
+// emitted it
+type MailboxDispatchTx struct {
+	Dispatch MailboxDispatchEvent
+	TxHash   string
+	// TxSender is the address that signed the tx that emitted the dispatch
+	TxSender string
+	Height   uint64
+}
+
 type MailboxMerkleHookPostDispatchEvent struct {
 	MessageID string `json:"message_id"`
 	Index     uint64 `json:"index"`
 }
+
+// Module types returned by an interchain security modules moduleType query
+const (
+	ISMTypeRouting            uint8 = 1
+	ISMTypeAggregation        uint8 = 2
+	ISMTypeMerkleRootMultisig uint8 = 4
+	ISMTypeMessageIDMultisig  uint8 = 5
+)
+
+// InterchainSecurityModule describes the ism that a message will be verified
+// by on its destination chain. Multisig isms are described by their validator
+// set and the number of validator signatures required. Aggregation isms are
+// described by their sub modules and the number of sub modules that must
+// verify the message. Routing isms are described by the single module that
+// they route the message to.
+type InterchainSecurityModule struct {
+	Address    string
+	ModuleType uint8
+	Threshold  uint8
+
+	// Validators is only set for multisig isms
+	Validators []common.Address
+
+	// Modules is set for aggregation isms, and for routing isms contains the
+	// single module that the message is routed to
+	Modules []InterchainSecurityModule
+}
+
+const (
+	AGGREGATION_METADATA_RANGE_LEN = 8
+)
+
+// ToAggregationMetadata encodes the metadata for each sub module of an
+// aggregation ism into the aggregation ism metadata format. subModuleMetadata
+// must have an entry for every sub module of the ism in the same order as the
+// ism's modules, with a nil entry for sub modules that will not verify the
+// message.
+func ToAggregationMetadata(subModuleMetadata [][]byte) ([]byte, error) {
+	/**
+	 * Format of metadata we need to construct:
+	 * [   0:   8] Sub module 0 metadata start and end offsets (uint32 each)
+	 * [   8:  16] Sub module 1 metadata start and end offsets (uint32 each)
+	 * [ ... ] ...
+	 * [n*8:????] Sub module metadata, concatenated
+	 *
+	 * A sub module with a start offset of 0 has no metadata and is skipped by
+	 * the aggregation ism during verification.
+	 */
+	var buf []byte
+	metadata := bytes.NewBuffer(buf)
+	var subModules []byte
+
+	offset := len(subModuleMetadata) * AGGREGATION_METADATA_RANGE_LEN
+	for i, subMetadata := range subModuleMetadata {
+		var start, end uint32
+		if len(subMetadata) > 0 {
+			start = uint32(offset)
+			end = uint32(offset + len(subMetadata))
+			offset += len(subMetadata)
+			subModules = append(subModules, subMetadata...)
+		}
+		if err := binary.Write(metadata, binary.BigEndian, start); err != nil {
+			return nil, fmt.Errorf("writing sub module %d metadata start offset to message metadata: %w", i, err)
+		}
+		if err := binary.Write(metadata, binary.BigEndian, end); err != nil {
+			return nil, fmt.Errorf("writing sub module %d metadata end offset to message metadata: %w", i, err)
+		}
+	}
+
+	if _, err := metadata.Write(subModules); err != nil {
+		return nil, fmt.Errorf("writing sub module metadata to message metadata: %w", err)
+	}
+
+	return metadata.Bytes(), nil
+}
+
+// ErrTxReplacementNotSupported is returned when replacing a stuck tx is not
+// supported on a chain
+var ErrTxReplacementNotSupported = errors.New("tx replacement is not supported")
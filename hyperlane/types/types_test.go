@@ -0,0 +1,77 @@
+package types_test
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToAggregationMetadata(t *testing.T) {
+	t.Run("sub modules without metadata have empty ranges", func(t *testing.T) {
+		metadata, err := types.ToAggregationMetadata([][]byte{
+			{0x01, 0x02},
+			nil,
+			{0x03, 0x04, 0x05},
+		})
+		require.NoError(t, err)
+
+		// 3 ranges of 8 bytes followed by 5 bytes of sub module metadata
+		require.Len(t, metadata, 29)
+
+		rangeAt := func(i int) (uint32, uint32) {
+			return binary.BigEndian.Uint32(metadata[i*8 : i*8+4]), binary.BigEndian.Uint32(metadata[i*8+4 : i*8+8])
+		}
+
+		start, end := rangeAt(0)
+		assert.Equal(t, uint32(24), start)
+		assert.Equal(t, uint32(26), end)
+		assert.Equal(t, []byte{0x01, 0x02}, metadata[start:end])
+
+		start, end = rangeAt(1)
+		assert.Equal(t, uint32(0), start)
+		assert.Equal(t, uint32(0), end)
+
+		start, end = rangeAt(2)
+		assert.Equal(t, uint32(26), start)
+		assert.Equal(t, uint32(29), end)
+		assert.Equal(t, []byte{0x03, 0x04, 0x05}, metadata[start:end])
+	})
+
+	t.Run("no sub modules", func(t *testing.T) {
+		metadata, err := types.ToAggregationMetadata(nil)
+		require.NoError(t, err)
+		assert.Empty(t, metadata)
+	})
+}
+
+func TestToMerkleRootMetadata(t *testing.T) {
+	checkpoint := types.MultiSigSignedCheckpoint{
+		Checkpoint: types.CheckpointWithMessageID{
+			Checkpoint: types.Checkpoint{
+				MerkleTreeHookAddress: "0x" + strings.Repeat("11", 32),
+				Root:                  "0x" + strings.Repeat("22", 32),
+				Index:                 7,
+			},
+			MessageID: "0x" + strings.Repeat("33", 32),
+		},
+		Signatures: []types.Signature{
+			{R: strings.Repeat("44", 32), S: strings.Repeat("55", 32), V: 27},
+		},
+	}
+	var proof types.MerkleProof
+	proof[0] = [32]byte{0x66}
+
+	metadata, err := checkpoint.ToMerkleRootMetadata(5, proof)
+	require.NoError(t, err)
+	require.Len(t, metadata, 1096+65)
+
+	assert.Equal(t, uint32(5), binary.BigEndian.Uint32(metadata[32:36]))
+	assert.Equal(t, byte(0x33), metadata[36])
+	assert.Equal(t, byte(0x66), metadata[68])
+	assert.Equal(t, uint32(7), binary.BigEndian.Uint32(metadata[1092:1096]))
+	assert.Equal(t, byte(27), metadata[1096+64])
+}
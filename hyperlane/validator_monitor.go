@@ -0,0 +1,322 @@
+package hyperlane
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	validatorMonitorInterval = time.Minute
+
+	// defaultValidatorLagThreshold is the number of checkpoints a validator
+	// can be behind the origin merkle tree and still be considered able to
+	// sign new messages, if no threshold is configured
+	defaultValidatorLagThreshold = 5
+
+	// defaultQuorumLossAlertThreshold is how long a route can be without a
+	// reachable quorum of validators before alerting, if no threshold is
+	// configured
+	defaultQuorumLossAlertThreshold = 10 * time.Minute
+)
+
+// route is a hyperlane route that messages are sent over, from an origin chain
+// to a destination chain
+type route struct {
+	originChainID      string
+	destinationChainID string
+}
+
+type routeQuorum struct {
+	reachable bool
+	// lostAt is when the route last lost its quorum, it is the zero time if
+	// the route has a reachable quorum
+	lostAt time.Time
+}
+
+// ValidatorMonitor periodically compares how far each validator securing a
+// route has signed checkpoints against the origin chains merkle tree, and
+// tracks whether each route currently has a quorum of validators that are
+// caught up enough to sign new messages.
+type ValidatorMonitor struct {
+	hyperlane                Client
+	storageLocationOverrides map[string]string
+	checkpoints              *checkpointCache
+
+	lock   sync.RWMutex
+	routes map[route]*routeQuorum
+}
+
+func NewValidatorMonitor(hyperlaneClient Client, storageLocationOverrides map[string]string) *ValidatorMonitor {
+	return &ValidatorMonitor{
+		hyperlane:                hyperlaneClient,
+		storageLocationOverrides: storageLocationOverrides,
+		checkpoints:              newCheckpointCache(),
+		routes:                   make(map[route]*routeQuorum),
+	}
+}
+
+func (m *ValidatorMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(validatorMonitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.monitor(ctx)
+		}
+	}
+}
+
+// IsRouteRelayable returns false if messages sent from originChainID to
+// destinationChainID can not currently be relayed because there is no
+// reachable quorum of validators for the route. Routes that have not been
+// monitored yet are assumed to be relayable.
+func (m *ValidatorMonitor) IsRouteRelayable(originChainID, destinationChainID string) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	quorum, ok := m.routes[route{originChainID: originChainID, destinationChainID: destinationChainID}]
+	return !ok || quorum.reachable
+}
+
+func (m *ValidatorMonitor) monitor(ctx context.Context) {
+	var chains []config.ChainConfig
+	for _, chainConfig := range config.GetConfigReader(ctx).Config().Chains {
+		if chainConfig.HyperlaneDomain == "" || chainConfig.FastTransferContractAddress == "" {
+			continue
+		}
+		chains = append(chains, chainConfig)
+	}
+
+	for _, origin := range chains {
+		var destinations []config.ChainConfig
+		for _, destination := range chains {
+			if destination.ChainID != origin.ChainID {
+				destinations = append(destinations, destination)
+			}
+		}
+		if err := m.monitorOrigin(ctx, origin, destinations, time.Now()); err != nil {
+			lmt.Logger(ctx).Error(
+				"error monitoring hyperlane validators",
+				zap.String("originChainID", origin.ChainID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// monitorOrigin updates the validator lag and quorum of every route from
+// origin to destinations
+func (m *ValidatorMonitor) monitorOrigin(ctx context.Context, origin config.ChainConfig, destinations []config.ChainConfig, now time.Time) error {
+	leafCount, err := m.hyperlane.MerkleTreeLeafCount(ctx, origin.HyperlaneDomain)
+	if err != nil {
+		return fmt.Errorf("getting merkle tree leaf count: %w", err)
+	}
+
+	lagThreshold := origin.Relayer.ValidatorLagThreshold
+	if lagThreshold == 0 {
+		lagThreshold = defaultValidatorLagThreshold
+	}
+	alertThreshold := origin.Relayer.QuorumLossAlertThreshold
+	if alertThreshold <= 0 {
+		alertThreshold = defaultQuorumLossAlertThreshold
+	}
+
+	// the lag of each validator is shared by every route from origin, so
+	// only fetch it once per validator
+	caughtUp := make(map[common.Address]bool)
+	for _, destination := range destinations {
+		ism, err := m.routeISM(ctx, origin, destination)
+		if err != nil {
+			lmt.Logger(ctx).Warn(
+				"could not get ism for hyperlane route",
+				zap.String("originChainID", origin.ChainID),
+				zap.String("destinationChainID", destination.ChainID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		var uncheckedValidators []common.Address
+		for _, validator := range ismValidators(*ism) {
+			if _, ok := caughtUp[validator]; !ok {
+				uncheckedValidators = append(uncheckedValidators, validator)
+			}
+		}
+		for validator, lag := range m.validatorLags(ctx, origin, uncheckedValidators, leafCount) {
+			caughtUp[validator] = lag <= lagThreshold
+		}
+
+		m.updateRouteQuorum(ctx, route{originChainID: origin.ChainID, destinationChainID: destination.ChainID}, quorumReachable(*ism, caughtUp), now, alertThreshold)
+	}
+
+	return nil
+}
+
+// routeISM resolves the ism that messages sent from origin to the gateway on
+// destination are verified by
+func (m *ValidatorMonitor) routeISM(ctx context.Context, origin config.ChainConfig, destination config.ChainConfig) (*types.InterchainSecurityModule, error) {
+	message, err := routeProbeMessage(origin.HyperlaneDomain, destination.HyperlaneDomain)
+	if err != nil {
+		return nil, err
+	}
+	return m.hyperlane.InterchainSecurityModule(ctx, destination.HyperlaneDomain, destination.FastTransferContractAddress, message)
+}
+
+// validatorLags returns how many checkpoints each validator is behind the
+// origin merkle tree that has leafCount leaves. Validators whose latest signed
+// checkpoint can not be fetched are omitted.
+func (m *ValidatorMonitor) validatorLags(ctx context.Context, origin config.ChainConfig, validators []common.Address, leafCount uint64) map[common.Address]uint64 {
+	lags := make(map[common.Address]uint64)
+	if len(validators) == 0 {
+		return lags
+	}
+
+	storageLocations, err := resolveValidatorStorageLocations(ctx, m.checkpoints, m.hyperlane, origin, validators, m.storageLocationOverrides)
+	if err != nil {
+		lmt.Logger(ctx).Warn("could not get validator storage locations", zap.String("originChainID", origin.ChainID), zap.Error(err))
+		return lags
+	}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for _, location := range storageLocations {
+		fetcher, err := NewCheckpointFetcherFromStorageLocation(location.StorageLocation, location.Validator, CheckpointFetcherOptions{Timeout: origin.Relayer.CheckpointFetchTimeout})
+		if err != nil {
+			lmt.Logger(ctx).Warn("could not create checkpoint fetcher", zap.String("validator", location.Validator), zap.Error(err))
+			continue
+		}
+
+		wg.Add(1)
+		go func(fetcher CheckpointFetcher) {
+			defer wg.Done()
+			latestIndex, err := fetcher.LatestIndex(ctx)
+			if err != nil {
+				lmt.Logger(ctx).Warn(
+					"could not get validator latest signed checkpoint index",
+					zap.String("originChainID", origin.ChainID),
+					zap.String("validator", fetcher.Validator()),
+					zap.Error(err),
+				)
+				return
+			}
+
+			lag := checkpointLag(leafCount, latestIndex)
+			metrics.FromContext(ctx).SetHyperlaneValidatorLag(origin.ChainID, fetcher.Validator(), lag)
+
+			lock.Lock()
+			defer lock.Unlock()
+			lags[common.HexToAddress(fetcher.Validator())] = lag
+		}(fetcher)
+	}
+	wg.Wait()
+
+	return lags
+}
+
+func (m *ValidatorMonitor) updateRouteQuorum(ctx context.Context, r route, reachable bool, now time.Time, alertThreshold time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	quorum, ok := m.routes[r]
+	if !ok {
+		quorum = &routeQuorum{reachable: true}
+		m.routes[r] = quorum
+	}
+	switch {
+	case reachable:
+		quorum.lostAt = time.Time{}
+	case quorum.reachable:
+		quorum.lostAt = now
+	}
+	quorum.reachable = reachable
+
+	alerting := !reachable && now.Sub(quorum.lostAt) >= alertThreshold
+	if alerting {
+		lmt.Logger(ctx).Error(
+			"hyperlane route has not had a reachable quorum of validators for longer than the alert threshold",
+			zap.String("originChainID", r.originChainID),
+			zap.String("destinationChainID", r.destinationChainID),
+			zap.Time("lostAt", quorum.lostAt),
+		)
+	}
+	metrics.FromContext(ctx).SetHyperlaneRouteQuorum(r.originChainID, r.destinationChainID, reachable, alerting)
+}
+
+// checkpointLag is how many checkpoints a validator whose latest signed
+// checkpoint is latestIndex is behind a merkle tree with leafCount leaves
+func checkpointLag(leafCount uint64, latestIndex uint64) uint64 {
+	if leafCount == 0 || latestIndex+1 >= leafCount {
+		return 0
+	}
+	return leafCount - 1 - latestIndex
+}
+
+// quorumReachable returns true if enough of the ism's validators are caught
+// up for a message to be verified by it
+func quorumReachable(ism types.InterchainSecurityModule, caughtUp map[common.Address]bool) bool {
+	switch ism.ModuleType {
+	case types.ISMTypeMessageIDMultisig, types.ISMTypeMerkleRootMultisig:
+		var count int
+		for _, validator := range ism.Validators {
+			if caughtUp[validator] {
+				count++
+			}
+		}
+		return count >= int(ism.Threshold)
+	case types.ISMTypeRouting:
+		return len(ism.Modules) == 1 && quorumReachable(ism.Modules[0], caughtUp)
+	case types.ISMTypeAggregation:
+		var count int
+		for _, module := range ism.Modules {
+			if quorumReachable(module, caughtUp) {
+				count++
+			}
+		}
+		return count >= int(ism.Threshold)
+	default:
+		return false
+	}
+}
+
+// ismValidators returns every validator of the ism and its sub modules
+func ismValidators(ism types.InterchainSecurityModule) []common.Address {
+	validators := append([]common.Address{}, ism.Validators...)
+	for _, module := range ism.Modules {
+		validators = append(validators, ismValidators(module)...)
+	}
+	return validators
+}
+
+// routeProbeMessage builds an empty hyperlane message from originDomain to
+// destinationDomain, used to resolve the ism that messages on a route are
+// verified by
+func routeProbeMessage(originDomain string, destinationDomain string) ([]byte, error) {
+	origin, err := strconv.ParseUint(originDomain, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parsing origin domain %s: %w", originDomain, err)
+	}
+	destination, err := strconv.ParseUint(destinationDomain, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parsing destination domain %s: %w", destinationDomain, err)
+	}
+
+	// version (1) | nonce (4) | origin (4) | sender (32) | destination (4) | recipient (32)
+	message := make([]byte, 77)
+	message[0] = 3
+	binary.BigEndian.PutUint32(message[5:9], uint32(origin))
+	binary.BigEndian.PutUint32(message[41:45], uint32(destination))
+	return message, nil
+}
@@ -0,0 +1,156 @@
+package hyperlane
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/skip-mev/go-fast-solver/hyperlane/types"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// latestIndexFetcher only serves a validators latest signed checkpoint index,
+// which is encoded in its storage location as latestindex://<index>
+type latestIndexFetcher struct {
+	CheckpointFetcher
+	validator   string
+	latestIndex uint64
+}
+
+func (f latestIndexFetcher) LatestIndex(ctx context.Context) (uint64, error) {
+	return f.latestIndex, nil
+}
+
+func (f latestIndexFetcher) Validator() string {
+	return f.validator
+}
+
+func init() {
+	RegisterCheckpointFetcher("latestindex", func(storageLocation string, validator string, _ CheckpointFetcherOptions) (CheckpointFetcher, error) {
+		latestIndex, err := strconv.ParseUint(strings.TrimPrefix(storageLocation, "latestindex://"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return latestIndexFetcher{validator: validator, latestIndex: latestIndex}, nil
+	})
+}
+
+type fakeValidatorMonitorClient struct {
+	Client
+	leafCount uint64
+	ism       types.InterchainSecurityModule
+}
+
+func (c *fakeValidatorMonitorClient) MerkleTreeLeafCount(ctx context.Context, domain string) (uint64, error) {
+	return c.leafCount, nil
+}
+
+func (c *fakeValidatorMonitorClient) InterchainSecurityModule(ctx context.Context, domain string, recipient string, message []byte) (*types.InterchainSecurityModule, error) {
+	ism := c.ism
+	return &ism, nil
+}
+
+func (c *fakeValidatorMonitorClient) ValidatorStorageLocations(ctx context.Context, domain string, validators []common.Address) ([]*types.ValidatorStorageLocation, error) {
+	var locations []*types.ValidatorStorageLocation
+	for _, validator := range validators {
+		locations = append(locations, &types.ValidatorStorageLocation{Validator: validator.Hex(), StorageLocation: "latestindex://0"})
+	}
+	return locations, nil
+}
+
+func TestValidatorMonitor(t *testing.T) {
+	ctx := context.Background()
+	origin := config.ChainConfig{ChainID: "1", HyperlaneDomain: "1", FastTransferContractAddress: testGateway}
+	destination := config.ChainConfig{ChainID: "42161", HyperlaneDomain: "42161", FastTransferContractAddress: testGateway}
+	_, validators := newValidatorKeys(t, 3)
+
+	client := &fakeValidatorMonitorClient{
+		leafCount: 100,
+		ism: types.InterchainSecurityModule{
+			ModuleType: types.ISMTypeMessageIDMultisig,
+			Validators: validators,
+			Threshold:  2,
+		},
+	}
+
+	// setLatestIndexes overrides each validators storage location to serve
+	// the given latest signed checkpoint index
+	setLatestIndexes := func(monitor *ValidatorMonitor, indexes ...uint64) {
+		for i, index := range indexes {
+			monitor.storageLocationOverrides[validators[i].Hex()] = "latestindex://" + strconv.FormatUint(index, 10)
+		}
+	}
+
+	monitor := NewValidatorMonitor(client, make(map[string]string))
+	assert.True(t, monitor.IsRouteRelayable("1", "42161"), "unmonitored routes are relayable")
+
+	now := time.Now()
+	setLatestIndexes(monitor, 99, 94, 10)
+	require.NoError(t, monitor.monitorOrigin(ctx, origin, []config.ChainConfig{destination}, now))
+	assert.True(t, monitor.IsRouteRelayable("1", "42161"))
+
+	setLatestIndexes(monitor, 99, 93, 10)
+	require.NoError(t, monitor.monitorOrigin(ctx, origin, []config.ChainConfig{destination}, now.Add(time.Minute)))
+	assert.False(t, monitor.IsRouteRelayable("1", "42161"))
+	assert.Equal(t, now.Add(time.Minute), monitor.routes[route{originChainID: "1", destinationChainID: "42161"}].lostAt)
+
+	// the time quorum was lost is kept while it stays lost
+	require.NoError(t, monitor.monitorOrigin(ctx, origin, []config.ChainConfig{destination}, now.Add(2*time.Minute)))
+	assert.Equal(t, now.Add(time.Minute), monitor.routes[route{originChainID: "1", destinationChainID: "42161"}].lostAt)
+
+	setLatestIndexes(monitor, 99, 99, 10)
+	require.NoError(t, monitor.monitorOrigin(ctx, origin, []config.ChainConfig{destination}, now.Add(3*time.Minute)))
+	assert.True(t, monitor.IsRouteRelayable("1", "42161"))
+	assert.True(t, monitor.routes[route{originChainID: "1", destinationChainID: "42161"}].lostAt.IsZero())
+}
+
+func TestQuorumReachable(t *testing.T) {
+	_, validators := newValidatorKeys(t, 4)
+	caughtUp := map[common.Address]bool{validators[0]: true, validators[1]: true}
+
+	multisig := func(threshold uint8, validators ...common.Address) types.InterchainSecurityModule {
+		return types.InterchainSecurityModule{ModuleType: types.ISMTypeMerkleRootMultisig, Validators: validators, Threshold: threshold}
+	}
+
+	tests := []struct {
+		name      string
+		ism       types.InterchainSecurityModule
+		reachable bool
+	}{
+		{"multisig with enough caught up validators", multisig(2, validators...), true},
+		{"multisig without enough caught up validators", multisig(3, validators...), false},
+		{
+			"routing to a reachable multisig",
+			types.InterchainSecurityModule{ModuleType: types.ISMTypeRouting, Modules: []types.InterchainSecurityModule{multisig(1, validators[0])}},
+			true,
+		},
+		{
+			"aggregation with enough reachable modules",
+			types.InterchainSecurityModule{
+				ModuleType: types.ISMTypeAggregation,
+				Threshold:  1,
+				Modules:    []types.InterchainSecurityModule{multisig(1, validators[2]), multisig(2, validators[:2]...)},
+			},
+			true,
+		},
+		{
+			"aggregation without enough reachable modules",
+			types.InterchainSecurityModule{
+				ModuleType: types.ISMTypeAggregation,
+				Threshold:  2,
+				Modules:    []types.InterchainSecurityModule{multisig(1, validators[2]), multisig(2, validators[:2]...)},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.reachable, quorumReachable(tt.ism, caughtUp))
+		})
+	}
+}
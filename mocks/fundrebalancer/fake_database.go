@@ -2,7 +2,9 @@ package fundrebalancer
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -16,28 +18,66 @@ type FakeTransfer struct {
 	DestinationChainID string
 	Amount             string
 	Status             string
+	QuotedCostUusdc    sql.NullString
+	Kind               string
 	CreatedAt          time.Time
 }
 
 type FakeDatabase struct {
-	db     []*FakeTransfer
-	dbLock *sync.RWMutex
+	// Querier is embedded so that the fake can be passed to InTx callbacks,
+	// queries that are not faked panic
+	db.Querier
+
+	db          []*FakeTransfer
+	steps       []*db.RebalanceTransferStep
+	cctp        []*db.CctpTransfer
+	ibc         []*db.IbcTransfer
+	orders      []db.Order
+	settlements []db.OrderSettlement
+	allowances  []*db.Erc20Allowance
+	dbLock      *sync.RWMutex
+
+	// failStepIndex is the index of the rebalance transfer step that fails
+	// to be inserted with failStepErr, if failStepErr is set
+	failStepIndex int64
+	failStepErr   error
 }
 
 func NewFakeDatabase() *FakeDatabase {
 	return &FakeDatabase{
 		db:     make([]*FakeTransfer, 0),
+		steps:  make([]*db.RebalanceTransferStep, 0),
+		cctp:   make([]*db.CctpTransfer, 0),
 		dbLock: new(sync.RWMutex),
 	}
 }
 
+// InTx runs fn against the fake database, discarding the transfers fn
+// inserted if it returns an error
+func (fdb *FakeDatabase) InTx(ctx context.Context, fn func(ctx context.Context, q db.Querier) error, opts *sql.TxOptions) error {
+	fdb.dbLock.RLock()
+	numTransfers, numSteps, numCCTP, numIBC := len(fdb.db), len(fdb.steps), len(fdb.cctp), len(fdb.ibc)
+	fdb.dbLock.RUnlock()
+
+	if err := fn(ctx, fdb); err != nil {
+		fdb.dbLock.Lock()
+		defer fdb.dbLock.Unlock()
+		fdb.db = fdb.db[:numTransfers]
+		fdb.steps = fdb.steps[:numSteps]
+		fdb.cctp = fdb.cctp[:numCCTP]
+		fdb.ibc = fdb.ibc[:numIBC]
+		return err
+	}
+	return nil
+}
+
 func (fdb *FakeDatabase) GetPendingRebalanceTransfersToChain(ctx context.Context, destinationChainID string) ([]db.GetPendingRebalanceTransfersToChainRow, error) {
 	fdb.dbLock.RLock()
 	defer fdb.dbLock.RUnlock()
 
 	var pendingTransfers []db.GetPendingRebalanceTransfersToChainRow
 	for _, transfer := range fdb.db {
-		if transfer.Status == "PENDING" && transfer.DestinationChainID == destinationChainID {
+		if transfer.Status == "PENDING" && transfer.Kind == "REBALANCE" && transfer.DestinationChainID == destinationChainID {
 			pendingTransfers = append(pendingTransfers, db.GetPendingRebalanceTransfersToChainRow{
 				ID:                 transfer.ID,
 				TxHash:             transfer.TxHash,
@@ -55,6 +95,14 @@ func (fdb *FakeDatabase) InsertSubmittedTx(ctx context.Context, arg db.InsertSub
 }
 
 func (fdb *FakeDatabase) InsertRebalanceTransfer(ctx context.Context, arg db.InsertRebalanceTransferParams) (int64, error) {
+	return fdb.insertTransfer(arg, "REBALANCE")
+}
+
+func (fdb *FakeDatabase) InsertGasTopUpTransfer(ctx context.Context, arg db.InsertGasTopUpTransferParams) (int64, error) {
+	return fdb.insertTransfer(db.InsertRebalanceTransferParams(arg), "GAS_TOP_UP")
+}
+
+func (fdb *FakeDatabase) insertTransfer(arg db.InsertRebalanceTransferParams, kind string) (int64, error) {
 	fdb.dbLock.Lock()
 	defer fdb.dbLock.Unlock()
 
@@ -70,6 +118,8 @@ func (fdb *FakeDatabase) InsertRebalanceTransfer(ctx context.Context, arg db.Ins
 		DestinationChainID: arg.DestinationChainID,
 		Amount:             arg.Amount,
 		Status:             "PENDING",
+		QuotedCostUusdc:    arg.QuotedCostUusdc,
+		Kind:               kind,
 		CreatedAt:          time.Now(),
 	})
 
@@ -90,6 +140,7 @@ func (fdb *FakeDatabase) GetAllPendingRebalanceTransfers(ctx context.Context) ([
 				DestinationChainID: transfer.DestinationChainID,
 				Amount:             transfer.Amount,
 				CreatedAt:          transfer.CreatedAt,
+				Kind:               transfer.Kind,
 			})
 		}
 	}
@@ -124,3 +175,478 @@ func (fdb *FakeDatabase) UpdateTransferCreatedAt(ctx context.Context, id int64,
 	}
 	return fmt.Errorf("transfer with id %d not found", id)
 }
+
+func (fdb *FakeDatabase) GetPendingRebalanceTransfersWithSteps(ctx context.Context) ([]db.RebalanceTransfer, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var pendingTransfers []db.RebalanceTransfer
+	for _, transfer := range fdb.db {
+		if transfer.Status != "PENDING" {
+			continue
+		}
+		for _, step := range fdb.steps {
+			if step.RebalanceTransferID == transfer.ID {
+				pendingTransfers = append(pendingTransfers, db.RebalanceTransfer{
+					ID:                 transfer.ID,
+					CreatedAt:          transfer.CreatedAt,
+					TxHash:             transfer.TxHash,
+					SourceChainID:      transfer.SourceChainID,
+					DestinationChainID: transfer.DestinationChainID,
+					Amount:             transfer.Amount,
+					Status:             transfer.Status,
+					Kind:               transfer.Kind,
+				})
+				break
+			}
+		}
+	}
+	return pendingTransfers, nil
+}
+
+func (fdb *FakeDatabase) GetRebalanceTransfersSince(ctx context.Context, createdAt time.Time) ([]db.RebalanceTransfer, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var transfers []db.RebalanceTransfer
+	for _, transfer := range fdb.db {
+		if transfer.CreatedAt.Before(createdAt) {
+			continue
+		}
+		transfers = append(transfers, db.RebalanceTransfer{
+			ID:                 transfer.ID,
+			CreatedAt:          transfer.CreatedAt,
+			TxHash:             transfer.TxHash,
+			SourceChainID:      transfer.SourceChainID,
+			DestinationChainID: transfer.DestinationChainID,
+			Amount:             transfer.Amount,
+			Status:             transfer.Status,
+			QuotedCostUusdc:    transfer.QuotedCostUusdc,
+			Kind:               transfer.Kind,
+		})
+	}
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].CreatedAt.Before(transfers[j].CreatedAt)
+	})
+	return transfers, nil
+}
+
+func (fdb *FakeDatabase) InsertRebalanceTransferStep(ctx context.Context, arg db.InsertRebalanceTransferStepParams) (db.RebalanceTransferStep, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	if fdb.failStepErr != nil && arg.StepIndex == fdb.failStepIndex {
+		return db.RebalanceTransferStep{}, fdb.failStepErr
+	}
+
+	now := time.Now()
+	step := &db.RebalanceTransferStep{
+		ID:                  int64(len(fdb.steps)),
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		RebalanceTransferID: arg.RebalanceTransferID,
+		StepIndex:           arg.StepIndex,
+		ChainID:             arg.ChainID,
+		Tx:                  arg.Tx,
+		TxHash:              arg.TxHash,
+		SubmittedAt:         arg.SubmittedAt,
+		Status:              arg.Status,
+		SourceDenom:         arg.SourceDenom,
+		DestinationDenom:    arg.DestinationDenom,
+		QuotedAmountIn:      arg.QuotedAmountIn,
+	}
+	fdb.steps = append(fdb.steps, step)
+	return *step, nil
+}
+
+func (fdb *FakeDatabase) GetRebalanceTransferSteps(ctx context.Context, rebalanceTransferID int64) ([]db.RebalanceTransferStep, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var steps []db.RebalanceTransferStep
+	for _, step := range fdb.steps {
+		if step.RebalanceTransferID == rebalanceTransferID {
+			steps = append(steps, *step)
+		}
+	}
+	return steps, nil
+}
+
+func (fdb *FakeDatabase) SetRebalanceTransferStepSubmitting(ctx context.Context, arg db.SetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	for _, step := range fdb.steps {
+		if step.ID == arg.ID && step.Status == "PENDING" {
+			step.Status = "SUBMITTING"
+			step.Tx = arg.Tx
+			return *step, nil
+		}
+	}
+	return db.RebalanceTransferStep{}, sql.ErrNoRows
+}
+
+func (fdb *FakeDatabase) ResetRebalanceTransferStepSubmitting(ctx context.Context, arg db.ResetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	for _, step := range fdb.steps {
+		if step.ID == arg.ID && step.Status == "SUBMITTING" {
+			step.Status = "PENDING"
+			step.StatusMessage = arg.StatusMessage
+			return *step, nil
+		}
+	}
+	return db.RebalanceTransferStep{}, sql.ErrNoRows
+}
+
+func (fdb *FakeDatabase) SetRebalanceTransferStepSubmitted(ctx context.Context, arg db.SetRebalanceTransferStepSubmittedParams) (db.RebalanceTransferStep, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	for _, step := range fdb.steps {
+		if step.ID == arg.ID {
+			step.Status = "SUBMITTED"
+			step.TxHash = arg.TxHash
+			step.SubmittedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			step.UpdatedAt = time.Now()
+			return *step, nil
+		}
+	}
+	return db.RebalanceTransferStep{}, fmt.Errorf("step with id %d not found", arg.ID)
+}
+
+func (fdb *FakeDatabase) SetRebalanceTransferStepStatus(ctx context.Context, arg db.SetRebalanceTransferStepStatusParams) (db.RebalanceTransferStep, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	for _, step := range fdb.steps {
+		if step.ID == arg.ID {
+			step.Status = arg.Status
+			step.StatusMessage = arg.StatusMessage
+			step.UpdatedAt = time.Now()
+			return *step, nil
+		}
+	}
+	return db.RebalanceTransferStep{}, fmt.Errorf("step with id %d not found", arg.ID)
+}
+
+// FailStepInsert makes inserting rebalance transfer steps with stepIndex fail
+// with err
+func (fdb *FakeDatabase) FailStepInsert(stepIndex int64, err error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	fdb.failStepIndex = stepIndex
+	fdb.failStepErr = err
+}
+
+func (fdb *FakeDatabase) GetStepContents() []*db.RebalanceTransferStep {
+	return fdb.steps
+}
+
+func (fdb *FakeDatabase) InsertCCTPTransfer(ctx context.Context, arg db.InsertCCTPTransferParams) (db.CctpTransfer, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	now := time.Now()
+	transfer := &db.CctpTransfer{
+		ID:                  int64(len(fdb.cctp)),
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		RebalanceTransferID: arg.RebalanceTransferID,
+		SourceChainID:       arg.SourceChainID,
+		DestinationChainID:  arg.DestinationChainID,
+		SourceDomain:        arg.SourceDomain,
+		DestinationDomain:   arg.DestinationDomain,
+		BurnTxHash:          arg.BurnTxHash,
+		Status:              "BURNED",
+	}
+	fdb.cctp = append(fdb.cctp, transfer)
+	return *transfer, nil
+}
+
+func (fdb *FakeDatabase) GetPendingCCTPTransfers(ctx context.Context) ([]db.CctpTransfer, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var pendingTransfers []db.CctpTransfer
+	for _, transfer := range fdb.cctp {
+		if transfer.Status != "RECEIVED" && transfer.Status != "FAILED" {
+			pendingTransfers = append(pendingTransfers, *transfer)
+		}
+	}
+	return pendingTransfers, nil
+}
+
+func (fdb *FakeDatabase) updateCCTPTransfer(id int64, update func(transfer *db.CctpTransfer)) (db.CctpTransfer, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	for _, transfer := range fdb.cctp {
+		if transfer.ID == id {
+			update(transfer)
+			transfer.UpdatedAt = time.Now()
+			return *transfer, nil
+		}
+	}
+	return db.CctpTransfer{}, fmt.Errorf("cctp transfer with id %d not found", id)
+}
+
+func (fdb *FakeDatabase) SetCCTPTransferMessage(ctx context.Context, arg db.SetCCTPTransferMessageParams) (db.CctpTransfer, error) {
+	return fdb.updateCCTPTransfer(arg.ID, func(transfer *db.CctpTransfer) {
+		transfer.Message = arg.Message
+		transfer.MessageHash = arg.MessageHash
+	})
+}
+
+func (fdb *FakeDatabase) SetCCTPTransferAttestation(ctx context.Context, arg db.SetCCTPTransferAttestationParams) (db.CctpTransfer, error) {
+	return fdb.updateCCTPTransfer(arg.ID, func(transfer *db.CctpTransfer) {
+		transfer.Attestation = arg.Attestation
+		transfer.Status = "ATTESTED"
+		transfer.StatusMessage = sql.NullString{}
+	})
+}
+
+func (fdb *FakeDatabase) SetCCTPTransferReceiveSubmitted(ctx context.Context, arg db.SetCCTPTransferReceiveSubmittedParams) (db.CctpTransfer, error) {
+	return fdb.updateCCTPTransfer(arg.ID, func(transfer *db.CctpTransfer) {
+		transfer.ReceiveTxHash = arg.ReceiveTxHash
+		transfer.ReceiveAttempts++
+		transfer.Status = "RECEIVE_SUBMITTED"
+	})
+}
+
+func (fdb *FakeDatabase) SetCCTPTransferStatus(ctx context.Context, arg db.SetCCTPTransferStatusParams) (db.CctpTransfer, error) {
+	return fdb.updateCCTPTransfer(arg.ID, func(transfer *db.CctpTransfer) {
+		transfer.Status = arg.Status
+		transfer.StatusMessage = arg.StatusMessage
+	})
+}
+
+func (fdb *FakeDatabase) GetCCTPContents() []*db.CctpTransfer {
+	return fdb.cctp
+}
+
+// SetCCTPTransferTimes sets when a cctp transfer was created and last updated
+func (fdb *FakeDatabase) SetCCTPTransferTimes(id int64, createdAt, updatedAt time.Time) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	for _, transfer := range fdb.cctp {
+		if transfer.ID == id {
+			transfer.CreatedAt = createdAt
+			transfer.UpdatedAt = updatedAt
+		}
+	}
+}
+
+func (fdb *FakeDatabase) InsertIBCTransfer(ctx context.Context, arg db.InsertIBCTransferParams) (db.IbcTransfer, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	now := time.Now()
+	transfer := &db.IbcTransfer{
+		ID:                  int64(len(fdb.ibc)),
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		RebalanceTransferID: arg.RebalanceTransferID,
+		SourceChainID:       arg.SourceChainID,
+		DestinationChainID:  arg.DestinationChainID,
+		SourceChannel:       arg.SourceChannel,
+		TxHash:              arg.TxHash,
+		TimeoutTimestamp:    arg.TimeoutTimestamp,
+		Status:              "SUBMITTED",
+	}
+	fdb.ibc = append(fdb.ibc, transfer)
+	return *transfer, nil
+}
+
+func (fdb *FakeDatabase) GetPendingIBCTransfers(ctx context.Context) ([]db.IbcTransfer, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var pendingTransfers []db.IbcTransfer
+	for _, transfer := range fdb.ibc {
+		if transfer.Status != "ACKNOWLEDGED" && transfer.Status != "REFUNDED" && transfer.Status != "FAILED" {
+			pendingTransfers = append(pendingTransfers, *transfer)
+		}
+	}
+	return pendingTransfers, nil
+}
+
+func (fdb *FakeDatabase) updateIBCTransfer(id int64, update func(transfer *db.IbcTransfer)) (db.IbcTransfer, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	for _, transfer := range fdb.ibc {
+		if transfer.ID == id {
+			update(transfer)
+			transfer.UpdatedAt = time.Now()
+			return *transfer, nil
+		}
+	}
+	return db.IbcTransfer{}, fmt.Errorf("ibc transfer with id %d not found", id)
+}
+
+func (fdb *FakeDatabase) SetIBCTransferPacketSent(ctx context.Context, arg db.SetIBCTransferPacketSentParams) (db.IbcTransfer, error) {
+	return fdb.updateIBCTransfer(arg.ID, func(transfer *db.IbcTransfer) {
+		transfer.PacketSequence = arg.PacketSequence
+		transfer.DestinationChannel = arg.DestinationChannel
+		transfer.Status = "PACKET_SENT"
+	})
+}
+
+func (fdb *FakeDatabase) SetIBCTransferStatus(ctx context.Context, arg db.SetIBCTransferStatusParams) (db.IbcTransfer, error) {
+	return fdb.updateIBCTransfer(arg.ID, func(transfer *db.IbcTransfer) {
+		transfer.Status = arg.Status
+		transfer.StatusMessage = arg.StatusMessage
+	})
+}
+
+func (fdb *FakeDatabase) GetIBCContents() []*db.IbcTransfer {
+	return fdb.ibc
+}
+
+// AddOrder adds an order to the order history used by order flow forecasts
+func (fdb *FakeDatabase) AddOrder(order db.Order) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+	fdb.orders = append(fdb.orders, order)
+}
+
+// AddOrderSettlement adds a settlement to the settlement history used by
+// order flow forecasts
+func (fdb *FakeDatabase) AddOrderSettlement(settlement db.OrderSettlement) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+	fdb.settlements = append(fdb.settlements, settlement)
+}
+
+func (fdb *FakeDatabase) GetOrderOutflowsToChainSince(ctx context.Context, arg db.GetOrderOutflowsToChainSinceParams) ([]db.GetOrderOutflowsToChainSinceRow, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var outflows []db.GetOrderOutflowsToChainSinceRow
+	for _, order := range fdb.orders {
+		if order.DestinationChainID == arg.DestinationChainID && !order.CreatedAt.Before(arg.CreatedAt) {
+			outflows = append(outflows, db.GetOrderOutflowsToChainSinceRow{
+				CreatedAt: order.CreatedAt,
+				AmountOut: order.AmountOut,
+			})
+		}
+	}
+	return outflows, nil
+}
+
+func (fdb *FakeDatabase) GetSettlementInflowsToChainSince(ctx context.Context, arg db.GetSettlementInflowsToChainSinceParams) ([]db.GetSettlementInflowsToChainSinceRow, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var inflows []db.GetSettlementInflowsToChainSinceRow
+	for _, settlement := range fdb.settlements {
+		if settlement.SourceChainID == arg.SourceChainID &&
+			settlement.SettlementStatus == arg.SettlementStatus &&
+			!settlement.UpdatedAt.Before(arg.UpdatedAt) {
+			inflows = append(inflows, db.GetSettlementInflowsToChainSinceRow{
+				UpdatedAt: settlement.UpdatedAt,
+				Amount:    settlement.Amount,
+			})
+		}
+	}
+	return inflows, nil
+}
+
+func (fdb *FakeDatabase) findAllowance(chainID, tokenContract, spender string) *db.Erc20Allowance {
+	for _, allowance := range fdb.allowances {
+		if allowance.ChainID == chainID && allowance.TokenContract == tokenContract && allowance.Spender == spender {
+			return allowance
+		}
+	}
+	return nil
+}
+
+func (fdb *FakeDatabase) upsertAllowance(chainID, tokenContract, spender string) *db.Erc20Allowance {
+	allowance := fdb.findAllowance(chainID, tokenContract, spender)
+	if allowance == nil {
+		allowance = &db.Erc20Allowance{
+			ID:            int64(len(fdb.allowances) + 1),
+			CreatedAt:     time.Now(),
+			ChainID:       chainID,
+			TokenContract: tokenContract,
+			Spender:       spender,
+		}
+		fdb.allowances = append(fdb.allowances, allowance)
+	}
+	allowance.UpdatedAt = time.Now()
+	allowance.LastUsedAt = time.Now()
+	return allowance
+}
+
+func (fdb *FakeDatabase) SetERC20AllowanceApproved(ctx context.Context, arg db.SetERC20AllowanceApprovedParams) (db.Erc20Allowance, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	allowance := fdb.upsertAllowance(arg.ChainID, arg.TokenContract, arg.Spender)
+	allowance.ApprovedAmount = arg.ApprovedAmount
+	allowance.ApproveTxHash = arg.ApproveTxHash
+	allowance.RevokeTxHash = sql.NullString{}
+	allowance.RevokedAt = sql.NullTime{}
+	return *allowance, nil
+}
+
+func (fdb *FakeDatabase) SetERC20AllowanceUsed(ctx context.Context, arg db.SetERC20AllowanceUsedParams) error {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	fdb.upsertAllowance(arg.ChainID, arg.TokenContract, arg.Spender)
+	return nil
+}
+
+func (fdb *FakeDatabase) GetERC20Allowances(ctx context.Context) ([]db.Erc20Allowance, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var allowances []db.Erc20Allowance
+	for _, allowance := range fdb.allowances {
+		allowances = append(allowances, *allowance)
+	}
+	return allowances, nil
+}
+
+func (fdb *FakeDatabase) GetIdleERC20Allowances(ctx context.Context, lastUsedAt time.Time) ([]db.Erc20Allowance, error) {
+	fdb.dbLock.RLock()
+	defer fdb.dbLock.RUnlock()
+
+	var allowances []db.Erc20Allowance
+	for _, allowance := range fdb.allowances {
+		if !allowance.RevokedAt.Valid && allowance.LastUsedAt.Before(lastUsedAt) {
+			allowances = append(allowances, *allowance)
+		}
+	}
+	return allowances, nil
+}
+
+func (fdb *FakeDatabase) SetERC20AllowanceRevoked(ctx context.Context, arg db.SetERC20AllowanceRevokedParams) (db.Erc20Allowance, error) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	allowance := fdb.findAllowance(arg.ChainID, arg.TokenContract, arg.Spender)
+	if allowance == nil {
+		return db.Erc20Allowance{}, sql.ErrNoRows
+	}
+	allowance.UpdatedAt = time.Now()
+	allowance.ApprovedAmount = sql.NullString{String: "0", Valid: true}
+	allowance.RevokeTxHash = arg.RevokeTxHash
+	allowance.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return *allowance, nil
+}
+
+// SetAllowanceLastUsedAt sets when the spender of an allowance was last used
+func (fdb *FakeDatabase) SetAllowanceLastUsedAt(chainID, tokenContract, spender string, lastUsedAt time.Time) {
+	fdb.dbLock.Lock()
+	defer fdb.dbLock.Unlock()
+
+	if allowance := fdb.findAllowance(chainID, tokenContract, spender); allowance != nil {
+		allowance.LastUsedAt = lastUsedAt
+	}
+}
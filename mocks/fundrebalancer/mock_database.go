@@ -3,10 +3,15 @@
 package fundrebalancer
 
 import (
+	context "context"
+
 	db "github.com/skip-mev/go-fast-solver/db/gen/db"
-	context "golang.org/x/net/context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	time "time"
 )
 
 // MockDatabase is an autogenerated mock type for the Database type
@@ -80,6 +85,298 @@ func (_c *MockDatabase_GetAllPendingRebalanceTransfers_Call) RunAndReturn(run fu
 	return _c
 }
 
+// GetERC20Allowances provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetERC20Allowances(ctx context.Context) ([]db.Erc20Allowance, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetERC20Allowances")
+	}
+
+	var r0 []db.Erc20Allowance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.Erc20Allowance, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.Erc20Allowance); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Erc20Allowance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetERC20Allowances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetERC20Allowances'
+type MockDatabase_GetERC20Allowances_Call struct {
+	*mock.Call
+}
+
+// GetERC20Allowances is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetERC20Allowances(ctx interface{}) *MockDatabase_GetERC20Allowances_Call {
+	return &MockDatabase_GetERC20Allowances_Call{Call: _e.mock.On("GetERC20Allowances", ctx)}
+}
+
+func (_c *MockDatabase_GetERC20Allowances_Call) Run(run func(ctx context.Context)) *MockDatabase_GetERC20Allowances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetERC20Allowances_Call) Return(_a0 []db.Erc20Allowance, _a1 error) *MockDatabase_GetERC20Allowances_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetERC20Allowances_Call) RunAndReturn(run func(context.Context) ([]db.Erc20Allowance, error)) *MockDatabase_GetERC20Allowances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdleERC20Allowances provides a mock function with given fields: ctx, lastUsedAt
+func (_m *MockDatabase) GetIdleERC20Allowances(ctx context.Context, lastUsedAt time.Time) ([]db.Erc20Allowance, error) {
+	ret := _m.Called(ctx, lastUsedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdleERC20Allowances")
+	}
+
+	var r0 []db.Erc20Allowance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.Erc20Allowance, error)); ok {
+		return rf(ctx, lastUsedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.Erc20Allowance); ok {
+		r0 = rf(ctx, lastUsedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Erc20Allowance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, lastUsedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetIdleERC20Allowances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdleERC20Allowances'
+type MockDatabase_GetIdleERC20Allowances_Call struct {
+	*mock.Call
+}
+
+// GetIdleERC20Allowances is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lastUsedAt time.Time
+func (_e *MockDatabase_Expecter) GetIdleERC20Allowances(ctx interface{}, lastUsedAt interface{}) *MockDatabase_GetIdleERC20Allowances_Call {
+	return &MockDatabase_GetIdleERC20Allowances_Call{Call: _e.mock.On("GetIdleERC20Allowances", ctx, lastUsedAt)}
+}
+
+func (_c *MockDatabase_GetIdleERC20Allowances_Call) Run(run func(ctx context.Context, lastUsedAt time.Time)) *MockDatabase_GetIdleERC20Allowances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetIdleERC20Allowances_Call) Return(_a0 []db.Erc20Allowance, _a1 error) *MockDatabase_GetIdleERC20Allowances_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetIdleERC20Allowances_Call) RunAndReturn(run func(context.Context, time.Time) ([]db.Erc20Allowance, error)) *MockDatabase_GetIdleERC20Allowances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrderOutflowsToChainSince provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) GetOrderOutflowsToChainSince(ctx context.Context, arg db.GetOrderOutflowsToChainSinceParams) ([]db.GetOrderOutflowsToChainSinceRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderOutflowsToChainSince")
+	}
+
+	var r0 []db.GetOrderOutflowsToChainSinceRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetOrderOutflowsToChainSinceParams) ([]db.GetOrderOutflowsToChainSinceRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetOrderOutflowsToChainSinceParams) []db.GetOrderOutflowsToChainSinceRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetOrderOutflowsToChainSinceRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetOrderOutflowsToChainSinceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetOrderOutflowsToChainSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrderOutflowsToChainSince'
+type MockDatabase_GetOrderOutflowsToChainSince_Call struct {
+	*mock.Call
+}
+
+// GetOrderOutflowsToChainSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetOrderOutflowsToChainSinceParams
+func (_e *MockDatabase_Expecter) GetOrderOutflowsToChainSince(ctx interface{}, arg interface{}) *MockDatabase_GetOrderOutflowsToChainSince_Call {
+	return &MockDatabase_GetOrderOutflowsToChainSince_Call{Call: _e.mock.On("GetOrderOutflowsToChainSince", ctx, arg)}
+}
+
+func (_c *MockDatabase_GetOrderOutflowsToChainSince_Call) Run(run func(ctx context.Context, arg db.GetOrderOutflowsToChainSinceParams)) *MockDatabase_GetOrderOutflowsToChainSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetOrderOutflowsToChainSinceParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetOrderOutflowsToChainSince_Call) Return(_a0 []db.GetOrderOutflowsToChainSinceRow, _a1 error) *MockDatabase_GetOrderOutflowsToChainSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetOrderOutflowsToChainSince_Call) RunAndReturn(run func(context.Context, db.GetOrderOutflowsToChainSinceParams) ([]db.GetOrderOutflowsToChainSinceRow, error)) *MockDatabase_GetOrderOutflowsToChainSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingCCTPTransfers provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetPendingCCTPTransfers(ctx context.Context) ([]db.CctpTransfer, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingCCTPTransfers")
+	}
+
+	var r0 []db.CctpTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.CctpTransfer, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.CctpTransfer); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CctpTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetPendingCCTPTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingCCTPTransfers'
+type MockDatabase_GetPendingCCTPTransfers_Call struct {
+	*mock.Call
+}
+
+// GetPendingCCTPTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetPendingCCTPTransfers(ctx interface{}) *MockDatabase_GetPendingCCTPTransfers_Call {
+	return &MockDatabase_GetPendingCCTPTransfers_Call{Call: _e.mock.On("GetPendingCCTPTransfers", ctx)}
+}
+
+func (_c *MockDatabase_GetPendingCCTPTransfers_Call) Run(run func(ctx context.Context)) *MockDatabase_GetPendingCCTPTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetPendingCCTPTransfers_Call) Return(_a0 []db.CctpTransfer, _a1 error) *MockDatabase_GetPendingCCTPTransfers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetPendingCCTPTransfers_Call) RunAndReturn(run func(context.Context) ([]db.CctpTransfer, error)) *MockDatabase_GetPendingCCTPTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingIBCTransfers provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetPendingIBCTransfers(ctx context.Context) ([]db.IbcTransfer, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingIBCTransfers")
+	}
+
+	var r0 []db.IbcTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.IbcTransfer, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []db.IbcTransfer); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.IbcTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetPendingIBCTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingIBCTransfers'
+type MockDatabase_GetPendingIBCTransfers_Call struct {
+	*mock.Call
+}
+
+// GetPendingIBCTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatabase_Expecter) GetPendingIBCTransfers(ctx interface{}) *MockDatabase_GetPendingIBCTransfers_Call {
+	return &MockDatabase_GetPendingIBCTransfers_Call{Call: _e.mock.On("GetPendingIBCTransfers", ctx)}
+}
+
+func (_c *MockDatabase_GetPendingIBCTransfers_Call) Run(run func(ctx context.Context)) *MockDatabase_GetPendingIBCTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetPendingIBCTransfers_Call) Return(_a0 []db.IbcTransfer, _a1 error) *MockDatabase_GetPendingIBCTransfers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetPendingIBCTransfers_Call) RunAndReturn(run func(context.Context) ([]db.IbcTransfer, error)) *MockDatabase_GetPendingIBCTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPendingRebalanceTransfersToChain provides a mock function with given fields: ctx, destinationChainID
 func (_m *MockDatabase) GetPendingRebalanceTransfersToChain(ctx context.Context, destinationChainID string) ([]db.GetPendingRebalanceTransfersToChainRow, error) {
 	ret := _m.Called(ctx, destinationChainID)
@@ -139,27 +436,29 @@ func (_c *MockDatabase_GetPendingRebalanceTransfersToChain_Call) RunAndReturn(ru
 	return _c
 }
 
-// InsertRebalanceTransfer provides a mock function with given fields: ctx, arg
-func (_m *MockDatabase) InsertRebalanceTransfer(ctx context.Context, arg db.InsertRebalanceTransferParams) (int64, error) {
-	ret := _m.Called(ctx, arg)
+// GetPendingRebalanceTransfersWithSteps provides a mock function with given fields: ctx
+func (_m *MockDatabase) GetPendingRebalanceTransfersWithSteps(ctx context.Context) ([]db.RebalanceTransfer, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertRebalanceTransfer")
+		panic("no return value specified for GetPendingRebalanceTransfersWithSteps")
 	}
 
-	var r0 int64
+	var r0 []db.RebalanceTransfer
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.InsertRebalanceTransferParams) (int64, error)); ok {
-		return rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]db.RebalanceTransfer, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.InsertRebalanceTransferParams) int64); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context) []db.RebalanceTransfer); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RebalanceTransfer)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.InsertRebalanceTransferParams) error); ok {
-		r1 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -167,56 +466,57 @@ func (_m *MockDatabase) InsertRebalanceTransfer(ctx context.Context, arg db.Inse
 	return r0, r1
 }
 
-// MockDatabase_InsertRebalanceTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertRebalanceTransfer'
-type MockDatabase_InsertRebalanceTransfer_Call struct {
+// MockDatabase_GetPendingRebalanceTransfersWithSteps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingRebalanceTransfersWithSteps'
+type MockDatabase_GetPendingRebalanceTransfersWithSteps_Call struct {
 	*mock.Call
 }
 
-// InsertRebalanceTransfer is a helper method to define mock.On call
+// GetPendingRebalanceTransfersWithSteps is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.InsertRebalanceTransferParams
-func (_e *MockDatabase_Expecter) InsertRebalanceTransfer(ctx interface{}, arg interface{}) *MockDatabase_InsertRebalanceTransfer_Call {
-	return &MockDatabase_InsertRebalanceTransfer_Call{Call: _e.mock.On("InsertRebalanceTransfer", ctx, arg)}
+func (_e *MockDatabase_Expecter) GetPendingRebalanceTransfersWithSteps(ctx interface{}) *MockDatabase_GetPendingRebalanceTransfersWithSteps_Call {
+	return &MockDatabase_GetPendingRebalanceTransfersWithSteps_Call{Call: _e.mock.On("GetPendingRebalanceTransfersWithSteps", ctx)}
 }
 
-func (_c *MockDatabase_InsertRebalanceTransfer_Call) Run(run func(ctx context.Context, arg db.InsertRebalanceTransferParams)) *MockDatabase_InsertRebalanceTransfer_Call {
+func (_c *MockDatabase_GetPendingRebalanceTransfersWithSteps_Call) Run(run func(ctx context.Context)) *MockDatabase_GetPendingRebalanceTransfersWithSteps_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.InsertRebalanceTransferParams))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_InsertRebalanceTransfer_Call) Return(_a0 int64, _a1 error) *MockDatabase_InsertRebalanceTransfer_Call {
+func (_c *MockDatabase_GetPendingRebalanceTransfersWithSteps_Call) Return(_a0 []db.RebalanceTransfer, _a1 error) *MockDatabase_GetPendingRebalanceTransfersWithSteps_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_InsertRebalanceTransfer_Call) RunAndReturn(run func(context.Context, db.InsertRebalanceTransferParams) (int64, error)) *MockDatabase_InsertRebalanceTransfer_Call {
+func (_c *MockDatabase_GetPendingRebalanceTransfersWithSteps_Call) RunAndReturn(run func(context.Context) ([]db.RebalanceTransfer, error)) *MockDatabase_GetPendingRebalanceTransfersWithSteps_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InsertSubmittedTx provides a mock function with given fields: ctx, arg
-func (_m *MockDatabase) InsertSubmittedTx(ctx context.Context, arg db.InsertSubmittedTxParams) (db.SubmittedTx, error) {
-	ret := _m.Called(ctx, arg)
+// GetRebalanceTransferSteps provides a mock function with given fields: ctx, rebalanceTransferID
+func (_m *MockDatabase) GetRebalanceTransferSteps(ctx context.Context, rebalanceTransferID int64) ([]db.RebalanceTransferStep, error) {
+	ret := _m.Called(ctx, rebalanceTransferID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertSubmittedTx")
+		panic("no return value specified for GetRebalanceTransferSteps")
 	}
 
-	var r0 db.SubmittedTx
+	var r0 []db.RebalanceTransferStep
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, db.InsertSubmittedTxParams) (db.SubmittedTx, error)); ok {
-		return rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]db.RebalanceTransferStep, error)); ok {
+		return rf(ctx, rebalanceTransferID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, db.InsertSubmittedTxParams) db.SubmittedTx); ok {
-		r0 = rf(ctx, arg)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []db.RebalanceTransferStep); ok {
+		r0 = rf(ctx, rebalanceTransferID)
 	} else {
-		r0 = ret.Get(0).(db.SubmittedTx)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RebalanceTransferStep)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, db.InsertSubmittedTxParams) error); ok {
-		r1 = rf(ctx, arg)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, rebalanceTransferID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -224,31 +524,1270 @@ func (_m *MockDatabase) InsertSubmittedTx(ctx context.Context, arg db.InsertSubm
 	return r0, r1
 }
 
-// MockDatabase_InsertSubmittedTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertSubmittedTx'
-type MockDatabase_InsertSubmittedTx_Call struct {
+// MockDatabase_GetRebalanceTransferSteps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRebalanceTransferSteps'
+type MockDatabase_GetRebalanceTransferSteps_Call struct {
+	*mock.Call
+}
+
+// GetRebalanceTransferSteps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rebalanceTransferID int64
+func (_e *MockDatabase_Expecter) GetRebalanceTransferSteps(ctx interface{}, rebalanceTransferID interface{}) *MockDatabase_GetRebalanceTransferSteps_Call {
+	return &MockDatabase_GetRebalanceTransferSteps_Call{Call: _e.mock.On("GetRebalanceTransferSteps", ctx, rebalanceTransferID)}
+}
+
+func (_c *MockDatabase_GetRebalanceTransferSteps_Call) Run(run func(ctx context.Context, rebalanceTransferID int64)) *MockDatabase_GetRebalanceTransferSteps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetRebalanceTransferSteps_Call) Return(_a0 []db.RebalanceTransferStep, _a1 error) *MockDatabase_GetRebalanceTransferSteps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetRebalanceTransferSteps_Call) RunAndReturn(run func(context.Context, int64) ([]db.RebalanceTransferStep, error)) *MockDatabase_GetRebalanceTransferSteps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRebalanceTransfersSince provides a mock function with given fields: ctx, createdAt
+func (_m *MockDatabase) GetRebalanceTransfersSince(ctx context.Context, createdAt time.Time) ([]db.RebalanceTransfer, error) {
+	ret := _m.Called(ctx, createdAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRebalanceTransfersSince")
+	}
+
+	var r0 []db.RebalanceTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]db.RebalanceTransfer, error)); ok {
+		return rf(ctx, createdAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []db.RebalanceTransfer); ok {
+		r0 = rf(ctx, createdAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.RebalanceTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, createdAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetRebalanceTransfersSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRebalanceTransfersSince'
+type MockDatabase_GetRebalanceTransfersSince_Call struct {
+	*mock.Call
+}
+
+// GetRebalanceTransfersSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - createdAt time.Time
+func (_e *MockDatabase_Expecter) GetRebalanceTransfersSince(ctx interface{}, createdAt interface{}) *MockDatabase_GetRebalanceTransfersSince_Call {
+	return &MockDatabase_GetRebalanceTransfersSince_Call{Call: _e.mock.On("GetRebalanceTransfersSince", ctx, createdAt)}
+}
+
+func (_c *MockDatabase_GetRebalanceTransfersSince_Call) Run(run func(ctx context.Context, createdAt time.Time)) *MockDatabase_GetRebalanceTransfersSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetRebalanceTransfersSince_Call) Return(_a0 []db.RebalanceTransfer, _a1 error) *MockDatabase_GetRebalanceTransfersSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetRebalanceTransfersSince_Call) RunAndReturn(run func(context.Context, time.Time) ([]db.RebalanceTransfer, error)) *MockDatabase_GetRebalanceTransfersSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSettlementInflowsToChainSince provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) GetSettlementInflowsToChainSince(ctx context.Context, arg db.GetSettlementInflowsToChainSinceParams) ([]db.GetSettlementInflowsToChainSinceRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettlementInflowsToChainSince")
+	}
+
+	var r0 []db.GetSettlementInflowsToChainSinceRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetSettlementInflowsToChainSinceParams) ([]db.GetSettlementInflowsToChainSinceRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.GetSettlementInflowsToChainSinceParams) []db.GetSettlementInflowsToChainSinceRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.GetSettlementInflowsToChainSinceRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.GetSettlementInflowsToChainSinceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_GetSettlementInflowsToChainSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettlementInflowsToChainSince'
+type MockDatabase_GetSettlementInflowsToChainSince_Call struct {
+	*mock.Call
+}
+
+// GetSettlementInflowsToChainSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetSettlementInflowsToChainSinceParams
+func (_e *MockDatabase_Expecter) GetSettlementInflowsToChainSince(ctx interface{}, arg interface{}) *MockDatabase_GetSettlementInflowsToChainSince_Call {
+	return &MockDatabase_GetSettlementInflowsToChainSince_Call{Call: _e.mock.On("GetSettlementInflowsToChainSince", ctx, arg)}
+}
+
+func (_c *MockDatabase_GetSettlementInflowsToChainSince_Call) Run(run func(ctx context.Context, arg db.GetSettlementInflowsToChainSinceParams)) *MockDatabase_GetSettlementInflowsToChainSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.GetSettlementInflowsToChainSinceParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_GetSettlementInflowsToChainSince_Call) Return(_a0 []db.GetSettlementInflowsToChainSinceRow, _a1 error) *MockDatabase_GetSettlementInflowsToChainSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_GetSettlementInflowsToChainSince_Call) RunAndReturn(run func(context.Context, db.GetSettlementInflowsToChainSinceParams) ([]db.GetSettlementInflowsToChainSinceRow, error)) *MockDatabase_GetSettlementInflowsToChainSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InTx provides a mock function with given fields: ctx, fn, opts
+func (_m *MockDatabase) InTx(ctx context.Context, fn func(context.Context, db.Querier) error, opts *sql.TxOptions) error {
+	ret := _m.Called(ctx, fn, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context, db.Querier) error, *sql.TxOptions) error); ok {
+		r0 = rf(ctx, fn, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_InTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTx'
+type MockDatabase_InTx_Call struct {
+	*mock.Call
+}
+
+// InTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(context.Context , db.Querier) error
+//   - opts *sql.TxOptions
+func (_e *MockDatabase_Expecter) InTx(ctx interface{}, fn interface{}, opts interface{}) *MockDatabase_InTx_Call {
+	return &MockDatabase_InTx_Call{Call: _e.mock.On("InTx", ctx, fn, opts)}
+}
+
+func (_c *MockDatabase_InTx_Call) Run(run func(ctx context.Context, fn func(context.Context, db.Querier) error, opts *sql.TxOptions)) *MockDatabase_InTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context, db.Querier) error), args[2].(*sql.TxOptions))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_InTx_Call) Return(_a0 error) *MockDatabase_InTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_InTx_Call) RunAndReturn(run func(context.Context, func(context.Context, db.Querier) error, *sql.TxOptions) error) *MockDatabase_InTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertCCTPTransfer provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) InsertCCTPTransfer(ctx context.Context, arg db.InsertCCTPTransferParams) (db.CctpTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertCCTPTransfer")
+	}
+
+	var r0 db.CctpTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertCCTPTransferParams) (db.CctpTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertCCTPTransferParams) db.CctpTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CctpTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertCCTPTransferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_InsertCCTPTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertCCTPTransfer'
+type MockDatabase_InsertCCTPTransfer_Call struct {
+	*mock.Call
+}
+
+// InsertCCTPTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertCCTPTransferParams
+func (_e *MockDatabase_Expecter) InsertCCTPTransfer(ctx interface{}, arg interface{}) *MockDatabase_InsertCCTPTransfer_Call {
+	return &MockDatabase_InsertCCTPTransfer_Call{Call: _e.mock.On("InsertCCTPTransfer", ctx, arg)}
+}
+
+func (_c *MockDatabase_InsertCCTPTransfer_Call) Run(run func(ctx context.Context, arg db.InsertCCTPTransferParams)) *MockDatabase_InsertCCTPTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertCCTPTransferParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_InsertCCTPTransfer_Call) Return(_a0 db.CctpTransfer, _a1 error) *MockDatabase_InsertCCTPTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_InsertCCTPTransfer_Call) RunAndReturn(run func(context.Context, db.InsertCCTPTransferParams) (db.CctpTransfer, error)) *MockDatabase_InsertCCTPTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertGasTopUpTransfer provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) InsertGasTopUpTransfer(ctx context.Context, arg db.InsertGasTopUpTransferParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertGasTopUpTransfer")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertGasTopUpTransferParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertGasTopUpTransferParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertGasTopUpTransferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_InsertGasTopUpTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertGasTopUpTransfer'
+type MockDatabase_InsertGasTopUpTransfer_Call struct {
+	*mock.Call
+}
+
+// InsertGasTopUpTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertGasTopUpTransferParams
+func (_e *MockDatabase_Expecter) InsertGasTopUpTransfer(ctx interface{}, arg interface{}) *MockDatabase_InsertGasTopUpTransfer_Call {
+	return &MockDatabase_InsertGasTopUpTransfer_Call{Call: _e.mock.On("InsertGasTopUpTransfer", ctx, arg)}
+}
+
+func (_c *MockDatabase_InsertGasTopUpTransfer_Call) Run(run func(ctx context.Context, arg db.InsertGasTopUpTransferParams)) *MockDatabase_InsertGasTopUpTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertGasTopUpTransferParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_InsertGasTopUpTransfer_Call) Return(_a0 int64, _a1 error) *MockDatabase_InsertGasTopUpTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_InsertGasTopUpTransfer_Call) RunAndReturn(run func(context.Context, db.InsertGasTopUpTransferParams) (int64, error)) *MockDatabase_InsertGasTopUpTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertIBCTransfer provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) InsertIBCTransfer(ctx context.Context, arg db.InsertIBCTransferParams) (db.IbcTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertIBCTransfer")
+	}
+
+	var r0 db.IbcTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertIBCTransferParams) (db.IbcTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertIBCTransferParams) db.IbcTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IbcTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertIBCTransferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_InsertIBCTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertIBCTransfer'
+type MockDatabase_InsertIBCTransfer_Call struct {
+	*mock.Call
+}
+
+// InsertIBCTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertIBCTransferParams
+func (_e *MockDatabase_Expecter) InsertIBCTransfer(ctx interface{}, arg interface{}) *MockDatabase_InsertIBCTransfer_Call {
+	return &MockDatabase_InsertIBCTransfer_Call{Call: _e.mock.On("InsertIBCTransfer", ctx, arg)}
+}
+
+func (_c *MockDatabase_InsertIBCTransfer_Call) Run(run func(ctx context.Context, arg db.InsertIBCTransferParams)) *MockDatabase_InsertIBCTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertIBCTransferParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_InsertIBCTransfer_Call) Return(_a0 db.IbcTransfer, _a1 error) *MockDatabase_InsertIBCTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_InsertIBCTransfer_Call) RunAndReturn(run func(context.Context, db.InsertIBCTransferParams) (db.IbcTransfer, error)) *MockDatabase_InsertIBCTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertRebalanceTransfer provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) InsertRebalanceTransfer(ctx context.Context, arg db.InsertRebalanceTransferParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertRebalanceTransfer")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertRebalanceTransferParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertRebalanceTransferParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertRebalanceTransferParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_InsertRebalanceTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertRebalanceTransfer'
+type MockDatabase_InsertRebalanceTransfer_Call struct {
+	*mock.Call
+}
+
+// InsertRebalanceTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertRebalanceTransferParams
+func (_e *MockDatabase_Expecter) InsertRebalanceTransfer(ctx interface{}, arg interface{}) *MockDatabase_InsertRebalanceTransfer_Call {
+	return &MockDatabase_InsertRebalanceTransfer_Call{Call: _e.mock.On("InsertRebalanceTransfer", ctx, arg)}
+}
+
+func (_c *MockDatabase_InsertRebalanceTransfer_Call) Run(run func(ctx context.Context, arg db.InsertRebalanceTransferParams)) *MockDatabase_InsertRebalanceTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertRebalanceTransferParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_InsertRebalanceTransfer_Call) Return(_a0 int64, _a1 error) *MockDatabase_InsertRebalanceTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_InsertRebalanceTransfer_Call) RunAndReturn(run func(context.Context, db.InsertRebalanceTransferParams) (int64, error)) *MockDatabase_InsertRebalanceTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertRebalanceTransferStep provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) InsertRebalanceTransferStep(ctx context.Context, arg db.InsertRebalanceTransferStepParams) (db.RebalanceTransferStep, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertRebalanceTransferStep")
+	}
+
+	var r0 db.RebalanceTransferStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertRebalanceTransferStepParams) (db.RebalanceTransferStep, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertRebalanceTransferStepParams) db.RebalanceTransferStep); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RebalanceTransferStep)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertRebalanceTransferStepParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_InsertRebalanceTransferStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertRebalanceTransferStep'
+type MockDatabase_InsertRebalanceTransferStep_Call struct {
+	*mock.Call
+}
+
+// InsertRebalanceTransferStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.InsertRebalanceTransferStepParams
+func (_e *MockDatabase_Expecter) InsertRebalanceTransferStep(ctx interface{}, arg interface{}) *MockDatabase_InsertRebalanceTransferStep_Call {
+	return &MockDatabase_InsertRebalanceTransferStep_Call{Call: _e.mock.On("InsertRebalanceTransferStep", ctx, arg)}
+}
+
+func (_c *MockDatabase_InsertRebalanceTransferStep_Call) Run(run func(ctx context.Context, arg db.InsertRebalanceTransferStepParams)) *MockDatabase_InsertRebalanceTransferStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertRebalanceTransferStepParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_InsertRebalanceTransferStep_Call) Return(_a0 db.RebalanceTransferStep, _a1 error) *MockDatabase_InsertRebalanceTransferStep_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_InsertRebalanceTransferStep_Call) RunAndReturn(run func(context.Context, db.InsertRebalanceTransferStepParams) (db.RebalanceTransferStep, error)) *MockDatabase_InsertRebalanceTransferStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertSubmittedTx provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) InsertSubmittedTx(ctx context.Context, arg db.InsertSubmittedTxParams) (db.SubmittedTx, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSubmittedTx")
+	}
+
+	var r0 db.SubmittedTx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertSubmittedTxParams) (db.SubmittedTx, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.InsertSubmittedTxParams) db.SubmittedTx); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.SubmittedTx)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.InsertSubmittedTxParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_InsertSubmittedTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertSubmittedTx'
+type MockDatabase_InsertSubmittedTx_Call struct {
 	*mock.Call
 }
 
 // InsertSubmittedTx is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.InsertSubmittedTxParams
-func (_e *MockDatabase_Expecter) InsertSubmittedTx(ctx interface{}, arg interface{}) *MockDatabase_InsertSubmittedTx_Call {
-	return &MockDatabase_InsertSubmittedTx_Call{Call: _e.mock.On("InsertSubmittedTx", ctx, arg)}
+//   - arg db.InsertSubmittedTxParams
+func (_e *MockDatabase_Expecter) InsertSubmittedTx(ctx interface{}, arg interface{}) *MockDatabase_InsertSubmittedTx_Call {
+	return &MockDatabase_InsertSubmittedTx_Call{Call: _e.mock.On("InsertSubmittedTx", ctx, arg)}
+}
+
+func (_c *MockDatabase_InsertSubmittedTx_Call) Run(run func(ctx context.Context, arg db.InsertSubmittedTxParams)) *MockDatabase_InsertSubmittedTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.InsertSubmittedTxParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_InsertSubmittedTx_Call) Return(_a0 db.SubmittedTx, _a1 error) *MockDatabase_InsertSubmittedTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_InsertSubmittedTx_Call) RunAndReturn(run func(context.Context, db.InsertSubmittedTxParams) (db.SubmittedTx, error)) *MockDatabase_InsertSubmittedTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetRebalanceTransferStepSubmitting provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) ResetRebalanceTransferStepSubmitting(ctx context.Context, arg db.ResetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetRebalanceTransferStepSubmitting")
+	}
+
+	var r0 db.RebalanceTransferStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.ResetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.ResetRebalanceTransferStepSubmittingParams) db.RebalanceTransferStep); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RebalanceTransferStep)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.ResetRebalanceTransferStepSubmittingParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_ResetRebalanceTransferStepSubmitting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetRebalanceTransferStepSubmitting'
+type MockDatabase_ResetRebalanceTransferStepSubmitting_Call struct {
+	*mock.Call
+}
+
+// ResetRebalanceTransferStepSubmitting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ResetRebalanceTransferStepSubmittingParams
+func (_e *MockDatabase_Expecter) ResetRebalanceTransferStepSubmitting(ctx interface{}, arg interface{}) *MockDatabase_ResetRebalanceTransferStepSubmitting_Call {
+	return &MockDatabase_ResetRebalanceTransferStepSubmitting_Call{Call: _e.mock.On("ResetRebalanceTransferStepSubmitting", ctx, arg)}
+}
+
+func (_c *MockDatabase_ResetRebalanceTransferStepSubmitting_Call) Run(run func(ctx context.Context, arg db.ResetRebalanceTransferStepSubmittingParams)) *MockDatabase_ResetRebalanceTransferStepSubmitting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.ResetRebalanceTransferStepSubmittingParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_ResetRebalanceTransferStepSubmitting_Call) Return(_a0 db.RebalanceTransferStep, _a1 error) *MockDatabase_ResetRebalanceTransferStepSubmitting_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_ResetRebalanceTransferStepSubmitting_Call) RunAndReturn(run func(context.Context, db.ResetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error)) *MockDatabase_ResetRebalanceTransferStepSubmitting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCCTPTransferAttestation provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetCCTPTransferAttestation(ctx context.Context, arg db.SetCCTPTransferAttestationParams) (db.CctpTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCCTPTransferAttestation")
+	}
+
+	var r0 db.CctpTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCCTPTransferAttestationParams) (db.CctpTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCCTPTransferAttestationParams) db.CctpTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CctpTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetCCTPTransferAttestationParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetCCTPTransferAttestation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCCTPTransferAttestation'
+type MockDatabase_SetCCTPTransferAttestation_Call struct {
+	*mock.Call
+}
+
+// SetCCTPTransferAttestation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetCCTPTransferAttestationParams
+func (_e *MockDatabase_Expecter) SetCCTPTransferAttestation(ctx interface{}, arg interface{}) *MockDatabase_SetCCTPTransferAttestation_Call {
+	return &MockDatabase_SetCCTPTransferAttestation_Call{Call: _e.mock.On("SetCCTPTransferAttestation", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetCCTPTransferAttestation_Call) Run(run func(ctx context.Context, arg db.SetCCTPTransferAttestationParams)) *MockDatabase_SetCCTPTransferAttestation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetCCTPTransferAttestationParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetCCTPTransferAttestation_Call) Return(_a0 db.CctpTransfer, _a1 error) *MockDatabase_SetCCTPTransferAttestation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetCCTPTransferAttestation_Call) RunAndReturn(run func(context.Context, db.SetCCTPTransferAttestationParams) (db.CctpTransfer, error)) *MockDatabase_SetCCTPTransferAttestation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCCTPTransferMessage provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetCCTPTransferMessage(ctx context.Context, arg db.SetCCTPTransferMessageParams) (db.CctpTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCCTPTransferMessage")
+	}
+
+	var r0 db.CctpTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCCTPTransferMessageParams) (db.CctpTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCCTPTransferMessageParams) db.CctpTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CctpTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetCCTPTransferMessageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetCCTPTransferMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCCTPTransferMessage'
+type MockDatabase_SetCCTPTransferMessage_Call struct {
+	*mock.Call
+}
+
+// SetCCTPTransferMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetCCTPTransferMessageParams
+func (_e *MockDatabase_Expecter) SetCCTPTransferMessage(ctx interface{}, arg interface{}) *MockDatabase_SetCCTPTransferMessage_Call {
+	return &MockDatabase_SetCCTPTransferMessage_Call{Call: _e.mock.On("SetCCTPTransferMessage", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetCCTPTransferMessage_Call) Run(run func(ctx context.Context, arg db.SetCCTPTransferMessageParams)) *MockDatabase_SetCCTPTransferMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetCCTPTransferMessageParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetCCTPTransferMessage_Call) Return(_a0 db.CctpTransfer, _a1 error) *MockDatabase_SetCCTPTransferMessage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetCCTPTransferMessage_Call) RunAndReturn(run func(context.Context, db.SetCCTPTransferMessageParams) (db.CctpTransfer, error)) *MockDatabase_SetCCTPTransferMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCCTPTransferReceiveSubmitted provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetCCTPTransferReceiveSubmitted(ctx context.Context, arg db.SetCCTPTransferReceiveSubmittedParams) (db.CctpTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCCTPTransferReceiveSubmitted")
+	}
+
+	var r0 db.CctpTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCCTPTransferReceiveSubmittedParams) (db.CctpTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCCTPTransferReceiveSubmittedParams) db.CctpTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CctpTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetCCTPTransferReceiveSubmittedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetCCTPTransferReceiveSubmitted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCCTPTransferReceiveSubmitted'
+type MockDatabase_SetCCTPTransferReceiveSubmitted_Call struct {
+	*mock.Call
+}
+
+// SetCCTPTransferReceiveSubmitted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetCCTPTransferReceiveSubmittedParams
+func (_e *MockDatabase_Expecter) SetCCTPTransferReceiveSubmitted(ctx interface{}, arg interface{}) *MockDatabase_SetCCTPTransferReceiveSubmitted_Call {
+	return &MockDatabase_SetCCTPTransferReceiveSubmitted_Call{Call: _e.mock.On("SetCCTPTransferReceiveSubmitted", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetCCTPTransferReceiveSubmitted_Call) Run(run func(ctx context.Context, arg db.SetCCTPTransferReceiveSubmittedParams)) *MockDatabase_SetCCTPTransferReceiveSubmitted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetCCTPTransferReceiveSubmittedParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetCCTPTransferReceiveSubmitted_Call) Return(_a0 db.CctpTransfer, _a1 error) *MockDatabase_SetCCTPTransferReceiveSubmitted_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetCCTPTransferReceiveSubmitted_Call) RunAndReturn(run func(context.Context, db.SetCCTPTransferReceiveSubmittedParams) (db.CctpTransfer, error)) *MockDatabase_SetCCTPTransferReceiveSubmitted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCCTPTransferStatus provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetCCTPTransferStatus(ctx context.Context, arg db.SetCCTPTransferStatusParams) (db.CctpTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCCTPTransferStatus")
+	}
+
+	var r0 db.CctpTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCCTPTransferStatusParams) (db.CctpTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetCCTPTransferStatusParams) db.CctpTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.CctpTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetCCTPTransferStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetCCTPTransferStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCCTPTransferStatus'
+type MockDatabase_SetCCTPTransferStatus_Call struct {
+	*mock.Call
+}
+
+// SetCCTPTransferStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetCCTPTransferStatusParams
+func (_e *MockDatabase_Expecter) SetCCTPTransferStatus(ctx interface{}, arg interface{}) *MockDatabase_SetCCTPTransferStatus_Call {
+	return &MockDatabase_SetCCTPTransferStatus_Call{Call: _e.mock.On("SetCCTPTransferStatus", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetCCTPTransferStatus_Call) Run(run func(ctx context.Context, arg db.SetCCTPTransferStatusParams)) *MockDatabase_SetCCTPTransferStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetCCTPTransferStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetCCTPTransferStatus_Call) Return(_a0 db.CctpTransfer, _a1 error) *MockDatabase_SetCCTPTransferStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetCCTPTransferStatus_Call) RunAndReturn(run func(context.Context, db.SetCCTPTransferStatusParams) (db.CctpTransfer, error)) *MockDatabase_SetCCTPTransferStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetERC20AllowanceApproved provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetERC20AllowanceApproved(ctx context.Context, arg db.SetERC20AllowanceApprovedParams) (db.Erc20Allowance, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetERC20AllowanceApproved")
+	}
+
+	var r0 db.Erc20Allowance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetERC20AllowanceApprovedParams) (db.Erc20Allowance, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetERC20AllowanceApprovedParams) db.Erc20Allowance); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Erc20Allowance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetERC20AllowanceApprovedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetERC20AllowanceApproved_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetERC20AllowanceApproved'
+type MockDatabase_SetERC20AllowanceApproved_Call struct {
+	*mock.Call
+}
+
+// SetERC20AllowanceApproved is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetERC20AllowanceApprovedParams
+func (_e *MockDatabase_Expecter) SetERC20AllowanceApproved(ctx interface{}, arg interface{}) *MockDatabase_SetERC20AllowanceApproved_Call {
+	return &MockDatabase_SetERC20AllowanceApproved_Call{Call: _e.mock.On("SetERC20AllowanceApproved", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetERC20AllowanceApproved_Call) Run(run func(ctx context.Context, arg db.SetERC20AllowanceApprovedParams)) *MockDatabase_SetERC20AllowanceApproved_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetERC20AllowanceApprovedParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetERC20AllowanceApproved_Call) Return(_a0 db.Erc20Allowance, _a1 error) *MockDatabase_SetERC20AllowanceApproved_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetERC20AllowanceApproved_Call) RunAndReturn(run func(context.Context, db.SetERC20AllowanceApprovedParams) (db.Erc20Allowance, error)) *MockDatabase_SetERC20AllowanceApproved_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetERC20AllowanceRevoked provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetERC20AllowanceRevoked(ctx context.Context, arg db.SetERC20AllowanceRevokedParams) (db.Erc20Allowance, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetERC20AllowanceRevoked")
+	}
+
+	var r0 db.Erc20Allowance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetERC20AllowanceRevokedParams) (db.Erc20Allowance, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetERC20AllowanceRevokedParams) db.Erc20Allowance); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Erc20Allowance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetERC20AllowanceRevokedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetERC20AllowanceRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetERC20AllowanceRevoked'
+type MockDatabase_SetERC20AllowanceRevoked_Call struct {
+	*mock.Call
+}
+
+// SetERC20AllowanceRevoked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetERC20AllowanceRevokedParams
+func (_e *MockDatabase_Expecter) SetERC20AllowanceRevoked(ctx interface{}, arg interface{}) *MockDatabase_SetERC20AllowanceRevoked_Call {
+	return &MockDatabase_SetERC20AllowanceRevoked_Call{Call: _e.mock.On("SetERC20AllowanceRevoked", ctx, arg)}
 }
 
-func (_c *MockDatabase_InsertSubmittedTx_Call) Run(run func(ctx context.Context, arg db.InsertSubmittedTxParams)) *MockDatabase_InsertSubmittedTx_Call {
+func (_c *MockDatabase_SetERC20AllowanceRevoked_Call) Run(run func(ctx context.Context, arg db.SetERC20AllowanceRevokedParams)) *MockDatabase_SetERC20AllowanceRevoked_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(db.InsertSubmittedTxParams))
+		run(args[0].(context.Context), args[1].(db.SetERC20AllowanceRevokedParams))
 	})
 	return _c
 }
 
-func (_c *MockDatabase_InsertSubmittedTx_Call) Return(_a0 db.SubmittedTx, _a1 error) *MockDatabase_InsertSubmittedTx_Call {
+func (_c *MockDatabase_SetERC20AllowanceRevoked_Call) Return(_a0 db.Erc20Allowance, _a1 error) *MockDatabase_SetERC20AllowanceRevoked_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDatabase_InsertSubmittedTx_Call) RunAndReturn(run func(context.Context, db.InsertSubmittedTxParams) (db.SubmittedTx, error)) *MockDatabase_InsertSubmittedTx_Call {
+func (_c *MockDatabase_SetERC20AllowanceRevoked_Call) RunAndReturn(run func(context.Context, db.SetERC20AllowanceRevokedParams) (db.Erc20Allowance, error)) *MockDatabase_SetERC20AllowanceRevoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetERC20AllowanceUsed provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetERC20AllowanceUsed(ctx context.Context, arg db.SetERC20AllowanceUsedParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetERC20AllowanceUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetERC20AllowanceUsedParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDatabase_SetERC20AllowanceUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetERC20AllowanceUsed'
+type MockDatabase_SetERC20AllowanceUsed_Call struct {
+	*mock.Call
+}
+
+// SetERC20AllowanceUsed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetERC20AllowanceUsedParams
+func (_e *MockDatabase_Expecter) SetERC20AllowanceUsed(ctx interface{}, arg interface{}) *MockDatabase_SetERC20AllowanceUsed_Call {
+	return &MockDatabase_SetERC20AllowanceUsed_Call{Call: _e.mock.On("SetERC20AllowanceUsed", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetERC20AllowanceUsed_Call) Run(run func(ctx context.Context, arg db.SetERC20AllowanceUsedParams)) *MockDatabase_SetERC20AllowanceUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetERC20AllowanceUsedParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetERC20AllowanceUsed_Call) Return(_a0 error) *MockDatabase_SetERC20AllowanceUsed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDatabase_SetERC20AllowanceUsed_Call) RunAndReturn(run func(context.Context, db.SetERC20AllowanceUsedParams) error) *MockDatabase_SetERC20AllowanceUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIBCTransferPacketSent provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetIBCTransferPacketSent(ctx context.Context, arg db.SetIBCTransferPacketSentParams) (db.IbcTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIBCTransferPacketSent")
+	}
+
+	var r0 db.IbcTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetIBCTransferPacketSentParams) (db.IbcTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetIBCTransferPacketSentParams) db.IbcTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IbcTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetIBCTransferPacketSentParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetIBCTransferPacketSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIBCTransferPacketSent'
+type MockDatabase_SetIBCTransferPacketSent_Call struct {
+	*mock.Call
+}
+
+// SetIBCTransferPacketSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetIBCTransferPacketSentParams
+func (_e *MockDatabase_Expecter) SetIBCTransferPacketSent(ctx interface{}, arg interface{}) *MockDatabase_SetIBCTransferPacketSent_Call {
+	return &MockDatabase_SetIBCTransferPacketSent_Call{Call: _e.mock.On("SetIBCTransferPacketSent", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetIBCTransferPacketSent_Call) Run(run func(ctx context.Context, arg db.SetIBCTransferPacketSentParams)) *MockDatabase_SetIBCTransferPacketSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetIBCTransferPacketSentParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetIBCTransferPacketSent_Call) Return(_a0 db.IbcTransfer, _a1 error) *MockDatabase_SetIBCTransferPacketSent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetIBCTransferPacketSent_Call) RunAndReturn(run func(context.Context, db.SetIBCTransferPacketSentParams) (db.IbcTransfer, error)) *MockDatabase_SetIBCTransferPacketSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIBCTransferStatus provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetIBCTransferStatus(ctx context.Context, arg db.SetIBCTransferStatusParams) (db.IbcTransfer, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIBCTransferStatus")
+	}
+
+	var r0 db.IbcTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetIBCTransferStatusParams) (db.IbcTransfer, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetIBCTransferStatusParams) db.IbcTransfer); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IbcTransfer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetIBCTransferStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetIBCTransferStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIBCTransferStatus'
+type MockDatabase_SetIBCTransferStatus_Call struct {
+	*mock.Call
+}
+
+// SetIBCTransferStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetIBCTransferStatusParams
+func (_e *MockDatabase_Expecter) SetIBCTransferStatus(ctx interface{}, arg interface{}) *MockDatabase_SetIBCTransferStatus_Call {
+	return &MockDatabase_SetIBCTransferStatus_Call{Call: _e.mock.On("SetIBCTransferStatus", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetIBCTransferStatus_Call) Run(run func(ctx context.Context, arg db.SetIBCTransferStatusParams)) *MockDatabase_SetIBCTransferStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetIBCTransferStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetIBCTransferStatus_Call) Return(_a0 db.IbcTransfer, _a1 error) *MockDatabase_SetIBCTransferStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetIBCTransferStatus_Call) RunAndReturn(run func(context.Context, db.SetIBCTransferStatusParams) (db.IbcTransfer, error)) *MockDatabase_SetIBCTransferStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRebalanceTransferStepStatus provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetRebalanceTransferStepStatus(ctx context.Context, arg db.SetRebalanceTransferStepStatusParams) (db.RebalanceTransferStep, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRebalanceTransferStepStatus")
+	}
+
+	var r0 db.RebalanceTransferStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetRebalanceTransferStepStatusParams) (db.RebalanceTransferStep, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetRebalanceTransferStepStatusParams) db.RebalanceTransferStep); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RebalanceTransferStep)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetRebalanceTransferStepStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetRebalanceTransferStepStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRebalanceTransferStepStatus'
+type MockDatabase_SetRebalanceTransferStepStatus_Call struct {
+	*mock.Call
+}
+
+// SetRebalanceTransferStepStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetRebalanceTransferStepStatusParams
+func (_e *MockDatabase_Expecter) SetRebalanceTransferStepStatus(ctx interface{}, arg interface{}) *MockDatabase_SetRebalanceTransferStepStatus_Call {
+	return &MockDatabase_SetRebalanceTransferStepStatus_Call{Call: _e.mock.On("SetRebalanceTransferStepStatus", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepStatus_Call) Run(run func(ctx context.Context, arg db.SetRebalanceTransferStepStatusParams)) *MockDatabase_SetRebalanceTransferStepStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetRebalanceTransferStepStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepStatus_Call) Return(_a0 db.RebalanceTransferStep, _a1 error) *MockDatabase_SetRebalanceTransferStepStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepStatus_Call) RunAndReturn(run func(context.Context, db.SetRebalanceTransferStepStatusParams) (db.RebalanceTransferStep, error)) *MockDatabase_SetRebalanceTransferStepStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRebalanceTransferStepSubmitted provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetRebalanceTransferStepSubmitted(ctx context.Context, arg db.SetRebalanceTransferStepSubmittedParams) (db.RebalanceTransferStep, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRebalanceTransferStepSubmitted")
+	}
+
+	var r0 db.RebalanceTransferStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetRebalanceTransferStepSubmittedParams) (db.RebalanceTransferStep, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetRebalanceTransferStepSubmittedParams) db.RebalanceTransferStep); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RebalanceTransferStep)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetRebalanceTransferStepSubmittedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetRebalanceTransferStepSubmitted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRebalanceTransferStepSubmitted'
+type MockDatabase_SetRebalanceTransferStepSubmitted_Call struct {
+	*mock.Call
+}
+
+// SetRebalanceTransferStepSubmitted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetRebalanceTransferStepSubmittedParams
+func (_e *MockDatabase_Expecter) SetRebalanceTransferStepSubmitted(ctx interface{}, arg interface{}) *MockDatabase_SetRebalanceTransferStepSubmitted_Call {
+	return &MockDatabase_SetRebalanceTransferStepSubmitted_Call{Call: _e.mock.On("SetRebalanceTransferStepSubmitted", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepSubmitted_Call) Run(run func(ctx context.Context, arg db.SetRebalanceTransferStepSubmittedParams)) *MockDatabase_SetRebalanceTransferStepSubmitted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetRebalanceTransferStepSubmittedParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepSubmitted_Call) Return(_a0 db.RebalanceTransferStep, _a1 error) *MockDatabase_SetRebalanceTransferStepSubmitted_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepSubmitted_Call) RunAndReturn(run func(context.Context, db.SetRebalanceTransferStepSubmittedParams) (db.RebalanceTransferStep, error)) *MockDatabase_SetRebalanceTransferStepSubmitted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRebalanceTransferStepSubmitting provides a mock function with given fields: ctx, arg
+func (_m *MockDatabase) SetRebalanceTransferStepSubmitting(ctx context.Context, arg db.SetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRebalanceTransferStepSubmitting")
+	}
+
+	var r0 db.RebalanceTransferStep
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, db.SetRebalanceTransferStepSubmittingParams) db.RebalanceTransferStep); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.RebalanceTransferStep)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, db.SetRebalanceTransferStepSubmittingParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatabase_SetRebalanceTransferStepSubmitting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRebalanceTransferStepSubmitting'
+type MockDatabase_SetRebalanceTransferStepSubmitting_Call struct {
+	*mock.Call
+}
+
+// SetRebalanceTransferStepSubmitting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.SetRebalanceTransferStepSubmittingParams
+func (_e *MockDatabase_Expecter) SetRebalanceTransferStepSubmitting(ctx interface{}, arg interface{}) *MockDatabase_SetRebalanceTransferStepSubmitting_Call {
+	return &MockDatabase_SetRebalanceTransferStepSubmitting_Call{Call: _e.mock.On("SetRebalanceTransferStepSubmitting", ctx, arg)}
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepSubmitting_Call) Run(run func(ctx context.Context, arg db.SetRebalanceTransferStepSubmittingParams)) *MockDatabase_SetRebalanceTransferStepSubmitting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(db.SetRebalanceTransferStepSubmittingParams))
+	})
+	return _c
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepSubmitting_Call) Return(_a0 db.RebalanceTransferStep, _a1 error) *MockDatabase_SetRebalanceTransferStepSubmitting_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatabase_SetRebalanceTransferStepSubmitting_Call) RunAndReturn(run func(context.Context, db.SetRebalanceTransferStepSubmittingParams) (db.RebalanceTransferStep, error)) *MockDatabase_SetRebalanceTransferStepSubmitting_Call {
 	_c.Call.Return(run)
 	return _c
 }
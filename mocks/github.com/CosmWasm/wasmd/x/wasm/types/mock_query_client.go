@@ -0,0 +1,854 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package types
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MockQueryClient is an autogenerated mock type for the QueryClient type
+type MockQueryClient struct {
+	mock.Mock
+}
+
+type MockQueryClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQueryClient) EXPECT() *MockQueryClient_Expecter {
+	return &MockQueryClient_Expecter{mock: &_m.Mock}
+}
+
+// AllContractState provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) AllContractState(ctx context.Context, in *types.QueryAllContractStateRequest, opts ...grpc.CallOption) (*types.QueryAllContractStateResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllContractState")
+	}
+
+	var r0 *types.QueryAllContractStateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryAllContractStateRequest, ...grpc.CallOption) (*types.QueryAllContractStateResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryAllContractStateRequest, ...grpc.CallOption) *types.QueryAllContractStateResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryAllContractStateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryAllContractStateRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_AllContractState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllContractState'
+type MockQueryClient_AllContractState_Call struct {
+	*mock.Call
+}
+
+// AllContractState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryAllContractStateRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) AllContractState(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_AllContractState_Call {
+	return &MockQueryClient_AllContractState_Call{Call: _e.mock.On("AllContractState",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_AllContractState_Call) Run(run func(ctx context.Context, in *types.QueryAllContractStateRequest, opts ...grpc.CallOption)) *MockQueryClient_AllContractState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryAllContractStateRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_AllContractState_Call) Return(_a0 *types.QueryAllContractStateResponse, _a1 error) *MockQueryClient_AllContractState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_AllContractState_Call) RunAndReturn(run func(context.Context, *types.QueryAllContractStateRequest, ...grpc.CallOption) (*types.QueryAllContractStateResponse, error)) *MockQueryClient_AllContractState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Code provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) Code(ctx context.Context, in *types.QueryCodeRequest, opts ...grpc.CallOption) (*types.QueryCodeResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Code")
+	}
+
+	var r0 *types.QueryCodeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryCodeRequest, ...grpc.CallOption) (*types.QueryCodeResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryCodeRequest, ...grpc.CallOption) *types.QueryCodeResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryCodeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryCodeRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_Code_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Code'
+type MockQueryClient_Code_Call struct {
+	*mock.Call
+}
+
+// Code is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryCodeRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) Code(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_Code_Call {
+	return &MockQueryClient_Code_Call{Call: _e.mock.On("Code",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_Code_Call) Run(run func(ctx context.Context, in *types.QueryCodeRequest, opts ...grpc.CallOption)) *MockQueryClient_Code_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryCodeRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_Code_Call) Return(_a0 *types.QueryCodeResponse, _a1 error) *MockQueryClient_Code_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_Code_Call) RunAndReturn(run func(context.Context, *types.QueryCodeRequest, ...grpc.CallOption) (*types.QueryCodeResponse, error)) *MockQueryClient_Code_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Codes provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) Codes(ctx context.Context, in *types.QueryCodesRequest, opts ...grpc.CallOption) (*types.QueryCodesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Codes")
+	}
+
+	var r0 *types.QueryCodesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryCodesRequest, ...grpc.CallOption) (*types.QueryCodesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryCodesRequest, ...grpc.CallOption) *types.QueryCodesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryCodesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryCodesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_Codes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Codes'
+type MockQueryClient_Codes_Call struct {
+	*mock.Call
+}
+
+// Codes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryCodesRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) Codes(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_Codes_Call {
+	return &MockQueryClient_Codes_Call{Call: _e.mock.On("Codes",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_Codes_Call) Run(run func(ctx context.Context, in *types.QueryCodesRequest, opts ...grpc.CallOption)) *MockQueryClient_Codes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryCodesRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_Codes_Call) Return(_a0 *types.QueryCodesResponse, _a1 error) *MockQueryClient_Codes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_Codes_Call) RunAndReturn(run func(context.Context, *types.QueryCodesRequest, ...grpc.CallOption) (*types.QueryCodesResponse, error)) *MockQueryClient_Codes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ContractHistory provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) ContractHistory(ctx context.Context, in *types.QueryContractHistoryRequest, opts ...grpc.CallOption) (*types.QueryContractHistoryResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContractHistory")
+	}
+
+	var r0 *types.QueryContractHistoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryContractHistoryRequest, ...grpc.CallOption) (*types.QueryContractHistoryResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryContractHistoryRequest, ...grpc.CallOption) *types.QueryContractHistoryResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryContractHistoryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryContractHistoryRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_ContractHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContractHistory'
+type MockQueryClient_ContractHistory_Call struct {
+	*mock.Call
+}
+
+// ContractHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryContractHistoryRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) ContractHistory(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_ContractHistory_Call {
+	return &MockQueryClient_ContractHistory_Call{Call: _e.mock.On("ContractHistory",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_ContractHistory_Call) Run(run func(ctx context.Context, in *types.QueryContractHistoryRequest, opts ...grpc.CallOption)) *MockQueryClient_ContractHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryContractHistoryRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_ContractHistory_Call) Return(_a0 *types.QueryContractHistoryResponse, _a1 error) *MockQueryClient_ContractHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_ContractHistory_Call) RunAndReturn(run func(context.Context, *types.QueryContractHistoryRequest, ...grpc.CallOption) (*types.QueryContractHistoryResponse, error)) *MockQueryClient_ContractHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ContractInfo provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) ContractInfo(ctx context.Context, in *types.QueryContractInfoRequest, opts ...grpc.CallOption) (*types.QueryContractInfoResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContractInfo")
+	}
+
+	var r0 *types.QueryContractInfoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryContractInfoRequest, ...grpc.CallOption) (*types.QueryContractInfoResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryContractInfoRequest, ...grpc.CallOption) *types.QueryContractInfoResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryContractInfoResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryContractInfoRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_ContractInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContractInfo'
+type MockQueryClient_ContractInfo_Call struct {
+	*mock.Call
+}
+
+// ContractInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryContractInfoRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) ContractInfo(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_ContractInfo_Call {
+	return &MockQueryClient_ContractInfo_Call{Call: _e.mock.On("ContractInfo",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_ContractInfo_Call) Run(run func(ctx context.Context, in *types.QueryContractInfoRequest, opts ...grpc.CallOption)) *MockQueryClient_ContractInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryContractInfoRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_ContractInfo_Call) Return(_a0 *types.QueryContractInfoResponse, _a1 error) *MockQueryClient_ContractInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_ContractInfo_Call) RunAndReturn(run func(context.Context, *types.QueryContractInfoRequest, ...grpc.CallOption) (*types.QueryContractInfoResponse, error)) *MockQueryClient_ContractInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ContractsByCode provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) ContractsByCode(ctx context.Context, in *types.QueryContractsByCodeRequest, opts ...grpc.CallOption) (*types.QueryContractsByCodeResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContractsByCode")
+	}
+
+	var r0 *types.QueryContractsByCodeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryContractsByCodeRequest, ...grpc.CallOption) (*types.QueryContractsByCodeResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryContractsByCodeRequest, ...grpc.CallOption) *types.QueryContractsByCodeResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryContractsByCodeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryContractsByCodeRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_ContractsByCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContractsByCode'
+type MockQueryClient_ContractsByCode_Call struct {
+	*mock.Call
+}
+
+// ContractsByCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryContractsByCodeRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) ContractsByCode(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_ContractsByCode_Call {
+	return &MockQueryClient_ContractsByCode_Call{Call: _e.mock.On("ContractsByCode",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_ContractsByCode_Call) Run(run func(ctx context.Context, in *types.QueryContractsByCodeRequest, opts ...grpc.CallOption)) *MockQueryClient_ContractsByCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryContractsByCodeRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_ContractsByCode_Call) Return(_a0 *types.QueryContractsByCodeResponse, _a1 error) *MockQueryClient_ContractsByCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_ContractsByCode_Call) RunAndReturn(run func(context.Context, *types.QueryContractsByCodeRequest, ...grpc.CallOption) (*types.QueryContractsByCodeResponse, error)) *MockQueryClient_ContractsByCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ContractsByCreator provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) ContractsByCreator(ctx context.Context, in *types.QueryContractsByCreatorRequest, opts ...grpc.CallOption) (*types.QueryContractsByCreatorResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContractsByCreator")
+	}
+
+	var r0 *types.QueryContractsByCreatorResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryContractsByCreatorRequest, ...grpc.CallOption) (*types.QueryContractsByCreatorResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryContractsByCreatorRequest, ...grpc.CallOption) *types.QueryContractsByCreatorResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryContractsByCreatorResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryContractsByCreatorRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_ContractsByCreator_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContractsByCreator'
+type MockQueryClient_ContractsByCreator_Call struct {
+	*mock.Call
+}
+
+// ContractsByCreator is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryContractsByCreatorRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) ContractsByCreator(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_ContractsByCreator_Call {
+	return &MockQueryClient_ContractsByCreator_Call{Call: _e.mock.On("ContractsByCreator",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_ContractsByCreator_Call) Run(run func(ctx context.Context, in *types.QueryContractsByCreatorRequest, opts ...grpc.CallOption)) *MockQueryClient_ContractsByCreator_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryContractsByCreatorRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_ContractsByCreator_Call) Return(_a0 *types.QueryContractsByCreatorResponse, _a1 error) *MockQueryClient_ContractsByCreator_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_ContractsByCreator_Call) RunAndReturn(run func(context.Context, *types.QueryContractsByCreatorRequest, ...grpc.CallOption) (*types.QueryContractsByCreatorResponse, error)) *MockQueryClient_ContractsByCreator_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Params provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) Params(ctx context.Context, in *types.QueryParamsRequest, opts ...grpc.CallOption) (*types.QueryParamsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Params")
+	}
+
+	var r0 *types.QueryParamsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryParamsRequest, ...grpc.CallOption) (*types.QueryParamsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryParamsRequest, ...grpc.CallOption) *types.QueryParamsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryParamsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryParamsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_Params_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Params'
+type MockQueryClient_Params_Call struct {
+	*mock.Call
+}
+
+// Params is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryParamsRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) Params(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_Params_Call {
+	return &MockQueryClient_Params_Call{Call: _e.mock.On("Params",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_Params_Call) Run(run func(ctx context.Context, in *types.QueryParamsRequest, opts ...grpc.CallOption)) *MockQueryClient_Params_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryParamsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_Params_Call) Return(_a0 *types.QueryParamsResponse, _a1 error) *MockQueryClient_Params_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_Params_Call) RunAndReturn(run func(context.Context, *types.QueryParamsRequest, ...grpc.CallOption) (*types.QueryParamsResponse, error)) *MockQueryClient_Params_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PinnedCodes provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) PinnedCodes(ctx context.Context, in *types.QueryPinnedCodesRequest, opts ...grpc.CallOption) (*types.QueryPinnedCodesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinnedCodes")
+	}
+
+	var r0 *types.QueryPinnedCodesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryPinnedCodesRequest, ...grpc.CallOption) (*types.QueryPinnedCodesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryPinnedCodesRequest, ...grpc.CallOption) *types.QueryPinnedCodesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryPinnedCodesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryPinnedCodesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_PinnedCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PinnedCodes'
+type MockQueryClient_PinnedCodes_Call struct {
+	*mock.Call
+}
+
+// PinnedCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryPinnedCodesRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) PinnedCodes(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_PinnedCodes_Call {
+	return &MockQueryClient_PinnedCodes_Call{Call: _e.mock.On("PinnedCodes",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_PinnedCodes_Call) Run(run func(ctx context.Context, in *types.QueryPinnedCodesRequest, opts ...grpc.CallOption)) *MockQueryClient_PinnedCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryPinnedCodesRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_PinnedCodes_Call) Return(_a0 *types.QueryPinnedCodesResponse, _a1 error) *MockQueryClient_PinnedCodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_PinnedCodes_Call) RunAndReturn(run func(context.Context, *types.QueryPinnedCodesRequest, ...grpc.CallOption) (*types.QueryPinnedCodesResponse, error)) *MockQueryClient_PinnedCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RawContractState provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) RawContractState(ctx context.Context, in *types.QueryRawContractStateRequest, opts ...grpc.CallOption) (*types.QueryRawContractStateResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RawContractState")
+	}
+
+	var r0 *types.QueryRawContractStateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryRawContractStateRequest, ...grpc.CallOption) (*types.QueryRawContractStateResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryRawContractStateRequest, ...grpc.CallOption) *types.QueryRawContractStateResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QueryRawContractStateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryRawContractStateRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_RawContractState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RawContractState'
+type MockQueryClient_RawContractState_Call struct {
+	*mock.Call
+}
+
+// RawContractState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QueryRawContractStateRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) RawContractState(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_RawContractState_Call {
+	return &MockQueryClient_RawContractState_Call{Call: _e.mock.On("RawContractState",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_RawContractState_Call) Run(run func(ctx context.Context, in *types.QueryRawContractStateRequest, opts ...grpc.CallOption)) *MockQueryClient_RawContractState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QueryRawContractStateRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_RawContractState_Call) Return(_a0 *types.QueryRawContractStateResponse, _a1 error) *MockQueryClient_RawContractState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_RawContractState_Call) RunAndReturn(run func(context.Context, *types.QueryRawContractStateRequest, ...grpc.CallOption) (*types.QueryRawContractStateResponse, error)) *MockQueryClient_RawContractState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SmartContractState provides a mock function with given fields: ctx, in, opts
+func (_m *MockQueryClient) SmartContractState(ctx context.Context, in *types.QuerySmartContractStateRequest, opts ...grpc.CallOption) (*types.QuerySmartContractStateResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SmartContractState")
+	}
+
+	var r0 *types.QuerySmartContractStateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QuerySmartContractStateRequest, ...grpc.CallOption) (*types.QuerySmartContractStateResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QuerySmartContractStateRequest, ...grpc.CallOption) *types.QuerySmartContractStateResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.QuerySmartContractStateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QuerySmartContractStateRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQueryClient_SmartContractState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SmartContractState'
+type MockQueryClient_SmartContractState_Call struct {
+	*mock.Call
+}
+
+// SmartContractState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *types.QuerySmartContractStateRequest
+//   - opts ...grpc.CallOption
+func (_e *MockQueryClient_Expecter) SmartContractState(ctx interface{}, in interface{}, opts ...interface{}) *MockQueryClient_SmartContractState_Call {
+	return &MockQueryClient_SmartContractState_Call{Call: _e.mock.On("SmartContractState",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockQueryClient_SmartContractState_Call) Run(run func(ctx context.Context, in *types.QuerySmartContractStateRequest, opts ...grpc.CallOption)) *MockQueryClient_SmartContractState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*types.QuerySmartContractStateRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockQueryClient_SmartContractState_Call) Return(_a0 *types.QuerySmartContractStateResponse, _a1 error) *MockQueryClient_SmartContractState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQueryClient_SmartContractState_Call) RunAndReturn(run func(context.Context, *types.QuerySmartContractStateRequest, ...grpc.CallOption) (*types.QuerySmartContractStateResponse, error)) *MockQueryClient_SmartContractState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockQueryClient creates a new instance of MockQueryClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQueryClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQueryClient {
+	mock := &MockQueryClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,76 @@
+package circle
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/skip-mev/go-fast-solver/shared/clients/circle"
+)
+
+// FakeAttestationService is a local stand in for Circle's attestation
+// service. Messages are unknown to the service until they are marked pending
+// or attested.
+type FakeAttestationService struct {
+	server *httptest.Server
+
+	mu           sync.Mutex
+	attestations map[common.Hash]*circle.Attestation
+}
+
+func NewFakeAttestationService() *FakeAttestationService {
+	s := &FakeAttestationService{attestations: make(map[common.Hash]*circle.Attestation)}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// URL is the base url the fake service is listening on
+func (s *FakeAttestationService) URL() string {
+	return s.server.URL
+}
+
+func (s *FakeAttestationService) Close() {
+	s.server.Close()
+}
+
+// SetPending marks the message with hash messageHash as seen but still
+// waiting for confirmations
+func (s *FakeAttestationService) SetPending(messageHash common.Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attestations[messageHash] = &circle.Attestation{Status: circle.AttestationStatusPendingConfirmations}
+}
+
+// Attest completes the attestation for the message with hash messageHash
+func (s *FakeAttestationService) Attest(messageHash common.Hash, attestation []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attestations[messageHash] = &circle.Attestation{Status: circle.AttestationStatusComplete, Attestation: attestation}
+}
+
+func (s *FakeAttestationService) handle(w http.ResponseWriter, r *http.Request) {
+	hash, ok := strings.CutPrefix(r.URL.Path, "/v1/attestations/")
+	if !ok || r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.mu.Lock()
+	attestation, ok := s.attestations[common.HexToHash(hash)]
+	s.mu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"Message hash not found"}`))
+		return
+	}
+
+	response := map[string]string{"status": string(attestation.Status), "attestation": "PENDING"}
+	if attestation.Status == circle.AttestationStatusComplete {
+		response["attestation"] = hexutil.Encode(attestation.Attestation)
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
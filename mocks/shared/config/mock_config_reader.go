@@ -7,6 +7,8 @@ import (
 
 	config "github.com/skip-mev/go-fast-solver/shared/config"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockConfigReader is an autogenerated mock type for the ConfigReader type
@@ -631,6 +633,64 @@ func (_c *MockConfigReader_GetRPCEndpoint_Call) RunAndReturn(run func(string) (s
 	return _c
 }
 
+// GetSettlementRepaymentAddress provides a mock function with given fields: chainID, batchValueUUSDC, at
+func (_m *MockConfigReader) GetSettlementRepaymentAddress(chainID string, batchValueUUSDC *big.Int, at time.Time) (string, error) {
+	ret := _m.Called(chainID, batchValueUUSDC, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettlementRepaymentAddress")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *big.Int, time.Time) (string, error)); ok {
+		return rf(chainID, batchValueUUSDC, at)
+	}
+	if rf, ok := ret.Get(0).(func(string, *big.Int, time.Time) string); ok {
+		r0 = rf(chainID, batchValueUUSDC, at)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *big.Int, time.Time) error); ok {
+		r1 = rf(chainID, batchValueUUSDC, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConfigReader_GetSettlementRepaymentAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettlementRepaymentAddress'
+type MockConfigReader_GetSettlementRepaymentAddress_Call struct {
+	*mock.Call
+}
+
+// GetSettlementRepaymentAddress is a helper method to define mock.On call
+//   - chainID string
+//   - batchValueUUSDC *big.Int
+//   - at time.Time
+func (_e *MockConfigReader_Expecter) GetSettlementRepaymentAddress(chainID interface{}, batchValueUUSDC interface{}, at interface{}) *MockConfigReader_GetSettlementRepaymentAddress_Call {
+	return &MockConfigReader_GetSettlementRepaymentAddress_Call{Call: _e.mock.On("GetSettlementRepaymentAddress", chainID, batchValueUUSDC, at)}
+}
+
+func (_c *MockConfigReader_GetSettlementRepaymentAddress_Call) Run(run func(chainID string, batchValueUUSDC *big.Int, at time.Time)) *MockConfigReader_GetSettlementRepaymentAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*big.Int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockConfigReader_GetSettlementRepaymentAddress_Call) Return(_a0 string, _a1 error) *MockConfigReader_GetSettlementRepaymentAddress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConfigReader_GetSettlementRepaymentAddress_Call) RunAndReturn(run func(string, *big.Int, time.Time) (string, error)) *MockConfigReader_GetSettlementRepaymentAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUSDCDenom provides a mock function with given fields: chainID
 func (_m *MockConfigReader) GetUSDCDenom(chainID string) (string, error) {
 	ret := _m.Called(chainID)
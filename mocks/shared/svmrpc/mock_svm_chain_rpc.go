@@ -0,0 +1,581 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package svmrpc
+
+import (
+	context "context"
+	big "math/big"
+
+	mock "github.com/stretchr/testify/mock"
+
+	rpc "github.com/gagliardetto/solana-go/rpc"
+
+	solana "github.com/gagliardetto/solana-go"
+)
+
+// MockSVMChainRPC is an autogenerated mock type for the SVMChainRPC type
+type MockSVMChainRPC struct {
+	mock.Mock
+}
+
+type MockSVMChainRPC_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSVMChainRPC) EXPECT() *MockSVMChainRPC_Expecter {
+	return &MockSVMChainRPC_Expecter{mock: &_m.Mock}
+}
+
+// GetAccountData provides a mock function with given fields: ctx, account
+func (_m *MockSVMChainRPC) GetAccountData(ctx context.Context, account solana.PublicKey) ([]byte, error) {
+	ret := _m.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountData")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, solana.PublicKey) ([]byte, error)); ok {
+		return rf(ctx, account)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, solana.PublicKey) []byte); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, solana.PublicKey) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSVMChainRPC_GetAccountData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountData'
+type MockSVMChainRPC_GetAccountData_Call struct {
+	*mock.Call
+}
+
+// GetAccountData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account solana.PublicKey
+func (_e *MockSVMChainRPC_Expecter) GetAccountData(ctx interface{}, account interface{}) *MockSVMChainRPC_GetAccountData_Call {
+	return &MockSVMChainRPC_GetAccountData_Call{Call: _e.mock.On("GetAccountData", ctx, account)}
+}
+
+func (_c *MockSVMChainRPC_GetAccountData_Call) Run(run func(ctx context.Context, account solana.PublicKey)) *MockSVMChainRPC_GetAccountData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(solana.PublicKey))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetAccountData_Call) Return(_a0 []byte, _a1 error) *MockSVMChainRPC_GetAccountData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetAccountData_Call) RunAndReturn(run func(context.Context, solana.PublicKey) ([]byte, error)) *MockSVMChainRPC_GetAccountData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalance provides a mock function with given fields: ctx, account
+func (_m *MockSVMChainRPC) GetBalance(ctx context.Context, account solana.PublicKey) (*big.Int, error) {
+	ret := _m.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, solana.PublicKey) (*big.Int, error)); ok {
+		return rf(ctx, account)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, solana.PublicKey) *big.Int); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, solana.PublicKey) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSVMChainRPC_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type MockSVMChainRPC_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account solana.PublicKey
+func (_e *MockSVMChainRPC_Expecter) GetBalance(ctx interface{}, account interface{}) *MockSVMChainRPC_GetBalance_Call {
+	return &MockSVMChainRPC_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, account)}
+}
+
+func (_c *MockSVMChainRPC_GetBalance_Call) Run(run func(ctx context.Context, account solana.PublicKey)) *MockSVMChainRPC_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(solana.PublicKey))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetBalance_Call) Return(_a0 *big.Int, _a1 error) *MockSVMChainRPC_GetBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetBalance_Call) RunAndReturn(run func(context.Context, solana.PublicKey) (*big.Int, error)) *MockSVMChainRPC_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlockHeight provides a mock function with given fields: ctx
+func (_m *MockSVMChainRPC) GetBlockHeight(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockHeight")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSVMChainRPC_GetBlockHeight_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockHeight'
+type MockSVMChainRPC_GetBlockHeight_Call struct {
+	*mock.Call
+}
+
+// GetBlockHeight is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSVMChainRPC_Expecter) GetBlockHeight(ctx interface{}) *MockSVMChainRPC_GetBlockHeight_Call {
+	return &MockSVMChainRPC_GetBlockHeight_Call{Call: _e.mock.On("GetBlockHeight", ctx)}
+}
+
+func (_c *MockSVMChainRPC_GetBlockHeight_Call) Run(run func(ctx context.Context)) *MockSVMChainRPC_GetBlockHeight_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetBlockHeight_Call) Return(_a0 uint64, _a1 error) *MockSVMChainRPC_GetBlockHeight_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetBlockHeight_Call) RunAndReturn(run func(context.Context) (uint64, error)) *MockSVMChainRPC_GetBlockHeight_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestBlockhash provides a mock function with given fields: ctx
+func (_m *MockSVMChainRPC) GetLatestBlockhash(ctx context.Context) (solana.Hash, uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestBlockhash")
+	}
+
+	var r0 solana.Hash
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (solana.Hash, uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) solana.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(solana.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) uint64); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSVMChainRPC_GetLatestBlockhash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestBlockhash'
+type MockSVMChainRPC_GetLatestBlockhash_Call struct {
+	*mock.Call
+}
+
+// GetLatestBlockhash is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSVMChainRPC_Expecter) GetLatestBlockhash(ctx interface{}) *MockSVMChainRPC_GetLatestBlockhash_Call {
+	return &MockSVMChainRPC_GetLatestBlockhash_Call{Call: _e.mock.On("GetLatestBlockhash", ctx)}
+}
+
+func (_c *MockSVMChainRPC_GetLatestBlockhash_Call) Run(run func(ctx context.Context)) *MockSVMChainRPC_GetLatestBlockhash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetLatestBlockhash_Call) Return(blockhash solana.Hash, lastValidBlockHeight uint64, err error) *MockSVMChainRPC_GetLatestBlockhash_Call {
+	_c.Call.Return(blockhash, lastValidBlockHeight, err)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetLatestBlockhash_Call) RunAndReturn(run func(context.Context) (solana.Hash, uint64, error)) *MockSVMChainRPC_GetLatestBlockhash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSignatureStatus provides a mock function with given fields: ctx, signature
+func (_m *MockSVMChainRPC) GetSignatureStatus(ctx context.Context, signature solana.Signature) (*rpc.SignatureStatusesResult, error) {
+	ret := _m.Called(ctx, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSignatureStatus")
+	}
+
+	var r0 *rpc.SignatureStatusesResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, solana.Signature) (*rpc.SignatureStatusesResult, error)); ok {
+		return rf(ctx, signature)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, solana.Signature) *rpc.SignatureStatusesResult); ok {
+		r0 = rf(ctx, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rpc.SignatureStatusesResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, solana.Signature) error); ok {
+		r1 = rf(ctx, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSVMChainRPC_GetSignatureStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSignatureStatus'
+type MockSVMChainRPC_GetSignatureStatus_Call struct {
+	*mock.Call
+}
+
+// GetSignatureStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signature solana.Signature
+func (_e *MockSVMChainRPC_Expecter) GetSignatureStatus(ctx interface{}, signature interface{}) *MockSVMChainRPC_GetSignatureStatus_Call {
+	return &MockSVMChainRPC_GetSignatureStatus_Call{Call: _e.mock.On("GetSignatureStatus", ctx, signature)}
+}
+
+func (_c *MockSVMChainRPC_GetSignatureStatus_Call) Run(run func(ctx context.Context, signature solana.Signature)) *MockSVMChainRPC_GetSignatureStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(solana.Signature))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetSignatureStatus_Call) Return(_a0 *rpc.SignatureStatusesResult, _a1 error) *MockSVMChainRPC_GetSignatureStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetSignatureStatus_Call) RunAndReturn(run func(context.Context, solana.Signature) (*rpc.SignatureStatusesResult, error)) *MockSVMChainRPC_GetSignatureStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSlot provides a mock function with given fields: ctx
+func (_m *MockSVMChainRPC) GetSlot(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSlot")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSVMChainRPC_GetSlot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSlot'
+type MockSVMChainRPC_GetSlot_Call struct {
+	*mock.Call
+}
+
+// GetSlot is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSVMChainRPC_Expecter) GetSlot(ctx interface{}) *MockSVMChainRPC_GetSlot_Call {
+	return &MockSVMChainRPC_GetSlot_Call{Call: _e.mock.On("GetSlot", ctx)}
+}
+
+func (_c *MockSVMChainRPC_GetSlot_Call) Run(run func(ctx context.Context)) *MockSVMChainRPC_GetSlot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetSlot_Call) Return(_a0 uint64, _a1 error) *MockSVMChainRPC_GetSlot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetSlot_Call) RunAndReturn(run func(context.Context) (uint64, error)) *MockSVMChainRPC_GetSlot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTokenBalance provides a mock function with given fields: ctx, tokenAccount
+func (_m *MockSVMChainRPC) GetTokenBalance(ctx context.Context, tokenAccount solana.PublicKey) (*big.Int, error) {
+	ret := _m.Called(ctx, tokenAccount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenBalance")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, solana.PublicKey) (*big.Int, error)); ok {
+		return rf(ctx, tokenAccount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, solana.PublicKey) *big.Int); ok {
+		r0 = rf(ctx, tokenAccount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, solana.PublicKey) error); ok {
+		r1 = rf(ctx, tokenAccount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSVMChainRPC_GetTokenBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTokenBalance'
+type MockSVMChainRPC_GetTokenBalance_Call struct {
+	*mock.Call
+}
+
+// GetTokenBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenAccount solana.PublicKey
+func (_e *MockSVMChainRPC_Expecter) GetTokenBalance(ctx interface{}, tokenAccount interface{}) *MockSVMChainRPC_GetTokenBalance_Call {
+	return &MockSVMChainRPC_GetTokenBalance_Call{Call: _e.mock.On("GetTokenBalance", ctx, tokenAccount)}
+}
+
+func (_c *MockSVMChainRPC_GetTokenBalance_Call) Run(run func(ctx context.Context, tokenAccount solana.PublicKey)) *MockSVMChainRPC_GetTokenBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(solana.PublicKey))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetTokenBalance_Call) Return(_a0 *big.Int, _a1 error) *MockSVMChainRPC_GetTokenBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetTokenBalance_Call) RunAndReturn(run func(context.Context, solana.PublicKey) (*big.Int, error)) *MockSVMChainRPC_GetTokenBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTx provides a mock function with given fields: ctx, signature
+func (_m *MockSVMChainRPC) GetTx(ctx context.Context, signature solana.Signature) (*solana.Transaction, *rpc.TransactionMeta, error) {
+	ret := _m.Called(ctx, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTx")
+	}
+
+	var r0 *solana.Transaction
+	var r1 *rpc.TransactionMeta
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, solana.Signature) (*solana.Transaction, *rpc.TransactionMeta, error)); ok {
+		return rf(ctx, signature)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, solana.Signature) *solana.Transaction); ok {
+		r0 = rf(ctx, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*solana.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, solana.Signature) *rpc.TransactionMeta); ok {
+		r1 = rf(ctx, signature)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*rpc.TransactionMeta)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, solana.Signature) error); ok {
+		r2 = rf(ctx, signature)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSVMChainRPC_GetTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTx'
+type MockSVMChainRPC_GetTx_Call struct {
+	*mock.Call
+}
+
+// GetTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signature solana.Signature
+func (_e *MockSVMChainRPC_Expecter) GetTx(ctx interface{}, signature interface{}) *MockSVMChainRPC_GetTx_Call {
+	return &MockSVMChainRPC_GetTx_Call{Call: _e.mock.On("GetTx", ctx, signature)}
+}
+
+func (_c *MockSVMChainRPC_GetTx_Call) Run(run func(ctx context.Context, signature solana.Signature)) *MockSVMChainRPC_GetTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(solana.Signature))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetTx_Call) Return(_a0 *solana.Transaction, _a1 *rpc.TransactionMeta, _a2 error) *MockSVMChainRPC_GetTx_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_GetTx_Call) RunAndReturn(run func(context.Context, solana.Signature) (*solana.Transaction, *rpc.TransactionMeta, error)) *MockSVMChainRPC_GetTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendTx provides a mock function with given fields: ctx, tx
+func (_m *MockSVMChainRPC) SendTx(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+	ret := _m.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTx")
+	}
+
+	var r0 solana.Signature
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *solana.Transaction) (solana.Signature, error)); ok {
+		return rf(ctx, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *solana.Transaction) solana.Signature); ok {
+		r0 = rf(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(solana.Signature)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *solana.Transaction) error); ok {
+		r1 = rf(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSVMChainRPC_SendTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTx'
+type MockSVMChainRPC_SendTx_Call struct {
+	*mock.Call
+}
+
+// SendTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *solana.Transaction
+func (_e *MockSVMChainRPC_Expecter) SendTx(ctx interface{}, tx interface{}) *MockSVMChainRPC_SendTx_Call {
+	return &MockSVMChainRPC_SendTx_Call{Call: _e.mock.On("SendTx", ctx, tx)}
+}
+
+func (_c *MockSVMChainRPC_SendTx_Call) Run(run func(ctx context.Context, tx *solana.Transaction)) *MockSVMChainRPC_SendTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*solana.Transaction))
+	})
+	return _c
+}
+
+func (_c *MockSVMChainRPC_SendTx_Call) Return(_a0 solana.Signature, _a1 error) *MockSVMChainRPC_SendTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSVMChainRPC_SendTx_Call) RunAndReturn(run func(context.Context, *solana.Transaction) (solana.Signature, error)) *MockSVMChainRPC_SendTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSVMChainRPC creates a new instance of MockSVMChainRPC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSVMChainRPC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSVMChainRPC {
+	mock := &MockSVMChainRPC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
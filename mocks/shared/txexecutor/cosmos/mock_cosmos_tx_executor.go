@@ -0,0 +1,179 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package cosmos
+
+import (
+	context "context"
+
+	client "github.com/cosmos/cosmos-sdk/client"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	mock "github.com/stretchr/testify/mock"
+
+	signing "github.com/skip-mev/go-fast-solver/shared/signing"
+
+	types "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MockCosmosTxExecutor is an autogenerated mock type for the CosmosTxExecutor type
+type MockCosmosTxExecutor struct {
+	mock.Mock
+}
+
+type MockCosmosTxExecutor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCosmosTxExecutor) EXPECT() *MockCosmosTxExecutor_Expecter {
+	return &MockCosmosTxExecutor_Expecter{mock: &_m.Mock}
+}
+
+// EstimateGas provides a mock function with given fields: ctx, chainID, signerAddress, msgs, txConfig, signer
+func (_m *MockCosmosTxExecutor) EstimateGas(ctx context.Context, chainID string, signerAddress string, msgs []types.Msg, txConfig client.TxConfig, signer signing.Signer) (uint64, error) {
+	ret := _m.Called(ctx, chainID, signerAddress, msgs, txConfig, signer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateGas")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer) (uint64, error)); ok {
+		return rf(ctx, chainID, signerAddress, msgs, txConfig, signer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer) uint64); ok {
+		r0 = rf(ctx, chainID, signerAddress, msgs, txConfig, signer)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer) error); ok {
+		r1 = rf(ctx, chainID, signerAddress, msgs, txConfig, signer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCosmosTxExecutor_EstimateGas_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateGas'
+type MockCosmosTxExecutor_EstimateGas_Call struct {
+	*mock.Call
+}
+
+// EstimateGas is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID string
+//   - signerAddress string
+//   - msgs []types.Msg
+//   - txConfig client.TxConfig
+//   - signer signing.Signer
+func (_e *MockCosmosTxExecutor_Expecter) EstimateGas(ctx interface{}, chainID interface{}, signerAddress interface{}, msgs interface{}, txConfig interface{}, signer interface{}) *MockCosmosTxExecutor_EstimateGas_Call {
+	return &MockCosmosTxExecutor_EstimateGas_Call{Call: _e.mock.On("EstimateGas", ctx, chainID, signerAddress, msgs, txConfig, signer)}
+}
+
+func (_c *MockCosmosTxExecutor_EstimateGas_Call) Run(run func(ctx context.Context, chainID string, signerAddress string, msgs []types.Msg, txConfig client.TxConfig, signer signing.Signer)) *MockCosmosTxExecutor_EstimateGas_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]types.Msg), args[4].(client.TxConfig), args[5].(signing.Signer))
+	})
+	return _c
+}
+
+func (_c *MockCosmosTxExecutor_EstimateGas_Call) Return(_a0 uint64, _a1 error) *MockCosmosTxExecutor_EstimateGas_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCosmosTxExecutor_EstimateGas_Call) RunAndReturn(run func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer) (uint64, error)) *MockCosmosTxExecutor_EstimateGas_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteTx provides a mock function with given fields: ctx, chainID, signerAddress, msgs, txConfig, signer, gasPrice, gasDenom
+func (_m *MockCosmosTxExecutor) ExecuteTx(ctx context.Context, chainID string, signerAddress string, msgs []types.Msg, txConfig client.TxConfig, signer signing.Signer, gasPrice float64, gasDenom string) (*coretypes.ResultBroadcastTx, types.Tx, error) {
+	ret := _m.Called(ctx, chainID, signerAddress, msgs, txConfig, signer, gasPrice, gasDenom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteTx")
+	}
+
+	var r0 *coretypes.ResultBroadcastTx
+	var r1 types.Tx
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer, float64, string) (*coretypes.ResultBroadcastTx, types.Tx, error)); ok {
+		return rf(ctx, chainID, signerAddress, msgs, txConfig, signer, gasPrice, gasDenom)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer, float64, string) *coretypes.ResultBroadcastTx); ok {
+		r0 = rf(ctx, chainID, signerAddress, msgs, txConfig, signer, gasPrice, gasDenom)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBroadcastTx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer, float64, string) types.Tx); ok {
+		r1 = rf(ctx, chainID, signerAddress, msgs, txConfig, signer, gasPrice, gasDenom)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(types.Tx)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer, float64, string) error); ok {
+		r2 = rf(ctx, chainID, signerAddress, msgs, txConfig, signer, gasPrice, gasDenom)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockCosmosTxExecutor_ExecuteTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteTx'
+type MockCosmosTxExecutor_ExecuteTx_Call struct {
+	*mock.Call
+}
+
+// ExecuteTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID string
+//   - signerAddress string
+//   - msgs []types.Msg
+//   - txConfig client.TxConfig
+//   - signer signing.Signer
+//   - gasPrice float64
+//   - gasDenom string
+func (_e *MockCosmosTxExecutor_Expecter) ExecuteTx(ctx interface{}, chainID interface{}, signerAddress interface{}, msgs interface{}, txConfig interface{}, signer interface{}, gasPrice interface{}, gasDenom interface{}) *MockCosmosTxExecutor_ExecuteTx_Call {
+	return &MockCosmosTxExecutor_ExecuteTx_Call{Call: _e.mock.On("ExecuteTx", ctx, chainID, signerAddress, msgs, txConfig, signer, gasPrice, gasDenom)}
+}
+
+func (_c *MockCosmosTxExecutor_ExecuteTx_Call) Run(run func(ctx context.Context, chainID string, signerAddress string, msgs []types.Msg, txConfig client.TxConfig, signer signing.Signer, gasPrice float64, gasDenom string)) *MockCosmosTxExecutor_ExecuteTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]types.Msg), args[4].(client.TxConfig), args[5].(signing.Signer), args[6].(float64), args[7].(string))
+	})
+	return _c
+}
+
+func (_c *MockCosmosTxExecutor_ExecuteTx_Call) Return(_a0 *coretypes.ResultBroadcastTx, _a1 types.Tx, _a2 error) *MockCosmosTxExecutor_ExecuteTx_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockCosmosTxExecutor_ExecuteTx_Call) RunAndReturn(run func(context.Context, string, string, []types.Msg, client.TxConfig, signing.Signer, float64, string) (*coretypes.ResultBroadcastTx, types.Tx, error)) *MockCosmosTxExecutor_ExecuteTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCosmosTxExecutor creates a new instance of MockCosmosTxExecutor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCosmosTxExecutor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCosmosTxExecutor {
+	mock := &MockCosmosTxExecutor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,7 +3,8 @@
 package evm
 
 import (
-	context "golang.org/x/net/context"
+	context "context"
+	big "math/big"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -92,6 +93,73 @@ func (_c *MockEVMTxExecutor_ExecuteTx_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// ReplaceTx provides a mock function with given fields: ctx, chainID, rawTxB64, maxTxFee, signer
+func (_m *MockEVMTxExecutor) ReplaceTx(ctx context.Context, chainID string, rawTxB64 string, maxTxFee *big.Int, signer signing.Signer) (string, string, error) {
+	ret := _m.Called(ctx, chainID, rawTxB64, maxTxFee, signer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceTx")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *big.Int, signing.Signer) (string, string, error)); ok {
+		return rf(ctx, chainID, rawTxB64, maxTxFee, signer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *big.Int, signing.Signer) string); ok {
+		r0 = rf(ctx, chainID, rawTxB64, maxTxFee, signer)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *big.Int, signing.Signer) string); ok {
+		r1 = rf(ctx, chainID, rawTxB64, maxTxFee, signer)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *big.Int, signing.Signer) error); ok {
+		r2 = rf(ctx, chainID, rawTxB64, maxTxFee, signer)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockEVMTxExecutor_ReplaceTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceTx'
+type MockEVMTxExecutor_ReplaceTx_Call struct {
+	*mock.Call
+}
+
+// ReplaceTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID string
+//   - rawTxB64 string
+//   - maxTxFee *big.Int
+//   - signer signing.Signer
+func (_e *MockEVMTxExecutor_Expecter) ReplaceTx(ctx interface{}, chainID interface{}, rawTxB64 interface{}, maxTxFee interface{}, signer interface{}) *MockEVMTxExecutor_ReplaceTx_Call {
+	return &MockEVMTxExecutor_ReplaceTx_Call{Call: _e.mock.On("ReplaceTx", ctx, chainID, rawTxB64, maxTxFee, signer)}
+}
+
+func (_c *MockEVMTxExecutor_ReplaceTx_Call) Run(run func(ctx context.Context, chainID string, rawTxB64 string, maxTxFee *big.Int, signer signing.Signer)) *MockEVMTxExecutor_ReplaceTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*big.Int), args[4].(signing.Signer))
+	})
+	return _c
+}
+
+func (_c *MockEVMTxExecutor_ReplaceTx_Call) Return(txHash string, replacementRawTxB64 string, err error) *MockEVMTxExecutor_ReplaceTx_Call {
+	_c.Call.Return(txHash, replacementRawTxB64, err)
+	return _c
+}
+
+func (_c *MockEVMTxExecutor_ReplaceTx_Call) RunAndReturn(run func(context.Context, string, string, *big.Int, signing.Signer) (string, string, error)) *MockEVMTxExecutor_ReplaceTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockEVMTxExecutor creates a new instance of MockEVMTxExecutor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockEVMTxExecutor(t interface {
@@ -0,0 +1,120 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package svm
+
+import (
+	context "context"
+
+	signing "github.com/skip-mev/go-fast-solver/shared/signing"
+	mock "github.com/stretchr/testify/mock"
+
+	solana "github.com/gagliardetto/solana-go"
+)
+
+// MockSVMTxExecutor is an autogenerated mock type for the SVMTxExecutor type
+type MockSVMTxExecutor struct {
+	mock.Mock
+}
+
+type MockSVMTxExecutor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSVMTxExecutor) EXPECT() *MockSVMTxExecutor_Expecter {
+	return &MockSVMTxExecutor_Expecter{mock: &_m.Mock}
+}
+
+// ExecuteTx provides a mock function with given fields: ctx, chainID, tx, signer, additionalSigners
+func (_m *MockSVMTxExecutor) ExecuteTx(ctx context.Context, chainID string, tx *solana.Transaction, signer signing.Signer, additionalSigners ...solana.PrivateKey) (string, string, error) {
+	_va := make([]interface{}, len(additionalSigners))
+	for _i := range additionalSigners {
+		_va[_i] = additionalSigners[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, chainID, tx, signer)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteTx")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *solana.Transaction, signing.Signer, ...solana.PrivateKey) (string, string, error)); ok {
+		return rf(ctx, chainID, tx, signer, additionalSigners...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *solana.Transaction, signing.Signer, ...solana.PrivateKey) string); ok {
+		r0 = rf(ctx, chainID, tx, signer, additionalSigners...)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *solana.Transaction, signing.Signer, ...solana.PrivateKey) string); ok {
+		r1 = rf(ctx, chainID, tx, signer, additionalSigners...)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *solana.Transaction, signing.Signer, ...solana.PrivateKey) error); ok {
+		r2 = rf(ctx, chainID, tx, signer, additionalSigners...)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockSVMTxExecutor_ExecuteTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteTx'
+type MockSVMTxExecutor_ExecuteTx_Call struct {
+	*mock.Call
+}
+
+// ExecuteTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chainID string
+//   - tx *solana.Transaction
+//   - signer signing.Signer
+//   - additionalSigners ...solana.PrivateKey
+func (_e *MockSVMTxExecutor_Expecter) ExecuteTx(ctx interface{}, chainID interface{}, tx interface{}, signer interface{}, additionalSigners ...interface{}) *MockSVMTxExecutor_ExecuteTx_Call {
+	return &MockSVMTxExecutor_ExecuteTx_Call{Call: _e.mock.On("ExecuteTx",
+		append([]interface{}{ctx, chainID, tx, signer}, additionalSigners...)...)}
+}
+
+func (_c *MockSVMTxExecutor_ExecuteTx_Call) Run(run func(ctx context.Context, chainID string, tx *solana.Transaction, signer signing.Signer, additionalSigners ...solana.PrivateKey)) *MockSVMTxExecutor_ExecuteTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]solana.PrivateKey, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(solana.PrivateKey)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(*solana.Transaction), args[3].(signing.Signer), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockSVMTxExecutor_ExecuteTx_Call) Return(txHash string, rawTxB64 string, err error) *MockSVMTxExecutor_ExecuteTx_Call {
+	_c.Call.Return(txHash, rawTxB64, err)
+	return _c
+}
+
+func (_c *MockSVMTxExecutor_ExecuteTx_Call) RunAndReturn(run func(context.Context, string, *solana.Transaction, signing.Signer, ...solana.PrivateKey) (string, string, error)) *MockSVMTxExecutor_ExecuteTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSVMTxExecutor creates a new instance of MockSVMTxExecutor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSVMTxExecutor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSVMTxExecutor {
+	mock := &MockSVMTxExecutor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -61,10 +61,17 @@ type Relayer interface {
 	CancelRelay(ctx context.Context, chainID, transactionHash string) (bool, error)
 }
 
+// RouteMonitor reports whether hyperlane messages can currently be relayed
+// from an origin chain to a destination chain
+type RouteMonitor interface {
+	IsRouteRelayable(originChainID, destinationChainID string) bool
+}
+
 type OrderSettler struct {
 	db            Database
 	clientManager *clientmanager.ClientManager
 	relayer       Relayer
+	routeMonitor  RouteMonitor
 	ordersSeen    map[string]bool
 }
 
@@ -73,11 +80,13 @@ func NewOrderSettler(
 	db Database,
 	clientManager *clientmanager.ClientManager,
 	relayer Relayer,
+	routeMonitor RouteMonitor,
 ) (*OrderSettler, error) {
 	return &OrderSettler{
 		db:            db,
 		clientManager: clientManager,
 		relayer:       relayer,
+		routeMonitor:  routeMonitor,
 		ordersSeen:    make(map[string]bool),
 	}, nil
 }
@@ -169,6 +178,17 @@ func (r *OrderSettler) settleOrders(ctx context.Context) error {
 			)
 			continue
 		}
+		// settlements are initiated on the batches destination chain and
+		// relayed back to its source chain, so don't initiate settlements
+		// that can not currently be relayed
+		if r.routeMonitor != nil && !r.routeMonitor.IsRouteRelayable(batch.DestinationChainID(), batch.SourceChainID()) {
+			lmt.Logger(ctx).Warn(
+				"not initiating settlement batch since there is no reachable quorum of hyperlane validators to relay it",
+				zap.String("sourceChainID", batch.SourceChainID()),
+				zap.String("destinationChainID", batch.DestinationChainID()),
+			)
+			continue
+		}
 		toSettle = append(toSettle, batch)
 	}
 
@@ -504,6 +524,22 @@ func (r *OrderSettler) SettleBatch(ctx context.Context, batch types.SettlementBa
 	if err != nil {
 		return "", fmt.Errorf("getting destination bridge client: %w", err)
 	}
+
+	// resolve the repayment address once up front and pin it to the batch so
+	// that the address recorded in the db is the same address that is used
+	// in the initiate settlement tx
+	repaymentAddress, err := batch.ResolveRepaymentAddress(ctx, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("resolving repayment address for batch: %w", err)
+	}
+	batch = batch.WithRepaymentAddress(repaymentAddress)
+	lmt.Logger(ctx).Info(
+		"resolved settlement batch repayment address",
+		zap.String("sourceChainID", batch.SourceChainID()),
+		zap.String("destinationChainID", batch.DestinationChainID()),
+		zap.String("repaymentAddress", repaymentAddress),
+	)
+
 	txHash, rawTx, err := destinationBridgeClient.InitiateBatchSettlement(ctx, batch)
 	metrics.FromContext(ctx).IncTransactionSubmitted(err == nil, batch.DestinationChainID(), dbtypes.TxTypeSettlement)
 	if err != nil {
@@ -526,6 +562,7 @@ func (r *OrderSettler) SettleBatch(ctx context.Context, batch types.SettlementBa
 				OrderID:                           settlement.OrderID,
 				SourceChainGatewayContractAddress: settlement.SourceChainGatewayContractAddress,
 				InitiateSettlementTx:              sql.NullString{String: txHash, Valid: true},
+				RepaymentAddress:                  sql.NullString{String: repaymentAddress, Valid: true},
 			}
 			if _, err = q.SetInitiateSettlementTx(ctx, settlementTx); err != nil {
 				return fmt.Errorf("setting initiate settlement tx for settlement from source chain %s with order id %s: %w", settlement.SourceChainID, settlement.OrderID, err)
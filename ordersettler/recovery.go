@@ -0,0 +1,374 @@
+package ordersettler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/bridges/cctp"
+	"github.com/skip-mev/go-fast-solver/shared/contracts/fast_transfer_gateway"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/metrics"
+	"go.uber.org/zap"
+)
+
+// FailureCause is the reason that a settlement has failed or become stuck
+type FailureCause string
+
+const (
+	// FailureCauseInitiationReverted means the initiate settlement tx landed
+	// on the settlement initiation chain but reverted.
+	FailureCauseInitiationReverted FailureCause = "INITIATION_REVERTED"
+	// FailureCauseInitiationNotFound means the initiate settlement tx was
+	// submitted but never landed on the settlement initiation chain.
+	FailureCauseInitiationNotFound FailureCause = "INITIATION_NOT_FOUND"
+	// FailureCauseRelayAbandoned means the settlement was initiated but the
+	// hyperlane relay of the settlement message was abandoned.
+	FailureCauseRelayAbandoned FailureCause = "RELAY_ABANDONED"
+	// FailureCauseDeliveredPayoutMissing means the settlement message was
+	// delivered to the payout chain but the order was never marked as
+	// settled by the payout chains gateway contract.
+	FailureCauseDeliveredPayoutMissing FailureCause = "DELIVERED_PAYOUT_MISSING"
+	// FailureCauseOrderRefunded means the order was refunded to the user on
+	// the payout chain, so it can no longer be settled to the solver.
+	FailureCauseOrderRefunded FailureCause = "ORDER_REFUNDED"
+	// FailureCauseAlreadySettled means the order has already been settled on
+	// the payout chain but the settlement was not marked complete in the db.
+	FailureCauseAlreadySettled FailureCause = "ALREADY_SETTLED"
+)
+
+// RecoveryAction is the action taken to recover a failed or stuck settlement
+type RecoveryAction string
+
+const (
+	// RecoveryActionReinitiate resets the settlement to pending so that it
+	// is batched and initiated again by the order settler.
+	RecoveryActionReinitiate RecoveryAction = "REINITIATE"
+	// RecoveryActionRerelay resets the settlements hyperlane transfer to
+	// pending so that it is relayed again by the relayer.
+	RecoveryActionRerelay RecoveryAction = "RERELAY"
+	// RecoveryActionWriteOff marks the settlement as written off, recording
+	// the amount the solver fronted for the order as a loss.
+	RecoveryActionWriteOff RecoveryAction = "WRITE_OFF"
+	// RecoveryActionMarkComplete marks the settlement as complete.
+	RecoveryActionMarkComplete RecoveryAction = "MARK_COMPLETE"
+)
+
+var recoveryActionsByCause = map[FailureCause]RecoveryAction{
+	FailureCauseInitiationReverted:     RecoveryActionReinitiate,
+	FailureCauseInitiationNotFound:     RecoveryActionReinitiate,
+	FailureCauseRelayAbandoned:         RecoveryActionRerelay,
+	FailureCauseDeliveredPayoutMissing: RecoveryActionReinitiate,
+	FailureCauseOrderRefunded:          RecoveryActionWriteOff,
+	FailureCauseAlreadySettled:         RecoveryActionMarkComplete,
+}
+
+// ParseRecoveryAction converts a string into a RecoveryAction, returning an
+// error if the string is not a known action.
+func ParseRecoveryAction(action string) (RecoveryAction, error) {
+	switch RecoveryAction(action) {
+	case RecoveryActionReinitiate, RecoveryActionRerelay, RecoveryActionWriteOff, RecoveryActionMarkComplete:
+		return RecoveryAction(action), nil
+	default:
+		return "", fmt.Errorf("unknown recovery action %s", action)
+	}
+}
+
+// SettlementRecovery is a failed or stuck settlement classified by the cause
+// of its failure, along with the recommended action to recover it.
+type SettlementRecovery struct {
+	Settlement db.OrderSettlement
+	Cause      FailureCause
+	Action     RecoveryAction
+	Reason     string
+}
+
+type RecoveryDatabase interface {
+	GetAllOrderSettlementsWithSettlementStatus(ctx context.Context, settlementStatus string) ([]db.OrderSettlement, error)
+	SetSettlementStatus(ctx context.Context, arg db.SetSettlementStatusParams) (db.OrderSettlement, error)
+	ResetOrderSettlement(ctx context.Context, arg db.ResetOrderSettlementParams) (db.OrderSettlement, error)
+
+	GetHyperlaneTransfer(ctx context.Context, id int64) (db.HyperlaneTransfer, error)
+	SetMessageStatus(ctx context.Context, arg db.SetMessageStatusParams) (db.HyperlaneTransfer, error)
+	ResetHyperlaneTransferRelayAttempts(ctx context.Context, id int64) (db.HyperlaneTransfer, error)
+}
+
+// BridgeClientManager returns the bridge client of a chain
+type BridgeClientManager interface {
+	GetClient(ctx context.Context, chainID string) (cctp.BridgeClient, error)
+}
+
+// SettlementRecoverer finds settlements that have failed or are stuck,
+// classifies them by the cause of failure, and applies recovery actions.
+type SettlementRecoverer struct {
+	db            RecoveryDatabase
+	clientManager BridgeClientManager
+
+	// stuckAfter is how long a settlement can sit in a non terminal state
+	// before it is considered stuck and a candidate for recovery
+	stuckAfter time.Duration
+}
+
+func NewSettlementRecoverer(db RecoveryDatabase, clientManager BridgeClientManager, stuckAfter time.Duration) *SettlementRecoverer {
+	return &SettlementRecoverer{
+		db:            db,
+		clientManager: clientManager,
+		stuckAfter:    stuckAfter,
+	}
+}
+
+// Classify returns a SettlementRecovery for every failed or stuck settlement
+// in the db. Settlements that are still progressing normally are not
+// returned.
+func (r *SettlementRecoverer) Classify(ctx context.Context) ([]SettlementRecovery, error) {
+	var candidates []db.OrderSettlement
+	for _, status := range []string{dbtypes.SettlementStatusFailed, dbtypes.SettlementStatusPending, dbtypes.SettlementStatusSettlementInitiated} {
+		settlements, err := r.db.GetAllOrderSettlementsWithSettlementStatus(ctx, status)
+		if err != nil {
+			return nil, fmt.Errorf("getting settlements with status %s: %w", status, err)
+		}
+		candidates = append(candidates, settlements...)
+	}
+
+	var recoveries []SettlementRecovery
+	for _, settlement := range candidates {
+		recovery, err := r.classify(ctx, settlement)
+		if err != nil {
+			lmt.Logger(ctx).Warn(
+				"failed to classify settlement for recovery, skipping",
+				zap.Error(err),
+				zap.String("orderID", settlement.OrderID),
+				zap.String("sourceChainID", settlement.SourceChainID),
+			)
+			continue
+		}
+		if recovery == nil {
+			continue
+		}
+		recoveries = append(recoveries, *recovery)
+	}
+
+	return recoveries, nil
+}
+
+// classify determines why a settlement has failed or is stuck. If the
+// settlement is not failed or stuck, nil is returned.
+func (r *SettlementRecoverer) classify(ctx context.Context, settlement db.OrderSettlement) (*SettlementRecovery, error) {
+	if settlement.SettlementStatus != dbtypes.SettlementStatusFailed && !r.isStale(settlement) {
+		return nil, nil
+	}
+
+	// the order status on the payout chain takes precedence over anything
+	// else, since it tells us if the order can still be settled at all
+	sourceBridgeClient, err := r.clientManager.GetClient(ctx, settlement.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("getting client for payout chain %s: %w", settlement.SourceChainID, err)
+	}
+	orderStatus, err := sourceBridgeClient.OrderStatus(ctx, settlement.SourceChainGatewayContractAddress, settlement.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("getting order status on payout chain %s: %w", settlement.SourceChainID, err)
+	}
+	switch orderStatus {
+	case fast_transfer_gateway.OrderStatusFilled:
+		return newSettlementRecovery(settlement, FailureCauseAlreadySettled, "order is already settled on the payout chain"), nil
+	case fast_transfer_gateway.OrderStatusRefunded:
+		return newSettlementRecovery(settlement, FailureCauseOrderRefunded, "order was refunded to the user on the payout chain"), nil
+	}
+
+	if settlement.SettlementStatus == dbtypes.SettlementStatusFailed {
+		return newSettlementRecovery(settlement, FailureCauseInitiationReverted, settlement.SettlementStatusMessage.String), nil
+	}
+
+	if !settlement.InitiateSettlementTx.Valid {
+		// the settlement is waiting to be batched, the order settler will
+		// initiate it once the batch is ready
+		return nil, nil
+	}
+
+	if settlement.SettlementStatus == dbtypes.SettlementStatusPending {
+		destinationBridgeClient, err := r.clientManager.GetClient(ctx, settlement.DestinationChainID)
+		if err != nil {
+			return nil, fmt.Errorf("getting client for settlement initiation chain %s: %w", settlement.DestinationChainID, err)
+		}
+		_, _, err = destinationBridgeClient.GetTxResult(ctx, settlement.InitiateSettlementTx.String)
+		if err != nil {
+			if errors.As(err, &cctp.ErrTxResultNotFound{}) {
+				return newSettlementRecovery(settlement, FailureCauseInitiationNotFound, fmt.Sprintf("initiate settlement tx %s was never found on chain", settlement.InitiateSettlementTx.String)), nil
+			}
+			return nil, fmt.Errorf("getting initiate settlement tx result: %w", err)
+		}
+		// the tx has landed, the order settler will move the settlement to
+		// initiated on its next verification
+		return nil, nil
+	}
+
+	if !settlement.HyperlaneTransferID.Valid {
+		// the order settler will submit the initiated settlement for relay
+		return nil, nil
+	}
+
+	transfer, err := r.db.GetHyperlaneTransfer(ctx, settlement.HyperlaneTransferID.Int64)
+	if err != nil {
+		return nil, fmt.Errorf("getting hyperlane transfer %d: %w", settlement.HyperlaneTransferID.Int64, err)
+	}
+	switch transfer.TransferStatus {
+	case dbtypes.TransferStatusAbandoned:
+		return newSettlementRecovery(settlement, FailureCauseRelayAbandoned, transfer.TransferStatusMessage.String), nil
+	case dbtypes.TransferStatusSuccess:
+		if time.Since(transfer.UpdatedAt) < r.stuckAfter {
+			// give the order settler time to verify the delivered settlement
+			return nil, nil
+		}
+		return newSettlementRecovery(settlement, FailureCauseDeliveredPayoutMissing, fmt.Sprintf("settlement message %s was delivered but the order is still unfilled on the payout chain", transfer.MessageID)), nil
+	}
+
+	return nil, nil
+}
+
+// isStale returns true if a settlement has not made progress for longer than
+// the recoverers stuck after duration.
+func (r *SettlementRecoverer) isStale(settlement db.OrderSettlement) bool {
+	lastProgress := settlement.CreatedAt
+	if settlement.InitiateSettlementTxTime.Valid {
+		lastProgress = settlement.InitiateSettlementTxTime.Time
+	}
+	return time.Since(lastProgress) > r.stuckAfter
+}
+
+// Recover applies action to the settlement in recovery. action does not have
+// to be the recommended action for the recoveries failure cause, so that
+// operators can manually override the recommendation.
+func (r *SettlementRecoverer) Recover(ctx context.Context, recovery SettlementRecovery, action RecoveryAction) error {
+	settlement := recovery.Settlement
+
+	switch action {
+	case RecoveryActionReinitiate:
+		if _, err := r.db.ResetOrderSettlement(ctx, db.ResetOrderSettlementParams{
+			SettlementStatus:                  dbtypes.SettlementStatusPending,
+			SettlementStatusMessage:           sql.NullString{String: fmt.Sprintf("reinitiating settlement after %s", recovery.Cause), Valid: true},
+			SourceChainID:                     settlement.SourceChainID,
+			OrderID:                           settlement.OrderID,
+			SourceChainGatewayContractAddress: settlement.SourceChainGatewayContractAddress,
+		}); err != nil {
+			return fmt.Errorf("resetting settlement to pending: %w", err)
+		}
+		metrics.FromContext(ctx).IncOrderSettlementStatusChange(settlement.SourceChainID, settlement.DestinationChainID, dbtypes.SettlementStatusPending)
+	case RecoveryActionRerelay:
+		if !settlement.HyperlaneTransferID.Valid {
+			return fmt.Errorf("settlement for order %s has no hyperlane transfer to re-relay", settlement.OrderID)
+		}
+		transfer, err := r.db.GetHyperlaneTransfer(ctx, settlement.HyperlaneTransferID.Int64)
+		if err != nil {
+			return fmt.Errorf("getting hyperlane transfer %d: %w", settlement.HyperlaneTransferID.Int64, err)
+		}
+		// the relay attempts that led to the transfer being abandoned should
+		// not count against the re-relay
+		if _, err := r.db.ResetHyperlaneTransferRelayAttempts(ctx, transfer.ID); err != nil {
+			return fmt.Errorf("resetting relay attempts of hyperlane transfer %d: %w", transfer.ID, err)
+		}
+		if _, err := r.db.SetMessageStatus(ctx, db.SetMessageStatusParams{
+			TransferStatus:        dbtypes.TransferStatusPending,
+			TransferStatusMessage: sql.NullString{String: fmt.Sprintf("re-relaying after %s", recovery.Cause), Valid: true},
+			SourceChainID:         transfer.SourceChainID,
+			DestinationChainID:    transfer.DestinationChainID,
+			MessageID:             transfer.MessageID,
+		}); err != nil {
+			return fmt.Errorf("resetting hyperlane transfer %d to pending: %w", transfer.ID, err)
+		}
+		metrics.FromContext(ctx).IncHyperlaneMessages(transfer.SourceChainID, transfer.DestinationChainID, dbtypes.TransferStatusPending)
+	case RecoveryActionWriteOff:
+		loss, err := settlementLoss(settlement)
+		if err != nil {
+			return err
+		}
+		if _, err := r.db.SetSettlementStatus(ctx, db.SetSettlementStatusParams{
+			SettlementStatus:                  dbtypes.SettlementStatusWrittenOff,
+			SettlementStatusMessage:           sql.NullString{String: fmt.Sprintf("written off after %s with a loss of %s uusdc", recovery.Cause, loss.String()), Valid: true},
+			SourceChainID:                     settlement.SourceChainID,
+			OrderID:                           settlement.OrderID,
+			SourceChainGatewayContractAddress: settlement.SourceChainGatewayContractAddress,
+		}); err != nil {
+			return fmt.Errorf("setting settlement status to written off: %w", err)
+		}
+		metrics.FromContext(ctx).IncOrderSettlementStatusChange(settlement.SourceChainID, settlement.DestinationChainID, dbtypes.SettlementStatusWrittenOff)
+	case RecoveryActionMarkComplete:
+		if _, err := r.db.SetSettlementStatus(ctx, db.SetSettlementStatusParams{
+			SettlementStatus:                  dbtypes.SettlementStatusComplete,
+			SourceChainID:                     settlement.SourceChainID,
+			OrderID:                           settlement.OrderID,
+			SourceChainGatewayContractAddress: settlement.SourceChainGatewayContractAddress,
+		}); err != nil {
+			return fmt.Errorf("setting settlement status to complete: %w", err)
+		}
+		metrics.FromContext(ctx).IncOrderSettlementStatusChange(settlement.SourceChainID, settlement.DestinationChainID, dbtypes.SettlementStatusComplete)
+	default:
+		return fmt.Errorf("unknown recovery action %s", action)
+	}
+
+	lmt.Logger(ctx).Info(
+		"recovered settlement",
+		zap.String("orderID", settlement.OrderID),
+		zap.String("sourceChainID", settlement.SourceChainID),
+		zap.String("destinationChainID", settlement.DestinationChainID),
+		zap.String("cause", string(recovery.Cause)),
+		zap.String("action", string(action)),
+	)
+
+	return nil
+}
+
+// RecoverAll classifies all failed and stuck settlements and applies the
+// recommended recovery action to each of them, returning the recoveries that
+// were successfully applied.
+func (r *SettlementRecoverer) RecoverAll(ctx context.Context) ([]SettlementRecovery, error) {
+	recoveries, err := r.Classify(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("classifying settlements: %w", err)
+	}
+
+	var recovered []SettlementRecovery
+	for _, recovery := range recoveries {
+		if err := r.Recover(ctx, recovery, recovery.Action); err != nil {
+			lmt.Logger(ctx).Error(
+				"failed to recover settlement",
+				zap.Error(err),
+				zap.String("orderID", recovery.Settlement.OrderID),
+				zap.String("sourceChainID", recovery.Settlement.SourceChainID),
+				zap.String("cause", string(recovery.Cause)),
+				zap.String("action", string(recovery.Action)),
+			)
+			continue
+		}
+		recovered = append(recovered, recovery)
+	}
+
+	return recovered, nil
+}
+
+func newSettlementRecovery(settlement db.OrderSettlement, cause FailureCause, reason string) *SettlementRecovery {
+	return &SettlementRecovery{
+		Settlement: settlement,
+		Cause:      cause,
+		Action:     recoveryActionsByCause[cause],
+		Reason:     reason,
+	}
+}
+
+// settlementLoss is the amount the solver fronted to fill the order, which is
+// lost if the settlement can not be paid out.
+func settlementLoss(settlement db.OrderSettlement) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(settlement.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("converting settlement amount %s to *big.Int", settlement.Amount)
+	}
+	profit, ok := new(big.Int).SetString(settlement.Profit, 10)
+	if !ok {
+		return nil, fmt.Errorf("converting settlement profit %s to *big.Int", settlement.Profit)
+	}
+	return amount.Sub(amount, profit), nil
+}
@@ -0,0 +1,214 @@
+package ordersettler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	dbtypes "github.com/skip-mev/go-fast-solver/db"
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/shared/bridges/cctp"
+	"github.com/skip-mev/go-fast-solver/shared/contracts/fast_transfer_gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	payoutChainID     = "1"
+	initiationChainID = "osmosis-1"
+	initiateTxHash    = "initiatetx"
+)
+
+// fakeBridgeClient answers the order status and tx result queries made when
+// classifying a settlement
+type fakeBridgeClient struct {
+	cctp.BridgeClient
+	orderStatus uint8
+	txResultErr error
+}
+
+func (c *fakeBridgeClient) OrderStatus(ctx context.Context, gatewayContractAddress, orderID string) (uint8, error) {
+	return c.orderStatus, nil
+}
+
+func (c *fakeBridgeClient) GetTxResult(ctx context.Context, txHash string) (*big.Int, *cctp.TxFailure, error) {
+	return big.NewInt(0), nil, c.txResultErr
+}
+
+type fakeBridgeClientManager map[string]cctp.BridgeClient
+
+func (m fakeBridgeClientManager) GetClient(ctx context.Context, chainID string) (cctp.BridgeClient, error) {
+	client, ok := m[chainID]
+	if !ok {
+		return nil, errors.New("no client")
+	}
+	return client, nil
+}
+
+// fakeRecoveryDatabase holds a single hyperlane transfer
+type fakeRecoveryDatabase struct {
+	RecoveryDatabase
+	transfer db.HyperlaneTransfer
+}
+
+func (d *fakeRecoveryDatabase) GetHyperlaneTransfer(ctx context.Context, id int64) (db.HyperlaneTransfer, error) {
+	if id != d.transfer.ID {
+		return db.HyperlaneTransfer{}, sql.ErrNoRows
+	}
+	return d.transfer, nil
+}
+
+func (d *fakeRecoveryDatabase) SetMessageStatus(ctx context.Context, arg db.SetMessageStatusParams) (db.HyperlaneTransfer, error) {
+	d.transfer.TransferStatus = arg.TransferStatus
+	d.transfer.TransferStatusMessage = arg.TransferStatusMessage
+	return d.transfer, nil
+}
+
+func (d *fakeRecoveryDatabase) ResetHyperlaneTransferRelayAttempts(ctx context.Context, id int64) (db.HyperlaneTransfer, error) {
+	d.transfer.AttemptCount = 0
+	d.transfer.NextAttemptAt = sql.NullTime{}
+	d.transfer.LastErrorClass = sql.NullString{}
+	return d.transfer, nil
+}
+
+func TestSettlementRecovererClassify(t *testing.T) {
+	stuckAfter := time.Hour
+	stale := time.Now().Add(-2 * stuckAfter)
+	recent := time.Now()
+
+	settlement := func(status string, initiatedAt time.Time, initiateTx bool, hyperlaneTransferID int64) db.OrderSettlement {
+		s := db.OrderSettlement{
+			SourceChainID:      payoutChainID,
+			DestinationChainID: initiationChainID,
+			OrderID:            "order",
+			SettlementStatus:   status,
+			CreatedAt:          initiatedAt,
+		}
+		if initiateTx {
+			s.InitiateSettlementTx = sql.NullString{String: initiateTxHash, Valid: true}
+			s.InitiateSettlementTxTime = sql.NullTime{Time: initiatedAt, Valid: true}
+		}
+		if hyperlaneTransferID != 0 {
+			s.HyperlaneTransferID = sql.NullInt64{Int64: hyperlaneTransferID, Valid: true}
+		}
+		return s
+	}
+
+	tests := []struct {
+		name        string
+		settlement  db.OrderSettlement
+		orderStatus uint8
+		txResultErr error
+		transfer    db.HyperlaneTransfer
+		expCause    FailureCause
+		expAction   RecoveryAction
+	}{
+		{
+			name:       "recent pending settlement is not stuck",
+			settlement: settlement(dbtypes.SettlementStatusPending, recent, true, 0),
+		},
+		{
+			name:        "filled order is already settled",
+			settlement:  settlement(dbtypes.SettlementStatusFailed, recent, true, 0),
+			orderStatus: fast_transfer_gateway.OrderStatusFilled,
+			expCause:    FailureCauseAlreadySettled,
+			expAction:   RecoveryActionMarkComplete,
+		},
+		{
+			name:        "refunded order is written off",
+			settlement:  settlement(dbtypes.SettlementStatusSettlementInitiated, stale, true, 1),
+			orderStatus: fast_transfer_gateway.OrderStatusRefunded,
+			expCause:    FailureCauseOrderRefunded,
+			expAction:   RecoveryActionWriteOff,
+		},
+		{
+			name:       "failed settlement is reinitiated",
+			settlement: settlement(dbtypes.SettlementStatusFailed, recent, true, 0),
+			expCause:   FailureCauseInitiationReverted,
+			expAction:  RecoveryActionReinitiate,
+		},
+		{
+			name:       "stale settlement waiting to be batched is not stuck",
+			settlement: settlement(dbtypes.SettlementStatusPending, stale, false, 0),
+		},
+		{
+			name:        "initiation tx that never landed is reinitiated",
+			settlement:  settlement(dbtypes.SettlementStatusPending, stale, true, 0),
+			txResultErr: cctp.ErrTxResultNotFound{TxHash: initiateTxHash},
+			expCause:    FailureCauseInitiationNotFound,
+			expAction:   RecoveryActionReinitiate,
+		},
+		{
+			name:       "landed initiation tx is not stuck",
+			settlement: settlement(dbtypes.SettlementStatusPending, stale, true, 0),
+		},
+		{
+			name:       "abandoned relay is re-relayed",
+			settlement: settlement(dbtypes.SettlementStatusSettlementInitiated, stale, true, 1),
+			transfer:   db.HyperlaneTransfer{ID: 1, TransferStatus: dbtypes.TransferStatusAbandoned},
+			expCause:   FailureCauseRelayAbandoned,
+			expAction:  RecoveryActionRerelay,
+		},
+		{
+			name:       "pending relay is not stuck",
+			settlement: settlement(dbtypes.SettlementStatusSettlementInitiated, stale, true, 1),
+			transfer:   db.HyperlaneTransfer{ID: 1, TransferStatus: dbtypes.TransferStatusPending},
+		},
+		{
+			name:       "recently delivered settlement is not stuck",
+			settlement: settlement(dbtypes.SettlementStatusSettlementInitiated, stale, true, 1),
+			transfer:   db.HyperlaneTransfer{ID: 1, TransferStatus: dbtypes.TransferStatusSuccess, UpdatedAt: recent},
+		},
+		{
+			name:       "delivered settlement that was never paid out is reinitiated",
+			settlement: settlement(dbtypes.SettlementStatusSettlementInitiated, stale, true, 1),
+			transfer:   db.HyperlaneTransfer{ID: 1, TransferStatus: dbtypes.TransferStatusSuccess, UpdatedAt: stale},
+			expCause:   FailureCauseDeliveredPayoutMissing,
+			expAction:  RecoveryActionReinitiate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := fakeBridgeClientManager{
+				payoutChainID:     &fakeBridgeClient{orderStatus: tt.orderStatus},
+				initiationChainID: &fakeBridgeClient{txResultErr: tt.txResultErr},
+			}
+			recoverer := NewSettlementRecoverer(&fakeRecoveryDatabase{transfer: tt.transfer}, clients, stuckAfter)
+
+			recovery, err := recoverer.classify(context.Background(), tt.settlement)
+			require.NoError(t, err)
+			if tt.expCause == "" {
+				assert.Nil(t, recovery)
+				return
+			}
+			require.NotNil(t, recovery)
+			assert.Equal(t, tt.expCause, recovery.Cause)
+			assert.Equal(t, tt.expAction, recovery.Action)
+		})
+	}
+}
+
+func TestSettlementRecovererRerelayResetsRelayAttempts(t *testing.T) {
+	database := &fakeRecoveryDatabase{transfer: db.HyperlaneTransfer{
+		ID:             1,
+		TransferStatus: dbtypes.TransferStatusAbandoned,
+		AttemptCount:   3,
+		NextAttemptAt:  sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		LastErrorClass: sql.NullString{String: dbtypes.RelayErrorClassReverted, Valid: true},
+	}}
+	recoverer := NewSettlementRecoverer(database, fakeBridgeClientManager{}, time.Hour)
+
+	err := recoverer.Recover(context.Background(), SettlementRecovery{
+		Settlement: db.OrderSettlement{HyperlaneTransferID: sql.NullInt64{Int64: 1, Valid: true}},
+		Cause:      FailureCauseRelayAbandoned,
+	}, RecoveryActionRerelay)
+	require.NoError(t, err)
+
+	assert.Equal(t, dbtypes.TransferStatusPending, database.transfer.TransferStatus)
+	assert.Zero(t, database.transfer.AttemptCount)
+	assert.False(t, database.transfer.NextAttemptAt.Valid)
+	assert.False(t, database.transfer.LastErrorClass.Valid)
+}
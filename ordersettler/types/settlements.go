@@ -2,8 +2,10 @@ package types
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/skip-mev/go-fast-solver/db/gen/db"
@@ -104,19 +106,29 @@ func (b SettlementBatch) DestinationGatewayContractAddress(ctx context.Context)
 	return addr, nil
 }
 
+// RepaymentAddress returns the 32 byte padded address that the batch should
+// be repaid to on its payout chain (the orders source chain). If a repayment
+// address has already been chosen for the batch via WithRepaymentAddress,
+// that address is used, otherwise the repayment address is resolved from the
+// payout chains config at the current time.
 func (b SettlementBatch) RepaymentAddress(ctx context.Context) ([]byte, error) {
 	sourceChainConfig, err := b.SourceChainConfig(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("getting source chain config: %w", err)
 	}
 
+	address := b[0].RepaymentAddress.String
+	if !b[0].RepaymentAddress.Valid {
+		address, err = b.ResolveRepaymentAddress(ctx, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("resolving repayment address: %w", err)
+		}
+	}
+
 	var repaymentAddress []byte
 	switch sourceChainConfig.Type {
 	case config.ChainType_EVM:
-		if sourceChainConfig.SolverAddress == "" {
-			return nil, fmt.Errorf("solver address not set for chain %s", sourceChainConfig.ChainID)
-		}
-		repaymentAddress = common.BytesToHash(common.HexToAddress(sourceChainConfig.SolverAddress).Bytes()).Bytes()
+		repaymentAddress = common.BytesToHash(common.HexToAddress(address).Bytes()).Bytes()
 	default:
 		return nil, fmt.Errorf("unsupported destination chain type %s for settlement", sourceChainConfig.Type)
 	}
@@ -124,6 +136,34 @@ func (b SettlementBatch) RepaymentAddress(ctx context.Context) ([]byte, error) {
 	return repaymentAddress, nil
 }
 
+// ResolveRepaymentAddress chooses the address on the payout chain that the
+// batch should be repaid to, based on the payout chains configured
+// settlement repayment rules, the total value of the batch, and the time the
+// batch is being initiated at.
+func (b SettlementBatch) ResolveRepaymentAddress(ctx context.Context, at time.Time) (string, error) {
+	value, err := b.TotalValue()
+	if err != nil {
+		return "", fmt.Errorf("getting settlement batch total value: %w", err)
+	}
+
+	address, err := config.GetConfigReader(ctx).GetSettlementRepaymentAddress(b.SourceChainID(), value, at)
+	if err != nil {
+		return "", fmt.Errorf("getting settlement repayment address for chain %s: %w", b.SourceChainID(), err)
+	}
+	return address, nil
+}
+
+// WithRepaymentAddress returns a copy of the batch where every settlement is
+// pinned to repay to address.
+func (b SettlementBatch) WithRepaymentAddress(address string) SettlementBatch {
+	pinned := make(SettlementBatch, len(b))
+	for i, settlement := range b {
+		settlement.RepaymentAddress = sql.NullString{String: address, Valid: true}
+		pinned[i] = settlement
+	}
+	return pinned
+}
+
 func (b SettlementBatch) TotalValue() (*big.Int, error) {
 	sum := big.NewInt(0)
 	for _, settlement := range b {
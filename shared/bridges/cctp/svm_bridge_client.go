@@ -0,0 +1,167 @@
+package cctp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/skip-mev/go-fast-solver/db/gen/db"
+	"github.com/skip-mev/go-fast-solver/ordersettler/types"
+	"github.com/skip-mev/go-fast-solver/shared/contracts/fast_transfer_gateway"
+	"github.com/skip-mev/go-fast-solver/shared/signing"
+	"github.com/skip-mev/go-fast-solver/shared/svmrpc"
+	"github.com/skip-mev/go-fast-solver/shared/txexecutor/svm"
+)
+
+// SVMBridgeClient reads balances and submits txs on Solana Virtual Machine
+// based chains. Orders are not filled or settled on svm chains yet, so only
+// the methods needed to hold inventory on them are supported.
+type SVMBridgeClient struct {
+	client svmrpc.SVMChainRPC
+
+	chainID    string
+	signer     signing.Signer
+	txExecutor svm.SVMTxExecutor
+}
+
+var _ BridgeClient = (*SVMBridgeClient)(nil)
+
+func NewSVMBridgeClient(
+	client svmrpc.SVMChainRPC,
+	chainID string,
+	signer signing.Signer,
+	txExecutor svm.SVMTxExecutor,
+) (*SVMBridgeClient, error) {
+	if signer == nil {
+		signer = signing.NewNopSigner()
+	}
+
+	return &SVMBridgeClient{
+		client:     client,
+		chainID:    chainID,
+		signer:     signer,
+		txExecutor: txExecutor,
+	}, nil
+}
+
+// Balance gets the balance of the spl token with mint denom held in the
+// associated token account of address
+func (c *SVMBridgeClient) Balance(ctx context.Context, address, denom string) (*big.Int, error) {
+	owner, err := solana.PublicKeyFromBase58(address)
+	if err != nil {
+		return nil, fmt.Errorf("decoding address %s: %w", address, err)
+	}
+	mint, err := solana.PublicKeyFromBase58(denom)
+	if err != nil {
+		return nil, fmt.Errorf("decoding mint %s: %w", denom, err)
+	}
+	tokenAccount, _, err := solana.FindAssociatedTokenAddress(owner, mint)
+	if err != nil {
+		return nil, fmt.Errorf("finding associated token account of %s for mint %s: %w", address, denom, err)
+	}
+	return c.client.GetTokenBalance(ctx, tokenAccount)
+}
+
+// SignerGasTokenBalance gets the lamport balance of the signer
+func (c *SVMBridgeClient) SignerGasTokenBalance(ctx context.Context) (*big.Int, error) {
+	balance, err := c.client.GetBalance(ctx, solana.PublicKeyFromBytes(c.signer.Address()))
+	if err != nil {
+		return nil, fmt.Errorf("querying lamport balance: %w", err)
+	}
+	return balance, nil
+}
+
+// SubmitTx signs tx with the signer and any additionalSigners and submits it,
+// waiting for it to land
+func (c *SVMBridgeClient) SubmitTx(ctx context.Context, tx *solana.Transaction, additionalSigners ...solana.PrivateKey) (string, string, error) {
+	return c.txExecutor.ExecuteTx(ctx, c.chainID, tx, c.signer, additionalSigners...)
+}
+
+// GetTxResult gets the fee in lamports paid by a landed tx, and the reason it
+// failed if it did not succeed
+func (c *SVMBridgeClient) GetTxResult(ctx context.Context, txHash string) (*big.Int, *TxFailure, error) {
+	signature, err := solana.SignatureFromBase58(txHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding tx signature %s: %w", txHash, err)
+	}
+	_, meta, err := c.client.GetTx(ctx, signature)
+	if err != nil {
+		if errors.Is(err, rpc.ErrNotFound) {
+			return nil, nil, ErrTxResultNotFound{TxHash: txHash}
+		}
+		return nil, nil, err
+	}
+	if meta == nil {
+		return nil, nil, errors.New("tx meta is nil")
+	}
+	fee := new(big.Int).SetUint64(meta.Fee)
+	if meta.Err != nil {
+		return fee, &TxFailure{fmt.Sprintf("%v", meta.Err)}, nil
+	}
+	return fee, nil, nil
+}
+
+func (c *SVMBridgeClient) WaitForTx(ctx context.Context, txHash string) error {
+	signature, err := solana.SignatureFromBase58(txHash)
+	if err != nil {
+		return fmt.Errorf("decoding tx signature %s: %w", txHash, err)
+	}
+	return retry.Do(func() error {
+		_, _, err := c.client.GetTx(ctx, signature)
+		return err
+	}, retry.Context(ctx), retry.Delay(1*time.Second), retry.MaxDelay(5*time.Second), retry.Attempts(20))
+}
+
+// BlockHeight gets the latest slot, since unlike block heights slots are
+// what svm chains are indexed by
+func (c *SVMBridgeClient) BlockHeight(ctx context.Context) (uint64, error) {
+	return c.client.GetSlot(ctx)
+}
+
+func (c *SVMBridgeClient) FillOrder(ctx context.Context, order db.Order, gatewayContractAddress string) (string, string, *uint64, error) {
+	return "", "", nil, errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) InitiateTimeout(ctx context.Context, order db.Order, gatewayContractAddress string) (string, string, *uint64, error) {
+	return "", "", nil, errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) InitiateBatchSettlement(ctx context.Context, batch types.SettlementBatch) (string, string, error) {
+	return "", "", errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) IsSettlementComplete(ctx context.Context, gatewayContractAddress, orderID string) (bool, error) {
+	return false, errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) OrderFillsByFiller(ctx context.Context, gatewayContractAddress, fillerAddress string) ([]Fill, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) QueryOrderFillEvent(ctx context.Context, gatewayContractAddress, orderID string) (*OrderFillEvent, time.Time, error) {
+	return nil, time.Time{}, errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) OrderExists(ctx context.Context, gatewayContractAddress, orderID string, blockNumber *big.Int) (bool, *big.Int, error) {
+	return false, nil, errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) IsOrderRefunded(ctx context.Context, gatewayContractAddress, orderID string) (bool, string, error) {
+	return false, "", errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) OrderStatus(ctx context.Context, gatewayContractAddress, orderID string) (uint8, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) QueryOrderSubmittedEvent(ctx context.Context, gatewayContractAddress, orderID string) (*fast_transfer_gateway.FastTransferOrder, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *SVMBridgeClient) Close() {}
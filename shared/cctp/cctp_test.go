@@ -0,0 +1,290 @@
+package cctp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/skip-mev/go-fast-solver/shared/cctp"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/signing"
+	"github.com/skip-mev/go-fast-solver/shared/svm/contracts/cctp/message_transmitter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageSentFromReceipt(t *testing.T) {
+	messageTransmitter := common.HexToAddress("0x0a992d191DEeC32aFe36203Ad87D7d289a738F81")
+	message := []byte("cctp burn message")
+
+	sent, err := cctp.MessageSentLog(messageTransmitter, message)
+	require.NoError(t, err)
+	// the same event emitted by another contract must be ignored
+	spoofed, err := cctp.MessageSentLog(common.HexToAddress("0x1"), []byte("spoofed"))
+	require.NoError(t, err)
+
+	decoded, err := cctp.MessageSentFromReceipt(&types.Receipt{Logs: []*types.Log{spoofed, sent}}, messageTransmitter)
+	require.NoError(t, err)
+	assert.Equal(t, message, decoded)
+
+	_, err = cctp.MessageSentFromReceipt(&types.Receipt{Logs: []*types.Log{spoofed}}, messageTransmitter)
+	assert.Error(t, err)
+}
+
+func TestPackDepositForBurn(t *testing.T) {
+	recipient, err := cctp.MintRecipient(config.ChainType_EVM, "0x8EB49E3D65d74967CC0Fe987FA2d015ae816352E")
+	require.NoError(t, err)
+
+	data, err := cctp.PackDepositForBurn(big.NewInt(100), 3, recipient, common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"))
+	require.NoError(t, err)
+	// depositForBurn(uint256,uint32,bytes32,address)
+	assert.Equal(t, []byte{0x6f, 0xd3, 0x50, 0x4e}, data[:4])
+	assert.Len(t, data, 4+4*32)
+}
+
+func TestMintRecipient(t *testing.T) {
+	recipient, err := cctp.MintRecipient(config.ChainType_EVM, "0x8EB49E3D65d74967CC0Fe987FA2d015ae816352E")
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, 12), recipient[:12])
+	assert.Equal(t, common.HexToAddress("0x8EB49E3D65d74967CC0Fe987FA2d015ae816352E").Bytes(), recipient[12:])
+
+	address, err := bech32.ConvertAndEncode("noble", common.HexToAddress("0x8EB49E3D65d74967CC0Fe987FA2d015ae816352E").Bytes())
+	require.NoError(t, err)
+	recipient, err = cctp.MintRecipient(config.ChainType_COSMOS, address)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x8EB49E3D65d74967CC0Fe987FA2d015ae816352E").Bytes(), recipient[12:])
+
+	tokenAccount := solana.NewWallet().PublicKey()
+	recipient, err = cctp.MintRecipient(config.ChainType_SVM, tokenAccount.String())
+	require.NoError(t, err)
+	assert.Equal(t, tokenAccount.Bytes(), recipient[:])
+
+	_, err = cctp.MintRecipient(config.ChainType_EVM, "noble1")
+	assert.Error(t, err)
+}
+
+func TestNobleMsgs(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	cctp.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+	txConfig := authtx.NewTxConfig(cdc, authtx.DefaultSignModes)
+
+	privateKey := secp256k1.GenPrivKey()
+	from, err := bech32.ConvertAndEncode("noble", privateKey.PubKey().Address())
+	require.NoError(t, err)
+	deposit := &cctp.MsgDepositForBurn{
+		From:              from,
+		Amount:            "1000000",
+		DestinationDomain: 3,
+		MintRecipient:     make([]byte, 32),
+		BurnToken:         "uusdc",
+	}
+	assert.Equal(t, "/circle.cctp.v1.MsgDepositForBurn", sdk.MsgTypeURL(deposit))
+
+	// msgs are passed to the fund rebalancer as json, the same as skip go txs
+	msgJSON, err := cdc.MarshalJSON(deposit)
+	require.NoError(t, err)
+	resolved, err := registry.Resolve(sdk.MsgTypeURL(deposit))
+	require.NoError(t, err)
+	require.NoError(t, cdc.UnmarshalJSON(msgJSON, resolved))
+	assert.Equal(t, deposit, resolved)
+
+	txBuilder := txConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(resolved, &cctp.MsgReceiveMessage{From: from, Message: []byte("message"), Attestation: []byte("attestation")}))
+	signed, err := signing.SignCosmosTx(context.Background(), privateKey, txConfig, "noble-1", 1, 0, txBuilder.GetTx(), from)
+	require.NoError(t, err)
+
+	txBytes, err := txConfig.TxEncoder()(signed)
+	require.NoError(t, err)
+	assert.NotEmpty(t, txBytes)
+	txJSON, err := txConfig.TxJSONEncoder()(signed)
+	require.NoError(t, err)
+	assert.Contains(t, string(txJSON), `"destination_domain":3`)
+}
+
+func TestMessageSentFromEvents(t *testing.T) {
+	message, err := cctp.MessageSentFromEvents([]abci.Event{
+		{Type: "message", Attributes: []abci.EventAttribute{{Key: "action", Value: "/circle.cctp.v1.MsgDepositForBurn"}}},
+		{Type: "circle.cctp.v1.MessageSent", Attributes: []abci.EventAttribute{{Key: "message", Value: `"Y2N0cCBidXJuIG1lc3NhZ2U="`}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("cctp burn message"), message)
+
+	_, err = cctp.MessageSentFromEvents(nil)
+	assert.Error(t, err)
+}
+
+var (
+	svmTokenMessengerMinter = solana.MustPublicKeyFromBase58("CCTPiPYPc6AsJuwueEnWgSgucamXDZwBd53dQ11YiKX3")
+	svmMessageTransmitter   = solana.MustPublicKeyFromBase58("CCTPmbSD7gX1bxKPAmg77w8oFzNFpaQiQUWD43TKaecd")
+	svmUSDCMint             = solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+)
+
+func TestSVMDepositForBurnInstruction(t *testing.T) {
+	owner := solana.NewWallet().PublicKey()
+	eventAccount := solana.NewWallet().PublicKey()
+	recipient, err := cctp.MintRecipient(config.ChainType_EVM, "0x8EB49E3D65d74967CC0Fe987FA2d015ae816352E")
+	require.NoError(t, err)
+
+	instruction, err := cctp.SVMDepositForBurnInstruction(svmTokenMessengerMinter, svmMessageTransmitter, owner, svmUSDCMint, eventAccount, 100, 3, recipient)
+	require.NoError(t, err)
+	assert.Equal(t, svmTokenMessengerMinter, instruction.ProgramID())
+
+	ownerTokenAccount, _, err := solana.FindAssociatedTokenAddress(owner, svmUSDCMint)
+	require.NoError(t, err)
+	accounts := instruction.Accounts()
+	require.Len(t, accounts, 17)
+	assert.Equal(t, ownerTokenAccount, accounts[3].PublicKey)
+	assert.True(t, accounts[3].IsWritable)
+
+	tx, err := solana.NewTransaction([]solana.Instruction{instruction}, solana.Hash{}, solana.TransactionPayer(owner))
+	require.NoError(t, err)
+	// the event account and the owner both sign the burn
+	assert.Equal(t, uint8(2), tx.Message.Header.NumRequiredSignatures)
+
+	found, err := cctp.SVMMessageSentEventAccount(tx, svmTokenMessengerMinter)
+	require.NoError(t, err)
+	assert.Equal(t, eventAccount, found)
+
+	_, err = cctp.SVMMessageSentEventAccount(tx, svmMessageTransmitter)
+	assert.Error(t, err)
+}
+
+func TestSVMReceiveMessageInstruction(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	recipientTokenAccount := solana.NewWallet().PublicKey()
+
+	message := func(sourceDomain uint32, nonce uint64) []byte {
+		message := make([]byte, 248)
+		binary.BigEndian.PutUint32(message[4:], sourceDomain)
+		binary.BigEndian.PutUint32(message[8:], 5)
+		binary.BigEndian.PutUint64(message[12:], nonce)
+		return message
+	}
+	usedNonces := func(seeds ...string) solana.PublicKey {
+		var seedBytes [][]byte
+		for _, seed := range seeds {
+			seedBytes = append(seedBytes, []byte(seed))
+		}
+		address, _, err := solana.FindProgramAddress(seedBytes, svmMessageTransmitter)
+		require.NoError(t, err)
+		return address
+	}
+
+	instruction, err := cctp.SVMReceiveMessageInstruction(svmTokenMessengerMinter, svmMessageTransmitter, payer, svmUSDCMint, recipientTokenAccount, message(0, 6401), []byte("attestation"))
+	require.NoError(t, err)
+	assert.Equal(t, svmMessageTransmitter, instruction.ProgramID())
+	accounts := instruction.Accounts()
+	require.Len(t, accounts, 19)
+	assert.Equal(t, usedNonces("used_nonces", "0", "6401"), accounts[4].PublicKey)
+	assert.Equal(t, recipientTokenAccount, accounts[14].PublicKey)
+	assert.True(t, accounts[14].IsWritable)
+
+	// used nonces seeds of two digit domains are delimited
+	instruction, err = cctp.SVMReceiveMessageInstruction(svmTokenMessengerMinter, svmMessageTransmitter, payer, svmUSDCMint, recipientTokenAccount, message(11, 6400), []byte("attestation"))
+	require.NoError(t, err)
+	assert.Equal(t, usedNonces("used_nonces", "11", "-", "1"), instruction.Accounts()[4].PublicKey)
+
+	_, err = cctp.SVMReceiveMessageInstruction(svmTokenMessengerMinter, svmMessageTransmitter, payer, svmUSDCMint, recipientTokenAccount, []byte("short"), nil)
+	assert.Error(t, err)
+}
+
+func TestMessageSentFromSVMAccount(t *testing.T) {
+	var data bytes.Buffer
+	require.NoError(t, bin.NewBorshEncoder(&data).Encode(message_transmitter.MessageSent{
+		RentPayer: solana.NewWallet().PublicKey(),
+		Message:   []byte("cctp burn message"),
+	}))
+
+	message, err := cctp.MessageSentFromSVMAccount(data.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("cctp burn message"), message)
+
+	_, err = cctp.MessageSentFromSVMAccount([]byte("not a message sent account"))
+	assert.Error(t, err)
+}
+
+func TestMessageNonce(t *testing.T) {
+	message := make([]byte, 248)
+	binary.BigEndian.PutUint32(message[4:], 6)
+	binary.BigEndian.PutUint64(message[12:], 42)
+
+	sourceDomain, nonce, err := cctp.MessageNonce(message)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(6), sourceDomain)
+	assert.Equal(t, uint64(42), nonce)
+
+	_, _, err = cctp.MessageNonce([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestUsedNonces(t *testing.T) {
+	data, err := cctp.PackUsedNonces(6, 42)
+	require.NoError(t, err)
+	// usedNonces(bytes32) keyed by keccak256(abi.encodePacked(sourceDomain, nonce))
+	assert.Equal(t, crypto.Keccak256([]byte("usedNonces(bytes32)"))[:4], data[:4])
+	assert.Equal(t, crypto.Keccak256([]byte{0, 0, 0, 6, 0, 0, 0, 0, 0, 0, 0, 42}), data[4:])
+
+	used, err := cctp.UnpackUsedNonces(common.LeftPadBytes([]byte{1}, 32))
+	require.NoError(t, err)
+	assert.True(t, used)
+	used, err = cctp.UnpackUsedNonces(make([]byte, 32))
+	require.NoError(t, err)
+	assert.False(t, used)
+}
+
+func TestNobleNonceUsed(t *testing.T) {
+	request, err := proto.Marshal(&cctp.QueryGetUsedNonceRequest{SourceDomain: 3, Nonce: 42})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x08, 3, 0x10, 42}, request)
+
+	used, err := cctp.NobleNonceUsed(abci.ResponseQuery{Value: []byte("nonce")})
+	require.NoError(t, err)
+	assert.True(t, used)
+	used, err = cctp.NobleNonceUsed(abci.ResponseQuery{Codespace: sdkerrors.ErrKeyNotFound.Codespace(), Code: sdkerrors.ErrKeyNotFound.ABCICode(), Log: "not found"})
+	require.NoError(t, err)
+	assert.False(t, used)
+	_, err = cctp.NobleNonceUsed(abci.ResponseQuery{Codespace: "sdk", Code: 1, Log: "internal"})
+	assert.Error(t, err)
+}
+
+func TestSVMNonceUsed(t *testing.T) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte("used_nonces"), []byte("0"), nil, []byte("6401")}, svmMessageTransmitter)
+	require.NoError(t, err)
+	account, err := cctp.SVMUsedNoncesAccount(svmMessageTransmitter, 0, 6465)
+	require.NoError(t, err)
+	assert.Equal(t, address, account)
+
+	// nonce 6465 is the first bit of the second word of the account
+	usedNonces := message_transmitter.UsedNonces{RemoteDomain: 0, FirstNonce: 6401}
+	usedNonces.UsedNonces[1] = 1
+	var data bytes.Buffer
+	require.NoError(t, bin.NewBorshEncoder(&data).Encode(usedNonces))
+
+	used, err := cctp.SVMNonceUsed(data.Bytes(), 6465)
+	require.NoError(t, err)
+	assert.True(t, used)
+	used, err = cctp.SVMNonceUsed(data.Bytes(), 6466)
+	require.NoError(t, err)
+	assert.False(t, used)
+	_, err = cctp.SVMNonceUsed(data.Bytes(), 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package cctp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const tokenMessengerABI = `[{"inputs":[{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint32","name":"destinationDomain","type":"uint32"},{"internalType":"bytes32","name":"mintRecipient","type":"bytes32"},{"internalType":"address","name":"burnToken","type":"address"}],"name":"depositForBurn","outputs":[{"internalType":"uint64","name":"_nonce","type":"uint64"}],"stateMutability":"nonpayable","type":"function"}]`
+
+const messageTransmitterABI = `[{"inputs":[{"internalType":"bytes","name":"message","type":"bytes"},{"internalType":"bytes","name":"attestation","type":"bytes"}],"name":"receiveMessage","outputs":[{"internalType":"bool","name":"success","type":"bool"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"name":"usedNonces","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"bytes","name":"message","type":"bytes"}],"name":"MessageSent","type":"event"}]`
+
+var (
+	tokenMessenger     = mustParseABI(tokenMessengerABI)
+	messageTransmitter = mustParseABI(messageTransmitterABI)
+)
+
+func mustParseABI(json string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(json))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// PackDepositForBurn packs calldata for TokenMessenger.depositForBurn, which
+// burns amount of burnToken to be minted to mintRecipient on the chain with
+// CCTP domain destinationDomain
+func PackDepositForBurn(amount *big.Int, destinationDomain uint32, mintRecipient [32]byte, burnToken common.Address) ([]byte, error) {
+	return tokenMessenger.Pack("depositForBurn", amount, destinationDomain, mintRecipient, burnToken)
+}
+
+// PackReceiveMessage packs calldata for MessageTransmitter.receiveMessage,
+// which mints the usdc burned by an attested burn message
+func PackReceiveMessage(message, attestation []byte) ([]byte, error) {
+	return messageTransmitter.Pack("receiveMessage", message, attestation)
+}
+
+// PackUsedNonces packs calldata for MessageTransmitter.usedNonces, which
+// returns a non zero value once the message with nonce from sourceDomain has
+// been received
+func PackUsedNonces(sourceDomain uint32, nonce uint64) ([]byte, error) {
+	key := crypto.Keccak256Hash(binary.BigEndian.AppendUint32(nil, sourceDomain), binary.BigEndian.AppendUint64(nil, nonce))
+	return messageTransmitter.Pack("usedNonces", [32]byte(key))
+}
+
+// UnpackUsedNonces unpacks the result of MessageTransmitter.usedNonces into
+// whether the nonce has been used
+func UnpackUsedNonces(data []byte) (bool, error) {
+	values, err := messageTransmitter.Unpack("usedNonces", data)
+	if err != nil {
+		return false, fmt.Errorf("unpacking usedNonces result: %w", err)
+	}
+	used, ok := values[0].(*big.Int)
+	if !ok {
+		return false, fmt.Errorf("unexpected usedNonces result type %T", values[0])
+	}
+	return used.Sign() != 0, nil
+}
+
+// MessageSentFromReceipt gets the CCTP message emitted by the
+// MessageTransmitter contract at messageTransmitterAddress in a burn tx
+func MessageSentFromReceipt(receipt *types.Receipt, messageTransmitterAddress common.Address) ([]byte, error) {
+	event := messageTransmitter.Events["MessageSent"]
+	for _, log := range receipt.Logs {
+		if log.Address != messageTransmitterAddress || len(log.Topics) == 0 || log.Topics[0] != event.ID {
+			continue
+		}
+		values, err := event.Inputs.Unpack(log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("unpacking MessageSent event: %w", err)
+		}
+		message, ok := values[0].([]byte)
+		if !ok {
+			return nil, fmt.Errorf("unexpected MessageSent event message type %T", values[0])
+		}
+		return message, nil
+	}
+	return nil, fmt.Errorf("no MessageSent event emitted by %s in tx %s", messageTransmitterAddress.Hex(), receipt.TxHash.Hex())
+}
+
+// MessageSentLog builds the log emitted by the MessageTransmitter contract at
+// messageTransmitterAddress when message is sent
+func MessageSentLog(messageTransmitterAddress common.Address, message []byte) (*types.Log, error) {
+	event := messageTransmitter.Events["MessageSent"]
+	data, err := event.Inputs.Pack(message)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Log{Address: messageTransmitterAddress, Topics: []common.Hash{event.ID}, Data: data}, nil
+}
+
+// MessageHash is the hash that attestations for message are looked up by
+func MessageHash(message []byte) common.Hash {
+	return crypto.Keccak256Hash(message)
+}
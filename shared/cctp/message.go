@@ -0,0 +1,25 @@
+package cctp
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// offsets of the fields read from cctp messages. a message is a 116 byte
+	// header followed by the burn message body.
+	messageSourceDomainOffset = 4
+	messageNonceOffset        = 12
+	messageBodyOffset         = 116
+	burnMessageBurnTokenStart = messageBodyOffset + 4
+	burnMessageBurnTokenEnd   = burnMessageBurnTokenStart + 32
+)
+
+// MessageNonce gets the source domain and nonce of a cctp message, which
+// together identify the message on its destination chain
+func MessageNonce(message []byte) (sourceDomain uint32, nonce uint64, err error) {
+	if len(message) < messageBodyOffset {
+		return 0, 0, fmt.Errorf("message of length %d is too short to have a header", len(message))
+	}
+	return binary.BigEndian.Uint32(message[messageSourceDomainOffset:]), binary.BigEndian.Uint64(message[messageNonceOffset:]), nil
+}
@@ -0,0 +1,120 @@
+package cctp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+const (
+	nobleMessageSentEventType = "circle.cctp.v1.MessageSent"
+	// NobleUsedNonceQueryPath is the abci query path of Noble's cctp module
+	// query for whether a nonce has been used
+	NobleUsedNonceQueryPath = "/circle.cctp.v1.Query/UsedNonce"
+)
+
+// MsgDepositForBurn burns usdc on Noble to be minted on the chain with CCTP
+// domain DestinationDomain. It mirrors circle.cctp.v1.MsgDepositForBurn from
+// Noble's cctp module.
+type MsgDepositForBurn struct {
+	From              string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Amount            string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount"`
+	DestinationDomain uint32 `protobuf:"varint,3,opt,name=destination_domain,json=destinationDomain,proto3" json:"destination_domain,omitempty"`
+	MintRecipient     []byte `protobuf:"bytes,4,opt,name=mint_recipient,json=mintRecipient,proto3" json:"mint_recipient,omitempty"`
+	BurnToken         string `protobuf:"bytes,5,opt,name=burn_token,json=burnToken,proto3" json:"burn_token,omitempty"`
+}
+
+func (m *MsgDepositForBurn) Reset()                { *m = MsgDepositForBurn{} }
+func (m *MsgDepositForBurn) String() string        { return proto.CompactTextString(m) }
+func (*MsgDepositForBurn) ProtoMessage()           {}
+func (*MsgDepositForBurn) XXX_MessageName() string { return "circle.cctp.v1.MsgDepositForBurn" }
+
+// MsgReceiveMessage mints usdc on Noble for an attested burn message. It
+// mirrors circle.cctp.v1.MsgReceiveMessage from Noble's cctp module.
+type MsgReceiveMessage struct {
+	From        string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Message     []byte `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Attestation []byte `protobuf:"bytes,3,opt,name=attestation,proto3" json:"attestation,omitempty"`
+}
+
+func (m *MsgReceiveMessage) Reset()                { *m = MsgReceiveMessage{} }
+func (m *MsgReceiveMessage) String() string        { return proto.CompactTextString(m) }
+func (*MsgReceiveMessage) ProtoMessage()           {}
+func (*MsgReceiveMessage) XXX_MessageName() string { return "circle.cctp.v1.MsgReceiveMessage" }
+
+// QueryGetUsedNonceRequest queries whether the message with Nonce from
+// SourceDomain has been received on Noble. It mirrors
+// circle.cctp.v1.QueryGetUsedNonceRequest from Noble's cctp module.
+type QueryGetUsedNonceRequest struct {
+	SourceDomain uint32 `protobuf:"varint,1,opt,name=source_domain,json=sourceDomain,proto3" json:"source_domain,omitempty"`
+	Nonce        uint64 `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (m *QueryGetUsedNonceRequest) Reset()         { *m = QueryGetUsedNonceRequest{} }
+func (m *QueryGetUsedNonceRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryGetUsedNonceRequest) ProtoMessage()    {}
+func (*QueryGetUsedNonceRequest) XXX_MessageName() string {
+	return "circle.cctp.v1.QueryGetUsedNonceRequest"
+}
+
+// NobleNonceUsed gets whether a nonce has been used from the response to a
+// QueryGetUsedNonceRequest. Noble's cctp module responds with a not found
+// error for nonces that have not been used.
+func NobleNonceUsed(response abci.ResponseQuery) (bool, error) {
+	switch {
+	case response.Code == 0:
+		return true, nil
+	case response.Codespace == sdkerrors.ErrKeyNotFound.Codespace() && response.Code == sdkerrors.ErrKeyNotFound.ABCICode():
+		return false, nil
+	default:
+		return false, fmt.Errorf("%s error, code: %d, log: %s", response.Codespace, response.Code, response.Log)
+	}
+}
+
+func init() {
+	proto.RegisterType((*MsgDepositForBurn)(nil), "circle.cctp.v1.MsgDepositForBurn")
+	proto.RegisterType((*MsgReceiveMessage)(nil), "circle.cctp.v1.MsgReceiveMessage")
+	proto.RegisterType((*QueryGetUsedNonceRequest)(nil), "circle.cctp.v1.QueryGetUsedNonceRequest")
+}
+
+// RegisterInterfaces registers Noble's cctp msgs with registry so that they
+// can be decoded and signed
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgDepositForBurn{},
+		&MsgReceiveMessage{},
+	)
+}
+
+// MessageSentFromEvents gets the CCTP message sent by a burn tx on Noble from
+// the tx's events
+func MessageSentFromEvents(events []abci.Event) ([]byte, error) {
+	for _, event := range events {
+		if event.Type != nobleMessageSentEventType {
+			continue
+		}
+		for _, attribute := range event.Attributes {
+			if attribute.Key != "message" {
+				continue
+			}
+			// typed events json encode their attributes, so the message is a
+			// quoted base64 string
+			var encoded string
+			if err := json.Unmarshal([]byte(attribute.Value), &encoded); err != nil {
+				return nil, fmt.Errorf("decoding %s message attribute: %w", nobleMessageSentEventType, err)
+			}
+			message, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("decoding %s message: %w", nobleMessageSentEventType, err)
+			}
+			return message, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s event found", nobleMessageSentEventType)
+}
@@ -0,0 +1,45 @@
+package cctp
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gagliardetto/solana-go"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+)
+
+// MintRecipient encodes address on a chain of type chainType as the bytes32
+// mint recipient that CCTP mints usdc to. On svm chains usdc is minted to a
+// token account rather than a wallet, so address must be the recipients usdc
+// token account.
+func MintRecipient(chainType config.ChainType, address string) ([32]byte, error) {
+	var recipient [32]byte
+	var addressBytes []byte
+	switch chainType {
+	case config.ChainType_EVM:
+		if !common.IsHexAddress(address) {
+			return recipient, fmt.Errorf("%s is not a hex address", address)
+		}
+		addressBytes = common.HexToAddress(address).Bytes()
+	case config.ChainType_COSMOS:
+		_, decoded, err := bech32.DecodeAndConvert(address)
+		if err != nil {
+			return recipient, fmt.Errorf("decoding bech32 address %s: %w", address, err)
+		}
+		addressBytes = decoded
+	case config.ChainType_SVM:
+		publicKey, err := solana.PublicKeyFromBase58(address)
+		if err != nil {
+			return recipient, fmt.Errorf("decoding base58 address %s: %w", address, err)
+		}
+		addressBytes = publicKey.Bytes()
+	default:
+		return recipient, fmt.Errorf("cctp is not supported on chain type %s", chainType)
+	}
+	if len(addressBytes) > len(recipient) {
+		return recipient, fmt.Errorf("address %s is longer than 32 bytes", address)
+	}
+	copy(recipient[len(recipient)-len(addressBytes):], addressBytes)
+	return recipient, nil
+}
@@ -0,0 +1,262 @@
+package cctp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/skip-mev/go-fast-solver/shared/svm/contracts/cctp/message_transmitter"
+	"github.com/skip-mev/go-fast-solver/shared/svm/contracts/cctp/token_messenger_minter"
+)
+
+const (
+	// svmUsedNoncesPerAccount is the number of nonces tracked by each used
+	// nonces account of the MessageTransmitter program
+	svmUsedNoncesPerAccount = 6400
+	// svmDepositForBurnMessageSentEventDataIndex is the index of the message
+	// sent event data account in the accounts of a depositForBurn instruction
+	svmDepositForBurnMessageSentEventDataIndex = 10
+)
+
+// SVMDepositForBurnInstruction builds the instruction burning amount of the
+// usdc mint held in owner's associated token account to be minted to
+// mintRecipient on destinationDomain. The message sent by the burn is stored
+// in the new messageSentEventData account, whose keypair must sign the tx.
+func SVMDepositForBurnInstruction(
+	tokenMessengerMinter solana.PublicKey,
+	messageTransmitter solana.PublicKey,
+	owner solana.PublicKey,
+	mint solana.PublicKey,
+	messageSentEventData solana.PublicKey,
+	amount uint64,
+	destinationDomain uint32,
+	mintRecipient [32]byte,
+) (solana.Instruction, error) {
+	burnTokenAccount, _, err := solana.FindAssociatedTokenAddress(owner, mint)
+	if err != nil {
+		return nil, fmt.Errorf("finding associated token account of %s: %w", owner, err)
+	}
+	destinationDomainSeed := []byte(strconv.FormatUint(uint64(destinationDomain), 10))
+	accounts, err := findPDAs(
+		pda{tokenMessengerMinter, [][]byte{[]byte("sender_authority")}},
+		pda{messageTransmitter, [][]byte{[]byte("message_transmitter")}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("token_messenger")}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("remote_token_messenger"), destinationDomainSeed}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("token_minter")}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("local_token"), mint.Bytes()}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("__event_authority")}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	instruction, err := token_messenger_minter.NewDepositForBurnInstruction(
+		token_messenger_minter.DepositForBurnParams{
+			Amount:            amount,
+			DestinationDomain: destinationDomain,
+			MintRecipient:     solana.PublicKeyFromBytes(mintRecipient[:]),
+		},
+		owner,
+		owner,
+		accounts[0],
+		burnTokenAccount,
+		accounts[1],
+		accounts[2],
+		accounts[3],
+		accounts[4],
+		accounts[5],
+		mint,
+		messageSentEventData,
+		messageTransmitter,
+		tokenMessengerMinter,
+		solana.TokenProgramID,
+		solana.SystemProgramID,
+		accounts[6],
+		tokenMessengerMinter,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, fmt.Errorf("building depositForBurn instruction: %w", err)
+	}
+	return withProgramID(tokenMessengerMinter, instruction.Accounts(), instruction)
+}
+
+// SVMReceiveMessageInstruction builds the instruction receiving an attested
+// burn message and minting the burned usdc to recipientTokenAccount, which
+// must be the usdc token account that the message was sent to
+func SVMReceiveMessageInstruction(
+	tokenMessengerMinter solana.PublicKey,
+	messageTransmitter solana.PublicKey,
+	payer solana.PublicKey,
+	mint solana.PublicKey,
+	recipientTokenAccount solana.PublicKey,
+	message []byte,
+	attestation []byte,
+) (solana.Instruction, error) {
+	if len(message) < burnMessageBurnTokenEnd {
+		return nil, fmt.Errorf("message of length %d is too short to be a burn message", len(message))
+	}
+	sourceDomain := binary.BigEndian.Uint32(message[messageSourceDomainOffset:])
+	nonce := binary.BigEndian.Uint64(message[messageNonceOffset:])
+	burnToken := message[burnMessageBurnTokenStart:burnMessageBurnTokenEnd]
+	if nonce == 0 {
+		return nil, fmt.Errorf("message has invalid nonce 0")
+	}
+
+	sourceDomainSeed := []byte(strconv.FormatUint(uint64(sourceDomain), 10))
+	accounts, err := findPDAs(
+		pda{messageTransmitter, [][]byte{[]byte("message_transmitter_authority"), tokenMessengerMinter.Bytes()}},
+		pda{messageTransmitter, [][]byte{[]byte("message_transmitter")}},
+		usedNoncesPDA(messageTransmitter, sourceDomain, nonce),
+		pda{messageTransmitter, [][]byte{[]byte("__event_authority")}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("token_messenger")}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("remote_token_messenger"), sourceDomainSeed}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("token_minter")}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("local_token"), mint.Bytes()}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("token_pair"), sourceDomainSeed, burnToken}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("custody"), mint.Bytes()}},
+		pda{tokenMessengerMinter, [][]byte{[]byte("__event_authority")}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	instruction, err := message_transmitter.NewReceiveMessageInstruction(
+		message_transmitter.ReceiveMessageParams{
+			Message:     message,
+			Attestation: attestation,
+		},
+		payer,
+		payer,
+		accounts[0],
+		accounts[1],
+		accounts[2],
+		tokenMessengerMinter,
+		solana.SystemProgramID,
+		accounts[3],
+		messageTransmitter,
+	).ValidateAndBuild()
+	if err != nil {
+		return nil, fmt.Errorf("building receiveMessage instruction: %w", err)
+	}
+
+	// the accounts the TokenMessengerMinter needs to mint the burned usdc are
+	// passed through the MessageTransmitter as remaining accounts
+	remainingAccounts := []*solana.AccountMeta{
+		solana.Meta(accounts[4]),
+		solana.Meta(accounts[5]),
+		solana.Meta(accounts[6]).WRITE(),
+		solana.Meta(accounts[7]).WRITE(),
+		solana.Meta(accounts[8]),
+		solana.Meta(recipientTokenAccount).WRITE(),
+		solana.Meta(accounts[9]).WRITE(),
+		solana.Meta(solana.TokenProgramID),
+		solana.Meta(accounts[10]),
+		solana.Meta(tokenMessengerMinter),
+	}
+	return withProgramID(messageTransmitter, append(instruction.Accounts(), remainingAccounts...), instruction)
+}
+
+// SVMMessageSentEventAccount gets the account that the message sent by the
+// depositForBurn instruction in tx is stored in
+func SVMMessageSentEventAccount(tx *solana.Transaction, tokenMessengerMinter solana.PublicKey) (solana.PublicKey, error) {
+	for _, instruction := range tx.Message.Instructions {
+		programID, err := tx.Message.Program(instruction.ProgramIDIndex)
+		if err != nil {
+			return solana.PublicKey{}, fmt.Errorf("getting program of instruction: %w", err)
+		}
+		if !programID.Equals(tokenMessengerMinter) || !bytes.HasPrefix(instruction.Data, token_messenger_minter.Instruction_DepositForBurn[:]) {
+			continue
+		}
+		if len(instruction.Accounts) <= svmDepositForBurnMessageSentEventDataIndex {
+			return solana.PublicKey{}, fmt.Errorf("depositForBurn instruction has %d accounts", len(instruction.Accounts))
+		}
+		return tx.Message.Account(instruction.Accounts[svmDepositForBurnMessageSentEventDataIndex])
+	}
+	return solana.PublicKey{}, fmt.Errorf("no depositForBurn instruction found in tx")
+}
+
+// MessageSentFromSVMAccount decodes the message stored in a MessageSent
+// account created by a burn on an svm chain
+func MessageSentFromSVMAccount(data []byte) ([]byte, error) {
+	var messageSent message_transmitter.MessageSent
+	if err := bin.NewBorshDecoder(data).Decode(&messageSent); err != nil {
+		return nil, fmt.Errorf("decoding MessageSent account: %w", err)
+	}
+	return messageSent.Message, nil
+}
+
+// SVMUsedNoncesAccount gets the MessageTransmitter account that records
+// whether the message with nonce from sourceDomain has been received
+func SVMUsedNoncesAccount(messageTransmitter solana.PublicKey, sourceDomain uint32, nonce uint64) (solana.PublicKey, error) {
+	if nonce == 0 {
+		return solana.PublicKey{}, fmt.Errorf("message has invalid nonce 0")
+	}
+	accounts, err := findPDAs(usedNoncesPDA(messageTransmitter, sourceDomain, nonce))
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	return accounts[0], nil
+}
+
+// SVMNonceUsed decodes a used nonces account of the MessageTransmitter and
+// gets whether nonce is marked as used in it
+func SVMNonceUsed(data []byte, nonce uint64) (bool, error) {
+	var usedNonces message_transmitter.UsedNonces
+	if err := bin.NewBorshDecoder(data).Decode(&usedNonces); err != nil {
+		return false, fmt.Errorf("decoding UsedNonces account: %w", err)
+	}
+	if nonce < usedNonces.FirstNonce || nonce-usedNonces.FirstNonce >= svmUsedNoncesPerAccount {
+		return false, fmt.Errorf("nonce %d is not tracked by used nonces account starting at nonce %d", nonce, usedNonces.FirstNonce)
+	}
+	index := nonce - usedNonces.FirstNonce
+	return usedNonces.UsedNonces[index/64]&(1<<(index%64)) != 0, nil
+}
+
+// usedNoncesPDA is the used nonces account that tracks nonce from
+// sourceDomain. Each account tracks svmUsedNoncesPerAccount nonces.
+func usedNoncesPDA(messageTransmitter solana.PublicKey, sourceDomain uint32, nonce uint64) pda {
+	firstNonce := ((nonce-1)/svmUsedNoncesPerAccount)*svmUsedNoncesPerAccount + 1
+	// the MessageTransmitter separates the domain and nonce of the used nonces
+	// seeds of domains with two digit ids, since they would otherwise collide
+	// with seeds of single digit domains
+	var delimiter []byte
+	if sourceDomain >= 11 {
+		delimiter = []byte("-")
+	}
+	return pda{messageTransmitter, [][]byte{
+		[]byte("used_nonces"),
+		[]byte(strconv.FormatUint(uint64(sourceDomain), 10)),
+		delimiter,
+		[]byte(strconv.FormatUint(firstNonce, 10)),
+	}}
+}
+
+type pda struct {
+	programID solana.PublicKey
+	seeds     [][]byte
+}
+
+func findPDAs(pdas ...pda) ([]solana.PublicKey, error) {
+	addresses := make([]solana.PublicKey, 0, len(pdas))
+	for _, p := range pdas {
+		address, _, err := solana.FindProgramAddress(p.seeds, p.programID)
+		if err != nil {
+			return nil, fmt.Errorf("finding pda with seed %q of program %s: %w", p.seeds[0], p.programID, err)
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// withProgramID sets the program of an instruction built with the generated
+// cctp bindings, since the bindings only support a single global program id
+func withProgramID(programID solana.PublicKey, accounts []*solana.AccountMeta, instruction solana.Instruction) (solana.Instruction, error) {
+	data, err := instruction.Data()
+	if err != nil {
+		return nil, fmt.Errorf("encoding instruction data: %w", err)
+	}
+	return solana.NewInstruction(programID, accounts, data), nil
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/skip-mev/go-fast-solver/shared/txexecutor/cosmos"
+	"github.com/skip-mev/go-fast-solver/shared/txexecutor/svm"
 	"sync"
 
 	"github.com/skip-mev/go-fast-solver/shared/bridges/cctp"
@@ -28,21 +29,27 @@ import (
 	ethereumrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/skip-mev/go-fast-solver/shared/config"
 	"github.com/skip-mev/go-fast-solver/shared/signing"
+	"github.com/skip-mev/go-fast-solver/shared/svmrpc"
 	"github.com/skip-mev/go-fast-solver/shared/utils"
 )
 
 type ClientManager struct {
-	keyStore         keys.KeyStore
-	clients          map[string]cctp.BridgeClient
-	mu               sync.RWMutex
-	cosmosTxExecutor cosmos.CosmosTxExecutor
+	keyStore            keys.KeyStore
+	clients             map[string]cctp.BridgeClient
+	mu                  sync.RWMutex
+	cosmosTxExecutor    cosmos.CosmosTxExecutor
+	svmRPCClientManager svmrpc.SVMRPCClientManager
+	svmTxExecutor       svm.SVMTxExecutor
 }
 
 func NewClientManager(chainIDToPrivateKey keys.KeyStore, cosmosTxExecutor cosmos.CosmosTxExecutor) *ClientManager {
+	svmRPCClientManager := svmrpc.NewSVMRPCClientManager()
 	return &ClientManager{
-		keyStore:         chainIDToPrivateKey,
-		clients:          make(map[string]cctp.BridgeClient),
-		cosmosTxExecutor: cosmosTxExecutor,
+		keyStore:            chainIDToPrivateKey,
+		clients:             make(map[string]cctp.BridgeClient),
+		cosmosTxExecutor:    cosmosTxExecutor,
+		svmRPCClientManager: svmRPCClientManager,
+		svmTxExecutor:       svm.DefaultSVMTxExecutor(svmRPCClientManager),
 	}
 }
 
@@ -77,6 +84,8 @@ func (cm *ClientManager) GetClient(
 		newClient, err = cm.createCosmosClient(ctx, chainID)
 	case config.ChainType_EVM:
 		newClient, err = cm.createEVMClient(ctx, chainID)
+	case config.ChainType_SVM:
+		newClient, err = cm.createSVMClient(ctx, chainID)
 	default:
 		return nil, errors.New("unsupported cctp domain")
 	}
@@ -211,3 +220,32 @@ func (cm *ClientManager) createEVMClient(
 
 	return bridgeClient, err
 }
+
+func (cm *ClientManager) createSVMClient(
+	ctx context.Context,
+	chainID string,
+) (cctp.BridgeClient, error) {
+	client, err := cm.svmRPCClientManager.GetClient(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeyStr, ok := cm.keyStore.GetPrivateKey(chainID)
+	if !ok {
+		return nil, fmt.Errorf("solver private key not found for chainID %s", chainID)
+	}
+
+	signer, err := signing.NewLocalSolanaSigner(privateKeyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	bridgeClient, err := cctp.NewSVMBridgeClient(
+		client,
+		chainID,
+		signer,
+		cm.svmTxExecutor,
+	)
+
+	return bridgeClient, err
+}
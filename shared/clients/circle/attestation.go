@@ -0,0 +1,98 @@
+package circle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/skip-mev/go-fast-solver/shared/clients/utils"
+)
+
+type AttestationStatus string
+
+const (
+	AttestationStatusComplete             AttestationStatus = "complete"
+	AttestationStatusPendingConfirmations AttestationStatus = "pending_confirmations"
+)
+
+// ErrAttestationNotFound is returned when the attestation service has not yet
+// seen the burn message, which is expected for a short time after a burn
+var ErrAttestationNotFound = errors.New("attestation not found")
+
+type Attestation struct {
+	Status AttestationStatus
+	// Attestation is the signed attestation for the message, only set when
+	// Status is AttestationStatusComplete
+	Attestation []byte
+}
+
+// AttestationClient fetches attestations for CCTP burn messages
+type AttestationClient interface {
+	GetAttestation(ctx context.Context, messageHash common.Hash) (*Attestation, error)
+}
+
+type attestationClient struct {
+	client  utils.HTTPClient
+	baseURL string
+}
+
+func NewAttestationClient(client utils.HTTPClient, baseURL string) AttestationClient {
+	return &attestationClient{client: client, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func DefaultAttestationClient(baseURL string) AttestationClient {
+	return NewAttestationClient(&http.Client{Timeout: 30 * time.Second}, baseURL)
+}
+
+type attestationResponse struct {
+	Attestation string `json:"attestation"`
+	Status      string `json:"status"`
+}
+
+// GetAttestation gets the attestation for the CCTP message with the keccak256
+// hash messageHash
+func (c *attestationClient) GetAttestation(ctx context.Context, messageHash common.Hash) (*Attestation, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/attestations/%s", c.baseURL, messageHash.Hex()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting attestation for message %s: %w", messageHash.Hex(), err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrAttestationNotFound
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading attestation response for message %s: %w", messageHash.Hex(), err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("attestation service returned status %d for message %s: %s", res.StatusCode, messageHash.Hex(), string(body))
+	}
+
+	var response attestationResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("decoding attestation response for message %s: %w", messageHash.Hex(), err)
+	}
+
+	attestation := &Attestation{Status: AttestationStatus(response.Status)}
+	if attestation.Status != AttestationStatusComplete {
+		return attestation, nil
+	}
+	attestation.Attestation, err = hexutil.Decode(response.Attestation)
+	if err != nil {
+		return nil, fmt.Errorf("decoding attestation %s for message %s: %w", response.Attestation, messageHash.Hex(), err)
+	}
+	return attestation, nil
+}
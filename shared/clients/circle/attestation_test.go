@@ -0,0 +1,36 @@
+package circle_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	mock_circle "github.com/skip-mev/go-fast-solver/mocks/shared/clients/circle"
+	"github.com/skip-mev/go-fast-solver/shared/clients/circle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationClient_GetAttestation(t *testing.T) {
+	ctx := context.Background()
+	service := mock_circle.NewFakeAttestationService()
+	defer service.Close()
+	client := circle.DefaultAttestationClient(service.URL() + "/")
+
+	messageHash := crypto.Keccak256Hash([]byte("message"))
+
+	_, err := client.GetAttestation(ctx, messageHash)
+	assert.ErrorIs(t, err, circle.ErrAttestationNotFound)
+
+	service.SetPending(messageHash)
+	attestation, err := client.GetAttestation(ctx, messageHash)
+	require.NoError(t, err)
+	assert.Equal(t, circle.AttestationStatusPendingConfirmations, attestation.Status)
+	assert.Empty(t, attestation.Attestation)
+
+	service.Attest(messageHash, []byte{0xde, 0xad, 0xbe, 0xef})
+	attestation, err = client.GetAttestation(ctx, messageHash)
+	require.NoError(t, err)
+	assert.Equal(t, circle.AttestationStatusComplete, attestation.Status)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, attestation.Attestation)
+}
@@ -10,6 +10,8 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+
+	"github.com/gagliardetto/solana-go"
 )
 
 type TxHash string
@@ -159,6 +161,26 @@ type RouteResponse struct {
 	USDAmountIn            string   `json:"usd_amount_in"`
 	USDAmountOut           string   `json:"usd_amount_out"`
 	SwapPriceImpactPercent string   `json:"swap_price_impact_percent"`
+
+	EstimatedFees                 []EstimatedFee `json:"estimated_fees"`
+	EstimatedRouteDurationSeconds int64          `json:"estimated_route_duration_seconds"`
+}
+
+// EstimatedFee is a fee Skip Go estimates will be charged to execute a route,
+// e.g. a smart relay fee
+type EstimatedFee struct {
+	FeeType     string   `json:"fee_type"`
+	BridgeID    string   `json:"bridge_id"`
+	Amount      string   `json:"amount"`
+	USDAmount   string   `json:"usd_amount"`
+	OriginAsset FeeAsset `json:"origin_asset"`
+	ChainID     string   `json:"chain_id"`
+	TxIndex     int      `json:"tx_index"`
+}
+
+type FeeAsset struct {
+	Denom   string `json:"denom"`
+	ChainID string `json:"chain_id"`
 }
 
 func (s *skipGoClient) Route(
@@ -259,9 +281,21 @@ type CosmosTx struct {
 	Msgs          []CosmosMessage `json:"msgs"`
 }
 
+type SVMTx struct {
+	ChainID string `json:"chain_id"`
+	// Tx is the base64 encoded unsigned tx
+	Tx            string `json:"tx"`
+	SignerAddress string `json:"signer_address"`
+	// AdditionalSigners are keys other than the solvers that must sign the
+	// tx, such as the keypairs of accounts the tx creates. They are never
+	// serialized.
+	AdditionalSigners []solana.PrivateKey `json:"-"`
+}
+
 type Tx struct {
 	EVMTx             *EVMTx    `json:"evm_tx"`
 	CosmosTx          *CosmosTx `json:"cosmos_tx"`
+	SVMTx             *SVMTx    `json:"svm_tx"`
 	OperationsIndices []int     `json:"operations_indices"`
 }
 
@@ -2,11 +2,17 @@ package config
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"os"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gagliardetto/solana-go"
 	"github.com/skip-mev/go-fast-solver/shared/lmt"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
@@ -18,6 +24,7 @@ type ChainType string
 const (
 	ChainType_COSMOS ChainType = "cosmos"
 	ChainType_EVM    ChainType = "evm"
+	ChainType_SVM    ChainType = "svm"
 )
 
 type ChainEnvironment string
@@ -39,6 +46,55 @@ type Config struct {
 	// amounts, and the FundRebalancer will use skip go to move funds between
 	// chains to maintain these values.
 	FundRebalancer map[string]FundRebalancerConfig `yaml:"fund_rebalancer"`
+	// CCTPAttestation configures the attestation service used when the fund
+	// rebalancer moves funds directly over CCTP instead of through skip go
+	CCTPAttestation CCTPAttestationConfig `yaml:"cctp_attestation"`
+	// FundRebalancerLimits optionally caps the rebalances into all chains
+	// combined. Per chain limits are configured in each chains
+	// FundRebalancer config.
+	FundRebalancerLimits *RebalanceLimitsConfig `yaml:"fund_rebalancer_limits,omitempty"`
+	// ERC20Approvals configures the erc20 approvals the fund rebalancer
+	// grants to the spender contracts of rebalance routes
+	ERC20Approvals ERC20ApprovalsConfig `yaml:"erc20_approvals"`
+	// SkipGo configures the Skip Go api that rebalance routes and balances
+	// are fetched from
+	SkipGo SkipGoConfig `yaml:"skip_go"`
+}
+
+// DefaultSkipGoURL is Skip's public Skip Go api
+const DefaultSkipGoURL = "https://api.skip.build"
+
+type SkipGoConfig struct {
+	// URL is the base url of the Skip Go api. Defaults to Skip's public Skip
+	// Go api.
+	URL string `yaml:"url"`
+}
+
+// DefaultCCTPAttestationURL is Circle's mainnet attestation service
+const DefaultCCTPAttestationURL = "https://iris-api.circle.com"
+
+// DefaultIBCTransferTimeout is how long ibc transfers sent by the fund
+// rebalancer have to be received if no timeout is configured
+const DefaultIBCTransferTimeout = 10 * time.Minute
+
+var ibcChannelRegex = regexp.MustCompile(`^channel-[0-9]+$`)
+
+type CCTPAttestationConfig struct {
+	// URL is the base url of the CCTP attestation service that attestations
+	// for burned usdc are fetched from. Defaults to Circle's mainnet
+	// attestation service.
+	URL string `yaml:"url"`
+}
+
+type ERC20ApprovalsConfig struct {
+	// BufferUUSDC is the amount of uusdc approved on top of the exact amount a
+	// rebalance needs, so that small follow up rebalances through the same
+	// spender do not need another approval. Defaults to 0.
+	BufferUUSDC string `yaml:"buffer_uusdc"`
+	// RevokeIdleAfter is how long a spender can go without being used by a
+	// rebalance before its allowance is revoked. Allowances are never revoked
+	// automatically if this is 0.
+	RevokeIdleAfter time.Duration `yaml:"revoke_idle_after"`
 }
 
 type OrderFillerConfig struct {
@@ -79,6 +135,53 @@ type FundRebalancerConfig struct {
 	// getting stuck with insufficient funds when gas costs are high. If gas costs
 	// exceed this cap even after timeout, the rebalancing will not occur.
 	TransferCostCapUUSDC string `yaml:"transfer_cost_cap_uusdc"`
+	// Forecast optionally enables predictive rebalancing for this chain. When
+	// set, MinAllowedAmount and TargetAmount are raised ahead of periods
+	// where order flow is expected to drain this chain, based on the order
+	// and settlement history for the same time of day.
+	Forecast *FundRebalancerForecastConfig `yaml:"forecast,omitempty"`
+	// Limits optionally caps the rebalances into this chain
+	Limits *RebalanceLimitsConfig `yaml:"limits,omitempty"`
+	// HysteresisBandUUSDC is the amount of uusdc above TargetAmount that this
+	// chain must hold before it is used as a source for rebalancing other
+	// chains. This stops funds from flapping between chains whose balances
+	// hover around their thresholds.
+	HysteresisBandUUSDC string `yaml:"hysteresis_band_uusdc"`
+}
+
+// RebalanceLimitsConfig are safety limits on the funds moved by the fund
+// rebalancer. All limits are optional, and budgets are enforced over a
+// rolling 24 hour window.
+type RebalanceLimitsConfig struct {
+	// MaxTransferAmountUUSDC is the maximum amount of uusdc moved by a single
+	// rebalance transfer. Larger rebalances are capped to this amount.
+	MaxTransferAmountUUSDC string `yaml:"max_transfer_amount_uusdc"`
+	// MaxDailyVolumeUUSDC is the maximum amount of uusdc moved by rebalance
+	// transfers in the last 24 hours
+	MaxDailyVolumeUUSDC string `yaml:"max_daily_volume_uusdc"`
+	// MaxDailyFeesUUSDC is the maximum quoted cost in uusdc, including fees
+	// and gas, of rebalance transfers in the last 24 hours
+	MaxDailyFeesUUSDC string `yaml:"max_daily_fees_uusdc"`
+	// Cooldown is the minimum time between rebalances into the same chain
+	Cooldown time.Duration `yaml:"cooldown"`
+}
+
+// DefaultForecastLookbackDays is the number of days of order history used to
+// forecast order flow if not configured
+const DefaultForecastLookbackDays = 14
+
+type FundRebalancerForecastConfig struct {
+	// LeadTime is how long it usually takes for a rebalance to this chain to
+	// arrive. The min allowed amount is raised to the net outflow expected
+	// over the next LeadTime, so that a rebalance is triggered early enough
+	// to land before the chain runs out of funds.
+	LeadTime time.Duration `yaml:"lead_time"`
+	// LookbackDays is the number of days of order and settlement history
+	// that forecasts are averaged over. Defaults to 14.
+	LookbackDays int `yaml:"lookback_days"`
+	// MaxTargetAmount is the maximum amount of uusdc that forecasts can
+	// raise the min allowed and target amounts of this chain to
+	MaxTargetAmount string `yaml:"max_target_amount"`
 }
 
 type TransferMonitorConfig struct {
@@ -91,7 +194,7 @@ type ChainConfig struct {
 	ChainName string `yaml:"chain_name"`
 	// e.g. osmosis-1
 	ChainID string `yaml:"chain_id"`
-	// (cosmos, evm)
+	// (cosmos, evm, svm)
 	Type ChainType `yaml:"type"`
 	// Environment specifies whether this is a mainnet or testnet configuration
 	Environment ChainEnvironment `yaml:"environment"`
@@ -99,6 +202,8 @@ type ChainConfig struct {
 	Cosmos *CosmosConfig `yaml:"cosmos,omitempty"`
 	// EVM contains specific configuration for Ethereum Virtual Machine based chains
 	EVM *EVMConfig `yaml:"evm,omitempty"`
+	// SVM contains specific configuration for Solana Virtual Machine based chains
+	SVM *SVMConfig `yaml:"svm,omitempty"`
 	// GasTokenSymbol is the symbol of the native gas token (e.g., "ETH", "MATIC")
 	GasTokenSymbol string `yaml:"gas_token_symbol"`
 	// GasTokenDecimals specifies the number of decimal places for the gas token
@@ -121,7 +226,8 @@ type ChainConfig struct {
 	// be used to fulfill orders and receive fees
 	SolverAddress string `yaml:"solver_address"`
 	// USDCDenom is the denomination or contract address for USDC on this chain
-	// (ERC20 contract address for EVM chains or IBC denom for Cosmos chains)
+	// (ERC20 contract address for EVM chains, IBC denom for Cosmos chains or
+	// SPL token mint address for SVM chains)
 	USDCDenom string `yaml:"usdc_denom"`
 	// Relayer contains configuration for the Hyperlane relayer service
 	// used for cross-chain message passing during settlement
@@ -240,6 +346,131 @@ type ChainConfig struct {
 	// When SkipSettlementProfitabilityChecks is set to true, the solver will skip profitability checks when relaying
 	// settlements.
 	SkipSettlementProfitabilityChecks bool `yaml:"skip_settlement_profitability_checks"`
+
+	// SettlementRepayment optionally overrides the address that settlements
+	// paid out on this chain are repaid to. By default, settlement principal
+	// and fees are repaid to the SolverAddress. This can be used to route
+	// repayments to a cold or treasury wallet, or to a different hot wallet
+	// that feeds the fund rebalancer.
+	SettlementRepayment *SettlementRepaymentConfig `yaml:"settlement_repayment,omitempty"`
+
+	// CCTP optionally configures Circle's CCTP contracts on this chain. If
+	// CCTP is configured on two chains, the fund rebalancer can move usdc
+	// between them by burning and minting over CCTP directly, without going
+	// through the skip go api. Only evm chains, Noble and svm chains support
+	// CCTP.
+	CCTP *CCTPConfig `yaml:"cctp,omitempty"`
+
+	// IBC optionally configures the ics-20 transfer channels on this chain
+	// that the fund rebalancer can send usdc over directly to other cosmos
+	// chains, without going through the skip go api. Only cosmos chains
+	// support IBC.
+	IBC *IBCConfig `yaml:"ibc,omitempty"`
+}
+
+type CCTPConfig struct {
+	// Domain is the CCTP domain of this chain, e.g. 0 for Ethereum, 3 for
+	// Arbitrum and 4 for Noble
+	Domain uint32 `yaml:"domain"`
+	// TokenMessengerAddress is the address of the CCTP TokenMessenger contract
+	// that usdc is burned through, or of the TokenMessengerMinter program on
+	// svm chains. Not used on cosmos chains.
+	TokenMessengerAddress string `yaml:"token_messenger_address"`
+	// MessageTransmitterAddress is the address of the CCTP MessageTransmitter
+	// contract (or program on svm chains) that burn messages are sent from and
+	// received by. Not used on cosmos chains.
+	MessageTransmitterAddress string `yaml:"message_transmitter_address"`
+}
+
+type IBCConfig struct {
+	// Channels maps the chain ids of cosmos chains that usdc can be sent to
+	// directly to the transfer channel on this chain that leads to them, e.g.
+	// "noble-1": "channel-750". usdc sent over a channel must arrive as the
+	// destination chain's usdc_denom, so channels can only be configured
+	// between Noble and the chains it issues usdc to over that channel. A
+	// channel from Noble also requires the destination chain to configure the
+	// channel back to Noble, which is the channel its usdc_denom is derived
+	// from.
+	Channels map[string]string `yaml:"channels"`
+	// TransferTimeout is how long after being sent a transfer times out if it
+	// has not been received on the destination chain, in which case the usdc
+	// is refunded on this chain once the timeout is relayed. Defaults to 10m.
+	TransferTimeout time.Duration `yaml:"transfer_timeout"`
+}
+
+type SettlementRepaymentConfig struct {
+	// Address is the default repayment address for settlements paid out on
+	// this chain. If this is not set, the chains SolverAddress is used.
+	Address string `yaml:"address"`
+
+	// Rules are optional routing rules that are evaluated in order when a
+	// settlement batch is initiated. The address of the first rule that
+	// matches the batch is used as the repayment address. If no rules match,
+	// Address is used.
+	Rules []RepaymentAddressRule `yaml:"rules"`
+}
+
+type RepaymentAddressRule struct {
+	// Address is the repayment address to use when this rule matches
+	Address string `yaml:"address"`
+
+	// MinBatchValueUUSDC is the minimum total value in uusdc (inclusive) of
+	// a settlement batch for this rule to match. If empty, there is no
+	// minimum.
+	MinBatchValueUUSDC string `yaml:"min_batch_value_uusdc"`
+
+	// MaxBatchValueUUSDC is the maximum total value in uusdc (exclusive) of
+	// a settlement batch for this rule to match. If empty, there is no
+	// maximum.
+	MaxBatchValueUUSDC string `yaml:"max_batch_value_uusdc"`
+
+	// StartHourUTC and EndHourUTC define a window of the day (in UTC hours,
+	// 0-23) that this rule matches in. The window includes StartHourUTC and
+	// excludes EndHourUTC, and may wrap around midnight (i.e. a start of 22
+	// and an end of 6 matches from 22:00 to 05:59). Both must be set or
+	// neither must be set. If neither is set, the rule matches at any time.
+	StartHourUTC *int `yaml:"start_hour_utc"`
+	EndHourUTC   *int `yaml:"end_hour_utc"`
+}
+
+// Matches returns true if a settlement batch with a total value of
+// batchValueUUSDC being initiated at time at satisfies all conditions of the
+// rule.
+func (r RepaymentAddressRule) Matches(batchValueUUSDC *big.Int, at time.Time) bool {
+	if r.MinBatchValueUUSDC != "" {
+		min, ok := new(big.Int).SetString(r.MinBatchValueUUSDC, 10)
+		if !ok || batchValueUUSDC.Cmp(min) < 0 {
+			return false
+		}
+	}
+	if r.MaxBatchValueUUSDC != "" {
+		max, ok := new(big.Int).SetString(r.MaxBatchValueUUSDC, 10)
+		if !ok || batchValueUUSDC.Cmp(max) >= 0 {
+			return false
+		}
+	}
+	if r.StartHourUTC != nil && r.EndHourUTC != nil {
+		hour := at.UTC().Hour()
+		start, end := *r.StartHourUTC, *r.EndHourUTC
+		if start <= end {
+			if hour < start || hour >= end {
+				return false
+			}
+		} else if hour < start && hour >= end {
+			// window wraps around midnight
+			return false
+		}
+	}
+	return true
+}
+
+type RelayRouteConfig struct {
+	// Disabled stops the solver from relaying messages on this route. Messages
+	// are still tracked until they are delivered by another relayer.
+	Disabled bool `yaml:"disabled,omitempty"`
+	// PublicRelayerGracePeriod overrides the chain wide public relayer grace
+	// period for this route
+	PublicRelayerGracePeriod *time.Duration `yaml:"public_relayer_grace_period,omitempty"`
 }
 
 type RelayerConfig struct {
@@ -249,9 +480,37 @@ type RelayerConfig struct {
 	// MerkleHookContractAddress is the address of the Hyperlane merkle hook
 	// contract used for verifying cross-chain message proofs
 	MerkleHookContractAddress string `yaml:"merkle_hook_contract_address"`
+	// MerkleHookStartHeight is the block height to start indexing the merkle
+	// hooks insertion events from when building the local merkle tree used to
+	// generate proofs for merkle root multisig isms. This should be set to the
+	// height that the merkle hook contract was deployed at.
+	MerkleHookStartHeight uint64 `yaml:"merkle_hook_start_height,omitempty"`
+	// DispatchIndexerStartHeight is the block height to start indexing the
+	// mailboxes dispatch events from the first time the solver runs, in order
+	// to relay settlements and timeouts sent by the solver that were never
+	// submitted to be relayed. If this is not set, indexing starts from the
+	// chains latest height.
+	DispatchIndexerStartHeight uint64 `yaml:"dispatch_indexer_start_height,omitempty"`
 	// MailboxAddress is the address of the Hyperlane mailbox contract used
 	// for sending and receiving cross-chain messages
 	MailboxAddress string `yaml:"mailbox_address"`
+	// CheckpointFetchTimeout is the maximum amount of time a single request
+	// to a validators remote checkpoint storage (s3, gcs or https) may take
+	// when relaying messages that originate on this chain. Defaults to 10s.
+	CheckpointFetchTimeout time.Duration `yaml:"checkpoint_fetch_timeout,omitempty"`
+	// CheckpointAwaitTimeout is the maximum amount of time to wait for each
+	// validator to sign the checkpoint of a message that originates on this
+	// chain during a single relay attempt. Defaults to 30s.
+	CheckpointAwaitTimeout time.Duration `yaml:"checkpoint_await_timeout,omitempty"`
+	// ValidatorLagThreshold is the number of checkpoints a validator may be
+	// behind this chains merkle tree and still count towards the quorum of a
+	// route from this chain. Settlements are not initiated on routes without
+	// a quorum of caught up validators. Defaults to 5.
+	ValidatorLagThreshold uint64 `yaml:"validator_lag_threshold,omitempty"`
+	// QuorumLossAlertThreshold is how long a route from this chain can be
+	// without a quorum of caught up validators before alerting. Defaults to
+	// 10m.
+	QuorumLossAlertThreshold time.Duration `yaml:"quorum_loss_alert_threshold,omitempty"`
 
 	// ProfitableRelayTimeout is the maximum amount of time delay relaying a
 	// transaction waiting for it to be profitable. Currently this only applies
@@ -269,6 +528,32 @@ type RelayerConfig struct {
 	// window, the relay cost cap will be used as the max uusdc value to pay
 	// for a tx if that value is greater than the profitable max tx fee.
 	RelayCostCapUUSDC string `yaml:"relay_cost_cap_uusdc"`
+
+	// CheckpointStorageLocationOverrides maps the addresses of validators that
+	// sign messages sent from this chain to a storage location to fetch their
+	// signed checkpoints from instead of the location they have announced,
+	// i.e. to use a mirror or a private endpoint for a validator. Validators
+	// with an override are not looked up on the validator announce contract,
+	// so this also configures validators in a routes ism validator set that
+	// have never announced a storage location. Only validators in the ism
+	// validator set are used, since the ism only accepts their signatures.
+	CheckpointStorageLocationOverrides map[string]string `yaml:"checkpoint_storage_location_overrides,omitempty"`
+	// PublicRelayerGracePeriod defers relaying messages sent from this chain
+	// to the public Hyperlane relayer. Messages are only relayed by the solver
+	// if they have not been delivered within the grace period after being
+	// submitted to be relayed. If this is not set, messages are relayed
+	// immediately.
+	PublicRelayerGracePeriod time.Duration `yaml:"public_relayer_grace_period,omitempty"`
+	// Routes configures relaying of messages sent from this chain to specific
+	// destination chains, keyed by destination chain id. Routes that are not
+	// configured use the chain wide relayer settings.
+	Routes map[string]RelayRouteConfig `yaml:"routes,omitempty"`
+
+	// StuckTxReplacementTimeout is how long a relay tx delivering a message to
+	// this chain can be pending before it is replaced by a tx with the same
+	// nonce and bumped fees. Replacements are capped by the relays max tx fee.
+	// Only applies to evm chains. Defaults to 3m.
+	StuckTxReplacementTimeout time.Duration `yaml:"stuck_tx_replacement_timeout,omitempty"`
 }
 
 // Used to monitor gas balance prometheus metric per chain for the solver addresses
@@ -279,6 +564,30 @@ type SignerGasBalanceConfig struct {
 	// CriticalThresholdWei specifies the gas balance threshold in Wei
 	// below which solver operations may be impacted
 	CriticalThresholdWei string `yaml:"critical_threshold_wei"`
+	// TopUp optionally configures automatic top ups of the solvers gas
+	// balance on this chain. When the balance drops below
+	// WarningThresholdWei, usdc is swapped into the gas token through skip go.
+	TopUp *GasTopUpConfig `yaml:"top_up,omitempty"`
+}
+
+type GasTopUpConfig struct {
+	// AmountUUSDC is the amount of uusdc swapped into the gas token per top up
+	AmountUUSDC string `yaml:"amount_uusdc"`
+	// GasTokenDenom is the skip go denom of the chains gas token, e.g.
+	// arbitrum-native. Defaults to cosmos.gas_denom on cosmos chains.
+	GasTokenDenom string `yaml:"gas_token_denom"`
+	// SourceChainID is the chain the usdc for top ups is taken from. If this
+	// is not set, usdc on this chain is swapped for the gas token. Otherwise
+	// the usdc is bridged from the source chain and swapped on this chain.
+	SourceChainID string `yaml:"source_chain_id,omitempty"`
+	// MaxDailyUUSDC is the max amount of uusdc that can be spent topping up
+	// this chains gas balance over a rolling 24h window. If this is not set,
+	// daily top ups are not capped.
+	MaxDailyUUSDC string `yaml:"max_daily_uusdc,omitempty"`
+	// Cooldown is the minimum amount of time between top ups of this chains
+	// gas balance. A chain is never topped up while a previous top up is
+	// still pending.
+	Cooldown time.Duration `yaml:"cooldown,omitempty"`
 }
 
 type CosmosConfig struct {
@@ -329,6 +638,28 @@ type EVMConfig struct {
 	SolverAddress string `yaml:"solver_address"`
 }
 
+type SVMConfig struct {
+	// RPC is the HTTP endpoint for the SVM chain's RPC server
+	RPC string `yaml:"rpc"`
+	// RPCBasicAuthVar is the environment variable name containing the basic auth
+	// credentials for the RPC endpoint if required
+	RPCBasicAuthVar string `yaml:"rpc_basic_auth_var"`
+	// GasBalance contains thresholds for monitoring the solver's gas balance
+	// in lamports
+	SignerGasBalance SignerGasBalanceConfig `yaml:"signer_gas_balance"`
+	// Commitment is the commitment level (processed, confirmed or finalized)
+	// that submitted txs must reach before they are considered landed.
+	// Defaults to confirmed.
+	Commitment string `yaml:"commitment"`
+	// ComputeUnitPriceMicroLamports is the priority fee paid per compute unit
+	// by txs the solver submits. No priority fee is paid if this is not set.
+	ComputeUnitPriceMicroLamports uint64 `yaml:"compute_unit_price_micro_lamports"`
+	// ComputeUnitLimit is the max amount of compute units txs the solver
+	// submits may consume. Only used when a priority fee is set, defaults to
+	// 200000.
+	ComputeUnitLimit uint32 `yaml:"compute_unit_limit"`
+}
+
 type CoingeckoConfig struct {
 	// BaseURL is the coingecko api url used to fetch token prices
 	BaseURL string `yaml:"base_url"`
@@ -364,6 +695,28 @@ func LoadConfig(path string) (Config, error) {
 			return Config{}, fmt.Errorf("invalid configuration for chain %s: %w", chainID, err)
 		}
 	}
+	if err := validateIBCChannels(config.Chains); err != nil {
+		return Config{}, err
+	}
+	for chainID, fundRebalancerConfig := range config.FundRebalancer {
+		if err := validateFundRebalancerForecastConfig(fundRebalancerConfig.Forecast, fundRebalancerConfig.TargetAmount); err != nil {
+			return Config{}, fmt.Errorf("invalid fund rebalancer configuration for chain %s: %w", chainID, err)
+		}
+		if err := validateRebalanceLimitsConfig(fundRebalancerConfig.Limits); err != nil {
+			return Config{}, fmt.Errorf("invalid fund rebalancer configuration for chain %s: %w", chainID, err)
+		}
+		if fundRebalancerConfig.HysteresisBandUUSDC != "" {
+			if _, ok := new(big.Int).SetString(fundRebalancerConfig.HysteresisBandUUSDC, 10); !ok {
+				return Config{}, fmt.Errorf("invalid fund rebalancer configuration for chain %s: hysteresis_band_uusdc must be an integer amount of uusdc", chainID)
+			}
+		}
+	}
+	if err := validateRebalanceLimitsConfig(config.FundRebalancerLimits); err != nil {
+		return Config{}, fmt.Errorf("invalid fund rebalancer limits configuration: %w", err)
+	}
+	if err := validateERC20ApprovalsConfig(config.ERC20Approvals); err != nil {
+		return Config{}, fmt.Errorf("invalid erc20 approvals configuration: %w", err)
+	}
 
 	return config, nil
 }
@@ -401,6 +754,8 @@ type ConfigReader interface {
 
 	GetGasAlertThresholds(chainID string) (warningThreshold, criticalThreshold *big.Int, err error)
 	GetFundRebalancingConfig(chainID string) (FundRebalancerConfig, error)
+
+	GetSettlementRepaymentAddress(chainID string, batchValueUUSDC *big.Int, at time.Time) (string, error)
 }
 
 type configReader struct {
@@ -445,6 +800,15 @@ func (r *configReader) createIndexes() {
 			)
 		}
 
+		if chain.Type == ChainType_SVM && chain.SVM == nil {
+			lmt.Logger(context.Background()).Error(
+				"invalid chain configuration",
+				zap.String("chainID", chain.ChainID),
+				zap.String("type", string(chain.Type)),
+				zap.Bool("hasSVMConfig", chain.SVM != nil),
+			)
+		}
+
 		r.chainIDIndex[chain.ChainID] = chain
 
 		lmt.Logger(context.Background()).Debug(
@@ -478,6 +842,8 @@ func (r configReader) GetRPCEndpoint(chainID string) (string, error) {
 		return chain.Cosmos.RPC, nil
 	case ChainType_EVM:
 		return chain.EVM.RPC, nil
+	case ChainType_SVM:
+		return chain.SVM.RPC, nil
 	}
 
 	return "", fmt.Errorf("unknown chain type")
@@ -495,6 +861,8 @@ func (r configReader) GetBasicAuth(chainID string) (*string, error) {
 		basicAuthVar = chain.Cosmos.RPCBasicAuthVar
 	case ChainType_EVM:
 		basicAuthVar = chain.EVM.RPCBasicAuthVar
+	case ChainType_SVM:
+		basicAuthVar = chain.SVM.RPCBasicAuthVar
 	}
 
 	if basicAuth, ok := os.LookupEnv(basicAuthVar); ok {
@@ -542,6 +910,21 @@ func (r configReader) GetGatewayContractAddress(chainID string) (string, error)
 	}
 }
 
+// RelayRoute returns whether messages from this chain to destinationChainID
+// should be relayed, and how long to defer to the public relayer before
+// relaying them
+func (c RelayerConfig) RelayRoute(destinationChainID string) (enabled bool, publicRelayerGracePeriod time.Duration) {
+	route, ok := c.Routes[destinationChainID]
+	if !ok {
+		return true, c.PublicRelayerGracePeriod
+	}
+	publicRelayerGracePeriod = c.PublicRelayerGracePeriod
+	if route.PublicRelayerGracePeriod != nil {
+		publicRelayerGracePeriod = *route.PublicRelayerGracePeriod
+	}
+	return !route.Disabled, publicRelayerGracePeriod
+}
+
 func (r configReader) GetChainIDByHyperlaneDomain(domain string) (string, error) {
 	for chainID, cfg := range r.chainIDIndex {
 		if cfg.HyperlaneDomain == domain {
@@ -572,6 +955,34 @@ func (r configReader) GetFundRebalancingConfig(chainID string) (FundRebalancerCo
 	return fundRebalancingConfig, nil
 }
 
+// GetSettlementRepaymentAddress returns the address that a settlement batch
+// paid out on chainID with a total value of batchValueUUSDC, initiated at time
+// at, should be repaid to. Configured repayment rules are evaluated in order,
+// falling back to the configured default repayment address and then the
+// solver address if no rules match.
+func (r configReader) GetSettlementRepaymentAddress(chainID string, batchValueUUSDC *big.Int, at time.Time) (string, error) {
+	chain, ok := r.chainIDIndex[chainID]
+	if !ok {
+		return "", fmt.Errorf("chain id %s not found", chainID)
+	}
+
+	if chain.SettlementRepayment != nil {
+		for _, rule := range chain.SettlementRepayment.Rules {
+			if rule.Matches(batchValueUUSDC, at) {
+				return rule.Address, nil
+			}
+		}
+		if chain.SettlementRepayment.Address != "" {
+			return chain.SettlementRepayment.Address, nil
+		}
+	}
+
+	if chain.SolverAddress == "" {
+		return "", fmt.Errorf("solver address not set for chain %s", chainID)
+	}
+	return chain.SolverAddress, nil
+}
+
 func ValidateChainConfig(chain ChainConfig) error {
 	if chain.ChainName == "" {
 		return fmt.Errorf("chain_name is required")
@@ -591,6 +1002,11 @@ func ValidateChainConfig(chain ChainConfig) error {
 	if chain.GasTokenDecimals == 0 {
 		return fmt.Errorf("gas_token_decimals is required")
 	}
+	if chain.Type == ChainType_SVM {
+		// orders are not filled or settled on svm chains yet, so only the
+		// config needed to hold and rebalance usdc is required
+		return validateSVMChainConfig(chain)
+	}
 	if chain.NumBlockConfirmationsBeforeFill == 0 {
 		return fmt.Errorf("num_block_confirmations_before_fill is required")
 	}
@@ -621,6 +1037,33 @@ func ValidateChainConfig(chain ChainConfig) error {
 	if chain.Relayer.MailboxAddress == "" {
 		return fmt.Errorf("relayer.mailbox_address is required")
 	}
+	for validator, storageLocation := range chain.Relayer.CheckpointStorageLocationOverrides {
+		if !common.IsHexAddress(validator) {
+			return fmt.Errorf("relayer.checkpoint_storage_location_overrides validator %s must be a hex address", validator)
+		}
+		if storageLocation == "" {
+			return fmt.Errorf("relayer.checkpoint_storage_location_overrides storage location is required for validator %s", validator)
+		}
+	}
+
+	if chain.SettlementRepayment != nil {
+		if err := validateSettlementRepaymentConfig(chain.Type, chain.SettlementRepayment); err != nil {
+			return err
+		}
+	}
+	if chain.IBC != nil {
+		if err := validateIBCConfig(chain); err != nil {
+			return err
+		}
+	}
+	if chain.CCTP != nil && chain.Type == ChainType_EVM {
+		if !common.IsHexAddress(chain.CCTP.TokenMessengerAddress) {
+			return fmt.Errorf("cctp.token_messenger_address must be a hex address")
+		}
+		if !common.IsHexAddress(chain.CCTP.MessageTransmitterAddress) {
+			return fmt.Errorf("cctp.message_transmitter_address must be a hex address")
+		}
+	}
 
 	switch chain.Type {
 	case ChainType_COSMOS:
@@ -642,6 +1085,55 @@ func ValidateChainConfig(chain ChainConfig) error {
 	}
 }
 
+func validateSVMChainConfig(chain ChainConfig) error {
+	if chain.SolverAddress == "" {
+		return fmt.Errorf("solver_address is required")
+	}
+	if _, err := solana.PublicKeyFromBase58(chain.SolverAddress); err != nil {
+		return fmt.Errorf("solver_address must be a base58 address: %w", err)
+	}
+	if chain.USDCDenom == "" {
+		return fmt.Errorf("usdc_denom is required")
+	}
+	if _, err := solana.PublicKeyFromBase58(chain.USDCDenom); err != nil {
+		return fmt.Errorf("usdc_denom must be the base58 address of the usdc mint: %w", err)
+	}
+	if chain.CCTP != nil {
+		if _, err := solana.PublicKeyFromBase58(chain.CCTP.TokenMessengerAddress); err != nil {
+			return fmt.Errorf("cctp.token_messenger_address must be the base58 address of the token messenger minter program: %w", err)
+		}
+		if _, err := solana.PublicKeyFromBase58(chain.CCTP.MessageTransmitterAddress); err != nil {
+			return fmt.Errorf("cctp.message_transmitter_address must be the base58 address of the message transmitter program: %w", err)
+		}
+	}
+	if chain.SVM == nil {
+		return fmt.Errorf("svm config is required for svm chain type")
+	}
+	return validateSVMConfig(chain.SVM)
+}
+
+func validateSVMConfig(config *SVMConfig) error {
+	if config.RPC == "" {
+		return fmt.Errorf("svm.rpc is required")
+	}
+	switch config.Commitment {
+	case "", "processed", "confirmed", "finalized":
+	default:
+		return fmt.Errorf("svm.commitment must be one of processed, confirmed or finalized")
+	}
+
+	if config.SignerGasBalance.WarningThresholdWei == "" {
+		return fmt.Errorf("svm.signer_gas_balance.warning_threshold_wei is required")
+	}
+	if config.SignerGasBalance.CriticalThresholdWei == "" {
+		return fmt.Errorf("svm.signer_gas_balance.critical_threshold_wei is required")
+	}
+	if config.SignerGasBalance.TopUp != nil {
+		return fmt.Errorf("svm.signer_gas_balance.top_up is not supported on svm chains")
+	}
+	return nil
+}
+
 func validateCosmosConfig(config *CosmosConfig, relayerConfig *RelayerConfig) error {
 	if config.RPC == "" {
 		return fmt.Errorf("cosmos.rpc is required")
@@ -674,6 +1166,9 @@ func validateCosmosConfig(config *CosmosConfig, relayerConfig *RelayerConfig) er
 	if config.SignerGasBalance.CriticalThresholdWei == "" {
 		return fmt.Errorf("cosmos.signer_gas_balance.critical_threshold_wei is required")
 	}
+	if err := validateGasTopUpConfig(config.SignerGasBalance.TopUp); err != nil {
+		return fmt.Errorf("cosmos.signer_gas_balance.%w", err)
+	}
 
 	if relayerConfig.ValidatorAnnounceContractAddress == "" {
 		return fmt.Errorf("relayer.validator_announce_contract_address is required")
@@ -685,6 +1180,222 @@ func validateCosmosConfig(config *CosmosConfig, relayerConfig *RelayerConfig) er
 	return nil
 }
 
+func validateIBCConfig(chain ChainConfig) error {
+	if chain.Type != ChainType_COSMOS {
+		return fmt.Errorf("ibc is only supported on cosmos chains")
+	}
+	for destinationChainID, channel := range chain.IBC.Channels {
+		if destinationChainID == chain.ChainID {
+			return fmt.Errorf("ibc.channels can not contain a channel to chain %s itself", chain.ChainID)
+		}
+		if !ibcChannelRegex.MatchString(channel) {
+			return fmt.Errorf("ibc.channels channel %s to chain %s must be of the form channel-<n>", channel, destinationChainID)
+		}
+	}
+	if chain.IBC.TransferTimeout < 0 {
+		return fmt.Errorf("ibc.transfer_timeout can not be negative")
+	}
+	return nil
+}
+
+// validateIBCChannels checks that usdc sent over each configured ibc channel
+// arrives on the destination chain as its usdc_denom. Transfers always send
+// the source chain's usdc_denom, which only arrives as the destination's
+// usdc_denom if either the source chain issues usdc and the destination's
+// usdc_denom is the voucher received over the channel, or the destination
+// chain issues usdc and the source's usdc_denom is the voucher that the
+// channel unwinds. usdc sent between two chains that both hold vouchers would
+// arrive as a voucher of a voucher instead.
+func validateIBCChannels(chains map[string]ChainConfig) error {
+	chainsByID := make(map[string]ChainConfig)
+	for _, chain := range chains {
+		chainsByID[chain.ChainID] = chain
+	}
+
+	for _, source := range chains {
+		if source.IBC == nil {
+			continue
+		}
+		for destinationChainID, channel := range source.IBC.Channels {
+			destination, ok := chainsByID[destinationChainID]
+			if !ok || destination.Type != ChainType_COSMOS {
+				// channels to chains that are not configured are never used
+				continue
+			}
+
+			switch {
+			case isIBCVoucherDenom(destination.USDCDenom) && !isIBCVoucherDenom(source.USDCDenom):
+				// the destination chain receives usdc from its issuer over
+				// the counterparty of this channel
+				var counterpartyChannel string
+				if destination.IBC != nil {
+					counterpartyChannel = destination.IBC.Channels[source.ChainID]
+				}
+				if counterpartyChannel == "" {
+					return fmt.Errorf("invalid ibc configuration for chain %s: chain %s must configure its ibc channel back to chain %s to receive usdc over channel %s", source.ChainID, destinationChainID, source.ChainID, channel)
+				}
+				if voucher := ibcVoucherDenom(counterpartyChannel, source.USDCDenom); voucher != destination.USDCDenom {
+					return fmt.Errorf("invalid ibc configuration for chain %s: usdc sent to chain %s over channel %s arrives as %s, not its usdc_denom %s", source.ChainID, destinationChainID, channel, voucher, destination.USDCDenom)
+				}
+			case isIBCVoucherDenom(source.USDCDenom) && !isIBCVoucherDenom(destination.USDCDenom):
+				// usdc sent back to its issuer is unwound if it was received
+				// over this channel
+				if voucher := ibcVoucherDenom(channel, destination.USDCDenom); voucher != source.USDCDenom {
+					return fmt.Errorf("invalid ibc configuration for chain %s: usdc_denom %s is not unwound to %s on chain %s by channel %s", source.ChainID, source.USDCDenom, destination.USDCDenom, destinationChainID, channel)
+				}
+			default:
+				return fmt.Errorf("invalid ibc configuration for chain %s: usdc can only be sent over ibc to or from its issuer, so chain %s can not be sent usdc over channel %s", source.ChainID, destinationChainID, channel)
+			}
+		}
+	}
+	return nil
+}
+
+func isIBCVoucherDenom(denom string) bool {
+	return strings.HasPrefix(denom, "ibc/")
+}
+
+// ibcVoucherDenom returns the denom that baseDenom is received as over the
+// transfer channel on the receiving chain
+func ibcVoucherDenom(channel, baseDenom string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("transfer/%s/%s", channel, baseDenom)))
+	return "ibc/" + strings.ToUpper(hex.EncodeToString(hash[:]))
+}
+
+func validateSettlementRepaymentConfig(chainType ChainType, config *SettlementRepaymentConfig) error {
+	if chainType != ChainType_EVM {
+		return fmt.Errorf("settlement_repayment is only supported on evm chains")
+	}
+	if config.Address != "" && !common.IsHexAddress(config.Address) {
+		return fmt.Errorf("settlement_repayment.address %s is not a valid evm address", config.Address)
+	}
+
+	for i, rule := range config.Rules {
+		if rule.Address == "" {
+			return fmt.Errorf("settlement_repayment.rules[%d].address is required", i)
+		}
+		if !common.IsHexAddress(rule.Address) {
+			return fmt.Errorf("settlement_repayment.rules[%d].address %s is not a valid evm address", i, rule.Address)
+		}
+
+		var min, max *big.Int
+		if rule.MinBatchValueUUSDC != "" {
+			var ok bool
+			if min, ok = new(big.Int).SetString(rule.MinBatchValueUUSDC, 10); !ok {
+				return fmt.Errorf("settlement_repayment.rules[%d].min_batch_value_uusdc must be an integer", i)
+			}
+		}
+		if rule.MaxBatchValueUUSDC != "" {
+			var ok bool
+			if max, ok = new(big.Int).SetString(rule.MaxBatchValueUUSDC, 10); !ok {
+				return fmt.Errorf("settlement_repayment.rules[%d].max_batch_value_uusdc must be an integer", i)
+			}
+		}
+		if min != nil && max != nil && max.Cmp(min) <= 0 {
+			return fmt.Errorf("settlement_repayment.rules[%d].max_batch_value_uusdc must be greater than min_batch_value_uusdc", i)
+		}
+
+		if (rule.StartHourUTC == nil) != (rule.EndHourUTC == nil) {
+			return fmt.Errorf("settlement_repayment.rules[%d] must set both start_hour_utc and end_hour_utc or neither", i)
+		}
+		if rule.StartHourUTC != nil {
+			if *rule.StartHourUTC < 0 || *rule.StartHourUTC > 23 || *rule.EndHourUTC < 0 || *rule.EndHourUTC > 23 {
+				return fmt.Errorf("settlement_repayment.rules[%d] hours must be between 0 and 23", i)
+			}
+			if *rule.StartHourUTC == *rule.EndHourUTC {
+				return fmt.Errorf("settlement_repayment.rules[%d].start_hour_utc and end_hour_utc must be different", i)
+			}
+		}
+
+		if rule.MinBatchValueUUSDC == "" && rule.MaxBatchValueUUSDC == "" && rule.StartHourUTC == nil {
+			return fmt.Errorf("settlement_repayment.rules[%d] must have at least one batch value or time condition", i)
+		}
+	}
+
+	return nil
+}
+
+func validateFundRebalancerForecastConfig(config *FundRebalancerForecastConfig, targetAmount string) error {
+	if config == nil {
+		return nil
+	}
+	if config.LeadTime <= 0 || config.LeadTime > 24*time.Hour {
+		return fmt.Errorf("forecast.lead_time must be greater than 0 and at most 24h")
+	}
+	if config.LookbackDays < 0 {
+		return fmt.Errorf("forecast.lookback_days must not be negative")
+	}
+	maxTargetAmount, ok := new(big.Int).SetString(config.MaxTargetAmount, 10)
+	if !ok {
+		return fmt.Errorf("forecast.max_target_amount must be an integer amount of uusdc")
+	}
+	target, ok := new(big.Int).SetString(targetAmount, 10)
+	if !ok {
+		return fmt.Errorf("target_amount must be an integer amount of uusdc")
+	}
+	// forecasts never lower the target amount, so a max target amount below
+	// it would silently have no effect
+	if maxTargetAmount.Cmp(target) < 0 {
+		return fmt.Errorf("forecast.max_target_amount %s must not be less than target_amount %s", config.MaxTargetAmount, targetAmount)
+	}
+	return nil
+}
+
+func validateRebalanceLimitsConfig(config *RebalanceLimitsConfig) error {
+	if config == nil {
+		return nil
+	}
+	amounts := map[string]string{
+		"max_transfer_amount_uusdc": config.MaxTransferAmountUUSDC,
+		"max_daily_volume_uusdc":    config.MaxDailyVolumeUUSDC,
+		"max_daily_fees_uusdc":      config.MaxDailyFeesUUSDC,
+	}
+	for name, amount := range amounts {
+		if amount == "" {
+			continue
+		}
+		if _, ok := new(big.Int).SetString(amount, 10); !ok {
+			return fmt.Errorf("limits.%s must be an integer amount of uusdc", name)
+		}
+	}
+	if config.Cooldown < 0 {
+		return fmt.Errorf("limits.cooldown must not be negative")
+	}
+	return nil
+}
+
+func validateERC20ApprovalsConfig(config ERC20ApprovalsConfig) error {
+	if config.BufferUUSDC != "" {
+		buffer, ok := new(big.Int).SetString(config.BufferUUSDC, 10)
+		if !ok || buffer.Sign() < 0 {
+			return fmt.Errorf("erc20_approvals.buffer_uusdc must be a non negative integer amount of uusdc")
+		}
+	}
+	if config.RevokeIdleAfter < 0 {
+		return fmt.Errorf("erc20_approvals.revoke_idle_after must not be negative")
+	}
+	return nil
+}
+
+func validateGasTopUpConfig(config *GasTopUpConfig) error {
+	if config == nil {
+		return nil
+	}
+	amount, ok := new(big.Int).SetString(config.AmountUUSDC, 10)
+	if !ok || amount.Sign() <= 0 {
+		return fmt.Errorf("top_up.amount_uusdc must be a positive integer amount of uusdc")
+	}
+	if config.MaxDailyUUSDC != "" {
+		if _, ok := new(big.Int).SetString(config.MaxDailyUUSDC, 10); !ok {
+			return fmt.Errorf("top_up.max_daily_uusdc must be an integer amount of uusdc")
+		}
+	}
+	if config.Cooldown < 0 {
+		return fmt.Errorf("top_up.cooldown must not be negative")
+	}
+	return nil
+}
+
 func validateEVMConfig(config *EVMConfig) error {
 	if config.RPC == "" {
 		return fmt.Errorf("evm.rpc is required")
@@ -696,6 +1407,12 @@ func validateEVMConfig(config *EVMConfig) error {
 	if config.SignerGasBalance.CriticalThresholdWei == "" {
 		return fmt.Errorf("evm.signer_gas_balance.critical_threshold_wei is required")
 	}
+	if err := validateGasTopUpConfig(config.SignerGasBalance.TopUp); err != nil {
+		return fmt.Errorf("evm.signer_gas_balance.%w", err)
+	}
+	if topUp := config.SignerGasBalance.TopUp; topUp != nil && topUp.GasTokenDenom == "" {
+		return fmt.Errorf("evm.signer_gas_balance.top_up.gas_token_denom is required")
+	}
 
 	return nil
 }
@@ -714,6 +1431,9 @@ func (r configReader) GetGasAlertThresholds(chainID string) (warningThreshold, c
 	case ChainType_EVM:
 		warningThresholdString = chain.EVM.SignerGasBalance.WarningThresholdWei
 		criticalThresholdString = chain.EVM.SignerGasBalance.CriticalThresholdWei
+	case ChainType_SVM:
+		warningThresholdString = chain.SVM.SignerGasBalance.WarningThresholdWei
+		criticalThresholdString = chain.SVM.SignerGasBalance.CriticalThresholdWei
 	default:
 		return nil, nil, fmt.Errorf("unknown chain type")
 	}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hour(h int) *int {
+	return &h
+}
+
+func at(h int) time.Time {
+	return time.Date(2024, 1, 1, h, 30, 0, 0, time.UTC)
+}
+
+func TestRepaymentAddressRuleMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    RepaymentAddressRule
+		value   int64
+		at      time.Time
+		matches bool
+	}{
+		{name: "below min", rule: RepaymentAddressRule{MinBatchValueUUSDC: "100"}, value: 99, at: at(12), matches: false},
+		{name: "min is inclusive", rule: RepaymentAddressRule{MinBatchValueUUSDC: "100"}, value: 100, at: at(12), matches: true},
+		{name: "below max", rule: RepaymentAddressRule{MaxBatchValueUUSDC: "100"}, value: 99, at: at(12), matches: true},
+		{name: "max is exclusive", rule: RepaymentAddressRule{MaxBatchValueUUSDC: "100"}, value: 100, at: at(12), matches: false},
+		{name: "within min and max", rule: RepaymentAddressRule{MinBatchValueUUSDC: "10", MaxBatchValueUUSDC: "100"}, value: 50, at: at(12), matches: true},
+		{name: "before window", rule: RepaymentAddressRule{StartHourUTC: hour(9), EndHourUTC: hour(17)}, value: 1, at: at(8), matches: false},
+		{name: "window start is inclusive", rule: RepaymentAddressRule{StartHourUTC: hour(9), EndHourUTC: hour(17)}, value: 1, at: at(9), matches: true},
+		{name: "window end is exclusive", rule: RepaymentAddressRule{StartHourUTC: hour(9), EndHourUTC: hour(17)}, value: 1, at: at(17), matches: false},
+		{name: "wrapping window before midnight", rule: RepaymentAddressRule{StartHourUTC: hour(22), EndHourUTC: hour(6)}, value: 1, at: at(23), matches: true},
+		{name: "wrapping window after midnight", rule: RepaymentAddressRule{StartHourUTC: hour(22), EndHourUTC: hour(6)}, value: 1, at: at(0), matches: true},
+		{name: "wrapping window end is exclusive", rule: RepaymentAddressRule{StartHourUTC: hour(22), EndHourUTC: hour(6)}, value: 1, at: at(6), matches: false},
+		{name: "outside wrapping window", rule: RepaymentAddressRule{StartHourUTC: hour(22), EndHourUTC: hour(6)}, value: 1, at: at(12), matches: false},
+		{name: "window is evaluated in utc", rule: RepaymentAddressRule{StartHourUTC: hour(9), EndHourUTC: hour(17)}, value: 1, at: at(12).In(time.FixedZone("UTC-10", -10*60*60)), matches: true},
+		{name: "value and window must both match", rule: RepaymentAddressRule{MinBatchValueUUSDC: "100", StartHourUTC: hour(9), EndHourUTC: hour(17)}, value: 50, at: at(12), matches: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, tt.rule.Matches(big.NewInt(tt.value), tt.at))
+		})
+	}
+}
+
+func TestGetSettlementRepaymentAddress(t *testing.T) {
+	const (
+		solverAddress  = "0x0000000000000000000000000000000000000001"
+		defaultAddress = "0x0000000000000000000000000000000000000002"
+		largeAddress   = "0x0000000000000000000000000000000000000003"
+		nightAddress   = "0x0000000000000000000000000000000000000004"
+	)
+	rules := []RepaymentAddressRule{
+		{Address: largeAddress, MinBatchValueUUSDC: "1000"},
+		{Address: nightAddress, StartHourUTC: hour(22), EndHourUTC: hour(6)},
+	}
+
+	tests := []struct {
+		name       string
+		repayment  *SettlementRepaymentConfig
+		value      int64
+		at         time.Time
+		expAddress string
+	}{
+		{name: "no repayment config uses solver address", repayment: nil, value: 5000, at: at(23), expAddress: solverAddress},
+		{name: "first matching rule takes precedence", repayment: &SettlementRepaymentConfig{Address: defaultAddress, Rules: rules}, value: 5000, at: at(23), expAddress: largeAddress},
+		{name: "later rule matches when earlier rules do not", repayment: &SettlementRepaymentConfig{Address: defaultAddress, Rules: rules}, value: 10, at: at(23), expAddress: nightAddress},
+		{name: "no matching rule uses default address", repayment: &SettlementRepaymentConfig{Address: defaultAddress, Rules: rules}, value: 10, at: at(12), expAddress: defaultAddress},
+		{name: "no matching rule or default address uses solver address", repayment: &SettlementRepaymentConfig{Rules: rules}, value: 10, at: at(12), expAddress: solverAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewConfigReader(Config{Chains: map[string]ChainConfig{
+				"ethereum": {ChainID: "1", Type: ChainType_EVM, SolverAddress: solverAddress, SettlementRepayment: tt.repayment},
+			}})
+			address, err := reader.GetSettlementRepaymentAddress("1", big.NewInt(tt.value), tt.at)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expAddress, address)
+		})
+	}
+
+	t.Run("missing solver address errors", func(t *testing.T) {
+		reader := NewConfigReader(Config{Chains: map[string]ChainConfig{
+			"ethereum": {ChainID: "1", Type: ChainType_EVM},
+		}})
+		_, err := reader.GetSettlementRepaymentAddress("1", big.NewInt(10), at(12))
+		require.Error(t, err)
+	})
+}
+
+func TestValidateSettlementRepaymentConfig(t *testing.T) {
+	const address = "0x0000000000000000000000000000000000000001"
+
+	tests := []struct {
+		name      string
+		chainType ChainType
+		config    SettlementRepaymentConfig
+		expErr    bool
+	}{
+		{name: "valid rules", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Address: address, Rules: []RepaymentAddressRule{
+			{Address: address, MinBatchValueUUSDC: "10", MaxBatchValueUUSDC: "100"},
+			{Address: address, StartHourUTC: hour(22), EndHourUTC: hour(6)},
+		}}},
+		{name: "non evm chain", chainType: ChainType_COSMOS, config: SettlementRepaymentConfig{Address: address}, expErr: true},
+		{name: "invalid default address", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Address: "osmo1abc"}, expErr: true},
+		{name: "missing rule address", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Rules: []RepaymentAddressRule{{MinBatchValueUUSDC: "10"}}}, expErr: true},
+		{name: "non integer min", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Rules: []RepaymentAddressRule{{Address: address, MinBatchValueUUSDC: "1.5"}}}, expErr: true},
+		{name: "max not greater than min", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Rules: []RepaymentAddressRule{{Address: address, MinBatchValueUUSDC: "100", MaxBatchValueUUSDC: "100"}}}, expErr: true},
+		{name: "only start hour", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Rules: []RepaymentAddressRule{{Address: address, StartHourUTC: hour(1)}}}, expErr: true},
+		{name: "hour out of range", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Rules: []RepaymentAddressRule{{Address: address, StartHourUTC: hour(1), EndHourUTC: hour(24)}}}, expErr: true},
+		{name: "empty window", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Rules: []RepaymentAddressRule{{Address: address, StartHourUTC: hour(5), EndHourUTC: hour(5)}}}, expErr: true},
+		{name: "rule without conditions", chainType: ChainType_EVM, config: SettlementRepaymentConfig{Rules: []RepaymentAddressRule{{Address: address}}}, expErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSettlementRepaymentConfig(tt.chainType, &tt.config)
+			if tt.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateFundRebalancerForecastConfig(t *testing.T) {
+	forecast := func(maxTargetAmount string) *FundRebalancerForecastConfig {
+		return &FundRebalancerForecastConfig{LeadTime: time.Hour, MaxTargetAmount: maxTargetAmount}
+	}
+
+	tests := []struct {
+		name         string
+		config       *FundRebalancerForecastConfig
+		targetAmount string
+		expErr       bool
+	}{
+		{name: "forecasting disabled", targetAmount: "1000"},
+		{name: "max target above target", config: forecast("2000"), targetAmount: "1000"},
+		{name: "max target equal to target", config: forecast("1000"), targetAmount: "1000"},
+		{name: "max target below target", config: forecast("999"), targetAmount: "1000", expErr: true},
+		{name: "non integer max target", config: forecast("1.5"), targetAmount: "1000", expErr: true},
+		{name: "non integer target", config: forecast("2000"), targetAmount: "", expErr: true},
+		{name: "no lead time", config: &FundRebalancerForecastConfig{MaxTargetAmount: "2000"}, targetAmount: "1000", expErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFundRebalancerForecastConfig(tt.config, tt.targetAmount)
+			if tt.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateIBCChannels(t *testing.T) {
+	const (
+		// the denom of usdc on osmosis, received from noble over channel-750
+		osmosisUSDCDenom = "ibc/498A0751C798A0D9A389AA3691123DADA57DAA4FE165D5C75894505B876BA6E4"
+		otherUSDCDenom   = "ibc/0000000000000000000000000000000000000000000000000000000000000000"
+	)
+	noble := func(channels map[string]string) ChainConfig {
+		return ChainConfig{ChainID: "noble-1", Type: ChainType_COSMOS, USDCDenom: "uusdc", IBC: &IBCConfig{Channels: channels}}
+	}
+	osmosis := func(usdcDenom string, channels map[string]string) ChainConfig {
+		return ChainConfig{ChainID: "osmosis-1", Type: ChainType_COSMOS, USDCDenom: usdcDenom, IBC: &IBCConfig{Channels: channels}}
+	}
+
+	tests := []struct {
+		name   string
+		chains map[string]ChainConfig
+		expErr bool
+	}{
+		{name: "channels between noble and the chain it issues usdc to", chains: map[string]ChainConfig{
+			"noble":   noble(map[string]string{"osmosis-1": "channel-1"}),
+			"osmosis": osmosis(osmosisUSDCDenom, map[string]string{"noble-1": "channel-750"}),
+		}},
+		{name: "channel to unconfigured chain is ignored", chains: map[string]ChainConfig{
+			"osmosis": osmosis(osmosisUSDCDenom, map[string]string{"neutron-1": "channel-10"}),
+		}},
+		{name: "channel back to noble does not unwind usdc", chains: map[string]ChainConfig{
+			"noble":   noble(nil),
+			"osmosis": osmosis(osmosisUSDCDenom, map[string]string{"noble-1": "channel-751"}),
+		}, expErr: true},
+		{name: "usdc from noble does not arrive as usdc denom", chains: map[string]ChainConfig{
+			"noble":   noble(map[string]string{"osmosis-1": "channel-1"}),
+			"osmosis": osmosis(otherUSDCDenom, map[string]string{"noble-1": "channel-750"}),
+		}, expErr: true},
+		{name: "channel from noble without channel back to noble", chains: map[string]ChainConfig{
+			"noble":   noble(map[string]string{"osmosis-1": "channel-1"}),
+			"osmosis": osmosis(osmosisUSDCDenom, nil),
+		}, expErr: true},
+		{name: "channel between chains that do not issue usdc", chains: map[string]ChainConfig{
+			"osmosis": osmosis(osmosisUSDCDenom, map[string]string{"neutron-1": "channel-10"}),
+			"neutron": {ChainID: "neutron-1", Type: ChainType_COSMOS, USDCDenom: otherUSDCDenom},
+		}, expErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateIBCChannels(tt.chains)
+			if tt.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package routing_ism
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// RoutingIsmMetaData contains all meta data concerning the RoutingIsm contract.
+var RoutingIsmMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"moduleType\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"_message\",\"type\":\"bytes\"}],\"name\":\"route\",\"outputs\":[{\"internalType\":\"contractIInterchainSecurityModule\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"_metadata\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_message\",\"type\":\"bytes\"}],\"name\":\"verify\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// RoutingIsmABI is the input ABI used to generate the binding from.
+// Deprecated: Use RoutingIsmMetaData.ABI instead.
+var RoutingIsmABI = RoutingIsmMetaData.ABI
+
+// RoutingIsm is an auto generated Go binding around an Ethereum contract.
+type RoutingIsm struct {
+	RoutingIsmCaller     // Read-only binding to the contract
+	RoutingIsmTransactor // Write-only binding to the contract
+	RoutingIsmFilterer   // Log filterer for contract events
+}
+
+// RoutingIsmCaller is an auto generated read-only Go binding around an Ethereum contract.
+type RoutingIsmCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RoutingIsmTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type RoutingIsmTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RoutingIsmFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type RoutingIsmFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RoutingIsmSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type RoutingIsmSession struct {
+	Contract     *RoutingIsm       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// RoutingIsmCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type RoutingIsmCallerSession struct {
+	Contract *RoutingIsmCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// RoutingIsmTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type RoutingIsmTransactorSession struct {
+	Contract     *RoutingIsmTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// RoutingIsmRaw is an auto generated low-level Go binding around an Ethereum contract.
+type RoutingIsmRaw struct {
+	Contract *RoutingIsm // Generic contract binding to access the raw methods on
+}
+
+// RoutingIsmCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type RoutingIsmCallerRaw struct {
+	Contract *RoutingIsmCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// RoutingIsmTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type RoutingIsmTransactorRaw struct {
+	Contract *RoutingIsmTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewRoutingIsm creates a new instance of RoutingIsm, bound to a specific deployed contract.
+func NewRoutingIsm(address common.Address, backend bind.ContractBackend) (*RoutingIsm, error) {
+	contract, err := bindRoutingIsm(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &RoutingIsm{RoutingIsmCaller: RoutingIsmCaller{contract: contract}, RoutingIsmTransactor: RoutingIsmTransactor{contract: contract}, RoutingIsmFilterer: RoutingIsmFilterer{contract: contract}}, nil
+}
+
+// NewRoutingIsmCaller creates a new read-only instance of RoutingIsm, bound to a specific deployed contract.
+func NewRoutingIsmCaller(address common.Address, caller bind.ContractCaller) (*RoutingIsmCaller, error) {
+	contract, err := bindRoutingIsm(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RoutingIsmCaller{contract: contract}, nil
+}
+
+// NewRoutingIsmTransactor creates a new write-only instance of RoutingIsm, bound to a specific deployed contract.
+func NewRoutingIsmTransactor(address common.Address, transactor bind.ContractTransactor) (*RoutingIsmTransactor, error) {
+	contract, err := bindRoutingIsm(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RoutingIsmTransactor{contract: contract}, nil
+}
+
+// NewRoutingIsmFilterer creates a new log filterer instance of RoutingIsm, bound to a specific deployed contract.
+func NewRoutingIsmFilterer(address common.Address, filterer bind.ContractFilterer) (*RoutingIsmFilterer, error) {
+	contract, err := bindRoutingIsm(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &RoutingIsmFilterer{contract: contract}, nil
+}
+
+// bindRoutingIsm binds a generic wrapper to an already deployed contract.
+func bindRoutingIsm(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := RoutingIsmMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_RoutingIsm *RoutingIsmRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _RoutingIsm.Contract.RoutingIsmCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_RoutingIsm *RoutingIsmRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _RoutingIsm.Contract.RoutingIsmTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_RoutingIsm *RoutingIsmRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _RoutingIsm.Contract.RoutingIsmTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_RoutingIsm *RoutingIsmCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _RoutingIsm.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_RoutingIsm *RoutingIsmTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _RoutingIsm.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_RoutingIsm *RoutingIsmTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _RoutingIsm.Contract.contract.Transact(opts, method, params...)
+}
+
+// ModuleType is a free data retrieval call binding the contract method 0x6465e69f.
+//
+// Solidity: function moduleType() view returns(uint8)
+func (_RoutingIsm *RoutingIsmCaller) ModuleType(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _RoutingIsm.contract.Call(opts, &out, "moduleType")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// ModuleType is a free data retrieval call binding the contract method 0x6465e69f.
+//
+// Solidity: function moduleType() view returns(uint8)
+func (_RoutingIsm *RoutingIsmSession) ModuleType() (uint8, error) {
+	return _RoutingIsm.Contract.ModuleType(&_RoutingIsm.CallOpts)
+}
+
+// ModuleType is a free data retrieval call binding the contract method 0x6465e69f.
+//
+// Solidity: function moduleType() view returns(uint8)
+func (_RoutingIsm *RoutingIsmCallerSession) ModuleType() (uint8, error) {
+	return _RoutingIsm.Contract.ModuleType(&_RoutingIsm.CallOpts)
+}
+
+// Route is a free data retrieval call binding the contract method 0x15ce45a2.
+//
+// Solidity: function route(bytes _message) view returns(address)
+func (_RoutingIsm *RoutingIsmCaller) Route(opts *bind.CallOpts, _message []byte) (common.Address, error) {
+	var out []interface{}
+	err := _RoutingIsm.contract.Call(opts, &out, "route", _message)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Route is a free data retrieval call binding the contract method 0x15ce45a2.
+//
+// Solidity: function route(bytes _message) view returns(address)
+func (_RoutingIsm *RoutingIsmSession) Route(_message []byte) (common.Address, error) {
+	return _RoutingIsm.Contract.Route(&_RoutingIsm.CallOpts, _message)
+}
+
+// Route is a free data retrieval call binding the contract method 0x15ce45a2.
+//
+// Solidity: function route(bytes _message) view returns(address)
+func (_RoutingIsm *RoutingIsmCallerSession) Route(_message []byte) (common.Address, error) {
+	return _RoutingIsm.Contract.Route(&_RoutingIsm.CallOpts, _message)
+}
+
+// Verify is a paid mutator transaction binding the contract method 0xf7e83aee.
+//
+// Solidity: function verify(bytes _metadata, bytes _message) returns(bool)
+func (_RoutingIsm *RoutingIsmTransactor) Verify(opts *bind.TransactOpts, _metadata []byte, _message []byte) (*types.Transaction, error) {
+	return _RoutingIsm.contract.Transact(opts, "verify", _metadata, _message)
+}
+
+// Verify is a paid mutator transaction binding the contract method 0xf7e83aee.
+//
+// Solidity: function verify(bytes _metadata, bytes _message) returns(bool)
+func (_RoutingIsm *RoutingIsmSession) Verify(_metadata []byte, _message []byte) (*types.Transaction, error) {
+	return _RoutingIsm.Contract.Verify(&_RoutingIsm.TransactOpts, _metadata, _message)
+}
+
+// Verify is a paid mutator transaction binding the contract method 0xf7e83aee.
+//
+// Solidity: function verify(bytes _metadata, bytes _message) returns(bool)
+func (_RoutingIsm *RoutingIsmTransactorSession) Verify(_metadata []byte, _message []byte) (*types.Transaction, error) {
+	return _RoutingIsm.Contract.Verify(&_RoutingIsm.TransactOpts, _metadata, _message)
+}
@@ -24,6 +24,10 @@ const (
 	gasBalanceLevelLabel    = "gas_balance_level"
 	gasTokenSymbolLabel     = "gas_token_symbol"
 	chainNameLabel          = "chain_name"
+	validatorLabel          = "validator"
+	reasonLabel             = "reason"
+	fetchStatusLabel        = "fetch_status"
+	limitLabel              = "limit"
 )
 
 type Metrics interface {
@@ -37,8 +41,14 @@ type Metrics interface {
 	ObserveSettlementLatency(sourceChainID, destinationChainID string, settlementStatus string, latency time.Duration)
 
 	IncFundsRebalanceTransferStatusChange(sourceChainID, destinationChainID string, transferStatus string)
+	IncFundsRebalanceLimitHit(chainID, limit string)
+	SetFundsRebalanceBudgetUsage(chainID, limit string, used, budget *big.Int)
 
 	IncHyperlaneCheckpointingErrors()
+	IncHyperlaneCheckpointSignatureMismatches(validator, reason string)
+	ObserveHyperlaneCheckpointFetchLatency(validator, fetchStatus string, latency time.Duration)
+	SetHyperlaneValidatorLag(chainID, validator string, lag uint64)
+	SetHyperlaneRouteQuorum(sourceChainID, destinationChainID string, reachable, alerting bool)
 	IncHyperlaneMessages(sourceChainID, destinationChainID string, messageStatus string)
 	ObserveHyperlaneLatency(sourceChainID, destinationChainID, transferStatus string, latency time.Duration)
 	IncHyperlaneRelayTooExpensive(sourceChainID, destinationChainID string)
@@ -84,9 +94,17 @@ type PromMetrics struct {
 	excessiveOrderFulfillmentLatency metrics.Counter
 
 	fundRebalanceTransferStatusChange metrics.Counter
+	fundRebalanceLimitHits            metrics.Counter
+	fundRebalanceBudgetUsed           metrics.Gauge
+	fundRebalanceBudget               metrics.Gauge
 
 	hplMessageStatusChange         metrics.Counter
 	hplCheckpointingErrors         metrics.Counter
+	hplCheckpointSigMismatches     metrics.Counter
+	hplCheckpointFetchLatency      metrics.Histogram
+	hplValidatorLag                metrics.Gauge
+	hplRouteQuorumReachable        metrics.Gauge
+	hplRouteQuorumAlert            metrics.Gauge
 	hplLatency                     metrics.Histogram
 	hplRelayTooExpensive           metrics.Counter
 	excessiveHyperlaneRelayLatency metrics.Counter
@@ -126,6 +144,21 @@ func NewPromMetrics() Metrics {
 			Name:      "funds_rebalance_transfer_status_change_counter",
 			Help:      "numbers of funds rebalance transfer status changes, paginated by source and destination chain, and status",
 		}, []string{sourceChainIDLabel, destinationChainIDLabel, transferStatusLabel}),
+		fundRebalanceLimitHits: prom.NewCounterFrom(stdprom.CounterOpts{
+			Namespace: "solver",
+			Name:      "funds_rebalance_limit_hit_counter",
+			Help:      "number of funds rebalances capped or blocked by a rebalance limit, paginated by destination chain (or all for global limits) and limit",
+		}, []string{chainIDLabel, limitLabel}),
+		fundRebalanceBudgetUsed: prom.NewGaugeFrom(stdprom.GaugeOpts{
+			Namespace: "solver",
+			Name:      "funds_rebalance_budget_used_gauge",
+			Help:      "uusdc of a daily funds rebalance budget used in the last 24 hours, paginated by destination chain (or all for global limits) and limit",
+		}, []string{chainIDLabel, limitLabel}),
+		fundRebalanceBudget: prom.NewGaugeFrom(stdprom.GaugeOpts{
+			Namespace: "solver",
+			Name:      "funds_rebalance_budget_gauge",
+			Help:      "uusdc of a daily funds rebalance budget, paginated by destination chain (or all for global limits) and limit",
+		}, []string{chainIDLabel, limitLabel}),
 		totalTransactionSubmitted: prom.NewCounterFrom(stdprom.CounterOpts{
 			Namespace: "solver",
 			Name:      "total_transactions_submitted_counter",
@@ -159,6 +192,17 @@ func NewPromMetrics() Metrics {
 			Name:      "hyperlane_checkpointing_errors",
 			Help:      "number of hyperlane checkpointing errors",
 		}, []string{}),
+		hplCheckpointSigMismatches: prom.NewCounterFrom(stdprom.CounterOpts{
+			Namespace: "solver",
+			Name:      "hyperlane_checkpoint_signature_mismatches",
+			Help:      "number of hyperlane checkpoints rejected because they were not signed by the expected validator, paginated by validator and reason",
+		}, []string{validatorLabel, reasonLabel}),
+		hplCheckpointFetchLatency: prom.NewHistogramFrom(stdprom.HistogramOpts{
+			Namespace: "solver",
+			Name:      "hyperlane_checkpoint_fetch_latency_seconds",
+			Help:      "latency of fetching a signed checkpoint from a validators storage location, paginated by validator and fetch status (signed, not_signed, timeout or error) (in seconds)",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60},
+		}, []string{validatorLabel, fetchStatusLabel}),
 		hplLatency: prom.NewHistogramFrom(stdprom.HistogramOpts{
 			Namespace: "solver",
 			Name:      "latency_per_hyperlane_message_seconds",
@@ -209,6 +253,21 @@ func NewPromMetrics() Metrics {
 				1000000000000, // 1,000,000 USDC
 			},
 		}, []string{chainIDLabel}),
+		hplValidatorLag: prom.NewGaugeFrom(stdprom.GaugeOpts{
+			Namespace: "solver",
+			Name:      "hyperlane_validator_lag",
+			Help:      "number of checkpoints a validator is behind the origin chains merkle tree, paginated by origin chain and validator",
+		}, []string{chainIDLabel, validatorLabel}),
+		hplRouteQuorumReachable: prom.NewGaugeFrom(stdprom.GaugeOpts{
+			Namespace: "solver",
+			Name:      "hyperlane_route_quorum_reachable",
+			Help:      "1 if a quorum of validators for a hyperlane route are caught up, 0 otherwise, paginated by source and destination chain",
+		}, []string{sourceChainIDLabel, destinationChainIDLabel}),
+		hplRouteQuorumAlert: prom.NewGaugeFrom(stdprom.GaugeOpts{
+			Namespace: "solver",
+			Name:      "hyperlane_route_quorum_alert",
+			Help:      "1 if a hyperlane route has not had a reachable quorum of validators for longer than the alert threshold, 0 otherwise, paginated by source and destination chain",
+		}, []string{sourceChainIDLabel, destinationChainIDLabel}),
 		gasBalance: prom.NewGaugeFrom(stdprom.GaugeOpts{
 			Namespace: "solver",
 			Name:      "gas_balance_gauge",
@@ -254,10 +313,45 @@ func (m *PromMetrics) IncFundsRebalanceTransferStatusChange(sourceChainID, desti
 	m.fundRebalanceTransferStatusChange.With(sourceChainIDLabel, sourceChainID, destinationChainIDLabel, destinationChainID, transferStatusLabel, transferStatus).Add(1)
 }
 
+func (m *PromMetrics) IncFundsRebalanceLimitHit(chainID, limit string) {
+	m.fundRebalanceLimitHits.With(chainIDLabel, chainID, limitLabel, limit).Add(1)
+}
+
+func (m *PromMetrics) SetFundsRebalanceBudgetUsage(chainID, limit string, used, budget *big.Int) {
+	usedFloat, _ := new(big.Float).SetInt(used).Float64()
+	budgetFloat, _ := new(big.Float).SetInt(budget).Float64()
+	m.fundRebalanceBudgetUsed.With(chainIDLabel, chainID, limitLabel, limit).Set(usedFloat)
+	m.fundRebalanceBudget.With(chainIDLabel, chainID, limitLabel, limit).Set(budgetFloat)
+}
+
 func (m *PromMetrics) IncHyperlaneCheckpointingErrors() {
 	m.hplCheckpointingErrors.Add(1)
 }
 
+func (m *PromMetrics) IncHyperlaneCheckpointSignatureMismatches(validator, reason string) {
+	m.hplCheckpointSigMismatches.With(validatorLabel, validator, reasonLabel, reason).Add(1)
+}
+
+func (m *PromMetrics) ObserveHyperlaneCheckpointFetchLatency(validator, fetchStatus string, latency time.Duration) {
+	m.hplCheckpointFetchLatency.With(validatorLabel, validator, fetchStatusLabel, fetchStatus).Observe(latency.Seconds())
+}
+
+func (m *PromMetrics) SetHyperlaneValidatorLag(chainID, validator string, lag uint64) {
+	m.hplValidatorLag.With(chainIDLabel, chainID, validatorLabel, validator).Set(float64(lag))
+}
+
+func (m *PromMetrics) SetHyperlaneRouteQuorum(sourceChainID, destinationChainID string, reachable, alerting bool) {
+	m.hplRouteQuorumReachable.With(sourceChainIDLabel, sourceChainID, destinationChainIDLabel, destinationChainID).Set(boolToFloat(reachable))
+	m.hplRouteQuorumAlert.With(sourceChainIDLabel, sourceChainID, destinationChainIDLabel, destinationChainID).Set(boolToFloat(alerting))
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func (m *PromMetrics) IncHyperlaneMessages(sourceChainID, destinationChainID, messageStatus string) {
 	m.hplMessageStatusChange.With(sourceChainIDLabel, sourceChainID, destinationChainIDLabel, destinationChainID, transferStatusLabel, messageStatus).Add(1)
 }
@@ -355,7 +449,16 @@ func (n NoOpMetrics) IncOrderSettlementStatusChange(sourceChainID, destinationCh
 }
 func (n NoOpMetrics) IncFundsRebalanceTransferStatusChange(sourceChainID, destinationChainID, transferStatus string) {
 }
-func (n NoOpMetrics) IncHyperlaneCheckpointingErrors()                                             {}
+func (n NoOpMetrics) IncFundsRebalanceLimitHit(chainID, limit string) {}
+func (n NoOpMetrics) SetFundsRebalanceBudgetUsage(chainID, limit string, used, budget *big.Int) {
+}
+func (n NoOpMetrics) IncHyperlaneCheckpointingErrors()                                   {}
+func (n NoOpMetrics) IncHyperlaneCheckpointSignatureMismatches(validator, reason string) {}
+func (n NoOpMetrics) ObserveHyperlaneCheckpointFetchLatency(validator, fetchStatus string, latency time.Duration) {
+}
+func (n NoOpMetrics) SetHyperlaneValidatorLag(chainID, validator string, lag uint64) {}
+func (n NoOpMetrics) SetHyperlaneRouteQuorum(sourceChainID, destinationChainID string, reachable, alerting bool) {
+}
 func (n NoOpMetrics) IncHyperlaneMessages(sourceChainID, destinationChainID, messageStatus string) {}
 func (n NoOpMetrics) ObserveTransferSizeOutOfRange(sourceChainID, destinationChainID string, amountExceededBy int64) {
 }
@@ -1,7 +1,9 @@
 package signing
 
 import (
+	"errors"
 	"fmt"
+
 	"golang.org/x/net/context"
 
 	"github.com/gagliardetto/solana-go"
@@ -24,9 +26,17 @@ func NewLocalSolanaSigner(privateKeyBase58 string) (*LocalSolanaSigner, error) {
 	}, nil
 }
 
-// Not used for Solana signing
+// Sign adds the signers signature to a solana tx, leaving the signatures of
+// any other required signers in place
 func (s *LocalSolanaSigner) Sign(ctx context.Context, chainID string, tx Transaction) (Transaction, error) {
-	return nil, nil
+	solanaTx, ok := tx.(*SolanaTransaction)
+	if !ok {
+		return nil, errors.New("unsupported transaction type")
+	}
+	if err := SignSolanaTx(solanaTx.Tx, s.privateKey); err != nil {
+		return nil, err
+	}
+	return solanaTx, nil
 }
 
 func (s *LocalSolanaSigner) GetPrivateKey() *solana.PrivateKey {
@@ -36,3 +46,35 @@ func (s *LocalSolanaSigner) GetPrivateKey() *solana.PrivateKey {
 func (s *LocalSolanaSigner) Address() []byte {
 	return s.privateKey.PublicKey().Bytes()
 }
+
+// SignSolanaTx signs tx with privateKey, placing the signature in the slot of
+// the keys account in the txs signer accounts
+func SignSolanaTx(tx *solana.Transaction, privateKey solana.PrivateKey) error {
+	numSigners := int(tx.Message.Header.NumRequiredSignatures)
+	signerIndex := -1
+	for i, key := range tx.Message.AccountKeys[:numSigners] {
+		if key.Equals(privateKey.PublicKey()) {
+			signerIndex = i
+			break
+		}
+	}
+	if signerIndex == -1 {
+		return fmt.Errorf("%s is not a required signer of the tx", privateKey.PublicKey())
+	}
+
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("encoding tx message for signing: %w", err)
+	}
+	signature, err := privateKey.Sign(message)
+	if err != nil {
+		return fmt.Errorf("signing tx message: %w", err)
+	}
+	if len(tx.Signatures) != numSigners {
+		signatures := make([]solana.Signature, numSigners)
+		copy(signatures, tx.Signatures)
+		tx.Signatures = signatures
+	}
+	tx.Signatures[signerIndex] = signature
+	return nil
+}
@@ -22,6 +22,8 @@ func NewSigner(ctx context.Context, chainID string, chainIDToPrivateKey map[stri
 		return newCosmosSigner(ctx, chainID, chainIDToPrivateKey)
 	case config.ChainType_EVM:
 		return newEVMSigner(ctx, chainID, chainIDToPrivateKey)
+	case config.ChainType_SVM:
+		return newSVMSigner(chainID, chainIDToPrivateKey)
 	default:
 		return nil, fmt.Errorf("no signer available for chain type: %s", chainConfig.Type)
 	}
@@ -75,3 +77,12 @@ func newEVMSigner(ctx context.Context, chainID string, chainIDToPrivateKey map[s
 
 	return NewLocalEthereumSigner(privateKey), nil
 }
+
+func newSVMSigner(chainID string, chainIDToPrivateKey map[string]string) (*LocalSolanaSigner, error) {
+	privateKeyStr, ok := chainIDToPrivateKey[chainID]
+	if !ok {
+		return nil, fmt.Errorf("solver private key not found for chainID %s", chainID)
+	}
+
+	return NewLocalSolanaSigner(privateKeyStr)
+}
@@ -2,8 +2,13 @@ package signing
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
 )
 
 type Signer interface {
@@ -54,3 +59,45 @@ func (t *CosmosTransaction) UnmarshalJSON(data []byte) error {
 	t.Tx, err = t.TxConfig.TxJSONDecoder()(data)
 	return err
 }
+
+type SolanaTransaction struct {
+	Tx *solana.Transaction
+}
+
+func NewSolanaTransaction(tx *solana.Transaction) *SolanaTransaction {
+	return &SolanaTransaction{Tx: tx}
+}
+
+func (t *SolanaTransaction) MarshalBinary() ([]byte, error) {
+	return t.Tx.MarshalBinary()
+}
+
+func (t *SolanaTransaction) UnmarshalBinary(data []byte) error {
+	tx, err := solana.TransactionFromDecoder(bin.NewBinDecoder(data))
+	if err != nil {
+		return err
+	}
+	t.Tx = tx
+	return nil
+}
+
+// MarshalJSON encodes the tx as a base64 json string of its wire format
+func (t *SolanaTransaction) MarshalJSON() ([]byte, error) {
+	txB64, err := t.Tx.ToBase64()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(txB64)
+}
+
+func (t *SolanaTransaction) UnmarshalJSON(data []byte) error {
+	var txB64 string
+	if err := json.Unmarshal(data, &txB64); err != nil {
+		return err
+	}
+	txBytes, err := base64.StdEncoding.DecodeString(txB64)
+	if err != nil {
+		return err
+	}
+	return t.UnmarshalBinary(txBytes)
+}
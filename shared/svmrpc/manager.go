@@ -0,0 +1,67 @@
+package svmrpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+)
+
+type SVMRPCClientManager interface {
+	GetClient(ctx context.Context, chainID string) (SVMChainRPC, error)
+}
+
+type SVMRPCClientManagerImpl struct {
+	clients map[string]SVMChainRPC
+	m       sync.Mutex
+}
+
+func NewSVMRPCClientManager() SVMRPCClientManager {
+	return &SVMRPCClientManagerImpl{
+		clients: make(map[string]SVMChainRPC),
+	}
+}
+
+func (m *SVMRPCClientManagerImpl) GetClient(ctx context.Context, chainID string) (SVMChainRPC, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	if _, ok := m.clients[chainID]; !ok {
+		chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+		if err != nil {
+			return nil, err
+		}
+		if chainConfig.SVM == nil {
+			return nil, fmt.Errorf("svm chain config is null for chain id %s", chainID)
+		}
+
+		endpoint, err := config.GetConfigReader(ctx).GetRPCEndpoint(chainID)
+		if err != nil {
+			return nil, err
+		}
+
+		basicAuth, err := config.GetConfigReader(ctx).GetBasicAuth(chainID)
+		if err != nil {
+			return nil, err
+		}
+
+		headers := make(map[string]string)
+		if basicAuth != nil {
+			headers["Authorization"] = fmt.Sprintf("Basic %s", *basicAuth)
+		}
+
+		m.clients[chainID] = NewSVMChainRPC(rpc.NewWithHeaders(endpoint, headers), Commitment(chainConfig.SVM))
+	}
+
+	return m.clients[chainID], nil
+}
+
+// Commitment gets the commitment level that txs on a chain must reach before
+// they are considered landed
+func Commitment(svmConfig *config.SVMConfig) rpc.CommitmentType {
+	if svmConfig == nil || svmConfig.Commitment == "" {
+		return rpc.CommitmentConfirmed
+	}
+	return rpc.CommitmentType(svmConfig.Commitment)
+}
@@ -0,0 +1,158 @@
+package svmrpc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"golang.org/x/net/context"
+)
+
+type SVMChainRPC interface {
+	// GetSlot gets the latest slot at the clients commitment level
+	GetSlot(ctx context.Context) (uint64, error)
+	// GetBlockHeight gets the latest block height at the clients commitment
+	// level, which is what blockhash expiry is measured in
+	GetBlockHeight(ctx context.Context) (uint64, error)
+	// GetLatestBlockhash gets the latest blockhash along with the last block
+	// height at which txs using it can still land
+	GetLatestBlockhash(ctx context.Context) (blockhash solana.Hash, lastValidBlockHeight uint64, err error)
+	// GetBalance gets the lamport balance of account
+	GetBalance(ctx context.Context, account solana.PublicKey) (*big.Int, error)
+	// GetTokenBalance gets the balance of an spl token account, or 0 if the
+	// token account does not exist
+	GetTokenBalance(ctx context.Context, tokenAccount solana.PublicKey) (*big.Int, error)
+	// GetAccountData gets the data of account, or nil if account does not
+	// exist
+	GetAccountData(ctx context.Context, account solana.PublicKey) ([]byte, error)
+	// SendTx submits a signed tx after simulating it
+	SendTx(ctx context.Context, tx *solana.Transaction) (solana.Signature, error)
+	// GetSignatureStatus gets the status of a submitted tx, or nil if the tx
+	// is not known to the node
+	GetSignatureStatus(ctx context.Context, signature solana.Signature) (*rpc.SignatureStatusesResult, error)
+	// GetTx gets a landed tx and its execution result. rpc.ErrNotFound is
+	// returned if the tx has not landed at the clients commitment level.
+	GetTx(ctx context.Context, signature solana.Signature) (*solana.Transaction, *rpc.TransactionMeta, error)
+}
+
+type chainRPCImpl struct {
+	client     *rpc.Client
+	commitment rpc.CommitmentType
+}
+
+func NewSVMChainRPC(client *rpc.Client, commitment rpc.CommitmentType) SVMChainRPC {
+	return &chainRPCImpl{client: client, commitment: commitment}
+}
+
+func (cr *chainRPCImpl) GetSlot(ctx context.Context) (uint64, error) {
+	return cr.client.GetSlot(ctx, cr.commitment)
+}
+
+func (cr *chainRPCImpl) GetBlockHeight(ctx context.Context) (uint64, error) {
+	return cr.client.GetBlockHeight(ctx, cr.commitment)
+}
+
+func (cr *chainRPCImpl) GetLatestBlockhash(ctx context.Context) (solana.Hash, uint64, error) {
+	result, err := cr.client.GetLatestBlockhash(ctx, cr.commitment)
+	if err != nil {
+		return solana.Hash{}, 0, err
+	}
+	if result == nil || result.Value == nil {
+		return solana.Hash{}, 0, errors.New("no blockhash returned")
+	}
+	return result.Value.Blockhash, result.Value.LastValidBlockHeight, nil
+}
+
+func (cr *chainRPCImpl) GetBalance(ctx context.Context, account solana.PublicKey) (*big.Int, error) {
+	result, err := cr.client.GetBalance(ctx, account, cr.commitment)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(result.Value), nil
+}
+
+func (cr *chainRPCImpl) GetTokenBalance(ctx context.Context, tokenAccount solana.PublicKey) (*big.Int, error) {
+	result, err := cr.client.GetTokenAccountBalance(ctx, tokenAccount, cr.commitment)
+	if err != nil {
+		// token accounts are only created once they first receive tokens,
+		// so a missing account has a balance of 0
+		data, accountErr := cr.GetAccountData(ctx, tokenAccount)
+		if accountErr == nil && data == nil {
+			return big.NewInt(0), nil
+		}
+		return nil, err
+	}
+	if result.Value == nil {
+		return nil, fmt.Errorf("no balance returned for token account %s", tokenAccount)
+	}
+	balance, ok := new(big.Int).SetString(result.Value.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("could not convert balance %s of token account %s to *big.Int", result.Value.Amount, tokenAccount)
+	}
+	return balance, nil
+}
+
+func (cr *chainRPCImpl) GetAccountData(ctx context.Context, account solana.PublicKey) ([]byte, error) {
+	result, err := cr.client.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: cr.commitment,
+	})
+	if errors.Is(err, rpc.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.GetBinary(), nil
+}
+
+func (cr *chainRPCImpl) SendTx(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+	// the rpc node is not asked to retry the tx, since it is rebroadcast by
+	// the tx executor until it lands or its blockhash expires
+	maxRetries := uint(0)
+	return cr.client.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
+		PreflightCommitment: cr.commitment,
+		MaxRetries:          &maxRetries,
+	})
+}
+
+func (cr *chainRPCImpl) GetSignatureStatus(ctx context.Context, signature solana.Signature) (*rpc.SignatureStatusesResult, error) {
+	result, err := cr.client.GetSignatureStatuses(ctx, false, signature)
+	if errors.Is(err, rpc.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 {
+		return nil, nil
+	}
+	return result.Value[0], nil
+}
+
+func (cr *chainRPCImpl) GetTx(ctx context.Context, signature solana.Signature) (*solana.Transaction, *rpc.TransactionMeta, error) {
+	maxSupportedTransactionVersion := uint64(0)
+	commitment := cr.commitment
+	if commitment == rpc.CommitmentProcessed {
+		// txs can not be fetched at the processed commitment level
+		commitment = rpc.CommitmentConfirmed
+	}
+	result, err := cr.client.GetTransaction(ctx, signature, &rpc.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     commitment,
+		MaxSupportedTransactionVersion: &maxSupportedTransactionVersion,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if result.Transaction == nil {
+		return nil, nil, rpc.ErrNotFound
+	}
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding tx %s: %w", signature, err)
+	}
+	return tx, result.Meta, nil
+}
@@ -38,6 +38,17 @@ type CosmosTxExecutor interface {
 		gasPrice float64,
 		gasDenom string,
 	) (*coretypes.ResultBroadcastTx, types.Tx, error)
+
+	// EstimateGas simulates executing msgs and returns the estimated gas
+	// limit, including the simulation gas multiplier, without broadcasting
+	EstimateGas(
+		ctx context.Context,
+		chainID string,
+		signerAddress string,
+		msgs []types.Msg,
+		txConfig sdkclient.TxConfig,
+		signer signing.Signer,
+	) (uint64, error)
 }
 
 type SerializedCosmosTxExecutor struct {
@@ -143,6 +154,32 @@ func (s *SerializedCosmosTxExecutor) ExecuteTx(
 	return res, txBuilder.GetTx(), err
 }
 
+func (s *SerializedCosmosTxExecutor) EstimateGas(
+	ctx context.Context,
+	chainID string,
+	signerAddress string,
+	msgs []types.Msg,
+	txConfig sdkclient.TxConfig,
+	signer signing.Signer,
+) (uint64, error) {
+	client, err := s.rpcClientManager.GetClient(ctx, chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	txBuilder := txConfig.NewTxBuilder()
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return 0, err
+	}
+
+	account, err := s.queryAccount(ctx, client, signerAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.estimateGasUsed(ctx, chainID, txBuilder.GetTx(), account, txConfig, signer)
+}
+
 func (s *SerializedCosmosTxExecutor) queryAccount(ctx context.Context, client client.Client, address string) (types.AccountI, error) {
 	requestBytes, err := s.cdc.Marshal(&authtypes.QueryAccountRequest{Address: address})
 	if err != nil {
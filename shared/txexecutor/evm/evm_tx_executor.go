@@ -2,39 +2,67 @@ package evm
 
 import (
 	"encoding/base64"
-	"sync"
+	"errors"
+	"strings"
 	"time"
 
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/skip-mev/go-fast-solver/shared/config"
 	"github.com/skip-mev/go-fast-solver/shared/evmrpc"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
 	"github.com/skip-mev/go-fast-solver/shared/signing"
 	"github.com/skip-mev/go-fast-solver/shared/signing/evm"
+	"go.uber.org/zap"
 	"golang.org/x/net/context"
 )
 
+// ErrReplacementTooExpensive is returned when a tx can not be replaced since
+// the bumped fees of the replacement would exceed the max tx fee
+var ErrReplacementTooExpensive = errors.New("replacement tx is too expensive")
+
+// replacementFeeBumpPercent is the percent that the gas tip and fee caps of a
+// tx are increased by when it is replaced. Nodes require at least a 10%
+// increase to accept a replacement into their mempool.
+const replacementFeeBumpPercent = 15
+
 type EVMTxExecutor interface {
 	ExecuteTx(ctx context.Context, chainID string, signerAddress string, data []byte, value string, to string, signer signing.Signer) (txHash string, rawTxB64 string, err error)
+	// ReplaceTx resubmits a previously executed tx with the same nonce and
+	// bumped fees, so that it is mined in place of the previous tx if that is
+	// stuck in the mempool. maxTxFee caps the max fee (gas limit * gas fee
+	// cap) in wei that the replacement may pay, if it is not nil.
+	ReplaceTx(ctx context.Context, chainID string, rawTxB64 string, maxTxFee *big.Int, signer signing.Signer) (txHash string, replacementRawTxB64 string, err error)
 }
 
+// SerializedEVMTxExecutor submits the txs of each signer on each chain one at
+// a time, waiting txSubmissionDelay between consecutive txs of the same signer.
+// Nonces are allocated locally by a NonceManager, so txs of different signers
+// and chains are submitted in parallel.
 type SerializedEVMTxExecutor struct {
-	lock               sync.Mutex
-	lastSubmissionTime time.Time
-	txSubmissionDelay  time.Duration
-	clientManager      evmrpc.EVMRPCClientManager
+	txSubmissionDelay time.Duration
+	clientManager     evmrpc.EVMRPCClientManager
+	nonces            *NonceManager
 }
 
+// DefaultTxSubmissionDelay is how long the default executor waits between
+// consecutive txs of the same signer
+const DefaultTxSubmissionDelay = 500 * time.Millisecond
+
 func DefaultEVMTxExecutor() EVMTxExecutor {
-	return NewSerializedEVMTxExecutor(evmrpc.NewEVMRPCClientManager(), 500*time.Millisecond)
+	return NewSerializedEVMTxExecutor(evmrpc.NewEVMRPCClientManager(), DefaultTxSubmissionDelay)
 }
 
 func NewSerializedEVMTxExecutor(clientManager evmrpc.EVMRPCClientManager, txSubmissionDelay time.Duration) EVMTxExecutor {
 	return &SerializedEVMTxExecutor{
 		clientManager:     clientManager,
 		txSubmissionDelay: txSubmissionDelay,
+		nonces:            NewNonceManager(clientManager, DefaultNonceResyncInterval, DefaultNonceGapTimeout),
 	}
 }
 
@@ -43,46 +71,155 @@ func (s *SerializedEVMTxExecutor) ExecuteTx(ctx context.Context, chainID string,
 	if err != nil {
 		return "", "", err
 	}
-	s.lock.Lock()
+
+	// a nonce error means the local nonce of the signer was out of sync with
+	// the chain, so the tx is retried once with the resynced nonce
+	txHash, rawTxB64, err = s.executeTx(ctx, client, chainID, signerAddress, data, value, to, signer)
+	if err != nil && IsNonceError(err) {
+		lmt.Logger(ctx).Warn(
+			"tx rejected due to nonce, retrying with nonce resynced from chain",
+			zap.String("chainID", chainID),
+			zap.String("signerAddress", signerAddress),
+			zap.Error(err),
+		)
+		txHash, rawTxB64, err = s.executeTx(ctx, client, chainID, signerAddress, data, value, to, signer)
+	}
+	return txHash, rawTxB64, err
+}
+
+func (s *SerializedEVMTxExecutor) executeTx(ctx context.Context, client evmrpc.EVMChainRPC, chainID string, signerAddress string, data []byte, value string, to string, signer signing.Signer) (txHash string, rawTxB64 string, err error) {
+	lease, err := s.nonces.Acquire(ctx, chainID, common.HexToAddress(signerAddress))
+	if err != nil {
+		return "", "", fmt.Errorf("acquiring nonce: %w", err)
+	}
+	// only errors from submitting txs leave the local nonce out of sync
+	var used bool
+	var submitErr error
 	defer func() {
-		if err == nil {
-			s.lastSubmissionTime = time.Now()
-		}
-		s.lock.Unlock()
+		lease.Release(used, submitErr)
 	}()
 	select {
-	case <-time.After(time.Until(s.lastSubmissionTime.Add(s.txSubmissionDelay))):
+	case <-time.After(time.Until(lease.LastSubmissionTime.Add(s.txSubmissionDelay))):
 	case <-ctx.Done():
 		return "", "", ctx.Err()
 	}
 
-	chainCfg, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	minGasTipCap, err := getMinGasTipCap(ctx, chainID)
 	if err != nil {
 		return "", "", err
 	}
-	if chainCfg.EVM == nil {
-		return "", "", fmt.Errorf("EVM chain config is null for chain id %s", chainID)
-	}
-	var minGasTipCap *big.Int
-	if chainCfg.EVM.MinGasTipCap != nil {
-		minGasTipCap = big.NewInt(*chainCfg.EVM.MinGasTipCap)
-	}
 
-	nonce, err := client.PendingNonceAt(ctx, common.HexToAddress(signerAddress))
-	if err != nil {
-		return "", "", err
+	for _, nonce := range lease.Gaps {
+		if submitErr = s.cancelNonce(ctx, client, chainID, signerAddress, nonce, minGasTipCap, signer); submitErr != nil {
+			return "", "", fmt.Errorf("filling nonce gap at nonce %d: %w", nonce, submitErr)
+		}
 	}
+
 	tx, err := evm.NewTxBuilder(client).Build(
 		ctx,
 		evm.WithData(data),
 		evm.WithValue(value),
 		evm.WithTo(to),
 		evm.WithChainID(chainID),
-		evm.WithNonce(nonce),
+		evm.WithNonce(lease.Nonce),
 		evm.WithEstimatedGasLimit(signerAddress, to, value, data),
 		evm.WithEstimatedGasTipCap(minGasTipCap),
 		evm.WithEstimatedGasFeeCap(minGasTipCap, big.NewFloat(2)),
 	)
+	if err != nil {
+		return "", "", fmt.Errorf("building tx: %w", err)
+	}
+	txHash, rawTxB64, submitErr = s.signAndSend(ctx, client, chainID, tx, signer)
+	used = submitErr == nil
+	return txHash, rawTxB64, submitErr
+}
+
+// cancelNonce fills a nonce whose tx was dropped with a zero value transfer
+// from the signer to itself, so that the signer's txs with later nonces can be
+// mined
+func (s *SerializedEVMTxExecutor) cancelNonce(ctx context.Context, client evmrpc.EVMChainRPC, chainID string, signerAddress string, nonce uint64, minGasTipCap *big.Int, signer signing.Signer) error {
+	tx, err := evm.NewTxBuilder(client).Build(
+		ctx,
+		evm.WithValue("0"),
+		evm.WithTo(signerAddress),
+		evm.WithChainID(chainID),
+		evm.WithNonce(nonce),
+		evm.WithEstimatedGasLimit(signerAddress, signerAddress, "0", nil),
+		evm.WithEstimatedGasTipCap(minGasTipCap),
+		evm.WithEstimatedGasFeeCap(minGasTipCap, big.NewFloat(2)),
+	)
+	if err != nil {
+		return fmt.Errorf("building cancellation tx: %w", err)
+	}
+	txHash, _, err := s.signAndSend(ctx, client, chainID, tx, signer)
+	if err != nil {
+		return fmt.Errorf("sending cancellation tx: %w", err)
+	}
+	lmt.Logger(ctx).Info(
+		"sent cancellation tx to fill nonce gap",
+		zap.String("chainID", chainID),
+		zap.String("signerAddress", signerAddress),
+		zap.Uint64("nonce", nonce),
+		zap.String("txHash", txHash),
+	)
+	return nil
+}
+
+// ReplaceTx does not lease a nonce from the nonce manager since the
+// replacement reuses the nonce of the tx it replaces, which has already been
+// allocated
+func (s *SerializedEVMTxExecutor) ReplaceTx(ctx context.Context, chainID string, rawTxB64 string, maxTxFee *big.Int, signer signing.Signer) (txHash string, replacementRawTxB64 string, err error) {
+	previous, err := decodeRawTx(rawTxB64)
+	if err != nil {
+		return "", "", fmt.Errorf("decoding tx to replace: %w", err)
+	}
+	if previous.Type() != types.DynamicFeeTxType {
+		return "", "", fmt.Errorf("tx to replace must be a dynamic fee tx, got type %d", previous.Type())
+	}
+
+	client, err := s.clientManager.GetClient(ctx, chainID)
+	if err != nil {
+		return "", "", err
+	}
+	minGasTipCap, err := getMinGasTipCap(ctx, chainID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// the replacement pays the larger of the bumped fees of the previous tx
+	// and the current fee estimates, in case fees have risen since the
+	// previous tx was sent
+	estimated, err := evm.NewTxBuilder(client).Build(
+		ctx,
+		evm.WithChainID(chainID),
+		evm.WithEstimatedGasTipCap(minGasTipCap),
+		evm.WithEstimatedGasFeeCap(minGasTipCap, big.NewFloat(2)),
+	)
+	if err != nil {
+		return "", "", fmt.Errorf("estimating gas fees: %w", err)
+	}
+	gasTipCap := bigMax(bumpFee(previous.GasTipCap()), estimated.GasTipCap())
+	gasFeeCap := bigMax(bumpFee(previous.GasFeeCap()), estimated.GasFeeCap(), gasTipCap)
+
+	txFee := new(big.Int).Mul(gasFeeCap, new(big.Int).SetUint64(previous.Gas()))
+	if maxTxFee != nil && txFee.Cmp(maxTxFee) > 0 {
+		return "", "", fmt.Errorf("%w: replacement max tx fee %s exceeds max tx fee %s", ErrReplacementTooExpensive, txFee.String(), maxTxFee.String())
+	}
+
+	replacement := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   previous.ChainId(),
+		Nonce:     previous.Nonce(),
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       previous.Gas(),
+		To:        previous.To(),
+		Value:     previous.Value(),
+		Data:      previous.Data(),
+	})
+	return s.signAndSend(ctx, client, chainID, replacement, signer)
+}
+
+func (s *SerializedEVMTxExecutor) signAndSend(ctx context.Context, client evmrpc.EVMChainRPC, chainID string, tx *types.Transaction, signer signing.Signer) (string, string, error) {
 	signedTx, err := signer.Sign(ctx, chainID, tx)
 	if err != nil {
 		return "", "", err
@@ -95,9 +232,67 @@ func (s *SerializedEVMTxExecutor) ExecuteTx(ctx context.Context, chainID string,
 	if err != nil {
 		return "", "", err
 	}
-	txHash, err = client.SendTx(ctx, signedTxBytes)
+	txHash, err := client.SendTx(ctx, signedTxBytes)
 	if err != nil {
 		return "", "", err
 	}
 	return txHash, base64.StdEncoding.EncodeToString(txJsonBytes), nil
 }
+
+func getMinGasTipCap(ctx context.Context, chainID string) (*big.Int, error) {
+	chainCfg, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if chainCfg.EVM == nil {
+		return nil, fmt.Errorf("EVM chain config is null for chain id %s", chainID)
+	}
+	if chainCfg.EVM.MinGasTipCap == nil {
+		return nil, nil
+	}
+	return big.NewInt(*chainCfg.EVM.MinGasTipCap), nil
+}
+
+// IsNonceError returns whether a tx was rejected since its nonce was already
+// used, is taken by a pending tx paying a higher fee, or is too far ahead of
+// the signer's pending nonce
+func IsNonceError(err error) bool {
+	for _, nonceErr := range []error{core.ErrNonceTooLow, core.ErrNonceTooHigh, txpool.ErrReplaceUnderpriced} {
+		// errors returned by a node over rpc only carry the message of the
+		// node's error
+		if errors.Is(err, nonceErr) || strings.Contains(err.Error(), nonceErr.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeRawTx decodes a raw tx as returned by ExecuteTx
+func decodeRawTx(rawTxB64 string) (*types.Transaction, error) {
+	txJsonBytes, err := base64.StdEncoding.DecodeString(rawTxB64)
+	if err != nil {
+		return nil, err
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalJSON(txJsonBytes); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// bumpFee increases fee by replacementFeeBumpPercent, rounding up
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+replacementFeeBumpPercent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func bigMax(first *big.Int, rest ...*big.Int) *big.Int {
+	max := first
+	for _, n := range rest {
+		if n.Cmp(max) > 0 {
+			max = n
+		}
+	}
+	return max
+}
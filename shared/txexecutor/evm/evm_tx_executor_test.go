@@ -1,6 +1,14 @@
 package evm
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/skip-mev/go-fast-solver/mocks/shared/config"
 	"github.com/skip-mev/go-fast-solver/mocks/shared/evmrpc"
@@ -25,6 +33,9 @@ func setupExecutor(t *testing.T, txSubmissionDelay time.Duration, chainID string
 	signer := mocksigning.NewMockSigner(t)
 
 	rpcClient.On("PendingNonceAt", mock.Anything, mock.Anything).Return(uint64(9), nil)
+	rpcClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	rpcClient.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(1), nil)
+	rpcClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(&types.Header{BaseFee: big.NewInt(1)}, nil)
 
 	tx := types.NewTx(&types.AccessListTx{Nonce: 9})
 	signer.On("Sign", mock.Anything, mock.Anything, mock.Anything).Return(tx, nil)
@@ -39,17 +50,17 @@ func setupExecutor(t *testing.T, txSubmissionDelay time.Duration, chainID string
 }
 
 func TestSerializedEVMTxExecutor_ExecuteTx_NoDelay(t *testing.T) {
-	executor, signer, ctx := setupExecutor(t, 2*time.Second, "chainID")
+	executor, signer, ctx := setupExecutor(t, 2*time.Second, "1")
 
 	// call ExecuteTx and ensure that it returns immediately since it is the first invocation
 	start := time.Now()
 	response, _, err := executor.ExecuteTx(
 		ctx,
-		"chainID",
-		"signerAddress",
+		"1",
+		"0x2222222222222222222222222222222222222222",
 		nil,
-		"value",
-		"to",
+		"1",
+		"0x1111111111111111111111111111111111111111",
 		signer,
 	)
 	require.Nil(t, err)
@@ -58,16 +69,16 @@ func TestSerializedEVMTxExecutor_ExecuteTx_NoDelay(t *testing.T) {
 }
 
 func TestSerializedEVMTxExecutor_ExecuteTx_WithDelay(t *testing.T) {
-	executor, signer, ctx := setupExecutor(t, 2*time.Second, "chainID")
+	executor, signer, ctx := setupExecutor(t, 2*time.Second, "1")
 
 	// call ExecuteTx to start delay timer
 	response, _, err := executor.ExecuteTx(
 		ctx,
-		"chainID",
-		"signerAddress",
+		"1",
+		"0x2222222222222222222222222222222222222222",
 		nil,
-		"value",
-		"to",
+		"1",
+		"0x1111111111111111111111111111111111111111",
 		signer,
 	)
 	require.Nil(t, err)
@@ -77,11 +88,11 @@ func TestSerializedEVMTxExecutor_ExecuteTx_WithDelay(t *testing.T) {
 	start := time.Now()
 	response, _, err = executor.ExecuteTx(
 		ctx,
-		"chainID",
-		"signerAddress",
+		"1",
+		"0x2222222222222222222222222222222222222222",
 		nil,
-		"value",
-		"to",
+		"1",
+		"0x1111111111111111111111111111111111111111",
 		signer,
 	)
 	require.Nil(t, err)
@@ -90,16 +101,16 @@ func TestSerializedEVMTxExecutor_ExecuteTx_WithDelay(t *testing.T) {
 }
 
 func TestSerializedEVMTxExecutor_ExecuteTx_DelayCancelled(t *testing.T) {
-	executor, signer, ctx := setupExecutor(t, 10*time.Second, "chainID")
+	executor, signer, ctx := setupExecutor(t, 10*time.Second, "1")
 
 	// call ExecuteTx to start delay timer
 	response, _, err := executor.ExecuteTx(
 		ctx,
-		"chainID",
-		"signerAddress",
+		"1",
+		"0x2222222222222222222222222222222222222222",
 		nil,
-		"value",
-		"to",
+		"1",
+		"0x1111111111111111111111111111111111111111",
 		signer,
 	)
 	require.Nil(t, err)
@@ -111,13 +122,108 @@ func TestSerializedEVMTxExecutor_ExecuteTx_DelayCancelled(t *testing.T) {
 	cancelFn()
 	_, _, err = executor.ExecuteTx(
 		cancelCtx,
-		"chainID",
-		"signerAddress",
+		"1",
+		"0x2222222222222222222222222222222222222222",
 		nil,
-		"value",
-		"to",
+		"1",
+		"0x1111111111111111111111111111111111111111",
 		signer,
 	)
 	require.NotNil(t, err)
 	require.WithinDuration(t, start, time.Now(), 100*time.Millisecond)
 }
+
+func TestSerializedEVMTxExecutor_ExecuteTx_BuildError(t *testing.T) {
+	executor, signer, ctx := setupExecutor(t, 0, "1")
+
+	// an invalid value fails to build the tx, which is returned without
+	// signing or sending a tx
+	_, _, err := executor.ExecuteTx(ctx, "1", "0x2222222222222222222222222222222222222222", nil, "invalid", "0x1111111111111111111111111111111111111111", signer)
+	require.Error(t, err)
+
+	// the leased nonce is released and is used by the next tx
+	txHash, _, err := executor.ExecuteTx(ctx, "1", "0x2222222222222222222222222222222222222222", nil, "1", "0x1111111111111111111111111111111111111111", signer)
+	require.NoError(t, err)
+	require.Equal(t, "txHash", txHash)
+}
+
+func TestIsNonceError(t *testing.T) {
+	require.True(t, IsNonceError(core.ErrNonceTooLow))
+	require.True(t, IsNonceError(fmt.Errorf("sending tx: %w", txpool.ErrReplaceUnderpriced)))
+	// errors returned over rpc only carry the node's error message
+	require.True(t, IsNonceError(errors.New("nonce too high: address 0x1, tx: 10 state: 8")))
+	require.False(t, IsNonceError(errors.New("insufficient funds for gas * price + value")))
+}
+
+func TestSerializedEVMTxExecutor_ReplaceTx(t *testing.T) {
+	chainID := "1"
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	previous := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		GasTipCap: big.NewInt(100),
+		GasFeeCap: big.NewInt(1000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      []byte{0x01},
+	})
+	previousJSON, err := previous.MarshalJSON()
+	require.NoError(t, err)
+	previousRawTx := base64.StdEncoding.EncodeToString(previousJSON)
+
+	setup := func(t *testing.T, suggestedTipCap int64, baseFee int64) (EVMTxExecutor, *mocksigning.MockSigner, context.Context) {
+		rpcClientManager := evmrpc.NewMockEVMRPCClientManager(t)
+		rpcClient := evmrpc.NewMockEVMChainRPC(t)
+		configReader := config.NewMockConfigReader(t)
+		configReader.On("GetChainConfig", chainID).Return(configreader.ChainConfig{EVM: &configreader.EVMConfig{}}, nil)
+		ctx := configreader.ConfigReaderContext(context.Background(), configReader)
+		rpcClientManager.On("GetClient", mock.Anything, chainID).Return(rpcClient, nil)
+		rpcClient.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(suggestedTipCap), nil)
+		rpcClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(&types.Header{BaseFee: big.NewInt(baseFee)}, nil)
+		rpcClient.On("SendTx", mock.Anything, mock.Anything).Return("replacementTxHash", nil).Maybe()
+		return NewSerializedEVMTxExecutor(rpcClientManager, 0), mocksigning.NewMockSigner(t), ctx
+	}
+
+	t.Run("replacement reuses nonce and bumps fees", func(t *testing.T) {
+		executor, signer, ctx := setup(t, 50, 100)
+
+		var replacement *types.Transaction
+		signer.On("Sign", mock.Anything, chainID, mock.Anything).Return(func(ctx context.Context, chainID string, tx signing.Transaction) (signing.Transaction, error) {
+			replacement = tx.(*types.Transaction)
+			return tx, nil
+		}, nil)
+
+		txHash, _, err := executor.ReplaceTx(ctx, chainID, previousRawTx, nil, signer)
+		require.NoError(t, err)
+		require.Equal(t, "replacementTxHash", txHash)
+
+		require.Equal(t, previous.Nonce(), replacement.Nonce())
+		require.Equal(t, previous.Gas(), replacement.Gas())
+		require.Equal(t, previous.Data(), replacement.Data())
+		require.Equal(t, big.NewInt(115), replacement.GasTipCap())
+		require.Equal(t, big.NewInt(1150), replacement.GasFeeCap())
+	})
+
+	t.Run("replacement uses current fee estimates if they are higher", func(t *testing.T) {
+		executor, signer, ctx := setup(t, 500, 1000)
+
+		var replacement *types.Transaction
+		signer.On("Sign", mock.Anything, chainID, mock.Anything).Return(func(ctx context.Context, chainID string, tx signing.Transaction) (signing.Transaction, error) {
+			replacement = tx.(*types.Transaction)
+			return tx, nil
+		}, nil)
+
+		_, _, err := executor.ReplaceTx(ctx, chainID, previousRawTx, nil, signer)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(500), replacement.GasTipCap())
+		require.Equal(t, big.NewInt(2500), replacement.GasFeeCap())
+	})
+
+	t.Run("replacement exceeding max tx fee is not sent", func(t *testing.T) {
+		executor, signer, ctx := setup(t, 50, 100)
+
+		_, _, err := executor.ReplaceTx(ctx, chainID, previousRawTx, big.NewInt(1150*21000-1), signer)
+		require.ErrorIs(t, err, ErrReplacementTooExpensive)
+	})
+}
@@ -0,0 +1,185 @@
+package evm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/skip-mev/go-fast-solver/shared/evmrpc"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+const (
+	// DefaultNonceResyncInterval is how often the locally allocated nonces of
+	// an account are checked against the chain's pending nonce
+	DefaultNonceResyncInterval = 30 * time.Second
+
+	// DefaultNonceGapTimeout is how long the chain's pending nonce of an
+	// account must stay behind the locally allocated nonces before the missing
+	// nonces are considered dropped and are filled with cancellation txs
+	DefaultNonceGapTimeout = 2 * time.Minute
+)
+
+type nonceAccount struct {
+	chainID string
+	address common.Address
+}
+
+type nonceAccountState struct {
+	// lock is held for the duration of a lease so that the txs of an account
+	// are submitted one at a time and in nonce order
+	lock sync.Mutex
+
+	synced             bool
+	next               uint64
+	lastSyncTime       time.Time
+	lastSubmissionTime time.Time
+
+	// gapStart is the chain's pending nonce when it was first seen behind the
+	// locally allocated nonces, at gapSince
+	gapStart uint64
+	gapSince time.Time
+}
+
+// NonceManager allocates the nonces of the txs sent by each signer on each
+// chain locally, so that txs do not need to query the chain for a nonce before
+// they are sent. Txs of the same signer on the same chain are submitted one at
+// a time, while different signers and chains submit in parallel.
+type NonceManager struct {
+	clientManager  evmrpc.EVMRPCClientManager
+	resyncInterval time.Duration
+	gapTimeout     time.Duration
+
+	lock     sync.Mutex
+	accounts map[nonceAccount]*nonceAccountState
+}
+
+func NewNonceManager(clientManager evmrpc.EVMRPCClientManager, resyncInterval, gapTimeout time.Duration) *NonceManager {
+	return &NonceManager{
+		clientManager:  clientManager,
+		resyncInterval: resyncInterval,
+		gapTimeout:     gapTimeout,
+		accounts:       make(map[nonceAccount]*nonceAccountState),
+	}
+}
+
+// NonceLease holds the lock of an account until it is released
+type NonceLease struct {
+	state *nonceAccountState
+
+	// Nonce is the nonce the next tx of the account should be sent with
+	Nonce uint64
+	// Gaps are nonces below Nonce whose txs have been missing from the chain
+	// for longer than the gap timeout. They must be filled, e.g. with
+	// cancellation txs, before a tx with Nonce can be mined.
+	Gaps []uint64
+	// LastSubmissionTime is when a tx of the account was last submitted
+	LastSubmissionTime time.Time
+}
+
+// Acquire locks the account of address on chainID and leases its next nonce.
+// The account's nonce is synced with the chain's pending nonce if it has not
+// been synced yet, if a previous submission failed, or if it has not been
+// synced for the resync interval. Release must be called once the lease is
+// no longer needed.
+func (m *NonceManager) Acquire(ctx context.Context, chainID string, address common.Address) (*NonceLease, error) {
+	state := m.account(chainID, address)
+
+	state.lock.Lock()
+	now := time.Now()
+	var gaps []uint64
+	if !state.synced || now.Sub(state.lastSyncTime) >= m.resyncInterval {
+		client, err := m.clientManager.GetClient(ctx, chainID)
+		if err != nil {
+			state.lock.Unlock()
+			return nil, err
+		}
+		pending, err := client.PendingNonceAt(ctx, address)
+		if err != nil {
+			state.lock.Unlock()
+			return nil, err
+		}
+		gaps = state.sync(pending, now, m.gapTimeout)
+		if len(gaps) > 0 {
+			lmt.Logger(ctx).Warn(
+				"pending nonce has been behind locally allocated nonces for longer than the gap timeout, filling nonce gap",
+				zap.String("chainID", chainID),
+				zap.String("address", address.Hex()),
+				zap.Uint64("pendingNonce", pending),
+				zap.Uint64("nextNonce", state.next),
+			)
+		}
+	}
+
+	return &NonceLease{
+		state:              state,
+		Nonce:              state.next,
+		Gaps:               gaps,
+		LastSubmissionTime: state.lastSubmissionTime,
+	}, nil
+}
+
+// Release unlocks the leased account. used reports whether a tx with the
+// leased nonce was submitted, after any gaps were filled. If err is not nil
+// the account is resynced with the chain before its next nonce is leased,
+// since a failed submission may leave the local nonce out of sync with the
+// chain.
+func (l *NonceLease) Release(used bool, err error) {
+	defer l.state.lock.Unlock()
+	if err != nil {
+		l.state.synced = false
+		return
+	}
+	if used {
+		l.state.next = l.Nonce + 1
+		l.state.lastSubmissionTime = time.Now()
+		if len(l.Gaps) > 0 {
+			l.state.gapSince = time.Time{}
+		}
+	}
+}
+
+func (m *NonceManager) account(chainID string, address common.Address) *nonceAccountState {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	key := nonceAccount{chainID: chainID, address: address}
+	state, ok := m.accounts[key]
+	if !ok {
+		state = &nonceAccountState{}
+		m.accounts[key] = state
+	}
+	return state
+}
+
+// sync updates the account's next nonce with the chain's pending nonce and
+// returns the nonces that are considered dropped from the chain
+func (s *nonceAccountState) sync(pending uint64, now time.Time, gapTimeout time.Duration) []uint64 {
+	s.lastSyncTime = now
+	if !s.synced || pending >= s.next {
+		// the chain is authoritative after a failed submission, and may be
+		// ahead of the local nonce if txs were sent from elsewhere
+		s.next = pending
+		s.synced = true
+		s.gapSince = time.Time{}
+		return nil
+	}
+
+	// txs with nonces from pending up to next have been submitted but are not
+	// known to the node. This is expected briefly while they propagate, so
+	// the gap is only filled once it has persisted for the gap timeout.
+	if s.gapSince.IsZero() || s.gapStart != pending {
+		s.gapStart = pending
+		s.gapSince = now
+		return nil
+	}
+	if now.Sub(s.gapSince) < gapTimeout {
+		return nil
+	}
+	var gaps []uint64
+	for nonce := pending; nonce < s.next; nonce++ {
+		gaps = append(gaps, nonce)
+	}
+	return gaps
+}
@@ -0,0 +1,265 @@
+package evm
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/skip-mev/go-fast-solver/mocks/shared/config"
+	mockevmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/evmrpc"
+	configreader "github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/evmrpc"
+	"github.com/skip-mev/go-fast-solver/shared/signing"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// the chain id of the simulated backend
+const simulatedChainID = "1337"
+
+// simulatedChainRPC implements the parts of evmrpc.EVMChainRPC used by the
+// tx executor on top of a simulated backend
+type simulatedChainRPC struct {
+	evmrpc.EVMChainRPC
+	client simulated.Client
+
+	pendingNonceQueries atomic.Int64
+	// dropNextTx makes SendTx report success for the next tx without sending
+	// it, as if it was dropped from the mempool
+	dropNextTx atomic.Bool
+}
+
+func (r *simulatedChainRPC) SendTx(ctx context.Context, txBytes []byte) (string, error) {
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalBinary(txBytes); err != nil {
+		return "", err
+	}
+	if r.dropNextTx.CompareAndSwap(true, false) {
+		return tx.Hash().Hex(), nil
+	}
+	if err := r.client.SendTransaction(ctx, tx); err != nil {
+		return "", err
+	}
+	return tx.Hash().Hex(), nil
+}
+
+func (r *simulatedChainRPC) PendingNonceAt(ctx context.Context, address common.Address) (uint64, error) {
+	r.pendingNonceQueries.Add(1)
+	return r.client.PendingNonceAt(ctx, address)
+}
+
+func (r *simulatedChainRPC) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return r.client.EstimateGas(ctx, msg)
+}
+
+func (r *simulatedChainRPC) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return r.client.SuggestGasTipCap(ctx)
+}
+
+func (r *simulatedChainRPC) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return r.client.HeaderByNumber(ctx, number)
+}
+
+type simulatedAccount struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+	signer  signing.Signer
+}
+
+func newSimulatedAccount(t *testing.T) simulatedAccount {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return simulatedAccount{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+		signer:  signing.NewLocalEthereumSigner(key),
+	}
+}
+
+func setupSimulatedExecutor(t *testing.T, txSubmissionDelay, resyncInterval, gapTimeout time.Duration, accounts ...simulatedAccount) (*SerializedEVMTxExecutor, *simulated.Backend, *simulatedChainRPC, context.Context) {
+	alloc := types.GenesisAlloc{}
+	for _, account := range accounts {
+		alloc[account.address] = types.Account{Balance: new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)}
+	}
+	backend := simulated.NewBackend(alloc)
+	t.Cleanup(func() { backend.Close() })
+	rpcClient := &simulatedChainRPC{client: backend.Client()}
+
+	rpcClientManager := mockevmrpc.NewMockEVMRPCClientManager(t)
+	rpcClientManager.On("GetClient", mock.Anything, simulatedChainID).Return(rpcClient, nil)
+	configReader := config.NewMockConfigReader(t)
+	configReader.On("GetChainConfig", simulatedChainID).Return(configreader.ChainConfig{EVM: &configreader.EVMConfig{}}, nil)
+	ctx := configreader.ConfigReaderContext(context.Background(), configReader)
+
+	executor := &SerializedEVMTxExecutor{
+		txSubmissionDelay: txSubmissionDelay,
+		clientManager:     rpcClientManager,
+		nonces:            NewNonceManager(rpcClientManager, resyncInterval, gapTimeout),
+	}
+	return executor, backend, rpcClient, ctx
+}
+
+func requireTxSucceeded(t *testing.T, ctx context.Context, backend *simulated.Backend, txHash string) {
+	receipt, err := backend.Client().TransactionReceipt(ctx, common.HexToHash(txHash))
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+}
+
+func TestSerializedEVMTxExecutor_NonceManager(t *testing.T) {
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111").Hex()
+
+	t.Run("concurrent txs of a signer are allocated consecutive nonces", func(t *testing.T) {
+		account := newSimulatedAccount(t)
+		executor, backend, rpcClient, ctx := setupSimulatedExecutor(t, 0, DefaultNonceResyncInterval, DefaultNonceGapTimeout, account)
+
+		const numTxs = 10
+		txHashes := make([]string, numTxs)
+		errs := make([]error, numTxs)
+		var wg sync.WaitGroup
+		for i := 0; i < numTxs; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				txHashes[i], _, errs[i] = executor.ExecuteTx(ctx, simulatedChainID, account.address.Hex(), nil, "1", to, account.signer)
+			}(i)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		backend.Commit()
+
+		for _, txHash := range txHashes {
+			requireTxSucceeded(t, ctx, backend, txHash)
+		}
+		nonce, err := backend.Client().NonceAt(ctx, account.address, nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(numTxs), nonce)
+
+		// the nonce is only queried from the chain when the account is first used
+		require.Equal(t, int64(1), rpcClient.pendingNonceQueries.Load())
+	})
+
+	t.Run("different signers submit in parallel", func(t *testing.T) {
+		first := newSimulatedAccount(t)
+		second := newSimulatedAccount(t)
+		txSubmissionDelay := 500 * time.Millisecond
+		executor, backend, _, ctx := setupSimulatedExecutor(t, txSubmissionDelay, DefaultNonceResyncInterval, DefaultNonceGapTimeout, first, second)
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		errs := make(chan error, 4)
+		for _, account := range []simulatedAccount{first, second} {
+			wg.Add(1)
+			go func(account simulatedAccount) {
+				defer wg.Done()
+				for i := 0; i < 2; i++ {
+					_, _, err := executor.ExecuteTx(ctx, simulatedChainID, account.address.Hex(), nil, "1", to, account.signer)
+					errs <- err
+				}
+			}(account)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		// each signer waits the submission delay between its own txs only
+		elapsed := time.Since(start)
+		require.GreaterOrEqual(t, elapsed, txSubmissionDelay)
+		require.Less(t, elapsed, 3*txSubmissionDelay)
+
+		backend.Commit()
+		for _, account := range []simulatedAccount{first, second} {
+			nonce, err := backend.Client().NonceAt(ctx, account.address, nil)
+			require.NoError(t, err)
+			require.Equal(t, uint64(2), nonce)
+		}
+	})
+
+	t.Run("nonce is resynced after tx is rejected due to nonce", func(t *testing.T) {
+		account := newSimulatedAccount(t)
+		executor, backend, _, ctx := setupSimulatedExecutor(t, 0, DefaultNonceResyncInterval, DefaultNonceGapTimeout, account)
+
+		_, _, err := executor.ExecuteTx(ctx, simulatedChainID, account.address.Hex(), nil, "1", to, account.signer)
+		require.NoError(t, err)
+
+		// a tx sent by the signer outside of the executor uses the nonce the
+		// executor allocates next
+		head, err := backend.Client().HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		toAddress := common.HexToAddress(to)
+		externalTx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(1337),
+			Nonce:     1,
+			GasTipCap: big.NewInt(1e9),
+			GasFeeCap: new(big.Int).Add(big.NewInt(1e9), new(big.Int).Mul(head.BaseFee, big.NewInt(2))),
+			Gas:       21000,
+			To:        &toAddress,
+			Value:     big.NewInt(1),
+		}), types.NewCancunSigner(big.NewInt(1337)), account.key)
+		require.NoError(t, err)
+		require.NoError(t, backend.Client().SendTransaction(ctx, externalTx))
+
+		txHash, _, err := executor.ExecuteTx(ctx, simulatedChainID, account.address.Hex(), nil, "1", to, account.signer)
+		require.NoError(t, err)
+		backend.Commit()
+
+		requireTxSucceeded(t, ctx, backend, txHash)
+		requireTxSucceeded(t, ctx, backend, externalTx.Hash().Hex())
+		nonce, err := backend.Client().NonceAt(ctx, account.address, nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), nonce)
+	})
+
+	t.Run("nonce gap left by dropped tx is filled with cancellation tx", func(t *testing.T) {
+		account := newSimulatedAccount(t)
+		executor, backend, rpcClient, ctx := setupSimulatedExecutor(t, 0, 0, 0, account)
+
+		rpcClient.dropNextTx.Store(true)
+		_, _, err := executor.ExecuteTx(ctx, simulatedChainID, account.address.Hex(), nil, "1", to, account.signer)
+		require.NoError(t, err)
+
+		// the gap is first observed, and the next tx is queued behind it
+		queuedTxHash, _, err := executor.ExecuteTx(ctx, simulatedChainID, account.address.Hex(), nil, "1", to, account.signer)
+		require.NoError(t, err)
+		backend.Commit()
+		nonce, err := backend.Client().NonceAt(ctx, account.address, nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), nonce)
+
+		// the gap has persisted for the gap timeout, so it is filled before the
+		// next tx is sent
+		txHash, _, err := executor.ExecuteTx(ctx, simulatedChainID, account.address.Hex(), nil, "1", to, account.signer)
+		require.NoError(t, err)
+		backend.Commit()
+
+		requireTxSucceeded(t, ctx, backend, queuedTxHash)
+		requireTxSucceeded(t, ctx, backend, txHash)
+		nonce, err = backend.Client().NonceAt(ctx, account.address, nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), nonce)
+
+		block, err := backend.Client().BlockByNumber(ctx, nil)
+		require.NoError(t, err)
+		var cancellation *types.Transaction
+		for _, tx := range block.Transactions() {
+			if tx.Nonce() == 0 {
+				cancellation = tx
+			}
+		}
+		require.NotNil(t, cancellation)
+		require.Equal(t, account.address, *cancellation.To())
+		require.Zero(t, cancellation.Value().Sign())
+	})
+}
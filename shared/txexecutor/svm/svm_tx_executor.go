@@ -0,0 +1,202 @@
+package svm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/lmt"
+	"github.com/skip-mev/go-fast-solver/shared/signing"
+	"github.com/skip-mev/go-fast-solver/shared/svmrpc"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+)
+
+// ErrBlockhashExpired is returned when a tx does not land before the
+// blockhash it was signed with expires, after which it can never land
+var ErrBlockhashExpired = errors.New("tx blockhash expired before the tx landed")
+
+const (
+	defaultConfirmationPollInterval = 2 * time.Second
+	// defaultMaxSubmissionAttempts is the number of blockhashes a tx is
+	// signed and submitted with before giving up on it landing
+	defaultMaxSubmissionAttempts = 3
+)
+
+type SVMTxExecutor interface {
+	// ExecuteTx sets a recent blockhash on tx, signs it with signer and any
+	// additionalSigners, and submits it, waiting for it to land at the chains
+	// configured commitment level. If the blockhash expires before the tx
+	// lands, the tx is signed again with a new blockhash and resubmitted.
+	ExecuteTx(ctx context.Context, chainID string, tx *solana.Transaction, signer signing.Signer, additionalSigners ...solana.PrivateKey) (txHash string, rawTxB64 string, err error)
+}
+
+type ConfirmingSVMTxExecutor struct {
+	clientManager         svmrpc.SVMRPCClientManager
+	pollInterval          time.Duration
+	maxSubmissionAttempts int
+}
+
+func DefaultSVMTxExecutor(clientManager svmrpc.SVMRPCClientManager) SVMTxExecutor {
+	return NewConfirmingSVMTxExecutor(clientManager, defaultConfirmationPollInterval, defaultMaxSubmissionAttempts)
+}
+
+func NewConfirmingSVMTxExecutor(clientManager svmrpc.SVMRPCClientManager, pollInterval time.Duration, maxSubmissionAttempts int) SVMTxExecutor {
+	return &ConfirmingSVMTxExecutor{
+		clientManager:         clientManager,
+		pollInterval:          pollInterval,
+		maxSubmissionAttempts: maxSubmissionAttempts,
+	}
+}
+
+func (e *ConfirmingSVMTxExecutor) ExecuteTx(ctx context.Context, chainID string, tx *solana.Transaction, signer signing.Signer, additionalSigners ...solana.PrivateKey) (txHash string, rawTxB64 string, err error) {
+	client, err := e.clientManager.GetClient(ctx, chainID)
+	if err != nil {
+		return "", "", err
+	}
+	chainCfg, err := config.GetConfigReader(ctx).GetChainConfig(chainID)
+	if err != nil {
+		return "", "", err
+	}
+	if chainCfg.SVM == nil {
+		return "", "", fmt.Errorf("SVM chain config is null for chain id %s", chainID)
+	}
+	commitment := svmrpc.Commitment(chainCfg.SVM)
+
+	for attempt := 1; attempt <= e.maxSubmissionAttempts; attempt++ {
+		txHash, rawTxB64, err = e.submitAndConfirm(ctx, client, chainID, commitment, tx, signer, additionalSigners)
+		if !errors.Is(err, ErrBlockhashExpired) {
+			return txHash, rawTxB64, err
+		}
+		lmt.Logger(ctx).Warn(
+			"svm tx did not land before its blockhash expired, resubmitting with a new blockhash",
+			zap.String("chainID", chainID),
+			zap.String("txHash", txHash),
+			zap.Int("attempt", attempt),
+		)
+	}
+	return "", "", fmt.Errorf("submitting tx to chain %s failed after %d attempts: %w", chainID, e.maxSubmissionAttempts, err)
+}
+
+// submitAndConfirm signs tx with the latest blockhash, submits it and waits
+// for it to land. The signed tx is rebroadcast until it lands, since rpc
+// nodes are not relied on to retry it. ErrBlockhashExpired is returned if the
+// tx has not landed by the time its blockhash expires.
+func (e *ConfirmingSVMTxExecutor) submitAndConfirm(
+	ctx context.Context,
+	client svmrpc.SVMChainRPC,
+	chainID string,
+	commitment rpc.CommitmentType,
+	tx *solana.Transaction,
+	signer signing.Signer,
+	additionalSigners []solana.PrivateKey,
+) (string, string, error) {
+	blockhash, lastValidBlockHeight, err := client.GetLatestBlockhash(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("getting latest blockhash: %w", err)
+	}
+	tx.Message.RecentBlockhash = blockhash
+	tx.Signatures = nil
+	for _, key := range additionalSigners {
+		if err := signing.SignSolanaTx(tx, key); err != nil {
+			return "", "", fmt.Errorf("signing tx with additional signer %s: %w", key.PublicKey(), err)
+		}
+	}
+	if _, err := signer.Sign(ctx, chainID, signing.NewSolanaTransaction(tx)); err != nil {
+		return "", "", fmt.Errorf("signing tx: %w", err)
+	}
+	rawTxB64, err := tx.ToBase64()
+	if err != nil {
+		return "", "", fmt.Errorf("encoding signed tx: %w", err)
+	}
+
+	signature, err := client.SendTx(ctx, tx)
+	if err != nil {
+		return "", "", fmt.Errorf("sending tx: %w", err)
+	}
+
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return signature.String(), rawTxB64, ctx.Err()
+		case <-ticker.C:
+		}
+
+		landed, err := e.landed(ctx, client, signature, commitment)
+		if err != nil {
+			return signature.String(), rawTxB64, err
+		}
+		if landed {
+			return signature.String(), rawTxB64, nil
+		}
+
+		blockHeight, err := client.GetBlockHeight(ctx)
+		if err != nil {
+			lmt.Logger(ctx).Warn("error getting block height while confirming svm tx", zap.String("chainID", chainID), zap.Error(err))
+			continue
+		}
+		if blockHeight > lastValidBlockHeight {
+			// the tx may have landed since its status was last checked
+			landed, err := e.landed(ctx, client, signature, commitment)
+			if err != nil {
+				return signature.String(), rawTxB64, err
+			}
+			if landed {
+				return signature.String(), rawTxB64, nil
+			}
+			return signature.String(), rawTxB64, ErrBlockhashExpired
+		}
+
+		if _, err := client.SendTx(ctx, tx); err != nil {
+			lmt.Logger(ctx).Debug("error rebroadcasting svm tx", zap.String("chainID", chainID), zap.String("txHash", signature.String()), zap.Error(err))
+		}
+	}
+}
+
+// landed returns true if the tx with signature has reached commitment, or
+// an error if the tx landed but failed
+func (e *ConfirmingSVMTxExecutor) landed(ctx context.Context, client svmrpc.SVMChainRPC, signature solana.Signature, commitment rpc.CommitmentType) (bool, error) {
+	status, err := client.GetSignatureStatus(ctx, signature)
+	if err != nil {
+		// the tx is still rebroadcast and its status checked again until its
+		// blockhash expires
+		lmt.Logger(ctx).Warn("error getting svm tx status", zap.String("txHash", signature.String()), zap.Error(err))
+		return false, nil
+	}
+	if status == nil || confirmationLevel(status.ConfirmationStatus) < commitmentLevel(commitment) {
+		return false, nil
+	}
+	if status.Err != nil {
+		return false, fmt.Errorf("tx %s failed: %v", signature, status.Err)
+	}
+	return true, nil
+}
+
+func confirmationLevel(status rpc.ConfirmationStatusType) int {
+	switch status {
+	case rpc.ConfirmationStatusProcessed:
+		return 1
+	case rpc.ConfirmationStatusConfirmed:
+		return 2
+	case rpc.ConfirmationStatusFinalized:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func commitmentLevel(commitment rpc.CommitmentType) int {
+	switch commitment {
+	case rpc.CommitmentProcessed:
+		return 1
+	case rpc.CommitmentFinalized:
+		return 3
+	default:
+		return 2
+	}
+}
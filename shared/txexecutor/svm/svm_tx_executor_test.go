@@ -0,0 +1,146 @@
+package svm
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	mock_config "github.com/skip-mev/go-fast-solver/mocks/shared/config"
+	mock_svmrpc "github.com/skip-mev/go-fast-solver/mocks/shared/svmrpc"
+	"github.com/skip-mev/go-fast-solver/shared/config"
+	"github.com/skip-mev/go-fast-solver/shared/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func setupExecutor(t *testing.T, chainID string) (SVMTxExecutor, *mock_svmrpc.MockSVMChainRPC, context.Context) {
+	configReader := mock_config.NewMockConfigReader(t)
+	configReader.On("GetChainConfig", chainID).Return(config.ChainConfig{SVM: &config.SVMConfig{}}, nil)
+	ctx := config.ConfigReaderContext(context.Background(), configReader)
+
+	rpcClientManager := mock_svmrpc.NewMockSVMRPCClientManager(t)
+	rpcClient := mock_svmrpc.NewMockSVMChainRPC(t)
+	rpcClientManager.On("GetClient", mock.Anything, chainID).Return(rpcClient, nil)
+
+	return NewConfirmingSVMTxExecutor(rpcClientManager, time.Millisecond, 2), rpcClient, ctx
+}
+
+func transferTx(t *testing.T, from, to solana.PublicKey) *solana.Transaction {
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{system.NewTransferInstruction(1, from, to).Build()},
+		solana.Hash{},
+		solana.TransactionPayer(from),
+	)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestConfirmingSVMTxExecutor_ExecuteTx(t *testing.T) {
+	solverKey, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	signer, err := signing.NewLocalSolanaSigner(solverKey.String())
+	require.NoError(t, err)
+	sendSignedTx := func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+		return tx.Signatures[0], nil
+	}
+
+	t.Run("tx is signed with the latest blockhash and confirmed", func(t *testing.T) {
+		executor, rpcClient, ctx := setupExecutor(t, "solana")
+		blockhash := solana.Hash{1}
+		rpcClient.On("GetLatestBlockhash", mock.Anything).Return(blockhash, uint64(100), nil).Once()
+		rpcClient.EXPECT().SendTx(mock.Anything, mock.Anything).RunAndReturn(sendSignedTx)
+		rpcClient.On("GetSignatureStatus", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		rpcClient.On("GetBlockHeight", mock.Anything).Return(uint64(99), nil).Once()
+		rpcClient.On("GetSignatureStatus", mock.Anything, mock.Anything).Return(&rpc.SignatureStatusesResult{
+			ConfirmationStatus: rpc.ConfirmationStatusConfirmed,
+		}, nil).Once()
+
+		tx := transferTx(t, solverKey.PublicKey(), solana.NewWallet().PublicKey())
+		txHash, rawTxB64, err := executor.ExecuteTx(ctx, "solana", tx, signer)
+		require.NoError(t, err)
+		assert.Equal(t, blockhash, tx.Message.RecentBlockhash)
+		require.Len(t, tx.Signatures, 1)
+		assert.Equal(t, tx.Signatures[0].String(), txHash)
+		require.NoError(t, tx.VerifySignatures())
+
+		rawTx, err := base64.StdEncoding.DecodeString(rawTxB64)
+		require.NoError(t, err)
+		decoded, err := solana.TransactionFromDecoder(bin.NewBinDecoder(rawTx))
+		require.NoError(t, err)
+		assert.Equal(t, tx.Signatures, decoded.Signatures)
+	})
+
+	t.Run("tx is resigned with a new blockhash once its blockhash expires", func(t *testing.T) {
+		executor, rpcClient, ctx := setupExecutor(t, "solana")
+		rpcClient.On("GetLatestBlockhash", mock.Anything).Return(solana.Hash{1}, uint64(100), nil).Once()
+		rpcClient.On("GetLatestBlockhash", mock.Anything).Return(solana.Hash{2}, uint64(200), nil).Once()
+		rpcClient.EXPECT().SendTx(mock.Anything, mock.Anything).RunAndReturn(sendSignedTx)
+		rpcClient.On("GetSignatureStatus", mock.Anything, mock.Anything).Return(nil, nil).Twice()
+		rpcClient.On("GetBlockHeight", mock.Anything).Return(uint64(101), nil).Once()
+		rpcClient.On("GetSignatureStatus", mock.Anything, mock.Anything).Return(&rpc.SignatureStatusesResult{
+			ConfirmationStatus: rpc.ConfirmationStatusFinalized,
+		}, nil).Once()
+
+		tx := transferTx(t, solverKey.PublicKey(), solana.NewWallet().PublicKey())
+		txHash, _, err := executor.ExecuteTx(ctx, "solana", tx, signer)
+		require.NoError(t, err)
+		assert.Equal(t, solana.Hash{2}, tx.Message.RecentBlockhash)
+		assert.Equal(t, tx.Signatures[0].String(), txHash)
+		require.NoError(t, tx.VerifySignatures())
+	})
+
+	t.Run("submission fails once every blockhash expires", func(t *testing.T) {
+		executor, rpcClient, ctx := setupExecutor(t, "solana")
+		rpcClient.On("GetLatestBlockhash", mock.Anything).Return(solana.Hash{1}, uint64(100), nil).Twice()
+		rpcClient.EXPECT().SendTx(mock.Anything, mock.Anything).RunAndReturn(sendSignedTx)
+		rpcClient.On("GetSignatureStatus", mock.Anything, mock.Anything).Return(nil, nil)
+		rpcClient.On("GetBlockHeight", mock.Anything).Return(uint64(101), nil)
+
+		_, _, err := executor.ExecuteTx(ctx, "solana", transferTx(t, solverKey.PublicKey(), solana.NewWallet().PublicKey()), signer)
+		assert.ErrorIs(t, err, ErrBlockhashExpired)
+	})
+
+	t.Run("additional signers sign in their own slot", func(t *testing.T) {
+		executor, rpcClient, ctx := setupExecutor(t, "solana")
+		rpcClient.On("GetLatestBlockhash", mock.Anything).Return(solana.Hash{1}, uint64(100), nil).Once()
+		rpcClient.EXPECT().SendTx(mock.Anything, mock.Anything).RunAndReturn(sendSignedTx)
+		rpcClient.On("GetSignatureStatus", mock.Anything, mock.Anything).Return(&rpc.SignatureStatusesResult{
+			ConfirmationStatus: rpc.ConfirmationStatusConfirmed,
+		}, nil).Once()
+
+		additionalSigner, err := solana.NewRandomPrivateKey()
+		require.NoError(t, err)
+		// the additional signer funds the transfer, so both keys must sign
+		tx, err := solana.NewTransaction(
+			[]solana.Instruction{system.NewTransferInstruction(1, additionalSigner.PublicKey(), solana.NewWallet().PublicKey()).Build()},
+			solana.Hash{},
+			solana.TransactionPayer(solverKey.PublicKey()),
+		)
+		require.NoError(t, err)
+
+		_, _, err = executor.ExecuteTx(ctx, "solana", tx, signer, additionalSigner)
+		require.NoError(t, err)
+		require.Len(t, tx.Signatures, 2)
+		require.NoError(t, tx.VerifySignatures())
+	})
+
+	t.Run("txs that land but fail return an error", func(t *testing.T) {
+		executor, rpcClient, ctx := setupExecutor(t, "solana")
+		rpcClient.On("GetLatestBlockhash", mock.Anything).Return(solana.Hash{1}, uint64(100), nil).Once()
+		rpcClient.EXPECT().SendTx(mock.Anything, mock.Anything).RunAndReturn(sendSignedTx)
+		rpcClient.On("GetSignatureStatus", mock.Anything, mock.Anything).Return(&rpc.SignatureStatusesResult{
+			ConfirmationStatus: rpc.ConfirmationStatusConfirmed,
+			Err:                map[string]any{"InstructionError": []any{0, "Custom"}},
+		}, nil).Once()
+
+		_, _, err := executor.ExecuteTx(ctx, "solana", transferTx(t, solverKey.PublicKey(), solana.NewWallet().PublicKey()), signer)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrBlockhashExpired)
+	})
+}
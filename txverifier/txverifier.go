@@ -11,6 +11,7 @@ import (
 	dbtypes "github.com/skip-mev/go-fast-solver/db"
 	"github.com/skip-mev/go-fast-solver/shared/bridges/cctp"
 	"github.com/skip-mev/go-fast-solver/shared/clientmanager"
+	"github.com/skip-mev/go-fast-solver/shared/config"
 	"github.com/skip-mev/go-fast-solver/shared/metrics"
 
 	"go.uber.org/zap"
@@ -22,6 +23,11 @@ import (
 
 const (
 	txAbandonedTimeout = 10 * time.Minute
+	// replaceableTxAbandonedTimeout is how long a replaceable tx can be
+	// missing before it is abandoned. This is longer than the time the
+	// process that submitted it takes to give up on replacing it, and only
+	// ensures that txs it stopped tracking are not pending forever.
+	replaceableTxAbandonedTimeout = time.Hour
 )
 
 type Config struct {
@@ -35,6 +41,8 @@ var params = Config{
 type Database interface {
 	GetSubmittedTxsWithStatus(ctx context.Context, txStatus string) ([]db.SubmittedTx, error)
 	SetSubmittedTxStatus(ctx context.Context, arg db.SetSubmittedTxStatusParams) (db.SubmittedTx, error)
+	GetSubmittedTx(ctx context.Context, id int64) (db.SubmittedTx, error)
+	GetSubmittedTxsReplacing(ctx context.Context, replacesSubmittedTxID sql.NullInt64) ([]db.SubmittedTx, error)
 }
 
 type Oracle interface {
@@ -130,6 +138,9 @@ func (r *TxVerifier) VerifyTx(ctx context.Context, submittedTx db.SubmittedTx) e
 		}); err != nil {
 			return fmt.Errorf("failed to set tx status to failed: %w", err)
 		}
+		if err := r.setReplacedTxsStatus(ctx, submittedTx); err != nil {
+			return err
+		}
 		return fmt.Errorf("tx failed: %s", failure.String())
 	} else {
 		metrics.FromContext(ctx).IncTransactionVerified(true, submittedTx.ChainID)
@@ -147,12 +158,65 @@ func (r *TxVerifier) VerifyTx(ctx context.Context, submittedTx db.SubmittedTx) e
 		}); err != nil {
 			return fmt.Errorf("failed to set tx status to success: %w", err)
 		}
+		if err := r.setReplacedTxsStatus(ctx, submittedTx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setReplacedTxsStatus marks every other pending version of a mined tx, i.e.
+// txs that it replaced or that replaced it, as replaced since they share the
+// mined txs nonce and can no longer be mined
+func (r *TxVerifier) setReplacedTxsStatus(ctx context.Context, minedTx db.SubmittedTx) error {
+	root := minedTx
+	for root.ReplacesSubmittedTxID.Valid {
+		replaced, err := r.db.GetSubmittedTx(ctx, root.ReplacesSubmittedTxID.Int64)
+		if err != nil {
+			return fmt.Errorf("getting submitted tx %d replaced by tx %s: %w", root.ReplacesSubmittedTxID.Int64, root.TxHash, err)
+		}
+		root = replaced
+	}
+
+	versions := []db.SubmittedTx{root}
+	for i := 0; i < len(versions); i++ {
+		replacements, err := r.db.GetSubmittedTxsReplacing(ctx, sql.NullInt64{Int64: versions[i].ID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("getting submitted txs replacing tx %s: %w", versions[i].TxHash, err)
+		}
+		versions = append(versions, replacements...)
+	}
+
+	for _, version := range versions {
+		if version.ID == minedTx.ID || version.TxStatus != dbtypes.TxStatusPending {
+			continue
+		}
+		if _, err := r.db.SetSubmittedTxStatus(ctx, db.SetSubmittedTxStatusParams{
+			TxStatus:        dbtypes.TxStatusReplaced,
+			TxHash:          version.TxHash,
+			ChainID:         version.ChainID,
+			TxStatusMessage: sql.NullString{String: fmt.Sprintf("replaced by mined tx %s", minedTx.TxHash), Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to set tx %s status to replaced: %w", version.TxHash, err)
+		}
 	}
 	return nil
 }
 
 func (r *TxVerifier) handleTxResultNotFound(ctx context.Context, submittedTx db.SubmittedTx) error {
-	if time.Since(submittedTx.CreatedAt) > txAbandonedTimeout {
+	replaceable, err := isReplaceable(ctx, submittedTx)
+	if err != nil {
+		return err
+	}
+	abandonedTimeout := txAbandonedTimeout
+	if replaceable {
+		// stuck txs that are replaceable are replaced with the same nonce
+		// rather than abandoned, since abandoning them would cause them to be
+		// resent with a new nonce while the stuck tx may still be mined
+		abandonedTimeout = replaceableTxAbandonedTimeout
+	}
+
+	if time.Since(submittedTx.CreatedAt) > abandonedTimeout {
 		if _, err := r.db.SetSubmittedTxStatus(ctx, db.SetSubmittedTxStatusParams{
 			TxStatus: dbtypes.TxStatusAbandoned,
 			TxHash:   submittedTx.TxHash,
@@ -164,3 +228,17 @@ func (r *TxVerifier) handleTxResultNotFound(ctx context.Context, submittedTx db.
 
 	return nil
 }
+
+// isReplaceable returns true if a stuck tx is replaced by the process that
+// submitted it, which is currently only done for hyperlane message deliveries
+// to evm chains
+func isReplaceable(ctx context.Context, submittedTx db.SubmittedTx) (bool, error) {
+	if submittedTx.TxType != dbtypes.TxTypeHyperlaneMessageDelivery {
+		return false, nil
+	}
+	chainConfig, err := config.GetConfigReader(ctx).GetChainConfig(submittedTx.ChainID)
+	if err != nil {
+		return false, fmt.Errorf("getting config for chain %s: %w", submittedTx.ChainID, err)
+	}
+	return chainConfig.Type == config.ChainType_EVM, nil
+}